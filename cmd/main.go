@@ -17,18 +17,25 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"math/rand"
 	"net/http"
 	"net/http/pprof"
 	"os"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/election"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/inventory"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/metric"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/tracing"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/log"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"k8s.io/apiserver/pkg/server/healthz"
@@ -39,6 +46,7 @@ import (
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
 	"sigs.k8s.io/controller-runtime/pkg/runtime/signals"
 )
@@ -50,10 +58,12 @@ const (
 	// High enough Burst to fit all expected use cases. Burst=0 is not set here, because
 	// client code is overriding it.
 	defaultBurst = 1e6
+
+	// eventFlushPeriod is how long to wait on shutdown for events to be sent, since they're sent asynchronously.
+	eventFlushPeriod = 2 * time.Second
 )
 
 func main() {
-	logf.SetLogger(glogr.New())
 	rand.Seed(time.Now().UnixNano())
 	fmt.Println(version.String())
 	options, err := getOptions()
@@ -63,21 +73,38 @@ func main() {
 	if options.ShowVersion {
 		os.Exit(0)
 	}
+	if err := log.Configure(options.logOptions); err != nil {
+		glog.Fatal(err)
+	}
+	if logger := log.LogR(); logger != nil {
+		logf.SetLogger(logger)
+	} else {
+		logf.SetLogger(glogr.New())
+	}
+	shutdownTracing, err := tracing.Setup(context.Background(), options.tracingOptions, options.ingressCTLConfig.ClusterName)
+	if err != nil {
+		glog.Fatal(err)
+	}
 
 	restCfg, err := buildRestConfig(options)
 	if err != nil {
 		glog.Fatal(err)
 	}
+	// leader election is run by elector rather than the manager, so that standbys start their caches too.
 	mgr, err := manager.New(restCfg, manager.Options{
-		Namespace:               options.WatchNamespace,
-		SyncPeriod:              &options.SyncPeriod,
-		LeaderElection:          options.LeaderElection,
-		LeaderElectionID:        options.LeaderElectionID,
-		LeaderElectionNamespace: options.LeaderElectionNamespace,
+		Namespace:  options.WatchNamespace,
+		SyncPeriod: &options.SyncPeriod,
 	})
 	if err != nil {
 		glog.Fatal(err)
 	}
+	elector, err := buildElector(restCfg, mgr, options)
+	if err != nil {
+		glog.Fatal(err)
+	}
+	if err := mgr.Add(elector); err != nil {
+		glog.Fatal(err)
+	}
 
 	reg := prometheus.NewRegistry()
 	reg.MustRegister(prometheus.NewGoCollector())
@@ -93,24 +120,56 @@ func main() {
 	if err != nil {
 		glog.Fatal(err)
 	}
-	if err := controller.Initialize(&options.ingressCTLConfig, mgr, mc, cloud); err != nil {
+	inv := inventory.NewInventory(cloud, options.ingressCTLConfig.ClusterName)
+	if err := controller.Initialize(&options.ingressCTLConfig, mgr, mc, cloud, inv, elector); err != nil {
 		glog.Fatal(err)
 	}
 
 	mux := http.NewServeMux()
 	if options.ProfilingEnabled {
 		registerProfiler(mux)
+		mux.Handle(inventory.StateHandlerPath, inventory.NewStateHandler(inv))
 	}
 	registerHealthz(mux, aws.NewHealthChecker(cloud))
 	registerMetrics(mux, reg)
 	registerHandlers(mux)
+	if options.AdminAPITokenFile != "" {
+		if err := registerAdminAPI(mux, inv, options.AdminAPITokenFile); err != nil {
+			glog.Fatal(err)
+		}
+	}
 	go startHTTPServer(options.HealthzPort, mux)
 
-	if err := mgr.Start(signals.SetupSignalHandler()); err != nil {
+	err = mgr.Start(signals.SetupSignalHandler())
+	shutdown(elector, options.ShutdownTimeout)
+	if shutdownErr := shutdownTracing(context.Background()); shutdownErr != nil {
+		glog.Errorf("failed to flush traces due to %v", shutdownErr)
+	}
+	if err != nil {
 		glog.Fatal(err)
 	}
 }
 
+// shutdown stops reconciles from beginning and waits for those in progress to end, so that ALBs aren't left half-modified,
+// before giving up leadership. A second SIGTERM exits right away.
+func shutdown(elector election.Elector, timeout time.Duration) {
+	deadline := time.Now().Add(timeout)
+	glog.Infof("shutting down, waiting up to %v for reconciles in progress", timeout)
+	if reconciles := elector.Drain(timeout); reconciles > 0 {
+		glog.Warningf("%v reconciles still in progress after %v, exiting anyway", reconciles, timeout)
+	}
+	flush := eventFlushPeriod
+	if remaining := time.Until(deadline); remaining < flush {
+		flush = remaining
+	}
+	if flush > 0 {
+		time.Sleep(flush)
+	}
+	if err := elector.Release(); err != nil {
+		glog.Errorf("failed to release leadership due to %v", err)
+	}
+}
+
 // buildRestConfig creates a new Kubernetes REST configuration. apiserverHost is
 // the URL of the API server in the format protocol://address:port/pathPrefix,
 // kubeConfig is the location of a kubeconfig file. If defined, the kubeconfig
@@ -129,6 +188,19 @@ func buildRestConfig(options *Options) (*rest.Config, error) {
 	return restCfg, nil
 }
 
+// buildElector creates the Elector of this replica, which always leads when leader election is disabled.
+func buildElector(restCfg *rest.Config, mgr manager.Manager, options *Options) (election.Elector, error) {
+	if !options.LeaderElection {
+		return election.NewAlwaysLeader(), nil
+	}
+	lock, err := election.NewLock(restCfg, mgr.GetRecorder("alb-ingress-controller-leader-election"),
+		options.LeaderElectionLockType, options.LeaderElectionNamespace, options.LeaderElectionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create leader election lock due to %w", err)
+	}
+	return election.NewElector(lock), nil
+}
+
 func registerHandlers(mux *http.ServeMux) {
 	mux.HandleFunc("/build", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -144,16 +216,30 @@ func registerHandlers(mux *http.ServeMux) {
 	})
 }
 
+func registerAdminAPI(mux *http.ServeMux, inv inventory.Inventory, tokenFile string) error {
+	content, err := ioutil.ReadFile(tokenFile)
+	if err != nil {
+		return fmt.Errorf("failed to read admin API token due to %w", err)
+	}
+	token := strings.TrimSpace(string(content))
+	if token == "" {
+		return fmt.Errorf("admin API token file %v is empty", tokenFile)
+	}
+	mux.Handle(inventory.HandlerPath, inventory.NewHandler(inv, token))
+	return nil
+}
+
 func registerHealthz(mux *http.ServeMux, awsChecker *aws.HealthChecker) {
 	healthz.InstallHandler(mux, healthz.PingHealthz, awsChecker)
 }
 
+// registerMetrics serves the controller's metrics, together with the work queue and client metrics of controller-runtime.
 func registerMetrics(mux *http.ServeMux, reg *prometheus.Registry) {
 	mux.Handle(
 		"/metrics",
 		promhttp.InstrumentMetricHandler(
 			reg,
-			promhttp.HandlerFor(reg, promhttp.HandlerOpts{}),
+			promhttp.HandlerFor(prometheus.Gatherers{reg, ctrlmetrics.Registry}, promhttp.HandlerOpts{}),
 		),
 	)
 }
@@ -161,6 +247,7 @@ func registerMetrics(mux *http.ServeMux, reg *prometheus.Registry) {
 func registerProfiler(mux *http.ServeMux) {
 	mux.HandleFunc("/debug/pprof/", pprof.Index)
 	mux.HandleFunc("/debug/pprof/heap", pprof.Index)
+	mux.HandleFunc("/debug/pprof/allocs", pprof.Index)
 	mux.HandleFunc("/debug/pprof/mutex", pprof.Index)
 	mux.HandleFunc("/debug/pprof/goroutine", pprof.Index)
 	mux.HandleFunc("/debug/pprof/threadcreate", pprof.Index)
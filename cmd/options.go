@@ -29,7 +29,10 @@ import (
 	"github.com/spf13/pflag"
 
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/config"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/election"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/net"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/tracing"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/log"
 	apiv1 "k8s.io/api/core/v1"
 )
 
@@ -37,11 +40,13 @@ const (
 	defaultLeaderElection          = true
 	defaultLeaderElectionID        = "ingress-controller-leader-alb"
 	defaultLeaderElectionNamespace = ""
+	defaultLeaderElectionLockType  = election.LockTypeConfigMaps
 	defaultWatchNamespace          = apiv1.NamespaceAll
 	defaultSyncPeriod              = 60 * time.Minute
 	defaultHealthCheckPeriod       = 1 * time.Minute
 	defaultHealthzPort             = 10254
 	defaultProfilingEnabled        = true
+	defaultShutdownTimeout         = 25 * time.Second
 )
 
 // Options defines the commandline interface of this binary
@@ -54,6 +59,7 @@ type Options struct {
 	LeaderElection          bool
 	LeaderElectionID        string
 	LeaderElectionNamespace string
+	LeaderElectionLockType  string
 
 	WatchNamespace    string
 	SyncPeriod        time.Duration
@@ -61,11 +67,23 @@ type Options struct {
 	HealthzPort       int
 	ProfilingEnabled  bool
 
+	// AdminAPITokenFile is the file containing the bearer token of the admin API, which is disabled when unset.
+	AdminAPITokenFile string
+
+	// ShutdownTimeout is how long the controller waits for reconciles in progress to end once asked to stop.
+	ShutdownTimeout time.Duration
+
 	// aws cloud specific configuration
 	cloudConfig aws.CloudConfig
 
 	// ingress controller specific configuration
 	ingressCTLConfig config.Configuration
+
+	// logOptions configures the format and levels of logs
+	logOptions log.Options
+
+	// tracingOptions configures the export of reconcile traces
+	tracingOptions tracing.Options
 }
 
 func (options *Options) BindFlags(fs *pflag.FlagSet) {
@@ -83,20 +101,28 @@ func (options *Options) BindFlags(fs *pflag.FlagSet) {
 		`Namespace of leader-election configmap for ingress controller`)
 	fs.StringVar(&options.LeaderElectionNamespace, "election-namespace", defaultLeaderElectionNamespace,
 		`Namespace of leader-election configmap for ingress controller. If unspecified, the namespace of this controller pod will be used`)
+	fs.StringVar(&options.LeaderElectionLockType, "election-lock-type", defaultLeaderElectionLockType,
+		`Kind of object holding the leader-election lock, either configmaps or leases. Standby replicas keep their caches synced to take over quickly.`)
 	fs.StringVar(&options.WatchNamespace, "watch-namespace", defaultWatchNamespace,
 		`Namespace the controller watches for updates to Kubernetes objects.
 		This includes Ingresses, Services and all configuration resources. All
 		namespaces are watched if this parameter is left empty.`)
 	fs.DurationVar(&options.SyncPeriod, "sync-period", defaultSyncPeriod,
-		`Period at which the controller forces the repopulation of its local object stores.`)
+		`Period at which the controller forces the repopulation of its local object stores. Ingresses aren't reconciled upon repopulation, see --full-sync-interval.`)
 	fs.DurationVar(&options.HealthCheckPeriod, "health-check-period", defaultHealthCheckPeriod,
 		`Period at which the controller executes AWS health checks for its healthz endpoint.`)
 	fs.IntVar(&options.HealthzPort, "healthz-port", defaultHealthzPort,
 		`Port to use for the healthz endpoint.`)
 	fs.BoolVar(&options.ProfilingEnabled, "profiling", defaultProfilingEnabled,
-		`Enable profiling via web interface host:port/debug/pprof/`)
+		`Enable profiling via web interface host:port/debug/pprof/, and the in-memory state of reconciles at host:port/debug/state`)
+	fs.StringVar(&options.AdminAPITokenFile, "admin-api-token-file", "",
+		`File containing the bearer token required by the read-only admin API on the healthz port. The admin API is disabled if unspecified.`)
+	fs.DurationVar(&options.ShutdownTimeout, "shutdown-timeout", defaultShutdownTimeout,
+		`Maximum duration to wait for reconciles in progress to end on SIGTERM, before releasing leadership and exiting. Keep it below the termination grace period of the pod.`)
 	options.cloudConfig.BindFlags(fs)
 	options.ingressCTLConfig.BindFlags(fs)
+	options.logOptions.BindFlags(fs)
+	options.tracingOptions.BindFlags(fs)
 
 	_ = fs.MarkDeprecated("aws-sync-period", `No longer used, will be removed in next release`)
 	_ = fs.MarkDeprecated("default-backend-service", `No longer used, will be removed in next release`)
@@ -119,6 +145,23 @@ func (options *Options) Validate() error {
 	if err := options.ingressCTLConfig.Validate(); err != nil {
 		return err
 	}
+	if options.LeaderElectionLockType != election.LockTypeConfigMaps && options.LeaderElectionLockType != election.LockTypeLeases {
+		return fmt.Errorf("invalid --election-lock-type %v, must be %v or %v", options.LeaderElectionLockType, election.LockTypeConfigMaps, election.LockTypeLeases)
+	}
+	if options.cloudConfig.APICacheTTL < 0 || options.cloudConfig.TargetHealthCacheTTL < 0 {
+		return fmt.Errorf("--aws-api-cache-ttl and --aws-target-health-cache-ttl must be non-negative")
+	}
+	if options.cloudConfig.ThrottleMaxBackoff < 0 {
+		return fmt.Errorf("--aws-throttle-max-backoff must be non-negative")
+	}
+	if options.ShutdownTimeout < 0 {
+		return fmt.Errorf("--shutdown-timeout must be non-negative")
+	}
+	// resources of ingresses outside the watched namespace would look orphaned.
+	if options.ingressCTLConfig.OrphanSweepInterval > 0 && options.WatchNamespace != apiv1.NamespaceAll {
+		return fmt.Errorf("--orphan-sweep-interval can't be used together with --watch-namespace")
+	}
+	options.cloudConfig.DryRun = options.ingressCTLConfig.DryRun
 	return nil
 }
 
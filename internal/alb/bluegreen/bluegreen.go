@@ -0,0 +1,201 @@
+package bluegreen
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/lb"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/loadbalancer"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/shard"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/k8s"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// Controller manages blue/green deployments of the LoadBalancer of an ingress.
+//
+// The blue stack is named after the ingress, while the green stack is named by shard.GreenName.
+// When the active stack is switched, the selected stack is built while the other stack keeps serving traffic.
+// Once all targetGroups of the selected stack have healthy targets and its listeners serve the desired rules, the ingress
+// status is swapped to the selected stack, and the other stack is deleted during the next reconcile.
+type Controller interface {
+	// Reconcile ensures the stack selected by the active-stack annotation exists, the blue stack is selected without the annotation.
+	// It returns the LoadBalancer that should be published in ingress status, and whether the swap is completed.
+	Reconcile(ctx context.Context, ingress *extensions.Ingress) (*lb.LoadBalancer, bool, error)
+	// HasGreenStack returns whether the green stack of ingress exists, it must be retired by reconciling ingress
+	// once the active-stack annotation is removed.
+	HasGreenStack(ctx context.Context, ingressKey types.NamespacedName) (bool, error)
+
+	// Delete ensures the green stack of ingress is removed.
+	Delete(ctx context.Context, ingressKey types.NamespacedName) error
+}
+
+func NewController(cloud aws.CloudAPI, store store.Storer, nameGen lb.NameGenerator, lbController lb.Controller) Controller {
+	return &defaultController{
+		cloud:        cloud,
+		store:        store,
+		nameGen:      nameGen,
+		lbController: lbController,
+	}
+}
+
+type defaultController struct {
+	cloud        aws.CloudAPI
+	store        store.Storer
+	nameGen      lb.NameGenerator
+	lbController lb.Controller
+}
+
+func (c *defaultController) Reconcile(ctx context.Context, ingress *extensions.Ingress) (*lb.LoadBalancer, bool, error) {
+	ingressKey := k8s.NamespacedName(ingress)
+	ingressAnnos, err := c.store.GetIngressAnnotations(ingressKey.String())
+	if err != nil {
+		return nil, false, err
+	}
+
+	activeIngress := ingress.DeepCopy()
+	retiringKey := shard.GreenKey(ingressKey)
+	if aws.StringValue(ingressAnnos.LoadBalancer.ActiveStack) == loadbalancer.StackGreen {
+		activeIngress.Name = shard.GreenName(ingress.Name)
+		retiringKey = ingressKey
+	}
+	active, err := c.lbController.Reconcile(ctx, activeIngress)
+	if err != nil {
+		return nil, false, err
+	}
+
+	retiring, err := c.cloud.GetLoadBalancerByName(ctx, c.nameGen.NameLB(retiringKey.Namespace, retiringKey.Name))
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to find existing LoadBalancer due to %w", err)
+	}
+	if retiring == nil {
+		return active, true, nil
+	}
+
+	ready, err := c.isReady(ctx, activeIngress, active)
+	if err != nil {
+		return nil, false, err
+	}
+	if !ready {
+		return &lb.LoadBalancer{
+			Arn:          aws.StringValue(retiring.LoadBalancerArn),
+			DNSName:      aws.StringValue(retiring.DNSName),
+			HostedZoneID: aws.StringValue(retiring.CanonicalHostedZoneId),
+			State:        lb.StateCode(retiring),
+		}, false, nil
+	}
+
+	// retire the previous stack once the ingress status points to the active one.
+	if !statusContains(ingress, active.DNSName) {
+		albctx.GetEventf(ctx)(corev1.EventTypeNormal, "MODIFY", "swapping LoadBalancer from %v to %v", aws.StringValue(retiring.LoadBalancerArn), active.Arn)
+		return active, false, nil
+	}
+	albctx.GetLogger(ctx).Infof("retiring LoadBalancer %v", aws.StringValue(retiring.LoadBalancerArn))
+	if err := c.lbController.Delete(ctx, retiringKey); err != nil {
+		return nil, false, errors.Wrap(err, "failed to retire LoadBalancer")
+	}
+	albctx.GetEventf(ctx)(corev1.EventTypeNormal, "DELETE", "LoadBalancer %v retired", aws.StringValue(retiring.LoadBalancerArn))
+	return active, true, nil
+}
+
+func (c *defaultController) HasGreenStack(ctx context.Context, ingressKey types.NamespacedName) (bool, error) {
+	greenKey := shard.GreenKey(ingressKey)
+	green, err := c.cloud.GetLoadBalancerByName(ctx, c.nameGen.NameLB(greenKey.Namespace, greenKey.Name))
+	if err != nil {
+		return false, fmt.Errorf("failed to find existing LoadBalancer due to %w", err)
+	}
+	return green != nil, nil
+}
+
+func (c *defaultController) Delete(ctx context.Context, ingressKey types.NamespacedName) error {
+	return c.lbController.Delete(ctx, shard.GreenKey(ingressKey))
+}
+
+// isReady checks whether the active stack can take over traffic from the retiring one.
+func (c *defaultController) isReady(ctx context.Context, activeIngress *extensions.Ingress, active *lb.LoadBalancer) (bool, error) {
+	healthy, err := c.isHealthy(ctx, active)
+	if err != nil {
+		return false, err
+	}
+	if !healthy {
+		albctx.GetLogger(ctx).Infof("waiting for targets of LoadBalancer %v to become healthy", active.Arn)
+		return false, nil
+	}
+	inSync, err := c.hasDesiredListeners(ctx, activeIngress)
+	if err != nil {
+		return false, err
+	}
+	if !inSync {
+		albctx.GetLogger(ctx).Infof("waiting for listeners of LoadBalancer %v to serve the desired rules", active.Arn)
+		return false, nil
+	}
+	return true, nil
+}
+
+// isHealthy checks whether each targetGroup of LoadBalancer have at least one healthy target.
+func (c *defaultController) isHealthy(ctx context.Context, instance *lb.LoadBalancer) (bool, error) {
+	for _, tgArn := range instance.TargetGroupArns {
+		resp, err := c.cloud.DescribeTargetHealthWithContext(ctx, &elbv2.DescribeTargetHealthInput{
+			TargetGroupArn: aws.String(tgArn),
+		})
+		if err != nil {
+			return false, fmt.Errorf("failed to describe target health of %v due to %w", tgArn, err)
+		}
+		healthy := false
+		for _, desc := range resp.TargetHealthDescriptions {
+			if desc.TargetHealth != nil && aws.StringValue(desc.TargetHealth.State) == elbv2.TargetHealthStateEnumHealthy {
+				healthy = true
+				break
+			}
+		}
+		if !healthy {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// listenerOperations are the operations that reconcile the listeners of a LoadBalancer and their rules.
+var listenerOperations = sets.NewString(
+	elbv2.ServiceName+"/CreateListener",
+	elbv2.ServiceName+"/ModifyListener",
+	elbv2.ServiceName+"/DeleteListener",
+	elbv2.ServiceName+"/CreateRule",
+	elbv2.ServiceName+"/ModifyRule",
+	elbv2.ServiceName+"/DeleteRule",
+	elbv2.ServiceName+"/SetRulePriorities",
+)
+
+// hasDesiredListeners checks whether every desired listener of the LoadBalancer of activeIngress exists with the desired rules,
+// by planning a reconcile of it: any listener or rule left to change means the stack can't serve traffic as desired yet.
+func (c *defaultController) hasDesiredListeners(ctx context.Context, activeIngress *extensions.Ingress) (bool, error) {
+	plan := &albctx.Plan{}
+	if _, err := c.lbController.Reconcile(albctx.SetPlan(ctx, plan), activeIngress); err != nil && !plan.Halted() {
+		return false, err
+	}
+	if plan.Halted() {
+		return false, nil
+	}
+	for _, operation := range plan.Operations() {
+		if listenerOperations.Has(operation) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func statusContains(ingress *extensions.Ingress, dnsName string) bool {
+	for _, lbIngress := range ingress.Status.LoadBalancer.Ingress {
+		if lbIngress.Hostname == dnsName {
+			return true
+		}
+	}
+	return false
+}
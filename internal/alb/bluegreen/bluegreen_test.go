@@ -0,0 +1,190 @@
+package bluegreen
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/lb"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/loadbalancer"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/shard"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+type fakeNameGenerator struct{}
+
+func (fakeNameGenerator) NameLB(namespace string, ingressName string) string {
+	return namespace + "-" + ingressName
+}
+
+func targetHealth(states ...string) *elbv2.DescribeTargetHealthOutput {
+	output := &elbv2.DescribeTargetHealthOutput{}
+	for _, state := range states {
+		output.TargetHealthDescriptions = append(output.TargetHealthDescriptions, &elbv2.TargetHealthDescription{
+			TargetHealth: &elbv2.TargetHealth{State: aws.String(state)},
+		})
+	}
+	return output
+}
+
+func Test_isHealthy(t *testing.T) {
+	for _, tc := range []struct {
+		Name            string
+		TargetHealth    map[string]*elbv2.DescribeTargetHealthOutput
+		ExpectedHealthy bool
+	}{
+		{
+			Name: "all targetGroups have healthy targets",
+			TargetHealth: map[string]*elbv2.DescribeTargetHealthOutput{
+				"tg1": targetHealth(elbv2.TargetHealthStateEnumInitial, elbv2.TargetHealthStateEnumHealthy),
+				"tg2": targetHealth(elbv2.TargetHealthStateEnumHealthy),
+			},
+			ExpectedHealthy: true,
+		},
+		{
+			Name: "targetGroup without healthy targets",
+			TargetHealth: map[string]*elbv2.DescribeTargetHealthOutput{
+				"tg1": targetHealth(elbv2.TargetHealthStateEnumHealthy),
+				"tg2": targetHealth(elbv2.TargetHealthStateEnumInitial),
+			},
+			ExpectedHealthy: false,
+		},
+		{
+			Name: "targetGroup without targets",
+			TargetHealth: map[string]*elbv2.DescribeTargetHealthOutput{
+				"tg1": targetHealth(),
+			},
+			ExpectedHealthy: false,
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			ctx := context.Background()
+			cloud := &mocks.CloudAPI{}
+			instance := &lb.LoadBalancer{}
+			for _, tgArn := range []string{"tg1", "tg2"} {
+				output, ok := tc.TargetHealth[tgArn]
+				if !ok {
+					continue
+				}
+				instance.TargetGroupArns = append(instance.TargetGroupArns, tgArn)
+				cloud.On("DescribeTargetHealthWithContext", ctx, &elbv2.DescribeTargetHealthInput{
+					TargetGroupArn: aws.String(tgArn),
+				}).Return(output, nil)
+			}
+
+			c := &defaultController{cloud: cloud}
+			healthy, err := c.isHealthy(ctx, instance)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.ExpectedHealthy, healthy)
+			cloud.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_HasGreenStack(t *testing.T) {
+	for _, tc := range []struct {
+		Name          string
+		Green         *elbv2.LoadBalancer
+		ExpectedGreen bool
+	}{
+		{
+			Name:          "green stack exists",
+			Green:         &elbv2.LoadBalancer{LoadBalancerArn: aws.String("green")},
+			ExpectedGreen: true,
+		},
+		{
+			Name:          "green stack doesn't exist",
+			ExpectedGreen: false,
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			ctx := context.Background()
+			cloud := &mocks.CloudAPI{}
+			cloud.On("GetLoadBalancerByName", ctx, "namespace-"+shard.GreenName("ingress")).Return(tc.Green, nil)
+			c := &defaultController{cloud: cloud, nameGen: fakeNameGenerator{}}
+			green, err := c.HasGreenStack(ctx, types.NamespacedName{Namespace: "namespace", Name: "ingress"})
+			assert.NoError(t, err)
+			assert.Equal(t, tc.ExpectedGreen, green)
+		})
+	}
+}
+
+// fakeLBController reconciles every stack to a LoadBalancer named after it, planned reconciles record plannedOperations.
+type fakeLBController struct {
+	plannedOperations []string
+	deleted           []types.NamespacedName
+}
+
+func (c *fakeLBController) Reconcile(ctx context.Context, ingress *extensions.Ingress) (*lb.LoadBalancer, error) {
+	if plan := albctx.GetPlan(ctx); plan != nil {
+		for _, operation := range c.plannedOperations {
+			plan.Record(operation)
+			if operation == "elasticloadbalancing/CreateListener" {
+				plan.Halt()
+				return nil, awserr.New(aws.ErrCodeDryRun, "CreateListener is not performed in dry-run", nil)
+			}
+		}
+	}
+	return &lb.LoadBalancer{Arn: ingress.Name, DNSName: ingress.Name + ".elb.amazonaws.com", TargetGroupArns: []string{"tg"}}, nil
+}
+
+func (c *fakeLBController) Delete(ctx context.Context, ingressKey types.NamespacedName) error {
+	c.deleted = append(c.deleted, ingressKey)
+	return nil
+}
+
+func TestDefaultController_Reconcile(t *testing.T) {
+	for _, tc := range []struct {
+		Name              string
+		PlannedOperations []string
+		ExpectedDNSName   string
+	}{
+		{
+			Name:              "listeners serve the desired rules",
+			PlannedOperations: []string{"elasticloadbalancing/AddTags"},
+			ExpectedDNSName:   "ingress.green.elb.amazonaws.com",
+		},
+		{
+			Name:              "listener missing",
+			PlannedOperations: []string{"elasticloadbalancing/CreateListener"},
+			ExpectedDNSName:   "blue.elb.amazonaws.com",
+		},
+		{
+			Name:              "rule differs",
+			PlannedOperations: []string{"elasticloadbalancing/ModifyRule"},
+			ExpectedDNSName:   "blue.elb.amazonaws.com",
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			ingress := &extensions.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "namespace", Name: "ingress"}}
+			dummyStore := store.NewDummy()
+			dummyStore.GetIngressAnnotationsResponse.LoadBalancer = &loadbalancer.Config{ActiveStack: aws.String(loadbalancer.StackGreen)}
+			cloud := &mocks.CloudAPI{}
+			cloud.On("GetLoadBalancerByName", mock.Anything, "namespace-ingress").Return(&elbv2.LoadBalancer{
+				LoadBalancerArn: aws.String("blue"),
+				DNSName:         aws.String("blue.elb.amazonaws.com"),
+			}, nil)
+			cloud.On("DescribeTargetHealthWithContext", mock.Anything, &elbv2.DescribeTargetHealthInput{
+				TargetGroupArn: aws.String("tg"),
+			}).Return(targetHealth(elbv2.TargetHealthStateEnumHealthy), nil)
+			lbController := &fakeLBController{plannedOperations: tc.PlannedOperations}
+
+			c := NewController(cloud, dummyStore, fakeNameGenerator{}, lbController)
+			instance, swapped, err := c.Reconcile(context.Background(), ingress)
+			assert.NoError(t, err)
+			assert.False(t, swapped)
+			assert.Equal(t, tc.ExpectedDNSName, instance.DNSName)
+			assert.Empty(t, lbController.deleted)
+			cloud.AssertExpectations(t)
+		})
+	}
+}
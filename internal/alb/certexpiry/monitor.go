@@ -0,0 +1,247 @@
+package certexpiry
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/generator"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/metric"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/metric/collectors"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/log"
+	corev1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// maximum number of resources per DescribeTags call.
+const describeTagsBatchSize = 20
+
+// NewMonitor constructs a runnable that periodically checks the expiry of certificates on the listeners of ALBs managed for clusterName.
+// Ingresses get a warning event once their certificates expire within warningWindow, and are reconciled once their certificates got renewed or expired.
+func NewMonitor(cloud aws.CloudAPI, mc metric.Collector, client client.Reader, recorder record.EventRecorder, ingressChan chan<- event.GenericEvent,
+	clusterName string, interval time.Duration, warningWindow time.Duration) manager.Runnable {
+	return &monitor{
+		cloud:         cloud,
+		mc:            mc,
+		client:        client,
+		recorder:      recorder,
+		ingressChan:   ingressChan,
+		clusterName:   clusterName,
+		interval:      interval,
+		warningWindow: warningWindow,
+		notAfter:      make(map[string]time.Time),
+		logger:        log.New("certificate-monitor"),
+	}
+}
+
+type monitor struct {
+	cloud         aws.CloudAPI
+	mc            metric.Collector
+	client        client.Reader
+	recorder      record.EventRecorder
+	ingressChan   chan<- event.GenericEvent
+	clusterName   string
+	interval      time.Duration
+	warningWindow time.Duration
+
+	// notAfter is the expiry of each certificate as of the previous check, to tell when certificates got renewed.
+	notAfter map[string]time.Time
+	logger   *log.Logger
+}
+
+type loadBalancer struct {
+	arn        string
+	ingressKey types.NamespacedName
+}
+
+// Start implements manager.Runnable
+func (m *monitor) Start(stop <-chan struct{}) error {
+	wait.Until(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), m.interval)
+		defer cancel()
+		if err := m.check(ctx, time.Now()); err != nil {
+			m.logger.Errorf("failed to check certificate expiry due to %v", err)
+		}
+	}, m.interval, stop)
+	return nil
+}
+
+func (m *monitor) check(ctx context.Context, now time.Time) error {
+	lbs, err := m.listLoadBalancers(ctx)
+	if err != nil {
+		return err
+	}
+	certArnsByIngress := make(map[types.NamespacedName]sets.String)
+	var ingressKeys []types.NamespacedName
+	for _, lb := range lbs {
+		certArns, err := m.listCertificates(ctx, lb.arn)
+		if err != nil {
+			return err
+		}
+		if _, ok := certArnsByIngress[lb.ingressKey]; !ok {
+			ingressKeys = append(ingressKeys, lb.ingressKey)
+			certArnsByIngress[lb.ingressKey] = sets.NewString()
+		}
+		certArnsByIngress[lb.ingressKey].Insert(certArns...)
+	}
+
+	notAfter, err := m.describeExpiries(ctx, certArnsByIngress)
+	if err != nil {
+		return err
+	}
+	var expiries []collectors.CertificateExpiry
+	for _, ingressKey := range ingressKeys {
+		renewedOrExpired := false
+		for _, certArn := range certArnsByIngress[ingressKey].List() {
+			expiry, ok := notAfter[certArn]
+			if !ok {
+				continue
+			}
+			expiries = append(expiries, collectors.CertificateExpiry{
+				Namespace:      ingressKey.Namespace,
+				IngressName:    ingressKey.Name,
+				CertificateArn: certArn,
+				NotAfter:       expiry,
+			})
+			if previous, ok := m.notAfter[certArn]; (ok && !previous.Equal(expiry)) || !expiry.After(now) {
+				renewedOrExpired = true
+			}
+			if expiry.Sub(now) < m.warningWindow {
+				m.eventf(ctx, ingressKey, corev1.EventTypeWarning, "ERROR", "certificate %v expires at %v", certArn, expiry.Format(time.RFC3339))
+			}
+		}
+		if renewedOrExpired {
+			m.enqueue(ctx, ingressKey)
+		}
+	}
+	m.notAfter = notAfter
+	m.mc.SetCertificateExpiry(expiries)
+	return nil
+}
+
+// listLoadBalancers returns the ALBs owned by the cluster, together with the ingress they belongs to.
+func (m *monitor) listLoadBalancers(ctx context.Context) ([]loadBalancer, error) {
+	arns, err := m.cloud.GetResourcesByFilters(map[string][]string{
+		"kubernetes.io/cluster/" + m.clusterName: {"owned"},
+	}, aws.ResourceTypeEnumELBLoadBalancer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get load balancers by tags due to %w", err)
+	}
+
+	var lbs []loadBalancer
+	for i := 0; i < len(arns); i += describeTagsBatchSize {
+		end := i + describeTagsBatchSize
+		if end > len(arns) {
+			end = len(arns)
+		}
+		resp, err := m.cloud.DescribeELBV2TagsWithContext(ctx, &elbv2.DescribeTagsInput{
+			ResourceArns: aws.StringSlice(arns[i:end]),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe tags of load balancers due to %w", err)
+		}
+		for _, desc := range resp.TagDescriptions {
+			lb := loadBalancer{arn: aws.StringValue(desc.ResourceArn)}
+			for _, tag := range desc.Tags {
+				switch aws.StringValue(tag.Key) {
+				case generator.TagKeyNamespace:
+					lb.ingressKey.Namespace = aws.StringValue(tag.Value)
+				case generator.TagKeyIngressName:
+					lb.ingressKey.Name = aws.StringValue(tag.Value)
+				}
+			}
+			lbs = append(lbs, lb)
+		}
+	}
+	return lbs, nil
+}
+
+// listCertificates returns the ARNs of certificates on the HTTPS listeners of LoadBalancer with lbArn.
+func (m *monitor) listCertificates(ctx context.Context, lbArn string) ([]string, error) {
+	listeners, err := m.cloud.ListListenersByLoadBalancer(ctx, lbArn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list listeners of %v due to %w", lbArn, err)
+	}
+	var certArns []string
+	for _, listener := range listeners {
+		if aws.StringValue(listener.Protocol) != elbv2.ProtocolEnumHttps {
+			continue
+		}
+		certificates, err := m.cloud.DescribeListenerCertificates(ctx, aws.StringValue(listener.ListenerArn))
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe certificates of %v due to %w", aws.StringValue(listener.ListenerArn), err)
+		}
+		for _, certificate := range certificates {
+			certArns = append(certArns, aws.StringValue(certificate.CertificateArn))
+		}
+	}
+	return certArns, nil
+}
+
+// describeExpiries returns the expiry of each ACM or IAM certificate in certArnsByIngress.
+func (m *monitor) describeExpiries(ctx context.Context, certArnsByIngress map[types.NamespacedName]sets.String) (map[string]time.Time, error) {
+	notAfter := make(map[string]time.Time)
+	iamListed := false
+	for _, certArns := range certArnsByIngress {
+		for certArn := range certArns {
+			if _, ok := notAfter[certArn]; ok {
+				continue
+			}
+			switch {
+			case strings.Contains(certArn, ":acm:"):
+				certDetail, err := m.cloud.DescribeCertificate(ctx, certArn)
+				if err != nil {
+					return nil, fmt.Errorf("failed to describe certificate %v due to %w", certArn, err)
+				}
+				if certDetail.NotAfter != nil {
+					notAfter[certArn] = *certDetail.NotAfter
+				}
+			case strings.Contains(certArn, ":iam:") && !iamListed:
+				iamListed = true
+				serverCertificates, err := m.cloud.ListServerCertificates(ctx)
+				if err != nil {
+					return nil, fmt.Errorf("failed to list server certificates due to %w", err)
+				}
+				for _, serverCertificate := range serverCertificates {
+					if serverCertificate.Expiration != nil {
+						notAfter[aws.StringValue(serverCertificate.Arn)] = *serverCertificate.Expiration
+					}
+				}
+			}
+		}
+	}
+	return notAfter, nil
+}
+
+func (m *monitor) eventf(ctx context.Context, ingressKey types.NamespacedName, eventType string, reason string, messageFmt string, args ...interface{}) {
+	ingress := &extensions.Ingress{}
+	if err := m.client.Get(ctx, ingressKey, ingress); err != nil {
+		m.logger.Warnf("failed to get ingress %v due to %v", ingressKey, err)
+		return
+	}
+	m.recorder.Eventf(ingress, eventType, reason, messageFmt, args...)
+}
+
+// enqueue triggers reconcile of the ingress with ingressKey, so that its listeners reference valid certificates.
+func (m *monitor) enqueue(ctx context.Context, ingressKey types.NamespacedName) {
+	ingress := &extensions.Ingress{}
+	if err := m.client.Get(ctx, ingressKey, ingress); err != nil {
+		m.logger.Warnf("failed to get ingress %v due to %v", ingressKey, err)
+		return
+	}
+	m.logger.Infof("certificates of ingress %v renewed or expired, reconciling it", ingressKey)
+	m.ingressChan <- event.GenericEvent{
+		Meta:   ingress,
+		Object: ingress,
+	}
+}
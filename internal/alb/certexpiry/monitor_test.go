@@ -0,0 +1,113 @@
+package certexpiry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/acm"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/metric"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/log"
+	"github.com/stretchr/testify/assert"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func Test_check(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	acmArn := "arn:aws:acm:us-west-2:123456789012:certificate/cert"
+	iamArn := "arn:aws:iam::123456789012:server-certificate/cert"
+
+	for _, tc := range []struct {
+		Name             string
+		ACMNotAfter      time.Time
+		PreviousNotAfter map[string]time.Time
+		ExpectedEvents   int
+		ExpectedEnqueue  bool
+	}{
+		{
+			Name:             "valid certificates",
+			ACMNotAfter:      now.Add(90 * 24 * time.Hour),
+			PreviousNotAfter: map[string]time.Time{acmArn: now.Add(90 * 24 * time.Hour)},
+		},
+		{
+			Name:             "certificate expires soon",
+			ACMNotAfter:      now.Add(7 * 24 * time.Hour),
+			PreviousNotAfter: map[string]time.Time{acmArn: now.Add(7 * 24 * time.Hour)},
+			ExpectedEvents:   1,
+		},
+		{
+			Name:             "certificate renewed",
+			ACMNotAfter:      now.Add(395 * 24 * time.Hour),
+			PreviousNotAfter: map[string]time.Time{acmArn: now.Add(7 * 24 * time.Hour)},
+			ExpectedEnqueue:  true,
+		},
+		{
+			Name:            "certificate expired",
+			ACMNotAfter:     now.Add(-time.Hour),
+			ExpectedEvents:  1,
+			ExpectedEnqueue: true,
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			ctx := context.Background()
+			cloud := &mocks.CloudAPI{}
+			cloud.On("GetResourcesByFilters", map[string][]string{
+				"kubernetes.io/cluster/cluster": {"owned"},
+			}, aws.ResourceTypeEnumELBLoadBalancer).Return([]string{"lbArn"}, nil)
+			cloud.On("DescribeELBV2TagsWithContext", ctx, &elbv2.DescribeTagsInput{
+				ResourceArns: aws.StringSlice([]string{"lbArn"}),
+			}).Return(&elbv2.DescribeTagsOutput{
+				TagDescriptions: []*elbv2.TagDescription{
+					{
+						ResourceArn: aws.String("lbArn"),
+						Tags: []*elbv2.Tag{
+							{Key: aws.String("kubernetes.io/namespace"), Value: aws.String("namespace")},
+							{Key: aws.String("kubernetes.io/ingress-name"), Value: aws.String("ingress")},
+						},
+					},
+				},
+			}, nil)
+			cloud.On("ListListenersByLoadBalancer", ctx, "lbArn").Return([]*elbv2.Listener{
+				{ListenerArn: aws.String("httpArn"), Protocol: aws.String(elbv2.ProtocolEnumHttp)},
+				{ListenerArn: aws.String("httpsArn"), Protocol: aws.String(elbv2.ProtocolEnumHttps)},
+			}, nil)
+			cloud.On("DescribeListenerCertificates", ctx, "httpsArn").Return([]*elbv2.Certificate{
+				{CertificateArn: aws.String(acmArn), IsDefault: aws.Bool(true)},
+				{CertificateArn: aws.String(iamArn)},
+			}, nil)
+			cloud.On("DescribeCertificate", ctx, acmArn).Return(&acm.CertificateDetail{NotAfter: &tc.ACMNotAfter}, nil)
+			iamNotAfter := now.Add(180 * 24 * time.Hour)
+			cloud.On("ListServerCertificates", ctx).Return([]*iam.ServerCertificateMetadata{
+				{Arn: aws.String(iamArn), Expiration: &iamNotAfter},
+			}, nil)
+
+			ingress := &extensions.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "namespace", Name: "ingress"}}
+			recorder := record.NewFakeRecorder(10)
+			ingressChan := make(chan event.GenericEvent, 1)
+			m := &monitor{
+				cloud:         cloud,
+				mc:            metric.DummyCollector{},
+				client:        fake.NewFakeClient(ingress),
+				recorder:      recorder,
+				ingressChan:   ingressChan,
+				clusterName:   "cluster",
+				warningWindow: 30 * 24 * time.Hour,
+				notAfter:      tc.PreviousNotAfter,
+				logger:        log.New("test"),
+			}
+			assert.NoError(t, m.check(ctx, now))
+			assert.Equal(t, tc.ExpectedEvents, len(recorder.Events))
+			assert.Equal(t, tc.ExpectedEnqueue, len(ingressChan) == 1)
+			assert.Equal(t, map[string]time.Time{acmArn: tc.ACMNotAfter, iamArn: iamNotAfter}, m.notAfter)
+			cloud.AssertExpectations(t)
+		})
+	}
+}
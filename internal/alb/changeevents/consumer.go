@@ -0,0 +1,248 @@
+package changeevents
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/generator"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/group"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/shard"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/log"
+	extensions "k8s.io/api/extensions/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+const (
+	// sources of the EventBridge events of CloudTrail API calls to ELBv2 and EC2.
+	sourceELBV2 = "aws.elasticloadbalancing"
+	sourceEC2   = "aws.ec2"
+
+	// long polling of the queue, the maximum allowed by SQS.
+	receiveWaitTimeSeconds = 20
+	receiveMaxMessages     = 10
+	// receiveRetryInterval is the interval between polls, which only matters once receiving fails.
+	receiveRetryInterval = 5 * time.Second
+)
+
+// NewConsumer constructs a runnable that consumes the EventBridge events of ELBv2 and EC2 API calls recorded by CloudTrail from the SQS queue at queueURL.
+// Ingresses whose ALB, listeners, rules, target groups or security groups are changed by anyone but the controller of clusterName are reconciled right away,
+// instead of at the next full sync.
+func NewConsumer(cloud aws.CloudAPI, client client.Reader, ingressChan chan<- event.GenericEvent, clusterName string, queueURL string) manager.Runnable {
+	return &consumer{
+		cloud:       cloud,
+		client:      client,
+		ingressChan: ingressChan,
+		clusterName: clusterName,
+		queueURL:    queueURL,
+		logger:      log.New("change-events"),
+	}
+}
+
+type consumer struct {
+	cloud       aws.CloudAPI
+	client      client.Reader
+	ingressChan chan<- event.GenericEvent
+	clusterName string
+	queueURL    string
+	logger      *log.Logger
+}
+
+// changeEvent is the part of an EventBridge "AWS API Call via CloudTrail" event identifying the changed resource.
+type changeEvent struct {
+	Source string `json:"source"`
+	Detail struct {
+		UserAgent         string                 `json:"userAgent"`
+		ErrorCode         string                 `json:"errorCode"`
+		RequestParameters map[string]interface{} `json:"requestParameters"`
+	} `json:"detail"`
+}
+
+// Start implements manager.Runnable
+func (c *consumer) Start(stop <-chan struct{}) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-stop:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	wait.Until(func() {
+		if err := c.consume(ctx); err != nil && ctx.Err() == nil {
+			c.logger.Errorf("failed to consume change events due to %v", err)
+		}
+	}, receiveRetryInterval, stop)
+	return nil
+}
+
+// consume receives messages until receiving fails. Messages are deleted once handled, including those that can't be handled,
+// since the periodic full sync eventually reconciles the ingresses they were about.
+func (c *consumer) consume(ctx context.Context) error {
+	for {
+		resp, err := c.cloud.ReceiveSQSMessagesWithContext(ctx, &sqs.ReceiveMessageInput{
+			QueueUrl:            aws.String(c.queueURL),
+			MaxNumberOfMessages: aws.Int64(receiveMaxMessages),
+			WaitTimeSeconds:     aws.Int64(receiveWaitTimeSeconds),
+		})
+		if err != nil {
+			return err
+		}
+		ingressKeys := make(map[types.NamespacedName]bool)
+		for _, message := range resp.Messages {
+			ingressKey, ok, err := c.resolveIngressKey(ctx, aws.StringValue(message.Body))
+			if err != nil {
+				c.logger.Warnf("failed to handle change event %v due to %v", aws.StringValue(message.MessageId), err)
+			} else if ok {
+				ingressKeys[ingressKey] = true
+			}
+			if _, err := c.cloud.DeleteSQSMessageWithContext(ctx, &sqs.DeleteMessageInput{
+				QueueUrl:      aws.String(c.queueURL),
+				ReceiptHandle: message.ReceiptHandle,
+			}); err != nil {
+				c.logger.Warnf("failed to delete change event %v due to %v", aws.StringValue(message.MessageId), err)
+			}
+		}
+		for ingressKey := range ingressKeys {
+			c.enqueue(ctx, ingressKey)
+		}
+	}
+}
+
+// resolveIngressKey returns the key of the ingress or IngressGroup owning the resource changed by the event in body.
+// It returns false for events of failed calls, calls made by the controller itself, and resources that don't belong to an ingress.
+func (c *consumer) resolveIngressKey(ctx context.Context, body string) (types.NamespacedName, bool, error) {
+	e := changeEvent{}
+	if err := json.Unmarshal([]byte(body), &e); err != nil {
+		return types.NamespacedName{}, false, fmt.Errorf("failed to parse event due to %w", err)
+	}
+	if e.Detail.ErrorCode != "" || aws.IsControllerUserAgent(e.Detail.UserAgent, c.clusterName) {
+		return types.NamespacedName{}, false, nil
+	}
+
+	var tags map[string]string
+	switch e.Source {
+	case sourceELBV2:
+		arn := changedELBV2Arn(e.Detail.RequestParameters)
+		if arn == "" {
+			return types.NamespacedName{}, false, nil
+		}
+		resp, err := c.cloud.DescribeELBV2TagsWithContext(ctx, &elbv2.DescribeTagsInput{
+			ResourceArns: aws.StringSlice([]string{arn}),
+		})
+		if err != nil {
+			return types.NamespacedName{}, false, fmt.Errorf("failed to describe tags of %v due to %w", arn, err)
+		}
+		tags = make(map[string]string)
+		for _, desc := range resp.TagDescriptions {
+			for _, tag := range desc.Tags {
+				tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+			}
+		}
+	case sourceEC2:
+		groupID, _ := e.Detail.RequestParameters["groupId"].(string)
+		if groupID == "" {
+			return types.NamespacedName{}, false, nil
+		}
+		securityGroups, err := c.cloud.DescribeSecurityGroups(ctx, &ec2.DescribeSecurityGroupsInput{
+			GroupIds: aws.StringSlice([]string{groupID}),
+		})
+		if err != nil {
+			return types.NamespacedName{}, false, fmt.Errorf("failed to describe securityGroup %v due to %w", groupID, err)
+		}
+		tags = make(map[string]string)
+		for _, securityGroup := range securityGroups {
+			for _, tag := range securityGroup.Tags {
+				tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+			}
+		}
+	default:
+		return types.NamespacedName{}, false, nil
+	}
+	ingressKey, ok := ingressKeyFromTags(tags, c.clusterName)
+	return ingressKey, ok, nil
+}
+
+// enqueue triggers reconcile of the ingress with ingressKey, or of any member of the IngressGroup with ingressKey.
+func (c *consumer) enqueue(ctx context.Context, ingressKey types.NamespacedName) {
+	ingress, err := c.findIngress(ctx, ingressKey)
+	if err != nil {
+		c.logger.Warnf("failed to get ingress %v due to %v", ingressKey, err)
+		return
+	}
+	if ingress == nil {
+		return
+	}
+	c.logger.Infof("AWS resources of ingress %v changed outside of the controller, reconciling it", ingressKey)
+	c.ingressChan <- event.GenericEvent{
+		Meta:   ingress,
+		Object: ingress,
+	}
+}
+
+func (c *consumer) findIngress(ctx context.Context, ingressKey types.NamespacedName) (*extensions.Ingress, error) {
+	if !group.IsKey(ingressKey) {
+		ingress := &extensions.Ingress{}
+		if err := c.client.Get(ctx, ingressKey, ingress); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		return ingress, nil
+	}
+	ingressList := &extensions.IngressList{}
+	if err := c.client.List(ctx, nil, ingressList); err != nil {
+		return nil, err
+	}
+	for i := range ingressList.Items {
+		if group.Name(&ingressList.Items[i]) == ingressKey.Name {
+			return &ingressList.Items[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// changedELBV2Arn returns the ARN of the LoadBalancer or targetGroup changed by an ELBv2 call with requestParameters.
+// Listeners and rules aren't tagged, so changes to them are attributed to their LoadBalancer.
+func changedELBV2Arn(requestParameters map[string]interface{}) string {
+	for _, key := range []string{"loadBalancerArn", "targetGroupArn", "listenerArn", "ruleArn"} {
+		if arn, ok := requestParameters[key].(string); ok && arn != "" {
+			return aws.LoadBalancerArn(arn)
+		}
+	}
+	// calls changing several rules at once, like SetRulePriorities.
+	if priorities, ok := requestParameters["rulePriorities"].([]interface{}); ok && len(priorities) > 0 {
+		if priority, ok := priorities[0].(map[string]interface{}); ok {
+			if arn, ok := priority["ruleArn"].(string); ok {
+				return aws.LoadBalancerArn(arn)
+			}
+		}
+	}
+	return ""
+}
+
+// ingressKeyFromTags returns the key of the ingress or IngressGroup a resource with tags belongs to, if it's owned by clusterName.
+// Resources of shards, secondary ALBs and green stacks belong to the ingress they're split from.
+func ingressKeyFromTags(tags map[string]string, clusterName string) (types.NamespacedName, bool) {
+	if tags[generator.TagKeyClusterName] != clusterName && tags["kubernetes.io/cluster/"+clusterName] != "owned" {
+		return types.NamespacedName{}, false
+	}
+	namespace, ingressName := tags[generator.TagKeyNamespace], tags[generator.TagKeyIngressName]
+	if namespace == "" || ingressName == "" {
+		return types.NamespacedName{}, false
+	}
+	return types.NamespacedName{Namespace: namespace, Name: shard.ParentName(ingressName)}, true
+}
@@ -0,0 +1,198 @@
+package changeevents
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"html"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/metric"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+const lbArn = "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/my-lb/50dc6c495c0c9188"
+
+func Test_resolveIngressKey(t *testing.T) {
+	lbTags := []*elbv2.Tag{
+		{Key: aws.String("kubernetes.io/cluster/cluster"), Value: aws.String("owned")},
+		{Key: aws.String("kubernetes.io/namespace"), Value: aws.String("namespace")},
+		{Key: aws.String("kubernetes.io/ingress-name"), Value: aws.String("ingress.shard-1")},
+	}
+	for _, tc := range []struct {
+		name        string
+		body        string
+		mock        func(ctx context.Context, cloud *mocks.CloudAPI)
+		expectedKey types.NamespacedName
+		expectedOK  bool
+		expectedErr bool
+	}{
+		{
+			name: "rule modified in the console",
+			body: `{"source":"aws.elasticloadbalancing","detail":{"eventName":"ModifyRule","userAgent":"console.amazonaws.com",
+				"requestParameters":{"ruleArn":"arn:aws:elasticloadbalancing:us-west-2:123456789012:listener-rule/app/my-lb/50dc6c495c0c9188/f2f7dc8efc522ab2/9683b2d02a6cabee"}}}`,
+			mock: func(ctx context.Context, cloud *mocks.CloudAPI) {
+				cloud.On("DescribeELBV2TagsWithContext", ctx, &elbv2.DescribeTagsInput{
+					ResourceArns: aws.StringSlice([]string{lbArn}),
+				}).Return(&elbv2.DescribeTagsOutput{
+					TagDescriptions: []*elbv2.TagDescription{{ResourceArn: aws.String(lbArn), Tags: lbTags}},
+				}, nil)
+			},
+			expectedKey: types.NamespacedName{Namespace: "namespace", Name: "ingress"},
+			expectedOK:  true,
+		},
+		{
+			name: "securityGroup rule revoked by another tool",
+			body: `{"source":"aws.ec2","detail":{"eventName":"RevokeSecurityGroupIngress","userAgent":"aws-cli/1.16.300",
+				"requestParameters":{"groupId":"sg-1234"}}}`,
+			mock: func(ctx context.Context, cloud *mocks.CloudAPI) {
+				cloud.On("DescribeSecurityGroups", ctx, &ec2.DescribeSecurityGroupsInput{
+					GroupIds: aws.StringSlice([]string{"sg-1234"}),
+				}).Return([]*ec2.SecurityGroup{
+					{
+						GroupId: aws.String("sg-1234"),
+						Tags: []*ec2.Tag{
+							{Key: aws.String("kubernetes.io/cluster-name"), Value: aws.String("cluster")},
+							{Key: aws.String("kubernetes.io/namespace"), Value: aws.String("ingress.group")},
+							{Key: aws.String("kubernetes.io/ingress-name"), Value: aws.String("group")},
+						},
+					},
+				}, nil)
+			},
+			expectedKey: types.NamespacedName{Namespace: "ingress.group", Name: "group"},
+			expectedOK:  true,
+		},
+		{
+			name: "call made by the controller",
+			body: `{"source":"aws.elasticloadbalancing","detail":{"eventName":"ModifyListener",
+				"userAgent":"aws-sdk-go/1.27.3 (go1.13.5; linux; amd64) aws-alb-ingress-controller/v1.1.5 (cluster/cluster; instance/controller-5d8f7c)",
+				"requestParameters":{"listenerArn":"arn:aws:elasticloadbalancing:us-west-2:123456789012:listener/app/my-lb/50dc6c495c0c9188/f2f7dc8efc522ab2"}}}`,
+		},
+		{
+			name: "failed call",
+			body: `{"source":"aws.elasticloadbalancing","detail":{"eventName":"DeleteRule","errorCode":"AccessDenied",
+				"requestParameters":{"ruleArn":"arn:aws:elasticloadbalancing:us-west-2:123456789012:listener-rule/app/my-lb/50dc6c495c0c9188/f2f7dc8efc522ab2/9683b2d02a6cabee"}}}`,
+		},
+		{
+			name: "LoadBalancer of another cluster",
+			body: `{"source":"aws.elasticloadbalancing","detail":{"eventName":"ModifyLoadBalancerAttributes","userAgent":"console.amazonaws.com",
+				"requestParameters":{"loadBalancerArn":"` + lbArn + `"}}}`,
+			mock: func(ctx context.Context, cloud *mocks.CloudAPI) {
+				cloud.On("DescribeELBV2TagsWithContext", ctx, &elbv2.DescribeTagsInput{
+					ResourceArns: aws.StringSlice([]string{lbArn}),
+				}).Return(&elbv2.DescribeTagsOutput{
+					TagDescriptions: []*elbv2.TagDescription{
+						{
+							ResourceArn: aws.String(lbArn),
+							Tags: []*elbv2.Tag{
+								{Key: aws.String("kubernetes.io/cluster/other"), Value: aws.String("owned")},
+								{Key: aws.String("kubernetes.io/namespace"), Value: aws.String("namespace")},
+								{Key: aws.String("kubernetes.io/ingress-name"), Value: aws.String("ingress")},
+							},
+						},
+					},
+				}, nil)
+			},
+		},
+		{
+			name:        "malformed event",
+			body:        `not json`,
+			expectedErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			cloud := &mocks.CloudAPI{}
+			if tc.mock != nil {
+				tc.mock(ctx, cloud)
+			}
+			c := &consumer{cloud: cloud, clusterName: "cluster"}
+			key, ok, err := c.resolveIngressKey(ctx, tc.body)
+			assert.Equal(t, tc.expectedErr, err != nil)
+			assert.Equal(t, tc.expectedOK, ok)
+			assert.Equal(t, tc.expectedKey, key)
+			cloud.AssertExpectations(t)
+		})
+	}
+}
+
+// sqsCloud sends SQS requests with sqs, other requests are mocked.
+type sqsCloud struct {
+	*mocks.CloudAPI
+	sqs *sqs.SQS
+}
+
+func (c sqsCloud) ReceiveSQSMessagesWithContext(ctx context.Context, i *sqs.ReceiveMessageInput) (*sqs.ReceiveMessageOutput, error) {
+	return c.sqs.ReceiveMessageWithContext(ctx, i)
+}
+
+func (c sqsCloud) DeleteSQSMessageWithContext(ctx context.Context, i *sqs.DeleteMessageInput) (*sqs.DeleteMessageOutput, error) {
+	return c.sqs.DeleteMessageWithContext(ctx, i)
+}
+
+func TestConsumer_consume_dryRun(t *testing.T) {
+	body := `{"source":"aws.elasticloadbalancing","detail":{"eventName":"ModifyLoadBalancerAttributes","userAgent":"console.amazonaws.com",
+		"requestParameters":{"loadBalancerArn":"` + lbArn + `"}}}`
+	bodyMD5 := md5.Sum([]byte(body))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var actions []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, r.ParseForm())
+		action := r.PostForm.Get("Action")
+		actions = append(actions, action)
+		var message string
+		if len(actions) == 1 {
+			message = fmt.Sprintf("<Message><MessageId>m1</MessageId><ReceiptHandle>h1</ReceiptHandle><MD5OfBody>%s</MD5OfBody><Body>%s</Body></Message>",
+				hex.EncodeToString(bodyMD5[:]), html.EscapeString(body))
+		} else {
+			// stops consuming once the first message is handled.
+			cancel()
+		}
+		fmt.Fprintf(w, "<%[1]sResponse><%[1]sResult>%s</%[1]sResult><ResponseMetadata><RequestId>r</RequestId></ResponseMetadata></%[1]sResponse>", action, message)
+	}))
+	defer server.Close()
+
+	session := aws.NewSession(&awssdk.Config{
+		Region:      awssdk.String("us-west-2"),
+		Endpoint:    awssdk.String(server.URL),
+		Credentials: credentials.NewStaticCredentials("id", "secret", ""),
+		MaxRetries:  awssdk.Int(0),
+	}, false, true, 0, metric.DummyCollector{})
+	cloud := sqsCloud{CloudAPI: &mocks.CloudAPI{}, sqs: sqs.New(session)}
+	cloud.On("DescribeELBV2TagsWithContext", mock.Anything, &elbv2.DescribeTagsInput{
+		ResourceArns: aws.StringSlice([]string{lbArn}),
+	}).Return(&elbv2.DescribeTagsOutput{
+		TagDescriptions: []*elbv2.TagDescription{{ResourceArn: aws.String(lbArn), Tags: []*elbv2.Tag{
+			{Key: aws.String("kubernetes.io/cluster/cluster"), Value: aws.String("owned")},
+			{Key: aws.String("kubernetes.io/namespace"), Value: aws.String("namespace")},
+			{Key: aws.String("kubernetes.io/ingress-name"), Value: aws.String("ingress")},
+		}}},
+	}, nil)
+	ingress := &extensions.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "namespace", Name: "ingress"}}
+	ingressChan := make(chan event.GenericEvent, 1)
+	c := NewConsumer(cloud, fake.NewFakeClient(ingress), ingressChan, "cluster", server.URL+"/queue").(*consumer)
+
+	assert.Error(t, c.consume(ctx))
+	// deleting messages is skipped in dry-run.
+	assert.Equal(t, []string{"ReceiveMessage", "ReceiveMessage"}, actions)
+	if assert.Len(t, ingressChan, 1) {
+		assert.Equal(t, "ingress", (<-ingressChan).Meta.GetName())
+	}
+}
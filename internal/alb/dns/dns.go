@@ -0,0 +1,394 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/lb"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/k8s"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/log"
+	corev1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	// ownerRecordPrefix is prepended to hosts to name their ownership TXT records, so that they don't clash with existing TXT records of hosts.
+	ownerRecordPrefix = "_alb-owner."
+
+	// ownerHeritage identifies ownership TXT records written by the controller.
+	ownerHeritage = "heritage=aws-alb-ingress-controller"
+
+	ownerRecordTTL = 300
+
+	// hostedZonesCacheTTL is how long the hosted zones of the account are cached, hosts of a zone created meanwhile get records once it expires.
+	hostedZonesCacheTTL = 10 * time.Minute
+)
+
+// Controller manages the Route 53 alias records that point the hosts of ingresses at their LoadBalancers.
+// Records are only touched if they don't exist yet, or are owned by the ingress according to their ownership TXT record.
+type Controller interface {
+	// Reconcile ensures the hosts of each of lbIngresses are aliased to the corresponding of lbInfos,
+	// and removes records owned by ingress that are no longer needed, including those in hosted zones none of its hosts belong to anymore.
+	Reconcile(ctx context.Context, ingress *extensions.Ingress, lbIngresses []*extensions.Ingress, lbInfos []*lb.LoadBalancer) error
+
+	// Delete ensures all records owned by the ingress are removed.
+	Delete(ctx context.Context, ingressKey types.NamespacedName) error
+}
+
+func NewController(cloud aws.CloudAPI, store store.Storer) Controller {
+	return &defaultController{
+		cloud:      cloud,
+		store:      store,
+		now:        time.Now,
+		ownedZones: make(map[types.NamespacedName]sets.String),
+	}
+}
+
+type defaultController struct {
+	cloud aws.CloudAPI
+	store store.Storer
+	now   func() time.Time
+
+	mutex       sync.Mutex
+	zones       []*route53.HostedZone
+	zonesExpiry time.Time
+	// ownedZones are the IDs of hosted zones with records owned by each ingress. They're unknown for ingresses not reconciled
+	// since the controller started, whose records are looked up in every hosted zone once.
+	ownedZones map[types.NamespacedName]sets.String
+}
+
+func (c *defaultController) Reconcile(ctx context.Context, ingress *extensions.Ingress, lbIngresses []*extensions.Ingress, lbInfos []*lb.LoadBalancer) error {
+	ingressKey := k8s.NamespacedName(ingress)
+	ingressAnnos, err := c.store.GetIngressAnnotations(ingressKey.String())
+	if err != nil {
+		return err
+	}
+	recordTypes := []string{route53.RRTypeA}
+	if aws.StringValue(ingressAnnos.LoadBalancer.IPAddressType) == elbv2.IpAddressTypeDualstack {
+		recordTypes = append(recordTypes, route53.RRTypeAaaa)
+	}
+	privateZone := aws.StringValue(ingressAnnos.LoadBalancer.Scheme) == elbv2.LoadBalancerSchemeEnumInternal
+
+	zones, err := c.listHostedZones(ctx)
+	if err != nil {
+		return err
+	}
+	desired := make(map[string][]*route53.ResourceRecordSet)
+	for i, lbIngress := range lbIngresses {
+		for _, host := range ingressHosts(lbIngress) {
+			hostZones := matchHostedZones(zones, host, privateZone)
+			if len(hostZones) == 0 {
+				albctx.GetEventf(ctx)(corev1.EventTypeWarning, "ERROR", "no hosted zone found for host %v", host)
+				continue
+			}
+			for _, zone := range hostZones {
+				zoneID := hostedZoneID(zone)
+				desired[zoneID] = append(desired[zoneID], buildRecordSets(ingressKey, host, recordTypes, lbInfos[i])...)
+			}
+		}
+	}
+
+	zoneIDs := c.getOwnedZones(ingressKey, zones)
+	for zoneID := range desired {
+		zoneIDs.Insert(zoneID)
+	}
+	return c.reconcileHostedZones(ctx, ingressKey, zoneIDs, desired)
+}
+
+func (c *defaultController) Delete(ctx context.Context, ingressKey types.NamespacedName) error {
+	zones, err := c.listHostedZones(ctx)
+	if err != nil {
+		return err
+	}
+	if err := c.reconcileHostedZones(ctx, ingressKey, c.getOwnedZones(ingressKey, zones), nil); err != nil {
+		return err
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	delete(c.ownedZones, ingressKey)
+	return nil
+}
+
+// reconcileHostedZones turns the records owned by ingress in each of zoneIDs into the desired ones of the zone,
+// and keeps track of the zones that still have records owned by ingress.
+func (c *defaultController) reconcileHostedZones(ctx context.Context, ingressKey types.NamespacedName, zoneIDs sets.String, desired map[string][]*route53.ResourceRecordSet) error {
+	owned := sets.NewString(zoneIDs.List()...)
+	defer func() {
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+		c.ownedZones[ingressKey] = owned
+	}()
+	for _, zoneID := range zoneIDs.List() {
+		owns, err := c.reconcileHostedZone(ctx, ingressKey, zoneID, desired[zoneID])
+		if err != nil {
+			return err
+		}
+		if !owns {
+			owned.Delete(zoneID)
+		}
+	}
+	return nil
+}
+
+// getOwnedZones returns the IDs of zones with records owned by ingress, or of every zone if they're unknown.
+// Zones that no longer exist are left out.
+func (c *defaultController) getOwnedZones(ingressKey types.NamespacedName, zones []*route53.HostedZone) sets.String {
+	c.mutex.Lock()
+	owned, known := c.ownedZones[ingressKey]
+	c.mutex.Unlock()
+	zoneIDs := sets.NewString()
+	for _, zone := range zones {
+		if zoneID := hostedZoneID(zone); !known || owned.Has(zoneID) {
+			zoneIDs.Insert(zoneID)
+		}
+	}
+	return zoneIDs
+}
+
+// listHostedZones returns the hosted zones of the account, which are cached for hostedZonesCacheTTL.
+func (c *defaultController) listHostedZones(ctx context.Context) ([]*route53.HostedZone, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.zones != nil && c.now().Before(c.zonesExpiry) {
+		return c.zones, nil
+	}
+	zones, err := c.cloud.ListHostedZones(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list hosted zones due to %w", err)
+	}
+	c.zones = zones
+	c.zonesExpiry = c.now().Add(hostedZonesCacheTTL)
+	return zones, nil
+}
+
+// reconcileHostedZone turns the records owned by ingress in hostedZoneID into desired ones, and returns whether ingress still owns any.
+// Desired records of hosts that already have records not owned by ingress are skipped.
+func (c *defaultController) reconcileHostedZone(ctx context.Context, ingressKey types.NamespacedName, hostedZoneID string, desired []*route53.ResourceRecordSet) (bool, error) {
+	recordSets, err := c.cloud.ListAllResourceRecordSets(ctx, hostedZoneID)
+	if err != nil {
+		return false, fmt.Errorf("failed to list record sets in %v due to %w", hostedZoneID, err)
+	}
+	owners := recordOwners(recordSets)
+	ownerValue := ownerRecordValue(ingressKey)
+
+	var current []*route53.ResourceRecordSet
+	occupied := make(map[string]bool)
+	for _, recordSet := range recordSets {
+		host := recordHost(recordSet)
+		if owners[host] == ownerValue {
+			current = append(current, recordSet)
+		} else if host != "" {
+			occupied[host] = true
+		}
+	}
+
+	var accepted []*route53.ResourceRecordSet
+	conflicts := make(map[string]bool)
+	for _, recordSet := range desired {
+		host := recordHost(recordSet)
+		if owners[host] == ownerValue || !occupied[host] {
+			accepted = append(accepted, recordSet)
+			continue
+		}
+		if !conflicts[host] {
+			conflicts[host] = true
+			albctx.GetEventf(ctx)(corev1.EventTypeWarning, "ERROR", "records of host %v in %v are not owned by this ingress, skipping them", host, hostedZoneID)
+		}
+	}
+	if err := c.changeRecordSets(ctx, hostedZoneID, recordSetsChangeSet(current, accepted)); err != nil {
+		return true, err
+	}
+	return len(accepted) != 0, nil
+}
+
+func (c *defaultController) changeRecordSets(ctx context.Context, hostedZoneID string, changes []*route53.Change) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	albctx.GetLogger(ctx).Infof("changing DNS records in %v: %v", hostedZoneID, log.Prettify(changes))
+	if _, err := c.cloud.ChangeResourceRecordSetsWithContext(ctx, &route53.ChangeResourceRecordSetsInput{
+		HostedZoneId: aws.String(hostedZoneID),
+		ChangeBatch: &route53.ChangeBatch{
+			Changes: changes,
+		},
+	}); err != nil {
+		albctx.GetEventf(ctx)(corev1.EventTypeWarning, "ERROR", "failed to change DNS records in %v due to %v", hostedZoneID, err)
+		return fmt.Errorf("failed to change DNS records in %v due to %w", hostedZoneID, err)
+	}
+	albctx.GetEventf(ctx)(corev1.EventTypeNormal, "MODIFY", "DNS records in %v modified", hostedZoneID)
+	return nil
+}
+
+// ingressHosts returns the distinct hosts of the rules of ingress, in their order of appearance.
+func ingressHosts(ingress *extensions.Ingress) []string {
+	var hosts []string
+	seen := make(map[string]bool)
+	for _, rule := range ingress.Spec.Rules {
+		host := canonicalDNSName(rule.Host)
+		if host == "" || seen[host] {
+			continue
+		}
+		seen[host] = true
+		hosts = append(hosts, host)
+	}
+	return hosts
+}
+
+// matchHostedZones returns the public or private hosted zones with the longest name that host belongs to.
+// Multiple zones are returned if private zones of the same name are associated with different VPCs.
+func matchHostedZones(zones []*route53.HostedZone, host string, privateZone bool) []*route53.HostedZone {
+	var matched []*route53.HostedZone
+	longest := 0
+	for _, zone := range zones {
+		if zone.Config != nil && aws.BoolValue(zone.Config.PrivateZone) != privateZone {
+			continue
+		}
+		name := canonicalDNSName(aws.StringValue(zone.Name))
+		if host != name && !strings.HasSuffix(host, "."+name) {
+			continue
+		}
+		if len(name) > longest {
+			matched = nil
+			longest = len(name)
+		}
+		if len(name) == longest {
+			matched = append(matched, zone)
+		}
+	}
+	return matched
+}
+
+// hostedZoneID returns the ID of zone without the `/hostedzone/` prefix returned by Route 53.
+func hostedZoneID(zone *route53.HostedZone) string {
+	return strings.TrimPrefix(aws.StringValue(zone.Id), "/hostedzone/")
+}
+
+// buildRecordSets builds the alias records of host pointing at instance, along with the ownership TXT record of host.
+func buildRecordSets(ingressKey types.NamespacedName, host string, recordTypes []string, instance *lb.LoadBalancer) []*route53.ResourceRecordSet {
+	var recordSets []*route53.ResourceRecordSet
+	for _, recordType := range recordTypes {
+		recordSets = append(recordSets, &route53.ResourceRecordSet{
+			Name: aws.String(host),
+			Type: aws.String(recordType),
+			AliasTarget: &route53.AliasTarget{
+				DNSName:              aws.String(instance.DNSName),
+				HostedZoneId:         aws.String(instance.HostedZoneID),
+				EvaluateTargetHealth: aws.Bool(false),
+			},
+		})
+	}
+	return append(recordSets, &route53.ResourceRecordSet{
+		Name:            aws.String(ownerRecordPrefix + host),
+		Type:            aws.String(route53.RRTypeTxt),
+		TTL:             aws.Int64(ownerRecordTTL),
+		ResourceRecords: []*route53.ResourceRecord{{Value: aws.String(ownerRecordValue(ingressKey))}},
+	})
+}
+
+// ownerRecordValue returns the quoted value of ownership TXT records of ingress.
+func ownerRecordValue(ingressKey types.NamespacedName) string {
+	return fmt.Sprintf(`"%v,ingress=%v"`, ownerHeritage, ingressKey)
+}
+
+// recordOwners returns the values of ownership TXT records by the host they belong to.
+func recordOwners(recordSets []*route53.ResourceRecordSet) map[string]string {
+	owners := make(map[string]string)
+	for _, recordSet := range recordSets {
+		name := canonicalDNSName(aws.StringValue(recordSet.Name))
+		if aws.StringValue(recordSet.Type) != route53.RRTypeTxt || !strings.HasPrefix(name, ownerRecordPrefix) {
+			continue
+		}
+		for _, record := range recordSet.ResourceRecords {
+			if value := aws.StringValue(record.Value); strings.HasPrefix(value, `"`+ownerHeritage+",") {
+				owners[strings.TrimPrefix(name, ownerRecordPrefix)] = value
+			}
+		}
+	}
+	return owners
+}
+
+// recordHost returns the host that recordSet is managed for, which is empty unless it's an alias, CNAME or ownership record.
+func recordHost(recordSet *route53.ResourceRecordSet) string {
+	name := canonicalDNSName(aws.StringValue(recordSet.Name))
+	switch aws.StringValue(recordSet.Type) {
+	case route53.RRTypeA, route53.RRTypeAaaa, route53.RRTypeCname:
+		return name
+	case route53.RRTypeTxt:
+		if strings.HasPrefix(name, ownerRecordPrefix) {
+			return strings.TrimPrefix(name, ownerRecordPrefix)
+		}
+	}
+	return ""
+}
+
+// recordSetsChangeSet computes the changes that turns current record sets into desired ones.
+func recordSetsChangeSet(current []*route53.ResourceRecordSet, desired []*route53.ResourceRecordSet) []*route53.Change {
+	var changes []*route53.Change
+	for _, desiredRecordSet := range desired {
+		upToDate := false
+		for _, currentRecordSet := range current {
+			if recordSetMatches(desiredRecordSet, currentRecordSet) {
+				upToDate = true
+				break
+			}
+		}
+		if !upToDate {
+			changes = append(changes, &route53.Change{
+				Action:            aws.String(route53.ChangeActionUpsert),
+				ResourceRecordSet: desiredRecordSet,
+			})
+		}
+	}
+	for _, currentRecordSet := range current {
+		wanted := false
+		for _, desiredRecordSet := range desired {
+			if recordSetKey(desiredRecordSet) == recordSetKey(currentRecordSet) {
+				wanted = true
+				break
+			}
+		}
+		if !wanted {
+			changes = append(changes, &route53.Change{
+				Action:            aws.String(route53.ChangeActionDelete),
+				ResourceRecordSet: currentRecordSet,
+			})
+		}
+	}
+	return changes
+}
+
+func recordSetKey(recordSet *route53.ResourceRecordSet) string {
+	return aws.StringValue(recordSet.Type) + "/" + canonicalDNSName(aws.StringValue(recordSet.Name))
+}
+
+func recordSetMatches(desired *route53.ResourceRecordSet, current *route53.ResourceRecordSet) bool {
+	if recordSetKey(desired) != recordSetKey(current) {
+		return false
+	}
+	if desired.AliasTarget == nil {
+		return current.AliasTarget == nil && len(current.ResourceRecords) == 1 &&
+			aws.StringValue(desired.ResourceRecords[0].Value) == aws.StringValue(current.ResourceRecords[0].Value)
+	}
+	if current.AliasTarget == nil {
+		return false
+	}
+	return canonicalDNSName(aws.StringValue(desired.AliasTarget.DNSName)) == canonicalDNSName(aws.StringValue(current.AliasTarget.DNSName)) &&
+		aws.StringValue(desired.AliasTarget.HostedZoneId) == aws.StringValue(current.AliasTarget.HostedZoneId)
+}
+
+// canonicalDNSName returns the lower cased DNS name without trailing dot.
+// Route 53 returns asterisks of wildcard names in their octal escaped form.
+func canonicalDNSName(name string) string {
+	name = strings.Replace(name, `\052`, "*", -1)
+	return strings.TrimSuffix(strings.ToLower(name), ".")
+}
@@ -0,0 +1,147 @@
+package dns
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/lb"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
+	"github.com/stretchr/testify/assert"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var (
+	ingressKey = types.NamespacedName{Namespace: "ns", Name: "ingress"}
+	instance   = &lb.LoadBalancer{DNSName: "ingress-1.us-west-2.elb.amazonaws.com", HostedZoneID: "Z1H1FL5HABSF5"}
+)
+
+func Test_matchHostedZones(t *testing.T) {
+	public := &route53.HostedZone{Id: aws.String("/hostedzone/Z1"), Name: aws.String("example.com."), Config: &route53.HostedZoneConfig{PrivateZone: aws.Bool(false)}}
+	publicSub := &route53.HostedZone{Id: aws.String("/hostedzone/Z2"), Name: aws.String("app.example.com."), Config: &route53.HostedZoneConfig{PrivateZone: aws.Bool(false)}}
+	private := &route53.HostedZone{Id: aws.String("/hostedzone/Z3"), Name: aws.String("example.com."), Config: &route53.HostedZoneConfig{PrivateZone: aws.Bool(true)}}
+	zones := []*route53.HostedZone{public, publicSub, private}
+
+	for _, tc := range []struct {
+		Name        string
+		Host        string
+		PrivateZone bool
+		Expected    []*route53.HostedZone
+	}{
+		{
+			Name:     "longest zone name wins",
+			Host:     "api.app.example.com",
+			Expected: []*route53.HostedZone{publicSub},
+		},
+		{
+			Name:     "apex of zone",
+			Host:     "example.com",
+			Expected: []*route53.HostedZone{public},
+		},
+		{
+			Name:        "private zone of internal LoadBalancer",
+			Host:        "app.example.com",
+			PrivateZone: true,
+			Expected:    []*route53.HostedZone{private},
+		},
+		{
+			Name: "no zone for host",
+			Host: "myexample.com",
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			assert.Equal(t, tc.Expected, matchHostedZones(zones, tc.Host, tc.PrivateZone))
+		})
+	}
+}
+
+func Test_reconcileHostedZone(t *testing.T) {
+	owned := buildRecordSets(ingressKey, "owned.example.com", []string{route53.RRTypeA}, &lb.LoadBalancer{DNSName: "old-1.us-west-2.elb.amazonaws.com", HostedZoneID: "Z1H1FL5HABSF5"})
+	stale := buildRecordSets(ingressKey, "stale.example.com", []string{route53.RRTypeA}, instance)
+	foreign := buildRecordSets(types.NamespacedName{Namespace: "ns", Name: "other"}, "foreign.example.com", []string{route53.RRTypeA}, instance)
+	unmanaged := &route53.ResourceRecordSet{
+		Name:            aws.String("unmanaged.example.com."),
+		Type:            aws.String(route53.RRTypeCname),
+		ResourceRecords: []*route53.ResourceRecord{{Value: aws.String("legacy.example.com")}},
+	}
+	// Route 53 returns fully qualified names, with wildcards escaped.
+	wildcard := buildRecordSets(ingressKey, "*.example.com", []string{route53.RRTypeA}, instance)
+	wildcard[0].Name = aws.String(`\052.example.com.`)
+	wildcard[1].Name = aws.String(`_alb-owner.\052.example.com.`)
+
+	current := []*route53.ResourceRecordSet{unmanaged}
+	for _, recordSets := range [][]*route53.ResourceRecordSet{owned, stale, foreign, wildcard} {
+		current = append(current, recordSets...)
+	}
+	var desired []*route53.ResourceRecordSet
+	for _, host := range []string{"owned.example.com", "foreign.example.com", "unmanaged.example.com", "new.example.com", "*.example.com"} {
+		desired = append(desired, buildRecordSets(ingressKey, host, []string{route53.RRTypeA}, instance)...)
+	}
+
+	ctx := context.Background()
+	cloud := &mocks.CloudAPI{}
+	cloud.On("ListAllResourceRecordSets", ctx, "Z1").Return(current, nil)
+	cloud.On("ChangeResourceRecordSetsWithContext", ctx, &route53.ChangeResourceRecordSetsInput{
+		HostedZoneId: aws.String("Z1"),
+		ChangeBatch: &route53.ChangeBatch{
+			Changes: []*route53.Change{
+				{Action: aws.String(route53.ChangeActionUpsert), ResourceRecordSet: desired[0]},
+				{Action: aws.String(route53.ChangeActionUpsert), ResourceRecordSet: desired[6]},
+				{Action: aws.String(route53.ChangeActionUpsert), ResourceRecordSet: desired[7]},
+				{Action: aws.String(route53.ChangeActionDelete), ResourceRecordSet: stale[0]},
+				{Action: aws.String(route53.ChangeActionDelete), ResourceRecordSet: stale[1]},
+			},
+		},
+	}).Return(&route53.ChangeResourceRecordSetsOutput{}, nil)
+
+	c := &defaultController{cloud: cloud}
+	owns, err := c.reconcileHostedZone(ctx, ingressKey, "Z1", desired)
+	assert.NoError(t, err)
+	assert.True(t, owns)
+	cloud.AssertExpectations(t)
+}
+
+func Test_defaultController_Reconcile(t *testing.T) {
+	ctx := context.Background()
+	zones := []*route53.HostedZone{
+		{Id: aws.String("/hostedzone/Z1"), Name: aws.String("example.com."), Config: &route53.HostedZoneConfig{PrivateZone: aws.Bool(false)}},
+		{Id: aws.String("/hostedzone/Z2"), Name: aws.String("example.org."), Config: &route53.HostedZoneConfig{PrivateZone: aws.Bool(false)}},
+		{Id: aws.String("/hostedzone/Z3"), Name: aws.String("example.net."), Config: &route53.HostedZoneConfig{PrivateZone: aws.Bool(false)}},
+	}
+	ingress := &extensions.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Namespace: ingressKey.Namespace, Name: ingressKey.Name},
+		Spec: extensions.IngressSpec{
+			Rules: []extensions.IngressRule{{Host: "app.example.com"}},
+		},
+	}
+	current := buildRecordSets(ingressKey, "app.example.com", []string{route53.RRTypeA}, instance)
+	stale := buildRecordSets(ingressKey, "app.example.org", []string{route53.RRTypeA}, instance)
+
+	cloud := &mocks.CloudAPI{}
+	cloud.On("ListHostedZones", ctx).Return(zones, nil).Once()
+	cloud.On("ListAllResourceRecordSets", ctx, "Z1").Return(current, nil).Twice()
+	cloud.On("ListAllResourceRecordSets", ctx, "Z2").Return(stale, nil).Once()
+	cloud.On("ListAllResourceRecordSets", ctx, "Z3").Return(nil, nil).Once()
+	cloud.On("ChangeResourceRecordSetsWithContext", ctx, &route53.ChangeResourceRecordSetsInput{
+		HostedZoneId: aws.String("Z2"),
+		ChangeBatch: &route53.ChangeBatch{
+			Changes: []*route53.Change{
+				{Action: aws.String(route53.ChangeActionDelete), ResourceRecordSet: stale[0]},
+				{Action: aws.String(route53.ChangeActionDelete), ResourceRecordSet: stale[1]},
+			},
+		},
+	}).Return(&route53.ChangeResourceRecordSetsOutput{}, nil)
+
+	store := store.NewDummy()
+	store.GetIngressAnnotationsResponse.LoadBalancer.Scheme = aws.String(elbv2.LoadBalancerSchemeEnumInternetFacing)
+	c := NewController(cloud, store)
+	// the first reconcile looks up records of the ingress in every zone, the next only in zones it owns records in.
+	assert.NoError(t, c.Reconcile(ctx, ingress, []*extensions.Ingress{ingress}, []*lb.LoadBalancer{instance}))
+	assert.NoError(t, c.Reconcile(ctx, ingress, []*extensions.Ingress{ingress}, []*lb.LoadBalancer{instance}))
+	cloud.AssertExpectations(t)
+}
@@ -0,0 +1,271 @@
+package failover
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/lb"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/shard"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/k8s"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/log"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	setIdentifierSuffixPrimary   = "-primary"
+	setIdentifierSuffixSecondary = "-secondary"
+)
+
+// Controller manages the secondary LoadBalancer and the Route 53 failover records of ingresses with failover enabled.
+type Controller interface {
+	// Reconcile ensures the secondary LoadBalancer and failover records exists if failover is enabled for ingress, or are removed otherwise.
+	Reconcile(ctx context.Context, ingress *extensions.Ingress, primary *lb.LoadBalancer) error
+
+	// Delete ensures the secondary LoadBalancer and failover records of ingress are removed.
+	Delete(ctx context.Context, ingressKey types.NamespacedName) error
+}
+
+func NewController(cloud aws.CloudAPI, store store.Storer, nameGen lb.NameGenerator, lbController lb.Controller) Controller {
+	return &defaultController{
+		cloud:        cloud,
+		store:        store,
+		nameGen:      nameGen,
+		lbController: lbController,
+	}
+}
+
+type defaultController struct {
+	cloud        aws.CloudAPI
+	store        store.Storer
+	nameGen      lb.NameGenerator
+	lbController lb.Controller
+}
+
+func (c *defaultController) Reconcile(ctx context.Context, ingress *extensions.Ingress, primary *lb.LoadBalancer) error {
+	ingressKey := k8s.NamespacedName(ingress)
+	ingressAnnos, err := c.store.GetIngressAnnotations(ingressKey.String())
+	if err != nil {
+		return err
+	}
+	failoverCfg := ingressAnnos.LoadBalancer.Failover
+	if failoverCfg == nil {
+		return c.Delete(ctx, ingressKey)
+	}
+
+	// the LoadBalancer named after the secondary ALB is only taken over if it's tagged as the secondary ALB.
+	if instance, hostedZoneID, _, err := c.findSecondary(ctx, ingressKey); err != nil {
+		return err
+	} else if instance != nil && hostedZoneID == "" {
+		return fmt.Errorf("LoadBalancer %v isn't tagged with %v, it's not the secondary LoadBalancer of ingress", aws.StringValue(instance.LoadBalancerName), lb.TagKeyFailoverRecord)
+	}
+	secondaryIngress := ingress.DeepCopy()
+	secondaryIngress.Name = shard.FailoverName(ingress.Name)
+	secondary, err := c.lbController.Reconcile(ctx, secondaryIngress)
+	if err != nil {
+		return errors.Wrap(err, "failed to reconcile secondary LoadBalancer")
+	}
+
+	recordTypes := []string{route53.RRTypeA}
+	if aws.StringValue(ingressAnnos.LoadBalancer.IPAddressType) == elbv2.IpAddressTypeDualstack {
+		recordTypes = append(recordTypes, route53.RRTypeAaaa)
+	}
+	desired := buildRecordSets(ingressKey, failoverCfg.RecordName, recordTypes, primary, secondary)
+	current, err := c.getCurrentRecordSets(ctx, ingressKey, failoverCfg.HostedZoneID, failoverCfg.RecordName)
+	if err != nil {
+		return err
+	}
+	return c.changeRecordSets(ctx, failoverCfg.HostedZoneID, recordSetsChangeSet(current, desired))
+}
+
+func (c *defaultController) Delete(ctx context.Context, ingressKey types.NamespacedName) error {
+	instance, hostedZoneID, recordName, err := c.findSecondary(ctx, ingressKey)
+	if err != nil {
+		return err
+	}
+	if instance == nil {
+		return nil
+	}
+	if hostedZoneID == "" {
+		albctx.GetLogger(ctx).Warnf("LoadBalancer %v isn't tagged with %v, it's not the secondary LoadBalancer of ingress and is left untouched",
+			aws.StringValue(instance.LoadBalancerName), lb.TagKeyFailoverRecord)
+		return nil
+	}
+
+	current, err := c.getCurrentRecordSets(ctx, ingressKey, hostedZoneID, recordName)
+	if err != nil {
+		return err
+	}
+	if err := c.changeRecordSets(ctx, hostedZoneID, recordSetsChangeSet(current, nil)); err != nil {
+		return err
+	}
+	if err := c.lbController.Delete(ctx, shard.FailoverKey(ingressKey)); err != nil {
+		return errors.Wrap(err, "failed to delete secondary LoadBalancer")
+	}
+	return nil
+}
+
+// findSecondary returns the LoadBalancer named after the secondary ALB of ingress, and the failover records tagged on it.
+// The LoadBalancer is the secondary ALB only if it's tagged with the failover records, hostedZoneID is empty otherwise.
+func (c *defaultController) findSecondary(ctx context.Context, ingressKey types.NamespacedName) (*elbv2.LoadBalancer, string, string, error) {
+	failoverKey := shard.FailoverKey(ingressKey)
+	instance, err := c.cloud.GetLoadBalancerByName(ctx, c.nameGen.NameLB(failoverKey.Namespace, failoverKey.Name))
+	if err != nil {
+		return nil, "", "", fmt.Errorf("failed to find existing secondary LoadBalancer due to %w", err)
+	}
+	if instance == nil {
+		return nil, "", "", nil
+	}
+	hostedZoneID, recordName, err := c.getFailoverRecord(ctx, aws.StringValue(instance.LoadBalancerArn))
+	if err != nil {
+		return nil, "", "", err
+	}
+	return instance, hostedZoneID, recordName, nil
+}
+
+// getFailoverRecord returns the hostedZoneID and recordName of failover records tagged on secondary LoadBalancer.
+func (c *defaultController) getFailoverRecord(ctx context.Context, lbArn string) (string, string, error) {
+	resp, err := c.cloud.DescribeELBV2TagsWithContext(ctx, &elbv2.DescribeTagsInput{
+		ResourceArns: aws.StringSlice([]string{lbArn}),
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("failed to describe tags of %v due to %w", lbArn, err)
+	}
+	for _, tagDescription := range resp.TagDescriptions {
+		for _, tag := range tagDescription.Tags {
+			if aws.StringValue(tag.Key) != lb.TagKeyFailoverRecord {
+				continue
+			}
+			parts := strings.SplitN(aws.StringValue(tag.Value), "/", 2)
+			if len(parts) != 2 {
+				return "", "", fmt.Errorf("invalid %v tag on %v: %v", lb.TagKeyFailoverRecord, lbArn, aws.StringValue(tag.Value))
+			}
+			return parts[0], parts[1], nil
+		}
+	}
+	return "", "", nil
+}
+
+// getCurrentRecordSets returns the failover record sets of recordName that are owned by ingress.
+func (c *defaultController) getCurrentRecordSets(ctx context.Context, ingressKey types.NamespacedName, hostedZoneID string, recordName string) ([]*route53.ResourceRecordSet, error) {
+	recordSets, err := c.cloud.GetResourceRecordSets(ctx, hostedZoneID, recordName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list record sets of %v in %v due to %w", recordName, hostedZoneID, err)
+	}
+	var owned []*route53.ResourceRecordSet
+	for _, recordSet := range recordSets {
+		setIdentifier := aws.StringValue(recordSet.SetIdentifier)
+		if setIdentifier == ingressKey.String()+setIdentifierSuffixPrimary || setIdentifier == ingressKey.String()+setIdentifierSuffixSecondary {
+			owned = append(owned, recordSet)
+		}
+	}
+	return owned, nil
+}
+
+func (c *defaultController) changeRecordSets(ctx context.Context, hostedZoneID string, changes []*route53.Change) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	albctx.GetLogger(ctx).Infof("changing failover records in %v: %v", hostedZoneID, log.Prettify(changes))
+	if _, err := c.cloud.ChangeResourceRecordSetsWithContext(ctx, &route53.ChangeResourceRecordSetsInput{
+		HostedZoneId: aws.String(hostedZoneID),
+		ChangeBatch: &route53.ChangeBatch{
+			Changes: changes,
+		},
+	}); err != nil {
+		albctx.GetEventf(ctx)(corev1.EventTypeWarning, "ERROR", "failed to change failover records in %v due to %v", hostedZoneID, err)
+		return fmt.Errorf("failed to change failover records in %v due to %w", hostedZoneID, err)
+	}
+	albctx.GetEventf(ctx)(corev1.EventTypeNormal, "MODIFY", "failover records in %v modified", hostedZoneID)
+	return nil
+}
+
+// buildRecordSets builds the alias record sets that fail over from primary to secondary LoadBalancer.
+// Route 53 evaluates the health of the aliased LoadBalancers, which is unhealthy when none of its targets are healthy.
+func buildRecordSets(ingressKey types.NamespacedName, recordName string, recordTypes []string, primary *lb.LoadBalancer, secondary *lb.LoadBalancer) []*route53.ResourceRecordSet {
+	var recordSets []*route53.ResourceRecordSet
+	for _, recordType := range recordTypes {
+		recordSets = append(recordSets,
+			buildRecordSet(recordName, recordType, ingressKey.String()+setIdentifierSuffixPrimary, route53.ResourceRecordSetFailoverPrimary, primary),
+			buildRecordSet(recordName, recordType, ingressKey.String()+setIdentifierSuffixSecondary, route53.ResourceRecordSetFailoverSecondary, secondary))
+	}
+	return recordSets
+}
+
+func buildRecordSet(recordName string, recordType string, setIdentifier string, failover string, instance *lb.LoadBalancer) *route53.ResourceRecordSet {
+	return &route53.ResourceRecordSet{
+		Name:          aws.String(recordName),
+		Type:          aws.String(recordType),
+		SetIdentifier: aws.String(setIdentifier),
+		Failover:      aws.String(failover),
+		AliasTarget: &route53.AliasTarget{
+			DNSName:              aws.String(instance.DNSName),
+			HostedZoneId:         aws.String(instance.HostedZoneID),
+			EvaluateTargetHealth: aws.Bool(true),
+		},
+	}
+}
+
+// recordSetsChangeSet computes the changes that turns current record sets into desired ones.
+func recordSetsChangeSet(current []*route53.ResourceRecordSet, desired []*route53.ResourceRecordSet) []*route53.Change {
+	var changes []*route53.Change
+	for _, desiredRecordSet := range desired {
+		upToDate := false
+		for _, currentRecordSet := range current {
+			if recordSetMatches(desiredRecordSet, currentRecordSet) {
+				upToDate = true
+				break
+			}
+		}
+		if !upToDate {
+			changes = append(changes, &route53.Change{
+				Action:            aws.String(route53.ChangeActionUpsert),
+				ResourceRecordSet: desiredRecordSet,
+			})
+		}
+	}
+	for _, currentRecordSet := range current {
+		wanted := false
+		for _, desiredRecordSet := range desired {
+			if recordSetKey(desiredRecordSet) == recordSetKey(currentRecordSet) {
+				wanted = true
+				break
+			}
+		}
+		if !wanted {
+			changes = append(changes, &route53.Change{
+				Action:            aws.String(route53.ChangeActionDelete),
+				ResourceRecordSet: currentRecordSet,
+			})
+		}
+	}
+	return changes
+}
+
+func recordSetKey(recordSet *route53.ResourceRecordSet) string {
+	return aws.StringValue(recordSet.Type) + "/" + aws.StringValue(recordSet.SetIdentifier)
+}
+
+func recordSetMatches(desired *route53.ResourceRecordSet, current *route53.ResourceRecordSet) bool {
+	if recordSetKey(desired) != recordSetKey(current) ||
+		aws.StringValue(desired.Failover) != aws.StringValue(current.Failover) ||
+		current.AliasTarget == nil {
+		return false
+	}
+	return canonicalDNSName(aws.StringValue(desired.AliasTarget.DNSName)) == canonicalDNSName(aws.StringValue(current.AliasTarget.DNSName)) &&
+		aws.StringValue(desired.AliasTarget.HostedZoneId) == aws.StringValue(current.AliasTarget.HostedZoneId) &&
+		aws.BoolValue(desired.AliasTarget.EvaluateTargetHealth) == aws.BoolValue(current.AliasTarget.EvaluateTargetHealth)
+}
+
+// canonicalDNSName returns the lower cased DNS name without trailing dot.
+func canonicalDNSName(name string) string {
+	return strings.TrimSuffix(strings.ToLower(name), ".")
+}
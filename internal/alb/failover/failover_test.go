@@ -0,0 +1,238 @@
+package failover
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/lb"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/loadbalancer"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var (
+	ingressKey = types.NamespacedName{Namespace: "ns", Name: "ingress"}
+	primary    = &lb.LoadBalancer{DNSName: "primary-1.us-west-2.elb.amazonaws.com", HostedZoneID: "Z1H1FL5HABSF5"}
+	secondary  = &lb.LoadBalancer{DNSName: "secondary-1.us-west-2.elb.amazonaws.com", HostedZoneID: "Z1H1FL5HABSF5"}
+)
+
+func Test_buildRecordSets(t *testing.T) {
+	recordSets := buildRecordSets(ingressKey, "app.example.com", []string{route53.RRTypeA}, primary, secondary)
+	assert.Equal(t, []*route53.ResourceRecordSet{
+		{
+			Name:          aws.String("app.example.com"),
+			Type:          aws.String(route53.RRTypeA),
+			SetIdentifier: aws.String("ns/ingress-primary"),
+			Failover:      aws.String(route53.ResourceRecordSetFailoverPrimary),
+			AliasTarget: &route53.AliasTarget{
+				DNSName:              aws.String("primary-1.us-west-2.elb.amazonaws.com"),
+				HostedZoneId:         aws.String("Z1H1FL5HABSF5"),
+				EvaluateTargetHealth: aws.Bool(true),
+			},
+		},
+		{
+			Name:          aws.String("app.example.com"),
+			Type:          aws.String(route53.RRTypeA),
+			SetIdentifier: aws.String("ns/ingress-secondary"),
+			Failover:      aws.String(route53.ResourceRecordSetFailoverSecondary),
+			AliasTarget: &route53.AliasTarget{
+				DNSName:              aws.String("secondary-1.us-west-2.elb.amazonaws.com"),
+				HostedZoneId:         aws.String("Z1H1FL5HABSF5"),
+				EvaluateTargetHealth: aws.Bool(true),
+			},
+		},
+	}, recordSets)
+}
+
+func Test_recordSetsChangeSet(t *testing.T) {
+	desired := buildRecordSets(ingressKey, "app.example.com", []string{route53.RRTypeA}, primary, secondary)
+
+	// Route 53 returns fully qualified, lower cased names.
+	currentPrimary := buildRecordSet("app.example.com.", route53.RRTypeA, "ns/ingress-primary", route53.ResourceRecordSetFailoverPrimary,
+		&lb.LoadBalancer{DNSName: "Primary-1.us-west-2.elb.amazonaws.com.", HostedZoneID: "Z1H1FL5HABSF5"})
+	staleSecondary := buildRecordSet("app.example.com.", route53.RRTypeA, "ns/ingress-secondary", route53.ResourceRecordSetFailoverSecondary,
+		&lb.LoadBalancer{DNSName: "old-1.us-west-2.elb.amazonaws.com.", HostedZoneID: "Z1H1FL5HABSF5"})
+	unwantedAAAA := buildRecordSet("app.example.com.", route53.RRTypeAaaa, "ns/ingress-primary", route53.ResourceRecordSetFailoverPrimary, primary)
+
+	for _, tc := range []struct {
+		Name            string
+		Current         []*route53.ResourceRecordSet
+		Desired         []*route53.ResourceRecordSet
+		ExpectedChanges []*route53.Change
+	}{
+		{
+			Name:    "create records",
+			Current: nil,
+			Desired: desired,
+			ExpectedChanges: []*route53.Change{
+				{Action: aws.String(route53.ChangeActionUpsert), ResourceRecordSet: desired[0]},
+				{Action: aws.String(route53.ChangeActionUpsert), ResourceRecordSet: desired[1]},
+			},
+		},
+		{
+			Name:    "update stale records and delete unwanted ones",
+			Current: []*route53.ResourceRecordSet{currentPrimary, staleSecondary, unwantedAAAA},
+			Desired: desired,
+			ExpectedChanges: []*route53.Change{
+				{Action: aws.String(route53.ChangeActionUpsert), ResourceRecordSet: desired[1]},
+				{Action: aws.String(route53.ChangeActionDelete), ResourceRecordSet: unwantedAAAA},
+			},
+		},
+		{
+			Name:    "delete records",
+			Current: []*route53.ResourceRecordSet{currentPrimary},
+			Desired: nil,
+			ExpectedChanges: []*route53.Change{
+				{Action: aws.String(route53.ChangeActionDelete), ResourceRecordSet: currentPrimary},
+			},
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			assert.Equal(t, tc.ExpectedChanges, recordSetsChangeSet(tc.Current, tc.Desired))
+		})
+	}
+}
+
+func Test_getFailoverRecord(t *testing.T) {
+	for _, tc := range []struct {
+		Name                 string
+		Tags                 []*elbv2.Tag
+		ExpectedHostedZoneID string
+		ExpectedRecordName   string
+		ExpectedError        bool
+	}{
+		{
+			Name: "tagged",
+			Tags: []*elbv2.Tag{
+				{Key: aws.String("k"), Value: aws.String("v")},
+				{Key: aws.String(lb.TagKeyFailoverRecord), Value: aws.String("Z123/app.example.com")},
+			},
+			ExpectedHostedZoneID: "Z123",
+			ExpectedRecordName:   "app.example.com",
+		},
+		{
+			Name: "untagged",
+			Tags: []*elbv2.Tag{{Key: aws.String("k"), Value: aws.String("v")}},
+		},
+		{
+			Name:          "invalid tag",
+			Tags:          []*elbv2.Tag{{Key: aws.String(lb.TagKeyFailoverRecord), Value: aws.String("Z123")}},
+			ExpectedError: true,
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			ctx := context.Background()
+			cloud := &mocks.CloudAPI{}
+			cloud.On("DescribeELBV2TagsWithContext", ctx, &elbv2.DescribeTagsInput{
+				ResourceArns: aws.StringSlice([]string{"lbArn"}),
+			}).Return(&elbv2.DescribeTagsOutput{
+				TagDescriptions: []*elbv2.TagDescription{{ResourceArn: aws.String("lbArn"), Tags: tc.Tags}},
+			}, nil)
+
+			c := &defaultController{cloud: cloud}
+			hostedZoneID, recordName, err := c.getFailoverRecord(ctx, "lbArn")
+			assert.Equal(t, tc.ExpectedError, err != nil)
+			assert.Equal(t, tc.ExpectedHostedZoneID, hostedZoneID)
+			assert.Equal(t, tc.ExpectedRecordName, recordName)
+			cloud.AssertExpectations(t)
+		})
+	}
+}
+
+type nameGen struct{}
+
+func (nameGen) NameLB(namespace string, ingressName string) string {
+	return namespace + "-" + ingressName
+}
+
+// fakeLBController records the LoadBalancers reconciled and deleted.
+type fakeLBController struct {
+	reconciled []string
+	deleted    []types.NamespacedName
+}
+
+func (c *fakeLBController) Reconcile(ctx context.Context, ingress *extensions.Ingress) (*lb.LoadBalancer, error) {
+	c.reconciled = append(c.reconciled, ingress.Name)
+	return secondary, nil
+}
+
+func (c *fakeLBController) Delete(ctx context.Context, ingressKey types.NamespacedName) error {
+	c.deleted = append(c.deleted, ingressKey)
+	return nil
+}
+
+func mockSecondary(cloud *mocks.CloudAPI, tags []*elbv2.Tag) {
+	cloud.On("GetLoadBalancerByName", mock.Anything, "ns-ingress.failover").Return(&elbv2.LoadBalancer{
+		LoadBalancerArn:  aws.String("lbArn"),
+		LoadBalancerName: aws.String("ns-ingress.failover"),
+	}, nil)
+	cloud.On("DescribeELBV2TagsWithContext", mock.Anything, &elbv2.DescribeTagsInput{
+		ResourceArns: aws.StringSlice([]string{"lbArn"}),
+	}).Return(&elbv2.DescribeTagsOutput{
+		TagDescriptions: []*elbv2.TagDescription{{ResourceArn: aws.String("lbArn"), Tags: tags}},
+	}, nil)
+}
+
+func TestDefaultController_Reconcile(t *testing.T) {
+	ingress := &extensions.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "ingress"}}
+	dummyStore := store.NewDummy()
+	dummyStore.GetIngressAnnotationsResponse.LoadBalancer = &loadbalancer.Config{
+		Failover: &loadbalancer.FailoverConfig{HostedZoneID: "Z123", RecordName: "app.example.com"},
+	}
+
+	t.Run("secondary LoadBalancer tagged with the failover records", func(t *testing.T) {
+		cloud := &mocks.CloudAPI{}
+		mockSecondary(cloud, []*elbv2.Tag{{Key: aws.String(lb.TagKeyFailoverRecord), Value: aws.String("Z123/app.example.com")}})
+		cloud.On("GetResourceRecordSets", mock.Anything, "Z123", "app.example.com").Return(buildRecordSets(ingressKey, "app.example.com", []string{route53.RRTypeA}, primary, secondary), nil)
+		lbController := &fakeLBController{}
+
+		c := NewController(cloud, dummyStore, nameGen{}, lbController)
+		assert.NoError(t, c.Reconcile(context.Background(), ingress, primary))
+		assert.Equal(t, []string{"ingress.failover"}, lbController.reconciled)
+		cloud.AssertExpectations(t)
+	})
+
+	t.Run("untagged LoadBalancer named after the secondary LoadBalancer", func(t *testing.T) {
+		cloud := &mocks.CloudAPI{}
+		mockSecondary(cloud, []*elbv2.Tag{{Key: aws.String("k"), Value: aws.String("v")}})
+		lbController := &fakeLBController{}
+
+		c := NewController(cloud, dummyStore, nameGen{}, lbController)
+		assert.Error(t, c.Reconcile(context.Background(), ingress, primary))
+		assert.Empty(t, lbController.reconciled)
+		cloud.AssertExpectations(t)
+	})
+}
+
+func TestDefaultController_Delete(t *testing.T) {
+	t.Run("secondary LoadBalancer tagged with the failover records", func(t *testing.T) {
+		cloud := &mocks.CloudAPI{}
+		mockSecondary(cloud, []*elbv2.Tag{{Key: aws.String(lb.TagKeyFailoverRecord), Value: aws.String("Z123/app.example.com")}})
+		cloud.On("GetResourceRecordSets", mock.Anything, "Z123", "app.example.com").Return(nil, nil)
+		lbController := &fakeLBController{}
+
+		c := NewController(cloud, store.NewDummy(), nameGen{}, lbController)
+		assert.NoError(t, c.Delete(context.Background(), ingressKey))
+		assert.Equal(t, []types.NamespacedName{{Namespace: "ns", Name: "ingress.failover"}}, lbController.deleted)
+		cloud.AssertExpectations(t)
+	})
+
+	t.Run("untagged LoadBalancer named after the secondary LoadBalancer", func(t *testing.T) {
+		cloud := &mocks.CloudAPI{}
+		mockSecondary(cloud, []*elbv2.Tag{{Key: aws.String("k"), Value: aws.String("v")}})
+		lbController := &fakeLBController{}
+
+		c := NewController(cloud, store.NewDummy(), nameGen{}, lbController)
+		assert.NoError(t, c.Delete(context.Background(), ingressKey))
+		assert.Empty(t, lbController.deleted)
+		cloud.AssertExpectations(t)
+	})
+}
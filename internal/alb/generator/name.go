@@ -59,3 +59,7 @@ func (gen *NameGenerator) NameLBSG(namespace string, ingressName string) string
 func (gen *NameGenerator) NameInstanceSG(namespace string, ingressName string) string {
 	return "instance-" + gen.NameLB(namespace, ingressName)
 }
+
+func (gen *NameGenerator) NameBackendSG() string {
+	return gen.ALBNamePrefix + "-backend"
+}
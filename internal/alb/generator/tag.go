@@ -2,6 +2,7 @@ package generator
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/lb"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/sg"
@@ -25,8 +26,12 @@ const (
 
 	V2ResourceIDLoadBalancer           = "LoadBalancer"
 	V2ResourceIDManagedLBSecurityGroup = "ManagedLBSecurityGroup"
+	V2ResourceIDBackendSecurityGroup   = "BackendSecurityGroup"
 )
 
+// stackVersion is stamped on every resource, except the ownership tags of targetGroups which select them for garbage collection.
+var stackVersion = strconv.Itoa(lb.CurrentStackVersion)
+
 var _ tg.TagGenerator = (*TagGenerator)(nil)
 var _ lb.TagGenerator = (*TagGenerator)(nil)
 var _ sg.TagGenerator = (*TagGenerator)(nil)
@@ -39,9 +44,17 @@ type TagGenerator struct {
 func (gen *TagGenerator) TagLB(namespace string, ingressName string) map[string]string {
 	resTags := gen.tagIngressResources(namespace, ingressName)
 	resTags[V2TagKeyResourceID] = V2ResourceIDLoadBalancer
+	resTags[lb.TagKeyStackVersion] = stackVersion
 	return resTags
 }
 
+// TagAdoptedLB doesn't tag existing LoadBalancers as owned by the cluster, since they outlive the ingress adopting them.
+func (gen *TagGenerator) TagAdoptedLB(namespace string, ingressName string) map[string]string {
+	return map[string]string{
+		lb.TagKeyAdoptedBy: gen.ClusterName + "/" + namespace + "/" + ingressName,
+	}
+}
+
 func (gen *TagGenerator) TagTGGroup(namespace string, ingressName string) map[string]string {
 	return gen.tagIngressResources(namespace, ingressName)
 }
@@ -53,6 +66,7 @@ func (gen *TagGenerator) TagTG(namespace string, ingressName string, serviceName
 	}
 	resID := gen.buildV2TargetGroupID(namespace, ingressName, serviceName, servicePort)
 	resTags[V2TagKeyResourceID] = resID
+	resTags[lb.TagKeyStackVersion] = stackVersion
 	return resTags
 }
 
@@ -66,6 +80,18 @@ func (gen *TagGenerator) TagInstanceSG(namespace string, ingressName string) map
 	return gen.tagSGs(namespace, ingressName)
 }
 
+// TagBackendSG isn't tagged by ingress, since the backend securityGroup is shared by all ingresses of the cluster.
+func (gen *TagGenerator) TagBackendSG() map[string]string {
+	m := make(map[string]string)
+	for label, value := range gen.DefaultTags {
+		m[label] = value
+	}
+	m[TagKeyClusterName] = gen.ClusterName
+	m[V2TagKeyClusterID] = gen.ClusterName
+	m[V2TagKeyResourceID] = V2ResourceIDBackendSecurityGroup
+	return m
+}
+
 func (gen *TagGenerator) tagIngressResources(namespace string, ingressName string) map[string]string {
 	m := make(map[string]string)
 	for label, value := range gen.DefaultTags {
@@ -100,6 +126,7 @@ func (gen *TagGenerator) tagSGs(namespace string, ingressName string) map[string
 
 	m[TagKeyNamespace] = namespace
 	m[TagKeyIngressName] = ingressName
+	m[lb.TagKeyStackVersion] = stackVersion
 
 	v2Tags := gen.tagIngressResourcesV2(namespace, ingressName)
 	for label, value := range v2Tags {
@@ -18,15 +18,30 @@ func Test_TagLB(t *testing.T) {
 		TagKeyIngressName:               "ingress",
 		TagKeyNamespace:                 "namespace",
 
-		"ingress.k8s.aws/cluster":  "cluster",
-		"ingress.k8s.aws/stack":    "namespace/ingress",
-		"ingress.k8s.aws/resource": "LoadBalancer",
-		"key":                      "value",
+		"ingress.k8s.aws/cluster":       "cluster",
+		"ingress.k8s.aws/stack":         "namespace/ingress",
+		"ingress.k8s.aws/resource":      "LoadBalancer",
+		"ingress.k8s.aws/stack-version": "2",
+		"key":                           "value",
 	}
 
 	assert.Equal(t, gen.TagLB("namespace", "ingress"), expected)
 }
 
+func Test_TagAdoptedLB(t *testing.T) {
+	gen := TagGenerator{
+		ClusterName: "cluster",
+		DefaultTags: map[string]string{
+			"key": "value",
+		},
+	}
+	expected := map[string]string{
+		"ingress.k8s.aws/adopted-by": "cluster/namespace/ingress",
+	}
+
+	assert.Equal(t, gen.TagAdoptedLB("namespace", "ingress"), expected)
+}
+
 func Test_TagTGGroup(t *testing.T) {
 	gen := TagGenerator{
 		ClusterName: "cluster",
@@ -50,9 +65,28 @@ func Test_TagTGGroup(t *testing.T) {
 func Test_TagTG(t *testing.T) {
 	gen := TagGenerator{}
 	expected := map[string]string{
-		TagKeyServiceName:          "service",
-		TagKeyServicePort:          "port",
-		"ingress.k8s.aws/resource": "namespace/ingress-service:port",
+		TagKeyServiceName:               "service",
+		TagKeyServicePort:               "port",
+		"ingress.k8s.aws/resource":      "namespace/ingress-service:port",
+		"ingress.k8s.aws/stack-version": "2",
 	}
 	assert.Equal(t, gen.TagTG("namespace", "ingress", "service", "port"), expected)
 }
+
+func Test_TagBackendSG(t *testing.T) {
+	gen := TagGenerator{
+		ClusterName: "cluster",
+		DefaultTags: map[string]string{
+			"key": "value",
+		},
+	}
+	expected := map[string]string{
+		TagKeyClusterName: "cluster",
+
+		"ingress.k8s.aws/cluster":  "cluster",
+		"ingress.k8s.aws/resource": "BackendSecurityGroup",
+		"key":                      "value",
+	}
+
+	assert.Equal(t, gen.TagBackendSG(), expected)
+}
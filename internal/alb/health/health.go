@@ -0,0 +1,82 @@
+package health
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/lb"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// Checker evaluates the health of targets behind the LoadBalancers of an ingress.
+type Checker interface {
+	// Check counts the unhealthy targets in targetGroups of LoadBalancers.
+	Check(ctx context.Context, lbInfos []*lb.LoadBalancer) (Result, error)
+}
+
+// Result is the health of targets behind the LoadBalancers of an ingress.
+type Result struct {
+	TotalTargets     int
+	UnhealthyTargets int
+
+	// UnhealthyServices are the names of services with unhealthy targets, in sorted order.
+	UnhealthyServices []string
+}
+
+// Exceeds returns whether unhealthy targets exceed threshold, which is either a count or a percentage of all targets.
+func (r Result) Exceeds(threshold intstr.IntOrString) bool {
+	limit, err := intstr.GetValueFromIntOrPercent(&threshold, r.TotalTargets, false)
+	if err != nil {
+		return false
+	}
+	return r.UnhealthyTargets > limit
+}
+
+func (r Result) String() string {
+	return fmt.Sprintf("%d of %d targets unhealthy, affected services: %v", r.UnhealthyTargets, r.TotalTargets, r.UnhealthyServices)
+}
+
+func NewChecker(cloud aws.CloudAPI) Checker {
+	return &defaultChecker{
+		cloud: cloud,
+	}
+}
+
+type defaultChecker struct {
+	cloud aws.CloudAPI
+}
+
+func (c *defaultChecker) Check(ctx context.Context, lbInfos []*lb.LoadBalancer) (Result, error) {
+	result := Result{}
+	unhealthyServices := sets.NewString()
+	for _, lbInfo := range lbInfos {
+		for _, tgArn := range lbInfo.TargetGroupArns {
+			resp, err := c.cloud.DescribeTargetHealthWithContext(ctx, &elbv2.DescribeTargetHealthInput{
+				TargetGroupArn: aws.String(tgArn),
+			})
+			if err != nil {
+				return Result{}, fmt.Errorf("failed to describe target health of %v due to %w", tgArn, err)
+			}
+			for _, description := range resp.TargetHealthDescriptions {
+				result.TotalTargets++
+				if description.TargetHealth != nil && aws.StringValue(description.TargetHealth.State) == elbv2.TargetHealthStateEnumUnhealthy {
+					result.UnhealthyTargets++
+					unhealthyServices.Insert(serviceName(lbInfo, tgArn))
+				}
+			}
+		}
+	}
+	result.UnhealthyServices = unhealthyServices.List()
+	return result, nil
+}
+
+// serviceName returns the name of backend service of targetGroup, or the targetGroup ARN if unknown.
+func serviceName(lbInfo *lb.LoadBalancer, tgArn string) string {
+	if name, ok := lbInfo.TargetGroupServices[tgArn]; ok {
+		return name
+	}
+	return tgArn
+}
@@ -0,0 +1,108 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/lb"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func targetHealth(states ...string) *elbv2.DescribeTargetHealthOutput {
+	output := &elbv2.DescribeTargetHealthOutput{}
+	for _, state := range states {
+		output.TargetHealthDescriptions = append(output.TargetHealthDescriptions, &elbv2.TargetHealthDescription{
+			TargetHealth: &elbv2.TargetHealth{State: aws.String(state)},
+		})
+	}
+	return output
+}
+
+func TestDefaultChecker_Check(t *testing.T) {
+	lbInfos := []*lb.LoadBalancer{
+		{
+			TargetGroupArns:     []string{"tg-a", "tg-b"},
+			TargetGroupServices: map[string]string{"tg-a": "service-a", "tg-b": "service-b"},
+		},
+		{
+			TargetGroupArns: []string{"tg-c"},
+		},
+	}
+
+	t.Run("counts unhealthy targets", func(t *testing.T) {
+		cloud := &mocks.CloudAPI{}
+		cloud.On("DescribeTargetHealthWithContext", context.Background(), &elbv2.DescribeTargetHealthInput{TargetGroupArn: aws.String("tg-a")}).
+			Return(targetHealth(elbv2.TargetHealthStateEnumHealthy, elbv2.TargetHealthStateEnumUnhealthy), nil)
+		cloud.On("DescribeTargetHealthWithContext", context.Background(), &elbv2.DescribeTargetHealthInput{TargetGroupArn: aws.String("tg-b")}).
+			Return(targetHealth(elbv2.TargetHealthStateEnumHealthy, elbv2.TargetHealthStateEnumInitial), nil)
+		cloud.On("DescribeTargetHealthWithContext", context.Background(), &elbv2.DescribeTargetHealthInput{TargetGroupArn: aws.String("tg-c")}).
+			Return(targetHealth(elbv2.TargetHealthStateEnumUnhealthy, elbv2.TargetHealthStateEnumUnhealthy), nil)
+
+		result, err := NewChecker(cloud).Check(context.Background(), lbInfos)
+		assert.NoError(t, err)
+		assert.Equal(t, Result{
+			TotalTargets:      6,
+			UnhealthyTargets:  3,
+			UnhealthyServices: []string{"service-a", "tg-c"},
+		}, result)
+		cloud.AssertExpectations(t)
+	})
+
+	t.Run("describe target health fails", func(t *testing.T) {
+		cloud := &mocks.CloudAPI{}
+		cloud.On("DescribeTargetHealthWithContext", context.Background(), &elbv2.DescribeTargetHealthInput{TargetGroupArn: aws.String("tg-a")}).
+			Return(nil, errors.New("access denied"))
+
+		_, err := NewChecker(cloud).Check(context.Background(), lbInfos)
+		assert.EqualError(t, err, "failed to describe target health of tg-a due to access denied")
+	})
+}
+
+func TestResult_Exceeds(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		result    Result
+		threshold intstr.IntOrString
+		expected  bool
+	}{
+		{
+			name:      "count not exceeded",
+			result:    Result{TotalTargets: 10, UnhealthyTargets: 2},
+			threshold: intstr.FromInt(2),
+			expected:  false,
+		},
+		{
+			name:      "count exceeded",
+			result:    Result{TotalTargets: 10, UnhealthyTargets: 3},
+			threshold: intstr.FromInt(2),
+			expected:  true,
+		},
+		{
+			name:      "percentage not exceeded",
+			result:    Result{TotalTargets: 10, UnhealthyTargets: 2},
+			threshold: intstr.FromString("25%"),
+			expected:  false,
+		},
+		{
+			name:      "percentage exceeded",
+			result:    Result{TotalTargets: 10, UnhealthyTargets: 3},
+			threshold: intstr.FromString("25%"),
+			expected:  true,
+		},
+		{
+			name:      "no targets",
+			result:    Result{},
+			threshold: intstr.FromString("0%"),
+			expected:  false,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.result.Exceeds(tc.threshold))
+		})
+	}
+}
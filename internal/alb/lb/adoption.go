@@ -0,0 +1,251 @@
+package lb
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/loadbalancer"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/k8s"
+	util "github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/types"
+	corev1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// ensureAdoptedLBInstance returns the existing LoadBalancer adopted by ingress, tagged with the ports of listeners managed on it.
+// Ports managed so far are returned and stay recorded along with ports until finishAdoption, so that their listeners are
+// deleted eventually even if the reconcile fails in between.
+func (controller *defaultController) ensureAdoptedLBInstance(ctx context.Context, ingress *extensions.Ingress, lbConfig *loadBalancerConfig,
+	existing *loadbalancer.ExistingLoadBalancerConfig, ports []int64) (*elbv2.LoadBalancer, []int64, error) {
+	ingressKey := k8s.NamespacedName(ingress)
+	owned, err := controller.findLBInstance(ctx, ingressKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find existing LoadBalancer due to %w", err)
+	}
+	if owned != nil {
+		return nil, nil, fmt.Errorf("ingress already has LoadBalancer %v created by the controller, which can't be replaced by an existing LoadBalancer",
+			aws.StringValue(owned.LoadBalancerArn))
+	}
+
+	instance, err := controller.findExistingLBInstance(ctx, existing.NameOrArn)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find existing LoadBalancer %v due to %w", existing.NameOrArn, err)
+	}
+	if instance == nil {
+		return nil, nil, fmt.Errorf("existing LoadBalancer %v not found", existing.NameOrArn)
+	}
+	lbArn := aws.StringValue(instance.LoadBalancerArn)
+	// mutations of the adopted LoadBalancer are serialized by its name, rather than the name of a LoadBalancer created for the ingress.
+	ctx = albctx.SetLBLocker(ctx, controller.lbLocks.Locker(aws.StringValue(instance.LoadBalancerName)))
+	if aws.StringValue(instance.Type) != elbv2.LoadBalancerTypeEnumApplication {
+		return nil, nil, fmt.Errorf("existing LoadBalancer %v is not an ApplicationLoadBalancer", lbArn)
+	}
+	// the scheme annotation doesn't apply to LoadBalancers adopted for their listeners, so restrictions apply to their actual scheme.
+	if existing.Scope == loadbalancer.AdoptionScopeListeners {
+		if err := controller.validateLBConfig(ctx, ingress, &loadBalancerConfig{Scheme: instance.Scheme}); err != nil {
+			return nil, nil, err
+		}
+	}
+	curTags, err := controller.getLBTags(ctx, lbArn)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get tags of %v due to %w", lbArn, err)
+	}
+	adoptedTags := controller.nameTagGen.TagAdoptedLB(ingressKey.Namespace, ingressKey.Name)
+	if err := validateAdoption(lbArn, curTags, adoptedTags[TagKeyAdoptedBy]); err != nil {
+		return nil, nil, err
+	}
+
+	var previousPorts []int64
+	if curTags[TagKeyAdoptedBy] == adoptedTags[TagKeyAdoptedBy] {
+		previousPorts = parseAdoptedListeners(curTags[TagKeyAdoptedListeners])
+	} else {
+		// the ingress may have adopted another LoadBalancer before.
+		if err := controller.releaseAdoptedLBInstances(ctx, ingressKey); err != nil {
+			return nil, nil, err
+		}
+		albctx.GetLogger(ctx).Infof("adopting LoadBalancer %v", lbArn)
+		albctx.GetEventf(ctx)(corev1.EventTypeNormal, "MODIFY", "LoadBalancer %v adopted with scope %v", lbArn, existing.Scope)
+	}
+	listeners, err := controller.cloud.ListListenersByLoadBalancer(ctx, lbArn)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list listeners of %v due to %w", lbArn, err)
+	}
+	if err := validateAdoptedListeners(lbArn, listeners, previousPorts, ports); err != nil {
+		return nil, nil, err
+	}
+	adoptedTags[TagKeyAdoptedListeners] = formatAdoptedListeners(append(previousPorts, ports...))
+
+	lbLocker := albctx.GetLBLocker(ctx)
+	lbLocker.Lock()
+	defer lbLocker.Unlock()
+	if err := controller.addLBTags(ctx, lbArn, curTags, adoptedTags); err != nil {
+		return nil, nil, fmt.Errorf("failed to tag %v as adopted due to %w", lbArn, err)
+	}
+	if existing.Scope == loadbalancer.AdoptionScopeLoadBalancer {
+		// adopted LoadBalancers are never recreated, since they're referenced outside of the cluster.
+		if !util.DeepEqual(instance.Scheme, lbConfig.Scheme) {
+			return nil, nil, fmt.Errorf("existing LoadBalancer %v is %v, which can't be changed to %v",
+				lbArn, aws.StringValue(instance.Scheme), aws.StringValue(lbConfig.Scheme))
+		}
+		if err := controller.reconcileLBInstance(ctx, instance, lbConfig); err != nil {
+			return nil, nil, err
+		}
+	}
+	return instance, previousPorts, nil
+}
+
+// finishAdoption deletes the listeners on previousPorts that are no longer in ports, and records ports as the only ones managed.
+func (controller *defaultController) finishAdoption(ctx context.Context, lbArn string, previousPorts []int64, ports []int64) error {
+	releasedPorts := sets.NewInt64(previousPorts...).Difference(sets.NewInt64(ports...)).List()
+	if len(releasedPorts) == 0 {
+		return nil
+	}
+	if err := controller.lsGroupController.DeletePorts(ctx, lbArn, releasedPorts); err != nil {
+		return fmt.Errorf("failed to delete listeners due to %w", err)
+	}
+	lbLocker := albctx.GetLBLocker(ctx)
+	lbLocker.Lock()
+	defer lbLocker.Unlock()
+	_, err := controller.cloud.AddELBV2TagsWithContext(ctx, &elbv2.AddTagsInput{
+		ResourceArns: aws.StringSlice([]string{lbArn}),
+		Tags: []*elbv2.Tag{
+			{Key: aws.String(TagKeyAdoptedListeners), Value: aws.String(formatAdoptedListeners(ports))},
+		},
+	})
+	return err
+}
+
+// releaseAdoptedLBInstances hands the LoadBalancers adopted by ingress back to their owner,
+// the listeners managed for ingress are deleted while anything else on them is left as is.
+func (controller *defaultController) releaseAdoptedLBInstances(ctx context.Context, ingressKey types.NamespacedName) error {
+	tagFilters := make(map[string][]string)
+	for k, v := range controller.nameTagGen.TagAdoptedLB(ingressKey.Namespace, ingressKey.Name) {
+		tagFilters[k] = []string{v}
+	}
+	instances, err := controller.cloud.GetLoadBalancersByTags(ctx, tagFilters)
+	if err != nil {
+		return fmt.Errorf("failed to find adopted LoadBalancers due to %w", err)
+	}
+	for _, instance := range instances {
+		lbArn := aws.StringValue(instance.LoadBalancerArn)
+		ctx := albctx.SetLBLocker(ctx, controller.lbLocks.Locker(aws.StringValue(instance.LoadBalancerName)))
+		curTags, err := controller.getLBTags(ctx, lbArn)
+		if err != nil {
+			return fmt.Errorf("failed to get tags of %v due to %w", lbArn, err)
+		}
+		albctx.GetLogger(ctx).Infof("releasing adopted LoadBalancer %v", lbArn)
+		if err := controller.lsGroupController.DeletePorts(ctx, lbArn, parseAdoptedListeners(curTags[TagKeyAdoptedListeners])); err != nil {
+			return fmt.Errorf("failed to delete listeners of adopted LoadBalancer %v due to %w", lbArn, err)
+		}
+		if _, err := controller.cloud.RemoveELBV2TagsWithContext(ctx, &elbv2.RemoveTagsInput{
+			ResourceArns: aws.StringSlice([]string{lbArn}),
+			TagKeys:      aws.StringSlice([]string{TagKeyAdoptedBy, TagKeyAdoptedListeners}),
+		}); err != nil {
+			return fmt.Errorf("failed to untag adopted LoadBalancer %v due to %w", lbArn, err)
+		}
+	}
+	return nil
+}
+
+// findExistingLBInstance returns the LoadBalancer with the ARN or name nameOrArn, or nil if it doesn't exist.
+func (controller *defaultController) findExistingLBInstance(ctx context.Context, nameOrArn string) (*elbv2.LoadBalancer, error) {
+	if strings.HasPrefix(nameOrArn, "arn:") {
+		return controller.cloud.GetLoadBalancerByArn(ctx, nameOrArn)
+	}
+	return controller.cloud.GetLoadBalancerByName(ctx, nameOrArn)
+}
+
+func (controller *defaultController) getLBTags(ctx context.Context, lbArn string) (map[string]string, error) {
+	resp, err := controller.cloud.DescribeELBV2TagsWithContext(ctx, &elbv2.DescribeTagsInput{
+		ResourceArns: aws.StringSlice([]string{lbArn}),
+	})
+	if err != nil {
+		return nil, err
+	}
+	lbTags := make(map[string]string)
+	for _, desc := range resp.TagDescriptions {
+		for _, tag := range desc.Tags {
+			lbTags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+		}
+	}
+	return lbTags, nil
+}
+
+// addLBTags adds tags missing from curTags, unlike tags.Controller it never removes tags set by the owner of the LoadBalancer.
+func (controller *defaultController) addLBTags(ctx context.Context, lbArn string, curTags map[string]string, desiredTags map[string]string) error {
+	modify := make(map[string]string)
+	for k, v := range desiredTags {
+		if curTags[k] != v {
+			modify[k] = v
+		}
+	}
+	if len(modify) == 0 {
+		return nil
+	}
+	var elbv2Tags []*elbv2.Tag
+	for _, k := range sets.StringKeySet(modify).List() {
+		elbv2Tags = append(elbv2Tags, &elbv2.Tag{Key: aws.String(k), Value: aws.String(modify[k])})
+	}
+	_, err := controller.cloud.AddELBV2TagsWithContext(ctx, &elbv2.AddTagsInput{
+		ResourceArns: aws.StringSlice([]string{lbArn}),
+		Tags:         elbv2Tags,
+	})
+	return err
+}
+
+// validateAdoption rejects adopting LoadBalancers managed by a controller, or already adopted by another ingress.
+func validateAdoption(lbArn string, curTags map[string]string, adoptedBy string) error {
+	if _, ok := curTags[TagKeyStackVersion]; ok {
+		return fmt.Errorf("existing LoadBalancer %v is managed by the controller for another ingress", lbArn)
+	}
+	for k, v := range curTags {
+		if strings.HasPrefix(k, "kubernetes.io/cluster/") && v == "owned" {
+			return fmt.Errorf("existing LoadBalancer %v is owned by cluster %v", lbArn, strings.TrimPrefix(k, "kubernetes.io/cluster/"))
+		}
+	}
+	if current, ok := curTags[TagKeyAdoptedBy]; ok && current != adoptedBy {
+		return fmt.Errorf("existing LoadBalancer %v is already adopted by %v", lbArn, current)
+	}
+	return nil
+}
+
+// validateAdoptedListeners rejects ports that already have a listener on the adopted LoadBalancer, unless it was created for the ingress
+// as recorded by managedPorts, since listeners of the owner must never be modified or deleted.
+func validateAdoptedListeners(lbArn string, listeners []*elbv2.Listener, managedPorts []int64, ports []int64) error {
+	managed := sets.NewInt64(managedPorts...)
+	desired := sets.NewInt64(ports...)
+	for _, listener := range listeners {
+		port := aws.Int64Value(listener.Port)
+		if desired.Has(port) && !managed.Has(port) {
+			return fmt.Errorf("existing LoadBalancer %v already has listener %v on port %v, which isn't managed by the ingress",
+				lbArn, aws.StringValue(listener.ListenerArn), port)
+		}
+	}
+	return nil
+}
+
+// parseAdoptedListeners parses the listener ports recorded by TagKeyAdoptedListeners, ignoring malformed ports.
+func parseAdoptedListeners(value string) []int64 {
+	var ports []int64
+	for _, field := range strings.Fields(value) {
+		if port, err := strconv.ParseInt(field, 10, 64); err == nil {
+			ports = append(ports, port)
+		}
+	}
+	return ports
+}
+
+// formatAdoptedListeners formats ports as the value of TagKeyAdoptedListeners, tag values can't contain commas.
+func formatAdoptedListeners(ports []int64) string {
+	var fields []string
+	for _, port := range sets.NewInt64(ports...).List() {
+		fields = append(fields, strconv.FormatInt(port, 10))
+	}
+	return strings.Join(fields, " ")
+}
@@ -0,0 +1,90 @@
+package lb
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_validateAdoption(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		curTags     map[string]string
+		expectedErr error
+	}{
+		{
+			name:    "untagged LoadBalancer",
+			curTags: map[string]string{"team": "edge"},
+		},
+		{
+			name:    "LoadBalancer adopted by the ingress",
+			curTags: map[string]string{TagKeyAdoptedBy: "cluster/namespace/ingress"},
+		},
+		{
+			name:        "LoadBalancer adopted by another ingress",
+			curTags:     map[string]string{TagKeyAdoptedBy: "cluster/namespace/other"},
+			expectedErr: errors.New("existing LoadBalancer lbArn is already adopted by cluster/namespace/other"),
+		},
+		{
+			name:        "LoadBalancer created by the controller",
+			curTags:     map[string]string{TagKeyStackVersion: "2"},
+			expectedErr: errors.New("existing LoadBalancer lbArn is managed by the controller for another ingress"),
+		},
+		{
+			name:        "LoadBalancer owned by a cluster",
+			curTags:     map[string]string{"kubernetes.io/cluster/other": "owned"},
+			expectedErr: errors.New("existing LoadBalancer lbArn is owned by cluster other"),
+		},
+		{
+			name:    "LoadBalancer shared with a cluster",
+			curTags: map[string]string{"kubernetes.io/cluster/other": "shared"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedErr, validateAdoption("lbArn", tc.curTags, "cluster/namespace/ingress"))
+		})
+	}
+}
+
+func Test_adoptedListeners(t *testing.T) {
+	assert.Equal(t, "80 443 8080", formatAdoptedListeners([]int64{443, 80, 8080, 443}))
+	assert.Equal(t, "", formatAdoptedListeners(nil))
+	assert.Equal(t, []int64{80, 443}, parseAdoptedListeners("80 443 http"))
+	assert.Nil(t, parseAdoptedListeners(""))
+}
+
+func Test_validateAdoptedListeners(t *testing.T) {
+	listeners := []*elbv2.Listener{
+		{ListenerArn: aws.String("lsArn80"), Port: aws.Int64(80)},
+		{ListenerArn: aws.String("lsArn443"), Port: aws.Int64(443)},
+	}
+	for _, tc := range []struct {
+		name         string
+		managedPorts []int64
+		ports        []int64
+		expectedErr  error
+	}{
+		{
+			name:  "new listener",
+			ports: []int64{8080},
+		},
+		{
+			name:         "listener managed for the ingress",
+			managedPorts: []int64{443},
+			ports:        []int64{443, 8080},
+		},
+		{
+			name:         "listener of the owner",
+			managedPorts: []int64{443},
+			ports:        []int64{80, 443},
+			expectedErr:  errors.New("existing LoadBalancer lbArn already has listener lsArn80 on port 80, which isn't managed by the ingress"),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedErr, validateAdoptedListeners("lbArn", listeners, tc.managedPorts, tc.ports))
+		})
+	}
+}
@@ -3,12 +3,14 @@ package lb
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strconv"
 
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/log"
+	util "github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/types"
 	api "k8s.io/api/core/v1"
 )
 
@@ -61,6 +63,10 @@ type Attributes struct {
 	// DropInvalidHeaderFieldsEnabled: routing.http.drop_invalid_header_fields.enabled - Indicates if
 	// invalid headers will be dropped. The default is false.
 	DropInvalidHeaderFieldsEnabled bool
+
+	// Extra are the attributes unknown to the controller, they're passed to ELBV2 as is, so that attributes
+	// introduced by AWS can be used right away. Their defaults are unknown, so they're left unchanged once removed.
+	Extra map[string]string
 }
 
 func NewAttributes(attrs []*elbv2.LoadBalancerAttribute) (a *Attributes, err error) {
@@ -73,7 +79,6 @@ func NewAttributes(attrs []*elbv2.LoadBalancerAttribute) (a *Attributes, err err
 		RoutingHTTP2Enabled:            RoutingHTTP2Enabled,
 		DropInvalidHeaderFieldsEnabled: DropInvalidHeaderFieldsEnabled,
 	}
-	var e error
 	for _, attr := range attrs {
 		attrValue := aws.StringValue(attr.Value)
 		switch attrKey := aws.StringValue(attr.Key); attrKey {
@@ -110,16 +115,22 @@ func NewAttributes(attrs []*elbv2.LoadBalancerAttribute) (a *Attributes, err err
 				return a, fmt.Errorf("invalid load balancer attribute value %s=%s", attrKey, attrValue)
 			}
 		default:
-			e = NewInvalidAttribute(attrKey)
+			if a.Extra == nil {
+				a.Extra = make(map[string]string)
+			}
+			a.Extra[attrKey] = attrValue
 		}
 	}
-	return a, e
+	return a, nil
 }
 
 // AttributesController provides functionality to manage Attributes
 type AttributesController interface {
 	// Reconcile ensures the load balancer attributes in AWS matches the state specified by the ingress configuration.
 	Reconcile(ctx context.Context, lbArn string, attrs []*elbv2.LoadBalancerAttribute) error
+
+	// DisableDeletionProtection disables deletion protection of the load balancer if it's enabled, so that it can be deleted.
+	DisableDeletionProtection(ctx context.Context, lbArn string) error
 }
 
 // NewAttributesController constructs a new attributes controller
@@ -136,34 +147,81 @@ type attributesController struct {
 func (c *attributesController) Reconcile(ctx context.Context, lbArn string, attrs []*elbv2.LoadBalancerAttribute) error {
 	desired, err := NewAttributes(attrs)
 	if err != nil {
-		return fmt.Errorf("failed parsing attributes; %v", err)
+		return fmt.Errorf("failed parsing attributes; %w", err)
 	}
 	raw, err := c.cloud.DescribeLoadBalancerAttributesWithContext(ctx, &elbv2.DescribeLoadBalancerAttributesInput{
 		LoadBalancerArn: aws.String(lbArn),
 	})
 
 	if err != nil {
-		return fmt.Errorf("failed to retrieve attributes from ELBV2 in AWS: %s", err.Error())
+		return fmt.Errorf("failed to retrieve attributes from ELBV2 in AWS: %w", err)
 	}
 
 	current, err := NewAttributes(raw.Attributes)
 	if err != nil && !IsInvalidAttribute(err) {
-		return fmt.Errorf("failed parsing attributes: %v", err)
+		return fmt.Errorf("failed parsing attributes: %w", err)
 	}
 
 	changeSet := attributesChangeSet(current, desired)
 	if len(changeSet) > 0 {
 		albctx.GetLogger(ctx).Infof("Modifying ELBV2 attributes to %v.", log.Prettify(changeSet))
+		lbLocker := albctx.GetLBLocker(ctx)
+		lbLocker.Lock()
 		_, err = c.cloud.ModifyLoadBalancerAttributesWithContext(ctx, &elbv2.ModifyLoadBalancerAttributesInput{
 			LoadBalancerArn: aws.String(lbArn),
 			Attributes:      changeSet,
 		})
+		lbLocker.Unlock()
 		if err != nil {
 			albctx.GetEventf(ctx)(api.EventTypeWarning, "ERROR", "%s attributes modification failed: %s", lbArn, err.Error())
-			return fmt.Errorf("failed modifying attributes: %s", err)
+			return fmt.Errorf("failed modifying attributes: %w", err)
 		}
+		albctx.GetEventf(ctx)(api.EventTypeNormal, "MODIFY", "LoadBalancer %v modified: %v", lbArn, attributesDiff(raw.Attributes, changeSet))
+	}
+	return nil
+}
+
+// attributesDiff describes how changeSet changes the current attributes of a LoadBalancer.
+func attributesDiff(current []*elbv2.LoadBalancerAttribute, changeSet []*elbv2.LoadBalancerAttribute) util.Diff {
+	currentValues := make(map[string]string, len(current))
+	for _, attr := range current {
+		currentValues[aws.StringValue(attr.Key)] = aws.StringValue(attr.Value)
+	}
+	var diff util.Diff
+	for _, attr := range changeSet {
+		diff.Add(aws.StringValue(attr.Key), currentValues[aws.StringValue(attr.Key)], aws.StringValue(attr.Value))
+	}
+	return diff
+}
 
+func (c *attributesController) DisableDeletionProtection(ctx context.Context, lbArn string) error {
+	raw, err := c.cloud.DescribeLoadBalancerAttributesWithContext(ctx, &elbv2.DescribeLoadBalancerAttributesInput{
+		LoadBalancerArn: aws.String(lbArn),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to retrieve attributes from ELBV2 in AWS: %w", err)
 	}
+	current, err := NewAttributes(raw.Attributes)
+	if err != nil {
+		return fmt.Errorf("failed parsing attributes: %w", err)
+	}
+	if !current.DeletionProtectionEnabled {
+		return nil
+	}
+
+	albctx.GetLogger(ctx).Infof("disabling deletion protection of %v", lbArn)
+	lbLocker := albctx.GetLBLocker(ctx)
+	lbLocker.Lock()
+	_, err = c.cloud.ModifyLoadBalancerAttributesWithContext(ctx, &elbv2.ModifyLoadBalancerAttributesInput{
+		LoadBalancerArn: aws.String(lbArn),
+		Attributes:      []*elbv2.LoadBalancerAttribute{lbAttribute(DeletionProtectionEnabledKey, "false")},
+	})
+	lbLocker.Unlock()
+	if err != nil {
+		albctx.GetEventf(ctx)(api.EventTypeWarning, "ERROR", "%s deletion protection removal failed: %s", lbArn, err.Error())
+		return fmt.Errorf("failed disabling deletion protection: %w", err)
+	}
+	albctx.GetEventf(ctx)(api.EventTypeNormal, "MODIFY", "%s deletion protection disabled for deletion", lbArn)
 	return nil
 }
 
@@ -200,6 +258,17 @@ func attributesChangeSet(current, desired *Attributes) (changeSet []*elbv2.LoadB
 		changeSet = append(changeSet, lbAttribute(DropInvalidHeaderFieldsEnabledKey, fmt.Sprintf("%v", desired.DropInvalidHeaderFieldsEnabled)))
 	}
 
+	extraKeys := make([]string, 0, len(desired.Extra))
+	for k := range desired.Extra {
+		extraKeys = append(extraKeys, k)
+	}
+	sort.Strings(extraKeys)
+	for _, k := range extraKeys {
+		if v, ok := current.Extra[k]; !ok || v != desired.Extra[k] {
+			changeSet = append(changeSet, lbAttribute(k, desired.Extra[k]))
+		}
+	}
+
 	return
 }
 
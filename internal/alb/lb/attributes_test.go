@@ -69,9 +69,19 @@ func Test_NewAttributes(t *testing.T) {
 			attributes: []*elbv2.LoadBalancerAttribute{lbAttribute(DropInvalidHeaderFieldsEnabledKey, "falfadssdfdsse")},
 		},
 		{
-			name:       fmt.Sprintf("undefined attribute"),
-			ok:         false,
-			attributes: []*elbv2.LoadBalancerAttribute{lbAttribute("not.real.attribute", "falfadssdfdsse")},
+			name:       fmt.Sprintf("attribute unknown to the controller"),
+			ok:         true,
+			attributes: []*elbv2.LoadBalancerAttribute{lbAttribute("waf.fail_open.enabled", "true")},
+			output: &Attributes{
+				DeletionProtectionEnabled:      DeletionProtectionEnabled,
+				AccessLogsS3Enabled:            AccessLogsS3Enabled,
+				AccessLogsS3Bucket:             AccessLogsS3Bucket,
+				AccessLogsS3Prefix:             AccessLogsS3Prefix,
+				IdleTimeoutTimeoutSeconds:      IdleTimeoutTimeoutSeconds,
+				RoutingHTTP2Enabled:            RoutingHTTP2Enabled,
+				DropInvalidHeaderFieldsEnabled: DropInvalidHeaderFieldsEnabled,
+				Extra:                          map[string]string{"waf.fail_open.enabled": "true"},
+			},
 		},
 		{
 			name: "non-default attributes",
@@ -168,6 +178,17 @@ func Test_attributesChangeSet(t *testing.T) {
 			b:         MustNewAttributes([]*elbv2.LoadBalancerAttribute{lbAttribute(DropInvalidHeaderFieldsEnabledKey, "true")}),
 			changeSet: []*elbv2.LoadBalancerAttribute{lbAttribute(DropInvalidHeaderFieldsEnabledKey, "true")},
 		},
+		{
+			name:      fmt.Sprintf("b contains an attribute unknown to the controller, make a change"),
+			a:         MustNewAttributes([]*elbv2.LoadBalancerAttribute{lbAttribute("waf.fail_open.enabled", "false")}),
+			b:         MustNewAttributes([]*elbv2.LoadBalancerAttribute{lbAttribute("waf.fail_open.enabled", "true")}),
+			changeSet: []*elbv2.LoadBalancerAttribute{lbAttribute("waf.fail_open.enabled", "true")},
+		},
+		{
+			name: fmt.Sprintf("a contains an attribute unknown to the controller, b doesn't, no change"),
+			a:    MustNewAttributes([]*elbv2.LoadBalancerAttribute{lbAttribute("waf.fail_open.enabled", "true")}),
+			b:    MustNewAttributes(nil),
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			changeSet := attributesChangeSet(tc.a, tc.b)
@@ -289,7 +310,57 @@ func TestReconcile(t *testing.T) {
 			err := controller.Reconcile(context.Background(), lbArn, tc.Attributes)
 
 			if tc.ExpectedError != nil {
-				assert.Equal(t, tc.ExpectedError, err)
+				assert.EqualError(t, err, tc.ExpectedError.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			cloud.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_DisableDeletionProtection(t *testing.T) {
+	lbArn := "arn:lb"
+	for _, tc := range []struct {
+		Name          string
+		Attributes    []*elbv2.LoadBalancerAttribute
+		ExpectModify  bool
+		ModifyErr     error
+		ExpectedError error
+	}{
+		{
+			Name:       "deletion protection disabled",
+			Attributes: []*elbv2.LoadBalancerAttribute{lbAttribute(DeletionProtectionEnabledKey, "false")},
+		},
+		{
+			Name:         "deletion protection enabled",
+			Attributes:   []*elbv2.LoadBalancerAttribute{lbAttribute(DeletionProtectionEnabledKey, "true")},
+			ExpectModify: true,
+		},
+		{
+			Name:          "modify attributes failed",
+			Attributes:    []*elbv2.LoadBalancerAttribute{lbAttribute(DeletionProtectionEnabledKey, "true")},
+			ExpectModify:  true,
+			ModifyErr:     errors.New("Something unexpected happened"),
+			ExpectedError: errors.New("failed disabling deletion protection: Something unexpected happened"),
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			ctx := context.Background()
+			cloud := &mocks.CloudAPI{}
+			cloud.On("DescribeLoadBalancerAttributesWithContext", ctx, &elbv2.DescribeLoadBalancerAttributesInput{LoadBalancerArn: aws.String(lbArn)}).Return(&elbv2.DescribeLoadBalancerAttributesOutput{Attributes: tc.Attributes}, nil)
+			if tc.ExpectModify {
+				cloud.On("ModifyLoadBalancerAttributesWithContext", ctx, &elbv2.ModifyLoadBalancerAttributesInput{
+					LoadBalancerArn: aws.String(lbArn),
+					Attributes:      []*elbv2.LoadBalancerAttribute{lbAttribute(DeletionProtectionEnabledKey, "false")},
+				}).Return(&elbv2.ModifyLoadBalancerAttributesOutput{}, tc.ModifyErr)
+			}
+
+			controller := NewAttributesController(cloud)
+			err := controller.DisableDeletionProtection(ctx, lbArn)
+
+			if tc.ExpectedError != nil {
+				assert.EqualError(t, err, tc.ExpectedError.Error())
 			} else {
 				assert.NoError(t, err)
 			}
@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"sync"
 
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/config"
 
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/ls"
@@ -17,10 +19,15 @@ import (
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/loadbalancer"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/shard"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/k8s"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/tracing"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/utils"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/log"
 	util "github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/types"
+	"go.opentelemetry.io/otel/attribute"
 	corev1 "k8s.io/api/core/v1"
 	extensions "k8s.io/api/extensions/v1beta1"
 	"k8s.io/apimachinery/pkg/types"
@@ -43,9 +50,12 @@ func NewController(
 	tgGroupController tg.GroupController,
 	lsGroupController ls.GroupController,
 	sgAssociationController sg.AssociationController,
-	tagsController tags.Controller) Controller {
+	tagsController tags.Controller,
+	certImporter ls.CertImporter) Controller {
 	attrsController := NewAttributesController(cloud)
 	wafController := NewWAFController(cloud)
+	wafv2Controller := NewWAFv2Controller(cloud)
+	shieldController := NewShieldController(cloud)
 
 	return &defaultController{
 		cloud:                   cloud,
@@ -55,8 +65,12 @@ func NewController(
 		lsGroupController:       lsGroupController,
 		sgAssociationController: sgAssociationController,
 		tagsController:          tagsController,
+		certImporter:            certImporter,
 		attrsController:         attrsController,
 		wafController:           wafController,
+		wafv2Controller:         wafv2Controller,
+		shieldController:        shieldController,
+		lbLocks:                 utils.NewKeyedMutex(),
 	}
 }
 
@@ -79,13 +93,30 @@ type defaultController struct {
 	lsGroupController       ls.GroupController
 	sgAssociationController sg.AssociationController
 	tagsController          tags.Controller
+	certImporter            ls.CertImporter
 	attrsController         AttributesController
 	wafController           WAFController
+	wafv2Controller         WAFv2Controller
+	shieldController        ShieldController
+
+	// lbLocks serializes mutations of each LoadBalancer by name, so that concurrent reconciles never interleave them.
+	lbLocks utils.KeyedMutex
+
+	// migratedStacks are the ingresses whose stack is known to be at CurrentStackVersion.
+	migratedStacksMutex sync.Mutex
+	migratedStacks      map[types.NamespacedName]bool
 }
 
 var _ Controller = (*defaultController)(nil)
 
 func (controller *defaultController) Reconcile(ctx context.Context, ingress *extensions.Ingress) (*LoadBalancer, error) {
+	ctx, span := tracing.Start(ctx, "LoadBalancer.Reconcile", attribute.String("k8s.ingress", k8s.MetaNamespaceKey(ingress)))
+	instance, err := controller.reconcile(ctx, ingress)
+	tracing.End(span, err)
+	return instance, err
+}
+
+func (controller *defaultController) reconcile(ctx context.Context, ingress *extensions.Ingress) (*LoadBalancer, error) {
 	ingressAnnos, err := controller.store.GetIngressAnnotations(k8s.MetaNamespaceKey(ingress))
 	if err != nil {
 		return nil, err
@@ -93,100 +124,270 @@ func (controller *defaultController) Reconcile(ctx context.Context, ingress *ext
 
 	lbConfig, err := controller.buildLBConfig(ctx, ingress, ingressAnnos)
 	if err != nil {
-		return nil, fmt.Errorf("failed to build LoadBalancer configuration due to %v", err)
+		return nil, fmt.Errorf("failed to build LoadBalancer configuration due to %w", err)
 	}
 	if err := controller.validateLBConfig(ctx, ingress, lbConfig); err != nil {
 		return nil, err
 	}
+	ctx = albctx.SetLBLocker(ctx, controller.lbLocks.Locker(lbConfig.Name))
 
 	ingKey := k8s.NamespacedName(ingress)
-	sgAttachment, err := controller.sgAssociationController.Setup(ctx, ingKey)
-	if err != nil {
+	if err := controller.migrateStack(ctx, ingKey); err != nil {
 		return nil, err
 	}
-	instance, err := controller.ensureLBInstance(ctx, lbConfig, sgAttachment)
-	if err != nil {
-		return nil, err
+	// securityGroups of adopted LoadBalancers are left to their owner, like external securityGroups.
+	existing := ingressAnnos.LoadBalancer.ExistingLoadBalancer
+	var instance *elbv2.LoadBalancer
+	var sgAttachment sg.LbAttachmentInfo
+	var adoptedPorts []int64
+	if existing != nil {
+		instance, adoptedPorts, err = controller.ensureAdoptedLBInstance(ctx, ingress, lbConfig, existing, listenPorts(ingressAnnos))
+		if err != nil {
+			return nil, err
+		}
+		ctx = albctx.SetLBLocker(ctx, controller.lbLocks.Locker(aws.StringValue(instance.LoadBalancerName)))
+	} else {
+		sgAttachment, err = controller.sgAssociationController.Setup(ctx, ingKey)
+		if err != nil {
+			return nil, err
+		}
+		instance, err = controller.ensureLBInstance(ctx, ingKey, lbConfig, sgAttachment)
+		if err != nil {
+			return nil, err
+		}
 	}
 	lbArn := aws.StringValue(instance.LoadBalancerArn)
-	if err := controller.attrsController.Reconcile(ctx, lbArn, ingressAnnos.LoadBalancer.Attributes); err != nil {
-		return nil, fmt.Errorf("failed to reconcile attributes of %v due to %v", lbArn, err)
-	}
-
-	if controller.store.GetConfig().FeatureGate.Enabled(config.WAF) {
-		if err := controller.wafController.Reconcile(ctx, lbArn, ingress); err != nil {
+	ctx = albctx.SetLogger(ctx, albctx.GetLogger(ctx).WithValues("loadBalancerArn", lbArn))
+	if existing == nil || existing.Scope == loadbalancer.AdoptionScopeLoadBalancer {
+		if err := controller.reconcileLBSettings(ctx, lbArn, lbConfig, ingress, ingressAnnos); err != nil {
 			return nil, err
 		}
 	}
 
 	tgGroup, err := controller.tgGroupController.Reconcile(ctx, ingress)
 	if err != nil {
-		return nil, fmt.Errorf("failed to reconcile targetGroups due to %v", err)
+		return nil, fmt.Errorf("failed to reconcile targetGroups due to %w", err)
 	}
 	if err := controller.lsGroupController.Reconcile(ctx, lbArn, ingress, tgGroup); err != nil {
-		return nil, fmt.Errorf("failed to reconcile listeners due to %v", err)
+		return nil, fmt.Errorf("failed to reconcile listeners due to %w", err)
+	}
+	if existing != nil {
+		if err := controller.finishAdoption(ctx, lbArn, adoptedPorts, listenPorts(ingressAnnos)); err != nil {
+			return nil, err
+		}
 	}
 	if err := controller.tgGroupController.GC(ctx, tgGroup); err != nil {
-		return nil, fmt.Errorf("failed to GC targetGroups due to %v", err)
+		return nil, fmt.Errorf("failed to GC targetGroups due to %w", err)
+	}
+	// certificates imported from secrets the ingress no longer references are detached by now, and cleaned up best-effort.
+	if controller.certImporter != nil {
+		if err := controller.certImporter.GC(ctx, ingKey, ls.TLSSecretKeys(ingress)); err != nil {
+			albctx.GetLogger(ctx).Warnf("failed to GC imported certificates due to %v", err)
+		}
 	}
 
-	if err := controller.sgAssociationController.Reconcile(ctx, ingKey, sgAttachment, instance, tgGroup); err != nil {
-		return nil, fmt.Errorf("failed to reconcile securityGroup associations due to %v", err)
+	if existing == nil {
+		if err := controller.sgAssociationController.Reconcile(ctx, ingKey, sgAttachment, instance, tgGroup); err != nil {
+			return nil, fmt.Errorf("failed to reconcile securityGroup associations due to %w", err)
+		}
 	}
+	var tgArns []string
+	tgServices := make(map[string]string)
+	for backend, targetGroup := range tgGroup.TGByBackend {
+		tgArns = append(tgArns, targetGroup.Arn)
+		tgServices[targetGroup.Arn] = backend.ServiceName
+	}
+	sort.Strings(tgArns)
 	return &LoadBalancer{
-		Arn:     lbArn,
-		DNSName: aws.StringValue(instance.DNSName),
+		Arn:                 lbArn,
+		DNSName:             aws.StringValue(instance.DNSName),
+		HostedZoneID:        aws.StringValue(instance.CanonicalHostedZoneId),
+		State:               StateCode(instance),
+		TargetGroupArns:     tgArns,
+		TargetGroupServices: tgServices,
 	}, nil
 }
 
+// reconcileLBSettings reconciles the attributes, WAF and Shield protection of the LoadBalancer with lbArn.
+func (controller *defaultController) reconcileLBSettings(ctx context.Context, lbArn string, lbConfig *loadBalancerConfig, ingress *extensions.Ingress, ingressAnnos *annotations.Ingress) error {
+	if err := controller.attrsController.Reconcile(ctx, lbArn, ingressAnnos.LoadBalancer.Attributes); err != nil {
+		return fmt.Errorf("failed to reconcile attributes of %v due to %w", lbArn, err)
+	}
+
+	if controller.store.GetConfig().FeatureGate.Enabled(config.WAF) {
+		if err := controller.wafController.Reconcile(ctx, lbArn, ingress); err != nil {
+			return err
+		}
+	}
+	if controller.store.GetConfig().FeatureGate.Enabled(config.WAFV2) {
+		if err := controller.wafv2Controller.Reconcile(ctx, lbArn, ingress); err != nil {
+			return err
+		}
+	}
+	return controller.shieldController.Reconcile(ctx, lbArn, lbConfig.Name, ingress)
+}
+
 func (controller *defaultController) Delete(ctx context.Context, ingressKey types.NamespacedName) error {
+	if err := controller.deleteLB(ctx, ingressKey); err != nil {
+		return err
+	}
+
+	if shard.IsFailover(ingressKey.Name) || shard.IsGreen(ingressKey.Name) {
+		return nil
+	}
+	// shards are numbered consecutively, so any shard following the deleted one is deleted as well.
+	name, idx := shard.Parse(ingressKey.Name)
+	parentKey := types.NamespacedName{Namespace: ingressKey.Namespace, Name: name}
+	for idx = idx + 1; ; idx++ {
+		shardKey := shard.Key(parentKey, idx)
+		instance, err := controller.findLBInstance(ctx, shardKey)
+		if err != nil {
+			return fmt.Errorf("failed to find existing LoadBalancer due to %w", err)
+		}
+		if instance == nil {
+			return nil
+		}
+		if err := controller.deleteLB(ctx, shardKey); err != nil {
+			return err
+		}
+	}
+}
+
+func (controller *defaultController) deleteLB(ctx context.Context, ingressKey types.NamespacedName) error {
+	controller.setStackMigrated(ingressKey, false)
 	lbName := controller.nameTagGen.NameLB(ingressKey.Namespace, ingressKey.Name)
-	instance, err := controller.cloud.GetLoadBalancerByName(ctx, lbName)
+	ctx = albctx.SetLBLocker(ctx, controller.lbLocks.Locker(lbName))
+	instance, err := controller.findLBInstance(ctx, ingressKey)
 	if err != nil {
-		return fmt.Errorf("failed to find existing LoadBalancer due to %v", err)
+		return fmt.Errorf("failed to find existing LoadBalancer due to %w", err)
 	}
 	if instance != nil {
+		retain, err := controller.isLBRetained(ctx, aws.StringValue(instance.LoadBalancerArn))
+		if err != nil {
+			return err
+		}
+		if retain {
+			return controller.retainLB(ctx, ingressKey, instance)
+		}
 		if err = controller.lsGroupController.Delete(ctx, aws.StringValue(instance.LoadBalancerArn)); err != nil {
-			return fmt.Errorf("failed to delete listeners due to %v", err)
+			return fmt.Errorf("failed to delete listeners due to %w", err)
 		}
 		if err = controller.tgGroupController.Delete(ctx, ingressKey); err != nil {
-			return fmt.Errorf("failed to GC targetGroups due to %v", err)
+			return fmt.Errorf("failed to GC targetGroups due to %w", err)
+		}
+
+		// protections outlive the LoadBalancer, failures are ignored since Shield Advanced is optional.
+		if err = controller.shieldController.Delete(ctx, aws.StringValue(instance.LoadBalancerArn)); err != nil {
+			albctx.GetLogger(ctx).Warnf("failed to clean up Shield protection due to %v", err)
+		}
+
+		// deletion protection guards against deletions outside the controller, not against deletion of the ingress.
+		if err = controller.attrsController.DisableDeletionProtection(ctx, aws.StringValue(instance.LoadBalancerArn)); err != nil {
+			return err
 		}
 
 		albctx.GetLogger(ctx).Infof("deleting LoadBalancer %v", aws.StringValue(instance.LoadBalancerArn))
-		if err = controller.cloud.DeleteLoadBalancerByArn(ctx, aws.StringValue(instance.LoadBalancerArn)); err != nil {
+		lbLocker := albctx.GetLBLocker(ctx)
+		lbLocker.Lock()
+		err = controller.cloud.DeleteLoadBalancerByArn(ctx, aws.StringValue(instance.LoadBalancerArn))
+		lbLocker.Unlock()
+		if err != nil {
 			return err
 		}
 	}
+	if instance == nil {
+		if err = controller.releaseAdoptedLBInstances(ctx, ingressKey); err != nil {
+			return err
+		}
+		if err = controller.tgGroupController.Delete(ctx, ingressKey); err != nil {
+			return fmt.Errorf("failed to GC targetGroups due to %w", err)
+		}
+	}
 	if err = controller.sgAssociationController.Delete(ctx, ingressKey); err != nil {
-		return fmt.Errorf("failed to clean up securityGroups due to %v", err)
+		return fmt.Errorf("failed to clean up securityGroups due to %w", err)
+	}
+	if controller.certImporter != nil {
+		if err = controller.certImporter.GC(ctx, ingressKey, nil); err != nil {
+			return fmt.Errorf("failed to clean up imported certificates due to %w", err)
+		}
 	}
 
 	return nil
 }
 
-func (controller *defaultController) ensureLBInstance(ctx context.Context, lbConfig *loadBalancerConfig, sgAttachment sg.LbAttachmentInfo) (*elbv2.LoadBalancer, error) {
-	instance, err := controller.cloud.GetLoadBalancerByName(ctx, lbConfig.Name)
+// listenPorts returns the ports of listeners desired by ingressAnnos.
+func listenPorts(ingressAnnos *annotations.Ingress) []int64 {
+	ports := make([]int64, 0, len(ingressAnnos.LoadBalancer.Ports))
+	for _, port := range ingressAnnos.LoadBalancer.Ports {
+		ports = append(ports, port.Port)
+	}
+	return ports
+}
+
+// StateCode returns the state code of instance, or empty string if it's unknown.
+func StateCode(instance *elbv2.LoadBalancer) string {
+	if instance.State == nil {
+		return ""
+	}
+	return aws.StringValue(instance.State.Code)
+}
+
+// findLBInstance returns the existing LoadBalancer of ingress, or nil if it doesn't exist.
+// LoadBalancers are looked up by the tags owned by this controller, and by name as fallback since the tagging API is eventually consistent
+// and won't return LoadBalancers that are just created.
+func (controller *defaultController) findLBInstance(ctx context.Context, ingressKey types.NamespacedName) (*elbv2.LoadBalancer, error) {
+	lbName := controller.nameTagGen.NameLB(ingressKey.Namespace, ingressKey.Name)
+	tagFilters := make(map[string][]string)
+	for k, v := range controller.nameTagGen.TagLB(ingressKey.Namespace, ingressKey.Name) {
+		tagFilters[k] = []string{v}
+	}
+	// LoadBalancers of older stacks don't carry the current stack version yet.
+	delete(tagFilters, TagKeyStackVersion)
+	instances, err := controller.cloud.GetLoadBalancersByTags(ctx, tagFilters)
 	if err != nil {
-		return nil, fmt.Errorf("failed to find existing LoadBalancer due to %v", err)
+		return nil, err
+	}
+	for _, instance := range instances {
+		if aws.StringValue(instance.LoadBalancerName) == lbName {
+			return instance, nil
+		}
+	}
+	return controller.cloud.GetLoadBalancerByName(ctx, lbName)
+}
+
+func (controller *defaultController) ensureLBInstance(ctx context.Context, ingressKey types.NamespacedName, lbConfig *loadBalancerConfig, sgAttachment sg.LbAttachmentInfo) (*elbv2.LoadBalancer, error) {
+	lbLocker := albctx.GetLBLocker(ctx)
+	lbLocker.Lock()
+	defer lbLocker.Unlock()
+
+	instance, err := controller.findLBInstance(ctx, ingressKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find existing LoadBalancer due to %w", err)
 	}
 	if instance == nil {
+		// an ingress that stopped adopting an existing LoadBalancer releases it before creating its own.
+		if err := controller.releaseAdoptedLBInstances(ctx, ingressKey); err != nil {
+			return nil, err
+		}
 		instance, err = controller.newLBInstance(ctx, lbConfig, sgAttachment)
 		if err != nil {
-			return nil, fmt.Errorf("failed to create LoadBalancer due to %v", err)
+			return nil, fmt.Errorf("failed to create LoadBalancer due to %w", err)
 		}
 		return instance, nil
 	}
 	if controller.isLBInstanceNeedRecreation(ctx, instance, lbConfig) {
 		instance, err = controller.recreateLBInstance(ctx, instance, lbConfig, sgAttachment)
 		if err != nil {
-			return nil, fmt.Errorf("failed to recreate LoadBalancer due to %v", err)
+			return nil, fmt.Errorf("failed to recreate LoadBalancer due to %w", err)
 		}
 		return instance, nil
 	}
 	if err := controller.reconcileLBInstance(ctx, instance, lbConfig); err != nil {
 		return nil, err
 	}
+	if err := controller.tagsController.ReconcileELB(ctx, aws.StringValue(instance.LoadBalancerArn), lbConfig.Tags); err != nil {
+		return nil, fmt.Errorf("failed to reconcile tags of %v due to %w", aws.StringValue(instance.LoadBalancerArn), err)
+	}
 	return instance, nil
 }
 
@@ -202,6 +403,14 @@ func (controller *defaultController) newLBInstance(ctx context.Context, lbConfig
 		Tags:           tags.ConvertToELBV2(lbConfig.Tags),
 	})
 	if err != nil {
+		instance, findErr := controller.findPartiallyCreatedLBInstance(ctx, lbConfig, err)
+		if findErr != nil {
+			albctx.GetEventf(ctx)(corev1.EventTypeWarning, "ERROR", "failed to create LoadBalancer %v due to %v", lbConfig.Name, findErr)
+			return nil, findErr
+		}
+		if instance != nil {
+			return instance, nil
+		}
 		albctx.GetLogger(ctx).Errorf("failed to create LoadBalancer %v due to %v", lbConfig.Name, err)
 		albctx.GetEventf(ctx)(corev1.EventTypeWarning, "ERROR", "failed to create LoadBalancer %v due to %v", lbConfig.Name, err)
 		return nil, err
@@ -213,6 +422,32 @@ func (controller *defaultController) newLBInstance(ctx context.Context, lbConfig
 	return instance, nil
 }
 
+// findPartiallyCreatedLBInstance returns the LoadBalancer named by lbConfig if its creation failed because it already exists.
+// This happens when a reconcile is interrupted right after creating the LoadBalancer, and the next reconcile missed it when looking it up,
+// its creation is then resumed instead of failing on the name conflict.
+// It fails if the cluster, namespace or ingress tags of the LoadBalancer don't match, since it may belong to another cluster or ingress.
+func (controller *defaultController) findPartiallyCreatedLBInstance(ctx context.Context, lbConfig *loadBalancerConfig, createErr error) (*elbv2.LoadBalancer, error) {
+	if awsErr, ok := createErr.(awserr.Error); !ok || awsErr.Code() != elbv2.ErrCodeDuplicateLoadBalancerNameException {
+		return nil, nil
+	}
+	instance, err := controller.cloud.GetLoadBalancerByName(ctx, lbConfig.Name)
+	if err != nil || instance == nil {
+		return nil, nil
+	}
+	lbArn := aws.StringValue(instance.LoadBalancerArn)
+	curTags, err := controller.getLBTags(ctx, lbArn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tags of %v due to %w", lbArn, err)
+	}
+	for _, k := range sets.StringKeySet(lbConfig.Tags).List() {
+		if strings.HasPrefix(k, "kubernetes.io/") && curTags[k] != lbConfig.Tags[k] {
+			return nil, fmt.Errorf("existing LoadBalancer %v isn't owned by the ingress, its tag %v is %q instead of %q", lbArn, k, curTags[k], lbConfig.Tags[k])
+		}
+	}
+	albctx.GetLogger(ctx).Infof("resuming creation of existing LoadBalancer %v, ARN: %v", lbConfig.Name, lbArn)
+	return instance, nil
+}
+
 func (controller *defaultController) recreateLBInstance(ctx context.Context, existingInstance *elbv2.LoadBalancer, lbConfig *loadBalancerConfig, sgAttachment sg.LbAttachmentInfo) (*elbv2.LoadBalancer, error) {
 	existingLBArn := aws.StringValue(existingInstance.LoadBalancerArn)
 	albctx.GetLogger(ctx).Infof("deleting LoadBalancer %v for recreation", existingLBArn)
@@ -231,9 +466,10 @@ func (controller *defaultController) reconcileLBInstance(ctx context.Context, in
 			IpAddressType:   lbConfig.IpAddressType,
 		}); err != nil {
 			albctx.GetEventf(ctx)(corev1.EventTypeNormal, "ERROR", "failed to modify IpAddressType of %v due to %v", lbArn, err)
-			return fmt.Errorf("failed to modify IpAddressType of %v due to %v", lbArn, err)
+			return fmt.Errorf("failed to modify IpAddressType of %v due to %w", lbArn, err)
 		}
-		albctx.GetEventf(ctx)(corev1.EventTypeNormal, "MODIFY", "IpAddressType of %v modified", lbArn)
+		albctx.GetEventf(ctx)(corev1.EventTypeNormal, "MODIFY", "LoadBalancer %v modified: IpAddressType: %v -> %v",
+			lbArn, aws.StringValue(instance.IpAddressType), aws.StringValue(lbConfig.IpAddressType))
 	}
 
 	desiredSubnets := sets.NewString(lbConfig.Subnets...)
@@ -245,17 +481,19 @@ func (controller *defaultController) reconcileLBInstance(ctx context.Context, in
 			Subnets:         aws.StringSlice(lbConfig.Subnets),
 		}); err != nil {
 			albctx.GetEventf(ctx)(corev1.EventTypeNormal, "ERROR", "failed to modify Subnets of %v due to %v", lbArn, err)
-			return fmt.Errorf("failed to modify Subnets of %v due to %v", lbArn, err)
+			return fmt.Errorf("failed to modify Subnets of %v due to %w", lbArn, err)
 		}
-	}
-
-	if err := controller.tagsController.ReconcileELB(ctx, lbArn, lbConfig.Tags); err != nil {
-		return fmt.Errorf("failed to reconcile tags of %v due to %v", lbArn, err)
+		albctx.GetEventf(ctx)(corev1.EventTypeNormal, "MODIFY", "LoadBalancer %v modified: Subnets: %v -> %v", lbArn, currentSubnets.List(), desiredSubnets.List())
 	}
 	return nil
 }
 
 func (controller *defaultController) isLBInstanceNeedRecreation(ctx context.Context, instance *elbv2.LoadBalancer, lbConfig *loadBalancerConfig) bool {
+	if instance.State != nil && aws.StringValue(instance.State.Code) == elbv2.LoadBalancerStateEnumFailed {
+		albctx.GetLogger(ctx).Infof("LoadBalancer %s need recreation due to failed provisioning(%s)",
+			lbConfig.Name, aws.StringValue(instance.State.Reason))
+		return true
+	}
 	if !util.DeepEqual(instance.Scheme, lbConfig.Scheme) {
 		albctx.GetLogger(ctx).Infof("LoadBalancer %s need recreation due to scheme changed(%s => %s)",
 			lbConfig.Name, aws.StringValue(instance.Scheme), aws.StringValue(lbConfig.Scheme))
@@ -269,9 +507,23 @@ func (controller *defaultController) buildLBConfig(ctx context.Context, ingress
 	for k, v := range ingressAnnos.Tags.LoadBalancer {
 		lbTags[k] = v
 	}
-	subnets, err := controller.resolveSubnets(ctx, aws.StringValue(ingressAnnos.LoadBalancer.Scheme), ingressAnnos.LoadBalancer.Subnets)
-	if err != nil {
-		return nil, err
+	if ingressAnnos.LoadBalancer.DeletionPolicy == loadbalancer.DeletionPolicyRetain {
+		lbTags[TagKeyDeletionPolicy] = loadbalancer.DeletionPolicyRetain
+	}
+	subnetNameOrIDs := ingressAnnos.LoadBalancer.Subnets
+	if failover := ingressAnnos.LoadBalancer.Failover; failover != nil && shard.IsFailover(ingress.Name) {
+		lbTags[TagKeyFailoverRecord] = failover.HostedZoneID + "/" + failover.RecordName
+		if len(failover.Subnets) != 0 {
+			subnetNameOrIDs = failover.Subnets
+		}
+	}
+	// subnets of LoadBalancers adopted for their listeners are left to their owner.
+	var subnets []string
+	if existing := ingressAnnos.LoadBalancer.ExistingLoadBalancer; existing == nil || existing.Scope == loadbalancer.AdoptionScopeLoadBalancer {
+		var err error
+		if subnets, err = controller.resolveSubnets(ctx, aws.StringValue(ingressAnnos.LoadBalancer.Scheme), subnetNameOrIDs); err != nil {
+			return nil, err
+		}
 	}
 
 	return &loadBalancerConfig{
@@ -289,8 +541,9 @@ func (controller *defaultController) validateLBConfig(ctx context.Context, ingre
 	controllerCfg := controller.store.GetConfig()
 	if controllerCfg.RestrictScheme && aws.StringValue(lbConfig.Scheme) == elbv2.LoadBalancerSchemeEnumInternetFacing {
 		whitelisted := false
+		ingressName := shard.ParentName(ingress.Name)
 		for _, name := range controllerCfg.InternetFacingIngresses[ingress.Namespace] {
-			if name == ingress.Name {
+			if name == ingressName {
 				whitelisted = true
 				break
 			}
@@ -299,7 +552,36 @@ func (controller *defaultController) validateLBConfig(ctx context.Context, ingre
 			return fmt.Errorf("ingress %v/%v is not in internetFacing whitelist", ingress.Namespace, ingress.Name)
 		}
 	}
+	if aws.StringValue(lbConfig.IpAddressType) == elbv2.IpAddressTypeDualstack {
+		return controller.validateDualstackSubnets(ctx, lbConfig.Subnets)
+	}
+
+	return nil
+}
 
+// validateDualstackSubnets ensures every subnet has an IPv6 CIDR, which dualstack LoadBalancers require.
+func (controller *defaultController) validateDualstackSubnets(ctx context.Context, subnetIDs []string) error {
+	subnets, err := controller.cloud.GetSubnetsByNameOrID(ctx, subnetIDs)
+	if err != nil {
+		return err
+	}
+	var ipv4Only []string
+	for _, subnet := range subnets {
+		hasIPv6 := false
+		for _, association := range subnet.Ipv6CidrBlockAssociationSet {
+			if association.Ipv6CidrBlockState != nil && aws.StringValue(association.Ipv6CidrBlockState.State) == ec2.SubnetCidrBlockStateCodeAssociated {
+				hasIPv6 = true
+				break
+			}
+		}
+		if !hasIPv6 {
+			ipv4Only = append(ipv4Only, aws.StringValue(subnet.SubnetId))
+		}
+	}
+	if len(ipv4Only) != 0 {
+		sort.Strings(ipv4Only)
+		return fmt.Errorf("subnets %v have no IPv6 CIDR, which is required by ip-address-type %v", strings.Join(ipv4Only, ","), elbv2.IpAddressTypeDualstack)
+	}
 	return nil
 }
 
@@ -355,7 +637,7 @@ func (controller *defaultController) clusterSubnets(ctx context.Context, scheme
 
 	clusterSubnets, err := controller.cloud.GetClusterSubnets(key)
 	if err != nil {
-		return nil, fmt.Errorf("unable to fetch subnets. Error: %s", err.Error())
+		return nil, fmt.Errorf("unable to fetch subnets. Error: %w", err)
 	}
 
 	for _, subnet := range clusterSubnets {
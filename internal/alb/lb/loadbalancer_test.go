@@ -1 +1,180 @@
 package lb
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_findPartiallyCreatedLBInstance(t *testing.T) {
+	existing := &elbv2.LoadBalancer{LoadBalancerArn: aws.String("lbArn"), LoadBalancerName: aws.String("name")}
+	lbTags := map[string]string{
+		"kubernetes.io/cluster/cluster": "owned",
+		"kubernetes.io/namespace":       "namespace",
+		"kubernetes.io/ingress-name":    "ingress",
+		"env":                           "prod",
+	}
+	for _, tc := range []struct {
+		name             string
+		createErr        error
+		existingInstance *elbv2.LoadBalancer
+		existingTags     map[string]string
+		expectedInstance *elbv2.LoadBalancer
+		expectedErr      string
+	}{
+		{
+			name:             "LoadBalancer created by an interrupted reconcile",
+			createErr:        awserr.New(elbv2.ErrCodeDuplicateLoadBalancerNameException, "duplicate", nil),
+			existingInstance: existing,
+			existingTags: map[string]string{
+				"kubernetes.io/cluster/cluster": "owned",
+				"kubernetes.io/namespace":       "namespace",
+				"kubernetes.io/ingress-name":    "ingress",
+			},
+			expectedInstance: existing,
+		},
+		{
+			name:             "LoadBalancer of another ingress",
+			createErr:        awserr.New(elbv2.ErrCodeDuplicateLoadBalancerNameException, "duplicate", nil),
+			existingInstance: existing,
+			existingTags: map[string]string{
+				"kubernetes.io/cluster/cluster": "owned",
+				"kubernetes.io/namespace":       "other",
+				"kubernetes.io/ingress-name":    "ingress",
+			},
+			expectedErr: `existing LoadBalancer lbArn isn't owned by the ingress, its tag kubernetes.io/namespace is "other" instead of "namespace"`,
+		},
+		{
+			name:             "LoadBalancer not created by the controller",
+			createErr:        awserr.New(elbv2.ErrCodeDuplicateLoadBalancerNameException, "duplicate", nil),
+			existingInstance: existing,
+			expectedErr:      `existing LoadBalancer lbArn isn't owned by the ingress, its tag kubernetes.io/cluster/cluster is "" instead of "owned"`,
+		},
+		{
+			name:      "LoadBalancer deleted since the conflict",
+			createErr: awserr.New(elbv2.ErrCodeDuplicateLoadBalancerNameException, "duplicate", nil),
+		},
+		{
+			name:      "other creation failure",
+			createErr: errors.New("failed"),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			cloud := &mocks.CloudAPI{}
+			cloud.On("GetLoadBalancerByName", ctx, "name").Return(tc.existingInstance, nil)
+			if tc.existingInstance != nil {
+				var existingTags []*elbv2.Tag
+				for k, v := range tc.existingTags {
+					existingTags = append(existingTags, &elbv2.Tag{Key: aws.String(k), Value: aws.String(v)})
+				}
+				cloud.On("DescribeELBV2TagsWithContext", ctx, &elbv2.DescribeTagsInput{ResourceArns: aws.StringSlice([]string{"lbArn"})}).Return(&elbv2.DescribeTagsOutput{
+					TagDescriptions: []*elbv2.TagDescription{{ResourceArn: aws.String("lbArn"), Tags: existingTags}},
+				}, nil)
+			}
+
+			controller := &defaultController{cloud: cloud}
+			instance, err := controller.findPartiallyCreatedLBInstance(ctx, &loadBalancerConfig{Name: "name", Tags: lbTags}, tc.createErr)
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedInstance, instance)
+		})
+	}
+}
+
+func Test_isLBInstanceNeedRecreation(t *testing.T) {
+	lbConfig := &loadBalancerConfig{Name: "name", Scheme: aws.String(elbv2.LoadBalancerSchemeEnumInternal)}
+	for _, tc := range []struct {
+		name     string
+		instance *elbv2.LoadBalancer
+		expected bool
+	}{
+		{
+			name: "provisioning LoadBalancer",
+			instance: &elbv2.LoadBalancer{
+				Scheme: aws.String(elbv2.LoadBalancerSchemeEnumInternal),
+				State:  &elbv2.LoadBalancerState{Code: aws.String(elbv2.LoadBalancerStateEnumProvisioning)},
+			},
+			expected: false,
+		},
+		{
+			name: "LoadBalancer failed provisioning",
+			instance: &elbv2.LoadBalancer{
+				Scheme: aws.String(elbv2.LoadBalancerSchemeEnumInternal),
+				State:  &elbv2.LoadBalancerState{Code: aws.String(elbv2.LoadBalancerStateEnumFailed)},
+			},
+			expected: true,
+		},
+		{
+			name: "scheme changed",
+			instance: &elbv2.LoadBalancer{
+				Scheme: aws.String(elbv2.LoadBalancerSchemeEnumInternetFacing),
+			},
+			expected: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			controller := &defaultController{}
+			assert.Equal(t, tc.expected, controller.isLBInstanceNeedRecreation(context.Background(), tc.instance, lbConfig))
+		})
+	}
+}
+
+func Test_validateDualstackSubnets(t *testing.T) {
+	associated := func(subnetID string, state string) *ec2.Subnet {
+		subnet := &ec2.Subnet{SubnetId: aws.String(subnetID)}
+		if state != "" {
+			subnet.Ipv6CidrBlockAssociationSet = []*ec2.SubnetIpv6CidrBlockAssociation{
+				{Ipv6CidrBlockState: &ec2.SubnetCidrBlockState{State: aws.String(state)}},
+			}
+		}
+		return subnet
+	}
+	for _, tc := range []struct {
+		name        string
+		subnets     []*ec2.Subnet
+		expectedErr string
+	}{
+		{
+			name: "all subnets have IPv6 CIDRs",
+			subnets: []*ec2.Subnet{
+				associated("subnet-a", ec2.SubnetCidrBlockStateCodeAssociated),
+				associated("subnet-b", ec2.SubnetCidrBlockStateCodeAssociated),
+			},
+		},
+		{
+			name: "subnets without associated IPv6 CIDRs",
+			subnets: []*ec2.Subnet{
+				associated("subnet-c", ""),
+				associated("subnet-a", ec2.SubnetCidrBlockStateCodeAssociated),
+				associated("subnet-b", ec2.SubnetCidrBlockStateCodeDisassociated),
+			},
+			expectedErr: "subnets subnet-b,subnet-c have no IPv6 CIDR, which is required by ip-address-type dualstack",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			subnetIDs := []string{"subnet-a", "subnet-b", "subnet-c"}
+			cloud := &mocks.CloudAPI{}
+			cloud.On("GetSubnetsByNameOrID", ctx, subnetIDs).Return(tc.subnets, nil)
+
+			controller := &defaultController{cloud: cloud}
+			err := controller.validateDualstackSubnets(ctx, subnetIDs)
+			if tc.expectedErr == "" {
+				assert.NoError(t, err)
+			} else if assert.Error(t, err) {
+				assert.Equal(t, tc.expectedErr, err.Error())
+			}
+		})
+	}
+}
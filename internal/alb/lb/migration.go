@@ -0,0 +1,156 @@
+package lb
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tags"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// TagKeyStackVersion is the tag on every resource managed for an ingress, that records the version of the stack of resources.
+// Stacks created before the tag was introduced are of version 1.
+const TagKeyStackVersion = "ingress.k8s.aws/stack-version"
+
+// CurrentStackVersion is the version of stacks managed by this controller, older stacks are migrated by stackMigrations.
+const CurrentStackVersion = 2
+
+// stackMigration converges a stack of an ingress to the next version in place.
+// Migrations must never recreate the LoadBalancer, since that changes its DNS name.
+type stackMigration struct {
+	description string
+	migrate     func(ctx context.Context, controller *defaultController, ingressKey types.NamespacedName, instance *elbv2.LoadBalancer) error
+}
+
+// stackMigrations migrate stacks to CurrentStackVersion, the migration at index i migrates stacks of version i+1.
+var stackMigrations = []stackMigration{
+	{
+		description: "adopt targetGroups without ownership tags",
+		migrate:     adoptLegacyTargetGroups,
+	},
+}
+
+// migrateStack migrates the existing stack of ingress to CurrentStackVersion.
+// The version is recorded after each migration, so an interrupted upgrade resumes from the failed migration.
+// Stacks found at CurrentStackVersion are remembered, so that their version is only looked up once after the controller starts.
+func (controller *defaultController) migrateStack(ctx context.Context, ingressKey types.NamespacedName) error {
+	if controller.isStackMigrated(ingressKey) {
+		return nil
+	}
+	lbLocker := albctx.GetLBLocker(ctx)
+	lbLocker.Lock()
+	defer lbLocker.Unlock()
+
+	instance, err := controller.findLBInstance(ctx, ingressKey)
+	if err != nil {
+		return fmt.Errorf("failed to find existing LoadBalancer due to %w", err)
+	}
+	// new stacks are tagged with CurrentStackVersion once created.
+	if instance == nil {
+		return nil
+	}
+	lbArn := aws.StringValue(instance.LoadBalancerArn)
+	version, err := controller.getStackVersion(ctx, lbArn)
+	if err != nil {
+		return err
+	}
+	if version > CurrentStackVersion {
+		return fmt.Errorf("LoadBalancer %v is of stack version %v, newer than version %v supported by this controller", lbArn, version, CurrentStackVersion)
+	}
+
+	for ; version < CurrentStackVersion; version++ {
+		migration := stackMigrations[version-1]
+		albctx.GetLogger(ctx).Infof("migrating stack of %v from version %v to %v: %v", lbArn, version, version+1, migration.description)
+		if err := migration.migrate(ctx, controller, ingressKey, instance); err != nil {
+			albctx.GetEventf(ctx)(corev1.EventTypeWarning, "ERROR", "failed to migrate stack of %v to version %v due to %v", lbArn, version+1, err)
+			return fmt.Errorf("failed to migrate stack of %v to version %v due to %w", lbArn, version+1, err)
+		}
+		if _, err := controller.cloud.AddELBV2TagsWithContext(ctx, &elbv2.AddTagsInput{
+			ResourceArns: aws.StringSlice([]string{lbArn}),
+			Tags:         tags.ConvertToELBV2(map[string]string{TagKeyStackVersion: strconv.Itoa(version + 1)}),
+		}); err != nil {
+			return fmt.Errorf("failed to record stack version of %v due to %w", lbArn, err)
+		}
+		albctx.GetEventf(ctx)(corev1.EventTypeNormal, "MIGRATE", "stack of %v migrated to version %v: %v", lbArn, version+1, migration.description)
+	}
+	// a dry-run only plans the migrations.
+	if albctx.GetPlan(ctx) == nil {
+		controller.setStackMigrated(ingressKey, true)
+	}
+	return nil
+}
+
+func (controller *defaultController) isStackMigrated(ingressKey types.NamespacedName) bool {
+	controller.migratedStacksMutex.Lock()
+	defer controller.migratedStacksMutex.Unlock()
+	return controller.migratedStacks[ingressKey]
+}
+
+// setStackMigrated records whether the stack of ingress is at CurrentStackVersion, it's forgotten once the stack is deleted.
+func (controller *defaultController) setStackMigrated(ingressKey types.NamespacedName, migrated bool) {
+	controller.migratedStacksMutex.Lock()
+	defer controller.migratedStacksMutex.Unlock()
+	if !migrated {
+		delete(controller.migratedStacks, ingressKey)
+		return
+	}
+	if controller.migratedStacks == nil {
+		controller.migratedStacks = make(map[types.NamespacedName]bool)
+	}
+	controller.migratedStacks[ingressKey] = true
+}
+
+// getStackVersion returns the stack version recorded on LoadBalancer.
+func (controller *defaultController) getStackVersion(ctx context.Context, lbArn string) (int, error) {
+	resp, err := controller.cloud.DescribeELBV2TagsWithContext(ctx, &elbv2.DescribeTagsInput{
+		ResourceArns: aws.StringSlice([]string{lbArn}),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to describe tags of %v due to %w", lbArn, err)
+	}
+	for _, desc := range resp.TagDescriptions {
+		for _, tag := range desc.Tags {
+			if aws.StringValue(tag.Key) != TagKeyStackVersion {
+				continue
+			}
+			version, err := strconv.Atoi(aws.StringValue(tag.Value))
+			if err != nil || version < 1 {
+				return 0, fmt.Errorf("invalid stack version %v on %v", aws.StringValue(tag.Value), lbArn)
+			}
+			return version, nil
+		}
+	}
+	return 1, nil
+}
+
+// adoptLegacyTargetGroups adds the ownership tags of the stack to targetGroups that only carry the kubernetes.io/ tags,
+// which were created before the ingress.k8s.aws/ tags were introduced, so they're garbage collected with the stack.
+func adoptLegacyTargetGroups(ctx context.Context, controller *defaultController, ingressKey types.NamespacedName, instance *elbv2.LoadBalancer) error {
+	ownershipTags := controller.nameTagGen.TagTGGroup(ingressKey.Namespace, ingressKey.Name)
+	legacyTagFilters := make(map[string][]string)
+	for k, v := range ownershipTags {
+		if strings.HasPrefix(k, "kubernetes.io/") {
+			legacyTagFilters[k] = []string{v}
+		}
+	}
+	tgArns, err := controller.cloud.GetResourcesByFilters(legacyTagFilters, aws.ResourceTypeEnumELBTargetGroup)
+	if err != nil {
+		return fmt.Errorf("failed to get targetGroups by tags due to %w", err)
+	}
+	for _, tgArn := range tgArns {
+		albctx.GetLogger(ctx).Infof("adding ownership tags to targetGroup %v", tgArn)
+		if _, err := controller.cloud.AddELBV2TagsWithContext(ctx, &elbv2.AddTagsInput{
+			ResourceArns: aws.StringSlice([]string{tgArn}),
+			Tags:         tags.ConvertToELBV2(ownershipTags),
+		}); err != nil {
+			return fmt.Errorf("failed to tag targetGroup %v due to %w", tgArn, err)
+		}
+	}
+	return nil
+}
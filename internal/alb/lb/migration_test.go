@@ -0,0 +1,104 @@
+package lb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+type fakeNameTagGenerator struct{}
+
+func (fakeNameTagGenerator) NameLB(namespace string, ingressName string) string {
+	return namespace + "-" + ingressName
+}
+
+func (fakeNameTagGenerator) TagLB(namespace string, ingressName string) map[string]string {
+	return map[string]string{"ingress.k8s.aws/stack": namespace + "/" + ingressName}
+}
+
+func (fakeNameTagGenerator) TagTGGroup(namespace string, ingressName string) map[string]string {
+	return map[string]string{"ingress.k8s.aws/stack": namespace + "/" + ingressName}
+}
+
+func (fakeNameTagGenerator) TagAdoptedLB(namespace string, ingressName string) map[string]string {
+	return nil
+}
+
+func Test_stackMigrations(t *testing.T) {
+	assert.Equal(t, CurrentStackVersion, len(stackMigrations)+1, "each stack version except the first must have a migration")
+}
+
+func Test_getStackVersion(t *testing.T) {
+	for _, tc := range []struct {
+		name            string
+		tags            []*elbv2.Tag
+		expectedVersion int
+		expectedErr     bool
+	}{
+		{
+			name:            "stack without version",
+			tags:            []*elbv2.Tag{{Key: aws.String("ingress.k8s.aws/stack"), Value: aws.String("namespace/ingress")}},
+			expectedVersion: 1,
+		},
+		{
+			name:            "stack with version",
+			tags:            []*elbv2.Tag{{Key: aws.String(TagKeyStackVersion), Value: aws.String("2")}},
+			expectedVersion: 2,
+		},
+		{
+			name:        "stack with invalid version",
+			tags:        []*elbv2.Tag{{Key: aws.String(TagKeyStackVersion), Value: aws.String("v2")}},
+			expectedErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			cloud := &mocks.CloudAPI{}
+			cloud.On("DescribeELBV2TagsWithContext", ctx, &elbv2.DescribeTagsInput{
+				ResourceArns: aws.StringSlice([]string{"lbArn"}),
+			}).Return(&elbv2.DescribeTagsOutput{
+				TagDescriptions: []*elbv2.TagDescription{{ResourceArn: aws.String("lbArn"), Tags: tc.tags}},
+			}, nil)
+
+			controller := &defaultController{cloud: cloud}
+			version, err := controller.getStackVersion(ctx, "lbArn")
+			if tc.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedVersion, version)
+			}
+		})
+	}
+}
+
+func Test_migrateStack_migrated(t *testing.T) {
+	ctx := context.Background()
+	ingressKey := types.NamespacedName{Namespace: "namespace", Name: "ingress"}
+	cloud := &mocks.CloudAPI{}
+	cloud.On("GetLoadBalancersByTags", ctx, map[string][]string{"ingress.k8s.aws/stack": {"namespace/ingress"}}).Return([]*elbv2.LoadBalancer{
+		{LoadBalancerName: aws.String("namespace-ingress"), LoadBalancerArn: aws.String("lbArn")},
+	}, nil)
+	cloud.On("DescribeELBV2TagsWithContext", ctx, &elbv2.DescribeTagsInput{
+		ResourceArns: aws.StringSlice([]string{"lbArn"}),
+	}).Return(&elbv2.DescribeTagsOutput{
+		TagDescriptions: []*elbv2.TagDescription{{ResourceArn: aws.String("lbArn"), Tags: []*elbv2.Tag{
+			{Key: aws.String(TagKeyStackVersion), Value: aws.String("2")},
+		}}},
+	}, nil)
+
+	controller := &defaultController{cloud: cloud, nameTagGen: fakeNameTagGenerator{}}
+	assert.NoError(t, controller.migrateStack(ctx, ingressKey))
+	assert.NoError(t, controller.migrateStack(ctx, ingressKey))
+	cloud.AssertNumberOfCalls(t, "DescribeELBV2TagsWithContext", 1)
+
+	// a stack recreated after deletion is looked up again.
+	controller.setStackMigrated(ingressKey, false)
+	assert.NoError(t, controller.migrateStack(ctx, ingressKey))
+	cloud.AssertNumberOfCalls(t, "DescribeELBV2TagsWithContext", 2)
+}
@@ -0,0 +1,63 @@
+package lb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tags"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/loadbalancer"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// isLBRetained returns whether the LoadBalancer with lbArn is tagged to outlive its ingress.
+func (controller *defaultController) isLBRetained(ctx context.Context, lbArn string) (bool, error) {
+	curTags, err := controller.getLBTags(ctx, lbArn)
+	if err != nil {
+		return false, fmt.Errorf("failed to get tags of %v due to %w", lbArn, err)
+	}
+	return curTags[TagKeyDeletionPolicy] == loadbalancer.DeletionPolicyRetain, nil
+}
+
+// retainLB leaves the LoadBalancer of ingress in place with its listeners, targetGroups and securityGroups, so that its DNS name
+// keeps resolving after the ingress is deleted. Their ownership tags are removed, so that nothing garbage collects them afterwards.
+// TagKeyDeletionPolicy is kept on the LoadBalancer, which makes retainLB idempotent since the LoadBalancer is still found by name.
+func (controller *defaultController) retainLB(ctx context.Context, ingressKey types.NamespacedName, instance *elbv2.LoadBalancer) error {
+	lbArn := aws.StringValue(instance.LoadBalancerArn)
+	tgTags := controller.nameTagGen.TagTGGroup(ingressKey.Namespace, ingressKey.Name)
+	tagFilters := make(map[string][]string)
+	for k, v := range tgTags {
+		tagFilters[k] = []string{v}
+	}
+	tgArns, err := controller.cloud.GetResourcesByFilters(tagFilters, aws.ResourceTypeEnumELBTargetGroup)
+	if err != nil {
+		return fmt.Errorf("failed to get targetGroups by tags due to %w", err)
+	}
+	tgTagKeys := append(tags.OwnershipKeys(tgTags), TagKeyStackVersion)
+	for _, tgArn := range tgArns {
+		albctx.GetLogger(ctx).Infof("retaining targetGroup %v, removing its ownership tags", tgArn)
+		if _, err := controller.cloud.RemoveELBV2TagsWithContext(ctx, &elbv2.RemoveTagsInput{
+			ResourceArns: aws.StringSlice([]string{tgArn}),
+			TagKeys:      aws.StringSlice(tgTagKeys),
+		}); err != nil {
+			return fmt.Errorf("failed to untag targetGroup %v due to %w", tgArn, err)
+		}
+	}
+	if err := controller.sgAssociationController.Retain(ctx, ingressKey); err != nil {
+		return fmt.Errorf("failed to retain securityGroups due to %w", err)
+	}
+
+	albctx.GetLogger(ctx).Infof("retaining LoadBalancer %v, removing its ownership tags", lbArn)
+	lbLocker := albctx.GetLBLocker(ctx)
+	lbLocker.Lock()
+	defer lbLocker.Unlock()
+	if _, err := controller.cloud.RemoveELBV2TagsWithContext(ctx, &elbv2.RemoveTagsInput{
+		ResourceArns: aws.StringSlice([]string{lbArn}),
+		TagKeys:      aws.StringSlice(tags.OwnershipKeys(controller.nameTagGen.TagLB(ingressKey.Namespace, ingressKey.Name))),
+	}); err != nil {
+		return fmt.Errorf("failed to untag LoadBalancer %v due to %w", lbArn, err)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package lb
+
+import (
+	"context"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+)
+
+// ShieldController provides functionality to manage Shield Advanced protection of ALBs.
+type ShieldController interface {
+	// Reconcile creates or deletes the protection of LoadBalancer per the `shield-advanced-protection` annotation.
+	// LoadBalancers of ingresses without the annotation are left untouched.
+	Reconcile(ctx context.Context, lbArn string, lbName string, ingress *extensions.Ingress) error
+
+	// Delete deletes the protection of LoadBalancer if there is any.
+	Delete(ctx context.Context, lbArn string) error
+}
+
+func NewShieldController(cloud aws.CloudAPI) ShieldController {
+	return &defaultShieldController{
+		cloud: cloud,
+	}
+}
+
+type defaultShieldController struct {
+	cloud aws.CloudAPI
+}
+
+func (c *defaultShieldController) Reconcile(ctx context.Context, lbArn string, lbName string, ing *extensions.Ingress) error {
+	var desiredProtection bool
+	exists, err := annotations.LoadBoolAnnotation("shield-advanced-protection", &desiredProtection, ing.Annotations)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return nil
+	}
+	protection, err := c.cloud.GetShieldProtection(ctx, aws.String(lbArn))
+	if err != nil {
+		albctx.GetEventf(ctx)(corev1.EventTypeWarning, "ERROR", "failed to get Shield protection of LoadBalancer %v due to %v", lbArn, err)
+		return errors.Wrapf(err, "failed to get Shield protection of LoadBalancer %v", lbArn)
+	}
+
+	switch {
+	case desiredProtection && protection == nil:
+		albctx.GetLogger(ctx).Infof("enabling Shield protection of %v", lbArn)
+		resp, err := c.cloud.CreateShieldProtection(ctx, aws.String(lbName), aws.String(lbArn))
+		if err != nil {
+			albctx.GetEventf(ctx)(corev1.EventTypeWarning, "ERROR", "failed to enable Shield protection of LoadBalancer %v due to %v", lbArn, err)
+			return errors.Wrapf(err, "failed to enable Shield protection of LoadBalancer %v", lbArn)
+		}
+		albctx.GetEventf(ctx)(corev1.EventTypeNormal, "CREATE", "Shield protection %v enabled for LoadBalancer %v", aws.StringValue(resp.ProtectionId), lbArn)
+	case !desiredProtection && protection != nil:
+		return c.deleteProtection(ctx, lbArn, aws.StringValue(protection.Id))
+	}
+	return nil
+}
+
+func (c *defaultShieldController) Delete(ctx context.Context, lbArn string) error {
+	protection, err := c.cloud.GetShieldProtection(ctx, aws.String(lbArn))
+	if err != nil {
+		return errors.Wrapf(err, "failed to get Shield protection of LoadBalancer %v", lbArn)
+	}
+	if protection == nil {
+		return nil
+	}
+	return c.deleteProtection(ctx, lbArn, aws.StringValue(protection.Id))
+}
+
+func (c *defaultShieldController) deleteProtection(ctx context.Context, lbArn string, protectionID string) error {
+	albctx.GetLogger(ctx).Infof("disabling Shield protection %v of %v", protectionID, lbArn)
+	if _, err := c.cloud.DeleteShieldProtection(ctx, aws.String(protectionID)); err != nil {
+		albctx.GetEventf(ctx)(corev1.EventTypeWarning, "ERROR", "failed to disable Shield protection of LoadBalancer %v due to %v", lbArn, err)
+		return errors.Wrapf(err, "failed to disable Shield protection of LoadBalancer %v", lbArn)
+	}
+	albctx.GetEventf(ctx)(corev1.EventTypeNormal, "DELETE", "Shield protection %v disabled for LoadBalancer %v", protectionID, lbArn)
+	return nil
+}
@@ -0,0 +1,84 @@
+package lb
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/shield"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
+	"github.com/stretchr/testify/assert"
+	extensions "k8s.io/api/extensions/v1beta1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_defaultShieldController_Reconcile(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		annotations  map[string]string
+		protection   *shield.Protection
+		getErr       error
+		expectCreate bool
+		expectDelete bool
+		expectedErr  bool
+	}{
+		{
+			name:        "ingress without shield settings",
+			annotations: map[string]string{},
+		},
+		{
+			name:         "enable protection",
+			annotations:  map[string]string{parser.AnnotationsPrefix + "/shield-advanced-protection": "true"},
+			expectCreate: true,
+		},
+		{
+			name:        "protection already enabled",
+			annotations: map[string]string{parser.AnnotationsPrefix + "/shield-advanced-protection": "true"},
+			protection:  &shield.Protection{Id: aws.String("protectionID")},
+		},
+		{
+			name:         "disable protection",
+			annotations:  map[string]string{parser.AnnotationsPrefix + "/shield-advanced-protection": "false"},
+			protection:   &shield.Protection{Id: aws.String("protectionID")},
+			expectDelete: true,
+		},
+		{
+			name:        "invalid annotation",
+			annotations: map[string]string{parser.AnnotationsPrefix + "/shield-advanced-protection": "yes please"},
+			expectedErr: true,
+		},
+		{
+			name:        "failed to get protection",
+			annotations: map[string]string{parser.AnnotationsPrefix + "/shield-advanced-protection": "true"},
+			getErr:      errors.New("AccessDenied"),
+			expectedErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			cloud := &mocks.CloudAPI{}
+			cloud.On("GetShieldProtection", ctx, aws.String("lbArn")).Return(tc.protection, tc.getErr)
+			if tc.expectCreate {
+				cloud.On("CreateShieldProtection", ctx, aws.String("lbName"), aws.String("lbArn")).Return(&shield.CreateProtectionOutput{ProtectionId: aws.String("protectionID")}, nil)
+			}
+			if tc.expectDelete {
+				cloud.On("DeleteShieldProtection", ctx, aws.String("protectionID")).Return(&shield.DeleteProtectionOutput{}, nil)
+			}
+
+			c := NewShieldController(cloud)
+			err := c.Reconcile(ctx, "lbArn", "lbName", &extensions.Ingress{
+				ObjectMeta: v1.ObjectMeta{Name: "ingress", Annotations: tc.annotations},
+			})
+			if tc.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			if tc.expectCreate || tc.expectDelete {
+				cloud.AssertExpectations(t)
+			}
+		})
+	}
+}
@@ -1,9 +1,30 @@
 package lb
 
+// TagKeyFailoverRecord is the tag on secondary LoadBalancers, that records the Route 53 failover record as `hostedZoneID/recordName`.
+const TagKeyFailoverRecord = "ingress.k8s.aws/failover-record"
+
+// TagKeyAdoptedBy is the tag on existing LoadBalancers adopted by an ingress, that records the ingress as `cluster/namespace/name`.
+const TagKeyAdoptedBy = "ingress.k8s.aws/adopted-by"
+
+// TagKeyAdoptedListeners is the tag on adopted LoadBalancers, that records the ports of listeners managed for the ingress, separated by spaces.
+const TagKeyAdoptedListeners = "ingress.k8s.aws/adopted-listeners"
+
+// TagKeyDeletionPolicy is the tag on LoadBalancers retained once their ingress is deleted, since the ingress and its annotations
+// may be gone by the time it's deleted.
+const TagKeyDeletionPolicy = "ingress.k8s.aws/deletion-policy"
+
 // LoadBalancer contains information of LoadBalancer in AWS
 type LoadBalancer struct {
-	Arn     string
-	DNSName string
+	Arn          string
+	DNSName      string
+	HostedZoneID string
+	// State is the state code of the LoadBalancer, e.g. provisioning or active.
+	State string
+
+	// TargetGroupArns are the targetGroups used by listeners of the LoadBalancer.
+	TargetGroupArns []string
+	// TargetGroupServices are the names of backend services, keyed by targetGroup ARN.
+	TargetGroupServices map[string]string
 }
 
 // NameGenerator generates name for loadBalancer resources
@@ -14,6 +35,12 @@ type NameGenerator interface {
 // TagGenerator generates tags for loadBalancer resources
 type TagGenerator interface {
 	TagLB(namespace string, ingressName string) map[string]string
+
+	// TagTGGroup generates the ownership tags of targetGroups, which are adopted by stack migrations.
+	TagTGGroup(namespace string, ingressName string) map[string]string
+
+	// TagAdoptedLB generates the tags recording the ingress that adopted an existing LoadBalancer.
+	TagAdoptedLB(namespace string, ingressName string) map[string]string
 }
 
 // NameTagGenerator combines NameGenerator & TagGenerator
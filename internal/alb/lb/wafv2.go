@@ -0,0 +1,94 @@
+package lb
+
+import (
+	"context"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+)
+
+// WAFv2Controller provides functionality to manage ALB's WAFv2 associations.
+type WAFv2Controller interface {
+	Reconcile(ctx context.Context, lbArn string, ingress *extensions.Ingress) error
+}
+
+func NewWAFv2Controller(cloud aws.CloudAPI) WAFv2Controller {
+	return &defaultWAFv2Controller{
+		cloud: cloud,
+	}
+}
+
+type defaultWAFv2Controller struct {
+	cloud aws.CloudAPI
+}
+
+// webACLArnNone is the `wafv2-acl-arn` annotation value that disassociates any webACL from the LoadBalancer.
+const webACLArnNone = "none"
+
+// Reconcile converges the WAFv2 webACL associated with LoadBalancer to the `wafv2-acl-arn` annotation.
+// Without the annotation, the association is left alone, so that webACLs associated out-of-band, e.g. by Firewall Manager, are kept.
+// Otherwise it's described on every reconcile rather than cached, so that associations changed out-of-band are restored.
+func (c *defaultWAFv2Controller) Reconcile(ctx context.Context, lbArn string, ing *extensions.Ingress) error {
+	desiredWebACLArn, managed, err := c.getDesiredWebACLArn(ing)
+	if err != nil {
+		return err
+	}
+	if !managed {
+		return nil
+	}
+	currentWebACL, err := c.cloud.GetWAFv2WebACLForResource(ctx, aws.String(lbArn))
+	if err != nil {
+		return errors.Wrapf(err, "failed to get WAFv2 webACL for LoadBalancer %v", lbArn)
+	}
+	var currentWebACLArn string
+	if currentWebACL != nil {
+		currentWebACLArn = aws.StringValue(currentWebACL.ARN)
+	}
+	if desiredWebACLArn == currentWebACLArn {
+		return nil
+	}
+
+	if desiredWebACLArn == "" {
+		albctx.GetLogger(ctx).Infof("disassociate WAFv2 webACL %v on %v", currentWebACLArn, lbArn)
+		if _, err := c.cloud.DisassociateWAFv2(ctx, aws.String(lbArn)); err != nil {
+			albctx.GetEventf(ctx)(corev1.EventTypeWarning, "ERROR", "failed to disassociate WAFv2 webACL on LoadBalancer %v due to %v", lbArn, err)
+			return errors.Wrapf(err, "failed to disassociate WAFv2 webACL on LoadBalancer %v", lbArn)
+		}
+		albctx.GetEventf(ctx)(corev1.EventTypeNormal, "MODIFY", "WAFv2 webACL %v disassociated from LoadBalancer %v", currentWebACLArn, lbArn)
+		return nil
+	}
+
+	if currentWebACLArn == "" {
+		albctx.GetLogger(ctx).Infof("associate WAFv2 webACL on %v to %v", lbArn, desiredWebACLArn)
+	} else {
+		albctx.GetLogger(ctx).Infof("associate WAFv2 webACL on %v from %v to %v", lbArn, currentWebACLArn, desiredWebACLArn)
+	}
+	if _, err := c.cloud.AssociateWAFv2(ctx, aws.String(lbArn), aws.String(desiredWebACLArn)); err != nil {
+		albctx.GetEventf(ctx)(corev1.EventTypeWarning, "ERROR", "failed to associate WAFv2 webACL %v on LoadBalancer %v due to %v", desiredWebACLArn, lbArn, err)
+		return errors.Wrapf(err, "failed to associate WAFv2 webACL on LoadBalancer %v", lbArn)
+	}
+	albctx.GetEventf(ctx)(corev1.EventTypeNormal, "MODIFY", "WAFv2 webACL %v associated with LoadBalancer %v", desiredWebACLArn, lbArn)
+	return nil
+}
+
+// getDesiredWebACLArn returns the webACL ARN of ingress, "" when it's disassociated, and whether the association is managed at all.
+func (c *defaultWAFv2Controller) getDesiredWebACLArn(ing *extensions.Ingress) (string, bool, error) {
+	var webACLArn, webACLId string
+	managed := annotations.LoadStringAnnotation("wafv2-acl-arn", &webACLArn, ing.Annotations)
+	_ = annotations.LoadStringAnnotation("waf-acl-id", &webACLId, ing.Annotations)
+	_ = annotations.LoadStringAnnotation("web-acl-id", &webACLId, ing.Annotations)
+	if !managed {
+		return "", false, nil
+	}
+	if webACLArn == webACLArnNone {
+		webACLArn = ""
+	}
+	if webACLArn != "" && webACLId != "" {
+		return "", false, errors.New("wafv2-acl-arn cannot be used together with waf-acl-id")
+	}
+	return webACLArn, true, nil
+}
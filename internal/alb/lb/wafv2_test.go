@@ -0,0 +1,99 @@
+package lb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/wafv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
+	"github.com/stretchr/testify/assert"
+	extensions "k8s.io/api/extensions/v1beta1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_defaultWAFv2Controller_Reconcile(t *testing.T) {
+	const webACLArn1 = "arn:aws:wafv2:us-west-2:123456789012:regional/webacl/acl-1/11111111-1111-1111-1111-111111111111"
+	const webACLArn2 = "arn:aws:wafv2:us-west-2:123456789012:regional/webacl/acl-2/22222222-2222-2222-2222-222222222222"
+	for _, tc := range []struct {
+		name               string
+		annotations        map[string]string
+		currentWebACL      *wafv2.WebACL
+		expectAssociate    *string
+		expectDisassociate bool
+		unmanaged          bool
+		expectedErr        string
+	}{
+		{
+			name:          "ingress without wafv2 settings",
+			annotations:   map[string]string{},
+			currentWebACL: nil,
+			unmanaged:     true,
+		},
+		{
+			name:            "associate webACL",
+			annotations:     map[string]string{parser.AnnotationsPrefix + "/wafv2-acl-arn": webACLArn1},
+			currentWebACL:   nil,
+			expectAssociate: aws.String(webACLArn1),
+		},
+		{
+			name:          "webACL already associated",
+			annotations:   map[string]string{parser.AnnotationsPrefix + "/wafv2-acl-arn": webACLArn1},
+			currentWebACL: &wafv2.WebACL{ARN: aws.String(webACLArn1)},
+		},
+		{
+			name:            "webACL changed out-of-band",
+			annotations:     map[string]string{parser.AnnotationsPrefix + "/wafv2-acl-arn": webACLArn1},
+			currentWebACL:   &wafv2.WebACL{ARN: aws.String(webACLArn2)},
+			expectAssociate: aws.String(webACLArn1),
+		},
+		{
+			name:          "webACL associated out-of-band is left alone",
+			annotations:   map[string]string{},
+			currentWebACL: &wafv2.WebACL{ARN: aws.String(webACLArn1)},
+			unmanaged:     true,
+		},
+		{
+			name:               "webACL disassociated",
+			annotations:        map[string]string{parser.AnnotationsPrefix + "/wafv2-acl-arn": "none"},
+			currentWebACL:      &wafv2.WebACL{ARN: aws.String(webACLArn1)},
+			expectDisassociate: true,
+		},
+		{
+			name: "used together with waf classic",
+			annotations: map[string]string{
+				parser.AnnotationsPrefix + "/wafv2-acl-arn": webACLArn1,
+				parser.AnnotationsPrefix + "/waf-acl-id":    "my-web-acl-id",
+			},
+			expectedErr: "wafv2-acl-arn cannot be used together with waf-acl-id",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			cloud := &mocks.CloudAPI{}
+			cloud.On("GetWAFv2WebACLForResource", ctx, aws.String("lbArn")).Return(tc.currentWebACL, nil)
+			if tc.expectAssociate != nil {
+				cloud.On("AssociateWAFv2", ctx, aws.String("lbArn"), tc.expectAssociate).Return(&wafv2.AssociateWebACLOutput{}, nil)
+			}
+			if tc.expectDisassociate {
+				cloud.On("DisassociateWAFv2", ctx, aws.String("lbArn")).Return(&wafv2.DisassociateWebACLOutput{}, nil)
+			}
+
+			c := NewWAFv2Controller(cloud)
+			err := c.Reconcile(ctx, "lbArn", &extensions.Ingress{
+				ObjectMeta: v1.ObjectMeta{Name: "ingress", Annotations: tc.annotations},
+			})
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			if tc.unmanaged {
+				cloud.AssertNotCalled(t, "GetWAFv2WebACLForResource", ctx, aws.String("lbArn"))
+				return
+			}
+			cloud.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,224 @@
+package lcu
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/generator"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/metric"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/metric/collectors"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/log"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+const (
+	// the window of CloudWatch metrics used for each estimation.
+	estimationWindow = 5 * time.Minute
+
+	// maximum number of load balancers per GetMetricData call, each load balancer takes one query per metric.
+	describeMetricsBatchSize = 100
+	// maximum number of resources per DescribeTags call.
+	describeTagsBatchSize = 20
+
+	// LCU capacities, see https://aws.amazon.com/elasticloadbalancing/pricing/
+	newConnectionsPerSecondPerLCU    = 25
+	activeConnectionsPerMinutePerLCU = 3000
+	processedBytesPerHourPerLCU      = 1000 * 1000 * 1000
+	ruleEvaluationsPerSecondPerLCU   = 1000
+)
+
+// LCU dimensions and the CloudWatch metric of ALBs they're estimated from.
+var dimensions = []struct {
+	name       string
+	metricName string
+}{
+	{collectors.LCUDimensionNewConnections, "NewConnectionCount"},
+	{collectors.LCUDimensionActiveConnections, "ActiveConnectionCount"},
+	{collectors.LCUDimensionProcessedBytes, "ProcessedBytes"},
+	{collectors.LCUDimensionRuleEvaluations, "RuleEvaluations"},
+}
+
+// NewEstimator constructs a runnable that periodically estimates the LCU consumption of ALBs managed for clusterName.
+func NewEstimator(cloud aws.CloudAPI, mc metric.Collector, clusterName string, interval time.Duration) manager.Runnable {
+	return &estimator{
+		cloud:       cloud,
+		mc:          mc,
+		clusterName: clusterName,
+		interval:    interval,
+		logger:      log.New("lcu-estimator"),
+	}
+}
+
+type estimator struct {
+	cloud       aws.CloudAPI
+	mc          metric.Collector
+	clusterName string
+	interval    time.Duration
+	logger      *log.Logger
+}
+
+type loadBalancer struct {
+	arn         string
+	namespace   string
+	ingressName string
+}
+
+// Start implements manager.Runnable
+func (e *estimator) Start(stop <-chan struct{}) error {
+	wait.Until(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), e.interval)
+		defer cancel()
+		if err := e.estimate(ctx, time.Now()); err != nil {
+			e.logger.Errorf("failed to estimate LCU usage due to %v", err)
+		}
+	}, e.interval, stop)
+	return nil
+}
+
+func (e *estimator) estimate(ctx context.Context, now time.Time) error {
+	lbs, err := e.listLoadBalancers(ctx)
+	if err != nil {
+		return err
+	}
+
+	end := now.Truncate(time.Minute)
+	start := end.Add(-estimationWindow)
+	var usages []collectors.LCUUsage
+	for i := 0; i < len(lbs); i += describeMetricsBatchSize {
+		batch := lbs[i:minInt(i+describeMetricsBatchSize, len(lbs))]
+		results, err := e.cloud.GetMetricData(ctx, buildMetricDataInput(batch, start, end))
+		if err != nil {
+			return fmt.Errorf("failed to get metric data due to %w", err)
+		}
+		usages = append(usages, buildLCUUsages(batch, results, estimationWindow)...)
+	}
+	e.mc.SetLCUUsage(usages)
+	return nil
+}
+
+// listLoadBalancers returns the ALBs owned by the cluster, together with the ingress they belongs to.
+func (e *estimator) listLoadBalancers(ctx context.Context) ([]loadBalancer, error) {
+	arns, err := e.cloud.GetResourcesByFilters(map[string][]string{
+		"kubernetes.io/cluster/" + e.clusterName: {"owned"},
+	}, aws.ResourceTypeEnumELBLoadBalancer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get load balancers by tags due to %w", err)
+	}
+
+	var lbs []loadBalancer
+	for i := 0; i < len(arns); i += describeTagsBatchSize {
+		resp, err := e.cloud.DescribeELBV2TagsWithContext(ctx, &elbv2.DescribeTagsInput{
+			ResourceArns: aws.StringSlice(arns[i:minInt(i+describeTagsBatchSize, len(arns))]),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe tags of load balancers due to %w", err)
+		}
+		for _, desc := range resp.TagDescriptions {
+			// LCU estimation only applies to ALBs, e.g. NetworkLoadBalancers providing static IPs are excluded.
+			if !strings.HasPrefix(metricDimension(aws.StringValue(desc.ResourceArn)), "app/") {
+				continue
+			}
+			lb := loadBalancer{arn: aws.StringValue(desc.ResourceArn)}
+			for _, tag := range desc.Tags {
+				switch aws.StringValue(tag.Key) {
+				case generator.TagKeyNamespace:
+					lb.namespace = aws.StringValue(tag.Value)
+				case generator.TagKeyIngressName:
+					lb.ingressName = aws.StringValue(tag.Value)
+				}
+			}
+			lbs = append(lbs, lb)
+		}
+	}
+	return lbs, nil
+}
+
+func buildMetricDataInput(lbs []loadBalancer, start time.Time, end time.Time) *cloudwatch.GetMetricDataInput {
+	var queries []*cloudwatch.MetricDataQuery
+	for i, lb := range lbs {
+		for j, dimension := range dimensions {
+			queries = append(queries, &cloudwatch.MetricDataQuery{
+				Id: aws.String(queryID(i, j)),
+				MetricStat: &cloudwatch.MetricStat{
+					Metric: &cloudwatch.Metric{
+						Namespace:  aws.String("AWS/ApplicationELB"),
+						MetricName: aws.String(dimension.metricName),
+						Dimensions: []*cloudwatch.Dimension{
+							{
+								Name:  aws.String("LoadBalancer"),
+								Value: aws.String(metricDimension(lb.arn)),
+							},
+						},
+					},
+					Period: aws.Int64(int64(end.Sub(start).Seconds())),
+					Stat:   aws.String(cloudwatch.StatisticSum),
+				},
+			})
+		}
+	}
+	return &cloudwatch.GetMetricDataInput{
+		StartTime:         aws.Time(start),
+		EndTime:           aws.Time(end),
+		MetricDataQueries: queries,
+	}
+}
+
+// buildLCUUsages converts the sums of metrics over window into LCUs.
+func buildLCUUsages(lbs []loadBalancer, results []*cloudwatch.MetricDataResult, window time.Duration) []collectors.LCUUsage {
+	sums := make(map[string]float64, len(results))
+	for _, result := range results {
+		for _, v := range result.Values {
+			sums[aws.StringValue(result.Id)] += aws.Float64Value(v)
+		}
+	}
+
+	var usages []collectors.LCUUsage
+	for i, lb := range lbs {
+		usage := collectors.LCUUsage{
+			Namespace:    lb.namespace,
+			IngressName:  lb.ingressName,
+			LoadBalancer: metricDimension(lb.arn),
+			Dimensions:   make(map[string]float64, len(dimensions)),
+		}
+		for j, dimension := range dimensions {
+			sum := sums[queryID(i, j)]
+			switch dimension.name {
+			case collectors.LCUDimensionNewConnections:
+				usage.Dimensions[dimension.name] = sum / window.Seconds() / newConnectionsPerSecondPerLCU
+			case collectors.LCUDimensionActiveConnections:
+				usage.Dimensions[dimension.name] = sum / window.Minutes() / activeConnectionsPerMinutePerLCU
+			case collectors.LCUDimensionProcessedBytes:
+				usage.Dimensions[dimension.name] = sum / window.Hours() / processedBytesPerHourPerLCU
+			case collectors.LCUDimensionRuleEvaluations:
+				usage.Dimensions[dimension.name] = sum / window.Seconds() / ruleEvaluationsPerSecondPerLCU
+			}
+		}
+		usages = append(usages, usage)
+	}
+	return usages
+}
+
+func queryID(lbIndex int, dimensionIndex int) string {
+	return fmt.Sprintf("m%d_%d", lbIndex, dimensionIndex)
+}
+
+// metricDimension returns the LoadBalancer dimension of CloudWatch metrics for the load balancer with arn, e.g. app/my-lb/50dc6c495c0c9188
+func metricDimension(arn string) string {
+	if idx := strings.Index(arn, ":loadbalancer/"); idx >= 0 {
+		return arn[idx+len(":loadbalancer/"):]
+	}
+	return arn
+}
+
+func minInt(a int, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,105 @@
+package lcu
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/metric/collectors"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_metricDimension(t *testing.T) {
+	assert.Equal(t, "app/my-lb/50dc6c495c0c9188", metricDimension("arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/my-lb/50dc6c495c0c9188"))
+	assert.Equal(t, "app/my-lb/50dc6c495c0c9188", metricDimension("app/my-lb/50dc6c495c0c9188"))
+}
+
+func Test_buildLCUUsages(t *testing.T) {
+	lbs := []loadBalancer{
+		{
+			arn:         "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/lb1/0123456789abcdef",
+			namespace:   "namespace",
+			ingressName: "ingress1",
+		},
+		{
+			arn:         "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/lb2/0123456789abcdef",
+			namespace:   "namespace",
+			ingressName: "ingress2",
+		},
+	}
+	results := []*cloudwatch.MetricDataResult{
+		{Id: aws.String("m0_0"), Values: aws.Float64Slice([]float64{7500})},
+		{Id: aws.String("m0_1"), Values: aws.Float64Slice([]float64{3000, 4500})},
+		{Id: aws.String("m0_2"), Values: aws.Float64Slice([]float64{250 * 1000 * 1000})},
+		{Id: aws.String("m0_3"), Values: aws.Float64Slice([]float64{600000})},
+		{Id: aws.String("m1_0"), Values: aws.Float64Slice([]float64{})},
+	}
+
+	usages := buildLCUUsages(lbs, results, 5*time.Minute)
+	assert.Equal(t, []collectors.LCUUsage{
+		{
+			Namespace:    "namespace",
+			IngressName:  "ingress1",
+			LoadBalancer: "app/lb1/0123456789abcdef",
+			Dimensions: map[string]float64{
+				collectors.LCUDimensionNewConnections:    1,
+				collectors.LCUDimensionActiveConnections: 0.5,
+				collectors.LCUDimensionProcessedBytes:    3,
+				collectors.LCUDimensionRuleEvaluations:   2,
+			},
+		},
+		{
+			Namespace:    "namespace",
+			IngressName:  "ingress2",
+			LoadBalancer: "app/lb2/0123456789abcdef",
+			Dimensions: map[string]float64{
+				collectors.LCUDimensionNewConnections:    0,
+				collectors.LCUDimensionActiveConnections: 0,
+				collectors.LCUDimensionProcessedBytes:    0,
+				collectors.LCUDimensionRuleEvaluations:   0,
+			},
+		},
+	}, usages)
+}
+
+func Test_listLoadBalancers(t *testing.T) {
+	albArn := "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/alb/50dc6c495c0c9188"
+	// NLBs of LoadBalancer services are tagged for the cluster as well, but have no LCU metrics of ALBs.
+	nlbArn := "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/net/nlb/73e2d6bc24d8a067"
+	ctx := context.Background()
+	cloud := &mocks.CloudAPI{}
+	cloud.On("GetResourcesByFilters", map[string][]string{
+		"kubernetes.io/cluster/cluster": {"owned"},
+	}, aws.ResourceTypeEnumELBLoadBalancer).Return([]string{albArn, nlbArn}, nil)
+	cloud.On("DescribeELBV2TagsWithContext", ctx, &elbv2.DescribeTagsInput{
+		ResourceArns: aws.StringSlice([]string{albArn, nlbArn}),
+	}).Return(&elbv2.DescribeTagsOutput{
+		TagDescriptions: []*elbv2.TagDescription{
+			{
+				ResourceArn: aws.String(albArn),
+				Tags: []*elbv2.Tag{
+					{Key: aws.String("kubernetes.io/namespace"), Value: aws.String("namespace")},
+					{Key: aws.String("kubernetes.io/ingress-name"), Value: aws.String("ingress")},
+					{Key: aws.String("kubernetes.io/cluster/cluster"), Value: aws.String("owned")},
+				},
+			},
+			{
+				ResourceArn: aws.String(nlbArn),
+				Tags: []*elbv2.Tag{
+					{Key: aws.String("kubernetes.io/service-name"), Value: aws.String("namespace/service")},
+					{Key: aws.String("kubernetes.io/cluster/cluster"), Value: aws.String("owned")},
+				},
+			},
+		},
+	}, nil)
+
+	e := &estimator{cloud: cloud, clusterName: "cluster"}
+	lbs, err := e.listLoadBalancers(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []loadBalancer{{arn: albArn, namespace: "namespace", ingressName: "ingress"}}, lbs)
+	cloud.AssertExpectations(t)
+}
@@ -100,7 +100,11 @@ func (d *acmCertDiscovery) loadDomainsForCertificate(ctx context.Context, certAr
 	return domains, nil
 }
 
+// domainMatchesHost returns whether the certificate domainName covers tlsHost, which may be a wildcard host itself.
+// Both are compared case-insensitively, as DNS names.
 func (d *acmCertDiscovery) domainMatchesHost(domainName string, tlsHost string) bool {
+	domainName = strings.ToLower(domainName)
+	tlsHost = strings.ToLower(tlsHost)
 	if strings.HasPrefix(domainName, "*.") {
 		ds := strings.Split(domainName, ".")
 		hs := strings.Split(tlsHost, ".")
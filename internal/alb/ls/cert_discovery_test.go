@@ -193,6 +193,13 @@ func Test_domainMatchesHost(t *testing.T) {
 		{"*.example.com", "foo.example.com", true},
 		{"*.example.com", "example.com", false},
 		{"*.exampl0.com", "foo.example.com", false},
+		{"*.example.com", "*.example.com", true},
+		{"example.com", "*.example.com", false},
+		{"*.example.com", "foo.bar.example.com", false},
+
+		// DNS names are case-insensitive
+		{"Example.com", "example.COM", true},
+		{"*.example.com", "FOO.Example.com", true},
 
 		// invalid hosts, not sure these are possible
 		{"*.*.example.com", "foo.bar.example.com", false},
@@ -0,0 +1,219 @@
+package ls
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/acm"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// tags of imported certificates, following the v2 tagging scheme of generator, which cannot be imported here.
+const (
+	tagKeyClusterID    = "ingress.k8s.aws/cluster"
+	tagKeyStackID      = "ingress.k8s.aws/stack"
+	tagKeyResourceID   = "ingress.k8s.aws/resource"
+	tagKeySecretDigest = "ingress.k8s.aws/secret-digest"
+)
+
+// CertImporter imports the certificates of kubernetes.io/tls secrets into ACM.
+type CertImporter interface {
+	// Import returns the ARN of the ACM certificate imported from secret for ingress, which is re-imported when the secret changed.
+	Import(ctx context.Context, ingressKey types.NamespacedName, secret *corev1.Secret) (string, error)
+
+	// GC deletes the ACM certificates imported for ingress, whose secrets are not in inUse.
+	GC(ctx context.Context, ingressKey types.NamespacedName, inUse sets.String) error
+}
+
+// NewACMCertImporter constructs a CertImporter, whose imported certificates are tagged with clusterName.
+func NewACMCertImporter(cloud aws.CloudAPI, clusterName string) CertImporter {
+	return &acmCertImporter{
+		cloud:       cloud,
+		clusterName: clusterName,
+	}
+}
+
+type importedCert struct {
+	ingressKey types.NamespacedName
+	secretKey  types.NamespacedName
+	digest     string
+}
+
+type acmCertImporter struct {
+	cloud       aws.CloudAPI
+	clusterName string
+
+	// mutex guards importedCerts, which maps certificate ARNs to the secrets they're imported from.
+	mutex         sync.Mutex
+	importedCerts map[string]importedCert
+}
+
+func (i *acmCertImporter) Import(ctx context.Context, ingressKey types.NamespacedName, secret *corev1.Secret) (string, error) {
+	secretKey := types.NamespacedName{Namespace: secret.Namespace, Name: secret.Name}
+	certificate, chain, err := splitCertificateChain(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return "", fmt.Errorf("failed to parse certificate of secret %v due to %w", secretKey, err)
+	}
+	privateKey := secret.Data[corev1.TLSPrivateKeyKey]
+	digest := secretDigest(secret.Data[corev1.TLSCertKey], privateKey)
+
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	if err := i.loadImportedCerts(ctx); err != nil {
+		return "", err
+	}
+	var certArn string
+	for arn, cert := range i.importedCerts {
+		if cert.ingressKey == ingressKey && cert.secretKey == secretKey {
+			if cert.digest == digest {
+				return arn, nil
+			}
+			certArn = arn
+			break
+		}
+	}
+
+	input := &acm.ImportCertificateInput{
+		Certificate:      certificate,
+		CertificateChain: chain,
+		PrivateKey:       privateKey,
+	}
+	if certArn != "" {
+		albctx.GetLogger(ctx).Infof("re-importing certificate %v from secret %v", certArn, secretKey)
+		input.CertificateArn = aws.String(certArn)
+		if _, err := i.cloud.ImportCertificate(ctx, input); err != nil {
+			return "", fmt.Errorf("failed to re-import certificate %v from secret %v due to %w", certArn, secretKey, err)
+		}
+		if err := i.cloud.AddTagsToCertificate(ctx, certArn, []*acm.Tag{
+			{Key: aws.String(tagKeySecretDigest), Value: aws.String(digest)},
+		}); err != nil {
+			return "", fmt.Errorf("failed to tag certificate %v due to %w", certArn, err)
+		}
+	} else {
+		albctx.GetLogger(ctx).Infof("importing certificate from secret %v", secretKey)
+		input.Tags = []*acm.Tag{
+			{Key: aws.String(tagKeyClusterID), Value: aws.String(i.clusterName)},
+			{Key: aws.String(tagKeyStackID), Value: aws.String(ingressKey.String())},
+			{Key: aws.String(tagKeyResourceID), Value: aws.String(secretKey.String())},
+			{Key: aws.String(tagKeySecretDigest), Value: aws.String(digest)},
+		}
+		if certArn, err = i.cloud.ImportCertificate(ctx, input); err != nil {
+			return "", fmt.Errorf("failed to import certificate from secret %v due to %w", secretKey, err)
+		}
+		albctx.GetLogger(ctx).Infof("imported certificate %v from secret %v", certArn, secretKey)
+	}
+	i.importedCerts[certArn] = importedCert{ingressKey: ingressKey, secretKey: secretKey, digest: digest}
+	return certArn, nil
+}
+
+func (i *acmCertImporter) GC(ctx context.Context, ingressKey types.NamespacedName, inUse sets.String) error {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	if err := i.loadImportedCerts(ctx); err != nil {
+		return err
+	}
+	for arn, cert := range i.importedCerts {
+		if cert.ingressKey != ingressKey || inUse.Has(cert.secretKey.String()) {
+			continue
+		}
+		albctx.GetLogger(ctx).Infof("deleting certificate %v imported from secret %v", arn, cert.secretKey)
+		if err := i.cloud.DeleteCertificate(ctx, arn); err != nil {
+			if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == acm.ErrCodeResourceNotFoundException {
+				delete(i.importedCerts, arn)
+				continue
+			}
+			return fmt.Errorf("failed to delete certificate %v due to %w", arn, err)
+		}
+		delete(i.importedCerts, arn)
+	}
+	return nil
+}
+
+// loadImportedCerts discovers the certificates imported for this cluster once, they're tracked in memory afterwards.
+func (i *acmCertImporter) loadImportedCerts(ctx context.Context) error {
+	if i.importedCerts != nil {
+		return nil
+	}
+	summaries, err := i.cloud.ListCertificates(ctx, &acm.ListCertificatesInput{
+		Includes: &acm.Filters{
+			KeyTypes: aws.StringSlice([]string{
+				acm.KeyAlgorithmRsa1024, acm.KeyAlgorithmRsa2048, acm.KeyAlgorithmRsa4096,
+				acm.KeyAlgorithmEcPrime256v1, acm.KeyAlgorithmEcSecp384r1, acm.KeyAlgorithmEcSecp521r1,
+			}),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list certificates due to %w", err)
+	}
+	importedCerts := make(map[string]importedCert)
+	for _, summary := range summaries {
+		certArn := aws.StringValue(summary.CertificateArn)
+		tags, err := i.cloud.ListTagsForCertificate(ctx, certArn)
+		if err != nil {
+			return fmt.Errorf("failed to list tags of certificate %v due to %w", certArn, err)
+		}
+		tagMap := make(map[string]string, len(tags))
+		for _, tag := range tags {
+			tagMap[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+		}
+		if tagMap[tagKeyClusterID] != i.clusterName || tagMap[tagKeySecretDigest] == "" {
+			continue
+		}
+		importedCerts[certArn] = importedCert{
+			ingressKey: parseNamespacedName(tagMap[tagKeyStackID]),
+			secretKey:  parseNamespacedName(tagMap[tagKeyResourceID]),
+			digest:     tagMap[tagKeySecretDigest],
+		}
+	}
+	i.importedCerts = importedCerts
+	return nil
+}
+
+// splitCertificateChain splits the PEM encoded tls.crt into the leaf certificate and its chain of intermediates.
+func splitCertificateChain(data []byte) ([]byte, []byte, error) {
+	var certificate, chain []byte
+	for {
+		block, rest := pem.Decode(data)
+		if block == nil {
+			break
+		}
+		data = rest
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		if certificate == nil {
+			certificate = pem.EncodeToMemory(block)
+		} else {
+			chain = append(chain, pem.EncodeToMemory(block)...)
+		}
+	}
+	if certificate == nil {
+		return nil, nil, fmt.Errorf("no PEM encoded certificate found")
+	}
+	return certificate, chain, nil
+}
+
+func secretDigest(certificate []byte, privateKey []byte) string {
+	h := sha256.New()
+	h.Write(certificate)
+	h.Write(privateKey)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func parseNamespacedName(s string) types.NamespacedName {
+	parts := strings.SplitN(s, "/", 2)
+	if len(parts) != 2 {
+		return types.NamespacedName{Name: s}
+	}
+	return types.NamespacedName{Namespace: parts[0], Name: parts[1]}
+}
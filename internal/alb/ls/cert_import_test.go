@@ -0,0 +1,146 @@
+package ls
+
+import (
+	"context"
+	"encoding/pem"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/acm"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func Test_splitCertificateChain(t *testing.T) {
+	leaf := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("leaf")})
+	intermediate := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("intermediate")})
+	root := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("root")})
+
+	certificate, chain, err := splitCertificateChain(append(append(append([]byte{}, leaf...), intermediate...), root...))
+	assert.NoError(t, err)
+	assert.Equal(t, leaf, certificate)
+	assert.Equal(t, append(append([]byte{}, intermediate...), root...), chain)
+
+	_, _, err = splitCertificateChain([]byte("not pem"))
+	assert.EqualError(t, err, "no PEM encoded certificate found")
+}
+
+func TestACMCertImporter_Import(t *testing.T) {
+	ctx := context.Background()
+	ingressKey := types.NamespacedName{Namespace: "namespace", Name: "ingress"}
+	crt := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("leaf")})
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "namespace", Name: "tls-secret"},
+		Data:       map[string][]byte{"tls.crt": crt, "tls.key": []byte("key")},
+	}
+	digest := secretDigest(crt, []byte("key"))
+
+	for _, tc := range []struct {
+		Name           string
+		ExistingTags   []*acm.Tag
+		ExpectReimport bool
+		ExpectImport   bool
+	}{
+		{
+			Name:         "new secret is imported",
+			ExpectImport: true,
+		},
+		{
+			Name: "unchanged secret is not re-imported",
+			ExistingTags: []*acm.Tag{
+				{Key: aws.String(tagKeyClusterID), Value: aws.String("cluster")},
+				{Key: aws.String(tagKeyStackID), Value: aws.String("namespace/ingress")},
+				{Key: aws.String(tagKeyResourceID), Value: aws.String("namespace/tls-secret")},
+				{Key: aws.String(tagKeySecretDigest), Value: aws.String(digest)},
+			},
+		},
+		{
+			Name: "rotated secret is re-imported",
+			ExistingTags: []*acm.Tag{
+				{Key: aws.String(tagKeyClusterID), Value: aws.String("cluster")},
+				{Key: aws.String(tagKeyStackID), Value: aws.String("namespace/ingress")},
+				{Key: aws.String(tagKeyResourceID), Value: aws.String("namespace/tls-secret")},
+				{Key: aws.String(tagKeySecretDigest), Value: aws.String("outdated")},
+			},
+			ExpectReimport: true,
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			cloud := &mocks.CloudAPI{}
+			var summaries []*acm.CertificateSummary
+			if tc.ExistingTags != nil {
+				summaries = append(summaries, &acm.CertificateSummary{CertificateArn: aws.String("existingArn")})
+				cloud.On("ListTagsForCertificate", ctx, "existingArn").Return(tc.ExistingTags, nil)
+			}
+			cloud.On("ListCertificates", ctx, mock.Anything).Return(summaries, nil)
+			expectedArn := "existingArn"
+			if tc.ExpectImport {
+				expectedArn = "newArn"
+				cloud.On("ImportCertificate", ctx, &acm.ImportCertificateInput{
+					Certificate: crt,
+					PrivateKey:  []byte("key"),
+					Tags: []*acm.Tag{
+						{Key: aws.String(tagKeyClusterID), Value: aws.String("cluster")},
+						{Key: aws.String(tagKeyStackID), Value: aws.String("namespace/ingress")},
+						{Key: aws.String(tagKeyResourceID), Value: aws.String("namespace/tls-secret")},
+						{Key: aws.String(tagKeySecretDigest), Value: aws.String(digest)},
+					},
+				}).Return("newArn", nil)
+			}
+			if tc.ExpectReimport {
+				cloud.On("ImportCertificate", ctx, &acm.ImportCertificateInput{
+					Certificate:    crt,
+					CertificateArn: aws.String("existingArn"),
+					PrivateKey:     []byte("key"),
+				}).Return("existingArn", nil)
+				cloud.On("AddTagsToCertificate", ctx, "existingArn", []*acm.Tag{
+					{Key: aws.String(tagKeySecretDigest), Value: aws.String(digest)},
+				}).Return(nil)
+			}
+
+			importer := NewACMCertImporter(cloud, "cluster")
+			certArn, err := importer.Import(ctx, ingressKey, secret)
+			assert.NoError(t, err)
+			assert.Equal(t, expectedArn, certArn)
+
+			// imported certificates are tracked in memory, so importing again won't call ACM.
+			certArn, err = importer.Import(ctx, ingressKey, secret)
+			assert.NoError(t, err)
+			assert.Equal(t, expectedArn, certArn)
+			cloud.AssertExpectations(t)
+		})
+	}
+}
+
+func TestACMCertImporter_GC(t *testing.T) {
+	ctx := context.Background()
+	cloud := &mocks.CloudAPI{}
+	importer := &acmCertImporter{
+		cloud:       cloud,
+		clusterName: "cluster",
+		importedCerts: map[string]importedCert{
+			"inUseArn": {
+				ingressKey: types.NamespacedName{Namespace: "namespace", Name: "ingress"},
+				secretKey:  types.NamespacedName{Namespace: "namespace", Name: "in-use"},
+			},
+			"unusedArn": {
+				ingressKey: types.NamespacedName{Namespace: "namespace", Name: "ingress"},
+				secretKey:  types.NamespacedName{Namespace: "namespace", Name: "unused"},
+			},
+			"otherArn": {
+				ingressKey: types.NamespacedName{Namespace: "namespace", Name: "other"},
+				secretKey:  types.NamespacedName{Namespace: "namespace", Name: "unused"},
+			},
+		},
+	}
+	cloud.On("DeleteCertificate", ctx, "unusedArn").Return(nil)
+
+	assert.NoError(t, importer.GC(ctx, types.NamespacedName{Namespace: "namespace", Name: "ingress"}, sets.NewString("namespace/in-use")))
+	assert.Equal(t, []string{"inUseArn", "otherArn"}, sets.StringKeySet(importer.importedCerts).List())
+	cloud.AssertExpectations(t)
+}
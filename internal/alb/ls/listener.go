@@ -11,7 +11,6 @@ import (
 
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/auth"
 
-	"github.com/aws/aws-sdk-go/aws/awsutil"
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tg"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
@@ -19,8 +18,12 @@ import (
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/action"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/loadbalancer"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/tracing"
 	util "github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/types"
+	"go.opentelemetry.io/otel/attribute"
+	corev1 "k8s.io/api/core/v1"
 	extensions "k8s.io/api/extensions/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 )
 
 const (
@@ -48,14 +51,16 @@ type Controller interface {
 	Reconcile(ctx context.Context, options ReconcileOptions) error
 }
 
-func NewController(cloud aws.CloudAPI, authModule auth.Module) Controller {
+func NewController(cloud aws.CloudAPI, authModule auth.Module, cache cache.Cache, certImporter CertImporter) Controller {
 	rulesController := NewRulesController(cloud, authModule)
 	certDiscovery := NewACMCertDiscovery(cloud)
+	tlsCertResolver := NewTLSCertResolver(cache, certImporter)
 	return &defaultController{
 		cloud:           cloud,
 		authModule:      authModule,
 		rulesController: rulesController,
 		certDiscovery:   certDiscovery,
+		tlsCertResolver: tlsCertResolver,
 	}
 }
 
@@ -64,6 +69,7 @@ type defaultController struct {
 	authModule      auth.Module
 	rulesController RulesController
 	certDiscovery   CertDiscovery
+	tlsCertResolver TLSCertResolver
 }
 
 type listenerConfig struct {
@@ -77,33 +83,53 @@ type listenerConfig struct {
 }
 
 func (controller *defaultController) Reconcile(ctx context.Context, options ReconcileOptions) error {
+	ctx, span := tracing.Start(ctx, "Listener.Reconcile", attribute.Int64("listener.port", options.Port.Port))
+	err := controller.reconcile(ctx, options)
+	tracing.End(span, err)
+	return err
+}
+
+func (controller *defaultController) reconcile(ctx context.Context, options ReconcileOptions) error {
 	config, err := controller.buildListenerConfig(ctx, options)
 	if err != nil {
-		return fmt.Errorf("failed to build listener config due to %v", err)
+		return fmt.Errorf("failed to build listener config due to %w", err)
+	}
+
+	instance, err := controller.reconcileListener(ctx, options, config)
+	if err != nil {
+		return err
+	}
+	if err := controller.rulesController.Reconcile(ctx, instance, options.Ingress, options.IngressAnnos, options.TGGroup); err != nil {
+		return fmt.Errorf("failed to reconcile rules due to %w", err)
 	}
+	return nil
+}
 
+// reconcileListener creates or modifies the listener and its certificates, without interleaving with other mutations of the LoadBalancer.
+func (controller *defaultController) reconcileListener(ctx context.Context, options ReconcileOptions, config listenerConfig) (*elbv2.Listener, error) {
+	lbLocker := albctx.GetLBLocker(ctx)
+	lbLocker.Lock()
+	defer lbLocker.Unlock()
+
+	var err error
 	instance := options.Instance
 	if instance == nil {
 		if instance, err = controller.newLSInstance(ctx, options.LBArn, config); err != nil {
-			return fmt.Errorf("failed to create listener due to %v", err)
+			return nil, fmt.Errorf("failed to create listener due to %w", err)
 		}
 	} else {
 		if instance, err = controller.reconcileLSInstance(ctx, instance, config); err != nil {
-			return fmt.Errorf("failed to reconcile listener due to %v", err)
+			return nil, fmt.Errorf("failed to reconcile listener due to %w", err)
 		}
 	}
 
 	if options.Port.Scheme == elbv2.ProtocolEnumHttps {
 		lsArn := aws.StringValue(instance.ListenerArn)
 		if err := controller.reconcileExtraCertificates(ctx, lsArn, config.ExtraCertificateARNs); err != nil {
-			return errors.Wrapf(err, "failed to reconcile extra certificates on listener %v", lsArn)
+			return nil, errors.Wrapf(err, "failed to reconcile extra certificates on listener %v", lsArn)
 		}
 	}
-
-	if err := controller.rulesController.Reconcile(ctx, instance, options.Ingress, options.IngressAnnos, options.TGGroup); err != nil {
-		return fmt.Errorf("failed to reconcile rules due to %v", err)
-	}
-	return nil
+	return instance, nil
 }
 
 func (controller *defaultController) newLSInstance(ctx context.Context, lbArn string, config listenerConfig) (*elbv2.Listener, error) {
@@ -123,8 +149,8 @@ func (controller *defaultController) newLSInstance(ctx context.Context, lbArn st
 }
 
 func (controller *defaultController) reconcileLSInstance(ctx context.Context, instance *elbv2.Listener, config listenerConfig) (*elbv2.Listener, error) {
-	if controller.LSInstanceNeedsModification(ctx, instance, config) {
-		albctx.GetLogger(ctx).Infof("modifying listener %v, arn: %v", aws.Int64Value(config.Port), aws.StringValue(instance.ListenerArn))
+	if diff := lsInstanceDiff(instance, config); !diff.Empty() {
+		albctx.GetLogger(ctx).Infof("modifying listener %v, arn: %v, changes: %v", aws.Int64Value(config.Port), aws.StringValue(instance.ListenerArn), diff)
 		output, err := controller.cloud.ModifyListenerWithContext(ctx, &elbv2.ModifyListenerInput{
 			ListenerArn:    instance.ListenerArn,
 			Port:           config.Port,
@@ -136,34 +162,35 @@ func (controller *defaultController) reconcileLSInstance(ctx context.Context, in
 		if err != nil {
 			return instance, err
 		}
+		albctx.GetEventf(ctx)(corev1.EventTypeNormal, "MODIFY", "listener %v modified: %v", aws.Int64Value(config.Port), diff)
+		// ModifyListener isn't sent in dry-run, leaving its output empty.
+		if len(output.Listeners) == 0 {
+			return instance, nil
+		}
 		return output.Listeners[0], nil
 	}
 	return instance, nil
 }
 
-func (controller *defaultController) LSInstanceNeedsModification(ctx context.Context, instance *elbv2.Listener, config listenerConfig) bool {
-	needModification := false
-	if !util.DeepEqual(instance.Port, config.Port) {
-		albctx.GetLogger(ctx).DebugLevelf(1, "listener port needs modification: %v => %v", awsutil.Prettify(instance.Port), awsutil.Prettify(config.Port))
-		needModification = true
-	}
-	if !util.DeepEqual(instance.Protocol, config.Protocol) {
-		albctx.GetLogger(ctx).DebugLevelf(1, "listener protocol needs modification: %v => %v", awsutil.Prettify(instance.Protocol), awsutil.Prettify(config.Protocol))
-		needModification = true
-	}
+// lsInstanceDiff returns the fields of listener instance that need modification to match config.
+func lsInstanceDiff(instance *elbv2.Listener, config listenerConfig) util.Diff {
+	var diff util.Diff
+	diff.Compare("Port", instance.Port, config.Port)
+	diff.Compare("Protocol", instance.Protocol, config.Protocol)
 	if !util.DeepEqual(instance.Certificates, config.DefaultCertificate) {
-		albctx.GetLogger(ctx).DebugLevelf(1, "listener certificates needs modification: %v => %v", awsutil.Prettify(instance.Certificates), awsutil.Prettify(config.DefaultCertificate))
-		needModification = true
+		diff.Add("Certificates", certificateArns(instance.Certificates), certificateArns(config.DefaultCertificate))
 	}
-	if !util.DeepEqual(instance.SslPolicy, config.SslPolicy) {
-		albctx.GetLogger(ctx).DebugLevelf(1, "listener sslPolicy needs modification: %v => %v", awsutil.Prettify(instance.SslPolicy), awsutil.Prettify(config.SslPolicy))
-		needModification = true
-	}
-	if !util.DeepEqual(instance.DefaultActions, config.DefaultActions) {
-		albctx.GetLogger(ctx).DebugLevelf(1, "listener defaultActions needs modification: %v => %v", awsutil.Prettify(instance.DefaultActions), awsutil.Prettify(config.DefaultActions))
-		needModification = true
+	diff.Compare("SslPolicy", instance.SslPolicy, config.SslPolicy)
+	diff.Compare("DefaultActions", instance.DefaultActions, config.DefaultActions)
+	return diff
+}
+
+func certificateArns(certificates []*elbv2.Certificate) []string {
+	arns := make([]string, 0, len(certificates))
+	for _, certificate := range certificates {
+		arns = append(arns, aws.StringValue(certificate.CertificateArn))
 	}
-	return needModification
+	return arns
 }
 
 func (controller *defaultController) reconcileExtraCertificates(ctx context.Context, lsArn string, extraCertificateARNs []string) error {
@@ -210,6 +237,36 @@ func (controller *defaultController) reconcileExtraCertificates(ctx context.Cont
 	return nil
 }
 
+// resolveCertificateARNs returns the certificates of HTTPS listeners for ingress, in order of precedence from
+// the certificate-arn annotation, the TLS entries, or ACM certificates matching hosts of ingress.
+func (controller *defaultController) resolveCertificateARNs(ctx context.Context, ingress *extensions.Ingress) ([]string, error) {
+	var certificateARNs []string
+	_ = annotations.LoadStringSliceAnnotation(AnnotationCertificateARN, &certificateARNs, ingress.Annotations)
+	if len(certificateARNs) != 0 {
+		return certificateARNs, nil
+	}
+	certs, err := controller.tlsCertResolver.Resolve(ctx, ingress)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to resolve certificates from spec.tls")
+	}
+	if len(certs) != 0 {
+		albctx.GetLogger(ctx).Infof("Added %d certificates from spec.tls to listener", len(certs))
+		return certs, nil
+	}
+
+	certs, err = controller.inferCertARNs(ctx, ingress)
+	if err != nil {
+		return nil, errors.Errorf("missing certificates annotation %v and could not auto-load certificates from ACM: %v",
+			parser.GetAnnotationWithPrefix(AnnotationCertificateARN), err)
+	}
+	if len(certs) == 0 {
+		return nil, errors.Errorf("missing certificates annotation %v could not find any matching certificates from ACM to auto-load",
+			parser.GetAnnotationWithPrefix(AnnotationCertificateARN))
+	}
+	albctx.GetLogger(ctx).Infof("Auto-detected and added %d certificates to listener", len(certs))
+	return certs, nil
+}
+
 func (controller *defaultController) buildListenerConfig(ctx context.Context, options ReconcileOptions) (listenerConfig, error) {
 	config := listenerConfig{
 		Port:     aws.Int64(options.Port.Port),
@@ -220,21 +277,16 @@ func (controller *defaultController) buildListenerConfig(ctx context.Context, op
 		_ = annotations.LoadStringAnnotation(AnnotationSSLPolicy, &sslPolicy, options.Ingress.Annotations)
 		config.SslPolicy = aws.String(sslPolicy)
 
-		var certificateARNs []string
-		_ = annotations.LoadStringSliceAnnotation(AnnotationCertificateARN, &certificateARNs, options.Ingress.Annotations)
-		if len(certificateARNs) == 0 {
-			certs, err := controller.inferCertARNs(ctx, options.Ingress)
+		certificateARNs, err := controller.resolveCertificateARNs(ctx, options.Ingress)
+		if certificates := albctx.GetCertificates(ctx); certificates != nil {
 			if err != nil {
-				return config, errors.Errorf("missing certificates annotation %v and could not auto-load certificates from ACM: %v",
-					parser.GetAnnotationWithPrefix(AnnotationCertificateARN), err)
-			}
-			if len(certs) == 0 {
-				return config, errors.Errorf("missing certificates annotation %v could not find any matching certificates from ACM to auto-load",
-					parser.GetAnnotationWithPrefix(AnnotationCertificateARN))
+				certificates.RecordFailure(err)
+			} else {
+				certificates.RecordAttached(certificateARNs)
 			}
-
-			albctx.GetLogger(ctx).Infof("Auto-detected and added %d certificates to listener", len(certs))
-			certificateARNs = certs
+		}
+		if err != nil {
+			return config, err
 		}
 		config.DefaultCertificate = []*elbv2.Certificate{
 			{
@@ -254,6 +306,9 @@ func (controller *defaultController) buildListenerConfig(ctx context.Context, op
 }
 
 func (controller *defaultController) buildDefaultActions(ctx context.Context, options ReconcileOptions) ([]*elbv2.Action, error) {
+	if port := sslRedirectPort(options.IngressAnnos, options.Port.Scheme); port != nil {
+		return buildSSLRedirectActions(*port), nil
+	}
 	backend := action.Default404Backend()
 	if options.Ingress.Spec.Backend != nil {
 		backend = *options.Ingress.Spec.Backend
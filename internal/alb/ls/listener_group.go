@@ -12,8 +12,10 @@ import (
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/k8s"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/utils"
 	extensions "k8s.io/api/extensions/v1beta1"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 )
 
 type GroupController interface {
@@ -22,14 +24,18 @@ type GroupController interface {
 
 	// Delete ensures all listeners are deleted
 	Delete(ctx context.Context, lbArn string) error
+
+	// DeletePorts ensures listeners on ports are deleted, other listeners are left alone.
+	DeletePorts(ctx context.Context, lbArn string, ports []int64) error
 }
 
-func NewGroupController(store store.Storer, cloud aws.CloudAPI, authModule auth.Module) GroupController {
-	lsController := NewController(cloud, authModule)
+func NewGroupController(store store.Storer, cloud aws.CloudAPI, authModule auth.Module, cache cache.Cache, certImporter CertImporter) GroupController {
+	lsController := NewController(cloud, authModule, cache, certImporter)
 	return &defaultGroupController{
-		cloud:        cloud,
-		store:        store,
-		lsController: lsController,
+		cloud:          cloud,
+		store:          store,
+		lsController:   lsController,
+		maxConcurrency: store.GetConfig().MaxConcurrentResourceReconciles,
 	}
 }
 
@@ -38,6 +44,9 @@ type defaultGroupController struct {
 	store store.Storer
 
 	lsController Controller
+
+	// maxConcurrency is the maximum number of listeners reconciled concurrently.
+	maxConcurrency int
 }
 
 func (controller *defaultGroupController) Reconcile(ctx context.Context, lbArn string, ingress *extensions.Ingress, tgGroup tg.TargetGroupGroup) error {
@@ -50,24 +59,51 @@ func (controller *defaultGroupController) Reconcile(ctx context.Context, lbArn s
 		return err
 	}
 
+	// listeners are independent of each other, so they are reconciled concurrently.
+	ports := ingressAnnos.LoadBalancer.Ports
 	portsInUse := sets.NewInt64()
-	for _, port := range ingressAnnos.LoadBalancer.Ports {
+	for _, port := range ports {
 		portsInUse.Insert(port.Port)
-		instance := instancesByPort[port.Port]
-		if err := controller.lsController.Reconcile(ctx, ReconcileOptions{
+	}
+	if err := utils.Parallelize(controller.maxConcurrency, len(ports), func(idx int) error {
+		port := ports[idx]
+		ctx := albctx.SetLogger(ctx, albctx.GetLogger(ctx).WithValues("listenerPort", port.Port))
+		return controller.lsController.Reconcile(ctx, ReconcileOptions{
 			LBArn:        lbArn,
 			Ingress:      ingress,
 			IngressAnnos: ingressAnnos,
 			Port:         port,
 			TGGroup:      tgGroup,
-			Instance:     instance,
-		}); err != nil {
-			return err
-		}
+			Instance:     instancesByPort[port.Port],
+		})
+	}); err != nil {
+		return err
 	}
-	portsUnsed := sets.Int64KeySet(instancesByPort).Difference(portsInUse)
-	for port := range portsUnsed {
-		instance := instancesByPort[port]
+
+	// other listeners of an adopted ALB are left to their owner, those managed for the ingress are deleted once released from its listen-ports.
+	if ingressAnnos.LoadBalancer.ExistingLoadBalancer != nil {
+		return nil
+	}
+	portsUnused := sets.Int64KeySet(instancesByPort).Difference(portsInUse).List()
+	return utils.Parallelize(controller.maxConcurrency, len(portsUnused), func(idx int) error {
+		instance := instancesByPort[portsUnused[idx]]
+		albctx.GetLogger(ctx).Infof("deleting listener %v, arn: %v", aws.Int64Value(instance.Port), aws.StringValue(instance.ListenerArn))
+		lbLocker := albctx.GetLBLocker(ctx)
+		lbLocker.Lock()
+		defer lbLocker.Unlock()
+		return controller.cloud.DeleteListenersByArn(ctx, aws.StringValue(instance.ListenerArn))
+	})
+}
+
+func (controller *defaultGroupController) Delete(ctx context.Context, lbArn string) error {
+	instancesByPort, err := controller.loadListenerInstances(ctx, lbArn)
+	if err != nil {
+		return err
+	}
+	lbLocker := albctx.GetLBLocker(ctx)
+	lbLocker.Lock()
+	defer lbLocker.Unlock()
+	for _, instance := range instancesByPort {
 		albctx.GetLogger(ctx).Infof("deleting listener %v, arn: %v", aws.Int64Value(instance.Port), aws.StringValue(instance.ListenerArn))
 		if err := controller.cloud.DeleteListenersByArn(ctx, aws.StringValue(instance.ListenerArn)); err != nil {
 			return err
@@ -76,13 +112,20 @@ func (controller *defaultGroupController) Reconcile(ctx context.Context, lbArn s
 	return nil
 }
 
-func (controller *defaultGroupController) Delete(ctx context.Context, lbArn string) error {
+func (controller *defaultGroupController) DeletePorts(ctx context.Context, lbArn string, ports []int64) error {
 	instancesByPort, err := controller.loadListenerInstances(ctx, lbArn)
 	if err != nil {
 		return err
 	}
-	for _, instance := range instancesByPort {
-		albctx.GetLogger(ctx).Infof("deleting listener %v, arn: %v", aws.Int64Value(instance.Port), aws.StringValue(instance.ListenerArn))
+	lbLocker := albctx.GetLBLocker(ctx)
+	lbLocker.Lock()
+	defer lbLocker.Unlock()
+	for _, port := range ports {
+		instance, ok := instancesByPort[port]
+		if !ok {
+			continue
+		}
+		albctx.GetLogger(ctx).Infof("deleting listener %v, arn: %v", port, aws.StringValue(instance.ListenerArn))
 		if err := controller.cloud.DeleteListenersByArn(ctx, aws.StringValue(instance.ListenerArn)); err != nil {
 			return err
 		}
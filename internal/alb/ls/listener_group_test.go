@@ -206,6 +206,50 @@ func TestDefaultGroupController_Reconcile(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name: "Reconcile keeps other listeners of an adopted ALB",
+			GetIngressAnnotationsCall: &GetIngressAnnotationsCall{
+				Key: "namespace/ingress",
+				IngressAnnos: &annotations.Ingress{
+					LoadBalancer: &loadbalancer.Config{
+						Ports: []loadbalancer.PortData{
+							{
+								Port:   80,
+								Scheme: elbv2.ProtocolEnumHttp,
+							},
+						},
+						ExistingLoadBalancer: &loadbalancer.ExistingLoadBalancerConfig{
+							NameOrArn: "my-alb",
+							Scope:     loadbalancer.AdoptionScopeLoadBalancer,
+						},
+					},
+				},
+			},
+			ListListenersByLoadBalancerCall: &ListListenersByLoadBalancerCall{
+				Listeners: []*elbv2.Listener{
+					{
+						ListenerArn: aws.String("lsArn1"),
+						Port:        aws.Int64(80),
+					},
+					{
+						ListenerArn: aws.String("lsArn2"),
+						Port:        aws.Int64(443),
+					},
+				},
+			},
+			LSControllerReconcileCalls: []LSControllerReconcileCall{
+				{
+					Port: loadbalancer.PortData{
+						Port:   80,
+						Scheme: elbv2.ProtocolEnumHttp,
+					},
+					Instance: &elbv2.Listener{
+						ListenerArn: aws.String("lsArn1"),
+						Port:        aws.Int64(80),
+					},
+				},
+			},
+		},
 		{
 			Name: "Reconcile failed when get ingress annotations",
 			GetIngressAnnotationsCall: &GetIngressAnnotationsCall{
@@ -343,9 +387,10 @@ func TestDefaultGroupController_Reconcile(t *testing.T) {
 			}
 
 			controller := &defaultGroupController{
-				cloud:        cloud,
-				store:        mockStore,
-				lsController: mockLSController,
+				cloud:          cloud,
+				store:          mockStore,
+				lsController:   mockLSController,
+				maxConcurrency: 2,
 			}
 
 			err := controller.Reconcile(context.Background(), lbArn, &ingress, targetGroup)
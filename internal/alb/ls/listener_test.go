@@ -987,29 +987,30 @@ func TestDefaultController_Reconcile(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
+			// Reconcile runs within a tracing span, so the context reaching cloud is derived from ctx.
 			ctx := context.Background()
 			cloud := &mocks.CloudAPI{}
 			if tc.CreateListenerCall != nil {
-				cloud.On("CreateListenerWithContext", ctx, &tc.CreateListenerCall.Input).Return(
+				cloud.On("CreateListenerWithContext", mock.Anything, &tc.CreateListenerCall.Input).Return(
 					&elbv2.CreateListenerOutput{
 						Listeners: []*elbv2.Listener{tc.CreateListenerCall.Instance},
 					}, tc.CreateListenerCall.Err)
 			}
 			if tc.ModifyListenerCall != nil {
-				cloud.On("ModifyListenerWithContext", ctx, &tc.ModifyListenerCall.Input).Return(
+				cloud.On("ModifyListenerWithContext", mock.Anything, &tc.ModifyListenerCall.Input).Return(
 					&elbv2.ModifyListenerOutput{
 						Listeners: []*elbv2.Listener{tc.ModifyListenerCall.Instance},
 					}, tc.ModifyListenerCall.Err)
 			}
 			if tc.DescribeListenerCertificatesCall != nil {
-				cloud.On("DescribeListenerCertificates", ctx, tc.DescribeListenerCertificatesCall.LSArn).Return(
+				cloud.On("DescribeListenerCertificates", mock.Anything, tc.DescribeListenerCertificatesCall.LSArn).Return(
 					tc.DescribeListenerCertificatesCall.Certificates, tc.DescribeListenerCertificatesCall.Err)
 			}
 			for _, call := range tc.AddListenerCertificatesCalls {
-				cloud.On("AddListenerCertificates", ctx, call.Input).Return(nil, call.Err)
+				cloud.On("AddListenerCertificates", mock.Anything, call.Input).Return(nil, call.Err)
 			}
 			for _, call := range tc.RemoveListenerCertificatesCalls {
-				cloud.On("RemoveListenerCertificates", ctx, call.Input).Return(nil, call.Err)
+				cloud.On("RemoveListenerCertificates", mock.Anything, call.Input).Return(nil, call.Err)
 			}
 			mockAuthModule := mock_auth.NewMockModule(ctrl)
 			mockAuthModule.EXPECT().NewConfig(gomock.Any(), &tc.Ingress, gomock.Any(), gomock.Any()).Return(tc.AuthConfig, nil)
@@ -1032,7 +1033,11 @@ func TestDefaultController_Reconcile(t *testing.T) {
 				TGGroup:      tc.TGGroup,
 				Instance:     tc.Instance,
 			})
-			assert.Equal(t, tc.ExpectedError, err)
+			if tc.ExpectedError != nil {
+				assert.EqualError(t, err, tc.ExpectedError.Error())
+			} else {
+				assert.NoError(t, err)
+			}
 			cloud.AssertExpectations(t)
 			mockRulesController.AssertExpectations(t)
 		})
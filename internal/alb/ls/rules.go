@@ -3,7 +3,9 @@ package ls
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"strconv"
+	"strings"
 
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/conditions"
 	"github.com/pkg/errors"
@@ -15,8 +17,13 @@ import (
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/action"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/loadbalancer"
+	priorityannos "github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/priority"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/auth"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/tracing"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/log"
+	util "github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/types"
+	"go.opentelemetry.io/otel/attribute"
 	corev1 "k8s.io/api/core/v1"
 	extensions "k8s.io/api/extensions/v1beta1"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -43,20 +50,37 @@ type rulesController struct {
 
 // Reconcile modifies AWS resources to match the rules defined in the Ingress
 func (c *rulesController) Reconcile(ctx context.Context, listener *elbv2.Listener, ingress *extensions.Ingress, ingressAnnos *annotations.Ingress, tgGroup tg.TargetGroupGroup) error {
+	ctx, span := tracing.Start(ctx, "Rules.Reconcile", attribute.String("listener.arn", aws.StringValue(listener.ListenerArn)))
+	err := c.reconcile(ctx, listener, ingress, ingressAnnos, tgGroup)
+	tracing.End(span, err)
+	return err
+}
+
+func (c *rulesController) reconcile(ctx context.Context, listener *elbv2.Listener, ingress *extensions.Ingress, ingressAnnos *annotations.Ingress, tgGroup tg.TargetGroupGroup) error {
 	desired, err := c.getDesiredRules(ctx, listener, ingress, ingressAnnos, tgGroup)
 	if err != nil {
 		return err
 	}
+	// rules of a listener are read and changed as a whole, so changes are never computed from rules that another mutation
+	// of the LoadBalancer changes in between, and priorities are never observed half-changed.
+	lbLocker := albctx.GetLBLocker(ctx)
+	lbLocker.Lock()
+	defer lbLocker.Unlock()
+
 	lsArn := aws.StringValue(listener.ListenerArn)
 	current, err := c.getCurrentRules(ctx, lsArn)
 	if err != nil {
 		return err
 	}
-	return c.reconcileRules(ctx, lsArn, current, desired)
+	return c.reconcileRules(ctx, lsArn, withoutExternalRules(current, externalRulePriorities(ingressAnnos)), desired)
 }
 
+// reconcileRules changes the rules of listener lsArn from current to desired, the LoadBalancer must be locked by the caller.
 func (c *rulesController) reconcileRules(ctx context.Context, lsArn string, current []elbv2.Rule, desired []elbv2.Rule) error {
 	additions, modifies, removals := rulesChangeSets(current, desired)
+	if len(additions) == 0 && len(modifies) == 0 && len(removals) == 0 {
+		return nil
+	}
 
 	for _, rule := range additions {
 		albctx.GetLogger(ctx).Infof("creating rule %v on %v", aws.StringValue(rule.Priority), lsArn)
@@ -69,10 +93,10 @@ func (c *rulesController) reconcileRules(ctx context.Context, lsArn string, curr
 		}
 
 		if _, err := c.cloud.CreateRuleWithContext(ctx, in); err != nil {
-			msg := fmt.Sprintf("failed creating rule %v on %v due to %v", aws.StringValue(rule.Priority), lsArn, err)
-			albctx.GetLogger(ctx).Errorf(msg)
-			albctx.GetEventf(ctx)(corev1.EventTypeWarning, "ERROR", msg)
-			return fmt.Errorf(msg)
+			err = fmt.Errorf("failed creating rule %v on %v due to %w", aws.StringValue(rule.Priority), lsArn, err)
+			albctx.GetLogger(ctx).Errorf(err.Error())
+			albctx.GetEventf(ctx)(corev1.EventTypeWarning, "ERROR", err.Error())
+			return err
 		}
 
 		msg := fmt.Sprintf("rule %v created with conditions %v", aws.StringValue(rule.Priority), log.Prettify(rule.Conditions))
@@ -80,8 +104,20 @@ func (c *rulesController) reconcileRules(ctx context.Context, lsArn string, curr
 		albctx.GetEventf(ctx)(corev1.EventTypeNormal, "CREATE", msg)
 	}
 
+	currentByPriority := make(map[string]elbv2.Rule, len(current))
+	for _, rule := range current {
+		currentByPriority[aws.StringValue(rule.Priority)] = rule
+	}
 	for _, rule := range modifies {
-		albctx.GetLogger(ctx).Infof("modifying rule %v on %v", aws.StringValue(rule.Priority), lsArn)
+		var diff util.Diff
+		currentRule := currentByPriority[aws.StringValue(rule.Priority)]
+		if !conditionsMatches(rule.Conditions, currentRule.Conditions) {
+			diff.Add("Conditions", currentRule.Conditions, rule.Conditions)
+		}
+		if !actionsMatches(rule.Actions, currentRule.Actions) {
+			diff.Add("Actions", currentRule.Actions, rule.Actions)
+		}
+		albctx.GetLogger(ctx).Infof("modifying rule %v on %v, changes: %v", aws.StringValue(rule.Priority), lsArn, diff)
 		in := &elbv2.ModifyRuleInput{
 			Actions:    rule.Actions,
 			Conditions: rule.Conditions,
@@ -89,13 +125,13 @@ func (c *rulesController) reconcileRules(ctx context.Context, lsArn string, curr
 		}
 
 		if _, err := c.cloud.ModifyRuleWithContext(ctx, in); err != nil {
-			msg := fmt.Sprintf("failed modifying rule %v on %v due to %v", aws.StringValue(rule.Priority), lsArn, err)
-			albctx.GetLogger(ctx).Errorf(msg)
-			albctx.GetEventf(ctx)(corev1.EventTypeWarning, "ERROR", msg)
-			return fmt.Errorf(msg)
+			err = fmt.Errorf("failed modifying rule %v on %v due to %w", aws.StringValue(rule.Priority), lsArn, err)
+			albctx.GetLogger(ctx).Errorf(err.Error())
+			albctx.GetEventf(ctx)(corev1.EventTypeWarning, "ERROR", err.Error())
+			return err
 		}
 
-		msg := fmt.Sprintf("rule %v modified with conditions %v", aws.StringValue(rule.Priority), log.Prettify(rule.Conditions))
+		msg := fmt.Sprintf("rule %v modified: %v", aws.StringValue(rule.Priority), diff)
 		albctx.GetEventf(ctx)(corev1.EventTypeNormal, "MODIFY", msg)
 		albctx.GetLogger(ctx).Infof(msg)
 	}
@@ -105,10 +141,10 @@ func (c *rulesController) reconcileRules(ctx context.Context, lsArn string, curr
 
 		in := &elbv2.DeleteRuleInput{RuleArn: rule.RuleArn}
 		if _, err := c.cloud.DeleteRuleWithContext(ctx, in); err != nil {
-			msg := fmt.Sprintf("failed deleting rule %v on %v due to %v", aws.StringValue(rule.Priority), lsArn, err)
-			albctx.GetLogger(ctx).Errorf(msg)
-			albctx.GetEventf(ctx)(corev1.EventTypeWarning, "ERROR", msg)
-			return fmt.Errorf(msg)
+			err = fmt.Errorf("failed deleting rule %v on %v due to %w", aws.StringValue(rule.Priority), lsArn, err)
+			albctx.GetLogger(ctx).Errorf(err.Error())
+			albctx.GetEventf(ctx)(corev1.EventTypeWarning, "ERROR", err.Error())
+			return err
 		}
 
 		msg := fmt.Sprintf("rule %v deleted with conditions %v", aws.StringValue(rule.Priority), log.Prettify(rule.Conditions))
@@ -119,15 +155,52 @@ func (c *rulesController) reconcileRules(ctx context.Context, lsArn string, curr
 }
 
 func (c *rulesController) getDesiredRules(ctx context.Context, listener *elbv2.Listener, ingress *extensions.Ingress, ingressAnnos *annotations.Ingress, tgGroup tg.TargetGroupGroup) ([]elbv2.Rule, error) {
-	var output []elbv2.Rule
+	if sslRedirectPort(ingressAnnos, aws.StringValue(listener.Protocol)) != nil {
+		// every request is redirected to HTTPS by the default action of the listener.
+		return nil, nil
+	}
+	var candidates []elbv2.Rule
+	// pinnedRules counts the rules of each backend with a pinned priority, which take consecutive priorities.
+	pinnedRules := make(map[string]int)
+	appendRule := func(elbActions []*elbv2.Action, elbConditions []*elbv2.RuleCondition, backendName string) {
+		elbRule := elbv2.Rule{
+			IsDefault:  aws.Bool(false),
+			Actions:    elbActions,
+			Conditions: elbConditions,
+		}
+		if createsRedirectLoop(listener, elbRule) {
+			return
+		}
+		if pinned := ingressAnnos.Priority.GetPriority(backendName); backendName != "" && pinned != nil {
+			elbRule.Priority = aws.String(strconv.Itoa(*pinned + pinnedRules[backendName]))
+			pinnedRules[backendName]++
+		}
+		candidates = append(candidates, elbRule)
+	}
+
+	// requests from denied sources are rejected before any other rule
+	filteredHosts := ipFilteredHosts(ingress, ingressAnnos)
+	for _, host := range filteredHosts {
+		for _, cidrs := range chunkSourceIPs(ingressAnnos.IPFilter.GetFilter(host).Deny) {
+			appendRule(buildForbiddenActions(), append(buildSourceIPConditions(cidrs), buildHostConditions(host)...), "")
+		}
+	}
 
-	nextPriority := 1
 	for _, ingressRule := range ingress.Spec.Rules {
 		// Ingress spec allows empty HTTP, and we will 'route all traffic to the default backend'(which relies on default action of listeners)
 		if ingressRule.HTTP == nil {
 			continue
 		}
+		if ingressRule.Host != "" {
+			if err := conditions.ValidateHost(ingressRule.Host); err != nil {
+				return nil, fmt.Errorf("invalid ingress rule due to %w", err)
+			}
+		}
 
+		var allowedSourceIPs []string
+		if filter := ingressAnnos.IPFilter.GetFilter(ingressRule.Host); filter != nil {
+			allowedSourceIPs = filter.Allow
+		}
 		for _, path := range ingressRule.HTTP.Paths {
 			authCfg, err := c.authModule.NewConfig(ctx, ingress, path.Backend, aws.StringValue(listener.Protocol))
 			if err != nil {
@@ -138,22 +211,246 @@ func (c *rulesController) getDesiredRules(ctx context.Context, listener *elbv2.L
 				return nil, err
 			}
 			elbConditions := buildConditions(ctx, ingressAnnos, ingressRule, path)
-			elbRule := elbv2.Rule{
-				IsDefault:  aws.Bool(false),
-				Priority:   aws.String(strconv.Itoa(nextPriority)),
-				Actions:    elbActions,
-				Conditions: elbConditions,
-			}
-			if createsRedirectLoop(listener, elbRule) {
+			if len(allowedSourceIPs) == 0 {
+				appendRule(elbActions, elbConditions, path.Backend.ServiceName)
 				continue
 			}
-			output = append(output, elbRule)
+			// the rule is repeated for each chunk of allowed sources, since rule conditions are limited in values
+			for _, cidrs := range chunkSourceIPs(allowedSourceIPs) {
+				appendRule(elbActions, append(buildSourceIPConditions(cidrs), elbConditions...), path.Backend.ServiceName)
+			}
+		}
+	}
+
+	// requests from sources not allowed are rejected after all rules
+	for _, host := range filteredHosts {
+		if len(ingressAnnos.IPFilter.GetFilter(host).Allow) != 0 {
+			appendRule(buildForbiddenActions(), buildHostConditions(host), "")
+		}
+	}
+
+	rules := mergeHostRules(mergePathRules(candidates))
+	if len(rules) > ruleQuota {
+		albctx.GetEventf(ctx)(corev1.EventTypeWarning, "ERROR", "listener %v needs %v rules, which exceeds the default quota of %v rules per ALB, consider shard-max-rules to split the ingress",
+			aws.StringValue(listener.ListenerArn), len(rules), ruleQuota)
+	}
+	reservedPriorities := externalRulePriorities(ingressAnnos)
+	pinnedPriorities := sets.NewInt()
+	for _, elbRule := range rules {
+		if elbRule.Priority == nil {
+			continue
+		}
+		priority, _ := strconv.Atoi(aws.StringValue(elbRule.Priority))
+		if priority > priorityannos.MaxPriority || reservedPriorities.Contains(priority) || pinnedPriorities.Has(priority) {
+			return nil, fmt.Errorf("pinned rule priority %v exceeds %v or collides with another rule", priority, priorityannos.MaxPriority)
+		}
+		pinnedPriorities.Insert(priority)
+	}
+
+	var output []elbv2.Rule
+	nextPriority := 1
+	for _, elbRule := range rules {
+		if elbRule.Priority == nil {
+			// rules are slotted around the priorities of external rules and pinned rules
+			for reservedPriorities.Contains(nextPriority) || pinnedPriorities.Has(nextPriority) {
+				nextPriority++
+			}
+			elbRule.Priority = aws.String(strconv.Itoa(nextPriority))
 			nextPriority++
 		}
+		output = append(output, elbRule)
 	}
 	return output, nil
 }
 
+// maxConditionValuesPerRule is the limit of condition values of a rule, across all of its conditions.
+const maxConditionValuesPerRule = 5
+
+// ruleQuota is the default quota of rules per ALB, besides the default rules of listeners.
+// It's shared by all listeners of the ALB, so rules of a single listener exceeding it are bound to fail.
+const ruleQuota = 100
+
+// mergePathRules collapses rules that only differ in their paths into a single rule with multiple path-pattern values.
+// A rule is merged into the rule right before it, or into an earlier rule if its hosts are known and none of the rules
+// in between may match them, so the merge doesn't change which rule a request matches.
+func mergePathRules(rules []elbv2.Rule) []elbv2.Rule {
+	var merged []elbv2.Rule
+	for _, elbRule := range rules {
+		paths := ruleConditionValues(elbRule, conditions.FieldPathPattern)
+		hosts := ruleHosts(elbRule)
+		mergedInto := false
+		if elbRule.Priority == nil && len(paths) != 0 {
+			for i := len(merged) - 1; i >= 0; i-- {
+				if merged[i].Priority != nil {
+					continue
+				}
+				if len(ruleConditionValues(merged[i], conditions.FieldPathPattern)) != 0 && rulesDifferOnlyIn(merged[i], elbRule, conditions.FieldPathPattern) &&
+					conditionValueCount(merged[i].Conditions)+len(paths) <= maxConditionValuesPerRule {
+					merged[i] = withAdditionalValues(merged[i], conditions.FieldPathPattern, paths)
+					mergedInto = true
+					break
+				}
+				if len(hosts) == 0 || hasWildcardHost(hosts) || mayMatchHosts(merged[i], hosts) {
+					break
+				}
+			}
+		}
+		if !mergedInto {
+			merged = append(merged, elbRule)
+		}
+	}
+	return merged
+}
+
+// mergeHostRules collapses rules that only differ in their hosts into a single rule with multiple host-header values,
+// to conserve the rules per listener quota. A rule is only merged into an earlier rule if none of the rules in between
+// may match its hosts, so the merge doesn't change which rule a request matches.
+// Rules with a pinned priority are never merged, their evaluation order doesn't depend on their position.
+func mergeHostRules(rules []elbv2.Rule) []elbv2.Rule {
+	var merged []elbv2.Rule
+	for _, elbRule := range rules {
+		hosts := ruleHosts(elbRule)
+		mergedInto := false
+		if elbRule.Priority == nil && len(hosts) != 0 && !hasWildcardHost(hosts) {
+			for i := len(merged) - 1; i >= 0; i-- {
+				if merged[i].Priority != nil {
+					continue
+				}
+				if len(ruleHosts(merged[i])) != 0 && rulesDifferOnlyIn(merged[i], elbRule, conditions.FieldHostHeader) &&
+					conditionValueCount(merged[i].Conditions)+len(hosts) <= maxConditionValuesPerRule {
+					merged[i] = withAdditionalValues(merged[i], conditions.FieldHostHeader, hosts)
+					mergedInto = true
+					break
+				}
+				if mayMatchHosts(merged[i], hosts) {
+					break
+				}
+			}
+		}
+		if !mergedInto {
+			merged = append(merged, elbRule)
+		}
+	}
+	return merged
+}
+
+// ruleHosts returns the values of the host-header condition of rule.
+func ruleHosts(rule elbv2.Rule) []string {
+	return ruleConditionValues(rule, conditions.FieldHostHeader)
+}
+
+// ruleConditionValues returns the values of the host-header or path-pattern condition of rule, depending on field.
+func ruleConditionValues(rule elbv2.Rule, field string) []string {
+	var values []string
+	for _, c := range rule.Conditions {
+		if aws.StringValue(c.Field) != field {
+			continue
+		}
+		switch field {
+		case conditions.FieldHostHeader:
+			values = append(values, aws.StringValueSlice(c.HostHeaderConfig.Values)...)
+		case conditions.FieldPathPattern:
+			values = append(values, aws.StringValueSlice(c.PathPatternConfig.Values)...)
+		}
+	}
+	return values
+}
+
+func hasWildcardHost(hosts []string) bool {
+	for _, host := range hosts {
+		if strings.ContainsAny(host, "*?") {
+			return true
+		}
+	}
+	return false
+}
+
+// mayMatchHosts returns whether rule may match requests for any of hosts, which don't contain wildcards.
+func mayMatchHosts(rule elbv2.Rule, hosts []string) bool {
+	ruleHosts := ruleHosts(rule)
+	if len(ruleHosts) == 0 || hasWildcardHost(ruleHosts) {
+		return true
+	}
+	for _, ruleHost := range ruleHosts {
+		for _, host := range hosts {
+			if strings.EqualFold(ruleHost, host) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// rulesDifferOnlyIn returns whether rules a and b have the same actions and the same conditions besides those of field.
+// actionsMatches only compares the ForwardConfig of forward actions, so their targetGroup is compared as well.
+func rulesDifferOnlyIn(a elbv2.Rule, b elbv2.Rule, field string) bool {
+	return actionsMatches(a.Actions, b.Actions) && reflect.DeepEqual(actionTargetGroupArns(a.Actions), actionTargetGroupArns(b.Actions)) &&
+		conditionsMatches(withoutConditions(a.Conditions, field), withoutConditions(b.Conditions, field))
+}
+
+// actionTargetGroupArns returns the targetGroup of each action of actions, in order.
+func actionTargetGroupArns(actions []*elbv2.Action) []string {
+	var tgArns []string
+	for _, action := range sortedActions(actions) {
+		tgArns = append(tgArns, aws.StringValue(action.TargetGroupArn))
+	}
+	return tgArns
+}
+
+func withoutConditions(elbConditions []*elbv2.RuleCondition, field string) []*elbv2.RuleCondition {
+	var result []*elbv2.RuleCondition
+	for _, c := range elbConditions {
+		if aws.StringValue(c.Field) != field {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// withAdditionalValues returns a copy of rule, with values added to its host-header or path-pattern condition, depending on field.
+func withAdditionalValues(rule elbv2.Rule, field string, values []string) elbv2.Rule {
+	elbConditions := make([]*elbv2.RuleCondition, 0, len(rule.Conditions))
+	for _, c := range rule.Conditions {
+		switch {
+		case aws.StringValue(c.Field) == field && field == conditions.FieldHostHeader:
+			c = &elbv2.RuleCondition{
+				Field:            c.Field,
+				HostHeaderConfig: &elbv2.HostHeaderConditionConfig{Values: append(append([]*string{}, c.HostHeaderConfig.Values...), aws.StringSlice(values)...)},
+			}
+		case aws.StringValue(c.Field) == field && field == conditions.FieldPathPattern:
+			c = &elbv2.RuleCondition{
+				Field:             c.Field,
+				PathPatternConfig: &elbv2.PathPatternConditionConfig{Values: append(append([]*string{}, c.PathPatternConfig.Values...), aws.StringSlice(values)...)},
+			}
+		}
+		elbConditions = append(elbConditions, c)
+	}
+	rule.Conditions = elbConditions
+	return rule
+}
+
+// conditionValueCount returns the number of values across elbConditions, which counts against maxConditionValuesPerRule.
+func conditionValueCount(elbConditions []*elbv2.RuleCondition) int {
+	count := 0
+	for _, c := range elbConditions {
+		switch aws.StringValue(c.Field) {
+		case conditions.FieldHostHeader:
+			count += len(c.HostHeaderConfig.Values)
+		case conditions.FieldPathPattern:
+			count += len(c.PathPatternConfig.Values)
+		case conditions.FieldHTTPRequestMethod:
+			count += len(c.HttpRequestMethodConfig.Values)
+		case conditions.FieldSourceIP:
+			count += len(c.SourceIpConfig.Values)
+		case conditions.FieldHTTPHeader:
+			count += len(c.HttpHeaderConfig.Values)
+		case conditions.FieldQueryString:
+			count += len(c.QueryStringConfig.Values)
+		}
+	}
+	return count
+}
+
 func (c *rulesController) getCurrentRules(ctx context.Context, listenerArn string) ([]elbv2.Rule, error) {
 	rules, err := c.cloud.GetRules(ctx, listenerArn)
 	if err != nil {
@@ -172,6 +469,38 @@ func (c *rulesController) getCurrentRules(ctx context.Context, listenerArn strin
 	return output, nil
 }
 
+// externalRulePriorities returns the rule priorities reserved for rules managed outside of the controller.
+func externalRulePriorities(ingressAnnos *annotations.Ingress) loadbalancer.PriorityRanges {
+	if ingressAnnos.LoadBalancer == nil {
+		return nil
+	}
+	return ingressAnnos.LoadBalancer.ExternalRulePriorities
+}
+
+// sslRedirectPort returns the HTTPS port that listeners of protocol redirect requests to, or nil if they route requests by rules.
+func sslRedirectPort(ingressAnnos *annotations.Ingress, protocol string) *int64 {
+	if protocol != elbv2.ProtocolEnumHttp || ingressAnnos.LoadBalancer == nil {
+		return nil
+	}
+	return ingressAnnos.LoadBalancer.SSLRedirect
+}
+
+// withoutExternalRules returns rules except those at priorities reserved for external rules, so they're never changed.
+func withoutExternalRules(rules []elbv2.Rule, reservedPriorities loadbalancer.PriorityRanges) []elbv2.Rule {
+	if len(reservedPriorities) == 0 {
+		return rules
+	}
+	var output []elbv2.Rule
+	for _, rule := range rules {
+		priority, err := strconv.Atoi(aws.StringValue(rule.Priority))
+		if err == nil && reservedPriorities.Contains(priority) {
+			continue
+		}
+		output = append(output, rule)
+	}
+	return output
+}
+
 // buildActions will build listener rule actions for specific authCfg and backend
 func buildActions(ctx context.Context, authCfg auth.Config, ingressAnnos *annotations.Ingress, backend extensions.IngressBackend, tgGroup tg.TargetGroupGroup) ([]*elbv2.Action, error) {
 	var elbActions []*elbv2.Action
@@ -309,6 +638,105 @@ func buildConditions(ctx context.Context, ingressAnnos *annotations.Ingress, rul
 	return elbConditions
 }
 
+// sourceIPValuesPerRule is the number of source IPs in each rule generated for ip filters, which leaves room for
+// the host-header and path-pattern values within the limit of five condition values per rule.
+const sourceIPValuesPerRule = 3
+
+// ipFilteredHosts returns the distinct hosts of ingress rules with an ip filter, in the order of ingress rules.
+func ipFilteredHosts(ingress *extensions.Ingress, ingressAnnos *annotations.Ingress) []string {
+	var hosts []string
+	visited := sets.NewString()
+	for _, ingressRule := range ingress.Spec.Rules {
+		if ingressRule.HTTP == nil || visited.Has(ingressRule.Host) {
+			continue
+		}
+		visited.Insert(ingressRule.Host)
+		if ingressAnnos.IPFilter.GetFilter(ingressRule.Host) != nil {
+			hosts = append(hosts, ingressRule.Host)
+		}
+	}
+	return hosts
+}
+
+// chunkSourceIPs splits cidrs into chunks of sourceIPValuesPerRule.
+func chunkSourceIPs(cidrs []string) [][]string {
+	var chunks [][]string
+	for start := 0; start < len(cidrs); start += sourceIPValuesPerRule {
+		end := start + sourceIPValuesPerRule
+		if end > len(cidrs) {
+			end = len(cidrs)
+		}
+		chunks = append(chunks, cidrs[start:end])
+	}
+	return chunks
+}
+
+func buildSourceIPConditions(cidrs []string) []*elbv2.RuleCondition {
+	return []*elbv2.RuleCondition{
+		{
+			Field: aws.String(conditions.FieldSourceIP),
+			SourceIpConfig: &elbv2.SourceIpConditionConfig{
+				Values: aws.StringSlice(cidrs),
+			},
+		},
+	}
+}
+
+// buildHostConditions builds conditions matching all requests to host, or all requests if host is empty.
+func buildHostConditions(host string) []*elbv2.RuleCondition {
+	if host == "" {
+		return []*elbv2.RuleCondition{
+			{
+				Field: aws.String(conditions.FieldPathPattern),
+				PathPatternConfig: &elbv2.PathPatternConditionConfig{
+					Values: []*string{aws.String("/*")},
+				},
+			},
+		}
+	}
+	return []*elbv2.RuleCondition{
+		{
+			Field: aws.String(conditions.FieldHostHeader),
+			HostHeaderConfig: &elbv2.HostHeaderConditionConfig{
+				Values: []*string{aws.String(host)},
+			},
+		},
+	}
+}
+
+// buildForbiddenActions builds the actions that reject requests filtered by source IP.
+func buildForbiddenActions() []*elbv2.Action {
+	return []*elbv2.Action{
+		{
+			Order: aws.Int64(1),
+			Type:  aws.String(elbv2.ActionTypeEnumFixedResponse),
+			FixedResponseConfig: &elbv2.FixedResponseActionConfig{
+				ContentType: aws.String("text/plain"),
+				StatusCode:  aws.String("403"),
+				MessageBody: aws.String("Forbidden"),
+			},
+		},
+	}
+}
+
+// buildSSLRedirectActions builds the actions that permanently redirect requests to HTTPS on port.
+func buildSSLRedirectActions(port int64) []*elbv2.Action {
+	return []*elbv2.Action{
+		{
+			Order: aws.Int64(1),
+			Type:  aws.String(elbv2.ActionTypeEnumRedirect),
+			RedirectConfig: &elbv2.RedirectActionConfig{
+				Host:       aws.String("#{host}"),
+				Path:       aws.String("/#{path}"),
+				Port:       aws.String(strconv.FormatInt(port, 10)),
+				Protocol:   aws.String(elbv2.ProtocolEnumHttps),
+				Query:      aws.String("#{query}"),
+				StatusCode: aws.String(elbv2.RedirectActionStatusCodeEnumHttp301),
+			},
+		},
+	}
+}
+
 // buildAuthAction builds ELB action for specific authCfg.
 // null will be returned if no auth is required.
 func buildAuthAction(ctx context.Context, authCfg auth.Config) *elbv2.Action {
@@ -395,6 +823,15 @@ func buildAnnotationForwardAction(ctx context.Context, action action.Action, tgG
 				TargetGroupArn: tgt.TargetGroupArn,
 				Weight:         normalizedWeight,
 			})
+		} else if tgt.LambdaFunctionArn != nil {
+			targetGroup, ok := tgGroup.TGByLambda[aws.StringValue(tgt.LambdaFunctionArn)]
+			if !ok {
+				return nil, errors.Errorf("unable to find targetGroup for Lambda function %v", aws.StringValue(tgt.LambdaFunctionArn))
+			}
+			elbTGs = append(elbTGs, &elbv2.TargetGroupTuple{
+				TargetGroupArn: aws.String(targetGroup.Arn),
+				Weight:         normalizedWeight,
+			})
 		} else {
 			backend := extensions.IngressBackend{
 				ServiceName: aws.StringValue(tgt.ServiceName),
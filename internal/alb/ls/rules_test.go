@@ -6,6 +6,9 @@ import (
 
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/action"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/conditions"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/ipfilter"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/loadbalancer"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/priority"
 	"github.com/pkg/errors"
 
 	"github.com/aws/aws-sdk-go/service/elbv2"
@@ -36,6 +39,41 @@ func Test_rulesController_getDesiredRules(t *testing.T) {
 		},
 	}
 
+	fixedResponseActions := []*elbv2.Action{
+		{
+			Order: aws.Int64(1),
+			Type:  aws.String(elbv2.ActionTypeEnumFixedResponse),
+			FixedResponseConfig: &elbv2.FixedResponseActionConfig{
+				ContentType: aws.String("text/plain"),
+				StatusCode:  aws.String("503"),
+				MessageBody: aws.String("message body"),
+			},
+		},
+	}
+	forbiddenActions := []*elbv2.Action{
+		{
+			Order: aws.Int64(1),
+			Type:  aws.String(elbv2.ActionTypeEnumFixedResponse),
+			FixedResponseConfig: &elbv2.FixedResponseActionConfig{
+				ContentType: aws.String("text/plain"),
+				StatusCode:  aws.String("403"),
+				MessageBody: aws.String("Forbidden"),
+			},
+		},
+	}
+	hostCondition := &elbv2.RuleCondition{
+		Field: aws.String(conditions.FieldHostHeader),
+		HostHeaderConfig: &elbv2.HostHeaderConditionConfig{
+			Values: aws.StringSlice([]string{"www.example.com"}),
+		},
+	}
+	pathCondition := &elbv2.RuleCondition{
+		Field: aws.String(conditions.FieldPathPattern),
+		PathPatternConfig: &elbv2.PathPatternConditionConfig{
+			Values: aws.StringSlice([]string{"/homepage"}),
+		},
+	}
+
 	for _, tc := range []struct {
 		name               string
 		ingress            extensions.Ingress
@@ -1713,6 +1751,363 @@ func Test_rulesController_getDesiredRules(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "one path with host and ip filter",
+			ingress: extensions.Ingress{
+				Spec: extensions.IngressSpec{
+					Rules: []extensions.IngressRule{
+						{
+							Host: "www.example.com",
+							IngressRuleValue: extensions.IngressRuleValue{
+								HTTP: &extensions.HTTPIngressRuleValue{
+									Paths: []extensions.HTTPIngressPath{
+										{
+											Path: "/homepage",
+											Backend: extensions.IngressBackend{
+												ServiceName: "fixed-response-action",
+												ServicePort: intstr.FromString("use-annotation"),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			ingressAnnos: annotations.Ingress{
+				Action: &action.Config{
+					Actions: map[string]action.Action{
+						"fixed-response-action": fixedResponseAction,
+					},
+				},
+				Conditions: &conditions.Config{
+					Conditions: nil,
+				},
+				IPFilter: &ipfilter.Config{
+					Filters: map[string]ipfilter.Filter{
+						"www.example.com": {
+							Allow: []string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16", "100.64.0.0/10"},
+							Deny:  []string{"10.1.0.0/16"},
+						},
+					},
+				},
+			},
+			authNewConfigCalls: []AuthNewConfigCall{
+				{
+					backend: extensions.IngressBackend{
+						ServiceName: "fixed-response-action",
+						ServicePort: intstr.FromString("use-annotation"),
+					},
+					authCfg: auth.Config{Type: auth.TypeNone},
+				},
+			},
+			expected: []elbv2.Rule{
+				{
+					IsDefault: aws.Bool(false),
+					Priority:  aws.String("1"),
+					Conditions: []*elbv2.RuleCondition{
+						{
+							Field: aws.String(conditions.FieldSourceIP),
+							SourceIpConfig: &elbv2.SourceIpConditionConfig{
+								Values: aws.StringSlice([]string{"10.1.0.0/16"}),
+							},
+						},
+						{
+							Field: aws.String(conditions.FieldHostHeader),
+							HostHeaderConfig: &elbv2.HostHeaderConditionConfig{
+								Values: aws.StringSlice([]string{"www.example.com"}),
+							},
+						},
+					},
+					Actions: forbiddenActions,
+				},
+				{
+					IsDefault: aws.Bool(false),
+					Priority:  aws.String("2"),
+					Conditions: []*elbv2.RuleCondition{
+						{
+							Field: aws.String(conditions.FieldSourceIP),
+							SourceIpConfig: &elbv2.SourceIpConditionConfig{
+								Values: aws.StringSlice([]string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16"}),
+							},
+						},
+						hostCondition,
+						pathCondition,
+					},
+					Actions: fixedResponseActions,
+				},
+				{
+					IsDefault: aws.Bool(false),
+					Priority:  aws.String("3"),
+					Conditions: []*elbv2.RuleCondition{
+						{
+							Field: aws.String(conditions.FieldSourceIP),
+							SourceIpConfig: &elbv2.SourceIpConditionConfig{
+								Values: aws.StringSlice([]string{"100.64.0.0/10"}),
+							},
+						},
+						hostCondition,
+						pathCondition,
+					},
+					Actions: fixedResponseActions,
+				},
+				{
+					IsDefault: aws.Bool(false),
+					Priority:  aws.String("4"),
+					Conditions: []*elbv2.RuleCondition{
+						{
+							Field: aws.String(conditions.FieldHostHeader),
+							HostHeaderConfig: &elbv2.HostHeaderConditionConfig{
+								Values: aws.StringSlice([]string{"www.example.com"}),
+							},
+						},
+					},
+					Actions: forbiddenActions,
+				},
+			},
+		},
+		{
+			name: "one path with host and external rule priorities",
+			ingress: extensions.Ingress{
+				Spec: extensions.IngressSpec{
+					Rules: []extensions.IngressRule{
+						{
+							Host: "www.example.com",
+							IngressRuleValue: extensions.IngressRuleValue{
+								HTTP: &extensions.HTTPIngressRuleValue{
+									Paths: []extensions.HTTPIngressPath{
+										{
+											Path: "/homepage",
+											Backend: extensions.IngressBackend{
+												ServiceName: "fixed-response-action",
+												ServicePort: intstr.FromString("use-annotation"),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			ingressAnnos: annotations.Ingress{
+				Action: &action.Config{
+					Actions: map[string]action.Action{
+						"fixed-response-action": fixedResponseAction,
+					},
+				},
+				Conditions: &conditions.Config{
+					Conditions: nil,
+				},
+				LoadBalancer: &loadbalancer.Config{
+					ExternalRulePriorities: loadbalancer.PriorityRanges{{From: 1, To: 2}},
+				},
+			},
+			authNewConfigCalls: []AuthNewConfigCall{
+				{
+					backend: extensions.IngressBackend{
+						ServiceName: "fixed-response-action",
+						ServicePort: intstr.FromString("use-annotation"),
+					},
+					authCfg: auth.Config{Type: auth.TypeNone},
+				},
+			},
+			expected: []elbv2.Rule{
+				{
+					IsDefault:  aws.Bool(false),
+					Priority:   aws.String("3"),
+					Conditions: []*elbv2.RuleCondition{hostCondition, pathCondition},
+					Actions:    fixedResponseActions,
+				},
+			},
+		},
+		{
+			name: "host with invalid characters",
+			ingress: extensions.Ingress{
+				Spec: extensions.IngressSpec{
+					Rules: []extensions.IngressRule{
+						{
+							Host: "www_example.com",
+							IngressRuleValue: extensions.IngressRuleValue{
+								HTTP: &extensions.HTTPIngressRuleValue{
+									Paths: []extensions.HTTPIngressPath{
+										{
+											Path: "/homepage",
+											Backend: extensions.IngressBackend{
+												ServiceName: "fixed-response-action",
+												ServicePort: intstr.FromString("use-annotation"),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			ingressAnnos: annotations.Ingress{
+				Action: &action.Config{
+					Actions: map[string]action.Action{
+						"fixed-response-action": fixedResponseAction,
+					},
+				},
+			},
+			expectedError: errors.New("invalid ingress rule due to host www_example.com contains invalid character '_'"),
+		},
+		{
+			name: "paths with pinned priority",
+			ingress: extensions.Ingress{
+				Spec: extensions.IngressSpec{
+					Rules: []extensions.IngressRule{
+						{
+							Host: "www.example.com",
+							IngressRuleValue: extensions.IngressRuleValue{
+								HTTP: &extensions.HTTPIngressRuleValue{
+									Paths: []extensions.HTTPIngressPath{
+										{
+											Path: "/homepage",
+											Backend: extensions.IngressBackend{
+												ServiceName: "fixed-response-action",
+												ServicePort: intstr.FromString("use-annotation"),
+											},
+										},
+										{
+											Path: "/pinned",
+											Backend: extensions.IngressBackend{
+												ServiceName: "pinned-action",
+												ServicePort: intstr.FromString("use-annotation"),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			ingressAnnos: annotations.Ingress{
+				Action: &action.Config{
+					Actions: map[string]action.Action{
+						"fixed-response-action": fixedResponseAction,
+						"pinned-action":         fixedResponseAction,
+					},
+				},
+				Conditions: &conditions.Config{
+					Conditions: nil,
+				},
+				Priority: &priority.Config{
+					Priorities: map[string]int{"pinned-action": 3},
+				},
+				LoadBalancer: &loadbalancer.Config{
+					ExternalRulePriorities: loadbalancer.PriorityRanges{{From: 1, To: 1}},
+				},
+			},
+			authNewConfigCalls: []AuthNewConfigCall{
+				{
+					backend: extensions.IngressBackend{
+						ServiceName: "fixed-response-action",
+						ServicePort: intstr.FromString("use-annotation"),
+					},
+					authCfg: auth.Config{Type: auth.TypeNone},
+				},
+				{
+					backend: extensions.IngressBackend{
+						ServiceName: "pinned-action",
+						ServicePort: intstr.FromString("use-annotation"),
+					},
+					authCfg: auth.Config{Type: auth.TypeNone},
+				},
+			},
+			expected: []elbv2.Rule{
+				{
+					IsDefault:  aws.Bool(false),
+					Priority:   aws.String("2"),
+					Conditions: []*elbv2.RuleCondition{hostCondition, pathCondition},
+					Actions:    fixedResponseActions,
+				},
+				{
+					IsDefault: aws.Bool(false),
+					Priority:  aws.String("3"),
+					Conditions: []*elbv2.RuleCondition{
+						hostCondition,
+						{
+							Field: aws.String(conditions.FieldPathPattern),
+							PathPatternConfig: &elbv2.PathPatternConditionConfig{
+								Values: aws.StringSlice([]string{"/pinned"}),
+							},
+						},
+					},
+					Actions: fixedResponseActions,
+				},
+			},
+		},
+		{
+			name: "pinned priority reserved for external rules",
+			ingress: extensions.Ingress{
+				Spec: extensions.IngressSpec{
+					Rules: []extensions.IngressRule{
+						{
+							Host: "www.example.com",
+							IngressRuleValue: extensions.IngressRuleValue{
+								HTTP: &extensions.HTTPIngressRuleValue{
+									Paths: []extensions.HTTPIngressPath{
+										{
+											Path: "/homepage",
+											Backend: extensions.IngressBackend{
+												ServiceName: "fixed-response-action",
+												ServicePort: intstr.FromString("use-annotation"),
+											},
+										},
+										{
+											Path: "/pinned",
+											Backend: extensions.IngressBackend{
+												ServiceName: "pinned-action",
+												ServicePort: intstr.FromString("use-annotation"),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			ingressAnnos: annotations.Ingress{
+				Action: &action.Config{
+					Actions: map[string]action.Action{
+						"fixed-response-action": fixedResponseAction,
+						"pinned-action":         fixedResponseAction,
+					},
+				},
+				Conditions: &conditions.Config{
+					Conditions: nil,
+				},
+				Priority: &priority.Config{
+					Priorities: map[string]int{"pinned-action": 1},
+				},
+				LoadBalancer: &loadbalancer.Config{
+					ExternalRulePriorities: loadbalancer.PriorityRanges{{From: 1, To: 1}},
+				},
+			},
+			authNewConfigCalls: []AuthNewConfigCall{
+				{
+					backend: extensions.IngressBackend{
+						ServiceName: "fixed-response-action",
+						ServicePort: intstr.FromString("use-annotation"),
+					},
+					authCfg: auth.Config{Type: auth.TypeNone},
+				},
+				{
+					backend: extensions.IngressBackend{
+						ServiceName: "pinned-action",
+						ServicePort: intstr.FromString("use-annotation"),
+					},
+					authCfg: auth.Config{Type: auth.TypeNone},
+				},
+			},
+			expectedError: errors.New("pinned rule priority 1 exceeds 50000 or collides with another rule"),
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
@@ -1740,6 +2135,146 @@ func Test_rulesController_getDesiredRules(t *testing.T) {
 	}
 }
 
+func Test_withoutExternalRules(t *testing.T) {
+	rules := []elbv2.Rule{
+		{Priority: aws.String("1")},
+		{Priority: aws.String("5")},
+		{Priority: aws.String("11")},
+	}
+	assert.Equal(t, rules, withoutExternalRules(rules, nil))
+	assert.Equal(t, []elbv2.Rule{{Priority: aws.String("1")}, {Priority: aws.String("11")}},
+		withoutExternalRules(rules, loadbalancer.PriorityRanges{{From: 2, To: 10}}))
+}
+
+func Test_mergeHostRules(t *testing.T) {
+	forward := func(tgArn string) []*elbv2.Action {
+		return []*elbv2.Action{{Type: aws.String(elbv2.ActionTypeEnumForward), TargetGroupArn: aws.String(tgArn), Order: aws.Int64(1)}}
+	}
+	rule := func(tgArn string, path string, hosts ...string) elbv2.Rule {
+		var elbConditions []*elbv2.RuleCondition
+		if len(hosts) != 0 {
+			elbConditions = append(elbConditions, &elbv2.RuleCondition{
+				Field:            aws.String(conditions.FieldHostHeader),
+				HostHeaderConfig: &elbv2.HostHeaderConditionConfig{Values: aws.StringSlice(hosts)},
+			})
+		}
+		elbConditions = append(elbConditions, &elbv2.RuleCondition{
+			Field:             aws.String(conditions.FieldPathPattern),
+			PathPatternConfig: &elbv2.PathPatternConditionConfig{Values: aws.StringSlice([]string{path})},
+		})
+		return elbv2.Rule{IsDefault: aws.Bool(false), Actions: forward(tgArn), Conditions: elbConditions}
+	}
+
+	for _, tc := range []struct {
+		name     string
+		rules    []elbv2.Rule
+		expected []elbv2.Rule
+	}{
+		{
+			name:     "hosts with the same path and backend are merged",
+			rules:    []elbv2.Rule{rule("tg1", "/api", "a.example.com"), rule("tg2", "/", "a.example.com"), rule("tg1", "/api", "b.example.com"), rule("tg2", "/", "b.example.com")},
+			expected: []elbv2.Rule{rule("tg1", "/api", "a.example.com", "b.example.com"), rule("tg2", "/", "a.example.com", "b.example.com")},
+		},
+		{
+			name:     "different backends aren't merged",
+			rules:    []elbv2.Rule{rule("tg1", "/", "a.example.com"), rule("tg2", "/", "b.example.com")},
+			expected: []elbv2.Rule{rule("tg1", "/", "a.example.com"), rule("tg2", "/", "b.example.com")},
+		},
+		{
+			name:     "rules aren't merged across a rule that may match the host",
+			rules:    []elbv2.Rule{rule("tg1", "/", "a.example.com"), rule("tg2", "/api", "b.example.com"), rule("tg1", "/", "b.example.com")},
+			expected: []elbv2.Rule{rule("tg1", "/", "a.example.com"), rule("tg2", "/api", "b.example.com"), rule("tg1", "/", "b.example.com")},
+		},
+		{
+			name:     "rules aren't merged across a rule without hosts",
+			rules:    []elbv2.Rule{rule("tg1", "/", "a.example.com"), rule("tg2", "/api"), rule("tg1", "/", "b.example.com")},
+			expected: []elbv2.Rule{rule("tg1", "/", "a.example.com"), rule("tg2", "/api"), rule("tg1", "/", "b.example.com")},
+		},
+		{
+			name:     "wildcard hosts aren't merged",
+			rules:    []elbv2.Rule{rule("tg1", "/", "a.example.com"), rule("tg1", "/", "*.example.com")},
+			expected: []elbv2.Rule{rule("tg1", "/", "a.example.com"), rule("tg1", "/", "*.example.com")},
+		},
+		{
+			name: "merged rules are limited to five condition values",
+			rules: []elbv2.Rule{
+				rule("tg1", "/", "a.example.com"), rule("tg1", "/", "b.example.com"), rule("tg1", "/", "c.example.com"),
+				rule("tg1", "/", "d.example.com"), rule("tg1", "/", "e.example.com"),
+			},
+			expected: []elbv2.Rule{
+				rule("tg1", "/", "a.example.com", "b.example.com", "c.example.com", "d.example.com"), rule("tg1", "/", "e.example.com"),
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, mergeHostRules(tc.rules))
+		})
+	}
+}
+
+func Test_mergePathRules(t *testing.T) {
+	forward := func(tgArn string) []*elbv2.Action {
+		return []*elbv2.Action{{Type: aws.String(elbv2.ActionTypeEnumForward), TargetGroupArn: aws.String(tgArn), Order: aws.Int64(1)}}
+	}
+	rule := func(tgArn string, host string, paths ...string) elbv2.Rule {
+		var elbConditions []*elbv2.RuleCondition
+		if host != "" {
+			elbConditions = append(elbConditions, &elbv2.RuleCondition{
+				Field:            aws.String(conditions.FieldHostHeader),
+				HostHeaderConfig: &elbv2.HostHeaderConditionConfig{Values: aws.StringSlice([]string{host})},
+			})
+		}
+		if len(paths) != 0 {
+			elbConditions = append(elbConditions, &elbv2.RuleCondition{
+				Field:             aws.String(conditions.FieldPathPattern),
+				PathPatternConfig: &elbv2.PathPatternConditionConfig{Values: aws.StringSlice(paths)},
+			})
+		}
+		return elbv2.Rule{IsDefault: aws.Bool(false), Actions: forward(tgArn), Conditions: elbConditions}
+	}
+
+	for _, tc := range []struct {
+		name     string
+		rules    []elbv2.Rule
+		expected []elbv2.Rule
+	}{
+		{
+			name:     "consecutive paths with the same backend are merged",
+			rules:    []elbv2.Rule{rule("tg1", "", "/api"), rule("tg1", "", "/v2/api"), rule("tg2", "", "/*")},
+			expected: []elbv2.Rule{rule("tg1", "", "/api", "/v2/api"), rule("tg2", "", "/*")},
+		},
+		{
+			name:     "paths are merged across rules of other hosts",
+			rules:    []elbv2.Rule{rule("tg1", "a.example.com", "/api"), rule("tg2", "b.example.com", "/*"), rule("tg1", "a.example.com", "/v2/api")},
+			expected: []elbv2.Rule{rule("tg1", "a.example.com", "/api", "/v2/api"), rule("tg2", "b.example.com", "/*")},
+		},
+		{
+			name:     "paths aren't merged across a rule that may match the host",
+			rules:    []elbv2.Rule{rule("tg1", "a.example.com", "/api"), rule("tg2", "a.example.com", "/*"), rule("tg1", "a.example.com", "/v2/api")},
+			expected: []elbv2.Rule{rule("tg1", "a.example.com", "/api"), rule("tg2", "a.example.com", "/*"), rule("tg1", "a.example.com", "/v2/api")},
+		},
+		{
+			name:     "paths without hosts aren't merged across other rules",
+			rules:    []elbv2.Rule{rule("tg1", "", "/api"), rule("tg2", "b.example.com", "/*"), rule("tg1", "", "/v2/api")},
+			expected: []elbv2.Rule{rule("tg1", "", "/api"), rule("tg2", "b.example.com", "/*"), rule("tg1", "", "/v2/api")},
+		},
+		{
+			name:     "rules without paths aren't merged",
+			rules:    []elbv2.Rule{rule("tg1", "a.example.com", "/api"), rule("tg1", "a.example.com")},
+			expected: []elbv2.Rule{rule("tg1", "a.example.com", "/api"), rule("tg1", "a.example.com")},
+		},
+		{
+			name:     "merged rules are limited to five condition values",
+			rules:    []elbv2.Rule{rule("tg1", "a.example.com", "/a", "/b"), rule("tg1", "a.example.com", "/c", "/d"), rule("tg1", "a.example.com", "/e")},
+			expected: []elbv2.Rule{rule("tg1", "a.example.com", "/a", "/b", "/c", "/d"), rule("tg1", "a.example.com", "/e")},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, mergePathRules(tc.rules))
+		})
+	}
+}
+
 type GetRulesCall struct {
 	Output []*elbv2.Rule
 	Error  error
@@ -2907,3 +3442,35 @@ func redirectActionConfig(override *elbv2.RedirectActionConfig) *elbv2.RedirectA
 	}
 	return r
 }
+
+func Test_sslRedirectPort(t *testing.T) {
+	redirectAnnos := &annotations.Ingress{LoadBalancer: &loadbalancer.Config{SSLRedirect: aws.Int64(443)}}
+	assert.Equal(t, aws.Int64(443), sslRedirectPort(redirectAnnos, elbv2.ProtocolEnumHttp))
+	assert.Nil(t, sslRedirectPort(redirectAnnos, elbv2.ProtocolEnumHttps))
+	assert.Nil(t, sslRedirectPort(&annotations.Ingress{LoadBalancer: &loadbalancer.Config{}}, elbv2.ProtocolEnumHttp))
+	assert.Nil(t, sslRedirectPort(&annotations.Ingress{}, elbv2.ProtocolEnumHttp))
+
+	rules, err := (&rulesController{}).getDesiredRules(context.Background(), &elbv2.Listener{Protocol: aws.String(elbv2.ProtocolEnumHttp)},
+		&extensions.Ingress{}, redirectAnnos, tg.TargetGroupGroup{})
+	assert.NoError(t, err)
+	assert.Nil(t, rules)
+}
+
+func Test_buildAnnotationForwardAction_lambda(t *testing.T) {
+	functionArn := "arn:aws:lambda:us-west-2:123456789012:function:fn"
+	forwardAction := action.Action{
+		Type: aws.String(elbv2.ActionTypeEnumForward),
+		ForwardConfig: &action.ForwardActionConfig{
+			TargetGroups: []*action.TargetGroupTuple{{LambdaFunctionArn: aws.String(functionArn)}},
+		},
+	}
+
+	elbAction, err := buildAnnotationForwardAction(context.Background(), forwardAction, tg.TargetGroupGroup{
+		TGByLambda: map[string]tg.TargetGroup{functionArn: {Arn: "lambda-tg-arn"}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []*elbv2.TargetGroupTuple{{TargetGroupArn: aws.String("lambda-tg-arn"), Weight: aws.Int64(1)}}, elbAction.ForwardConfig.TargetGroups)
+
+	_, err = buildAnnotationForwardAction(context.Background(), forwardAction, tg.TargetGroupGroup{})
+	assert.EqualError(t, err, "unable to find targetGroup for Lambda function "+functionArn)
+}
@@ -0,0 +1,128 @@
+package ls
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/k8s"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+)
+
+type TLSCertResolver interface {
+	// Resolve returns the ACM certificates referenced by secretName of ingress's TLS entries.
+	Resolve(ctx context.Context, ingress *extensions.Ingress) ([]string, error)
+}
+
+// NewTLSCertResolver constructs a TLSCertResolver that recognizes these conventions for spec.tls[].secretName:
+// 1. the secretName is an ACM certificate ARN.
+// 2. the secretName references a secret that contains only an ACM certificate ARN.
+// 3. the secretName references a kubernetes.io/tls secret, which is imported into ACM by importer unless it's nil.
+func NewTLSCertResolver(cache cache.Cache, importer CertImporter) TLSCertResolver {
+	return &defaultTLSCertResolver{
+		cache:    cache,
+		importer: importer,
+	}
+}
+
+type defaultTLSCertResolver struct {
+	cache    cache.Cache
+	importer CertImporter
+}
+
+func (r *defaultTLSCertResolver) Resolve(ctx context.Context, ingress *extensions.Ingress) ([]string, error) {
+	var certARNs []string
+	for _, tls := range ingress.Spec.TLS {
+		if tls.SecretName == "" {
+			continue
+		}
+		if isACMCertificateARN(tls.SecretName) {
+			certARNs = appendIfMissing(certARNs, tls.SecretName)
+			continue
+		}
+
+		secretKey := tlsSecretKey(ingress, tls.SecretName)
+		secret := corev1.Secret{}
+		if err := r.cache.Get(ctx, secretKey, &secret); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, errors.Wrapf(err, "failed to load k8s secret: %v", secretKey)
+		}
+		if certARN, ok := certificateARNFromSecret(secret); ok {
+			certARNs = appendIfMissing(certARNs, certARN)
+			continue
+		}
+		if r.importer != nil && isTLSSecret(secret) {
+			certARN, err := r.importer.Import(ctx, k8s.NamespacedName(ingress), &secret)
+			if err != nil {
+				return nil, err
+			}
+			certARNs = appendIfMissing(certARNs, certARN)
+		}
+	}
+	return certARNs, nil
+}
+
+// TLSSecretKeys returns the keys of secrets referenced by ingress's TLS entries, in "namespace/name" form.
+func TLSSecretKeys(ingress *extensions.Ingress) sets.String {
+	secretKeys := sets.NewString()
+	for _, tls := range ingress.Spec.TLS {
+		if tls.SecretName == "" || isACMCertificateARN(tls.SecretName) {
+			continue
+		}
+		secretKeys.Insert(tlsSecretKey(ingress, tls.SecretName).String())
+	}
+	return secretKeys
+}
+
+func tlsSecretKey(ingress *extensions.Ingress, secretName string) types.NamespacedName {
+	// secrets of merged IngressGroup ingresses are qualified by the namespace of their member ingress.
+	if parts := strings.SplitN(secretName, "/", 2); len(parts) == 2 {
+		return types.NamespacedName{Namespace: parts[0], Name: parts[1]}
+	}
+	return types.NamespacedName{Namespace: ingress.Namespace, Name: secretName}
+}
+
+func isTLSSecret(secret corev1.Secret) bool {
+	return len(secret.Data[corev1.TLSCertKey]) != 0 && len(secret.Data[corev1.TLSPrivateKeyKey]) != 0
+}
+
+// certificateARNFromSecret returns the ACM certificate ARN if it's the only content of secret.
+func certificateARNFromSecret(secret corev1.Secret) (string, bool) {
+	if len(secret.Data)+len(secret.StringData) != 1 {
+		return "", false
+	}
+	for _, v := range secret.Data {
+		value := strings.TrimSpace(string(v))
+		return value, isACMCertificateARN(value)
+	}
+	for _, v := range secret.StringData {
+		value := strings.TrimSpace(v)
+		return value, isACMCertificateARN(value)
+	}
+	return "", false
+}
+
+func isACMCertificateARN(s string) bool {
+	parsed, err := arn.Parse(s)
+	if err != nil {
+		return false
+	}
+	return parsed.Service == "acm" && strings.HasPrefix(parsed.Resource, "certificate/")
+}
+
+func appendIfMissing(certARNs []string, certARN string) []string {
+	for _, existing := range certARNs {
+		if existing == certARN {
+			return certARNs
+		}
+	}
+	return append(certARNs, certARN)
+}
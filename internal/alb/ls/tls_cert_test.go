@@ -0,0 +1,151 @@
+package ls
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	mock_cache "github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks/controller-runtime/cache"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func TestDefaultTLSCertResolver_Resolve(t *testing.T) {
+	const certARN1 = "arn:aws:acm:us-west-2:123456789012:certificate/11111111-1111-1111-1111-111111111111"
+	const certARN2 = "arn:aws:acm:us-west-2:123456789012:certificate/22222222-2222-2222-2222-222222222222"
+
+	type secretCall struct {
+		name   string
+		secret *corev1.Secret
+		err    error
+	}
+	for _, tc := range []struct {
+		name             string
+		tls              []extensions.IngressTLS
+		secretCalls      []secretCall
+		importer         CertImporter
+		expectedCertARNs []string
+		expectedErr      string
+	}{
+		{
+			name: "secretName is certificate ARN",
+			tls: []extensions.IngressTLS{
+				{Hosts: []string{"a.example.com"}, SecretName: certARN1},
+				{Hosts: []string{"b.example.com"}, SecretName: certARN1},
+				{Hosts: []string{"c.example.com"}, SecretName: certARN2},
+			},
+			expectedCertARNs: []string{certARN1, certARN2},
+		},
+		{
+			name: "secret contains only certificate ARN",
+			tls: []extensions.IngressTLS{
+				{Hosts: []string{"a.example.com"}, SecretName: "arn-secret"},
+			},
+			secretCalls: []secretCall{
+				{
+					name: "arn-secret",
+					secret: &corev1.Secret{
+						Data: map[string][]byte{"certificate-arn": []byte(certARN1 + "\n")},
+					},
+				},
+			},
+			expectedCertARNs: []string{certARN1},
+		},
+		{
+			name: "tls secrets and missing secrets are ignored",
+			tls: []extensions.IngressTLS{
+				{Hosts: []string{"a.example.com"}, SecretName: "tls-secret"},
+				{Hosts: []string{"b.example.com"}, SecretName: "missing-secret"},
+				{Hosts: []string{"c.example.com"}},
+			},
+			secretCalls: []secretCall{
+				{
+					name: "tls-secret",
+					secret: &corev1.Secret{
+						Data: map[string][]byte{"tls.crt": []byte("crt"), "tls.key": []byte("key")},
+					},
+				},
+				{
+					name: "missing-secret",
+					err:  apierrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, "missing-secret"),
+				},
+			},
+			expectedCertARNs: nil,
+		},
+		{
+			name: "tls secrets are imported",
+			tls: []extensions.IngressTLS{
+				{Hosts: []string{"a.example.com"}, SecretName: "tls-secret"},
+			},
+			secretCalls: []secretCall{
+				{
+					name: "tls-secret",
+					secret: &corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{Namespace: "namespace", Name: "tls-secret"},
+						Data:       map[string][]byte{"tls.crt": []byte("crt"), "tls.key": []byte("key")},
+					},
+				},
+			},
+			importer:         &stubCertImporter{certARN: certARN2},
+			expectedCertARNs: []string{certARN2},
+		},
+		{
+			name: "failed to get secret",
+			tls: []extensions.IngressTLS{
+				{Hosts: []string{"a.example.com"}, SecretName: "arn-secret"},
+			},
+			secretCalls: []secretCall{
+				{
+					name: "arn-secret",
+					err:  errors.New("boom"),
+				},
+			},
+			expectedErr: "failed to load k8s secret: namespace/arn-secret: boom",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockCache := mock_cache.NewMockCache(ctrl)
+			for _, call := range tc.secretCalls {
+				expect := mockCache.EXPECT().Get(gomock.Any(), types.NamespacedName{Namespace: "namespace", Name: call.name}, gomock.Any())
+				if call.secret != nil {
+					expect.SetArg(2, *call.secret)
+				}
+				expect.Return(call.err)
+			}
+
+			resolver := NewTLSCertResolver(mockCache, tc.importer)
+			certARNs, err := resolver.Resolve(context.Background(), &extensions.Ingress{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "namespace", Name: "ingress"},
+				Spec:       extensions.IngressSpec{TLS: tc.tls},
+			})
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedCertARNs, certARNs)
+			}
+		})
+	}
+}
+
+type stubCertImporter struct {
+	certARN string
+}
+
+func (i *stubCertImporter) Import(ctx context.Context, ingressKey types.NamespacedName, secret *corev1.Secret) (string, error) {
+	return i.certARN, nil
+}
+
+func (i *stubCertImporter) GC(ctx context.Context, ingressKey types.NamespacedName, inUse sets.String) error {
+	return nil
+}
@@ -0,0 +1,245 @@
+package orphan
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/generator"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/group"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/metric"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/metric/collectors"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/shard"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/log"
+	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+const (
+	// ModeAudit only reports orphaned resources.
+	ModeAudit = "audit"
+	// ModeDelete deletes orphaned resources.
+	ModeDelete = "delete"
+
+	// maximum number of resources per DescribeTags call.
+	describeTagsBatchSize = 20
+)
+
+// NewSweeper constructs a runnable that periodically looks for ALBs, targetGroups and securityGroups tagged for clusterName
+// whose ingress no longer exists, e.g. because the controller crashed halfway through creating them.
+// In ModeDelete, a resource is deleted once every sweep for at least gracePeriod found it orphaned.
+func NewSweeper(cloud aws.CloudAPI, mc metric.Collector, client client.Reader, clusterName string, interval time.Duration, mode string, gracePeriod time.Duration) manager.Runnable {
+	return &sweeper{
+		cloud:       cloud,
+		mc:          mc,
+		client:      client,
+		clusterName: clusterName,
+		interval:    interval,
+		mode:        mode,
+		gracePeriod: gracePeriod,
+		now:         time.Now,
+		orphans:     make(map[string]time.Time),
+		logger:      log.New("orphan-sweeper"),
+	}
+}
+
+type sweeper struct {
+	cloud       aws.CloudAPI
+	mc          metric.Collector
+	client      client.Reader
+	clusterName string
+	interval    time.Duration
+	mode        string
+	gracePeriod time.Duration
+	now         func() time.Time
+
+	// orphans are the IDs of resources found orphaned by the previous sweep, mapped to when they were first found orphaned.
+	orphans map[string]time.Time
+	logger  *log.Logger
+}
+
+// resource is an AWS resource tagged with the ingress it belongs to.
+type resource struct {
+	resourceType string
+	id           string
+	ingressKey   types.NamespacedName
+}
+
+// Start implements manager.Runnable
+func (s *sweeper) Start(stop <-chan struct{}) error {
+	wait.Until(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), s.interval)
+		defer cancel()
+		if err := s.sweep(ctx); err != nil {
+			s.logger.Errorf("failed to sweep orphaned resources due to %v", err)
+		}
+	}, s.interval, stop)
+	return nil
+}
+
+func (s *sweeper) sweep(ctx context.Context) error {
+	resources, err := s.listResources(ctx)
+	if err != nil {
+		return err
+	}
+	// ingresses are listed after resources, so that resources of an ingress created in between are never deemed orphaned.
+	ingressKeys, err := s.listIngressKeys(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := s.now()
+	orphans := make(map[string]time.Time)
+	counts := make(map[string]int)
+	var deletions []resource
+	for _, res := range resources {
+		if ingressKeys.Has(res.ingressKey.String()) {
+			continue
+		}
+		// a resource whose ingress reappeared in between starts over.
+		firstSeen, ok := s.orphans[res.id]
+		if !ok {
+			firstSeen = now
+		}
+		orphans[res.id] = firstSeen
+		counts[res.resourceType]++
+		if s.mode == ModeDelete && ok && now.Sub(firstSeen) >= s.gracePeriod {
+			deletions = append(deletions, res)
+		} else {
+			s.logger.Infof("%v %v of ingress %v is orphaned", res.resourceType, res.id, res.ingressKey)
+		}
+	}
+	s.orphans = orphans
+	s.mc.SetOrphanedResources(counts)
+
+	// resources are listed in the order they depend on each other, so that a LoadBalancer is deleted before its targetGroups,
+	// resources failing to delete, e.g. securityGroups still attached to network interfaces, are retried at the next sweep.
+	for _, res := range deletions {
+		s.logger.Infof("deleting orphaned %v %v of ingress %v", res.resourceType, res.id, res.ingressKey)
+		if err := s.delete(ctx, res); err != nil {
+			s.logger.Errorf("failed to delete orphaned %v %v due to %v", res.resourceType, res.id, err)
+		}
+	}
+	return nil
+}
+
+func (s *sweeper) delete(ctx context.Context, res resource) error {
+	switch res.resourceType {
+	case collectors.OrphanTypeLoadBalancer:
+		return s.cloud.DeleteLoadBalancerByArn(ctx, res.id)
+	case collectors.OrphanTypeTargetGroup:
+		return s.cloud.DeleteTargetGroupByArn(ctx, res.id)
+	case collectors.OrphanTypeSecurityGroup:
+		return s.cloud.DeleteSecurityGroupByID(ctx, res.id)
+	}
+	return fmt.Errorf("unknown resource type %v", res.resourceType)
+}
+
+// listIngressKeys returns the keys of every ingress and IngressGroup in the cluster, regardless of their ingress class,
+// so that resources of ingresses moved to another class are left alone.
+func (s *sweeper) listIngressKeys(ctx context.Context) (sets.String, error) {
+	ingressList := &extensions.IngressList{}
+	if err := s.client.List(ctx, &client.ListOptions{}, ingressList); err != nil {
+		return nil, fmt.Errorf("failed to list ingresses due to %w", err)
+	}
+	keys := sets.NewString()
+	for _, ingress := range ingressList.Items {
+		keys.Insert(types.NamespacedName{Namespace: ingress.Namespace, Name: ingress.Name}.String())
+		if groupName := group.Name(&ingress); groupName != "" {
+			keys.Insert(group.Key(groupName).String())
+		}
+	}
+	return keys, nil
+}
+
+// listResources returns the LoadBalancers, targetGroups and securityGroups owned by the cluster and tagged with the ingress they belong to.
+// Resources tagged for the cluster without an ingress, like the backend securityGroup or NLBs of LoadBalancer services, are excluded.
+func (s *sweeper) listResources(ctx context.Context) ([]resource, error) {
+	var resources []resource
+	for _, elbv2Type := range []struct {
+		resourceType   string
+		rgtAPIResource string
+	}{
+		{collectors.OrphanTypeLoadBalancer, aws.ResourceTypeEnumELBLoadBalancer},
+		{collectors.OrphanTypeTargetGroup, aws.ResourceTypeEnumELBTargetGroup},
+	} {
+		arns, err := s.cloud.GetResourcesByFilters(map[string][]string{
+			"kubernetes.io/cluster/" + s.clusterName: {"owned"},
+		}, elbv2Type.rgtAPIResource)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %v by tags due to %w", elbv2Type.resourceType, err)
+		}
+		for i := 0; i < len(arns); i += describeTagsBatchSize {
+			end := i + describeTagsBatchSize
+			if end > len(arns) {
+				end = len(arns)
+			}
+			resp, err := s.cloud.DescribeELBV2TagsWithContext(ctx, &elbv2.DescribeTagsInput{
+				ResourceArns: aws.StringSlice(arns[i:end]),
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to describe tags of %v due to %w", elbv2Type.resourceType, err)
+			}
+			for _, desc := range resp.TagDescriptions {
+				tags := make(map[string]string, len(desc.Tags))
+				for _, tag := range desc.Tags {
+					tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+				}
+				// the resourcegroupstaggingapi is eventually consistent, so ownership is checked against the current tags.
+				if tags["kubernetes.io/cluster/"+s.clusterName] != "owned" {
+					continue
+				}
+				if res, ok := newResource(elbv2Type.resourceType, aws.StringValue(desc.ResourceArn), tags); ok {
+					resources = append(resources, res)
+				}
+			}
+		}
+	}
+
+	securityGroups, err := s.cloud.DescribeSecurityGroups(ctx, &ec2.DescribeSecurityGroupsInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("tag:" + generator.TagKeyClusterName),
+				Values: aws.StringSlice([]string{s.clusterName}),
+			},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe securityGroups by tags due to %w", err)
+	}
+	for _, securityGroup := range securityGroups {
+		tags := make(map[string]string, len(securityGroup.Tags))
+		for _, tag := range securityGroup.Tags {
+			tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+		}
+		// securityGroups aren't tagged with kubernetes.io/cluster/<clusterName>, see generator.TagGenerator.
+		if tags[generator.TagKeyClusterName] != s.clusterName {
+			continue
+		}
+		if res, ok := newResource(collectors.OrphanTypeSecurityGroup, aws.StringValue(securityGroup.GroupId), tags); ok {
+			resources = append(resources, res)
+		}
+	}
+	return resources, nil
+}
+
+// newResource returns the resource with id and tags, unless it isn't tagged with an ingress.
+// Resources of shards, secondary ALBs and green stacks belong to the ingress they're split from.
+func newResource(resourceType string, id string, tags map[string]string) (resource, bool) {
+	namespace, ingressName := tags[generator.TagKeyNamespace], tags[generator.TagKeyIngressName]
+	if namespace == "" || ingressName == "" {
+		return resource{}, false
+	}
+	return resource{
+		resourceType: resourceType,
+		id:           id,
+		ingressKey:   types.NamespacedName{Namespace: namespace, Name: shard.ParentName(ingressName)},
+	}, true
+}
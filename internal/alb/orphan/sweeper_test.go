@@ -0,0 +1,153 @@
+package orphan
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/metric"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/log"
+	"github.com/stretchr/testify/assert"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func elbv2Tags(namespace string, ingressName string) []*elbv2.Tag {
+	return []*elbv2.Tag{
+		{Key: aws.String("kubernetes.io/cluster/cluster"), Value: aws.String("owned")},
+		{Key: aws.String("kubernetes.io/namespace"), Value: aws.String(namespace)},
+		{Key: aws.String("kubernetes.io/ingress-name"), Value: aws.String(ingressName)},
+	}
+}
+
+func Test_sweep(t *testing.T) {
+	now := time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)
+	for _, tc := range []struct {
+		Name              string
+		Mode              string
+		PreviousOrphans   map[string]time.Time
+		ExpectedOrphans   map[string]time.Time
+		ExpectedDeletions bool
+	}{
+		{
+			Name:            "audit mode never deletes",
+			Mode:            ModeAudit,
+			PreviousOrphans: map[string]time.Time{"orphanLB": now.Add(-48 * time.Hour), "orphanTG": now.Add(-48 * time.Hour), "sg-orphan": now.Add(-48 * time.Hour)},
+			ExpectedOrphans: map[string]time.Time{"orphanLB": now.Add(-48 * time.Hour), "orphanTG": now.Add(-48 * time.Hour), "sg-orphan": now.Add(-48 * time.Hour)},
+		},
+		{
+			Name:            "resources are only deleted once found orphaned twice",
+			Mode:            ModeDelete,
+			ExpectedOrphans: map[string]time.Time{"orphanLB": now, "orphanTG": now, "sg-orphan": now},
+		},
+		{
+			Name:            "resources are only deleted once found orphaned during the grace period",
+			Mode:            ModeDelete,
+			PreviousOrphans: map[string]time.Time{"orphanLB": now.Add(-time.Hour), "orphanTG": now.Add(-time.Hour), "sg-orphan": now.Add(-time.Hour)},
+			ExpectedOrphans: map[string]time.Time{"orphanLB": now.Add(-time.Hour), "orphanTG": now.Add(-time.Hour), "sg-orphan": now.Add(-time.Hour)},
+		},
+		{
+			Name:              "orphaned resources are deleted",
+			Mode:              ModeDelete,
+			PreviousOrphans:   map[string]time.Time{"orphanLB": now.Add(-48 * time.Hour), "orphanTG": now.Add(-48 * time.Hour), "sg-orphan": now.Add(-48 * time.Hour), "liveLB": now.Add(-48 * time.Hour)},
+			ExpectedOrphans:   map[string]time.Time{"orphanLB": now.Add(-48 * time.Hour), "orphanTG": now.Add(-48 * time.Hour), "sg-orphan": now.Add(-48 * time.Hour)},
+			ExpectedDeletions: true,
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			ctx := context.Background()
+			cloud := &mocks.CloudAPI{}
+			cloud.On("GetResourcesByFilters", map[string][]string{
+				"kubernetes.io/cluster/cluster": {"owned"},
+			}, aws.ResourceTypeEnumELBLoadBalancer).Return([]string{"liveLB", "shardLB", "groupLB", "orphanLB", "serviceLB", "untaggedLB"}, nil)
+			cloud.On("DescribeELBV2TagsWithContext", ctx, &elbv2.DescribeTagsInput{
+				ResourceArns: aws.StringSlice([]string{"liveLB", "shardLB", "groupLB", "orphanLB", "serviceLB", "untaggedLB"}),
+			}).Return(&elbv2.DescribeTagsOutput{
+				TagDescriptions: []*elbv2.TagDescription{
+					{ResourceArn: aws.String("liveLB"), Tags: elbv2Tags("namespace", "live")},
+					{ResourceArn: aws.String("shardLB"), Tags: elbv2Tags("namespace", "live.shard-1")},
+					{ResourceArn: aws.String("groupLB"), Tags: elbv2Tags("ingress.group", "group")},
+					{ResourceArn: aws.String("orphanLB"), Tags: elbv2Tags("namespace", "deleted")},
+					{
+						ResourceArn: aws.String("serviceLB"),
+						Tags: []*elbv2.Tag{
+							{Key: aws.String("kubernetes.io/cluster/cluster"), Value: aws.String("owned")},
+							{Key: aws.String("kubernetes.io/service-name"), Value: aws.String("namespace/service")},
+						},
+					},
+					{
+						// the ownership tag was removed since the resourcegroupstaggingapi last indexed it.
+						ResourceArn: aws.String("untaggedLB"),
+						Tags: []*elbv2.Tag{
+							{Key: aws.String("kubernetes.io/namespace"), Value: aws.String("namespace")},
+							{Key: aws.String("kubernetes.io/ingress-name"), Value: aws.String("deleted")},
+						},
+					},
+				},
+			}, nil)
+			cloud.On("GetResourcesByFilters", map[string][]string{
+				"kubernetes.io/cluster/cluster": {"owned"},
+			}, aws.ResourceTypeEnumELBTargetGroup).Return([]string{"liveTG", "orphanTG"}, nil)
+			cloud.On("DescribeELBV2TagsWithContext", ctx, &elbv2.DescribeTagsInput{
+				ResourceArns: aws.StringSlice([]string{"liveTG", "orphanTG"}),
+			}).Return(&elbv2.DescribeTagsOutput{
+				TagDescriptions: []*elbv2.TagDescription{
+					{ResourceArn: aws.String("liveTG"), Tags: elbv2Tags("namespace", "live")},
+					{ResourceArn: aws.String("orphanTG"), Tags: elbv2Tags("namespace", "deleted")},
+				},
+			}, nil)
+			cloud.On("DescribeSecurityGroups", ctx, &ec2.DescribeSecurityGroupsInput{
+				Filters: []*ec2.Filter{
+					{Name: aws.String("tag:kubernetes.io/cluster-name"), Values: aws.StringSlice([]string{"cluster"})},
+				},
+			}).Return([]*ec2.SecurityGroup{
+				{
+					GroupId: aws.String("sg-backend"),
+					Tags:    []*ec2.Tag{{Key: aws.String("kubernetes.io/cluster-name"), Value: aws.String("cluster")}},
+				},
+				{
+					GroupId: aws.String("sg-orphan"),
+					Tags: []*ec2.Tag{
+						{Key: aws.String("kubernetes.io/cluster-name"), Value: aws.String("cluster")},
+						{Key: aws.String("kubernetes.io/namespace"), Value: aws.String("namespace")},
+						{Key: aws.String("kubernetes.io/ingress-name"), Value: aws.String("deleted")},
+					},
+				},
+			}, nil)
+			if tc.ExpectedDeletions {
+				cloud.On("DeleteLoadBalancerByArn", ctx, "orphanLB").Return(nil)
+				cloud.On("DeleteTargetGroupByArn", ctx, "orphanTG").Return(nil)
+				cloud.On("DeleteSecurityGroupByID", ctx, "sg-orphan").Return(nil)
+			}
+
+			live := &extensions.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "namespace", Name: "live"}}
+			member := &extensions.Ingress{ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "other",
+				Name:        "member",
+				Annotations: map[string]string{"alb.ingress.kubernetes.io/group.name": "group"},
+			}}
+			s := &sweeper{
+				cloud:       cloud,
+				mc:          metric.DummyCollector{},
+				client:      fake.NewFakeClient(live, member),
+				clusterName: "cluster",
+				mode:        tc.Mode,
+				gracePeriod: 24 * time.Hour,
+				now:         func() time.Time { return now },
+				orphans:     tc.PreviousOrphans,
+				logger:      log.New("test"),
+			}
+			assert.NoError(t, s.sweep(ctx))
+			assert.Equal(t, tc.ExpectedOrphans, s.orphans)
+			cloud.AssertExpectations(t)
+			if !tc.ExpectedDeletions {
+				cloud.AssertNotCalled(t, "DeleteLoadBalancerByArn", ctx, "orphanLB")
+			}
+		})
+	}
+}
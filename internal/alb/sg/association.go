@@ -12,6 +12,7 @@ import (
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
 	"k8s.io/apimachinery/pkg/types"
 
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/loadbalancer"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
 
 	"github.com/aws/aws-sdk-go/service/ec2"
@@ -30,7 +31,8 @@ are provided via annotation `alb.ingress.kubernetes.io/security-groups`.
 
 * external SecurityGroups unspecified:
 	1. controller will automatically create an SecurityGroup, which will be applied to LoadBalancer.
-	2. controller will modify the securityGroup on worker nodes to allow inbound traffic from the LB SecurityGroup.
+	2. controller will modify the securityGroup on worker nodes to allow inbound traffic from the LB SecurityGroup,
+		on the ports of targets and health checks.
 		* under **instance** targeting mode:
 			1. controller will modify the SecurityGroup on primary ENI of all worker nodes to allow traffic from LB SecurityGroup.
 		* under **ip** targeting mode with amazon-vpc-cni-k8s:
@@ -40,6 +42,9 @@ are provided via annotation `alb.ingress.kubernetes.io/security-groups`.
 		2. if there are multiple SecurityGroup on ENI, the single SecurityGroup with tag `kubernetes.io/cluster/<cluster-name>` will be chosen.
 		3. otherwise, error will be raised.
 
+	When the backend securityGroup is enabled by `--backend-security-group`, a securityGroup shared by all managed LoadBalancers is
+	attached to them as well, and worker node SecurityGroups allow inbound traffic from it instead of from each LB SecurityGroup.
+
 	NOTE: older versions will try to create an standalone SecurityGroup which allows from traffic from LB SecurityGroup and attach to worker nodes ENI.
 	This behavior is changed to above due un-scalability caused by AWS limits of allow securityGroup per ENI.
 */
@@ -57,6 +62,10 @@ type AssociationController interface {
 	// Delete ensures the SecurityGroup created for LB are deleted.
 	// Also, if managed LB SecurityGroup is used, the SecurityGroups on worker nodes will be adjusted to remove inbound traffic permission from it.
 	Delete(ctx context.Context, ingKey types.NamespacedName) error
+
+	// Retain leaves the SecurityGroups created for LB in place for a LoadBalancer outliving its ingress, only their ownership tags are removed.
+	// SecurityGroups on worker nodes keep granting inbound traffic permission, so that the LoadBalancer keeps serving.
+	Retain(ctx context.Context, ingKey types.NamespacedName) error
 }
 
 // NewAssociationController constructs a new association controller
@@ -71,10 +80,12 @@ func NewAssociationController(store store.Storer, cloud aws.CloudAPI, tagsContro
 	targetENIsResolver := NewTargetENIsResolver(store, cloud)
 	instanceAttachmentController := NewInstanceAttachmentController(
 		sgController, targetENIsResolver, nameTagGen, store, cloud)
+	backendSGController := NewBackendSGController(sgController, targetENIsResolver, nameTagGen, cloud)
 
 	return &associationController{
 		lbAttachmentController:       lbAttachmentController,
 		instanceAttachmentController: instanceAttachmentController,
+		backendSGController:          backendSGController,
 		sgController:                 sgController,
 		nameTagGen:                   nameTagGen,
 		store:                        store,
@@ -85,6 +96,7 @@ func NewAssociationController(store store.Storer, cloud aws.CloudAPI, tagsContro
 type associationController struct {
 	lbAttachmentController       LbAttachmentController
 	instanceAttachmentController InstanceAttachmentController
+	backendSGController          BackendSGController
 	sgController                 SecurityGroupController
 	nameTagGen                   NameTagGenerator
 
@@ -96,8 +108,12 @@ type associationConfig struct {
 	LbPorts          []int64
 	LbInboundCIDRs   []string
 	LbInboundV6CIDRs []string
-	LbExternalSGs    []string
-	AdditionalTags   map[string]string
+	// LbInboundPrefixLists are IDs of managed prefix lists allowed along with LbInboundCIDRs and LbInboundV6CIDRs.
+	LbInboundPrefixLists []string
+	// LbInboundCIDRsByPort overrides LbInboundCIDRs, LbInboundV6CIDRs and LbInboundPrefixLists for specific ports.
+	LbInboundCIDRsByPort map[int64][]string
+	LbExternalSGs        []string
+	AdditionalTags       map[string]string
 }
 
 func (c *associationController) Setup(ctx context.Context, ingKey types.NamespacedName) (LbAttachmentInfo, error) {
@@ -116,9 +132,16 @@ func (c *associationController) Setup(ctx context.Context, ingKey types.Namespac
 	if err != nil {
 		return LbAttachmentInfo{}, errors.Wrap(err, "failed to reconcile LB managed SecurityGroup")
 	}
+	var backendSG string
+	if c.store.GetConfig().BackendSecurityGroup {
+		if backendSG, err = c.backendSGController.Acquire(ctx, ingKey); err != nil {
+			return LbAttachmentInfo{}, err
+		}
+	}
 	return LbAttachmentInfo{
 		ManagedSGID:   lbManagedSG,
 		ExternalSGIDs: nil,
+		BackendSGID:   backendSG,
 	}, nil
 }
 
@@ -128,7 +151,7 @@ func (c *associationController) Reconcile(ctx context.Context, ingKey types.Name
 	if len(attachmentInfo.ExternalSGIDs) != 0 {
 		return c.reconcileWithExternalSGs(ctx, ingKey, lbInstance, attachmentInfo.ExternalSGIDs)
 	}
-	return c.reconcileWithManagedSGs(ctx, ingKey, lbInstance, attachmentInfo.ManagedSGID, tgGroup)
+	return c.reconcileWithManagedSGs(ctx, ingKey, lbInstance, attachmentInfo, tgGroup)
 }
 
 func (c *associationController) Delete(ctx context.Context, ingKey types.NamespacedName) error {
@@ -136,7 +159,35 @@ func (c *associationController) Delete(ctx context.Context, ingKey types.Namespa
 		return errors.Wrap(err, "failed to delete instance securityGroup attachment")
 	}
 	if err := c.deleteLBManagedSG(ctx, ingKey); err != nil {
-		return fmt.Errorf("failed to delete managed LoadBalancer securityGroups due to %v", err)
+		return fmt.Errorf("failed to delete managed LoadBalancer securityGroups due to %w", err)
+	}
+	return c.releaseBackendSG(ctx, ingKey)
+}
+
+func (c *associationController) Retain(ctx context.Context, ingKey types.NamespacedName) error {
+	sgTagsByName := map[string]map[string]string{
+		c.nameTagGen.NameLBSG(ingKey.Namespace, ingKey.Name):       c.nameTagGen.TagLBSG(ingKey.Namespace, ingKey.Name),
+		c.nameTagGen.NameInstanceSG(ingKey.Namespace, ingKey.Name): c.nameTagGen.TagInstanceSG(ingKey.Namespace, ingKey.Name),
+	}
+	for sgName, sgTags := range sgTagsByName {
+		sgInstance, err := c.cloud.GetSecurityGroupByName(sgName)
+		if err != nil {
+			return err
+		}
+		if sgInstance == nil {
+			continue
+		}
+		var ec2Tags []*ec2.Tag
+		for _, k := range tags.OwnershipKeys(sgTags) {
+			ec2Tags = append(ec2Tags, &ec2.Tag{Key: aws.String(k)})
+		}
+		albctx.GetLogger(ctx).Infof("retaining securityGroup %v, removing its ownership tags", aws.StringValue(sgInstance.GroupId))
+		if _, err := c.cloud.DeleteEC2TagsWithContext(ctx, &ec2.DeleteTagsInput{
+			Resources: []*string{sgInstance.GroupId},
+			Tags:      ec2Tags,
+		}); err != nil {
+			return fmt.Errorf("failed to untag securityGroup %v due to %w", aws.StringValue(sgInstance.GroupId), err)
+		}
 	}
 	return nil
 }
@@ -149,17 +200,39 @@ func (c *associationController) reconcileWithExternalSGs(ctx context.Context, in
 		return errors.Wrap(err, "failed to delete instance securityGroup attachment")
 	}
 	if err := c.deleteLBManagedSG(ctx, ingKey); err != nil {
-		return fmt.Errorf("failed to delete managed LoadBalancer securityGroups due to %v", err)
+		return fmt.Errorf("failed to delete managed LoadBalancer securityGroups due to %w", err)
 	}
-	return nil
+	return c.releaseBackendSG(ctx, ingKey)
 }
 
-func (c *associationController) reconcileWithManagedSGs(ctx context.Context, ingKey types.NamespacedName, lbInstance *elbv2.LoadBalancer, lbManagedSGID string, tgGroup tg.TargetGroupGroup) error {
-	if err := c.lbAttachmentController.Reconcile(ctx, lbInstance, []string{lbManagedSGID}); err != nil {
+func (c *associationController) reconcileWithManagedSGs(ctx context.Context, ingKey types.NamespacedName, lbInstance *elbv2.LoadBalancer, attachmentInfo LbAttachmentInfo, tgGroup tg.TargetGroupGroup) error {
+	if err := c.lbAttachmentController.Reconcile(ctx, lbInstance, attachmentInfo.SGIDs()); err != nil {
 		return errors.Wrap(err, "failed to reconcile managed LoadBalancer securityGroup attachment")
 	}
-	if err := c.instanceAttachmentController.Reconcile(ctx, ingKey, lbManagedSGID, tgGroup); err != nil {
-		return errors.Wrap(err, "failed to reconcile instance securityGroup attachment")
+	if attachmentInfo.BackendSGID == "" {
+		if err := c.instanceAttachmentController.Reconcile(ctx, ingKey, attachmentInfo.ManagedSGID, tgGroup); err != nil {
+			return errors.Wrap(err, "failed to reconcile instance securityGroup attachment")
+		}
+		return nil
+	}
+
+	if err := c.backendSGController.Reconcile(ctx, attachmentInfo.BackendSGID, tgGroup); err != nil {
+		return errors.Wrap(err, "failed to reconcile backend securityGroup attachment")
+	}
+	// rules for the LB SecurityGroup are superseded by the backend securityGroup.
+	if err := c.instanceAttachmentController.Delete(ctx, ingKey); err != nil {
+		return errors.Wrap(err, "failed to delete instance securityGroup attachment")
+	}
+	return nil
+}
+
+// releaseBackendSG releases the backend securityGroup referenced by ingress, if the backend securityGroup is enabled.
+func (c *associationController) releaseBackendSG(ctx context.Context, ingKey types.NamespacedName) error {
+	if !c.store.GetConfig().BackendSecurityGroup {
+		return nil
+	}
+	if err := c.backendSGController.Release(ctx, ingKey); err != nil {
+		return fmt.Errorf("failed to release backend securityGroup due to %w", err)
 	}
 	return nil
 }
@@ -178,8 +251,22 @@ func (c *associationController) ensureLBManagedSG(ctx context.Context, ingKey ty
 
 	var inboundPermissions []*ec2.IpPermission
 	for _, port := range cfg.LbPorts {
-		ipRanges := make([]*ec2.IpRange, 0, len(cfg.LbInboundCIDRs))
-		for _, cidr := range cfg.LbInboundCIDRs {
+		inboundCIDRs, inboundV6CIDRs, inboundPrefixLists := cfg.LbInboundCIDRs, cfg.LbInboundV6CIDRs, cfg.LbInboundPrefixLists
+		if cidrs, ok := cfg.LbInboundCIDRsByPort[port]; ok {
+			inboundCIDRs, inboundV6CIDRs, inboundPrefixLists = nil, nil, nil
+			for _, cidr := range cidrs {
+				if loadbalancer.IsPrefixListID(cidr) {
+					inboundPrefixLists = append(inboundPrefixLists, cidr)
+				} else if strings.Contains(cidr, ":") {
+					inboundV6CIDRs = append(inboundV6CIDRs, cidr)
+				} else {
+					inboundCIDRs = append(inboundCIDRs, cidr)
+				}
+			}
+		}
+
+		ipRanges := make([]*ec2.IpRange, 0, len(inboundCIDRs))
+		for _, cidr := range inboundCIDRs {
 			ipRanges = append(ipRanges, &ec2.IpRange{
 				CidrIp:      aws.String(cidr),
 				Description: aws.String(fmt.Sprintf("Allow ingress on port %v from %v", port, cidr)),
@@ -194,8 +281,8 @@ func (c *associationController) ensureLBManagedSG(ctx context.Context, ingKey ty
 			})
 		}
 
-		ipv6Ranges := make([]*ec2.Ipv6Range, 0, len(cfg.LbInboundV6CIDRs))
-		for _, cidr := range cfg.LbInboundV6CIDRs {
+		ipv6Ranges := make([]*ec2.Ipv6Range, 0, len(inboundV6CIDRs))
+		for _, cidr := range inboundV6CIDRs {
 			ipv6Ranges = append(ipv6Ranges, &ec2.Ipv6Range{
 				CidrIpv6:    aws.String(cidr),
 				Description: aws.String(fmt.Sprintf("Allow ingress on port %v from %v", port, cidr)),
@@ -209,9 +296,25 @@ func (c *associationController) ensureLBManagedSG(ctx context.Context, ingKey ty
 				Ipv6Ranges: ipv6Ranges,
 			})
 		}
+
+		prefixListIDs := make([]*ec2.PrefixListId, 0, len(inboundPrefixLists))
+		for _, prefixList := range inboundPrefixLists {
+			prefixListIDs = append(prefixListIDs, &ec2.PrefixListId{
+				PrefixListId: aws.String(prefixList),
+				Description:  aws.String(fmt.Sprintf("Allow ingress on port %v from %v", port, prefixList)),
+			})
+		}
+		if len(prefixListIDs) > 0 {
+			inboundPermissions = append(inboundPermissions, &ec2.IpPermission{
+				IpProtocol:    aws.String("tcp"),
+				FromPort:      aws.Int64(port),
+				ToPort:        aws.Int64(port),
+				PrefixListIds: prefixListIDs,
+			})
+		}
 	}
 	if err := c.sgController.Reconcile(ctx, sgInstance, inboundPermissions, sgTags); err != nil {
-		return "", fmt.Errorf("failed to reconcile managed LoadBalancer securityGroup due to %v", err)
+		return "", fmt.Errorf("failed to reconcile managed LoadBalancer securityGroup due to %w", err)
 	}
 	return aws.StringValue(sgInstance.GroupId), nil
 }
@@ -245,12 +348,17 @@ func (c *associationController) buildAssociationConfig(ctx context.Context, ingK
 	if err != nil {
 		return associationConfig{}, err
 	}
+	if err := c.validateExternalSecurityGroups(ctx, lbExternalSGs); err != nil {
+		return associationConfig{}, err
+	}
 	return associationConfig{
-		LbPorts:          lbPorts,
-		LbInboundCIDRs:   ingressAnnos.LoadBalancer.InboundCidrs,
-		LbInboundV6CIDRs: ingressAnnos.LoadBalancer.InboundV6CIDRs,
-		LbExternalSGs:    lbExternalSGs,
-		AdditionalTags:   ingressAnnos.Tags.LoadBalancer,
+		LbPorts:              lbPorts,
+		LbInboundCIDRs:       ingressAnnos.LoadBalancer.InboundCidrs,
+		LbInboundV6CIDRs:     ingressAnnos.LoadBalancer.InboundV6CIDRs,
+		LbInboundPrefixLists: ingressAnnos.LoadBalancer.InboundPrefixLists,
+		LbInboundCIDRsByPort: ingressAnnos.LoadBalancer.InboundCIDRsByPort,
+		LbExternalSGs:        lbExternalSGs,
+		AdditionalTags:       ingressAnnos.Tags.LoadBalancer,
 	}, nil
 }
 
@@ -284,3 +392,31 @@ func (c *associationController) resolveSecurityGroupIDs(ctx context.Context, sgI
 
 	return output, nil
 }
+
+// validateExternalSecurityGroups ensures external SecurityGroups exist within the VPC of the cluster,
+// since the controller leaves their lifecycle and rules to their owner.
+func (c *associationController) validateExternalSecurityGroups(ctx context.Context, sgIDs []string) error {
+	if len(sgIDs) == 0 {
+		return nil
+	}
+	groups, err := c.cloud.DescribeSecurityGroups(ctx, &ec2.DescribeSecurityGroupsInput{
+		GroupIds: aws.StringSlice(sgIDs),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to describe external securityGroups due to %w", err)
+	}
+	found := make(map[string]bool, len(groups))
+	for _, group := range groups {
+		found[aws.StringValue(group.GroupId)] = true
+	}
+	var missing []string
+	for _, sgID := range sgIDs {
+		if !found[sgID] {
+			missing = append(missing, sgID)
+		}
+	}
+	if len(missing) != 0 {
+		return fmt.Errorf("securityGroups %v are not within the VPC of the cluster", strings.Join(missing, ","))
+	}
+	return nil
+}
@@ -72,7 +72,7 @@ func Test_resolveSecurityGroupIDs(t *testing.T) {
 			cloud := &mocks.CloudAPI{}
 			if tc.GetSecurityGroupsByNameInput != nil {
 				cloud.On("GetSecurityGroupsByName",
-					context.TODO(),
+					ctx,
 					tc.GetSecurityGroupsByNameInput).Return(
 					tc.GetSecurityGroupsByNameOutput,
 					tc.GetSecurityGroupsByNameError,
@@ -92,3 +92,58 @@ func Test_resolveSecurityGroupIDs(t *testing.T) {
 		})
 	}
 }
+
+func Test_validateExternalSecurityGroups(t *testing.T) {
+	for _, tc := range []struct {
+		Name  string
+		Input []string
+
+		DescribeSecurityGroupsOutput []*ec2.SecurityGroup
+		DescribeSecurityGroupsError  error
+
+		ExpectedError error
+	}{
+		{
+			Name: "no external securityGroups",
+		},
+		{
+			Name:                         "securityGroups within the VPC",
+			Input:                        []string{"sg-123456", "sg-456789"},
+			DescribeSecurityGroupsOutput: []*ec2.SecurityGroup{{GroupId: aws.String("sg-123456")}, {GroupId: aws.String("sg-456789")}},
+		},
+		{
+			Name:                         "securityGroup outside the VPC",
+			Input:                        []string{"sg-123456", "sg-456789"},
+			DescribeSecurityGroupsOutput: []*ec2.SecurityGroup{{GroupId: aws.String("sg-123456")}},
+			ExpectedError:                errors.New("securityGroups sg-456789 are not within the VPC of the cluster"),
+		},
+		{
+			Name:                        "Error from DescribeSecurityGroups",
+			Input:                       []string{"sg-123456"},
+			DescribeSecurityGroupsError: errors.New("Some API error"),
+			ExpectedError:               errors.New("failed to describe external securityGroups due to Some API error"),
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			ctx := context.Background()
+			cloud := &mocks.CloudAPI{}
+			if len(tc.Input) != 0 {
+				cloud.On("DescribeSecurityGroups", ctx, &ec2.DescribeSecurityGroupsInput{
+					GroupIds: aws.StringSlice(tc.Input),
+				}).Return(tc.DescribeSecurityGroupsOutput, tc.DescribeSecurityGroupsError)
+			}
+
+			controller := &associationController{
+				cloud: cloud,
+			}
+
+			err := controller.validateExternalSecurityGroups(ctx, tc.Input)
+			if tc.ExpectedError != nil {
+				assert.Equal(t, err.Error(), tc.ExpectedError.Error())
+			} else {
+				assert.Equal(t, err, nil)
+			}
+			cloud.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package sg
+
+import (
+	"context"
+	"sync"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tg"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// backendSGPortRange is the port range worker nodes allow from the backend securityGroup, since the ports of targets
+// differ among LoadBalancers sharing it.
+var backendSGPortRange = portRange{from: 0, to: 65535}
+
+// BackendSGController manages the backend securityGroup shared by managed LoadBalancers of the cluster.
+// Worker node securityGroups allow inbound traffic from the backend securityGroup with a single rule, instead of
+// one rule per LoadBalancer, which keeps them under the limit of rules per securityGroup.
+type BackendSGController interface {
+	// Acquire ensures the backend securityGroup exists, and records it's referenced by ingress.
+	Acquire(ctx context.Context, ingKey types.NamespacedName) (string, error)
+
+	// Reconcile ensures securityGroups on ENIs of targets in tgGroup allow inbound traffic from the backend securityGroup.
+	Reconcile(ctx context.Context, backendSGID string, tgGroup tg.TargetGroupGroup) error
+
+	// Release records ingress no longer references the backend securityGroup, which is deleted along with its rules
+	// on worker nodes once it's referenced by neither ingresses nor LoadBalancers.
+	Release(ctx context.Context, ingKey types.NamespacedName) error
+}
+
+// NewBackendSGController constructs a new backend securityGroup controller
+func NewBackendSGController(sgController SecurityGroupController, targetENIsResolver TargetENIsResolver, nameTagGen NameTagGenerator, cloud aws.CloudAPI) BackendSGController {
+	return &backendSGController{
+		sgController:       sgController,
+		targetENIsResolver: targetENIsResolver,
+		nameTagGen:         nameTagGen,
+		cloud:              cloud,
+		refs:               make(map[types.NamespacedName]bool),
+	}
+}
+
+type backendSGController struct {
+	sgController       SecurityGroupController
+	targetENIsResolver TargetENIsResolver
+	nameTagGen         NameTagGenerator
+	cloud              aws.CloudAPI
+
+	// mutex serializes acquires, reconciles and releases, so that the backend securityGroup is never deleted while being acquired,
+	// and workers reconciling targets behind the same worker node securityGroup don't grant the same permission twice.
+	mutex sync.Mutex
+	// refs are the ingresses referencing the backend securityGroup since the controller started,
+	// LoadBalancers referencing it are checked as well, since refs are lost on restarts.
+	refs map[types.NamespacedName]bool
+}
+
+func (c *backendSGController) Acquire(ctx context.Context, ingKey types.NamespacedName) (string, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	sgInstance, err := c.sgController.EnsureSGInstanceByName(ctx, c.nameTagGen.NameBackendSG(), "backend securityGroup shared by LoadBalancers of ALB Ingress Controller")
+	if err != nil {
+		return "", errors.Wrap(err, "failed to reconcile backend securityGroup")
+	}
+	if err := c.sgController.Reconcile(ctx, sgInstance, nil, c.nameTagGen.TagBackendSG()); err != nil {
+		return "", errors.Wrap(err, "failed to reconcile backend securityGroup")
+	}
+	c.refs[ingKey] = true
+	return aws.StringValue(sgInstance.GroupId), nil
+}
+
+func (c *backendSGController) Reconcile(ctx context.Context, backendSGID string, tgGroup tg.TargetGroupGroup) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	instanceSGs, err := findInstanceSGsForTgGroup(ctx, c.cloud, c.targetENIsResolver, tgGroup)
+	if err != nil {
+		return err
+	}
+	// permissions are never revoked here, since other LoadBalancers may have targets behind the same securityGroups.
+	for _, instanceSG := range instanceSGs {
+		if sourceSGPortRanges(backendSGID, instanceSG)[backendSGPortRange] {
+			continue
+		}
+		if err := reconcileSourceSGPermissions(ctx, c.cloud, backendSGID, instanceSG, []portRange{backendSGPortRange}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *backendSGController) Release(ctx context.Context, ingKey types.NamespacedName) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	delete(c.refs, ingKey)
+	if len(c.refs) != 0 {
+		return nil
+	}
+	sgInstance, err := c.cloud.GetSecurityGroupByName(c.nameTagGen.NameBackendSG())
+	if err != nil {
+		return err
+	}
+	if sgInstance == nil {
+		return nil
+	}
+	backendSGID := aws.StringValue(sgInstance.GroupId)
+	referenced, err := c.isReferencedByLoadBalancers(ctx, backendSGID)
+	if err != nil || referenced {
+		return err
+	}
+
+	attachedInstanceSGs, err := findInstanceSGsAttachedWithSourceSG(ctx, c.cloud, backendSGID)
+	if err != nil {
+		return err
+	}
+	for _, instanceSG := range attachedInstanceSGs {
+		if err := reconcileSourceSGPermissions(ctx, c.cloud, backendSGID, instanceSG, nil); err != nil {
+			return err
+		}
+	}
+	albctx.GetLogger(ctx).Infof("deleting backend securityGroup %v", backendSGID)
+	return c.cloud.DeleteSecurityGroupByID(ctx, backendSGID)
+}
+
+// isReferencedByLoadBalancers returns whether any LoadBalancer of the cluster uses the backend securityGroup.
+func (c *backendSGController) isReferencedByLoadBalancers(ctx context.Context, backendSGID string) (bool, error) {
+	loadBalancers, err := c.cloud.GetLoadBalancersByTags(ctx, map[string][]string{
+		"kubernetes.io/cluster/" + c.cloud.GetClusterName(): {"owned"},
+	})
+	if err != nil {
+		return false, errors.Wrap(err, "failed to find LoadBalancers referencing backend securityGroup")
+	}
+	for _, loadBalancer := range loadBalancers {
+		for _, sgID := range loadBalancer.SecurityGroups {
+			if aws.StringValue(sgID) == backendSGID {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
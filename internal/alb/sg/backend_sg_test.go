@@ -0,0 +1,82 @@
+package sg
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+type fakeNameTagGenerator struct {
+	NameTagGenerator
+}
+
+func (fakeNameTagGenerator) NameBackendSG() string {
+	return "prefix-backend"
+}
+
+func Test_backendSGController_Release(t *testing.T) {
+	ingKey := types.NamespacedName{Namespace: "namespace", Name: "ingress"}
+	backendSG := &ec2.SecurityGroup{GroupId: aws.String("sg-backend")}
+	instanceSG := &ec2.SecurityGroup{
+		GroupId:       aws.String("sg-instance"),
+		IpPermissions: []*ec2.IpPermission{sourceSGPermission("sg-backend", backendSGPortRange)},
+	}
+	for _, tc := range []struct {
+		name          string
+		otherRefs     []types.NamespacedName
+		loadBalancers []*elbv2.LoadBalancer
+		expectDelete  bool
+	}{
+		{
+			name:      "referenced by other ingresses",
+			otherRefs: []types.NamespacedName{{Namespace: "namespace", Name: "other"}},
+		},
+		{
+			name: "referenced by LoadBalancers",
+			loadBalancers: []*elbv2.LoadBalancer{
+				{SecurityGroups: aws.StringSlice([]string{"sg-managed", "sg-backend"})},
+			},
+		},
+		{
+			name: "unreferenced",
+			loadBalancers: []*elbv2.LoadBalancer{
+				{SecurityGroups: aws.StringSlice([]string{"sg-external"})},
+			},
+			expectDelete: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			cloud := &mocks.CloudAPI{}
+			if len(tc.otherRefs) == 0 {
+				cloud.On("GetSecurityGroupByName", "prefix-backend").Return(backendSG, nil)
+				cloud.On("GetClusterName").Return("cluster")
+				cloud.On("GetLoadBalancersByTags", ctx, map[string][]string{"kubernetes.io/cluster/cluster": {"owned"}}).Return(tc.loadBalancers, nil)
+			}
+			if tc.expectDelete {
+				cloud.On("DescribeSecurityGroups", ctx, mock.Anything).Return([]*ec2.SecurityGroup{instanceSG}, nil)
+				cloud.On("RevokeSecurityGroupIngressWithContext", ctx, &ec2.RevokeSecurityGroupIngressInput{
+					GroupId:       instanceSG.GroupId,
+					IpPermissions: []*ec2.IpPermission{sourceSGPermission("sg-backend", backendSGPortRange)},
+				}).Return(&ec2.RevokeSecurityGroupIngressOutput{}, nil)
+				cloud.On("DeleteSecurityGroupByID", ctx, "sg-backend").Return(nil)
+			}
+
+			controller := NewBackendSGController(nil, nil, fakeNameTagGenerator{}, cloud).(*backendSGController)
+			controller.refs[ingKey] = true
+			for _, ref := range tc.otherRefs {
+				controller.refs[ref] = true
+			}
+			assert.NoError(t, controller.Release(ctx, ingKey))
+			assert.False(t, controller.refs[ingKey])
+			cloud.AssertExpectations(t)
+		})
+	}
+}
@@ -3,6 +3,8 @@ package sg
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strconv"
 
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tg"
@@ -43,28 +45,29 @@ type instanceAttachmentControllerV2 struct {
 }
 
 func (c *instanceAttachmentControllerV2) Reconcile(ctx context.Context, ingKey types.NamespacedName, lbSGID string, tgGroup tg.TargetGroupGroup) error {
-	targetInstanceSGs, err := c.findInstanceSGsForTgGroup(ctx, tgGroup)
+	targetInstanceSGs, err := findInstanceSGsForTgGroup(ctx, c.cloud, c.targetENIsResolver, tgGroup)
 	if err != nil {
 		return err
 	}
-	targetInstanceSGIDs := sets.StringKeySet(targetInstanceSGs)
-
-	attachedInstanceSGs, err := c.findInstanceSGsAttachedWithLBSG(ctx, lbSGID)
+	attachedInstanceSGs, err := findInstanceSGsAttachedWithSourceSG(ctx, c.cloud, lbSGID)
 	if err != nil {
 		return err
 	}
-	attachedInstanceSGIDs := sets.StringKeySet(attachedInstanceSGs)
 
-	shouldAttachInstanceSGs := targetInstanceSGIDs.Difference(attachedInstanceSGIDs)
-	for instanceSGID := range shouldAttachInstanceSGs {
-		if err := c.ensureLBSGAttachedToInstanceSG(ctx, lbSGID, targetInstanceSGs[instanceSGID]); err != nil {
+	var desiredRanges []portRange
+	for _, port := range targetPortsForTgGroup(tgGroup) {
+		desiredRanges = append(desiredRanges, portRange{from: port, to: port})
+	}
+	for _, instanceSG := range targetInstanceSGs {
+		if err := reconcileSourceSGPermissions(ctx, c.cloud, lbSGID, instanceSG, desiredRanges); err != nil {
 			return err
 		}
 	}
-
-	shouldDetachENIIDs := attachedInstanceSGIDs.Difference(targetInstanceSGIDs)
-	for instanceSGID := range shouldDetachENIIDs {
-		if err := c.ensureLBSGDetachedFromInstanceSG(ctx, lbSGID, attachedInstanceSGs[instanceSGID]); err != nil {
+	for instanceSGID, instanceSG := range attachedInstanceSGs {
+		if _, ok := targetInstanceSGs[instanceSGID]; ok {
+			continue
+		}
+		if err := reconcileSourceSGPermissions(ctx, c.cloud, lbSGID, instanceSG, nil); err != nil {
 			return err
 		}
 	}
@@ -81,21 +84,22 @@ func (c *instanceAttachmentControllerV2) Delete(ctx context.Context, ingKey type
 		return nil
 	}
 	lbSGID := aws.StringValue(sgInstance.GroupId)
-	attachedInstanceSGs, err := c.findInstanceSGsAttachedWithLBSG(ctx, lbSGID)
+	attachedInstanceSGs, err := findInstanceSGsAttachedWithSourceSG(ctx, c.cloud, lbSGID)
 	if err != nil {
 		return err
 	}
 
 	for _, instanceSG := range attachedInstanceSGs {
-		if err := c.ensureLBSGDetachedFromInstanceSG(ctx, lbSGID, instanceSG); err != nil {
+		if err := reconcileSourceSGPermissions(ctx, c.cloud, lbSGID, instanceSG, nil); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func (c *instanceAttachmentControllerV2) findInstanceSGsForTgGroup(ctx context.Context, tgGroup tg.TargetGroupGroup) (map[string]*ec2.SecurityGroup, error) {
-	targetENIs, err := c.targetENIsResolver.Resolve(ctx, tgGroup)
+// findInstanceSGsForTgGroup returns the securityGroups on ENIs of targets in tgGroup, which should allow inbound traffic from LoadBalancers.
+func findInstanceSGsForTgGroup(ctx context.Context, cloud aws.CloudAPI, targetENIsResolver TargetENIsResolver, tgGroup tg.TargetGroupGroup) (map[string]*ec2.SecurityGroup, error) {
+	targetENIs, err := targetENIsResolver.Resolve(ctx, tgGroup)
 	if err != nil {
 		return nil, err
 	}
@@ -107,7 +111,7 @@ func (c *instanceAttachmentControllerV2) findInstanceSGsForTgGroup(ctx context.C
 	if len(sgIDs) == 0 {
 		return nil, nil
 	}
-	sgs, err := c.cloud.DescribeSecurityGroups(ctx, &ec2.DescribeSecurityGroupsInput{
+	sgs, err := cloud.DescribeSecurityGroups(ctx, &ec2.DescribeSecurityGroupsInput{
 		GroupIds: aws.StringSlice(sgIDs.List()),
 	})
 	if err != nil {
@@ -122,7 +126,7 @@ func (c *instanceAttachmentControllerV2) findInstanceSGsForTgGroup(ctx context.C
 		sgByID[aws.StringValue(sg.GroupId)] = sg
 	}
 
-	clusterTag := "kubernetes.io/cluster/" + c.cloud.GetClusterName()
+	clusterTag := "kubernetes.io/cluster/" + cloud.GetClusterName()
 	instanceSGIDs := sets.NewString()
 	for eniID, eni := range targetENIs {
 		eniSGIDs := eni.SecurityGroups()
@@ -155,12 +159,13 @@ func (c *instanceAttachmentControllerV2) findInstanceSGsForTgGroup(ctx context.C
 	return result, nil
 }
 
-func (c *instanceAttachmentControllerV2) findInstanceSGsAttachedWithLBSG(ctx context.Context, lbSGID string) (map[string]*ec2.SecurityGroup, error) {
-	sgs, err := c.cloud.DescribeSecurityGroups(ctx, &ec2.DescribeSecurityGroupsInput{
+// findInstanceSGsAttachedWithSourceSG returns the securityGroups that allow inbound traffic from sourceSGID.
+func findInstanceSGsAttachedWithSourceSG(ctx context.Context, cloud aws.CloudAPI, sourceSGID string) (map[string]*ec2.SecurityGroup, error) {
+	sgs, err := cloud.DescribeSecurityGroups(ctx, &ec2.DescribeSecurityGroupsInput{
 		Filters: []*ec2.Filter{
 			{
 				Name:   aws.String("ip-permission.group-id"),
-				Values: aws.StringSlice([]string{lbSGID}),
+				Values: aws.StringSlice([]string{sourceSGID}),
 			},
 		},
 	})
@@ -175,50 +180,114 @@ func (c *instanceAttachmentControllerV2) findInstanceSGsAttachedWithLBSG(ctx con
 	return sgByID, nil
 }
 
-func (c *instanceAttachmentControllerV2) ensureLBSGAttachedToInstanceSG(ctx context.Context, lbSGID string, instanceSG *ec2.SecurityGroup) error {
-	inboundPermissions := []*ec2.IpPermission{
-		{
-			IpProtocol: aws.String("tcp"),
-			FromPort:   aws.Int64(0),
-			ToPort:     aws.Int64(65535),
-			UserIdGroupPairs: []*ec2.UserIdGroupPair{
-				{
-					GroupId: aws.String(lbSGID),
-				},
-			},
-		},
+// reconcileSourceSGPermissions ensures instanceSG allows inbound traffic from sourceSGID on exactly desiredRanges.
+// Permissions are granted before revoked ones, so that traffic isn't interrupted when ports change.
+func reconcileSourceSGPermissions(ctx context.Context, cloud aws.CloudAPI, sourceSGID string, instanceSG *ec2.SecurityGroup, desiredRanges []portRange) error {
+	currentRanges := sourceSGPortRanges(sourceSGID, instanceSG)
+	desired := make(map[portRange]bool, len(desiredRanges))
+	for _, r := range desiredRanges {
+		desired[r] = true
+	}
+
+	var granted, revoked []*ec2.IpPermission
+	for _, r := range desiredRanges {
+		if !currentRanges[r] {
+			granted = append(granted, sourceSGPermission(sourceSGID, r))
+		}
+	}
+	for _, r := range sortedPortRanges(currentRanges) {
+		if !desired[r] {
+			revoked = append(revoked, sourceSGPermission(sourceSGID, r))
+		}
 	}
 
-	albctx.GetLogger(ctx).Infof("granting inbound permissions to securityGroup %s: %v", aws.StringValue(instanceSG.GroupId), log.Prettify(inboundPermissions))
-	if _, err := c.cloud.AuthorizeSecurityGroupIngressWithContext(ctx, &ec2.AuthorizeSecurityGroupIngressInput{
-		GroupId:       instanceSG.GroupId,
-		IpPermissions: inboundPermissions,
-	}); err != nil {
-		return fmt.Errorf("failed to grant inbound permissions due to %v", err)
+	if len(granted) != 0 {
+		albctx.GetLogger(ctx).Infof("granting inbound permissions to securityGroup %s: %v", aws.StringValue(instanceSG.GroupId), log.Prettify(granted))
+		if _, err := cloud.AuthorizeSecurityGroupIngressWithContext(ctx, &ec2.AuthorizeSecurityGroupIngressInput{
+			GroupId:       instanceSG.GroupId,
+			IpPermissions: granted,
+		}); err != nil {
+			return fmt.Errorf("failed to grant inbound permissions due to %w", err)
+		}
+	}
+	if len(revoked) != 0 {
+		albctx.GetLogger(ctx).Infof("revoking inbound permissions from securityGroup %s: %v", aws.StringValue(instanceSG.GroupId), log.Prettify(revoked))
+		if _, err := cloud.RevokeSecurityGroupIngressWithContext(ctx, &ec2.RevokeSecurityGroupIngressInput{
+			GroupId:       instanceSG.GroupId,
+			IpPermissions: revoked,
+		}); err != nil {
+			return fmt.Errorf("failed to revoke inbound permissions due to %w", err)
+		}
 	}
 	return nil
 }
 
-func (c *instanceAttachmentControllerV2) ensureLBSGDetachedFromInstanceSG(ctx context.Context, lbSGID string, instanceSG *ec2.SecurityGroup) error {
-	inboundPermissions := []*ec2.IpPermission{
-		{
-			IpProtocol: aws.String("tcp"),
-			FromPort:   aws.Int64(0),
-			ToPort:     aws.Int64(65535),
-			UserIdGroupPairs: []*ec2.UserIdGroupPair{
-				{
-					GroupId: aws.String(lbSGID),
-				},
-			},
-		},
+type portRange struct {
+	from int64
+	to   int64
+}
+
+// targetPortsForTgGroup returns the sorted ports that the LoadBalancer sends traffic and health checks to.
+func targetPortsForTgGroup(tgGroup tg.TargetGroupGroup) []int64 {
+	ports := make(map[int64]bool)
+	for _, tgroup := range tgGroup.TGByBackend {
+		for _, target := range tgroup.Targets {
+			if target.Port != nil {
+				ports[aws.Int64Value(target.Port)] = true
+			}
+		}
+		if port, err := strconv.ParseInt(tgroup.HealthCheckPort, 10, 64); err == nil {
+			ports[port] = true
+		}
+	}
+	result := make([]int64, 0, len(ports))
+	for port := range ports {
+		result = append(result, port)
 	}
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+	return result
+}
 
-	albctx.GetLogger(ctx).Infof("revoking inbound permissions from securityGroup %s: %v", aws.StringValue(instanceSG.GroupId), log.Prettify(inboundPermissions))
-	if _, err := c.cloud.RevokeSecurityGroupIngressWithContext(ctx, &ec2.RevokeSecurityGroupIngressInput{
-		GroupId:       instanceSG.GroupId,
-		IpPermissions: inboundPermissions,
-	}); err != nil {
-		return fmt.Errorf("failed to revoke inbound permissions due to %v", err)
+// sourceSGPortRanges returns the TCP port ranges that instanceSG allows inbound traffic from sourceSGID on.
+func sourceSGPortRanges(sourceSGID string, instanceSG *ec2.SecurityGroup) map[portRange]bool {
+	ranges := make(map[portRange]bool)
+	for _, permission := range instanceSG.IpPermissions {
+		if aws.StringValue(permission.IpProtocol) != "tcp" {
+			continue
+		}
+		for _, pair := range permission.UserIdGroupPairs {
+			if aws.StringValue(pair.GroupId) == sourceSGID {
+				ranges[portRange{from: aws.Int64Value(permission.FromPort), to: aws.Int64Value(permission.ToPort)}] = true
+				break
+			}
+		}
+	}
+	return ranges
+}
+
+func sortedPortRanges(ranges map[portRange]bool) []portRange {
+	result := make([]portRange, 0, len(ranges))
+	for r := range ranges {
+		result = append(result, r)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].from != result[j].from {
+			return result[i].from < result[j].from
+		}
+		return result[i].to < result[j].to
+	})
+	return result
+}
+
+func sourceSGPermission(sourceSGID string, r portRange) *ec2.IpPermission {
+	return &ec2.IpPermission{
+		IpProtocol: aws.String("tcp"),
+		FromPort:   aws.Int64(r.from),
+		ToPort:     aws.Int64(r.to),
+		UserIdGroupPairs: []*ec2.UserIdGroupPair{
+			{
+				GroupId: aws.String(sourceSGID),
+			},
+		},
 	}
-	return nil
 }
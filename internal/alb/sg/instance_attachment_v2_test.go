@@ -0,0 +1,86 @@
+package sg
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tg"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
+	"github.com/stretchr/testify/assert"
+	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func Test_targetPortsForTgGroup(t *testing.T) {
+	tgGroup := tg.TargetGroupGroup{
+		TGByBackend: map[extensions.IngressBackend]tg.TargetGroup{
+			{ServiceName: "svc-a", ServicePort: intstr.FromInt(80)}: {
+				Targets: []*elbv2.TargetDescription{
+					{Id: aws.String("i-1"), Port: aws.Int64(30080)},
+					{Id: aws.String("i-2"), Port: aws.Int64(30080)},
+				},
+				HealthCheckPort: "traffic-port",
+			},
+			{ServiceName: "svc-b", ServicePort: intstr.FromInt(80)}: {
+				Targets:         []*elbv2.TargetDescription{{Id: aws.String("i-1"), Port: aws.Int64(30090)}},
+				HealthCheckPort: "30001",
+			},
+		},
+	}
+	assert.Equal(t, []int64{30001, 30080, 30090}, targetPortsForTgGroup(tgGroup))
+}
+
+func Test_reconcileSourceSGPermissions(t *testing.T) {
+	lbSGID := "sg-lb"
+	instanceSG := &ec2.SecurityGroup{
+		GroupId: aws.String("sg-instance"),
+		IpPermissions: []*ec2.IpPermission{
+			sourceSGPermission(lbSGID, portRange{from: 0, to: 65535}),
+			sourceSGPermission(lbSGID, portRange{from: 30080, to: 30080}),
+			sourceSGPermission("sg-other", portRange{from: 30090, to: 30090}),
+		},
+	}
+	for _, tc := range []struct {
+		name          string
+		desiredRanges []portRange
+		granted       []*ec2.IpPermission
+		revoked       []*ec2.IpPermission
+	}{
+		{
+			name:          "ports changed",
+			desiredRanges: []portRange{{from: 30080, to: 30080}, {from: 30090, to: 30090}},
+			granted:       []*ec2.IpPermission{sourceSGPermission(lbSGID, portRange{from: 30090, to: 30090})},
+			revoked:       []*ec2.IpPermission{sourceSGPermission(lbSGID, portRange{from: 0, to: 65535})},
+		},
+		{
+			name: "detached",
+			revoked: []*ec2.IpPermission{
+				sourceSGPermission(lbSGID, portRange{from: 0, to: 65535}),
+				sourceSGPermission(lbSGID, portRange{from: 30080, to: 30080}),
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			cloud := &mocks.CloudAPI{}
+			if tc.granted != nil {
+				cloud.On("AuthorizeSecurityGroupIngressWithContext", ctx, &ec2.AuthorizeSecurityGroupIngressInput{
+					GroupId:       instanceSG.GroupId,
+					IpPermissions: tc.granted,
+				}).Return(&ec2.AuthorizeSecurityGroupIngressOutput{}, nil)
+			}
+			if tc.revoked != nil {
+				cloud.On("RevokeSecurityGroupIngressWithContext", ctx, &ec2.RevokeSecurityGroupIngressInput{
+					GroupId:       instanceSG.GroupId,
+					IpPermissions: tc.revoked,
+				}).Return(&ec2.RevokeSecurityGroupIngressOutput{}, nil)
+			}
+
+			assert.NoError(t, reconcileSourceSGPermissions(ctx, cloud, lbSGID, instanceSG, tc.desiredRanges))
+			cloud.AssertExpectations(t)
+		})
+	}
+}
@@ -66,7 +66,7 @@ func (c *securityGroupController) reconcileInboundPermissions(ctx context.Contex
 			GroupId:       sgInstance.GroupId,
 			IpPermissions: permissionsToRevoke,
 		}); err != nil {
-			return fmt.Errorf("failed to revoke inbound permissions due to %v", err)
+			return fmt.Errorf("failed to revoke inbound permissions due to %w", err)
 		}
 	}
 
@@ -77,7 +77,7 @@ func (c *securityGroupController) reconcileInboundPermissions(ctx context.Contex
 			GroupId:       sgInstance.GroupId,
 			IpPermissions: permissionsToGrant,
 		}); err != nil {
-			return fmt.Errorf("failed to grant inbound permissions due to %v", err)
+			return fmt.Errorf("failed to grant inbound permissions due to %w", err)
 		}
 	}
 
@@ -91,7 +91,7 @@ func (c *securityGroupController) reconcileTags(ctx context.Context, sgInstance
 		curTags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
 	}
 	if err := c.tagsController.ReconcileEC2WithCurTags(ctx, aws.StringValue(sgInstance.GroupId), tags, curTags); err != nil {
-		return fmt.Errorf("failed to reconcile tags due to %v", err)
+		return fmt.Errorf("failed to reconcile tags due to %w", err)
 	}
 	return nil
 }
@@ -136,6 +136,12 @@ func ipPermissionEquals(source *ec2.IpPermission, target *ec2.IpPermission) bool
 	if len(diffIPv6Ranges(target.Ipv6Ranges, source.Ipv6Ranges)) != 0 {
 		return false
 	}
+	if len(diffPrefixListIDs(source.PrefixListIds, target.PrefixListIds)) != 0 {
+		return false
+	}
+	if len(diffPrefixListIDs(target.PrefixListIds, source.PrefixListIds)) != 0 {
+		return false
+	}
 	if len(diffUserIDGroupPairs(source.UserIdGroupPairs, target.UserIdGroupPairs)) != 0 {
 		return false
 	}
@@ -185,6 +191,23 @@ func ipRangeEquals(source *string, target *string) bool {
 	return aws.StringValue(source) == aws.StringValue(target)
 }
 
+// diffPrefixListIDs calculates set_difference as source - target
+func diffPrefixListIDs(source []*ec2.PrefixListId, target []*ec2.PrefixListId) (diffs []*ec2.PrefixListId) {
+	for _, sPrefixList := range source {
+		containsInTarget := false
+		for _, tPrefixList := range target {
+			if aws.StringValue(sPrefixList.PrefixListId) == aws.StringValue(tPrefixList.PrefixListId) {
+				containsInTarget = true
+				break
+			}
+		}
+		if !containsInTarget {
+			diffs = append(diffs, sPrefixList)
+		}
+	}
+	return diffs
+}
+
 // diffUserIDGroupPairs calculates set_difference as source - target
 func diffUserIDGroupPairs(source []*ec2.UserIdGroupPair, target []*ec2.UserIdGroupPair) (diffs []*ec2.UserIdGroupPair) {
 	for _, sPair := range source {
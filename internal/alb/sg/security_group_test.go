@@ -366,7 +366,11 @@ func TestReconcile(t *testing.T) {
 			}
 
 			err := sgController.Reconcile(context.Background(), &tc.Instance, tc.InboundPermissions, tc.Tags)
-			assert.Equal(t, err, tc.ExpectedError)
+			if tc.ExpectedError != nil {
+				assert.Equal(t, err.Error(), tc.ExpectedError.Error())
+			} else {
+				assert.Equal(t, err, nil)
+			}
 			tagsController.AssertExpectations(t)
 			cloud.AssertExpectations(t)
 		})
@@ -844,6 +848,44 @@ func TestDiffIPPermissions(t *testing.T) {
 				},
 			},
 		},
+		{
+			source: []*ec2.IpPermission{
+				{
+					IpProtocol: aws.String("tcp"),
+					FromPort:   aws.Int64(443),
+					ToPort:     aws.Int64(443),
+					PrefixListIds: []*ec2.PrefixListId{
+						{
+							PrefixListId: aws.String("pl-00000001"),
+						},
+					},
+				},
+			},
+			target: []*ec2.IpPermission{
+				{
+					IpProtocol: aws.String("tcp"),
+					FromPort:   aws.Int64(443),
+					ToPort:     aws.Int64(443),
+					PrefixListIds: []*ec2.PrefixListId{
+						{
+							PrefixListId: aws.String("pl-00000002"),
+						},
+					},
+				},
+			},
+			expectedDiffs: []*ec2.IpPermission{
+				{
+					IpProtocol: aws.String("tcp"),
+					FromPort:   aws.Int64(443),
+					ToPort:     aws.Int64(443),
+					PrefixListIds: []*ec2.PrefixListId{
+						{
+							PrefixListId: aws.String("pl-00000001"),
+						},
+					},
+				},
+			},
+		},
 	} {
 		actualDiffs := diffIPPermissions(tc.source, tc.target)
 		if !reflect.DeepEqual(tc.expectedDiffs, actualDiffs) {
@@ -7,10 +7,16 @@ type LbAttachmentInfo struct {
 
 	// The external provided securityGroupID.
 	ExternalSGIDs []string
+
+	// The backend securityGroupID shared by LoadBalancers of the cluster. It's only used along with ManagedSGID.
+	BackendSGID string
 }
 
 func (i *LbAttachmentInfo) SGIDs() []string {
 	if i.ManagedSGID != "" {
+		if i.BackendSGID != "" {
+			return []string{i.ManagedSGID, i.BackendSGID}
+		}
 		return []string{i.ManagedSGID}
 	}
 	return i.ExternalSGIDs
@@ -23,6 +29,9 @@ type NameGenerator interface {
 
 	// NameLBSG generates name for managed securityGroup that will be attached to EC2 instances.
 	NameInstanceSG(namespace string, ingressName string) string
+
+	// NameBackendSG generates name for the backend securityGroup shared by LoadBalancers of the cluster.
+	NameBackendSG() string
 }
 
 // TagGenerator provides tag generation functionality for sg package.
@@ -32,6 +41,9 @@ type TagGenerator interface {
 
 	// TagInstanceSG generates tags for managed securityGroup that will be attached to EC2 instances.
 	TagInstanceSG(namespace string, ingressName string) map[string]string
+
+	// TagBackendSG generates tags for the backend securityGroup shared by LoadBalancers of the cluster.
+	TagBackendSG() map[string]string
 }
 
 // NameTagGenerator is combination of NameGenerator and TagGenerator
@@ -0,0 +1,193 @@
+package staticip
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/generator"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tags"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/loadbalancer"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// EndpointServiceController manages the VPC endpoint service that exposes a NetworkLoadBalancer over PrivateLink.
+type EndpointServiceController interface {
+	// Reconcile ensures the endpoint service of NetworkLoadBalancer matches cfg, or is removed if cfg is nil.
+	// The endpoint service is tagged with serviceTags, which are used to look it up.
+	Reconcile(ctx context.Context, nlbArn string, serviceTags map[string]string, cfg *loadbalancer.EndpointServiceConfig) error
+
+	// Delete ensures the endpoint service of NetworkLoadBalancer tagged with serviceTags is removed, existing endpoint connections are rejected.
+	Delete(ctx context.Context, nlbArn string, serviceTags map[string]string) error
+}
+
+func NewEndpointServiceController(cloud aws.CloudAPI, tagsController tags.Controller) EndpointServiceController {
+	return &defaultEndpointServiceController{
+		cloud:          cloud,
+		tagsController: tagsController,
+	}
+}
+
+type defaultEndpointServiceController struct {
+	cloud          aws.CloudAPI
+	tagsController tags.Controller
+}
+
+func (c *defaultEndpointServiceController) Reconcile(ctx context.Context, nlbArn string, serviceTags map[string]string, cfg *loadbalancer.EndpointServiceConfig) error {
+	if cfg == nil {
+		return c.Delete(ctx, nlbArn, serviceTags)
+	}
+	service, err := c.findEndpointService(ctx, nlbArn, serviceTags)
+	if err != nil {
+		return err
+	}
+	if service == nil {
+		resp, err := c.cloud.CreateVpcEndpointServiceConfigurationWithContext(ctx, &ec2.CreateVpcEndpointServiceConfigurationInput{
+			AcceptanceRequired:      aws.Bool(cfg.AcceptanceRequired),
+			NetworkLoadBalancerArns: aws.StringSlice([]string{nlbArn}),
+		})
+		if err != nil {
+			albctx.GetEventf(ctx)(corev1.EventTypeWarning, "ERROR", "failed to create endpoint service for %v due to %v", nlbArn, err)
+			return fmt.Errorf("failed to create endpoint service for %v due to %w", nlbArn, err)
+		}
+		service = resp.ServiceConfiguration
+		if err := c.tagsController.ReconcileEC2WithCurTags(ctx, aws.StringValue(service.ServiceId), serviceTags, nil); err != nil {
+			return fmt.Errorf("failed to tag endpoint service %v due to %w", aws.StringValue(service.ServiceId), err)
+		}
+		albctx.GetLogger(ctx).Infof("endpoint service %v created, service name: %v", aws.StringValue(service.ServiceId), aws.StringValue(service.ServiceName))
+		albctx.GetEventf(ctx)(corev1.EventTypeNormal, "CREATE", "endpoint service %v created, service name: %v", aws.StringValue(service.ServiceId), aws.StringValue(service.ServiceName))
+	} else if aws.BoolValue(service.AcceptanceRequired) != cfg.AcceptanceRequired {
+		albctx.GetLogger(ctx).Infof("modifying endpoint service %v due to AcceptanceRequired change (%v => %v)", aws.StringValue(service.ServiceId), aws.BoolValue(service.AcceptanceRequired), cfg.AcceptanceRequired)
+		if _, err := c.cloud.ModifyVpcEndpointServiceConfigurationWithContext(ctx, &ec2.ModifyVpcEndpointServiceConfigurationInput{
+			ServiceId:          service.ServiceId,
+			AcceptanceRequired: aws.Bool(cfg.AcceptanceRequired),
+		}); err != nil {
+			return fmt.Errorf("failed to modify endpoint service %v due to %w", aws.StringValue(service.ServiceId), err)
+		}
+	}
+	return c.reconcilePermissions(ctx, aws.StringValue(service.ServiceId), cfg.AllowedPrincipals)
+}
+
+func (c *defaultEndpointServiceController) Delete(ctx context.Context, nlbArn string, serviceTags map[string]string) error {
+	service, err := c.findEndpointService(ctx, nlbArn, serviceTags)
+	if err != nil {
+		return err
+	}
+	if service == nil {
+		return nil
+	}
+	serviceID := aws.StringValue(service.ServiceId)
+
+	// endpoint services with active connections cannot be deleted.
+	connections, err := c.cloud.DescribeVpcEndpointConnections(ctx, &ec2.DescribeVpcEndpointConnectionsInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("service-id"),
+				Values: aws.StringSlice([]string{serviceID}),
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to describe connections of endpoint service %v due to %w", serviceID, err)
+	}
+	var endpointIDs []string
+	for _, connection := range connections {
+		switch aws.StringValue(connection.VpcEndpointState) {
+		case ec2.StateAvailable, ec2.StatePending, ec2.StatePendingAcceptance:
+			endpointIDs = append(endpointIDs, aws.StringValue(connection.VpcEndpointId))
+		}
+	}
+	if len(endpointIDs) != 0 {
+		albctx.GetLogger(ctx).Infof("rejecting connections %v to endpoint service %v", endpointIDs, serviceID)
+		if _, err := c.cloud.RejectVpcEndpointConnectionsWithContext(ctx, &ec2.RejectVpcEndpointConnectionsInput{
+			ServiceId:      service.ServiceId,
+			VpcEndpointIds: aws.StringSlice(endpointIDs),
+		}); err != nil {
+			return fmt.Errorf("failed to reject connections to endpoint service %v due to %w", serviceID, err)
+		}
+	}
+
+	albctx.GetLogger(ctx).Infof("deleting endpoint service %v", serviceID)
+	resp, err := c.cloud.DeleteVpcEndpointServiceConfigurationsWithContext(ctx, &ec2.DeleteVpcEndpointServiceConfigurationsInput{
+		ServiceIds: []*string{service.ServiceId},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete endpoint service %v due to %w", serviceID, err)
+	}
+	for _, item := range resp.Unsuccessful {
+		if item.Error != nil {
+			return fmt.Errorf("failed to delete endpoint service %v due to %v", serviceID, aws.StringValue(item.Error.Message))
+		}
+	}
+	albctx.GetEventf(ctx)(corev1.EventTypeNormal, "DELETE", "endpoint service %v deleted", serviceID)
+	return nil
+}
+
+func (c *defaultEndpointServiceController) reconcilePermissions(ctx context.Context, serviceID string, allowedPrincipals []string) error {
+	current, err := c.cloud.DescribeVpcEndpointServicePermissions(ctx, &ec2.DescribeVpcEndpointServicePermissionsInput{
+		ServiceId: aws.String(serviceID),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to describe permissions of endpoint service %v due to %w", serviceID, err)
+	}
+	currentPrincipals := sets.NewString()
+	for _, principal := range current {
+		currentPrincipals.Insert(aws.StringValue(principal.Principal))
+	}
+	desiredPrincipals := sets.NewString(allowedPrincipals...)
+	additions := desiredPrincipals.Difference(currentPrincipals)
+	removals := currentPrincipals.Difference(desiredPrincipals)
+	if additions.Len() == 0 && removals.Len() == 0 {
+		return nil
+	}
+
+	albctx.GetLogger(ctx).Infof("modifying allowed principals of endpoint service %v, adding %v, removing %v", serviceID, additions.List(), removals.List())
+	input := &ec2.ModifyVpcEndpointServicePermissionsInput{
+		ServiceId: aws.String(serviceID),
+	}
+	if additions.Len() != 0 {
+		input.AddAllowedPrincipals = aws.StringSlice(additions.List())
+	}
+	if removals.Len() != 0 {
+		input.RemoveAllowedPrincipals = aws.StringSlice(removals.List())
+	}
+	if _, err := c.cloud.ModifyVpcEndpointServicePermissionsWithContext(ctx, input); err != nil {
+		albctx.GetEventf(ctx)(corev1.EventTypeWarning, "ERROR", "failed to modify allowed principals of endpoint service %v due to %v", serviceID, err)
+		return fmt.Errorf("failed to modify allowed principals of endpoint service %v due to %w", serviceID, err)
+	}
+	albctx.GetEventf(ctx)(corev1.EventTypeNormal, "MODIFY", "allowed principals of endpoint service %v modified", serviceID)
+	return nil
+}
+
+// findEndpointService returns the endpoint service of NetworkLoadBalancer, or nil if it doesn't exist.
+// Only endpoint services tagged with serviceTags are described, instead of every endpoint service in the account.
+func (c *defaultEndpointServiceController) findEndpointService(ctx context.Context, nlbArn string, serviceTags map[string]string) (*ec2.ServiceConfiguration, error) {
+	var filters []*ec2.Filter
+	for _, key := range []string{generator.V2TagKeyClusterID, generator.V2TagKeyStackID, generator.V2TagKeyResourceID} {
+		filters = append(filters, &ec2.Filter{
+			Name:   aws.String("tag:" + key),
+			Values: aws.StringSlice([]string{serviceTags[key]}),
+		})
+	}
+	services, err := c.cloud.DescribeVpcEndpointServiceConfigurations(ctx, &ec2.DescribeVpcEndpointServiceConfigurationsInput{
+		Filters: filters,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe endpoint services due to %w", err)
+	}
+	for _, service := range services {
+		switch aws.StringValue(service.ServiceState) {
+		case ec2.ServiceStateDeleting, ec2.ServiceStateDeleted, ec2.ServiceStateFailed:
+			continue
+		}
+		for _, arn := range service.NetworkLoadBalancerArns {
+			if aws.StringValue(arn) == nlbArn {
+				return service, nil
+			}
+		}
+	}
+	return nil, nil
+}
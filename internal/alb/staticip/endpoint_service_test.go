@@ -0,0 +1,166 @@
+package staticip
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tags"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/loadbalancer"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	nlbArn    = "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/net/nlb/0123456789abcdef"
+	serviceID = "vpce-svc-0123456789abcdef"
+)
+
+var (
+	serviceTags = map[string]string{
+		"ingress.k8s.aws/cluster":  "cluster",
+		"ingress.k8s.aws/stack":    "ns/ingress",
+		"ingress.k8s.aws/resource": resourceIDEndpointService,
+	}
+	describeServicesInput = &ec2.DescribeVpcEndpointServiceConfigurationsInput{
+		Filters: []*ec2.Filter{
+			{Name: aws.String("tag:ingress.k8s.aws/cluster"), Values: aws.StringSlice([]string{"cluster"})},
+			{Name: aws.String("tag:ingress.k8s.aws/stack"), Values: aws.StringSlice([]string{"ns/ingress"})},
+			{Name: aws.String("tag:ingress.k8s.aws/resource"), Values: aws.StringSlice([]string{resourceIDEndpointService})},
+		},
+	}
+)
+
+func TestDefaultEndpointServiceController_Reconcile(t *testing.T) {
+	otherService := &ec2.ServiceConfiguration{
+		ServiceId:               aws.String("vpce-svc-other"),
+		NetworkLoadBalancerArns: aws.StringSlice([]string{"arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/net/other/0123456789abcdef"}),
+		ServiceState:            aws.String(ec2.ServiceStateAvailable),
+	}
+	for _, tc := range []struct {
+		name              string
+		cfg               *loadbalancer.EndpointServiceConfig
+		existingServices  []*ec2.ServiceConfiguration
+		createCall        bool
+		modifyCall        bool
+		currentPrincipals []string
+		permissionsCall   *ec2.ModifyVpcEndpointServicePermissionsInput
+	}{
+		{
+			name:             "create endpoint service",
+			cfg:              &loadbalancer.EndpointServiceConfig{AcceptanceRequired: true, AllowedPrincipals: []string{"arn:aws:iam::111111111111:root"}},
+			existingServices: []*ec2.ServiceConfiguration{otherService},
+			createCall:       true,
+			permissionsCall: &ec2.ModifyVpcEndpointServicePermissionsInput{
+				ServiceId:            aws.String(serviceID),
+				AddAllowedPrincipals: aws.StringSlice([]string{"arn:aws:iam::111111111111:root"}),
+			},
+		},
+		{
+			name: "update acceptance and principals of existing endpoint service",
+			cfg:  &loadbalancer.EndpointServiceConfig{AcceptanceRequired: false, AllowedPrincipals: []string{"arn:aws:iam::111111111111:root"}},
+			existingServices: []*ec2.ServiceConfiguration{
+				otherService,
+				{
+					ServiceId:               aws.String(serviceID),
+					NetworkLoadBalancerArns: aws.StringSlice([]string{nlbArn}),
+					ServiceState:            aws.String(ec2.ServiceStateAvailable),
+					AcceptanceRequired:      aws.Bool(true),
+				},
+			},
+			modifyCall:        true,
+			currentPrincipals: []string{"arn:aws:iam::222222222222:root"},
+			permissionsCall: &ec2.ModifyVpcEndpointServicePermissionsInput{
+				ServiceId:               aws.String(serviceID),
+				AddAllowedPrincipals:    aws.StringSlice([]string{"arn:aws:iam::111111111111:root"}),
+				RemoveAllowedPrincipals: aws.StringSlice([]string{"arn:aws:iam::222222222222:root"}),
+			},
+		},
+		{
+			name: "endpoint service up to date",
+			cfg:  &loadbalancer.EndpointServiceConfig{AcceptanceRequired: true, AllowedPrincipals: []string{"arn:aws:iam::111111111111:root"}},
+			existingServices: []*ec2.ServiceConfiguration{
+				{
+					ServiceId:               aws.String(serviceID),
+					NetworkLoadBalancerArns: aws.StringSlice([]string{nlbArn}),
+					ServiceState:            aws.String(ec2.ServiceStateAvailable),
+					AcceptanceRequired:      aws.Bool(true),
+				},
+			},
+			currentPrincipals: []string{"arn:aws:iam::111111111111:root"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			cloud := &mocks.CloudAPI{}
+			tagsController := &tags.MockController{}
+			cloud.On("DescribeVpcEndpointServiceConfigurations", ctx, describeServicesInput).Return(tc.existingServices, nil)
+			if tc.createCall {
+				cloud.On("CreateVpcEndpointServiceConfigurationWithContext", ctx, &ec2.CreateVpcEndpointServiceConfigurationInput{
+					AcceptanceRequired:      aws.Bool(tc.cfg.AcceptanceRequired),
+					NetworkLoadBalancerArns: aws.StringSlice([]string{nlbArn}),
+				}).Return(&ec2.CreateVpcEndpointServiceConfigurationOutput{
+					ServiceConfiguration: &ec2.ServiceConfiguration{ServiceId: aws.String(serviceID)},
+				}, nil)
+				tagsController.On("ReconcileEC2WithCurTags", ctx, serviceID, serviceTags, map[string]string(nil)).Return(nil)
+			}
+			if tc.modifyCall {
+				cloud.On("ModifyVpcEndpointServiceConfigurationWithContext", ctx, &ec2.ModifyVpcEndpointServiceConfigurationInput{
+					ServiceId:          aws.String(serviceID),
+					AcceptanceRequired: aws.Bool(tc.cfg.AcceptanceRequired),
+				}).Return(&ec2.ModifyVpcEndpointServiceConfigurationOutput{}, nil)
+			}
+			var currentPrincipals []*ec2.AllowedPrincipal
+			for _, principal := range tc.currentPrincipals {
+				currentPrincipals = append(currentPrincipals, &ec2.AllowedPrincipal{Principal: aws.String(principal)})
+			}
+			cloud.On("DescribeVpcEndpointServicePermissions", ctx, &ec2.DescribeVpcEndpointServicePermissionsInput{
+				ServiceId: aws.String(serviceID),
+			}).Return(currentPrincipals, nil)
+			if tc.permissionsCall != nil {
+				cloud.On("ModifyVpcEndpointServicePermissionsWithContext", ctx, tc.permissionsCall).Return(&ec2.ModifyVpcEndpointServicePermissionsOutput{}, nil)
+			}
+
+			controller := NewEndpointServiceController(cloud, tagsController)
+			assert.NoError(t, controller.Reconcile(ctx, nlbArn, serviceTags, tc.cfg))
+			cloud.AssertExpectations(t)
+			tagsController.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDefaultEndpointServiceController_Delete(t *testing.T) {
+	ctx := context.Background()
+	cloud := &mocks.CloudAPI{}
+	cloud.On("DescribeVpcEndpointServiceConfigurations", ctx, describeServicesInput).Return([]*ec2.ServiceConfiguration{
+		{
+			ServiceId:               aws.String("vpce-svc-deleted"),
+			NetworkLoadBalancerArns: aws.StringSlice([]string{nlbArn}),
+			ServiceState:            aws.String(ec2.ServiceStateDeleted),
+		},
+		{
+			ServiceId:               aws.String(serviceID),
+			NetworkLoadBalancerArns: aws.StringSlice([]string{nlbArn}),
+			ServiceState:            aws.String(ec2.ServiceStateAvailable),
+		},
+	}, nil)
+	cloud.On("DescribeVpcEndpointConnections", ctx, &ec2.DescribeVpcEndpointConnectionsInput{
+		Filters: []*ec2.Filter{{Name: aws.String("service-id"), Values: aws.StringSlice([]string{serviceID})}},
+	}).Return([]*ec2.VpcEndpointConnection{
+		{VpcEndpointId: aws.String("vpce-1"), VpcEndpointState: aws.String(ec2.StateAvailable)},
+		{VpcEndpointId: aws.String("vpce-2"), VpcEndpointState: aws.String(ec2.StateRejected)},
+		{VpcEndpointId: aws.String("vpce-3"), VpcEndpointState: aws.String(ec2.StatePendingAcceptance)},
+	}, nil)
+	cloud.On("RejectVpcEndpointConnectionsWithContext", ctx, &ec2.RejectVpcEndpointConnectionsInput{
+		ServiceId:      aws.String(serviceID),
+		VpcEndpointIds: aws.StringSlice([]string{"vpce-1", "vpce-3"}),
+	}).Return(&ec2.RejectVpcEndpointConnectionsOutput{}, nil)
+	cloud.On("DeleteVpcEndpointServiceConfigurationsWithContext", ctx, &ec2.DeleteVpcEndpointServiceConfigurationsInput{
+		ServiceIds: aws.StringSlice([]string{serviceID}),
+	}).Return(&ec2.DeleteVpcEndpointServiceConfigurationsOutput{}, nil)
+
+	controller := NewEndpointServiceController(cloud, &tags.MockController{})
+	assert.NoError(t, controller.Delete(ctx, nlbArn, serviceTags))
+	cloud.AssertExpectations(t)
+}
@@ -0,0 +1,473 @@
+package staticip
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/generator"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/lb"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tags"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/loadbalancer"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/k8s"
+	util "github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/types"
+	corev1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	// nameSuffix is appended to the ingress name to generate the name of the NetworkLoadBalancer.
+	nameSuffix = ".static-ip"
+
+	// targetTypeALB is the targetType of targetGroups that use an ALB as target.
+	targetTypeALB = "alb"
+
+	resourceIDLoadBalancer    = "StaticIPLoadBalancer"
+	resourceIDTargetGroup     = "StaticIPTargetGroup"
+	resourceIDAddress         = "StaticIPAddress"
+	resourceIDEndpointService = "StaticIPEndpointService"
+)
+
+// Controller manages the NetworkLoadBalancer that provides static IPs to ingresses with static-ip enabled.
+//
+// The NetworkLoadBalancer is placed into the subnets of the ALB, with one Elastic IP per subnet.
+// Each listen port of the ALB gets a TCP listener on the NetworkLoadBalancer, which forwards to a targetGroup with the ALB as target.
+// The NetworkLoadBalancer can optionally be exposed over PrivateLink by a VPC endpoint service.
+type Controller interface {
+	// Reconcile ensures the NetworkLoadBalancer exists in front of alb if static-ip is enabled for ingress, or is removed otherwise.
+	// It returns the LoadBalancer that should be published in ingress status.
+	Reconcile(ctx context.Context, ingress *extensions.Ingress, alb *lb.LoadBalancer) (*lb.LoadBalancer, error)
+
+	// Delete ensures the NetworkLoadBalancer of ingress and the Elastic IPs allocated for it are removed.
+	Delete(ctx context.Context, ingressKey types.NamespacedName) error
+}
+
+func NewController(cloud aws.CloudAPI, store store.Storer, nameTagGen lb.NameTagGenerator, tagsController tags.Controller) Controller {
+	endpointServiceController := NewEndpointServiceController(cloud, tagsController)
+
+	return &defaultController{
+		cloud:                     cloud,
+		store:                     store,
+		nameTagGen:                nameTagGen,
+		tagsController:            tagsController,
+		endpointServiceController: endpointServiceController,
+	}
+}
+
+type defaultController struct {
+	cloud                     aws.CloudAPI
+	store                     store.Storer
+	nameTagGen                lb.NameTagGenerator
+	tagsController            tags.Controller
+	endpointServiceController EndpointServiceController
+}
+
+func (c *defaultController) Reconcile(ctx context.Context, ingress *extensions.Ingress, alb *lb.LoadBalancer) (*lb.LoadBalancer, error) {
+	ingressKey := k8s.NamespacedName(ingress)
+	ingressAnnos, err := c.store.GetIngressAnnotations(ingressKey.String())
+	if err != nil {
+		return nil, err
+	}
+	staticIPCfg := ingressAnnos.LoadBalancer.StaticIP
+	if staticIPCfg == nil {
+		exists, err := c.hasResources(ingressKey)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return alb, nil
+		}
+		return alb, c.Delete(ctx, ingressKey)
+	}
+
+	albInstance, err := c.cloud.GetLoadBalancerByArn(ctx, alb.Arn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find LoadBalancer %v due to %w", alb.Arn, err)
+	}
+	if albInstance == nil {
+		return nil, fmt.Errorf("LoadBalancer %v not found", alb.Arn)
+	}
+	subnets := aws.StringValueSlice(util.AvailabilityZones(albInstance.AvailabilityZones).AsSubnets())
+	sort.Strings(subnets)
+
+	allocationIDs, err := c.ensureAddresses(ctx, ingressKey, staticIPCfg, len(subnets))
+	if err != nil {
+		return nil, err
+	}
+	subnetMappings := make([]*elbv2.SubnetMapping, 0, len(subnets))
+	for i, subnet := range subnets {
+		subnetMappings = append(subnetMappings, &elbv2.SubnetMapping{
+			SubnetId:     aws.String(subnet),
+			AllocationId: aws.String(allocationIDs[i]),
+		})
+	}
+
+	nlb, err := c.ensureLBInstance(ctx, ingressKey, subnetMappings)
+	if err != nil {
+		return nil, err
+	}
+	tgArns, err := c.reconcileListeners(ctx, ingressKey, nlb, alb.Arn, ingressAnnos.LoadBalancer.Ports)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.gcTargetGroups(ctx, ingressKey, tgArns); err != nil {
+		return nil, err
+	}
+	if err := c.endpointServiceController.Reconcile(ctx, aws.StringValue(nlb.LoadBalancerArn), c.buildTags(ingressKey, resourceIDEndpointService), staticIPCfg.EndpointService); err != nil {
+		return nil, err
+	}
+	if err := c.releaseAddresses(ctx, ingressKey, allocationIDs); err != nil {
+		return nil, err
+	}
+
+	return &lb.LoadBalancer{
+		Arn:             aws.StringValue(nlb.LoadBalancerArn),
+		DNSName:         aws.StringValue(nlb.DNSName),
+		HostedZoneID:    aws.StringValue(nlb.CanonicalHostedZoneId),
+		State:           lb.StateCode(nlb),
+		TargetGroupArns: tgArns,
+	}, nil
+}
+
+func (c *defaultController) Delete(ctx context.Context, ingressKey types.NamespacedName) error {
+	nlbName := c.nameLB(ingressKey)
+	instance, err := c.cloud.GetLoadBalancerByName(ctx, nlbName)
+	if err != nil {
+		return fmt.Errorf("failed to find existing LoadBalancer due to %w", err)
+	}
+	if instance != nil {
+		if err := c.endpointServiceController.Delete(ctx, aws.StringValue(instance.LoadBalancerArn), c.buildTags(ingressKey, resourceIDEndpointService)); err != nil {
+			return err
+		}
+		albctx.GetLogger(ctx).Infof("deleting LoadBalancer %v", aws.StringValue(instance.LoadBalancerArn))
+		if err := c.cloud.DeleteLoadBalancerByArn(ctx, aws.StringValue(instance.LoadBalancerArn)); err != nil {
+			return err
+		}
+		albctx.GetEventf(ctx)(corev1.EventTypeNormal, "DELETE", "LoadBalancer %v deleted", nlbName)
+	}
+	if err := c.gcTargetGroups(ctx, ingressKey, nil); err != nil {
+		return err
+	}
+	return c.releaseAddresses(ctx, ingressKey, nil)
+}
+
+// hasResources returns whether any NetworkLoadBalancer, targetGroup or Elastic IP was created for ingress,
+// so that ingresses without static-ip don't look them up one by one at each reconcile.
+func (c *defaultController) hasResources(ingressKey types.NamespacedName) (bool, error) {
+	stackTags := c.nameTagGen.TagLB(ingressKey.Namespace, ingressKey.Name)
+	arns, err := c.cloud.GetResourcesByFilters(map[string][]string{
+		generator.V2TagKeyClusterID:  {stackTags[generator.V2TagKeyClusterID]},
+		generator.V2TagKeyStackID:    {stackTags[generator.V2TagKeyStackID]},
+		generator.V2TagKeyResourceID: {resourceIDLoadBalancer, resourceIDTargetGroup, resourceIDAddress},
+	}, aws.ResourceTypeEnumELBLoadBalancer, aws.ResourceTypeEnumELBTargetGroup, aws.ResourceTypeEnumEC2ElasticIP)
+	if err != nil {
+		return false, fmt.Errorf("failed to get static IP resources by tags due to %w", err)
+	}
+	return len(arns) != 0, nil
+}
+
+// ensureAddresses returns the Elastic IPs to use for each subnet, allocating new ones if they are not specified by annotation.
+func (c *defaultController) ensureAddresses(ctx context.Context, ingressKey types.NamespacedName, cfg *loadbalancer.StaticIPConfig, numSubnets int) ([]string, error) {
+	if len(cfg.AllocationIDs) != 0 {
+		if len(cfg.AllocationIDs) != numSubnets {
+			return nil, fmt.Errorf("number of Elastic IP allocation IDs(%d) must match the number of subnets(%d)", len(cfg.AllocationIDs), numSubnets)
+		}
+		return cfg.AllocationIDs, nil
+	}
+
+	addresses, err := c.getOwnedAddresses(ctx, ingressKey)
+	if err != nil {
+		return nil, err
+	}
+	var allocationIDs []string
+	for _, address := range addresses {
+		allocationIDs = append(allocationIDs, aws.StringValue(address.AllocationId))
+	}
+	sort.Strings(allocationIDs)
+
+	addressTags := c.buildTags(ingressKey, resourceIDAddress)
+	for len(allocationIDs) < numSubnets {
+		resp, err := c.cloud.AllocateAddressWithContext(ctx, &ec2.AllocateAddressInput{
+			Domain: aws.String(ec2.DomainTypeVpc),
+		})
+		if err != nil {
+			albctx.GetEventf(ctx)(corev1.EventTypeWarning, "ERROR", "failed to allocate Elastic IP due to %v", err)
+			return nil, fmt.Errorf("failed to allocate Elastic IP due to %w", err)
+		}
+		allocationID := aws.StringValue(resp.AllocationId)
+		if err := c.tagsController.ReconcileEC2WithCurTags(ctx, allocationID, addressTags, nil); err != nil {
+			return nil, fmt.Errorf("failed to tag Elastic IP %v due to %w", allocationID, err)
+		}
+		albctx.GetLogger(ctx).Infof("Elastic IP %v allocated, address: %v", allocationID, aws.StringValue(resp.PublicIp))
+		albctx.GetEventf(ctx)(corev1.EventTypeNormal, "CREATE", "Elastic IP %v allocated, address: %v", allocationID, aws.StringValue(resp.PublicIp))
+		allocationIDs = append(allocationIDs, allocationID)
+	}
+	return allocationIDs[:numSubnets], nil
+}
+
+// releaseAddresses releases the Elastic IPs allocated for ingress, except the ones in inUse.
+func (c *defaultController) releaseAddresses(ctx context.Context, ingressKey types.NamespacedName, inUse []string) error {
+	addresses, err := c.getOwnedAddresses(ctx, ingressKey)
+	if err != nil {
+		return err
+	}
+	inUseSet := sets.NewString(inUse...)
+	for _, address := range addresses {
+		allocationID := aws.StringValue(address.AllocationId)
+		if inUseSet.Has(allocationID) {
+			continue
+		}
+		albctx.GetLogger(ctx).Infof("releasing Elastic IP %v, address: %v", allocationID, aws.StringValue(address.PublicIp))
+		if _, err := c.cloud.ReleaseAddressWithContext(ctx, &ec2.ReleaseAddressInput{
+			AllocationId: address.AllocationId,
+		}); err != nil {
+			return fmt.Errorf("failed to release Elastic IP %v due to %w", allocationID, err)
+		}
+		albctx.GetEventf(ctx)(corev1.EventTypeNormal, "DELETE", "Elastic IP %v released", allocationID)
+	}
+	return nil
+}
+
+func (c *defaultController) getOwnedAddresses(ctx context.Context, ingressKey types.NamespacedName) ([]*ec2.Address, error) {
+	addressTags := c.buildTags(ingressKey, resourceIDAddress)
+	var filters []*ec2.Filter
+	for _, key := range []string{generator.V2TagKeyClusterID, generator.V2TagKeyStackID, generator.V2TagKeyResourceID} {
+		filters = append(filters, &ec2.Filter{
+			Name:   aws.String("tag:" + key),
+			Values: aws.StringSlice([]string{addressTags[key]}),
+		})
+	}
+	resp, err := c.cloud.DescribeAddressesWithContext(ctx, &ec2.DescribeAddressesInput{
+		Filters: filters,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe Elastic IPs due to %w", err)
+	}
+	return resp.Addresses, nil
+}
+
+func (c *defaultController) ensureLBInstance(ctx context.Context, ingressKey types.NamespacedName, subnetMappings []*elbv2.SubnetMapping) (*elbv2.LoadBalancer, error) {
+	nlbName := c.nameLB(ingressKey)
+	nlbTags := c.buildTags(ingressKey, resourceIDLoadBalancer)
+	instance, err := c.cloud.GetLoadBalancerByName(ctx, nlbName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find existing LoadBalancer due to %w", err)
+	}
+	if instance != nil {
+		if subnetMappingsEqual(instance, subnetMappings) {
+			if err := c.tagsController.ReconcileELB(ctx, aws.StringValue(instance.LoadBalancerArn), nlbTags); err != nil {
+				return nil, fmt.Errorf("failed to reconcile tags of %v due to %w", aws.StringValue(instance.LoadBalancerArn), err)
+			}
+			return instance, nil
+		}
+		// subnets and Elastic IPs of a NetworkLoadBalancer cannot be modified.
+		albctx.GetLogger(ctx).Infof("deleting LoadBalancer %v for recreation due to subnet mappings change", aws.StringValue(instance.LoadBalancerArn))
+		if err := c.endpointServiceController.Delete(ctx, aws.StringValue(instance.LoadBalancerArn), c.buildTags(ingressKey, resourceIDEndpointService)); err != nil {
+			return nil, err
+		}
+		if err := c.cloud.DeleteLoadBalancerByArn(ctx, aws.StringValue(instance.LoadBalancerArn)); err != nil {
+			return nil, err
+		}
+	}
+
+	albctx.GetLogger(ctx).Infof("creating LoadBalancer %v", nlbName)
+	resp, err := c.cloud.CreateLoadBalancerWithContext(ctx, &elbv2.CreateLoadBalancerInput{
+		Name:           aws.String(nlbName),
+		Type:           aws.String(elbv2.LoadBalancerTypeEnumNetwork),
+		Scheme:         aws.String(elbv2.LoadBalancerSchemeEnumInternetFacing),
+		SubnetMappings: subnetMappings,
+		Tags:           tags.ConvertToELBV2(nlbTags),
+	})
+	if err != nil {
+		albctx.GetEventf(ctx)(corev1.EventTypeWarning, "ERROR", "failed to create LoadBalancer %v due to %v", nlbName, err)
+		return nil, fmt.Errorf("failed to create LoadBalancer %v due to %w", nlbName, err)
+	}
+	instance = resp.LoadBalancers[0]
+	albctx.GetLogger(ctx).Infof("LoadBalancer %v created, ARN: %v", nlbName, aws.StringValue(instance.LoadBalancerArn))
+	albctx.GetEventf(ctx)(corev1.EventTypeNormal, "CREATE", "LoadBalancer %v created, ARN: %v", nlbName, aws.StringValue(instance.LoadBalancerArn))
+	return instance, nil
+}
+
+// reconcileListeners ensures a TCP listener forwarding to the ALB exists for each listen port of the ALB.
+// It returns the targetGroups used by the listeners.
+func (c *defaultController) reconcileListeners(ctx context.Context, ingressKey types.NamespacedName, nlb *elbv2.LoadBalancer, albArn string, ports []loadbalancer.PortData) ([]string, error) {
+	nlbArn := aws.StringValue(nlb.LoadBalancerArn)
+	current, err := c.cloud.ListListenersByLoadBalancer(ctx, nlbArn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list listeners of %v due to %w", nlbArn, err)
+	}
+	currentByPort := make(map[int64]*elbv2.Listener, len(current))
+	for _, listener := range current {
+		currentByPort[aws.Int64Value(listener.Port)] = listener
+	}
+
+	var tgArns []string
+	for _, port := range ports {
+		tgArn, err := c.ensureTargetGroup(ctx, ingressKey, aws.StringValue(nlb.LoadBalancerName), albArn, port)
+		if err != nil {
+			return nil, err
+		}
+		tgArns = append(tgArns, tgArn)
+
+		defaultActions := []*elbv2.Action{
+			{
+				Type:           aws.String(elbv2.ActionTypeEnumForward),
+				TargetGroupArn: aws.String(tgArn),
+			},
+		}
+		listener, exists := currentByPort[port.Port]
+		delete(currentByPort, port.Port)
+		if !exists {
+			albctx.GetLogger(ctx).Infof("creating listener %v on %v", port.Port, nlbArn)
+			if _, err := c.cloud.CreateListenerWithContext(ctx, &elbv2.CreateListenerInput{
+				LoadBalancerArn: nlb.LoadBalancerArn,
+				Port:            aws.Int64(port.Port),
+				Protocol:        aws.String(elbv2.ProtocolEnumTcp),
+				DefaultActions:  defaultActions,
+			}); err != nil {
+				return nil, fmt.Errorf("failed to create listener %v on %v due to %w", port.Port, nlbArn, err)
+			}
+			continue
+		}
+		if len(listener.DefaultActions) != 1 || aws.StringValue(listener.DefaultActions[0].TargetGroupArn) != tgArn {
+			albctx.GetLogger(ctx).Infof("modifying listener %v", aws.StringValue(listener.ListenerArn))
+			if _, err := c.cloud.ModifyListenerWithContext(ctx, &elbv2.ModifyListenerInput{
+				ListenerArn:    listener.ListenerArn,
+				Protocol:       aws.String(elbv2.ProtocolEnumTcp),
+				DefaultActions: defaultActions,
+			}); err != nil {
+				return nil, fmt.Errorf("failed to modify listener %v due to %w", aws.StringValue(listener.ListenerArn), err)
+			}
+		}
+	}
+
+	for _, listener := range currentByPort {
+		albctx.GetLogger(ctx).Infof("deleting listener %v", aws.StringValue(listener.ListenerArn))
+		if err := c.cloud.DeleteListenersByArn(ctx, aws.StringValue(listener.ListenerArn)); err != nil {
+			return nil, fmt.Errorf("failed to delete listener %v due to %w", aws.StringValue(listener.ListenerArn), err)
+		}
+	}
+	return tgArns, nil
+}
+
+// ensureTargetGroup ensures the targetGroup for port exists, with the ALB registered as target.
+func (c *defaultController) ensureTargetGroup(ctx context.Context, ingressKey types.NamespacedName, nlbName string, albArn string, port loadbalancer.PortData) (string, error) {
+	tgName := nameTG(nlbName, port.Port)
+	instance, err := c.cloud.GetTargetGroupByName(ctx, tgName)
+	if err != nil {
+		return "", fmt.Errorf("failed to find existing targetGroup due to %w", err)
+	}
+	if instance == nil {
+		albctx.GetLogger(ctx).Infof("creating targetGroup %v", tgName)
+		resp, err := c.cloud.CreateTargetGroupWithContext(ctx, &elbv2.CreateTargetGroupInput{
+			Name:                aws.String(tgName),
+			TargetType:          aws.String(targetTypeALB),
+			Protocol:            aws.String(elbv2.ProtocolEnumTcp),
+			Port:                aws.Int64(port.Port),
+			HealthCheckProtocol: aws.String(port.Scheme),
+			HealthCheckPath:     aws.String("/"),
+		})
+		if err != nil {
+			albctx.GetEventf(ctx)(corev1.EventTypeWarning, "ERROR", "failed to create targetGroup %v due to %v", tgName, err)
+			return "", fmt.Errorf("failed to create targetGroup %v due to %w", tgName, err)
+		}
+		instance = resp.TargetGroups[0]
+		albctx.GetEventf(ctx)(corev1.EventTypeNormal, "CREATE", "targetGroup %v created", tgName)
+	}
+	tgArn := aws.StringValue(instance.TargetGroupArn)
+	if err := c.tagsController.ReconcileELB(ctx, tgArn, c.buildTags(ingressKey, resourceIDTargetGroup)); err != nil {
+		return "", fmt.Errorf("failed to reconcile tags of %v due to %w", tgArn, err)
+	}
+
+	resp, err := c.cloud.DescribeTargetHealthWithContext(ctx, &elbv2.DescribeTargetHealthInput{
+		TargetGroupArn: aws.String(tgArn),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to describe target health of %v due to %w", tgArn, err)
+	}
+	for _, desc := range resp.TargetHealthDescriptions {
+		if desc.Target != nil && aws.StringValue(desc.Target.Id) == albArn {
+			return tgArn, nil
+		}
+	}
+	albctx.GetLogger(ctx).Infof("registering LoadBalancer %v to targetGroup %v", albArn, tgArn)
+	if _, err := c.cloud.RegisterTargetsWithContext(ctx, &elbv2.RegisterTargetsInput{
+		TargetGroupArn: aws.String(tgArn),
+		Targets: []*elbv2.TargetDescription{
+			{
+				Id:   aws.String(albArn),
+				Port: aws.Int64(port.Port),
+			},
+		},
+	}); err != nil {
+		return "", fmt.Errorf("failed to register LoadBalancer %v to targetGroup %v due to %w", albArn, tgArn, err)
+	}
+	return tgArn, nil
+}
+
+// gcTargetGroups deletes the targetGroups created for ingress, except the ones in inUse.
+func (c *defaultController) gcTargetGroups(ctx context.Context, ingressKey types.NamespacedName, inUse []string) error {
+	tgTags := c.buildTags(ingressKey, resourceIDTargetGroup)
+	tgArns, err := c.cloud.GetResourcesByFilters(map[string][]string{
+		generator.V2TagKeyClusterID:  {tgTags[generator.V2TagKeyClusterID]},
+		generator.V2TagKeyStackID:    {tgTags[generator.V2TagKeyStackID]},
+		generator.V2TagKeyResourceID: {resourceIDTargetGroup},
+	}, aws.ResourceTypeEnumELBTargetGroup)
+	if err != nil {
+		return fmt.Errorf("failed to get targetGroups by tags due to %w", err)
+	}
+	inUseSet := sets.NewString(inUse...)
+	for _, tgArn := range tgArns {
+		if inUseSet.Has(tgArn) {
+			continue
+		}
+		albctx.GetLogger(ctx).Infof("deleting targetGroup %v", tgArn)
+		if err := c.cloud.DeleteTargetGroupByArn(ctx, tgArn); err != nil {
+			return fmt.Errorf("failed to delete targetGroup %v due to %w", tgArn, err)
+		}
+		albctx.GetEventf(ctx)(corev1.EventTypeNormal, "DELETE", "targetGroup %v deleted", tgArn)
+	}
+	return nil
+}
+
+func (c *defaultController) nameLB(ingressKey types.NamespacedName) string {
+	return c.nameTagGen.NameLB(ingressKey.Namespace, ingressKey.Name+nameSuffix)
+}
+
+func (c *defaultController) buildTags(ingressKey types.NamespacedName, resourceID string) map[string]string {
+	resTags := c.nameTagGen.TagLB(ingressKey.Namespace, ingressKey.Name)
+	resTags[generator.V2TagKeyResourceID] = resourceID
+	return resTags
+}
+
+// nameTG generates the name of the targetGroup for port of NetworkLoadBalancer.
+func nameTG(nlbName string, port int64) string {
+	hasher := md5.New()
+	_, _ = hasher.Write([]byte(nlbName))
+	_, _ = hasher.Write([]byte(strconv.FormatInt(port, 10)))
+	return fmt.Sprintf("%.12s-%.19s", nlbName, hex.EncodeToString(hasher.Sum(nil)))
+}
+
+func subnetMappingsEqual(instance *elbv2.LoadBalancer, subnetMappings []*elbv2.SubnetMapping) bool {
+	current := sets.NewString()
+	for _, az := range instance.AvailabilityZones {
+		for _, address := range az.LoadBalancerAddresses {
+			current.Insert(aws.StringValue(az.SubnetId) + "/" + aws.StringValue(address.AllocationId))
+		}
+	}
+	desired := sets.NewString()
+	for _, mapping := range subnetMappings {
+		desired.Insert(aws.StringValue(mapping.SubnetId) + "/" + aws.StringValue(mapping.AllocationId))
+	}
+	return current.Equal(desired)
+}
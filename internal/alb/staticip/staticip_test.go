@@ -0,0 +1,236 @@
+package staticip
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/generator"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/lb"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tags"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/loadbalancer"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var ingressKey = types.NamespacedName{Namespace: "ns", Name: "ingress"}
+
+func Test_nameTG(t *testing.T) {
+	name80 := nameTG("alb-ns-ingress-1234", 80)
+	name443 := nameTG("alb-ns-ingress-1234", 443)
+	assert.Equal(t, name80, nameTG("alb-ns-ingress-1234", 80))
+	assert.NotEqual(t, name80, name443)
+	assert.True(t, len(name80) <= 32)
+	assert.Equal(t, "alb-ns-ingre", name80[:12])
+}
+
+func Test_subnetMappingsEqual(t *testing.T) {
+	instance := &elbv2.LoadBalancer{
+		AvailabilityZones: []*elbv2.AvailabilityZone{
+			{
+				SubnetId:              aws.String("subnet-1"),
+				LoadBalancerAddresses: []*elbv2.LoadBalancerAddress{{AllocationId: aws.String("eipalloc-1")}},
+			},
+			{
+				SubnetId:              aws.String("subnet-2"),
+				LoadBalancerAddresses: []*elbv2.LoadBalancerAddress{{AllocationId: aws.String("eipalloc-2")}},
+			},
+		},
+	}
+	for _, tc := range []struct {
+		name           string
+		subnetMappings []*elbv2.SubnetMapping
+		expected       bool
+	}{
+		{
+			name: "same mappings in different order",
+			subnetMappings: []*elbv2.SubnetMapping{
+				{SubnetId: aws.String("subnet-2"), AllocationId: aws.String("eipalloc-2")},
+				{SubnetId: aws.String("subnet-1"), AllocationId: aws.String("eipalloc-1")},
+			},
+			expected: true,
+		},
+		{
+			name: "allocation changed",
+			subnetMappings: []*elbv2.SubnetMapping{
+				{SubnetId: aws.String("subnet-1"), AllocationId: aws.String("eipalloc-1")},
+				{SubnetId: aws.String("subnet-2"), AllocationId: aws.String("eipalloc-3")},
+			},
+			expected: false,
+		},
+		{
+			name: "subnet added",
+			subnetMappings: []*elbv2.SubnetMapping{
+				{SubnetId: aws.String("subnet-1"), AllocationId: aws.String("eipalloc-1")},
+				{SubnetId: aws.String("subnet-2"), AllocationId: aws.String("eipalloc-2")},
+				{SubnetId: aws.String("subnet-3"), AllocationId: aws.String("eipalloc-3")},
+			},
+			expected: false,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, subnetMappingsEqual(instance, tc.subnetMappings))
+		})
+	}
+}
+
+func TestDefaultController_ensureAddresses(t *testing.T) {
+	nameTagGen := &generator.NameTagGenerator{
+		NameGenerator: generator.NameGenerator{ALBNamePrefix: "alb"},
+		TagGenerator:  generator.TagGenerator{ClusterName: "cluster"},
+	}
+	addressTags := nameTagGen.TagLB("ns", "ingress")
+	addressTags[generator.V2TagKeyResourceID] = resourceIDAddress
+	describeAddressesInput := &ec2.DescribeAddressesInput{
+		Filters: []*ec2.Filter{
+			{Name: aws.String("tag:ingress.k8s.aws/cluster"), Values: aws.StringSlice([]string{"cluster"})},
+			{Name: aws.String("tag:ingress.k8s.aws/stack"), Values: aws.StringSlice([]string{"ns/ingress"})},
+			{Name: aws.String("tag:ingress.k8s.aws/resource"), Values: aws.StringSlice([]string{resourceIDAddress})},
+		},
+	}
+
+	for _, tc := range []struct {
+		name                  string
+		cfg                   *loadbalancer.StaticIPConfig
+		numSubnets            int
+		ownedAddresses        []*ec2.Address
+		allocatedIDs          []string
+		allocateErr           error
+		expectedAllocationIDs []string
+		expectedErr           string
+	}{
+		{
+			name:                  "allocation IDs specified",
+			cfg:                   &loadbalancer.StaticIPConfig{AllocationIDs: []string{"eipalloc-b", "eipalloc-a"}},
+			numSubnets:            2,
+			expectedAllocationIDs: []string{"eipalloc-b", "eipalloc-a"},
+		},
+		{
+			name:        "allocation IDs mismatch subnets",
+			cfg:         &loadbalancer.StaticIPConfig{AllocationIDs: []string{"eipalloc-a"}},
+			numSubnets:  2,
+			expectedErr: "number of Elastic IP allocation IDs(1) must match the number of subnets(2)",
+		},
+		{
+			name:       "reuse owned addresses and allocate missing ones",
+			cfg:        &loadbalancer.StaticIPConfig{},
+			numSubnets: 3,
+			ownedAddresses: []*ec2.Address{
+				{AllocationId: aws.String("eipalloc-2")},
+				{AllocationId: aws.String("eipalloc-1")},
+			},
+			allocatedIDs:          []string{"eipalloc-3"},
+			expectedAllocationIDs: []string{"eipalloc-1", "eipalloc-2", "eipalloc-3"},
+		},
+		{
+			name:       "more owned addresses than subnets",
+			cfg:        &loadbalancer.StaticIPConfig{},
+			numSubnets: 1,
+			ownedAddresses: []*ec2.Address{
+				{AllocationId: aws.String("eipalloc-2")},
+				{AllocationId: aws.String("eipalloc-1")},
+			},
+			expectedAllocationIDs: []string{"eipalloc-1"},
+		},
+		{
+			name:        "failed to allocate address",
+			cfg:         &loadbalancer.StaticIPConfig{},
+			numSubnets:  2,
+			allocateErr: errors.New("AddressLimitExceeded"),
+			expectedErr: "failed to allocate Elastic IP due to AddressLimitExceeded",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			cloud := &mocks.CloudAPI{}
+			tagsController := &tags.MockController{}
+			if len(tc.cfg.AllocationIDs) == 0 {
+				cloud.On("DescribeAddressesWithContext", ctx, describeAddressesInput).Return(&ec2.DescribeAddressesOutput{Addresses: tc.ownedAddresses}, nil)
+			}
+			for _, allocationID := range tc.allocatedIDs {
+				cloud.On("AllocateAddressWithContext", ctx, &ec2.AllocateAddressInput{Domain: aws.String(ec2.DomainTypeVpc)}).
+					Return(&ec2.AllocateAddressOutput{AllocationId: aws.String(allocationID)}, nil).Once()
+				tagsController.On("ReconcileEC2WithCurTags", ctx, allocationID, addressTags, map[string]string(nil)).Return(nil)
+			}
+			if tc.allocateErr != nil {
+				cloud.On("AllocateAddressWithContext", ctx, &ec2.AllocateAddressInput{Domain: aws.String(ec2.DomainTypeVpc)}).Return(nil, tc.allocateErr)
+			}
+
+			controller := &defaultController{
+				cloud:          cloud,
+				nameTagGen:     nameTagGen,
+				tagsController: tagsController,
+			}
+			allocationIDs, err := controller.ensureAddresses(ctx, ingressKey, tc.cfg, tc.numSubnets)
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedAllocationIDs, allocationIDs)
+			}
+			cloud.AssertExpectations(t)
+			tagsController.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDefaultController_Reconcile_disabled(t *testing.T) {
+	nameTagGen := &generator.NameTagGenerator{
+		NameGenerator: generator.NameGenerator{ALBNamePrefix: "alb"},
+		TagGenerator:  generator.TagGenerator{ClusterName: "cluster"},
+	}
+	stackFilters := map[string][]string{
+		generator.V2TagKeyClusterID:  {"cluster"},
+		generator.V2TagKeyStackID:    {"ns/ingress"},
+		generator.V2TagKeyResourceID: {resourceIDLoadBalancer, resourceIDTargetGroup, resourceIDAddress},
+	}
+	for _, tc := range []struct {
+		name         string
+		existingArns []string
+	}{
+		{
+			name: "nothing to delete",
+		},
+		{
+			name:         "delete Elastic IP left over",
+			existingArns: []string{"arn:aws:ec2:us-west-2:123456789012:elastic-ip/eipalloc-1"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			ingress := &extensions.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "ingress"}}
+			alb := &lb.LoadBalancer{Arn: "albArn"}
+			mockStore := &store.MockStorer{}
+			mockStore.On("GetIngressAnnotations", "ns/ingress").Return(&annotations.Ingress{LoadBalancer: &loadbalancer.Config{}}, nil)
+			cloud := &mocks.CloudAPI{}
+			cloud.On("GetResourcesByFilters", stackFilters, aws.ResourceTypeEnumELBLoadBalancer, aws.ResourceTypeEnumELBTargetGroup, aws.ResourceTypeEnumEC2ElasticIP).Return(tc.existingArns, nil)
+			if len(tc.existingArns) != 0 {
+				cloud.On("GetLoadBalancerByName", ctx, nameTagGen.NameLB("ns", "ingress"+nameSuffix)).Return(nil, nil)
+				cloud.On("GetResourcesByFilters", map[string][]string{
+					generator.V2TagKeyClusterID:  {"cluster"},
+					generator.V2TagKeyStackID:    {"ns/ingress"},
+					generator.V2TagKeyResourceID: {resourceIDTargetGroup},
+				}, aws.ResourceTypeEnumELBTargetGroup).Return(nil, nil)
+				cloud.On("DescribeAddressesWithContext", ctx, mock.Anything).Return(&ec2.DescribeAddressesOutput{
+					Addresses: []*ec2.Address{{AllocationId: aws.String("eipalloc-1")}},
+				}, nil)
+				cloud.On("ReleaseAddressWithContext", ctx, &ec2.ReleaseAddressInput{AllocationId: aws.String("eipalloc-1")}).Return(&ec2.ReleaseAddressOutput{}, nil)
+			}
+
+			c := &defaultController{cloud: cloud, store: mockStore, nameTagGen: nameTagGen}
+			result, err := c.Reconcile(ctx, ingress, alb)
+			assert.NoError(t, err)
+			assert.Equal(t, alb, result)
+			cloud.AssertExpectations(t)
+		})
+	}
+}
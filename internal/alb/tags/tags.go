@@ -2,6 +2,8 @@ package tags
 
 import (
 	"context"
+	"sort"
+	"strings"
 
 	"github.com/aws/aws-sdk-go/service/ec2"
 	api "k8s.io/api/core/v1"
@@ -124,6 +126,19 @@ func changeSets(source, target map[string]string) (map[string]string, map[string
 	return modify, remove
 }
 
+// OwnershipKeys returns the sorted keys of tags set by the controller to track ownership of resources, i.e. those under the
+// `kubernetes.io/` and `ingress.k8s.aws/` prefixes, leaving out default tags configured by users.
+func OwnershipKeys(tags map[string]string) []string {
+	var keys []string
+	for k := range tags {
+		if strings.HasPrefix(k, "kubernetes.io/") || strings.HasPrefix(k, "ingress.k8s.aws/") {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 // ConvertToELBV2 will convert tags to ELBV2 Tags
 func ConvertToELBV2(tags map[string]string) []*elbv2.Tag {
 	output := make([]*elbv2.Tag, 0, len(tags))
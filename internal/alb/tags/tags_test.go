@@ -76,6 +76,18 @@ func Test_ConvertToEC2(t *testing.T) {
 	assert.Equal(t, ConvertToEC2(source), expected)
 }
 
+func Test_OwnershipKeys(t *testing.T) {
+	source := map[string]string{
+		"kubernetes.io/ingress-name":   "ingress",
+		"kubernetes.io/cluster/prod":   "owned",
+		"ingress.k8s.aws/stack":        "namespace/ingress",
+		"team":                         "edge",
+		"kubernetes.io-lookalike/name": "val",
+	}
+	assert.Equal(t, []string{"ingress.k8s.aws/stack", "kubernetes.io/cluster/prod", "kubernetes.io/ingress-name"}, OwnershipKeys(source))
+	assert.Nil(t, OwnershipKeys(map[string]string{"team": "edge"}))
+}
+
 type DescribeELBV2TagsWithContextCall struct {
 	Output *elbv2.DescribeTagsOutput
 	Err    error
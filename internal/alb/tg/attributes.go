@@ -3,29 +3,35 @@ package tg
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strconv"
 
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/log"
+	util "github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/types"
 	api "k8s.io/api/core/v1"
 )
 
 const (
-	DeregistrationDelayTimeoutSecondsKey = "deregistration_delay.timeout_seconds"
-	SlowStartDurationSecondsKey          = "slow_start.duration_seconds"
-	StickinessEnabledKey                 = "stickiness.enabled"
-	StickinessTypeKey                    = "stickiness.type"
-	StickinessLbCookieDurationSecondsKey = "stickiness.lb_cookie.duration_seconds"
-	LoadBalancingAlgorithmTypeKey        = "load_balancing.algorithm.type"
-
-	DeregistrationDelayTimeoutSeconds = 300
-	SlowStartDurationSeconds          = 0
-	StickinessEnabled                 = false
-	StickinessType                    = "lb_cookie"
-	StickinessLbCookieDurationSeconds = 86400
-	LoadBalancingAlgorithmType        = "round_robin"
+	DeregistrationDelayTimeoutSecondsKey  = "deregistration_delay.timeout_seconds"
+	SlowStartDurationSecondsKey           = "slow_start.duration_seconds"
+	StickinessEnabledKey                  = "stickiness.enabled"
+	StickinessTypeKey                     = "stickiness.type"
+	StickinessLbCookieDurationSecondsKey  = "stickiness.lb_cookie.duration_seconds"
+	StickinessAppCookieNameKey            = "stickiness.app_cookie.cookie_name"
+	StickinessAppCookieDurationSecondsKey = "stickiness.app_cookie.duration_seconds"
+	LoadBalancingAlgorithmTypeKey         = "load_balancing.algorithm.type"
+
+	DeregistrationDelayTimeoutSeconds  = 300
+	SlowStartDurationSeconds           = 0
+	StickinessEnabled                  = false
+	StickinessType                     = "lb_cookie"
+	StickinessLbCookieDurationSeconds  = 86400
+	StickinessAppCookieName            = ""
+	StickinessAppCookieDurationSeconds = 86400
+	LoadBalancingAlgorithmType         = "round_robin"
 )
 
 // Attributes represents the desired state of attributes for a target group.
@@ -47,8 +53,8 @@ type Attributes struct {
 	// The value is true or false. The default is false.
 	StickinessEnabled bool
 
-	// StickinessType: stickiness.type - The type of sticky sessions. The possible value is
-	// lb_cookie.
+	// StickinessType: stickiness.type - The type of sticky sessions. The possible values are
+	// lb_cookie and app_cookie.
 	StickinessType string
 
 	// StickinessLbCookieDurationSeconds: stickiness.lb_cookie.duration_seconds - The time period, in seconds,
@@ -58,22 +64,36 @@ type Attributes struct {
 	// default value is 1 day (86400 seconds).
 	StickinessLbCookieDurationSeconds int64
 
+	// StickinessAppCookieName: stickiness.app_cookie.cookie_name - The name of the application-based cookie,
+	// which is required when stickiness.type is app_cookie. The names AWSALB, AWSALBAPP and AWSALBTG are
+	// reserved for the load balancer.
+	StickinessAppCookieName string
+
+	// StickinessAppCookieDurationSeconds: stickiness.app_cookie.duration_seconds - The time period, in seconds,
+	// during which requests from a client should be routed to the same target with an application-based cookie.
+	// The range is 1 second to 1 week (604800 seconds). The default value is 1 day (86400 seconds).
+	StickinessAppCookieDurationSeconds int64
+
 	// LoadBalancingAlgorithmType: load_balancing.algorithm.type - The load balancing algorithm determines
 	// how the load balancer selects targets when routing requests. The value is round_robin or
 	// least_outstanding_requests. The default is round_robin.
 	LoadBalancingAlgorithmType string
+
+	// Extra holds attributes without a field above, which are passed through to ELBV2 unvalidated.
+	Extra map[string]string
 }
 
 func NewAttributes(attrs []*elbv2.TargetGroupAttribute) (a *Attributes, err error) {
 	a = &Attributes{
-		DeregistrationDelayTimeoutSeconds: DeregistrationDelayTimeoutSeconds,
-		SlowStartDurationSeconds:          SlowStartDurationSeconds,
-		StickinessEnabled:                 StickinessEnabled,
-		StickinessType:                    StickinessType,
-		StickinessLbCookieDurationSeconds: StickinessLbCookieDurationSeconds,
-		LoadBalancingAlgorithmType:        LoadBalancingAlgorithmType,
-	}
-	var e error
+		DeregistrationDelayTimeoutSeconds:  DeregistrationDelayTimeoutSeconds,
+		SlowStartDurationSeconds:           SlowStartDurationSeconds,
+		StickinessEnabled:                  StickinessEnabled,
+		StickinessType:                     StickinessType,
+		StickinessLbCookieDurationSeconds:  StickinessLbCookieDurationSeconds,
+		StickinessAppCookieName:            StickinessAppCookieName,
+		StickinessAppCookieDurationSeconds: StickinessAppCookieDurationSeconds,
+		LoadBalancingAlgorithmType:         LoadBalancingAlgorithmType,
+	}
 	for _, attr := range attrs {
 		attrValue := aws.StringValue(attr.Value)
 		switch attrKey := aws.StringValue(attr.Key); attrKey {
@@ -100,7 +120,7 @@ func NewAttributes(attrs []*elbv2.TargetGroupAttribute) (a *Attributes, err erro
 			}
 		case StickinessTypeKey:
 			a.StickinessType = attrValue
-			if attrValue != "lb_cookie" {
+			if attrValue != "lb_cookie" && attrValue != "app_cookie" {
 				return a, fmt.Errorf("invalid target group attribute value %s=%s", attrKey, attrValue)
 			}
 		case StickinessLbCookieDurationSecondsKey:
@@ -111,16 +131,40 @@ func NewAttributes(attrs []*elbv2.TargetGroupAttribute) (a *Attributes, err erro
 			if a.StickinessLbCookieDurationSeconds < 1 || a.StickinessLbCookieDurationSeconds > 604800 {
 				return a, fmt.Errorf("%s must be within 1-604800 seconds, not %v", attrKey, attrValue)
 			}
+		case StickinessAppCookieNameKey:
+			a.StickinessAppCookieName = attrValue
+			for _, reserved := range []string{"AWSALB", "AWSALBAPP", "AWSALBTG"} {
+				if attrValue == reserved {
+					return a, fmt.Errorf("%s must not be %v, which is reserved for the load balancer", attrKey, attrValue)
+				}
+			}
+		case StickinessAppCookieDurationSecondsKey:
+			a.StickinessAppCookieDurationSeconds, err = strconv.ParseInt(attrValue, 10, 64)
+			if err != nil {
+				return a, fmt.Errorf("invalid target group attribute value %s=%s", attrKey, attrValue)
+			}
+			if a.StickinessAppCookieDurationSeconds < 1 || a.StickinessAppCookieDurationSeconds > 604800 {
+				return a, fmt.Errorf("%s must be within 1-604800 seconds, not %v", attrKey, attrValue)
+			}
 		case LoadBalancingAlgorithmTypeKey:
 			a.LoadBalancingAlgorithmType = attrValue
 			if attrValue != "round_robin" && attrValue != "least_outstanding_requests" {
 				return a, fmt.Errorf("invalid target group attribute value %s=%s", attrKey, attrValue)
 			}
 		default:
-			e = NewInvalidAttribute(attrKey)
+			if a.Extra == nil {
+				a.Extra = make(map[string]string)
+			}
+			a.Extra[attrKey] = attrValue
 		}
 	}
-	return a, e
+	if a.LoadBalancingAlgorithmType == "least_outstanding_requests" && a.SlowStartDurationSeconds != 0 {
+		return a, fmt.Errorf("%s can't be least_outstanding_requests when %s is set", LoadBalancingAlgorithmTypeKey, SlowStartDurationSecondsKey)
+	}
+	if a.StickinessEnabled && a.StickinessType == "app_cookie" && a.StickinessAppCookieName == "" {
+		return a, fmt.Errorf("%s is required when %s is app_cookie", StickinessAppCookieNameKey, StickinessTypeKey)
+	}
+	return a, nil
 }
 
 // AttributesController provides functionality to manage Attributes
@@ -143,17 +187,17 @@ type attributesController struct {
 func (c *attributesController) Reconcile(ctx context.Context, tgArn string, attributes []*elbv2.TargetGroupAttribute) error {
 	desired, err := NewAttributes(attributes)
 	if err != nil {
-		return fmt.Errorf("invalid attributes due to %v", err)
+		return fmt.Errorf("invalid attributes due to %w", err)
 	}
 	raw, err := c.cloud.DescribeTargetGroupAttributesWithContext(ctx, &elbv2.DescribeTargetGroupAttributesInput{
 		TargetGroupArn: aws.String(tgArn),
 	})
 	if err != nil {
-		return fmt.Errorf("failed to retrieve attributes from TargetGroup in AWS: %s", err.Error())
+		return fmt.Errorf("failed to retrieve attributes from TargetGroup in AWS: %w", err)
 	}
 	current, err := NewAttributes(raw.Attributes)
 	if err != nil && !IsInvalidAttribute(err) {
-		return fmt.Errorf("failed parsing attributes: %v", err)
+		return fmt.Errorf("failed parsing attributes: %w", err)
 	}
 
 	changeSet := attributesChangeSet(current, desired)
@@ -167,10 +211,24 @@ func (c *attributesController) Reconcile(ctx context.Context, tgArn string, attr
 			albctx.GetEventf(ctx)(api.EventTypeWarning, "ERROR", "%s attributes modification failed: %s", tgArn, err.Error())
 			return err
 		}
+		albctx.GetEventf(ctx)(api.EventTypeNormal, "MODIFY", "target group %v modified: %v", tgArn, attributesDiff(raw.Attributes, changeSet))
 	}
 	return nil
 }
 
+// attributesDiff pairs each attribute of changeSet with its value in current, the attributes of the target group in AWS.
+func attributesDiff(current []*elbv2.TargetGroupAttribute, changeSet []*elbv2.TargetGroupAttribute) util.Diff {
+	currentValues := make(map[string]string, len(current))
+	for _, attr := range current {
+		currentValues[aws.StringValue(attr.Key)] = aws.StringValue(attr.Value)
+	}
+	var diff util.Diff
+	for _, attr := range changeSet {
+		diff.Add(aws.StringValue(attr.Key), currentValues[aws.StringValue(attr.Key)], aws.StringValue(attr.Value))
+	}
+	return diff
+}
+
 // attributesChangeSet returns a list of elbv2.TargetGroupAttribute required to change a into b
 func attributesChangeSet(a, b *Attributes) (changeSet []*elbv2.TargetGroupAttribute) {
 	if a.DeregistrationDelayTimeoutSeconds != b.DeregistrationDelayTimeoutSeconds {
@@ -193,10 +251,29 @@ func attributesChangeSet(a, b *Attributes) (changeSet []*elbv2.TargetGroupAttrib
 		changeSet = append(changeSet, tgAttribute(StickinessLbCookieDurationSecondsKey, fmt.Sprintf("%v", b.StickinessLbCookieDurationSeconds)))
 	}
 
+	if a.StickinessAppCookieName != b.StickinessAppCookieName {
+		changeSet = append(changeSet, tgAttribute(StickinessAppCookieNameKey, b.StickinessAppCookieName))
+	}
+
+	if a.StickinessAppCookieDurationSeconds != b.StickinessAppCookieDurationSeconds {
+		changeSet = append(changeSet, tgAttribute(StickinessAppCookieDurationSecondsKey, fmt.Sprintf("%v", b.StickinessAppCookieDurationSeconds)))
+	}
+
 	if a.LoadBalancingAlgorithmType != b.LoadBalancingAlgorithmType {
 		changeSet = append(changeSet, tgAttribute(LoadBalancingAlgorithmTypeKey, b.LoadBalancingAlgorithmType))
 	}
 
+	extraKeys := make([]string, 0, len(b.Extra))
+	for k := range b.Extra {
+		extraKeys = append(extraKeys, k)
+	}
+	sort.Strings(extraKeys)
+	for _, k := range extraKeys {
+		if v, ok := a.Extra[k]; !ok || v != b.Extra[k] {
+			changeSet = append(changeSet, tgAttribute(k, b.Extra[k]))
+		}
+	}
+
 	return
 }
 
@@ -151,9 +151,60 @@ func Test_NewAttributes(t *testing.T) {
 		},
 
 		{
-			name:       "Invalid attribute",
+			name: "LoadBalancingAlgorithmTypeKey is least_outstanding_requests with slow start",
+			ok:   false,
+			attributes: []*elbv2.TargetGroupAttribute{
+				tgAttribute(LoadBalancingAlgorithmTypeKey, "least_outstanding_requests"),
+				tgAttribute(SlowStartDurationSecondsKey, "30"),
+			},
+		},
+
+		{
+			name: "StickinessTypeKey is app_cookie",
+			ok:   true,
+			attributes: []*elbv2.TargetGroupAttribute{
+				tgAttribute(StickinessEnabledKey, "true"),
+				tgAttribute(StickinessTypeKey, "app_cookie"),
+				tgAttribute(StickinessAppCookieNameKey, "session"),
+				tgAttribute(StickinessAppCookieDurationSecondsKey, "3600"),
+			},
+			output: func() *Attributes {
+				a := MustNewAttributes(nil)
+				a.StickinessEnabled = true
+				a.StickinessType = "app_cookie"
+				a.StickinessAppCookieName = "session"
+				a.StickinessAppCookieDurationSeconds = 3600
+				return a
+			}(),
+		},
+		{
+			name: "StickinessAppCookieNameKey is absent for app_cookie",
+			ok:   false,
+			attributes: []*elbv2.TargetGroupAttribute{
+				tgAttribute(StickinessEnabledKey, "true"),
+				tgAttribute(StickinessTypeKey, "app_cookie"),
+			},
+		},
+		{
+			name:       "StickinessAppCookieNameKey is reserved",
+			ok:         false,
+			attributes: []*elbv2.TargetGroupAttribute{tgAttribute(StickinessAppCookieNameKey, "AWSALB")},
+		},
+		{
+			name:       "StickinessAppCookieDurationSecondsKey is > 604800",
 			ok:         false,
-			attributes: []*elbv2.TargetGroupAttribute{tgAttribute("not a real attribute", "error")},
+			attributes: []*elbv2.TargetGroupAttribute{tgAttribute(StickinessAppCookieDurationSecondsKey, "604801")},
+		},
+
+		{
+			name:       "attribute unknown to the controller",
+			ok:         true,
+			attributes: []*elbv2.TargetGroupAttribute{tgAttribute("lambda.multi_value_headers.enabled", "false")},
+			output: func() *Attributes {
+				a := MustNewAttributes(nil)
+				a.Extra = map[string]string{"lambda.multi_value_headers.enabled": "false"}
+				return a
+			}(),
 		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
@@ -314,6 +365,42 @@ func Test_attributesChangeSet(t *testing.T) {
 			b:         MustNewAttributes([]*elbv2.TargetGroupAttribute{tgAttribute(LoadBalancingAlgorithmTypeKey, "least_outstanding_requests")}),
 			changeSet: []*elbv2.TargetGroupAttribute{tgAttribute(LoadBalancingAlgorithmTypeKey, "least_outstanding_requests")},
 		},
+
+		{
+			name:      "StickinessAppCookieNameKey: a!=b",
+			a:         MustNewAttributes(nil),
+			b:         MustNewAttributes([]*elbv2.TargetGroupAttribute{tgAttribute(StickinessAppCookieNameKey, "session")}),
+			changeSet: []*elbv2.TargetGroupAttribute{tgAttribute(StickinessAppCookieNameKey, "session")},
+		},
+		{
+			name:      "StickinessAppCookieDurationSecondsKey: a!=b",
+			a:         MustNewAttributes(nil),
+			b:         MustNewAttributes([]*elbv2.TargetGroupAttribute{tgAttribute(StickinessAppCookieDurationSecondsKey, "3600")}),
+			changeSet: []*elbv2.TargetGroupAttribute{tgAttribute(StickinessAppCookieDurationSecondsKey, "3600")},
+		},
+
+		{
+			name: "Extra: a=b",
+			a:    MustNewAttributes([]*elbv2.TargetGroupAttribute{tgAttribute("lambda.multi_value_headers.enabled", "false")}),
+			b:    MustNewAttributes([]*elbv2.TargetGroupAttribute{tgAttribute("lambda.multi_value_headers.enabled", "false")}),
+		},
+		{
+			name: "Extra: a!=b",
+			a:    MustNewAttributes([]*elbv2.TargetGroupAttribute{tgAttribute("lambda.multi_value_headers.enabled", "false")}),
+			b: MustNewAttributes([]*elbv2.TargetGroupAttribute{
+				tgAttribute("load_balancing.cross_zone.enabled", "true"),
+				tgAttribute("lambda.multi_value_headers.enabled", "true"),
+			}),
+			changeSet: []*elbv2.TargetGroupAttribute{
+				tgAttribute("lambda.multi_value_headers.enabled", "true"),
+				tgAttribute("load_balancing.cross_zone.enabled", "true"),
+			},
+		},
+		{
+			name: "Extra: removed from b",
+			a:    MustNewAttributes([]*elbv2.TargetGroupAttribute{tgAttribute("lambda.multi_value_headers.enabled", "false")}),
+			b:    MustNewAttributes(nil),
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			changeSet := attributesChangeSet(tc.a, tc.b)
@@ -428,7 +515,7 @@ func Test_AttributesReconcile(t *testing.T) {
 			err := controller.Reconcile(context.Background(), "arn", tc.Attributes)
 
 			if tc.ExpectedError != nil {
-				assert.Equal(t, tc.ExpectedError, err)
+				assert.EqualError(t, err, tc.ExpectedError.Error())
 			} else {
 				assert.NoError(t, err)
 			}
@@ -0,0 +1,115 @@
+package tg
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/k8s"
+	extensions "k8s.io/api/extensions/v1beta1"
+)
+
+// lambdaInvokePrincipal is the principal allowed to invoke Lambda functions registered to targetGroups.
+const lambdaInvokePrincipal = "elasticloadbalancing.amazonaws.com"
+
+func (controller *defaultController) ReconcileLambda(ctx context.Context, ingress *extensions.Ingress, functionArn string) (TargetGroup, error) {
+	ingressAnnos, err := controller.store.GetIngressAnnotations(k8s.MetaNamespaceKey(ingress))
+	if err != nil {
+		return TargetGroup{}, fmt.Errorf("failed to load ingressAnnotation due to %w", err)
+	}
+
+	tgName := controller.nameTagGen.NameTG(ingress.Namespace, ingress.Name, functionArn, "", elbv2.TargetTypeEnumLambda, "")
+	tgInstance, err := controller.findExistingTGInstance(ctx, tgName)
+	if err != nil {
+		return TargetGroup{}, fmt.Errorf("failed to find existing targetGroup due to %w", err)
+	}
+	if tgInstance == nil {
+		albctx.GetLogger(ctx).Infof("creating target group %v", tgName)
+		resp, err := controller.cloud.CreateTargetGroupWithContext(ctx, &elbv2.CreateTargetGroupInput{
+			Name:       aws.String(tgName),
+			TargetType: aws.String(elbv2.TargetTypeEnumLambda),
+		})
+		if err != nil {
+			return TargetGroup{}, fmt.Errorf("failed to create targetGroup due to %w", err)
+		}
+		tgInstance = resp.TargetGroups[0]
+		albctx.GetLogger(ctx).Infof("target group %v created: %v", tgName, aws.StringValue(tgInstance.TargetGroupArn))
+	}
+
+	tgArn := aws.StringValue(tgInstance.TargetGroupArn)
+	tgTags := make(map[string]string)
+	for k, v := range controller.nameTagGen.TagTGGroup(ingress.Namespace, ingress.Name) {
+		tgTags[k] = v
+	}
+	for k, v := range controller.nameTagGen.TagTG(ingress.Namespace, ingress.Name, functionArn, "") {
+		tgTags[k] = v
+	}
+	for k, v := range ingressAnnos.Tags.LoadBalancer {
+		tgTags[k] = v
+	}
+	if err := controller.tagsController.ReconcileELB(ctx, tgArn, tgTags); err != nil {
+		return TargetGroup{}, fmt.Errorf("failed to reconcile targetGroup tags due to %w", err)
+	}
+
+	// the function can only be registered once the targetGroup is allowed to invoke it.
+	if err := controller.cloud.AddLambdaInvokePermission(ctx, functionArn, lambdaPermissionStatementID(tgArn), lambdaInvokePrincipal, tgArn); err != nil {
+		return TargetGroup{}, fmt.Errorf("failed to grant targetGroup permission to invoke Lambda function due to %w", err)
+	}
+	targets := []*elbv2.TargetDescription{{Id: aws.String(functionArn)}}
+	current, err := controller.cloud.DescribeTargetHealthWithContext(ctx, &elbv2.DescribeTargetHealthInput{TargetGroupArn: aws.String(tgArn)})
+	if err != nil {
+		return TargetGroup{}, fmt.Errorf("failed to reconcile targetGroup targets due to %w", err)
+	}
+	if len(current.TargetHealthDescriptions) == 0 {
+		albctx.GetLogger(ctx).Infof("Adding targets to %v: %v", tgArn, tdsString(targets))
+		if _, err := controller.cloud.RegisterTargetsWithContext(ctx, &elbv2.RegisterTargetsInput{
+			TargetGroupArn: aws.String(tgArn),
+			Targets:        targets,
+		}); err != nil {
+			return TargetGroup{}, fmt.Errorf("failed to reconcile targetGroup targets due to %w", err)
+		}
+	}
+
+	return TargetGroup{
+		Arn:        tgArn,
+		TargetType: elbv2.TargetTypeEnumLambda,
+		Targets:    targets,
+	}, nil
+}
+
+// releaseLambdaPermissions removes the permissions of targetGroup of type lambda to invoke Lambda functions registered to it.
+func releaseLambdaPermissions(ctx context.Context, cloud aws.CloudAPI, tgArn string) error {
+	resp, err := cloud.DescribeTargetHealthWithContext(ctx, &elbv2.DescribeTargetHealthInput{TargetGroupArn: aws.String(tgArn)})
+	if err != nil {
+		return err
+	}
+	for _, thd := range resp.TargetHealthDescriptions {
+		functionArn := aws.StringValue(thd.Target.Id)
+		if parsed, err := arn.Parse(functionArn); err != nil || parsed.Service != "lambda" {
+			continue
+		}
+		albctx.GetLogger(ctx).Infof("removing permission of target group %v to invoke %v", tgArn, functionArn)
+		if err := cloud.RemoveLambdaInvokePermission(ctx, functionArn, lambdaPermissionStatementID(tgArn)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lambdaPermissionStatementID returns the ID of the statement allowing targetGroup to invoke Lambda functions,
+// which is the name of targetGroup within its ARN `arn:aws:elasticloadbalancing:region:account:targetgroup/name/id`.
+func lambdaPermissionStatementID(tgArn string) string {
+	parsed, err := arn.Parse(tgArn)
+	if err != nil {
+		return tgArn
+	}
+	parts := strings.Split(parsed.Resource, "/")
+	if len(parts) != 3 {
+		return parsed.Resource
+	}
+	return parts[1]
+}
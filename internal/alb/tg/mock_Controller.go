@@ -31,3 +31,24 @@ func (_m *MockController) Reconcile(ctx context.Context, ingress *v1beta1.Ingres
 
 	return r0, r1
 }
+
+// ReconcileLambda provides a mock function with given fields: ctx, ingress, functionArn
+func (_m *MockController) ReconcileLambda(ctx context.Context, ingress *v1beta1.Ingress, functionArn string) (TargetGroup, error) {
+	ret := _m.Called(ctx, ingress, functionArn)
+
+	var r0 TargetGroup
+	if rf, ok := ret.Get(0).(func(context.Context, *v1beta1.Ingress, string) TargetGroup); ok {
+		r0 = rf(ctx, ingress, functionArn)
+	} else {
+		r0 = ret.Get(0).(TargetGroup)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *v1beta1.Ingress, string) error); ok {
+		r1 = rf(ctx, ingress, functionArn)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
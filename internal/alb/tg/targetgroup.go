@@ -11,14 +11,19 @@ import (
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/healthcheck"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/targetgroup"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/backend"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/k8s"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/tracing"
 	util "github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/types"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+	corev1 "k8s.io/api/core/v1"
 	extensions "k8s.io/api/extensions/v1beta1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
 // The port used when creating targetGroup serves as a default value for targets registered without port specified.
@@ -32,11 +37,14 @@ const targetGroupDefaultPort = 1
 type Controller interface {
 	// Reconcile ensures an targetGroup exists for specified backend of ingress.
 	Reconcile(ctx context.Context, ingress *extensions.Ingress, backend extensions.IngressBackend) (TargetGroup, error)
+
+	// ReconcileLambda ensures an targetGroup exists which invokes the Lambda function with functionArn for ingress.
+	ReconcileLambda(ctx context.Context, ingress *extensions.Ingress, functionArn string) (TargetGroup, error)
 }
 
-func NewController(cloud aws.CloudAPI, store store.Storer, nameTagGen NameTagGenerator, tagsController tags.Controller, endpointResolver backend.EndpointResolver) Controller {
+func NewController(cloud aws.CloudAPI, store store.Storer, nameTagGen NameTagGenerator, tagsController tags.Controller, endpointResolver backend.EndpointResolver, nodePortManager backend.NodePortManager, podConditionManager backend.PodConditionManager) Controller {
 	attrsController := NewAttributesController(cloud)
-	targetsController := NewTargetsController(cloud, endpointResolver)
+	targetsController := NewTargetsController(cloud, endpointResolver, podConditionManager)
 	return &defaultController{
 		cloud:             cloud,
 		store:             store,
@@ -44,6 +52,7 @@ func NewController(cloud aws.CloudAPI, store store.Storer, nameTagGen NameTagGen
 		tagsController:    tagsController,
 		attrsController:   attrsController,
 		targetsController: targetsController,
+		nodePortManager:   nodePortManager,
 	}
 }
 
@@ -57,61 +66,82 @@ type defaultController struct {
 	tagsController    tags.Controller
 	attrsController   AttributesController
 	targetsController TargetsController
+	nodePortManager   backend.NodePortManager
+}
+
+func (controller *defaultController) Reconcile(ctx context.Context, ingress *extensions.Ingress, ingressBackend extensions.IngressBackend) (TargetGroup, error) {
+	ctx, span := tracing.Start(ctx, "TargetGroup.Reconcile",
+		attribute.String("k8s.service", ingressBackend.ServiceName),
+		attribute.String("k8s.service.port", ingressBackend.ServicePort.String()),
+	)
+	targetGroup, err := controller.reconcile(ctx, ingress, ingressBackend)
+	tracing.End(span, err)
+	return targetGroup, err
 }
 
-func (controller *defaultController) Reconcile(ctx context.Context, ingress *extensions.Ingress, backend extensions.IngressBackend) (TargetGroup, error) {
+func (controller *defaultController) reconcile(ctx context.Context, ingress *extensions.Ingress, ingressBackend extensions.IngressBackend) (TargetGroup, error) {
 	ingressAnnos, err := controller.store.GetIngressAnnotations(k8s.MetaNamespaceKey(ingress))
 	if err != nil {
-		return TargetGroup{}, fmt.Errorf("failed to load ingressAnnotation due to %v", err)
+		return TargetGroup{}, fmt.Errorf("failed to load ingressAnnotation due to %w", err)
 	}
-	serviceKey := types.NamespacedName{Namespace: ingress.Namespace, Name: backend.ServiceName}
+	serviceKey := backend.ServiceKey(ingress, ingressBackend)
 	serviceAnnos, err := controller.store.GetServiceAnnotations(serviceKey.String(), ingressAnnos)
 	if err != nil {
-		return TargetGroup{}, fmt.Errorf("failed to load serviceAnnotation due to %v", err)
+		return TargetGroup{}, fmt.Errorf("failed to load serviceAnnotation due to %w", err)
 	}
 
 	protocol := aws.StringValue(serviceAnnos.TargetGroup.BackendProtocol)
+	// the protocol version can't be modified, targetGroups of other versions than the default are named apart.
+	if protocolVersion := aws.StringValue(serviceAnnos.TargetGroup.ProtocolVersion); protocolVersion != targetgroup.DefaultProtocolVersion {
+		protocol += protocolVersion
+	}
 	targetType := aws.StringValue(serviceAnnos.TargetGroup.TargetType)
+	if targetType == elbv2.TargetTypeEnumInstance {
+		if err := controller.nodePortManager.Reconcile(ctx, serviceKey.Namespace, serviceKey.Name, aws.BoolValue(serviceAnnos.TargetGroup.ManageNodePort)); err != nil {
+			return TargetGroup{}, fmt.Errorf("failed to reconcile NodePort service due to %w", err)
+		}
+	}
 
-	healthCheckPort, err := controller.resolveServiceHealthCheckPort(ingress.Namespace, backend.ServiceName, intstr.Parse(*serviceAnnos.HealthCheck.Port), targetType)
+	healthCheckPort, err := controller.resolveServiceHealthCheckPort(serviceKey.Namespace, serviceKey.Name, intstr.Parse(*serviceAnnos.HealthCheck.Port), targetType)
 
 	if err != nil {
-		return TargetGroup{}, fmt.Errorf("failed to resolve healthcheck port due to %v", err)
+		return TargetGroup{}, fmt.Errorf("failed to resolve healthcheck port due to %w", err)
 	}
 
-	tgName := controller.nameTagGen.NameTG(ingress.Namespace, ingress.Name, backend.ServiceName, backend.ServicePort.String(), targetType, protocol)
+	tgName := controller.nameTagGen.NameTG(ingress.Namespace, ingress.Name, serviceID(ingress, serviceKey), ingressBackend.ServicePort.String(), targetType, protocol)
 	tgInstance, err := controller.findExistingTGInstance(ctx, tgName)
 	if err != nil {
-		return TargetGroup{}, fmt.Errorf("failed to find existing targetGroup due to %v", err)
+		return TargetGroup{}, fmt.Errorf("failed to find existing targetGroup due to %w", err)
 	}
 	if tgInstance == nil {
 		if tgInstance, err = controller.newTGInstance(ctx, tgName, serviceAnnos, healthCheckPort); err != nil {
-			return TargetGroup{}, fmt.Errorf("failed to create targetGroup due to %v", err)
+			return TargetGroup{}, fmt.Errorf("failed to create targetGroup due to %w", err)
 		}
 	} else {
 		if tgInstance, err = controller.reconcileTGInstance(ctx, tgInstance, serviceAnnos, healthCheckPort); err != nil {
-			return TargetGroup{}, fmt.Errorf("failed to modify targetGroup due to %v", err)
+			return TargetGroup{}, fmt.Errorf("failed to modify targetGroup due to %w", err)
 		}
 	}
 
 	tgArn := aws.StringValue(tgInstance.TargetGroupArn)
-	tgTags := controller.buildTags(ingress, backend, ingressAnnos)
+	tgTags := controller.buildTags(ingress, ingressBackend, ingressAnnos)
 	if err := controller.tagsController.ReconcileELB(ctx, tgArn, tgTags); err != nil {
-		return TargetGroup{}, fmt.Errorf("failed to reconcile targetGroup tags due to %v", err)
+		return TargetGroup{}, fmt.Errorf("failed to reconcile targetGroup tags due to %w", err)
 	}
 	if err := controller.attrsController.Reconcile(ctx, tgArn, serviceAnnos.TargetGroup.Attributes); err != nil {
-		return TargetGroup{}, fmt.Errorf("failed to reconcile targetGroup attributes due to %v", err)
+		return TargetGroup{}, fmt.Errorf("failed to reconcile targetGroup attributes due to %w", err)
 	}
-	tgTargets := NewTargets(targetType, ingress, &backend)
+	tgTargets := NewTargets(targetType, ingress, &ingressBackend)
 	tgTargets.TgArn = tgArn
 	if err = controller.targetsController.Reconcile(ctx, tgTargets); err != nil {
-		return TargetGroup{}, fmt.Errorf("failed to reconcile targetGroup targets due to %v", err)
+		return TargetGroup{}, fmt.Errorf("failed to reconcile targetGroup targets due to %w", err)
 	}
 
 	return TargetGroup{
-		Arn:        tgArn,
-		TargetType: targetType,
-		Targets:    tgTargets.Targets,
+		Arn:             tgArn,
+		TargetType:      targetType,
+		Targets:         tgTargets.Targets,
+		HealthCheckPort: healthCheckPort,
 	}, nil
 }
 
@@ -126,7 +156,8 @@ func (controller *defaultController) newTGInstance(ctx context.Context, name str
 		HealthCheckTimeoutSeconds:  serviceAnnos.HealthCheck.TimeoutSeconds,
 		TargetType:                 serviceAnnos.TargetGroup.TargetType,
 		Protocol:                   serviceAnnos.TargetGroup.BackendProtocol,
-		Matcher:                    &elbv2.Matcher{HttpCode: serviceAnnos.TargetGroup.SuccessCodes},
+		ProtocolVersion:            serviceAnnos.TargetGroup.ProtocolVersion,
+		Matcher:                    matcher(serviceAnnos.TargetGroup),
 		HealthyThresholdCount:      serviceAnnos.TargetGroup.HealthyThresholdCount,
 		UnhealthyThresholdCount:    serviceAnnos.TargetGroup.UnhealthyThresholdCount,
 		Port:                       aws.Int64(targetGroupDefaultPort),
@@ -140,8 +171,8 @@ func (controller *defaultController) newTGInstance(ctx context.Context, name str
 }
 
 func (controller *defaultController) reconcileTGInstance(ctx context.Context, instance *elbv2.TargetGroup, serviceAnnos *annotations.Service, healthCheckPort string) (*elbv2.TargetGroup, error) {
-	if controller.TGInstanceNeedsModification(ctx, instance, serviceAnnos) {
-		albctx.GetLogger(ctx).Infof("modify target group %v", aws.StringValue(instance.TargetGroupArn))
+	if diff := tgInstanceDiff(instance, serviceAnnos, healthCheckPort); !diff.Empty() {
+		albctx.GetLogger(ctx).Infof("modify target group %v, changes: %v", aws.StringValue(instance.TargetGroupArn), diff)
 
 		output, err := controller.cloud.ModifyTargetGroupWithContext(ctx, &elbv2.ModifyTargetGroupInput{
 			TargetGroupArn:             instance.TargetGroupArn,
@@ -150,18 +181,32 @@ func (controller *defaultController) reconcileTGInstance(ctx context.Context, in
 			HealthCheckPort:            aws.String(healthCheckPort),
 			HealthCheckProtocol:        serviceAnnos.HealthCheck.Protocol,
 			HealthCheckTimeoutSeconds:  serviceAnnos.HealthCheck.TimeoutSeconds,
-			Matcher:                    &elbv2.Matcher{HttpCode: serviceAnnos.TargetGroup.SuccessCodes},
+			Matcher:                    matcher(serviceAnnos.TargetGroup),
 			HealthyThresholdCount:      serviceAnnos.TargetGroup.HealthyThresholdCount,
 			UnhealthyThresholdCount:    serviceAnnos.TargetGroup.UnhealthyThresholdCount,
 		})
 		if err != nil {
 			return instance, err
 		}
+		albctx.GetEventf(ctx)(corev1.EventTypeNormal, "MODIFY", "target group %v modified: %v", aws.StringValue(instance.TargetGroupName), diff)
+		// the output is empty in dry-run.
+		if len(output.TargetGroups) == 0 {
+			return instance, nil
+		}
 		return output.TargetGroups[0], err
 	}
 	return instance, nil
 }
 
+// serviceID identifies the service with serviceKey in names and tags of targetGroups for ingress.
+// Services in other namespaces than ingress are qualified by their namespace.
+func serviceID(ingress *extensions.Ingress, serviceKey types.NamespacedName) string {
+	if serviceKey.Namespace == ingress.Namespace {
+		return serviceKey.Name
+	}
+	return serviceKey.String()
+}
+
 // resolveServiceHealthCheckPort checks if the service-port annotation is a string. If so, it tries to look up a port with the same name
 // on the service and use that port's NodePort as the health check port.
 func (controller *defaultController) resolveServiceHealthCheckPort(namespace string, serviceName string, servicePortAnnotation intstr.IntOrString, targetType string) (string, error) {
@@ -185,6 +230,12 @@ func (controller *defaultController) resolveServiceHealthCheckPort(namespace str
 		return servicePort, errors.Wrap(err, "failed to resolve healthcheck service name")
 	}
 
+	if targetType == elbv2.TargetTypeEnumInstance {
+		if service, err = backend.ResolveNodePortService(controller.store, service); err != nil {
+			return servicePort, errors.Wrap(err, "failed to resolve healthcheck service name")
+		}
+	}
+
 	resolvedServicePort, err := k8s.LookupServicePort(service, servicePortAnnotation)
 	if err != nil {
 		return servicePort, errors.Wrap(err, "failed to resolve healthcheck port for service")
@@ -195,45 +246,82 @@ func (controller *defaultController) resolveServiceHealthCheckPort(namespace str
 		}
 		return strconv.Itoa(int(resolvedServicePort.NodePort)), nil
 	}
+	if resolvedServicePort.TargetPort.Type == intstr.String {
+		return controller.resolveNamedTargetPort(serviceKey, resolvedServicePort)
+	}
 	return resolvedServicePort.TargetPort.String(), nil
 
 }
 
-func (controller *defaultController) TGInstanceNeedsModification(ctx context.Context, instance *elbv2.TargetGroup, serviceAnnos *annotations.Service) bool {
-	needsChange := false
-	if !util.DeepEqual(instance.HealthCheckPath, serviceAnnos.HealthCheck.Path) {
-		needsChange = true
-	}
-	if !util.DeepEqual(instance.HealthCheckPort, serviceAnnos.HealthCheck.Port) {
-		needsChange = true
-	}
-	if !util.DeepEqual(instance.HealthCheckProtocol, serviceAnnos.HealthCheck.Protocol) {
-		needsChange = true
+// resolveNamedTargetPort resolves the container port named by the targetPort of servicePort from the endpoints of service,
+// which must be the same port on every pod, since targetGroups support a single health check port.
+func (controller *defaultController) resolveNamedTargetPort(serviceKey string, servicePort *corev1.ServicePort) (string, error) {
+	eps, err := controller.store.GetServiceEndpoints(serviceKey)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to resolve healthcheck port for service")
 	}
-	if !util.DeepEqual(instance.HealthCheckIntervalSeconds, serviceAnnos.HealthCheck.IntervalSeconds) {
-		needsChange = true
+	ports := sets.NewString()
+	for _, epSubset := range eps.Subsets {
+		for _, epPort := range epSubset.Ports {
+			if servicePort.Name != "" && servicePort.Name != epPort.Name {
+				continue
+			}
+			ports.Insert(strconv.Itoa(int(epPort.Port)))
+		}
 	}
-	if !util.DeepEqual(instance.HealthCheckTimeoutSeconds, serviceAnnos.HealthCheck.TimeoutSeconds) {
-		needsChange = true
+	switch ports.Len() {
+	case 0:
+		return "", fmt.Errorf("failed to resolve targetPort %v of service %s, no endpoints are ready", servicePort.TargetPort.String(), serviceKey)
+	case 1:
+		return ports.List()[0], nil
+	default:
+		return "", fmt.Errorf("targetPort %v of service %s resolves to different ports %v among pods", servicePort.TargetPort.String(), serviceKey, ports.List())
 	}
-	if !util.DeepEqual(instance.Matcher.HttpCode, serviceAnnos.TargetGroup.SuccessCodes) {
-		needsChange = true
+}
+
+// TGInstanceNeedsModification returns whether the health check of targetGroup instance drifted from the annotations,
+// the health check port is compared with healthCheckPort resolved from the annotation, such as NodePort of a named port.
+func (controller *defaultController) TGInstanceNeedsModification(ctx context.Context, instance *elbv2.TargetGroup, serviceAnnos *annotations.Service, healthCheckPort string) bool {
+	return !tgInstanceDiff(instance, serviceAnnos, healthCheckPort).Empty()
+}
+
+// tgInstanceDiff returns the health check fields of targetGroup instance that need modification to match the annotations.
+func tgInstanceDiff(instance *elbv2.TargetGroup, serviceAnnos *annotations.Service, healthCheckPort string) util.Diff {
+	var diff util.Diff
+	diff.Compare("HealthCheckPath", instance.HealthCheckPath, serviceAnnos.HealthCheck.Path)
+	if aws.StringValue(instance.HealthCheckPort) != healthCheckPort {
+		diff.Add("HealthCheckPort", instance.HealthCheckPort, healthCheckPort)
 	}
-	if !util.DeepEqual(instance.HealthyThresholdCount, serviceAnnos.TargetGroup.HealthyThresholdCount) {
-		needsChange = true
+	diff.Compare("HealthCheckProtocol", instance.HealthCheckProtocol, serviceAnnos.HealthCheck.Protocol)
+	diff.Compare("HealthCheckIntervalSeconds", instance.HealthCheckIntervalSeconds, serviceAnnos.HealthCheck.IntervalSeconds)
+	diff.Compare("HealthCheckTimeoutSeconds", instance.HealthCheckTimeoutSeconds, serviceAnnos.HealthCheck.TimeoutSeconds)
+	switch {
+	case instance.Matcher == nil:
+		diff.Add("SuccessCodes", nil, serviceAnnos.TargetGroup.SuccessCodes)
+	case aws.StringValue(serviceAnnos.TargetGroup.ProtocolVersion) == targetgroup.ProtocolVersionGRPC:
+		diff.Compare("SuccessCodes", instance.Matcher.GrpcCode, serviceAnnos.TargetGroup.SuccessCodes)
+	default:
+		diff.Compare("SuccessCodes", instance.Matcher.HttpCode, serviceAnnos.TargetGroup.SuccessCodes)
 	}
-	if !util.DeepEqual(instance.UnhealthyThresholdCount, serviceAnnos.TargetGroup.UnhealthyThresholdCount) {
-		needsChange = true
+	diff.Compare("HealthyThresholdCount", instance.HealthyThresholdCount, serviceAnnos.TargetGroup.HealthyThresholdCount)
+	diff.Compare("UnhealthyThresholdCount", instance.UnhealthyThresholdCount, serviceAnnos.TargetGroup.UnhealthyThresholdCount)
+	return diff
+}
+
+// matcher returns the health check matcher of the success codes, which are gRPC codes for GRPC targetGroups and HTTP codes otherwise.
+func matcher(tgAnnos *targetgroup.Config) *elbv2.Matcher {
+	if aws.StringValue(tgAnnos.ProtocolVersion) == targetgroup.ProtocolVersionGRPC {
+		return &elbv2.Matcher{GrpcCode: tgAnnos.SuccessCodes}
 	}
-	return needsChange
+	return &elbv2.Matcher{HttpCode: tgAnnos.SuccessCodes}
 }
 
-func (controller *defaultController) buildTags(ingress *extensions.Ingress, backend extensions.IngressBackend, ingressAnnos *annotations.Ingress) map[string]string {
+func (controller *defaultController) buildTags(ingress *extensions.Ingress, ingressBackend extensions.IngressBackend, ingressAnnos *annotations.Ingress) map[string]string {
 	tgTags := make(map[string]string)
 	for k, v := range controller.nameTagGen.TagTGGroup(ingress.Namespace, ingress.Name) {
 		tgTags[k] = v
 	}
-	for k, v := range controller.nameTagGen.TagTG(ingress.Namespace, ingress.Name, backend.ServiceName, backend.ServicePort.String()) {
+	for k, v := range controller.nameTagGen.TagTG(ingress.Namespace, ingress.Name, serviceID(ingress, backend.ServiceKey(ingress, ingressBackend)), ingressBackend.ServicePort.String()) {
 		tgTags[k] = v
 	}
 	for k, v := range ingressAnnos.Tags.LoadBalancer {
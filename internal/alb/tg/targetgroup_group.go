@@ -3,6 +3,7 @@ package tg
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -14,6 +15,7 @@ import (
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/backend"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/k8s"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/utils"
 	extensions "k8s.io/api/extensions/v1beta1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -37,13 +39,16 @@ func NewGroupController(
 	store store.Storer,
 	nameTagGen NameTagGenerator,
 	tagsController tags.Controller,
-	endpointResolver backend.EndpointResolver) GroupController {
-	tgController := NewController(cloud, store, nameTagGen, tagsController, endpointResolver)
+	endpointResolver backend.EndpointResolver,
+	nodePortManager backend.NodePortManager,
+	podConditionManager backend.PodConditionManager) GroupController {
+	tgController := NewController(cloud, store, nameTagGen, tagsController, endpointResolver, nodePortManager, podConditionManager)
 	return &defaultGroupController{
-		cloud:        cloud,
-		store:        store,
-		nameTagGen:   nameTagGen,
-		tgController: tgController,
+		cloud:          cloud,
+		store:          store,
+		nameTagGen:     nameTagGen,
+		tgController:   tgController,
+		maxConcurrency: store.GetConfig().MaxConcurrentResourceReconciles,
 	}
 }
 
@@ -55,26 +60,74 @@ type defaultGroupController struct {
 	nameTagGen NameTagGenerator
 
 	tgController Controller
+
+	// maxConcurrency is the maximum number of targetGroups reconciled concurrently.
+	maxConcurrency int
 }
 
 func (controller *defaultGroupController) Reconcile(ctx context.Context, ingress *extensions.Ingress) (TargetGroupGroup, error) {
 	tgByBackend := make(map[extensions.IngressBackend]TargetGroup)
 
-	backends, err := controller.extractTargetGroupBackends(ingress)
+	backends, functionArns, err := controller.extractTargetGroupBackends(ingress)
 	if err != nil {
 		return TargetGroupGroup{}, err
 	}
-	for _, backend := range backends {
-		if _, ok := tgByBackend[backend]; ok {
+	// targetGroups of different services are reconciled concurrently, while targetGroups of the same service are
+	// reconciled serially since they share the NodePort service managed for that service.
+	var backendsByService [][]extensions.IngressBackend
+	serviceIndexes := make(map[string]int)
+	visited := make(map[extensions.IngressBackend]bool)
+	for _, ingressBackend := range backends {
+		if visited[ingressBackend] {
 			continue
 		}
-		if tgByBackend[backend], err = controller.tgController.Reconcile(ctx, ingress, backend); err != nil {
+		visited[ingressBackend] = true
+		serviceKey := backend.ServiceKey(ingress, ingressBackend).String()
+		idx, ok := serviceIndexes[serviceKey]
+		if !ok {
+			idx = len(backendsByService)
+			serviceIndexes[serviceKey] = idx
+			backendsByService = append(backendsByService, nil)
+		}
+		backendsByService[idx] = append(backendsByService[idx], ingressBackend)
+	}
+
+	tgsByService := make([][]TargetGroup, len(backendsByService))
+	if err := utils.Parallelize(controller.maxConcurrency, len(backendsByService), func(idx int) error {
+		for _, backend := range backendsByService[idx] {
+			tg, err := controller.tgController.Reconcile(ctx, ingress, backend)
+			if err != nil {
+				return err
+			}
+			tgsByService[idx] = append(tgsByService[idx], tg)
+		}
+		return nil
+	}); err != nil {
+		return TargetGroupGroup{}, err
+	}
+	for idx, serviceBackends := range backendsByService {
+		for backendIdx, backend := range serviceBackends {
+			tgByBackend[backend] = tgsByService[idx][backendIdx]
+		}
+	}
+	var tgByLambda map[string]TargetGroup
+	for _, functionArn := range functionArns {
+		if _, ok := tgByLambda[functionArn]; ok {
+			continue
+		}
+		if tgByLambda == nil {
+			tgByLambda = make(map[string]TargetGroup)
+		}
+		tg, err := controller.tgController.ReconcileLambda(ctx, ingress, functionArn)
+		if err != nil {
 			return TargetGroupGroup{}, err
 		}
+		tgByLambda[functionArn] = tg
 	}
 	selector := controller.nameTagGen.TagTGGroup(ingress.Namespace, ingress.Name)
 	return TargetGroupGroup{
 		TGByBackend: tgByBackend,
+		TGByLambda:  tgByLambda,
 		selector:    selector,
 	}, nil
 }
@@ -89,16 +142,28 @@ func (controller *defaultGroupController) GC(ctx context.Context, tgGroup Target
 	for _, tg := range tgGroup.TGByBackend {
 		usedTgArns.Insert(tg.Arn)
 	}
+	for _, tg := range tgGroup.TGByLambda {
+		usedTgArns.Insert(tg.Arn)
+	}
 	arns, err := controller.cloud.GetResourcesByFilters(tagFilters, aws.ResourceTypeEnumELBTargetGroup)
 	if err != nil {
-		return fmt.Errorf("failed to get targetGroups due to %v", err)
+		return fmt.Errorf("failed to get targetGroups due to %w", err)
 	}
 	currentTgArns := sets.NewString(arns...)
 	unusedTgArns := currentTgArns.Difference(usedTgArns)
 	for arn := range unusedTgArns {
+		tgInstance, err := controller.cloud.GetTargetGroupByArn(ctx, arn)
+		if err != nil {
+			return fmt.Errorf("failed to describe targetGroup due to %w", err)
+		}
+		if tgInstance != nil && aws.StringValue(tgInstance.TargetType) == elbv2.TargetTypeEnumLambda {
+			if err := releaseLambdaPermissions(ctx, controller.cloud, arn); err != nil {
+				return fmt.Errorf("failed to remove Lambda permissions of targetGroup due to %w", err)
+			}
+		}
 		albctx.GetLogger(ctx).Infof("deleting target group %v", arn)
 		if err := controller.cloud.DeleteTargetGroupByArn(ctx, arn); err != nil {
-			return fmt.Errorf("failed to delete targetGroup due to %v", err)
+			return fmt.Errorf("failed to delete targetGroup due to %w", err)
 		}
 	}
 	return nil
@@ -112,7 +177,8 @@ func (controller *defaultGroupController) Delete(ctx context.Context, ingressKey
 	return controller.GC(ctx, tgGroup)
 }
 
-func (controller *defaultGroupController) extractTargetGroupBackends(ingress *extensions.Ingress) ([]extensions.IngressBackend, error) {
+// extractTargetGroupBackends returns the backends of ingress, and the ARNs of Lambda functions forwarded to by actions.
+func (controller *defaultGroupController) extractTargetGroupBackends(ingress *extensions.Ingress) ([]extensions.IngressBackend, []string, error) {
 	var rawIngBackends []extensions.IngressBackend
 	if ingress.Spec.Backend != nil {
 		rawIngBackends = append(rawIngBackends, *ingress.Spec.Backend)
@@ -136,9 +202,10 @@ func (controller *defaultGroupController) extractTargetGroupBackends(ingress *ex
 
 	ingAnnos, err := controller.store.GetIngressAnnotations(k8s.MetaNamespaceKey(ingress))
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
+	var functionArns []string
 	for _, action := range ingAnnos.Action.Actions {
 		if aws.StringValue(action.Type) != elbv2.ActionTypeEnumForward {
 			continue
@@ -151,8 +218,12 @@ func (controller *defaultGroupController) extractTargetGroupBackends(ingress *ex
 					ServicePort: intstr.Parse(aws.StringValue(tgt.ServicePort)),
 				})
 			}
+			if tgt.LambdaFunctionArn != nil {
+				functionArns = append(functionArns, aws.StringValue(tgt.LambdaFunctionArn))
+			}
 		}
 	}
+	sort.Strings(functionArns)
 
-	return ingBackends, nil
+	return ingBackends, functionArns, nil
 }
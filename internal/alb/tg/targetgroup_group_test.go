@@ -608,10 +608,11 @@ func TestDefaultGroupController_Reconcile(t *testing.T) {
 			}
 
 			controller := &defaultGroupController{
-				cloud:        cloud,
-				nameTagGen:   mockNameTagGen,
-				store:        mockStore,
-				tgController: mockTGController,
+				cloud:          cloud,
+				nameTagGen:     mockNameTagGen,
+				store:          mockStore,
+				tgController:   mockTGController,
+				maxConcurrency: 2,
 			}
 
 			tgGroup, err := controller.Reconcile(context.Background(), &tc.Ingress)
@@ -706,6 +707,8 @@ func TestDefaultGroupController_GC(t *testing.T) {
 			cloud.On("GetResourcesByFilters", tc.GetResourcesByFiltersCall.TagFilters, tc.GetResourcesByFiltersCall.ResourceType).Return(tc.GetResourcesByFiltersCall.Arns, tc.GetResourcesByFiltersCall.Err)
 		}
 		for _, call := range tc.DeleteTargetGroupByArnCalls {
+			// targetGroups of other types than lambda never have their targets described.
+			cloud.On("GetTargetGroupByArn", ctx, call.Arn).Return(&elbv2.TargetGroup{TargetType: aws.String(elbv2.TargetTypeEnumInstance)}, nil)
 			cloud.On("DeleteTargetGroupByArn", ctx, call.Arn).Return(call.Err)
 		}
 		mockNameTagGen := &MockNameTagGenerator{}
@@ -718,7 +721,11 @@ func TestDefaultGroupController_GC(t *testing.T) {
 		}
 
 		err := controller.GC(context.Background(), tc.TGGroup)
-		assert.Equal(t, tc.ExpectedError, err)
+		if tc.ExpectedError != nil {
+			assert.EqualError(t, err, tc.ExpectedError.Error())
+		} else {
+			assert.NoError(t, err)
+		}
 		cloud.AssertExpectations(t)
 		mockNameTagGen.AssertExpectations(t)
 		mockTGController.AssertExpectations(t)
@@ -811,6 +818,8 @@ func TestDefaultGroupController_Delete(t *testing.T) {
 			cloud.On("GetResourcesByFilters", tc.GetResourcesByFiltersCall.TagFilters, tc.GetResourcesByFiltersCall.ResourceType).Return(tc.GetResourcesByFiltersCall.Arns, tc.GetResourcesByFiltersCall.Err)
 		}
 		for _, call := range tc.DeleteTargetGroupByArnCalls {
+			// targetGroups of other types than lambda never have their targets described.
+			cloud.On("GetTargetGroupByArn", ctx, call.Arn).Return(&elbv2.TargetGroup{TargetType: aws.String(elbv2.TargetTypeEnumInstance)}, nil)
 			cloud.On("DeleteTargetGroupByArn", ctx, call.Arn).Return(call.Err)
 		}
 		mockNameTagGen := &MockNameTagGenerator{}
@@ -826,9 +835,85 @@ func TestDefaultGroupController_Delete(t *testing.T) {
 		}
 
 		err := controller.Delete(context.Background(), tc.IngressKey)
-		assert.Equal(t, tc.ExpectedError, err)
+		if tc.ExpectedError != nil {
+			assert.EqualError(t, err, tc.ExpectedError.Error())
+		} else {
+			assert.NoError(t, err)
+		}
 		cloud.AssertExpectations(t)
 		mockNameTagGen.AssertExpectations(t)
 		mockTGController.AssertExpectations(t)
 	}
 }
+
+func TestDefaultGroupController_Reconcile_lambda(t *testing.T) {
+	ingress := &extensions.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: "ingress", Namespace: "namespace"},
+	}
+	functionArn := "arn:aws:lambda:us-west-2:123456789012:function:fn"
+	mockStore := &store.MockStorer{}
+	mockStore.On("GetIngressAnnotations", "namespace/ingress").Return(&annotations.Ingress{
+		Action: &action.Config{
+			Actions: map[string]action.Action{
+				"lambda": {
+					Type: aws.String(elbv2.ActionTypeEnumForward),
+					ForwardConfig: &action.ForwardActionConfig{
+						TargetGroups: []*action.TargetGroupTuple{{LambdaFunctionArn: aws.String(functionArn)}},
+					},
+				},
+				"weighted": {
+					Type: aws.String(elbv2.ActionTypeEnumForward),
+					ForwardConfig: &action.ForwardActionConfig{
+						TargetGroups: []*action.TargetGroupTuple{
+							{LambdaFunctionArn: aws.String(functionArn), Weight: aws.Int64(50)},
+							{TargetGroupArn: aws.String("arn2"), Weight: aws.Int64(50)},
+						},
+					},
+				},
+			},
+		},
+	}, nil)
+	mockNameTagGen := &MockNameTagGenerator{}
+	mockNameTagGen.On("TagTGGroup", "namespace", "ingress").Return(map[string]string{"key": "value"})
+	mockTGController := &MockController{}
+	lambdaTG := TargetGroup{Arn: "arn1", TargetType: elbv2.TargetTypeEnumLambda}
+	mockTGController.On("ReconcileLambda", mock.Anything, ingress, functionArn).Return(lambdaTG, nil).Once()
+
+	controller := &defaultGroupController{
+		cloud:          &mocks.CloudAPI{},
+		nameTagGen:     mockNameTagGen,
+		store:          mockStore,
+		tgController:   mockTGController,
+		maxConcurrency: 2,
+	}
+	tgGroup, err := controller.Reconcile(context.Background(), ingress)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]TargetGroup{functionArn: lambdaTG}, tgGroup.TGByLambda)
+	assert.Empty(t, tgGroup.TGByBackend)
+	mockTGController.AssertExpectations(t)
+}
+
+func TestDefaultGroupController_GC_lambda(t *testing.T) {
+	ctx := context.Background()
+	tgArn := "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/tg-name/0123456789abcdef"
+	functionArn := "arn:aws:lambda:us-west-2:123456789012:function:fn"
+	cloud := &mocks.CloudAPI{}
+	cloud.On("GetResourcesByFilters", map[string][]string{"key": {"value"}}, aws.ResourceTypeEnumELBTargetGroup).Return([]string{"arn1", tgArn}, nil)
+	cloud.On("GetTargetGroupByArn", ctx, tgArn).Return(&elbv2.TargetGroup{
+		TargetGroupArn: aws.String(tgArn),
+		TargetType:     aws.String(elbv2.TargetTypeEnumLambda),
+	}, nil)
+	cloud.On("DescribeTargetHealthWithContext", ctx, &elbv2.DescribeTargetHealthInput{TargetGroupArn: aws.String(tgArn)}).Return(&elbv2.DescribeTargetHealthOutput{
+		TargetHealthDescriptions: []*elbv2.TargetHealthDescription{{Target: &elbv2.TargetDescription{Id: aws.String(functionArn)}}},
+	}, nil)
+	cloud.On("RemoveLambdaInvokePermission", ctx, functionArn, "tg-name").Return(nil)
+	cloud.On("DeleteTargetGroupByArn", ctx, tgArn).Return(nil)
+
+	controller := &defaultGroupController{cloud: cloud}
+	err := controller.GC(ctx, TargetGroupGroup{
+		TGByLambda: map[string]TargetGroup{"other-function": {Arn: "arn1"}},
+		selector:   map[string]string{"key": "value"},
+	})
+	assert.NoError(t, err)
+	cloud.AssertExpectations(t)
+}
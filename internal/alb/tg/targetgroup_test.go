@@ -12,6 +12,7 @@ import (
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/healthcheck"
 	annoTags "github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/tags"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/targetgroup"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/backend"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
 	"github.com/stretchr/testify/assert"
@@ -92,6 +93,13 @@ type AttributesReconcileCall struct {
 	Err        error
 }
 
+type NodePortReconcileCall struct {
+	Namespace   string
+	ServiceName string
+	Manage      bool
+	Err         error
+}
+
 type TargetsReconcileCall struct {
 	Targets       *Targets
 	ResultTargets []*elbv2.TargetDescription
@@ -116,6 +124,7 @@ func TestDefaultController_Reconcile(t *testing.T) {
 		GetIngressAnnotationsCall *GetIngressAnnotationsCall
 		GetServiceAnnotationsCall *GetServiceAnnotationsCall
 		GetServiceCall            *GetServiceCall
+		NodePortReconcileCall     *NodePortReconcileCall
 		NameTGCall                *NameTGCall
 		TagTGCall                 *TagTGCall
 		TagTGGroupCall            *TagTGGroupCall
@@ -250,6 +259,7 @@ func TestDefaultController_Reconcile(t *testing.T) {
 						Port: aws.Int64(8888),
 					},
 				},
+				HealthCheckPort: "8080",
 			},
 		},
 		{
@@ -299,6 +309,11 @@ func TestDefaultController_Reconcile(t *testing.T) {
 					},
 				},
 			},
+			NodePortReconcileCall: &NodePortReconcileCall{
+				Namespace:   "namespace",
+				ServiceName: "service",
+				Manage:      false,
+			},
 			NameTGCall: &NameTGCall{
 				Namespace:   "namespace",
 				IngressName: "ingress",
@@ -387,6 +402,7 @@ func TestDefaultController_Reconcile(t *testing.T) {
 						Port: aws.Int64(8888),
 					},
 				},
+				HealthCheckPort: "9090",
 			},
 		},
 		{
@@ -525,6 +541,7 @@ func TestDefaultController_Reconcile(t *testing.T) {
 						Port: aws.Int64(8888),
 					},
 				},
+				HealthCheckPort: "9091",
 			},
 		},
 		{
@@ -632,6 +649,7 @@ func TestDefaultController_Reconcile(t *testing.T) {
 						Port: aws.Int64(8888),
 					},
 				},
+				HealthCheckPort: "8080",
 			},
 		},
 		{
@@ -765,6 +783,7 @@ func TestDefaultController_Reconcile(t *testing.T) {
 						Port: aws.Int64(8888),
 					},
 				},
+				HealthCheckPort: "8080",
 			},
 		},
 		{
@@ -882,6 +901,68 @@ func TestDefaultController_Reconcile(t *testing.T) {
 			},
 			ExpectedError: errors.New("failed to create targetGroup due to CreateTargetGroup"),
 		},
+		{
+			Name:    "Reconcile creates GRPC targetGroup named apart from HTTP1 ones",
+			Ingress: ingress,
+			Backend: ingressBackend,
+			GetIngressAnnotationsCall: &GetIngressAnnotationsCall{
+				Key:          "namespace/ingress",
+				IngressAnnos: &annotations.Ingress{Tags: &annoTags.Config{}},
+			},
+			GetServiceAnnotationsCall: &GetServiceAnnotationsCall{
+				Key:          "namespace/service",
+				IngressAnnos: &annotations.Ingress{Tags: &annoTags.Config{}},
+				ServiceAnnos: &annotations.Service{
+					HealthCheck: &healthcheck.Config{
+						Path:            aws.String("/AWS.ALB/healthcheck"),
+						Port:            aws.String("8080"),
+						Protocol:        aws.String("HTTP"),
+						IntervalSeconds: aws.Int64(10),
+						TimeoutSeconds:  aws.Int64(60),
+					},
+					TargetGroup: &targetgroup.Config{
+						BackendProtocol:         aws.String("HTTP"),
+						ProtocolVersion:         aws.String("GRPC"),
+						TargetType:              aws.String("ip"),
+						SuccessCodes:            aws.String("12"),
+						HealthyThresholdCount:   aws.Int64(8),
+						UnhealthyThresholdCount: aws.Int64(5),
+					},
+				},
+			},
+			NameTGCall: &NameTGCall{
+				Namespace:   "namespace",
+				IngressName: "ingress",
+				ServiceName: "service",
+				ServicePort: "443",
+				TargetType:  "ip",
+				Protocol:    "HTTPGRPC",
+				TGName:      "k8s-grpcTGName",
+			},
+			GetTargetGroupByNameCall: &GetTargetGroupByNameCall{
+				TGName:   "k8s-grpcTGName",
+				Instance: nil,
+			},
+			CreateTargetGroupCall: &CreateTargetGroupCall{
+				Input: &elbv2.CreateTargetGroupInput{
+					Name:                       aws.String("k8s-grpcTGName"),
+					HealthCheckPath:            aws.String("/AWS.ALB/healthcheck"),
+					HealthCheckPort:            aws.String("8080"),
+					HealthCheckProtocol:        aws.String("HTTP"),
+					HealthCheckIntervalSeconds: aws.Int64(10),
+					HealthCheckTimeoutSeconds:  aws.Int64(60),
+					Protocol:                   aws.String("HTTP"),
+					ProtocolVersion:            aws.String("GRPC"),
+					TargetType:                 aws.String("ip"),
+					Matcher:                    &elbv2.Matcher{GrpcCode: aws.String("12")},
+					HealthyThresholdCount:      aws.Int64(8),
+					UnhealthyThresholdCount:    aws.Int64(5),
+					Port:                       aws.Int64(targetGroupDefaultPort),
+				},
+				Err: errors.New("CreateTargetGroup"),
+			},
+			ExpectedError: errors.New("failed to create targetGroup due to CreateTargetGroup"),
+		},
 		{
 			Name:    "Reconcile failed when modify targetGroup instance",
 			Ingress: ingress,
@@ -1242,20 +1323,46 @@ func TestDefaultController_Reconcile(t *testing.T) {
 			},
 			ExpectedError: errors.New("failed to load serviceAnnotation due to GetServiceAnnotations"),
 		},
+		{
+			Name:    "NodePortManager returns error",
+			Ingress: ingress,
+			Backend: ingressBackend,
+			GetIngressAnnotationsCall: &GetIngressAnnotationsCall{
+				Key:          "namespace/ingress",
+				IngressAnnos: &annotations.Ingress{Tags: &annoTags.Config{}},
+			},
+			GetServiceAnnotationsCall: &GetServiceAnnotationsCall{
+				Key:          "namespace/service",
+				IngressAnnos: &annotations.Ingress{Tags: &annoTags.Config{}},
+				ServiceAnnos: &annotations.Service{
+					TargetGroup: &targetgroup.Config{
+						TargetType:     aws.String("instance"),
+						ManageNodePort: aws.Bool(true),
+					},
+				},
+			},
+			NodePortReconcileCall: &NodePortReconcileCall{
+				Namespace:   "namespace",
+				ServiceName: "service",
+				Manage:      true,
+				Err:         errors.New("NodePortReconcileCall"),
+			},
+			ExpectedError: errors.New("failed to reconcile NodePort service due to NodePortReconcileCall"),
+		},
 	} {
 		t.Run(tc.Name, func(t *testing.T) {
-			ctx := context.Background()
+			// Reconcile runs within a tracing span, so AWS calls get a derived context.
 			cloud := &mocks.CloudAPI{}
 			if tc.GetTargetGroupByNameCall != nil {
-				cloud.On("GetTargetGroupByName", ctx, tc.GetTargetGroupByNameCall.TGName).Return(tc.GetTargetGroupByNameCall.Instance, tc.GetTargetGroupByNameCall.Err)
+				cloud.On("GetTargetGroupByName", mock.Anything, tc.GetTargetGroupByNameCall.TGName).Return(tc.GetTargetGroupByNameCall.Instance, tc.GetTargetGroupByNameCall.Err)
 			}
 			if tc.ModifyTargetGroupCall != nil {
-				cloud.On("ModifyTargetGroupWithContext", ctx, tc.ModifyTargetGroupCall.Input).Return(&elbv2.ModifyTargetGroupOutput{
+				cloud.On("ModifyTargetGroupWithContext", mock.Anything, tc.ModifyTargetGroupCall.Input).Return(&elbv2.ModifyTargetGroupOutput{
 					TargetGroups: []*elbv2.TargetGroup{tc.ModifyTargetGroupCall.Instance},
 				}, tc.ModifyTargetGroupCall.Err)
 			}
 			if tc.CreateTargetGroupCall != nil {
-				cloud.On("CreateTargetGroupWithContext", ctx, tc.CreateTargetGroupCall.Input).Return(&elbv2.CreateTargetGroupOutput{
+				cloud.On("CreateTargetGroupWithContext", mock.Anything, tc.CreateTargetGroupCall.Input).Return(&elbv2.CreateTargetGroupOutput{
 					TargetGroups: []*elbv2.TargetGroup{tc.CreateTargetGroupCall.Instance},
 				}, tc.CreateTargetGroupCall.Err)
 			}
@@ -1293,6 +1400,11 @@ func TestDefaultController_Reconcile(t *testing.T) {
 				mockAttrsController.On("Reconcile", mock.Anything, tc.AttributesReconcileCall.TGArn, tc.AttributesReconcileCall.Attributes).Return(tc.AttributesReconcileCall.Err)
 			}
 
+			mockNodePortManager := &backend.MockNodePortManager{}
+			if tc.NodePortReconcileCall != nil {
+				mockNodePortManager.On("Reconcile", mock.Anything, tc.NodePortReconcileCall.Namespace, tc.NodePortReconcileCall.ServiceName, tc.NodePortReconcileCall.Manage).Return(tc.NodePortReconcileCall.Err)
+			}
+
 			mockTargetsController := &MockTargetsController{}
 			if tc.TargetsReconcileCall != nil {
 				mockTargetsController.On("Reconcile", mock.Anything, tc.TargetsReconcileCall.Targets).Return(tc.TargetsReconcileCall.Err).Run(func(args mock.Arguments) {
@@ -1309,17 +1421,150 @@ func TestDefaultController_Reconcile(t *testing.T) {
 				tagsController:    mockTagsController,
 				attrsController:   mockAttrsController,
 				targetsController: mockTargetsController,
+				nodePortManager:   mockNodePortManager,
 			}
 
 			tg, err := controller.Reconcile(context.Background(), &tc.Ingress, tc.Backend)
 			assert.Equal(t, tc.ExpectedTG, tg)
-			assert.Equal(t, tc.ExpectedError, err)
+			if tc.ExpectedError != nil {
+				assert.EqualError(t, err, tc.ExpectedError.Error())
+			} else {
+				assert.NoError(t, err)
+			}
 			cloud.AssertExpectations(t)
 			mockStore.AssertExpectations(t)
 			mockNameTagGen.AssertExpectations(t)
 			mockTagsController.AssertExpectations(t)
 			mockAttrsController.AssertExpectations(t)
 			mockTargetsController.AssertExpectations(t)
+			mockNodePortManager.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_TGInstanceNeedsModification(t *testing.T) {
+	serviceAnnos := &annotations.Service{
+		HealthCheck: &healthcheck.Config{
+			Path:            aws.String("/ping"),
+			Port:            aws.String("my-port"),
+			Protocol:        aws.String("HTTP"),
+			IntervalSeconds: aws.Int64(10),
+			TimeoutSeconds:  aws.Int64(5),
+		},
+		TargetGroup: &targetgroup.Config{
+			SuccessCodes:            aws.String("200"),
+			HealthyThresholdCount:   aws.Int64(2),
+			UnhealthyThresholdCount: aws.Int64(2),
+		},
+	}
+	instance := func(modify func(*elbv2.TargetGroup)) *elbv2.TargetGroup {
+		tgInstance := &elbv2.TargetGroup{
+			HealthCheckPath:            aws.String("/ping"),
+			HealthCheckPort:            aws.String("30080"),
+			HealthCheckProtocol:        aws.String("HTTP"),
+			HealthCheckIntervalSeconds: aws.Int64(10),
+			HealthCheckTimeoutSeconds:  aws.Int64(5),
+			Matcher:                    &elbv2.Matcher{HttpCode: aws.String("200")},
+			HealthyThresholdCount:      aws.Int64(2),
+			UnhealthyThresholdCount:    aws.Int64(2),
+		}
+		if modify != nil {
+			modify(tgInstance)
+		}
+		return tgInstance
+	}
+	for _, tc := range []struct {
+		name     string
+		instance *elbv2.TargetGroup
+		expected bool
+	}{
+		{
+			name:     "health check matches resolved port",
+			instance: instance(nil),
+		},
+		{
+			name:     "health check port drifted",
+			instance: instance(func(tg *elbv2.TargetGroup) { tg.HealthCheckPort = aws.String("traffic-port") }),
+			expected: true,
+		},
+		{
+			name:     "health check path drifted",
+			instance: instance(func(tg *elbv2.TargetGroup) { tg.HealthCheckPath = aws.String("/") }),
+			expected: true,
+		},
+		{
+			name:     "success codes drifted",
+			instance: instance(func(tg *elbv2.TargetGroup) { tg.Matcher = &elbv2.Matcher{HttpCode: aws.String("200-299")} }),
+			expected: true,
+		},
+		{
+			name:     "unhealthy threshold drifted",
+			instance: instance(func(tg *elbv2.TargetGroup) { tg.UnhealthyThresholdCount = aws.Int64(5) }),
+			expected: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			controller := &defaultController{}
+			assert.Equal(t, tc.expected, controller.TGInstanceNeedsModification(context.Background(), tc.instance, serviceAnnos, "30080"))
+		})
+	}
+
+	grpcAnnos := &annotations.Service{
+		HealthCheck: serviceAnnos.HealthCheck,
+		TargetGroup: &targetgroup.Config{
+			ProtocolVersion:         aws.String("GRPC"),
+			SuccessCodes:            aws.String("0-99"),
+			HealthyThresholdCount:   aws.Int64(2),
+			UnhealthyThresholdCount: aws.Int64(2),
+		},
+	}
+	controller := &defaultController{}
+	assert.False(t, controller.TGInstanceNeedsModification(context.Background(), instance(func(tg *elbv2.TargetGroup) { tg.Matcher = &elbv2.Matcher{GrpcCode: aws.String("0-99")} }), grpcAnnos, "30080"))
+	assert.True(t, controller.TGInstanceNeedsModification(context.Background(), instance(func(tg *elbv2.TargetGroup) { tg.Matcher = &elbv2.Matcher{GrpcCode: aws.String("12")} }), grpcAnnos, "30080"))
+}
+
+func Test_resolveNamedTargetPort(t *testing.T) {
+	servicePort := &corev1.ServicePort{Name: "http", TargetPort: intstr.FromString("admin")}
+	subset := func(ports ...int32) corev1.EndpointSubset {
+		result := corev1.EndpointSubset{}
+		for _, port := range ports {
+			result.Ports = append(result.Ports, corev1.EndpointPort{Name: "http", Port: port})
+		}
+		return result
+	}
+	for _, tc := range []struct {
+		name         string
+		subsets      []corev1.EndpointSubset
+		expectedPort string
+		expectedErr  string
+	}{
+		{
+			name:         "same port on every pod",
+			subsets:      []corev1.EndpointSubset{subset(9090), subset(9090)},
+			expectedPort: "9090",
+		},
+		{
+			name:        "different ports among pods",
+			subsets:     []corev1.EndpointSubset{subset(9090), subset(9091)},
+			expectedErr: "targetPort admin of service namespace/service resolves to different ports [9090 9091] among pods",
+		},
+		{
+			name:        "no endpoints",
+			expectedErr: "failed to resolve targetPort admin of service namespace/service, no endpoints are ready",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mockStore := &store.MockStorer{}
+			mockStore.On("GetServiceEndpoints", "namespace/service").Return(&corev1.Endpoints{Subsets: tc.subsets}, nil)
+
+			controller := &defaultController{store: mockStore}
+			port, err := controller.resolveNamedTargetPort("namespace/service", servicePort)
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedPort, port)
+			}
 		})
 	}
 }
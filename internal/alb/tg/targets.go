@@ -10,6 +10,8 @@ import (
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/backend"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
 	api "k8s.io/api/core/v1"
 	extensions "k8s.io/api/extensions/v1beta1"
 )
@@ -48,19 +50,28 @@ type TargetsController interface {
 }
 
 // NewTargetsController constructs a new target group targets controller
-func NewTargetsController(cloud aws.CloudAPI, endpointResolver backend.EndpointResolver) TargetsController {
+func NewTargetsController(cloud aws.CloudAPI, endpointResolver backend.EndpointResolver, podConditionManager backend.PodConditionManager) TargetsController {
 	return &targetsController{
-		cloud:            cloud,
-		endpointResolver: endpointResolver,
+		cloud:               cloud,
+		endpointResolver:    endpointResolver,
+		podConditionManager: podConditionManager,
 	}
 }
 
 type targetsController struct {
-	cloud            aws.CloudAPI
-	endpointResolver backend.EndpointResolver
+	cloud               aws.CloudAPI
+	endpointResolver    backend.EndpointResolver
+	podConditionManager backend.PodConditionManager
 }
 
 func (c *targetsController) Reconcile(ctx context.Context, t *Targets) error {
+	ctx, span := tracing.Start(ctx, "Targets.Reconcile", attribute.String("targetGroup.arn", t.TgArn))
+	err := c.reconcile(ctx, t)
+	tracing.End(span, err)
+	return err
+}
+
+func (c *targetsController) reconcile(ctx context.Context, t *Targets) error {
 	desired, err := c.endpointResolver.Resolve(t.Ingress, t.Backend, t.TargetType)
 	if err != nil {
 		return err
@@ -105,6 +116,11 @@ func (c *targetsController) Reconcile(ctx context.Context, t *Targets) error {
 		}
 		// TODO add Delete events ?
 	}
+	if t.TargetType == elbv2.TargetTypeEnumIp {
+		if err := c.podConditionManager.Reconcile(ctx, t.Ingress, *t.Backend, t.TgArn); err != nil {
+			return fmt.Errorf("failed to reconcile pod target health conditions due to %w", err)
+		}
+	}
 	t.Targets = desired
 	return nil
 }
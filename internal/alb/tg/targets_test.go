@@ -9,9 +9,11 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/backend"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/dummy"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	corev1 "k8s.io/api/core/v1"
 	extensions "k8s.io/api/extensions/v1beta1"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -85,6 +87,10 @@ type RegisterTargetsCall struct {
 	Err   error
 }
 
+type PodConditionReconcileCall struct {
+	Err error
+}
+
 type ResolveCall struct {
 	InputIngress    *extensions.Ingress
 	InputBackend    *extensions.IngressBackend
@@ -97,32 +103,33 @@ func Test_TargetsReconcile(t *testing.T) {
 	tgArn := "arn:"
 	serviceName := "name"
 	servicePort := intstr.FromInt(123)
-	backend := &extensions.IngressBackend{ServiceName: serviceName, ServicePort: servicePort}
+	ingressBackend := &extensions.IngressBackend{ServiceName: serviceName, ServicePort: servicePort}
 
 	for _, tc := range []struct {
-		Name                     string
-		Targets                  *Targets
-		DescribeTargetHealthCall *DescribeTargetHealthCall
-		RegisterTargetsCall      *RegisterTargetsCall
-		DeregisterTargetsCall    *DeregisterTargetsCall
-		GetVpcCall               *GetVpcCall
-		ResolveCall              *ResolveCall
-		ExpectedError            error
+		Name                      string
+		Targets                   *Targets
+		DescribeTargetHealthCall  *DescribeTargetHealthCall
+		RegisterTargetsCall       *RegisterTargetsCall
+		DeregisterTargetsCall     *DeregisterTargetsCall
+		GetVpcCall                *GetVpcCall
+		ResolveCall               *ResolveCall
+		PodConditionReconcileCall *PodConditionReconcileCall
+		ExpectedError             error
 	}{
 		{
 			Name:          "Resolve endpoint throws error",
-			Targets:       &Targets{TgArn: tgArn, Ingress: dummy.NewIngress(), Backend: backend, TargetType: elbv2.TargetTypeEnumInstance},
+			Targets:       &Targets{TgArn: tgArn, Ingress: dummy.NewIngress(), Backend: ingressBackend, TargetType: elbv2.TargetTypeEnumInstance},
 			ExpectedError: errors.New("ERROR STRING"),
 			ResolveCall: &ResolveCall{
 				InputIngress:    dummy.NewIngress(),
-				InputBackend:    backend,
+				InputBackend:    ingressBackend,
 				InputTargetType: elbv2.TargetTypeEnumInstance,
 				Err:             errors.New("ERROR STRING"),
 			},
 		},
 		{
 			Name:    "DescribeTargetHealth throws error",
-			Targets: &Targets{TgArn: tgArn, Ingress: dummy.NewIngress(), Backend: backend, TargetType: elbv2.TargetTypeEnumInstance},
+			Targets: &Targets{TgArn: tgArn, Ingress: dummy.NewIngress(), Backend: ingressBackend, TargetType: elbv2.TargetTypeEnumInstance},
 			DescribeTargetHealthCall: &DescribeTargetHealthCall{
 				TgArn: tgArn,
 				Err:   fmt.Errorf("ERROR STRING"),
@@ -130,13 +137,13 @@ func Test_TargetsReconcile(t *testing.T) {
 			ExpectedError: errors.New("ERROR STRING"),
 			ResolveCall: &ResolveCall{
 				InputIngress:    dummy.NewIngress(),
-				InputBackend:    backend,
+				InputBackend:    ingressBackend,
 				InputTargetType: elbv2.TargetTypeEnumInstance,
 			},
 		},
 		{
 			Name:    "deregister a target",
-			Targets: &Targets{TgArn: tgArn, Ingress: dummy.NewIngress(), Backend: backend, TargetType: elbv2.TargetTypeEnumInstance},
+			Targets: &Targets{TgArn: tgArn, Ingress: dummy.NewIngress(), Backend: ingressBackend, TargetType: elbv2.TargetTypeEnumInstance},
 			DescribeTargetHealthCall: &DescribeTargetHealthCall{
 				TgArn: tgArn,
 				Output: &elbv2.DescribeTargetHealthOutput{TargetHealthDescriptions: []*elbv2.TargetHealthDescription{
@@ -148,13 +155,13 @@ func Test_TargetsReconcile(t *testing.T) {
 			},
 			ResolveCall: &ResolveCall{
 				InputIngress:    dummy.NewIngress(),
-				InputBackend:    backend,
+				InputBackend:    ingressBackend,
 				InputTargetType: elbv2.TargetTypeEnumInstance,
 			},
 		},
 		{
 			Name:    "deregister a target with error",
-			Targets: &Targets{TgArn: tgArn, Ingress: dummy.NewIngress(), Backend: backend, TargetType: elbv2.TargetTypeEnumInstance},
+			Targets: &Targets{TgArn: tgArn, Ingress: dummy.NewIngress(), Backend: ingressBackend, TargetType: elbv2.TargetTypeEnumInstance},
 			DescribeTargetHealthCall: &DescribeTargetHealthCall{
 				TgArn: tgArn,
 				Output: &elbv2.DescribeTargetHealthOutput{TargetHealthDescriptions: []*elbv2.TargetHealthDescription{
@@ -167,14 +174,14 @@ func Test_TargetsReconcile(t *testing.T) {
 			},
 			ResolveCall: &ResolveCall{
 				InputIngress:    dummy.NewIngress(),
-				InputBackend:    backend,
+				InputBackend:    ingressBackend,
 				InputTargetType: elbv2.TargetTypeEnumInstance,
 			},
 			ExpectedError: errors.New("ERROR STRING"),
 		},
 		{
 			Name:    "add a target",
-			Targets: &Targets{TgArn: tgArn, Ingress: dummy.NewIngress(), Backend: backend, TargetType: elbv2.TargetTypeEnumInstance},
+			Targets: &Targets{TgArn: tgArn, Ingress: dummy.NewIngress(), Backend: ingressBackend, TargetType: elbv2.TargetTypeEnumInstance},
 			DescribeTargetHealthCall: &DescribeTargetHealthCall{
 				TgArn: tgArn,
 				Output: &elbv2.DescribeTargetHealthOutput{TargetHealthDescriptions: []*elbv2.TargetHealthDescription{
@@ -186,14 +193,14 @@ func Test_TargetsReconcile(t *testing.T) {
 			},
 			ResolveCall: &ResolveCall{
 				InputIngress:    dummy.NewIngress(),
-				InputBackend:    backend,
+				InputBackend:    ingressBackend,
 				InputTargetType: elbv2.TargetTypeEnumInstance,
 				Output:          []*elbv2.TargetDescription{newTd("id", 123), newTd("id2", 1234)},
 			},
 		},
 		{
 			Name:    "add targets when there the it's been drained",
-			Targets: &Targets{TgArn: tgArn, Ingress: dummy.NewIngress(), Backend: backend, TargetType: elbv2.TargetTypeEnumInstance},
+			Targets: &Targets{TgArn: tgArn, Ingress: dummy.NewIngress(), Backend: ingressBackend, TargetType: elbv2.TargetTypeEnumInstance},
 			DescribeTargetHealthCall: &DescribeTargetHealthCall{
 				TgArn: tgArn,
 				Output: &elbv2.DescribeTargetHealthOutput{TargetHealthDescriptions: []*elbv2.TargetHealthDescription{
@@ -205,14 +212,14 @@ func Test_TargetsReconcile(t *testing.T) {
 			},
 			ResolveCall: &ResolveCall{
 				InputIngress:    dummy.NewIngress(),
-				InputBackend:    backend,
+				InputBackend:    ingressBackend,
 				InputTargetType: elbv2.TargetTypeEnumInstance,
 				Output:          []*elbv2.TargetDescription{newTd("id", 123)},
 			},
 		},
 		{
 			Name:    "add a target with error",
-			Targets: &Targets{TgArn: tgArn, Ingress: dummy.NewIngress(), Backend: backend, TargetType: elbv2.TargetTypeEnumInstance},
+			Targets: &Targets{TgArn: tgArn, Ingress: dummy.NewIngress(), Backend: ingressBackend, TargetType: elbv2.TargetTypeEnumInstance},
 			DescribeTargetHealthCall: &DescribeTargetHealthCall{
 				TgArn: tgArn,
 				Output: &elbv2.DescribeTargetHealthOutput{TargetHealthDescriptions: []*elbv2.TargetHealthDescription{
@@ -225,7 +232,7 @@ func Test_TargetsReconcile(t *testing.T) {
 			},
 			ResolveCall: &ResolveCall{
 				InputIngress:    dummy.NewIngress(),
-				InputBackend:    backend,
+				InputBackend:    ingressBackend,
 				InputTargetType: elbv2.TargetTypeEnumInstance,
 				Output:          []*elbv2.TargetDescription{newTd("id", 123), newTd("id2", 1234)},
 			},
@@ -233,7 +240,7 @@ func Test_TargetsReconcile(t *testing.T) {
 		},
 		{
 			Name:    "add a target with an AZ of ALL",
-			Targets: &Targets{TgArn: tgArn, Ingress: dummy.NewIngress(), Backend: backend, TargetType: elbv2.TargetTypeEnumIp},
+			Targets: &Targets{TgArn: tgArn, Ingress: dummy.NewIngress(), Backend: ingressBackend, TargetType: elbv2.TargetTypeEnumIp},
 			DescribeTargetHealthCall: &DescribeTargetHealthCall{
 				TgArn: tgArn,
 				Output: &elbv2.DescribeTargetHealthOutput{TargetHealthDescriptions: []*elbv2.TargetHealthDescription{
@@ -254,14 +261,43 @@ func Test_TargetsReconcile(t *testing.T) {
 			},
 			ResolveCall: &ResolveCall{
 				InputIngress:    dummy.NewIngress(),
-				InputBackend:    backend,
+				InputBackend:    ingressBackend,
 				InputTargetType: elbv2.TargetTypeEnumIp,
 				Output:          []*elbv2.TargetDescription{newTd("192.168.0.1", 123), newTd("192.168.1.1", 1234)},
 			},
+			PodConditionReconcileCall: &PodConditionReconcileCall{},
+		},
+		{
+			Name:    "pod condition reconcile with error",
+			Targets: &Targets{TgArn: tgArn, Ingress: dummy.NewIngress(), Backend: ingressBackend, TargetType: elbv2.TargetTypeEnumIp},
+			DescribeTargetHealthCall: &DescribeTargetHealthCall{
+				TgArn: tgArn,
+				Output: &elbv2.DescribeTargetHealthOutput{TargetHealthDescriptions: []*elbv2.TargetHealthDescription{
+					{Target: newTd("192.168.0.1", 123), TargetHealth: newTh(elbv2.TargetHealthStateEnumHealthy)},
+				}},
+			},
+			GetVpcCall: &GetVpcCall{
+				Output: &ec2.Vpc{
+					CidrBlockAssociationSet: []*ec2.VpcCidrBlockAssociation{
+						&ec2.VpcCidrBlockAssociation{
+							CidrBlock: aws.String("192.168.0.0/24"),
+						},
+					},
+				},
+			},
+			ResolveCall: &ResolveCall{
+				InputIngress:    dummy.NewIngress(),
+				InputBackend:    ingressBackend,
+				InputTargetType: elbv2.TargetTypeEnumIp,
+				Output:          []*elbv2.TargetDescription{newTd("192.168.0.1", 123)},
+			},
+			PodConditionReconcileCall: &PodConditionReconcileCall{Err: errors.New("ERROR STRING")},
+			ExpectedError:             errors.New("failed to reconcile pod target health conditions due to ERROR STRING"),
 		},
 	} {
 		t.Run(tc.Name, func(t *testing.T) {
-			ctx := context.Background()
+			// Reconcile runs within a tracing span.
+			ctx := mock.Anything
 			endpointResolver := &mocks.EndpointResolver{}
 			if tc.ResolveCall != nil {
 				endpointResolver.On("Resolve", tc.ResolveCall.InputIngress, tc.ResolveCall.InputBackend, tc.ResolveCall.InputTargetType).Return(tc.ResolveCall.Output, tc.ResolveCall.Err)
@@ -281,16 +317,22 @@ func Test_TargetsReconcile(t *testing.T) {
 				cloud.On("GetVpcWithContext", ctx).Return(tc.GetVpcCall.Output, tc.GetVpcCall.Err)
 			}
 
-			controller := NewTargetsController(cloud, endpointResolver)
+			podConditionManager := &backend.MockPodConditionManager{}
+			if tc.PodConditionReconcileCall != nil {
+				podConditionManager.On("Reconcile", ctx, tc.Targets.Ingress, *tc.Targets.Backend, tc.Targets.TgArn).Return(tc.PodConditionReconcileCall.Err)
+			}
+
+			controller := NewTargetsController(cloud, endpointResolver, podConditionManager)
 			err := controller.Reconcile(context.Background(), tc.Targets)
 
 			if tc.ExpectedError != nil {
-				assert.Equal(t, tc.ExpectedError, err)
+				assert.EqualError(t, err, tc.ExpectedError.Error())
 			} else {
 				assert.NoError(t, err)
 			}
 			cloud.AssertExpectations(t)
 			endpointResolver.AssertExpectations(t)
+			podConditionManager.AssertExpectations(t)
 		})
 
 	}
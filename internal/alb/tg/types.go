@@ -10,12 +10,17 @@ type TargetGroup struct {
 	Arn        string
 	TargetType string
 	Targets    []*elbv2.TargetDescription
+
+	// HealthCheckPort is the resolved port of health checks, or traffic-port if they're sent to the port of targets.
+	HealthCheckPort string
 }
 
 // TargetGroupGroup represents an collection of targetGroups for a single ingress in AWS
 type TargetGroupGroup struct {
 	TGByBackend map[extensions.IngressBackend]TargetGroup
-	selector    map[string]string
+	// TGByLambda are the targetGroups invoking Lambda functions, keyed by ARN of the functions.
+	TGByLambda map[string]TargetGroup
+	selector   map[string]string
 }
 
 // NameGenerator provides name generation functionality for tg package.
@@ -0,0 +1,201 @@
+package verify
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/lb"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/loadbalancer"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/utils"
+	extensions "k8s.io/api/extensions/v1beta1"
+)
+
+// requestTimeout is the timeout of each verification request.
+const requestTimeout = 5 * time.Second
+
+// verifyTimeout bounds all verification requests of an ingress, so that unreachable LoadBalancers don't hold up the reconcile worker.
+const verifyTimeout = 10 * time.Second
+
+// maxConcurrentRequests is the maximum number of verification requests issued concurrently for an ingress.
+const maxConcurrentRequests = 10
+
+// maxReportedFailures is the maximum number of failed requests described in a Result.
+const maxReportedFailures = 3
+
+// Verifier issues synthetic requests against the LoadBalancers of an ingress.
+type Verifier interface {
+	// Verify requests each host and path of targets on each listen port, expecting a status code in successCodes.
+	Verify(ctx context.Context, targets []Target, ports []loadbalancer.PortData, successCodes loadbalancer.StatusCodes) Result
+}
+
+// Target is a LoadBalancer, together with the ingress(or shard of the ingress) it serves.
+type Target struct {
+	Ingress      *extensions.Ingress
+	LoadBalancer *lb.LoadBalancer
+}
+
+// Result is the outcome of the verification requests of an ingress.
+type Result struct {
+	Requests int
+	// Failures describe the failed requests.
+	Failures []string
+}
+
+// Succeeded returns whether all requests returned an expected status code.
+func (r Result) Succeeded() bool {
+	return len(r.Failures) == 0
+}
+
+func (r Result) String() string {
+	if r.Succeeded() {
+		return fmt.Sprintf("%d of %d requests succeeded", r.Requests, r.Requests)
+	}
+	failures := r.Failures
+	if len(failures) > maxReportedFailures {
+		failures = append(failures[:maxReportedFailures:maxReportedFailures], fmt.Sprintf("and %d more", len(r.Failures)-maxReportedFailures))
+	}
+	return fmt.Sprintf("%d of %d requests failed: %v", len(r.Failures), r.Requests, strings.Join(failures, "; "))
+}
+
+// NewVerifier constructs a Verifier.
+func NewVerifier() Verifier {
+	return &defaultVerifier{
+		timeout:        requestTimeout,
+		overallTimeout: verifyTimeout,
+	}
+}
+
+type defaultVerifier struct {
+	timeout        time.Duration
+	overallTimeout time.Duration
+}
+
+// Verify issues the requests concurrently, requests still pending after overallTimeout fail.
+func (v *defaultVerifier) Verify(ctx context.Context, targets []Target, ports []loadbalancer.PortData, successCodes loadbalancer.StatusCodes) Result {
+	ctx, cancel := context.WithTimeout(ctx, v.overallTimeout)
+	defer cancel()
+
+	probes := buildProbes(targets, ports)
+	failures := make([]string, len(probes))
+	_ = utils.Parallelize(maxConcurrentRequests, len(probes), func(idx int) error {
+		statusCode, err := v.do(ctx, probes[idx])
+		switch {
+		case err != nil:
+			failures[idx] = fmt.Sprintf("%v failed due to %v", probes[idx], err)
+		case !successCodes.Matches(statusCode):
+			failures[idx] = fmt.Sprintf("%v returned %d", probes[idx], statusCode)
+		}
+		return nil
+	})
+
+	result := Result{Requests: len(probes)}
+	for _, failure := range failures {
+		if failure != "" {
+			result.Failures = append(result.Failures, failure)
+		}
+	}
+	return result
+}
+
+func (v *defaultVerifier) do(ctx context.Context, probe probe) (int, error) {
+	req, err := http.NewRequest(http.MethodGet, probe.url, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Host = probe.host
+	client := &http.Client{
+		Timeout: v.timeout,
+		Transport: &http.Transport{
+			// the host is presented as SNI so the ALB picks its certificate, which isn't verified since only the response status matters.
+			TLSClientConfig:   &tls.Config{ServerName: probe.host, InsecureSkipVerify: true},
+			DisableKeepAlives: true,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return 0, err
+	}
+	_ = resp.Body.Close()
+	return resp.StatusCode, nil
+}
+
+// probe is a single verification request.
+type probe struct {
+	url  string
+	host string
+}
+
+func (p probe) String() string {
+	if p.host == "" {
+		return "GET " + p.url
+	}
+	return fmt.Sprintf("GET %v with host %v", p.url, p.host)
+}
+
+// buildProbes returns a request per listen port for each host and path of targets.
+// Wildcard hosts are skipped, and wildcards in paths are dropped.
+func buildProbes(targets []Target, ports []loadbalancer.PortData) []probe {
+	var probes []probe
+	for _, target := range targets {
+		for _, hostPath := range hostPaths(target.Ingress) {
+			for _, port := range ports {
+				scheme := "http"
+				if port.Scheme == elbv2.ProtocolEnumHttps {
+					scheme = "https"
+				}
+				address := net.JoinHostPort(target.LoadBalancer.DNSName, strconv.FormatInt(port.Port, 10))
+				probes = append(probes, probe{
+					url:  fmt.Sprintf("%v://%v%v", scheme, address, hostPath.path),
+					host: hostPath.host,
+				})
+			}
+		}
+	}
+	return probes
+}
+
+type hostPath struct {
+	host string
+	path string
+}
+
+// hostPaths returns the hosts and paths of ingress rules, or the root path if ingress only has a default backend.
+func hostPaths(ingress *extensions.Ingress) []hostPath {
+	var result []hostPath
+	for _, rule := range ingress.Spec.Rules {
+		if strings.Contains(rule.Host, "*") {
+			continue
+		}
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			result = append(result, hostPath{host: rule.Host, path: probePath(path.Path)})
+		}
+	}
+	if len(result) == 0 && ingress.Spec.Backend != nil {
+		result = append(result, hostPath{path: "/"})
+	}
+	return result
+}
+
+// probePath returns a concrete path matched by the path pattern of an ALB rule.
+func probePath(pattern string) string {
+	if idx := strings.IndexAny(pattern, "*?"); idx >= 0 {
+		pattern = pattern[:idx]
+	}
+	if !strings.HasPrefix(pattern, "/") {
+		pattern = "/" + pattern
+	}
+	return pattern
+}
@@ -0,0 +1,107 @@
+package verify
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/lb"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/loadbalancer"
+	"github.com/stretchr/testify/assert"
+	extensions "k8s.io/api/extensions/v1beta1"
+)
+
+func newIngress(host string, paths ...string) *extensions.Ingress {
+	rule := extensions.IngressRule{
+		Host: host,
+		IngressRuleValue: extensions.IngressRuleValue{
+			HTTP: &extensions.HTTPIngressRuleValue{},
+		},
+	}
+	for _, path := range paths {
+		rule.HTTP.Paths = append(rule.HTTP.Paths, extensions.HTTPIngressPath{Path: path})
+	}
+	return &extensions.Ingress{
+		Spec: extensions.IngressSpec{
+			Rules: []extensions.IngressRule{rule},
+		},
+	}
+}
+
+func Test_buildProbes(t *testing.T) {
+	targets := []Target{
+		{Ingress: newIngress("www.example.com", "/*", "/api/*"), LoadBalancer: &lb.LoadBalancer{DNSName: "lb1"}},
+		{Ingress: newIngress("*.example.com", "/"), LoadBalancer: &lb.LoadBalancer{DNSName: "lb2"}},
+		{
+			Ingress: &extensions.Ingress{
+				Spec: extensions.IngressSpec{Backend: &extensions.IngressBackend{ServiceName: "service"}},
+			},
+			LoadBalancer: &lb.LoadBalancer{DNSName: "lb3"},
+		},
+	}
+	ports := []loadbalancer.PortData{{Port: 80, Scheme: "HTTP"}, {Port: 443, Scheme: "HTTPS"}}
+	assert.Equal(t, []probe{
+		{url: "http://lb1:80/", host: "www.example.com"},
+		{url: "https://lb1:443/", host: "www.example.com"},
+		{url: "http://lb1:80/api/", host: "www.example.com"},
+		{url: "https://lb1:443/api/", host: "www.example.com"},
+		{url: "http://lb3:80/"},
+		{url: "https://lb3:443/"},
+	}, buildProbes(targets, ports))
+}
+
+func TestVerify(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Host == "www.example.com" && r.URL.Path == "/" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	host, rawPort, _ := net.SplitHostPort(server.Listener.Addr().String())
+	port, _ := strconv.ParseInt(rawPort, 10, 64)
+
+	targets := []Target{
+		{Ingress: newIngress("www.example.com", "/*", "/api"), LoadBalancer: &lb.LoadBalancer{DNSName: host}},
+	}
+	result := NewVerifier().Verify(context.Background(), targets, []loadbalancer.PortData{{Port: port, Scheme: "HTTP"}},
+		loadbalancer.StatusCodes{{From: 200, To: 299}})
+	assert.Equal(t, 2, result.Requests)
+	assert.False(t, result.Succeeded())
+	assert.Equal(t, []string{"GET http://" + server.Listener.Addr().String() + "/api with host www.example.com returned 503"}, result.Failures)
+}
+
+func TestVerify_timeout(t *testing.T) {
+	unblock := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-unblock
+	}))
+	defer server.Close()
+	defer close(unblock)
+	host, rawPort, _ := net.SplitHostPort(server.Listener.Addr().String())
+	port, _ := strconv.ParseInt(rawPort, 10, 64)
+
+	targets := []Target{
+		{Ingress: newIngress("www.example.com", "/a", "/b", "/c"), LoadBalancer: &lb.LoadBalancer{DNSName: host}},
+	}
+	v := &defaultVerifier{timeout: time.Minute, overallTimeout: 100 * time.Millisecond}
+	start := time.Now()
+	result := v.Verify(context.Background(), targets, []loadbalancer.PortData{{Port: port, Scheme: "HTTP"}},
+		loadbalancer.StatusCodes{{From: 200, To: 299}})
+	assert.True(t, time.Since(start) < 5*time.Second, "verification took %v", time.Since(start))
+	assert.Equal(t, 3, result.Requests)
+	assert.Len(t, result.Failures, 3)
+}
+
+func TestResult_String(t *testing.T) {
+	assert.Equal(t, "2 of 2 requests succeeded", Result{Requests: 2}.String())
+	assert.Equal(t, "5 of 6 requests failed: f1; f2; f3; and 2 more", Result{
+		Requests: 6,
+		Failures: []string{"f1", "f2", "f3", "f4", "f5"},
+	}.String())
+}
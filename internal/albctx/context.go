@@ -3,9 +3,14 @@ package albctx
 import (
 	"context"
 	"fmt"
+	"sync"
 
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/golang/glog"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/log"
+	"github.com/pkg/errors"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 )
 
 type contextKey string
@@ -13,6 +18,11 @@ type contextKey string
 var (
 	contextKeyEventf = contextKey("Eventf")
 	contextKeyLogger = contextKey("Logger")
+
+	contextKeyAWSFailures  = contextKey("AWSFailures")
+	contextKeyLBLocker     = contextKey("LBLocker")
+	contextKeyCertificates = contextKey("Certificates")
+	contextKeyPlan         = contextKey("Plan")
 )
 
 type Eventf func(string, string, string, ...interface{})
@@ -44,3 +54,219 @@ func GetLogger(ctx context.Context) *log.Logger {
 	}
 	return logger
 }
+
+// AWSFailures records the AWS operations failed during a reconcile.
+type AWSFailures struct {
+	mutex        sync.Mutex
+	failures     []awsFailure
+	throttled    string
+	throttledErr error
+}
+
+type awsFailure struct {
+	operation string
+	err       error
+}
+
+// Record records that operation failed with err.
+func (f *AWSFailures) Record(operation string, err error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.failures = append(f.failures, awsFailure{operation: operation, err: err})
+	f.throttled = ""
+	f.throttledErr = nil
+}
+
+// RecordThrottled records that operation failed with err because AWS throttled it, which isn't a failure of operation itself.
+func (f *AWSFailures) RecordThrottled(operation string, err error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.throttled = operation
+	f.throttledErr = err
+}
+
+// RecordSucceeded records that an operation succeeded, so that operations throttled before it, and retried by the caller, are forgotten.
+func (f *AWSFailures) RecordSucceeded() {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.throttled = ""
+	f.throttledErr = nil
+}
+
+// ThrottledCause returns the throttled operation that err results from, or empty string if err doesn't result from throttling.
+func (f *AWSFailures) ThrottledCause(err error) string {
+	if err == nil {
+		return ""
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if f.throttled == "" {
+		return ""
+	}
+	if awsErr := awsError(err); (awsErr != nil && request.IsErrorThrottle(awsErr)) || resultsFrom(err, f.throttledErr) {
+		return f.throttled
+	}
+	return ""
+}
+
+// FailedOperation returns the latest failed operation that err results from, or empty string if err doesn't result from a failed operation.
+// Operations whose failure is handled, like lookups failing with NotFound errors, are thus ignored.
+func (f *AWSFailures) FailedOperation(err error) string {
+	if err == nil {
+		return ""
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	for i := len(f.failures) - 1; i >= 0; i-- {
+		if resultsFrom(err, f.failures[i].err) {
+			return f.failures[i].operation
+		}
+	}
+	return ""
+}
+
+// resultsFrom returns whether err results from cause, errors are wrapped with their cause by the %w verb or errors.Wrap.
+// Failures of distinct requests are distinct errors, even if their messages are the same.
+// An aggregate, like the result of parallel reconciles, results from cause if any of its errors does.
+func resultsFrom(err error, cause error) bool {
+	if errors.Is(err, cause) {
+		return true
+	}
+	var agg utilerrors.Aggregate
+	if errors.As(err, &agg) {
+		for _, e := range agg.Errors() {
+			if resultsFrom(e, cause) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// awsError returns the AWS error that err results from, or nil if err doesn't result from an AWS error.
+func awsError(err error) awserr.Error {
+	var awsErr awserr.Error
+	if errors.As(err, &awsErr) {
+		return awsErr
+	}
+	return nil
+}
+
+func SetAWSFailures(ctx context.Context, failures *AWSFailures) context.Context {
+	return context.WithValue(ctx, contextKeyAWSFailures, failures)
+}
+
+// GetAWSFailures returns the AWSFailures of ctx, or nil if failures are not recorded for ctx.
+func GetAWSFailures(ctx context.Context) *AWSFailures {
+	failures, _ := ctx.Value(contextKeyAWSFailures).(*AWSFailures)
+	return failures
+}
+
+// Certificates records the certificates attached to HTTPS listeners during a reconcile, or why they couldn't be resolved.
+type Certificates struct {
+	mutex    sync.Mutex
+	attached []string
+	failure  string
+}
+
+// RecordAttached records that certArns are attached to a listener.
+func (c *Certificates) RecordAttached(certArns []string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.attached = append(c.attached, certArns...)
+}
+
+// RecordFailure records that certificates of a listener couldn't be resolved.
+func (c *Certificates) RecordFailure(err error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.failure = err.Error()
+}
+
+// Attached returns the certificates attached to listeners, possibly with duplicates.
+func (c *Certificates) Attached() []string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.attached
+}
+
+// Failure returns why certificates of a listener couldn't be resolved, or empty string if all were resolved.
+func (c *Certificates) Failure() string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.failure
+}
+
+func SetCertificates(ctx context.Context, certificates *Certificates) context.Context {
+	return context.WithValue(ctx, contextKeyCertificates, certificates)
+}
+
+// GetCertificates returns the Certificates of ctx, or nil if certificates are not recorded for ctx.
+func GetCertificates(ctx context.Context) *Certificates {
+	certificates, _ := ctx.Value(contextKeyCertificates).(*Certificates)
+	return certificates
+}
+
+// Plan records the AWS operations that a dry-run reconcile would perform instead of performing them.
+type Plan struct {
+	mutex      sync.Mutex
+	operations []string
+	halted     bool
+}
+
+// Record records that operation would be performed.
+func (p *Plan) Record(operation string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.operations = append(p.operations, operation)
+}
+
+// Halt records that a resource would be created, operations depending on it cannot be planned.
+func (p *Plan) Halt() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.halted = true
+}
+
+// Operations returns the operations that would be performed, in order.
+func (p *Plan) Operations() []string {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.operations
+}
+
+// Halted returns whether planning stopped at a resource that would be created.
+func (p *Plan) Halted() bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.halted
+}
+
+func SetPlan(ctx context.Context, plan *Plan) context.Context {
+	return context.WithValue(ctx, contextKeyPlan, plan)
+}
+
+// GetPlan returns the Plan of ctx, or nil if ctx is not a dry-run.
+func GetPlan(ctx context.Context) *Plan {
+	plan, _ := ctx.Value(contextKeyPlan).(*Plan)
+	return plan
+}
+
+type noopLocker struct{}
+
+func (noopLocker) Lock()   {}
+func (noopLocker) Unlock() {}
+
+// SetLBLocker sets the locker that serializes mutations of the LoadBalancer reconciled with ctx.
+func SetLBLocker(ctx context.Context, locker sync.Locker) context.Context {
+	return context.WithValue(ctx, contextKeyLBLocker, locker)
+}
+
+// GetLBLocker returns the locker that serializes mutations of the LoadBalancer reconciled with ctx,
+// mutations are not serialized if no locker is set for ctx.
+func GetLBLocker(ctx context.Context) sync.Locker {
+	if locker, ok := ctx.Value(contextKeyLBLocker).(sync.Locker); ok {
+		return locker
+	}
+	return noopLocker{}
+}
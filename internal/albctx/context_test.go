@@ -1 +1,90 @@
 package albctx
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+func TestAWSFailures_ThrottledCause(t *testing.T) {
+	throttleErr := awserr.New("Throttling", "Rate exceeded", nil)
+	for _, tc := range []struct {
+		name     string
+		record   func(f *AWSFailures)
+		err      error
+		expected string
+	}{
+		{
+			name:     "error wrapping the throttled request",
+			record:   func(f *AWSFailures) { f.RecordThrottled("ec2/DescribeSubnets", throttleErr) },
+			err:      fmt.Errorf("failed to resolve subnets due to %w", throttleErr),
+			expected: "ec2/DescribeSubnets",
+		},
+		{
+			name:   "unrelated error after a throttled request",
+			record: func(f *AWSFailures) { f.RecordThrottled("ec2/DescribeSubnets", throttleErr) },
+			err:    errors.New("failed to get service"),
+		},
+		{
+			name: "throttled request retried successfully",
+			record: func(f *AWSFailures) {
+				f.RecordThrottled("ec2/DescribeSubnets", throttleErr)
+				f.RecordSucceeded()
+			},
+			err: fmt.Errorf("failed to resolve subnets due to %w", throttleErr),
+		},
+		{
+			name: "failed request after a throttled one",
+			record: func(f *AWSFailures) {
+				f.RecordThrottled("ec2/DescribeSubnets", throttleErr)
+				f.Record("elasticloadbalancing/CreateLoadBalancer", awserr.New("ValidationError", "invalid subnets", nil))
+			},
+			err: errors.New("failed to create LoadBalancer"),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			failures := &AWSFailures{}
+			tc.record(failures)
+			assert.Equal(t, tc.expected, failures.ThrottledCause(tc.err))
+		})
+	}
+}
+
+func TestAWSFailures_FailedOperation(t *testing.T) {
+	notFoundErr := awserr.New("LoadBalancerNotFound", "not found", nil)
+	validationErr := awserr.New("ValidationError", "invalid subnets", nil)
+	failures := &AWSFailures{}
+	failures.Record("elasticloadbalancing/DescribeLoadBalancers", notFoundErr)
+	assert.Equal(t, "", failures.FailedOperation(errors.New("failed to get service")))
+	assert.Equal(t, "", failures.FailedOperation(nil))
+
+	failures.Record("elasticloadbalancing/CreateLoadBalancer", validationErr)
+	assert.Equal(t, "elasticloadbalancing/CreateLoadBalancer", failures.FailedOperation(fmt.Errorf("failed to create LoadBalancer due to %w", validationErr)))
+	assert.Equal(t, "elasticloadbalancing/CreateLoadBalancer", failures.FailedOperation(pkgerrors.Wrap(validationErr, "failed to create LoadBalancer")))
+	// an error quoting the failure without wrapping it doesn't result from it.
+	assert.Equal(t, "", failures.FailedOperation(fmt.Errorf("failed to create LoadBalancer due to %v", validationErr)))
+	// an aggregate of parallel reconciles results from the failures of its errors.
+	agg := utilerrors.NewAggregate([]error{errors.New("failed to get service"), fmt.Errorf("failed to create LoadBalancer due to %w", validationErr)})
+	assert.Equal(t, "elasticloadbalancing/CreateLoadBalancer", failures.FailedOperation(fmt.Errorf("failed to reconcile target groups due to %w", agg)))
+}
+
+func TestAWSFailures_FailedOperation_sharedMessagePrefix(t *testing.T) {
+	createErr := awserr.New("ValidationError", "invalid subnets subnet-1, subnet-2", nil)
+	setSubnetsErr := awserr.New("ValidationError", "invalid subnets", nil)
+	failures := &AWSFailures{}
+	failures.Record("elasticloadbalancing/CreateLoadBalancer", createErr)
+	failures.Record("elasticloadbalancing/SetSubnets", setSubnetsErr)
+
+	assert.Equal(t, "elasticloadbalancing/CreateLoadBalancer", failures.FailedOperation(fmt.Errorf("failed to create LoadBalancer due to %w", createErr)))
+	assert.Equal(t, "elasticloadbalancing/SetSubnets", failures.FailedOperation(fmt.Errorf("failed to set subnets due to %w", setSubnetsErr)))
+
+	// distinct failures with the same message are still told apart.
+	modifyErr := awserr.New("ValidationError", "invalid subnets", nil)
+	failures.Record("elasticloadbalancing/ModifyLoadBalancerAttributes", modifyErr)
+	assert.Equal(t, "elasticloadbalancing/SetSubnets", failures.FailedOperation(fmt.Errorf("failed to set subnets due to %w", setSubnetsErr)))
+}
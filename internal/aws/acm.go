@@ -23,6 +23,18 @@ type ACMAPI interface {
 
 	// DescribeCertificate is an wrapper around acm.DescribeCertificate
 	DescribeCertificate(ctx context.Context, certArn string) (*acm.CertificateDetail, error)
+
+	// ImportCertificate imports a certificate into ACM, or re-imports it if input has a CertificateArn.
+	ImportCertificate(ctx context.Context, input *acm.ImportCertificateInput) (string, error)
+
+	// ListTagsForCertificate returns the tags of certificate with certArn.
+	ListTagsForCertificate(ctx context.Context, certArn string) ([]*acm.Tag, error)
+
+	// AddTagsToCertificate adds or overwrites tags of certificate with certArn.
+	AddTagsToCertificate(ctx context.Context, certArn string, tags []*acm.Tag) error
+
+	// DeleteCertificate deletes certificate with certArn, which must not be in use.
+	DeleteCertificate(ctx context.Context, certArn string) error
 }
 
 // Status validates ACM connectivity
@@ -33,7 +45,7 @@ func (c *Cloud) StatusACM() func() error {
 		}
 
 		if _, err := c.acm.ListCertificatesWithContext(context.TODO(), in); err != nil {
-			return fmt.Errorf("[acm.ListCertificatesWithContext]: %v", err)
+			return fmt.Errorf("[acm.ListCertificatesWithContext]: %w", err)
 		}
 		return nil
 	}
@@ -68,3 +80,36 @@ func (c *Cloud) DescribeCertificate(ctx context.Context, certArn string) (*acm.C
 	}
 	return resp.Certificate, nil
 }
+
+func (c *Cloud) ImportCertificate(ctx context.Context, input *acm.ImportCertificateInput) (string, error) {
+	resp, err := c.acm.ImportCertificateWithContext(ctx, input)
+	if err != nil {
+		return "", err
+	}
+	return aws.StringValue(resp.CertificateArn), nil
+}
+
+func (c *Cloud) ListTagsForCertificate(ctx context.Context, certArn string) ([]*acm.Tag, error) {
+	resp, err := c.acm.ListTagsForCertificateWithContext(ctx, &acm.ListTagsForCertificateInput{
+		CertificateArn: aws.String(certArn),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Tags, nil
+}
+
+func (c *Cloud) AddTagsToCertificate(ctx context.Context, certArn string, tags []*acm.Tag) error {
+	_, err := c.acm.AddTagsToCertificateWithContext(ctx, &acm.AddTagsToCertificateInput{
+		CertificateArn: aws.String(certArn),
+		Tags:           tags,
+	})
+	return err
+}
+
+func (c *Cloud) DeleteCertificate(ctx context.Context, certArn string) error {
+	_, err := c.acm.DeleteCertificateWithContext(ctx, &acm.DeleteCertificateInput{
+		CertificateArn: aws.String(certArn),
+	})
+	return err
+}
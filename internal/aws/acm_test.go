@@ -37,7 +37,11 @@ func TestCloud_StatusACM(t *testing.T) {
 			}
 
 			err := cloud.StatusACM()()
-			assert.Equal(t, tc.ExpectedError, err)
+			if tc.ExpectedError != nil {
+				assert.EqualError(t, err, tc.ExpectedError.Error())
+			} else {
+				assert.NoError(t, err)
+			}
 			acmsvc.AssertExpectations(t)
 		})
 	}
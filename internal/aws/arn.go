@@ -0,0 +1,19 @@
+package aws
+
+import "strings"
+
+// LoadBalancerArn returns the ARN of the LoadBalancer of the listener or rule with arn, other ARNs are returned as is.
+// e.g. arn:aws:elasticloadbalancing:us-west-2:123456789012:listener-rule/app/my-lb/50dc6c495c0c9188/f2f7dc8efc522ab2/9683b2d02a6cabee
+// belongs to arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/my-lb/50dc6c495c0c9188
+func LoadBalancerArn(arn string) string {
+	parts := strings.SplitN(arn, ":", 6)
+	if len(parts) != 6 {
+		return arn
+	}
+	resource := strings.Split(parts[5], "/")
+	if (resource[0] != "listener" && resource[0] != "listener-rule") || len(resource) < 4 {
+		return arn
+	}
+	parts[5] = strings.Join(append([]string{"loadbalancer"}, resource[1:4]...), "/")
+	return strings.Join(parts, ":")
+}
@@ -0,0 +1,100 @@
+package aws
+
+import (
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awsutil"
+)
+
+// responseCache caches AWS responses for ttl. Each entry depends on scopes, e.g. the ARNs of the resources it describes,
+// and is invalidated once any of them is changed. It's disabled when ttl is zero.
+type responseCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mutex   sync.Mutex
+	entries map[string]responseCacheEntry
+}
+
+type responseCacheEntry struct {
+	response interface{}
+	expires  time.Time
+	scopes   []string
+}
+
+func newResponseCache(ttl time.Duration) *responseCache {
+	return &responseCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[string]responseCacheEntry),
+	}
+}
+
+// load returns a copy of the cached response with key, or the response of fetch along with the scopes it depends on,
+// which is cached unless it fails or has no scopes. Responses must be pointers to structs, they're copied so that callers
+// modifying them don't corrupt the cache.
+func (c *responseCache) load(key string, fetch func() (interface{}, []string, error)) (interface{}, error) {
+	if c.ttl == 0 {
+		response, _, err := fetch()
+		return response, err
+	}
+	now := c.now()
+	c.mutex.Lock()
+	entry, ok := c.entries[key]
+	c.mutex.Unlock()
+	if ok && now.Before(entry.expires) {
+		return awsutil.CopyOf(entry.response), nil
+	}
+
+	response, scopes, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	if len(scopes) == 0 {
+		return response, nil
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.entries[key] = responseCacheEntry{
+		response: awsutil.CopyOf(response),
+		expires:  now.Add(c.ttl),
+		scopes:   scopes,
+	}
+	c.evictExpired(now)
+	return response, nil
+}
+
+// invalidate removes the entries depending on any of scopes.
+func (c *responseCache) invalidate(scopes ...string) {
+	if c.ttl == 0 {
+		return
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for key, entry := range c.entries {
+		if dependsOnAny(entry.scopes, scopes) {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// evictExpired removes expired entries, so that entries of deleted resources don't pile up.
+func (c *responseCache) evictExpired(now time.Time) {
+	for key, entry := range c.entries {
+		if !now.Before(entry.expires) {
+			delete(c.entries, key)
+		}
+	}
+}
+
+func dependsOnAny(entryScopes []string, scopes []string) bool {
+	for _, entryScope := range entryScopes {
+		for _, scope := range scopes {
+			if entryScope == scope {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -2,31 +2,56 @@ package aws
 
 import (
 	"fmt"
+	"os"
+	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/ec2metadata"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/acm"
 	"github.com/aws/aws-sdk-go/service/acm/acmiface"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
 	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/aws/aws-sdk-go/service/iam/iamiface"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
 	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
 	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi/resourcegroupstaggingapiiface"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/aws/aws-sdk-go/service/route53/route53iface"
+	"github.com/aws/aws-sdk-go/service/shield"
+	"github.com/aws/aws-sdk-go/service/shield/shieldiface"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
 	"github.com/aws/aws-sdk-go/service/wafregional"
 	"github.com/aws/aws-sdk-go/service/wafregional/wafregionaliface"
+	"github.com/aws/aws-sdk-go/service/wafv2"
+	"github.com/aws/aws-sdk-go/service/wafv2/wafv2iface"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/metric"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/version"
 )
 
+// userAgentName identifies the controller in the user-agent of AWS requests.
+const userAgentName = "aws-alb-ingress-controller"
+
 type CloudAPI interface {
 	ACMAPI
+	CloudWatchAPI
 	EC2API
 	ELBV2API
 	IAMAPI
+	LambdaAPI
 	ResourceGroupsTaggingAPIAPI
+	Route53API
+	ShieldAPI
+	SQSAPI
 	WAFRegionalAPI
+	WAFV2API
 
 	GetClusterName() string
 	GetVpcID() string
@@ -38,11 +63,17 @@ type Cloud struct {
 	clusterName string
 
 	acm         acmiface.ACMAPI
+	cloudwatch  cloudwatchiface.CloudWatchAPI
 	ec2         ec2iface.EC2API
 	elbv2       elbv2iface.ELBV2API
 	iam         iamiface.IAMAPI
+	lambda      lambdaiface.LambdaAPI
 	rgt         resourcegroupstaggingapiiface.ResourceGroupsTaggingAPIAPI
+	route53     route53iface.Route53API
+	shield      shieldiface.ShieldAPI
+	sqs         sqsiface.SQSAPI
 	wafregional wafregionaliface.WAFRegionalAPI
+	wafv2       wafv2iface.WAFV2API
 }
 
 // Initialize the global AWS clients.
@@ -50,36 +81,70 @@ type Cloud struct {
 // TODO: remove clusterName dependency
 // TODO: remove mc dependency like https://github.com/kubernetes/kubernetes/blob/master/pkg/cloudprovider/providers/aws/aws_metrics.go
 func New(cfg CloudConfig, clusterName string, mc metric.Collector) (CloudAPI, error) {
-	awsSession := NewSession(&aws.Config{MaxRetries: aws.Int(cfg.APIMaxRetries)}, cfg.APIDebug, mc)
+	awsSession := NewSession(&aws.Config{MaxRetries: aws.Int(cfg.APIMaxRetries)}, cfg.APIDebug, cfg.DryRun, cfg.ThrottleMaxBackoff, mc)
+	awsSession.Handlers.Build.PushBack(request.MakeAddToUserAgentFreeFormHandler(buildUserAgent(clusterName, controllerInstance(), cfg.UserAgentSuffix)))
 	metadata := ec2metadata.New(awsSession)
 
 	if len(cfg.VpcID) == 0 {
 		vpcID, err := GetVpcIDFromEC2Metadata(metadata)
 		if err != nil {
-			return nil, fmt.Errorf("failed to introspect vpcID from ec2Metadata due to %v, specify --aws-vpc-id instead if ec2Metadata is unavailable", err)
+			return nil, fmt.Errorf("failed to introspect vpcID from ec2Metadata due to %w, specify --aws-vpc-id instead if ec2Metadata is unavailable", err)
 		}
 		cfg.VpcID = vpcID
 	}
 	if len(cfg.Region) == 0 {
 		region, err := metadata.Region()
 		if err != nil {
-			return nil, fmt.Errorf("failed to introspect region from ec2Metadata due to %v, specify --aws-region instead if ec2Metadata is unavailable", err)
+			return nil, fmt.Errorf("failed to introspect region from ec2Metadata due to %w, specify --aws-region instead if ec2Metadata is unavailable", err)
 		}
 		cfg.Region = region
 	}
 
 	regionCfg := &aws.Config{Region: aws.String(cfg.Region)}
-	return &Cloud{
+	cloud := &Cloud{
 		cfg.VpcID,
 		cfg.Region,
 		clusterName,
 		acm.New(awsSession, regionCfg),
+		cloudwatch.New(awsSession, regionCfg),
 		ec2.New(awsSession, regionCfg),
 		elbv2.New(awsSession, regionCfg),
 		iam.New(awsSession, regionCfg),
+		lambda.New(awsSession, regionCfg),
 		resourcegroupstaggingapi.New(awsSession, regionCfg),
+		route53.New(awsSession, regionCfg),
+		shield.New(awsSession, &aws.Config{Region: aws.String(shieldRegion(cfg.Region))}),
+		sqs.New(awsSession, regionCfg),
 		wafregional.New(awsSession, regionCfg),
-	}, nil
+		wafv2.New(awsSession, regionCfg),
+	}
+	if cfg.APICacheTTL > 0 || cfg.TargetHealthCacheTTL > 0 {
+		return newCachedCloud(cloud, cfg.APICacheTTL, cfg.TargetHealthCacheTTL), nil
+	}
+	return cloud, nil
+}
+
+// buildUserAgent returns the user-agent appended to AWS requests, which attributes API activity in CloudTrail to the cluster and controller instance.
+func buildUserAgent(clusterName string, instance string, suffix string) string {
+	userAgent := fmt.Sprintf("%s/%s (cluster/%s; instance/%s)", userAgentName, version.RELEASE, clusterName, instance)
+	if suffix != "" {
+		userAgent += " " + suffix
+	}
+	return userAgent
+}
+
+// IsControllerUserAgent returns whether userAgent, as recorded by CloudTrail, is the one of the controller of clusterName.
+func IsControllerUserAgent(userAgent string, clusterName string) bool {
+	return strings.Contains(userAgent, fmt.Sprintf("%s/", userAgentName)) && strings.Contains(userAgent, fmt.Sprintf("(cluster/%s;", clusterName))
+}
+
+// controllerInstance returns the name of the controller instance, which is the pod name when running inside kubernetes.
+func controllerInstance() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return "unknown"
+	}
+	return hostname
 }
 
 func (c *Cloud) GetClusterName() string {
@@ -2,8 +2,10 @@ package aws
 
 import (
 	"net/http"
+	"testing"
 
 	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/stretchr/testify/assert"
 )
 
 func newReq(data interface{}, err error) *request.Request {
@@ -14,3 +16,32 @@ func newReq(data interface{}, err error) *request.Request {
 		Error:       err,
 	}
 }
+
+func Test_buildUserAgent(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		suffix   string
+		expected string
+	}{
+		{
+			name:     "without suffix",
+			expected: "aws-alb-ingress-controller/UNKNOWN (cluster/my-cluster; instance/alb-ingress-controller-5d8f7c)",
+		},
+		{
+			name:     "with suffix",
+			suffix:   "team/platform",
+			expected: "aws-alb-ingress-controller/UNKNOWN (cluster/my-cluster; instance/alb-ingress-controller-5d8f7c) team/platform",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, buildUserAgent("my-cluster", "alb-ingress-controller-5d8f7c", tc.suffix))
+		})
+	}
+}
+
+func Test_IsControllerUserAgent(t *testing.T) {
+	userAgent := "aws-sdk-go/1.27.3 (go1.13.5; linux; amd64) " + buildUserAgent("my-cluster", "alb-ingress-controller-5d8f7c", "")
+	assert.True(t, IsControllerUserAgent(userAgent, "my-cluster"))
+	assert.False(t, IsControllerUserAgent(userAgent, "other-cluster"))
+	assert.False(t, IsControllerUserAgent("console.amazonaws.com", "my-cluster"))
+}
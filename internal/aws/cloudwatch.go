@@ -0,0 +1,22 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+)
+
+// CloudWatchAPI is our wrapper CloudWatch API interface
+type CloudWatchAPI interface {
+	// GetMetricData returns the results of all metric data queries, across all pages.
+	GetMetricData(context.Context, *cloudwatch.GetMetricDataInput) ([]*cloudwatch.MetricDataResult, error)
+}
+
+func (c *Cloud) GetMetricData(ctx context.Context, i *cloudwatch.GetMetricDataInput) ([]*cloudwatch.MetricDataResult, error) {
+	var result []*cloudwatch.MetricDataResult
+	err := c.cloudwatch.GetMetricDataPagesWithContext(ctx, i, func(output *cloudwatch.GetMetricDataOutput, _ bool) bool {
+		result = append(result, output.MetricDataResults...)
+		return true
+	})
+	return result, err
+}
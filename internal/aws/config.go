@@ -4,16 +4,21 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/golang/glog"
 	"github.com/spf13/pflag"
 )
 
 const (
-	defaultVpcID         = ""
-	defaultRegion        = ""
-	defaultAPIMaxRetries = 10
-	defaultAPIDebug      = false
+	defaultVpcID                = ""
+	defaultRegion               = ""
+	defaultAPIMaxRetries        = 10
+	defaultAPIDebug             = false
+	defaultUserAgentSuffix      = ""
+	defaultAPICacheTTL          = 0
+	defaultTargetHealthCacheTTL = 0
+	defaultThrottleMaxBackoff   = 20 * time.Second
 )
 
 // configuration for cloud
@@ -23,6 +28,20 @@ type CloudConfig struct {
 
 	APIMaxRetries int
 	APIDebug      bool
+
+	// ThrottleMaxBackoff caps the backoff shared by requests to a service that throttles the controller, it's disabled when zero.
+	ThrottleMaxBackoff time.Duration
+
+	// UserAgentSuffix is appended to the user-agent of AWS requests, after the cluster name and controller instance.
+	UserAgentSuffix string
+
+	// APICacheTTL is how long responses describing ELBv2 LoadBalancers, listeners, rules and tags are cached, it's disabled when zero.
+	APICacheTTL time.Duration
+	// TargetHealthCacheTTL is how long DescribeTargetHealth responses are cached, it's disabled when zero.
+	TargetHealthCacheTTL time.Duration
+
+	// DryRun logs mutating AWS requests instead of sending them, it's set by the --dry-run flag of the controller.
+	DryRun bool
 }
 
 func (cfg *CloudConfig) BindFlags(fs *pflag.FlagSet) {
@@ -32,8 +51,16 @@ func (cfg *CloudConfig) BindFlags(fs *pflag.FlagSet) {
 		`AWS Region for the kubernetes cluster`)
 	fs.IntVar(&cfg.APIMaxRetries, "aws-max-retries", defaultAPIMaxRetries,
 		`Maximum number of times to retry the AWS API.`)
+	fs.DurationVar(&cfg.ThrottleMaxBackoff, "aws-throttle-max-backoff", defaultThrottleMaxBackoff,
+		`Maximum backoff of requests to an AWS service once it throttles the controller, shared by all workers. Backoff is disabled if zero.`)
 	fs.BoolVar(&cfg.APIDebug, "aws-api-debug", defaultAPIDebug,
-		`Enable debug logging of AWS API`)
+		`Enable debug logging of AWS API, sensitive values in payloads are redacted`)
+	fs.StringVar(&cfg.UserAgentSuffix, "aws-user-agent-suffix", defaultUserAgentSuffix,
+		`Suffix appended to the user-agent of AWS API requests, to attribute API activity in CloudTrail`)
+	fs.DurationVar(&cfg.APICacheTTL, "aws-api-cache-ttl", defaultAPICacheTTL,
+		`Duration to cache DescribeLoadBalancers, DescribeListeners, DescribeRules and DescribeTags responses. Changes made through the controller invalidate them. Caching is disabled if zero.`)
+	fs.DurationVar(&cfg.TargetHealthCacheTTL, "aws-target-health-cache-ttl", defaultTargetHealthCacheTTL,
+		`Duration to cache DescribeTargetHealth responses. Caching is disabled if zero.`)
 }
 
 func (cfg *CloudConfig) BindEnv() error {
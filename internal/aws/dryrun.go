@@ -0,0 +1,96 @@
+package aws
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/ec2metadata"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/acm"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/aws/aws-sdk-go/service/shield"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/aws/aws-sdk-go/service/wafregional"
+	"github.com/aws/aws-sdk-go/service/wafv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/log"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// ErrCodeDryRun is the error code of requests creating resources in dry-run, which are not sent.
+const ErrCodeDryRun = "DryRun"
+
+// readOperations are the AWS operations used by the controller that never mutate resources, keyed by service.
+// Any other operation is assumed to mutate resources, and is not sent in dry-run.
+var readOperations = map[string]sets.String{
+	acm.ServiceName:         sets.NewString("DescribeCertificate", "ListCertificates", "ListTagsForCertificate"),
+	cloudwatch.ServiceName:  sets.NewString("GetMetricData"),
+	ec2metadata.ServiceName: sets.NewString("GetToken", "GetMetadata", "GetUserData", "GetDynamicData"),
+	ec2.ServiceName: sets.NewString("DescribeAddresses", "DescribeInstanceStatus", "DescribeInstances", "DescribeNetworkInterfaces",
+		"DescribeSecurityGroups", "DescribeSubnets", "DescribeTags", "DescribeVpcEndpointConnections",
+		"DescribeVpcEndpointServiceConfigurations", "DescribeVpcEndpointServicePermissions", "DescribeVpcs"),
+	elbv2.ServiceName: sets.NewString("DescribeListenerCertificates", "DescribeListeners", "DescribeLoadBalancerAttributes",
+		"DescribeLoadBalancers", "DescribeRules", "DescribeTags", "DescribeTargetGroupAttributes", "DescribeTargetGroups",
+		"DescribeTargetHealth"),
+	iam.ServiceName:                      sets.NewString("ListServerCertificates"),
+	resourcegroupstaggingapi.ServiceName: sets.NewString("GetResources"),
+	route53.ServiceName:                  sets.NewString("ListHostedZones", "ListResourceRecordSets"),
+	shield.ServiceName:                   sets.NewString("DescribeProtection"),
+	// receiving messages only hides them from other consumers until their visibility timeout expires, while deleting them is skipped.
+	sqs.ServiceName:         sets.NewString("ReceiveMessage"),
+	wafregional.ServiceName: sets.NewString("GetWebACL", "GetWebACLForResource"),
+	wafv2.ServiceName:       sets.NewString("GetWebACLForResource"),
+}
+
+// newDryRunHandler returns a Validate handler that stops mutating requests from being sent when dryRun is set,
+// or the request belongs to a dry-run reconcile. Requests creating resources fail with ErrCodeDryRun, since their
+// callers depend on the created resource. Other requests succeed with an empty output.
+func newDryRunHandler(dryRun bool) func(r *request.Request) {
+	return func(r *request.Request) {
+		plan := albctx.GetPlan(r.Context())
+		if (!dryRun && plan == nil) || !isMutatingOperation(r.ClientInfo.ServiceName, r.Operation.Name) {
+			return
+		}
+		operation := fmt.Sprintf("%s/%s", r.ClientInfo.ServiceName, r.Operation.Name)
+		albctx.GetLogger(r.Context()).Infof("dry-run: skipping %s, Payload: %s", operation, log.Prettify(r.Params))
+		if plan != nil {
+			plan.Record(operation)
+		}
+		if createsResource(r.Operation.Name) {
+			if plan != nil {
+				plan.Halt()
+			}
+			r.Error = awserr.New(ErrCodeDryRun, fmt.Sprintf("%s is not performed in dry-run", operation), nil)
+			return
+		}
+		r.Handlers.Sign.Clear()
+		r.Handlers.Send.Clear()
+		r.Handlers.UnmarshalMeta.Clear()
+		r.Handlers.ValidateResponse.Clear()
+		r.Handlers.Unmarshal.Clear()
+	}
+}
+
+func isMutatingOperation(service string, name string) bool {
+	return !readOperations[service].Has(name)
+}
+
+// createsResource returns whether operation returns a new resource, e.g. CreateLoadBalancer or AllocateAddress.
+func createsResource(name string) bool {
+	if name == "CreateTags" {
+		return false
+	}
+	return strings.HasPrefix(name, "Create") || strings.HasPrefix(name, "Allocate") || name == "ImportCertificate"
+}
+
+// IsDryRunError returns whether err is from a request creating resources in dry-run.
+func IsDryRunError(err error) bool {
+	awsErr, ok := err.(awserr.Error)
+	return ok && awsErr.Code() == ErrCodeDryRun
+}
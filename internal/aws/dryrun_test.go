@@ -0,0 +1,62 @@
+package aws
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/service/acm"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/metric"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_isMutatingOperation(t *testing.T) {
+	for _, tc := range []struct {
+		Service  string
+		Name     string
+		Expected bool
+	}{
+		{Service: elbv2.ServiceName, Name: "DescribeLoadBalancers", Expected: false},
+		{Service: acm.ServiceName, Name: "ListCertificates", Expected: false},
+		{Service: resourcegroupstaggingapi.ServiceName, Name: "GetResources", Expected: false},
+		{Service: sqs.ServiceName, Name: "ReceiveMessage", Expected: false},
+		{Service: elbv2.ServiceName, Name: "ModifyRule", Expected: true},
+		{Service: ec2.ServiceName, Name: "CreateTags", Expected: true},
+		{Service: route53.ServiceName, Name: "ChangeResourceRecordSets", Expected: true},
+		{Service: sqs.ServiceName, Name: "DeleteMessage", Expected: true},
+		// operations are only read-only for the services they're listed for.
+		{Service: sqs.ServiceName, Name: "DescribeTags", Expected: true},
+	} {
+		assert.Equal(t, tc.Expected, isMutatingOperation(tc.Service, tc.Name), "%s/%s", tc.Service, tc.Name)
+	}
+}
+
+func TestNewSession_dryRun(t *testing.T) {
+	// requests must never reach the endpoint in dry-run.
+	session := NewSession(&aws.Config{
+		Region:      aws.String("us-west-2"),
+		Endpoint:    aws.String("http://127.0.0.1:1"),
+		Credentials: credentials.NewStaticCredentials("id", "secret", ""),
+		MaxRetries:  aws.Int(0),
+	}, false, false, 0, metric.DummyCollector{})
+	svc := elbv2.New(session)
+	plan := &albctx.Plan{}
+	ctx := albctx.SetPlan(context.Background(), plan)
+
+	output, err := svc.ModifyRuleWithContext(ctx, &elbv2.ModifyRuleInput{RuleArn: aws.String("ruleArn")})
+	assert.NoError(t, err)
+	assert.Empty(t, output.Rules)
+	assert.False(t, plan.Halted())
+
+	_, err = svc.CreateTargetGroupWithContext(ctx, &elbv2.CreateTargetGroupInput{Name: aws.String("tg")})
+	assert.True(t, IsDryRunError(err))
+	assert.True(t, plan.Halted())
+	assert.Equal(t, []string{"elasticloadbalancing/ModifyRule", "elasticloadbalancing/CreateTargetGroup"}, plan.Operations())
+}
@@ -62,6 +62,24 @@ type EC2API interface {
 	RevokeSecurityGroupIngressWithContext(context.Context, *ec2.RevokeSecurityGroupIngressInput) (*ec2.RevokeSecurityGroupIngressOutput, error)
 	CreateEC2TagsWithContext(context.Context, *ec2.CreateTagsInput) (*ec2.CreateTagsOutput, error)
 	DeleteEC2TagsWithContext(context.Context, *ec2.DeleteTagsInput) (*ec2.DeleteTagsOutput, error)
+	DescribeAddressesWithContext(context.Context, *ec2.DescribeAddressesInput) (*ec2.DescribeAddressesOutput, error)
+	AllocateAddressWithContext(context.Context, *ec2.AllocateAddressInput) (*ec2.AllocateAddressOutput, error)
+	ReleaseAddressWithContext(context.Context, *ec2.ReleaseAddressInput) (*ec2.ReleaseAddressOutput, error)
+
+	// DescribeVpcEndpointServiceConfigurations list VPC endpoint service configurations.
+	DescribeVpcEndpointServiceConfigurations(context.Context, *ec2.DescribeVpcEndpointServiceConfigurationsInput) ([]*ec2.ServiceConfiguration, error)
+
+	// DescribeVpcEndpointServicePermissions list principals allowed to connect to a VPC endpoint service.
+	DescribeVpcEndpointServicePermissions(context.Context, *ec2.DescribeVpcEndpointServicePermissionsInput) ([]*ec2.AllowedPrincipal, error)
+
+	// DescribeVpcEndpointConnections list VPC endpoint connections to VPC endpoint services.
+	DescribeVpcEndpointConnections(context.Context, *ec2.DescribeVpcEndpointConnectionsInput) ([]*ec2.VpcEndpointConnection, error)
+
+	CreateVpcEndpointServiceConfigurationWithContext(context.Context, *ec2.CreateVpcEndpointServiceConfigurationInput) (*ec2.CreateVpcEndpointServiceConfigurationOutput, error)
+	ModifyVpcEndpointServiceConfigurationWithContext(context.Context, *ec2.ModifyVpcEndpointServiceConfigurationInput) (*ec2.ModifyVpcEndpointServiceConfigurationOutput, error)
+	ModifyVpcEndpointServicePermissionsWithContext(context.Context, *ec2.ModifyVpcEndpointServicePermissionsInput) (*ec2.ModifyVpcEndpointServicePermissionsOutput, error)
+	DeleteVpcEndpointServiceConfigurationsWithContext(context.Context, *ec2.DeleteVpcEndpointServiceConfigurationsInput) (*ec2.DeleteVpcEndpointServiceConfigurationsOutput, error)
+	RejectVpcEndpointConnectionsWithContext(context.Context, *ec2.RejectVpcEndpointConnectionsInput) (*ec2.RejectVpcEndpointConnectionsOutput, error)
 
 	// GetVpcWithContext returns the VPC for the configured VPC ID
 	GetVpcWithContext(context.Context) (*ec2.Vpc, error)
@@ -93,6 +111,65 @@ func (c *Cloud) DeleteEC2TagsWithContext(ctx context.Context, i *ec2.DeleteTagsI
 	return c.ec2.DeleteTagsWithContext(ctx, i)
 }
 
+func (c *Cloud) DescribeAddressesWithContext(ctx context.Context, i *ec2.DescribeAddressesInput) (*ec2.DescribeAddressesOutput, error) {
+	return c.ec2.DescribeAddressesWithContext(ctx, i)
+}
+
+func (c *Cloud) AllocateAddressWithContext(ctx context.Context, i *ec2.AllocateAddressInput) (*ec2.AllocateAddressOutput, error) {
+	return c.ec2.AllocateAddressWithContext(ctx, i)
+}
+
+func (c *Cloud) ReleaseAddressWithContext(ctx context.Context, i *ec2.ReleaseAddressInput) (*ec2.ReleaseAddressOutput, error) {
+	return c.ec2.ReleaseAddressWithContext(ctx, i)
+}
+
+func (c *Cloud) CreateVpcEndpointServiceConfigurationWithContext(ctx context.Context, i *ec2.CreateVpcEndpointServiceConfigurationInput) (*ec2.CreateVpcEndpointServiceConfigurationOutput, error) {
+	return c.ec2.CreateVpcEndpointServiceConfigurationWithContext(ctx, i)
+}
+
+func (c *Cloud) ModifyVpcEndpointServiceConfigurationWithContext(ctx context.Context, i *ec2.ModifyVpcEndpointServiceConfigurationInput) (*ec2.ModifyVpcEndpointServiceConfigurationOutput, error) {
+	return c.ec2.ModifyVpcEndpointServiceConfigurationWithContext(ctx, i)
+}
+
+func (c *Cloud) ModifyVpcEndpointServicePermissionsWithContext(ctx context.Context, i *ec2.ModifyVpcEndpointServicePermissionsInput) (*ec2.ModifyVpcEndpointServicePermissionsOutput, error) {
+	return c.ec2.ModifyVpcEndpointServicePermissionsWithContext(ctx, i)
+}
+
+func (c *Cloud) DeleteVpcEndpointServiceConfigurationsWithContext(ctx context.Context, i *ec2.DeleteVpcEndpointServiceConfigurationsInput) (*ec2.DeleteVpcEndpointServiceConfigurationsOutput, error) {
+	return c.ec2.DeleteVpcEndpointServiceConfigurationsWithContext(ctx, i)
+}
+
+func (c *Cloud) RejectVpcEndpointConnectionsWithContext(ctx context.Context, i *ec2.RejectVpcEndpointConnectionsInput) (*ec2.RejectVpcEndpointConnectionsOutput, error) {
+	return c.ec2.RejectVpcEndpointConnectionsWithContext(ctx, i)
+}
+
+func (c *Cloud) DescribeVpcEndpointServiceConfigurations(ctx context.Context, input *ec2.DescribeVpcEndpointServiceConfigurationsInput) ([]*ec2.ServiceConfiguration, error) {
+	var result []*ec2.ServiceConfiguration
+	err := c.ec2.DescribeVpcEndpointServiceConfigurationsPagesWithContext(ctx, input, func(output *ec2.DescribeVpcEndpointServiceConfigurationsOutput, _ bool) bool {
+		result = append(result, output.ServiceConfigurations...)
+		return true
+	})
+	return result, err
+}
+
+func (c *Cloud) DescribeVpcEndpointServicePermissions(ctx context.Context, input *ec2.DescribeVpcEndpointServicePermissionsInput) ([]*ec2.AllowedPrincipal, error) {
+	var result []*ec2.AllowedPrincipal
+	err := c.ec2.DescribeVpcEndpointServicePermissionsPagesWithContext(ctx, input, func(output *ec2.DescribeVpcEndpointServicePermissionsOutput, _ bool) bool {
+		result = append(result, output.AllowedPrincipals...)
+		return true
+	})
+	return result, err
+}
+
+func (c *Cloud) DescribeVpcEndpointConnections(ctx context.Context, input *ec2.DescribeVpcEndpointConnectionsInput) ([]*ec2.VpcEndpointConnection, error) {
+	var result []*ec2.VpcEndpointConnection
+	err := c.ec2.DescribeVpcEndpointConnectionsPagesWithContext(ctx, input, func(output *ec2.DescribeVpcEndpointConnectionsOutput, _ bool) bool {
+		result = append(result, output.VpcEndpointConnections...)
+		return true
+	})
+	return result, err
+}
+
 func (c *Cloud) DescribeNetworkInterfaces(ctx context.Context, input *ec2.DescribeNetworkInterfacesInput) ([]*ec2.NetworkInterface, error) {
 	var result []*ec2.NetworkInterface
 	err := c.ec2.DescribeNetworkInterfacesPagesWithContext(ctx, input, func(output *ec2.DescribeNetworkInterfacesOutput, _ bool) bool {
@@ -158,7 +235,7 @@ func (c *Cloud) GetSubnetsByNameOrID(ctx context.Context, nameOrIDs []string) (s
 	for _, in := range filters {
 		describeSubnetsOutput, err := c.ec2.DescribeSubnetsWithContext(ctx, &ec2.DescribeSubnetsInput{Filters: in})
 		if err != nil {
-			return subnets, fmt.Errorf("unable to fetch subnets due to %v", err)
+			return subnets, fmt.Errorf("unable to fetch subnets due to %w", err)
 		}
 
 		subnets = append(subnets, describeSubnetsOutput.Subnets...)
@@ -201,7 +278,7 @@ func (c *Cloud) GetSecurityGroupsByName(ctx context.Context, names []string) (gr
 
 	describeSecurityGroupsOutput, err := c.ec2.DescribeSecurityGroupsWithContext(ctx, in)
 	if err != nil {
-		return nil, fmt.Errorf("unable to fetch security groups %v due to %v", in.Filters, err)
+		return nil, fmt.Errorf("unable to fetch security groups %v due to %w", in.Filters, err)
 	}
 
 	if describeSecurityGroupsOutput == nil {
@@ -320,7 +397,7 @@ func (c *Cloud) StatusEC2() func() error {
 		in := &ec2.DescribeTagsInput{MaxResults: aws.Int64(5)}
 
 		if _, err := c.ec2.DescribeTagsWithContext(context.TODO(), in); err != nil {
-			return fmt.Errorf("[ec2.DescribeTagsWithContext]: %v", err)
+			return fmt.Errorf("[ec2.DescribeTagsWithContext]: %w", err)
 		}
 		return nil
 	}
@@ -333,7 +410,7 @@ func (c *Cloud) IsNodeHealthy(instanceid string) (bool, error) {
 	}
 	o, err := c.ec2.DescribeInstanceStatus(in)
 	if err != nil {
-		return false, fmt.Errorf("Unable to DescribeInstanceStatus on %v: %v", instanceid, err.Error())
+		return false, fmt.Errorf("Unable to DescribeInstanceStatus on %v: %w", instanceid, err)
 	}
 
 	for _, instanceStatus := range o.InstanceStatuses {
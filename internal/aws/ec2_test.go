@@ -39,7 +39,11 @@ func TestCloud_StatusEC2(t *testing.T) {
 			}
 
 			err := cloud.StatusEC2()()
-			assert.Equal(t, tc.ExpectedError, err)
+			if tc.ExpectedError != nil {
+				assert.EqualError(t, err, tc.ExpectedError.Error())
+			} else {
+				assert.NoError(t, err)
+			}
 			ec2svc.AssertExpectations(t)
 		})
 	}
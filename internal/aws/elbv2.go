@@ -10,6 +10,9 @@ import (
 	"github.com/aws/aws-sdk-go/service/elbv2"
 )
 
+// describeLoadBalancersMaxArns is the maximum number of LoadBalancerArns in a single DescribeLoadBalancers call.
+const describeLoadBalancersMaxArns = 20
+
 type ELBV2API interface {
 	StatusELBV2() func() error
 
@@ -27,6 +30,9 @@ type ELBV2API interface {
 	// GetLoadBalancerByName retrieve LoadBalancer instance by name
 	GetLoadBalancerByName(context.Context, string) (*elbv2.LoadBalancer, error)
 
+	// GetLoadBalancersByTags retrieve LoadBalancer instances tagged with tagFilters
+	GetLoadBalancersByTags(context.Context, map[string][]string) ([]*elbv2.LoadBalancer, error)
+
 	// DeleteLoadBalancerByArn deletes LoadBalancer instance by arn
 	DeleteLoadBalancerByArn(context.Context, string) error
 
@@ -190,7 +196,7 @@ func (c *Cloud) StatusELBV2() func() error {
 		in := &elbv2.DescribeLoadBalancersInput{PageSize: aws.Int64(1)}
 
 		if _, err := c.elbv2.DescribeLoadBalancersWithContext(context.TODO(), in); err != nil {
-			return fmt.Errorf("[elbv2.DescribeLoadBalancersWithContext]: %v", err)
+			return fmt.Errorf("[elbv2.DescribeLoadBalancersWithContext]: %w", err)
 		}
 		return nil
 	}
@@ -252,6 +258,40 @@ func (c *Cloud) GetLoadBalancerByName(ctx context.Context, name string) (*elbv2.
 	return loadBalancers[0], nil
 }
 
+// GetLoadBalancersByTags retrieve LoadBalancer instances tagged with tagFilters.
+// The tagging API is used to find matching LoadBalancers, so only owned LoadBalancers are described instead of every LoadBalancer in account.
+func (c *Cloud) GetLoadBalancersByTags(ctx context.Context, tagFilters map[string][]string) ([]*elbv2.LoadBalancer, error) {
+	arns, err := c.GetResourcesByFilters(tagFilters, ResourceTypeEnumELBLoadBalancer)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*elbv2.LoadBalancer
+	for len(arns) != 0 {
+		batch := arns
+		if len(batch) > describeLoadBalancersMaxArns {
+			batch = batch[:describeLoadBalancersMaxArns]
+		}
+		arns = arns[len(batch):]
+
+		loadBalancers, err := c.describeLoadBalancersHelper(&elbv2.DescribeLoadBalancersInput{
+			LoadBalancerArns: aws.StringSlice(batch),
+		})
+		if err != nil {
+			awsError, ok := err.(awserr.Error)
+			if !ok || awsError.Code() != elbv2.ErrCodeLoadBalancerNotFoundException {
+				return nil, err
+			}
+			// the tagging API is eventually consistent and may return deleted LoadBalancers, which fails the whole batch.
+			if loadBalancers, err = c.describeExistingLoadBalancers(batch); err != nil {
+				return nil, err
+			}
+		}
+		result = append(result, loadBalancers...)
+	}
+	return result, nil
+}
+
 func (c *Cloud) DeleteLoadBalancerByArn(ctx context.Context, arn string) error {
 	_, err := c.elbv2.DeleteLoadBalancerWithContext(ctx, &elbv2.DeleteLoadBalancerInput{
 		LoadBalancerArn: aws.String(arn),
@@ -299,6 +339,23 @@ func (c *Cloud) DeleteTargetGroupByArn(ctx context.Context, arn string) error {
 	return err
 }
 
+// describeExistingLoadBalancers describes LoadBalancers by arn one by one, LoadBalancers that don't exist are ignored.
+func (c *Cloud) describeExistingLoadBalancers(arns []string) (result []*elbv2.LoadBalancer, err error) {
+	for _, arn := range arns {
+		loadBalancers, err := c.describeLoadBalancersHelper(&elbv2.DescribeLoadBalancersInput{
+			LoadBalancerArns: []*string{aws.String(arn)},
+		})
+		if err != nil {
+			if awsError, ok := err.(awserr.Error); ok && awsError.Code() == elbv2.ErrCodeLoadBalancerNotFoundException {
+				continue
+			}
+			return nil, err
+		}
+		result = append(result, loadBalancers...)
+	}
+	return result, nil
+}
+
 // describeLoadBalancersHelper is an helper to handle pagination in describeLoadBalancers call
 func (c *Cloud) describeLoadBalancersHelper(input *elbv2.DescribeLoadBalancersInput) (result []*elbv2.LoadBalancer, err error) {
 	err = c.elbv2.DescribeLoadBalancersPages(input, func(output *elbv2.DescribeLoadBalancersOutput, _ bool) bool {
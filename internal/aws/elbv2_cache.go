@@ -0,0 +1,231 @@
+package aws
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awsutil"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+)
+
+// newCachedCloud wraps cloud to cache the responses of DescribeLoadBalancers, DescribeListeners, DescribeRules and DescribeTags for ttl,
+// and of DescribeTargetHealth for targetHealthTTL, since target health also changes without ELBv2 calls.
+// Writes through the controller invalidate the cached responses about the LoadBalancer or targetGroup they change,
+// responses about a LoadBalancer include those about its listeners and rules.
+func newCachedCloud(cloud CloudAPI, ttl time.Duration, targetHealthTTL time.Duration) CloudAPI {
+	return &cachedCloud{
+		CloudAPI:          cloud,
+		cache:             newResponseCache(ttl),
+		targetHealthCache: newResponseCache(targetHealthTTL),
+	}
+}
+
+type cachedCloud struct {
+	CloudAPI
+
+	cache             *responseCache
+	targetHealthCache *responseCache
+}
+
+// loadBalancerNameScope is the scope of responses describing a LoadBalancer by name, which may not exist yet.
+func loadBalancerNameScope(name string) string {
+	return "loadbalancer-name/" + name
+}
+
+func (c *cachedCloud) invalidate(scopes ...string) {
+	c.cache.invalidate(scopes...)
+	c.targetHealthCache.invalidate(scopes...)
+}
+
+func (c *cachedCloud) GetLoadBalancerByArn(ctx context.Context, arn string) (*elbv2.LoadBalancer, error) {
+	resp, err := c.cache.load("DescribeLoadBalancers/arn/"+arn, func() (interface{}, []string, error) {
+		instance, err := c.CloudAPI.GetLoadBalancerByArn(ctx, arn)
+		return describedLoadBalancer(instance), loadBalancerScopes(instance, arn), err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return firstLoadBalancer(resp.(*elbv2.DescribeLoadBalancersOutput)), nil
+}
+
+func (c *cachedCloud) GetLoadBalancerByName(ctx context.Context, name string) (*elbv2.LoadBalancer, error) {
+	resp, err := c.cache.load("DescribeLoadBalancers/name/"+name, func() (interface{}, []string, error) {
+		instance, err := c.CloudAPI.GetLoadBalancerByName(ctx, name)
+		return describedLoadBalancer(instance), loadBalancerScopes(instance, loadBalancerNameScope(name)), err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return firstLoadBalancer(resp.(*elbv2.DescribeLoadBalancersOutput)), nil
+}
+
+func (c *cachedCloud) ListListenersByLoadBalancer(ctx context.Context, lbArn string) ([]*elbv2.Listener, error) {
+	resp, err := c.cache.load("DescribeListeners/"+lbArn, func() (interface{}, []string, error) {
+		listeners, err := c.CloudAPI.ListListenersByLoadBalancer(ctx, lbArn)
+		return &elbv2.DescribeListenersOutput{Listeners: listeners}, []string{lbArn}, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*elbv2.DescribeListenersOutput).Listeners, nil
+}
+
+func (c *cachedCloud) GetRules(ctx context.Context, listenerArn string) ([]*elbv2.Rule, error) {
+	resp, err := c.cache.load("DescribeRules/"+listenerArn, func() (interface{}, []string, error) {
+		rules, err := c.CloudAPI.GetRules(ctx, listenerArn)
+		return &elbv2.DescribeRulesOutput{Rules: rules}, []string{LoadBalancerArn(listenerArn)}, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*elbv2.DescribeRulesOutput).Rules, nil
+}
+
+func (c *cachedCloud) DescribeELBV2TagsWithContext(ctx context.Context, i *elbv2.DescribeTagsInput) (*elbv2.DescribeTagsOutput, error) {
+	arns := StringValueSlice(i.ResourceArns)
+	sort.Strings(arns)
+	resp, err := c.cache.load("DescribeTags/"+strings.Join(arns, ","), func() (interface{}, []string, error) {
+		resp, err := c.CloudAPI.DescribeELBV2TagsWithContext(ctx, i)
+		return resp, arns, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*elbv2.DescribeTagsOutput), nil
+}
+
+func (c *cachedCloud) DescribeTargetHealthWithContext(ctx context.Context, i *elbv2.DescribeTargetHealthInput) (*elbv2.DescribeTargetHealthOutput, error) {
+	tgArn := StringValue(i.TargetGroupArn)
+	resp, err := c.targetHealthCache.load("DescribeTargetHealth/"+awsutil.Prettify(i), func() (interface{}, []string, error) {
+		resp, err := c.CloudAPI.DescribeTargetHealthWithContext(ctx, i)
+		return resp, []string{tgArn}, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*elbv2.DescribeTargetHealthOutput), nil
+}
+
+func (c *cachedCloud) CreateLoadBalancerWithContext(ctx context.Context, i *elbv2.CreateLoadBalancerInput) (*elbv2.CreateLoadBalancerOutput, error) {
+	defer c.invalidate(loadBalancerNameScope(StringValue(i.Name)))
+	return c.CloudAPI.CreateLoadBalancerWithContext(ctx, i)
+}
+
+func (c *cachedCloud) DeleteLoadBalancerByArn(ctx context.Context, arn string) error {
+	defer c.invalidate(arn)
+	return c.CloudAPI.DeleteLoadBalancerByArn(ctx, arn)
+}
+
+func (c *cachedCloud) SetSecurityGroupsWithContext(ctx context.Context, i *elbv2.SetSecurityGroupsInput) (*elbv2.SetSecurityGroupsOutput, error) {
+	defer c.invalidate(StringValue(i.LoadBalancerArn))
+	return c.CloudAPI.SetSecurityGroupsWithContext(ctx, i)
+}
+
+func (c *cachedCloud) SetSubnetsWithContext(ctx context.Context, i *elbv2.SetSubnetsInput) (*elbv2.SetSubnetsOutput, error) {
+	defer c.invalidate(StringValue(i.LoadBalancerArn))
+	return c.CloudAPI.SetSubnetsWithContext(ctx, i)
+}
+
+func (c *cachedCloud) SetIpAddressTypeWithContext(ctx context.Context, i *elbv2.SetIpAddressTypeInput) (*elbv2.SetIpAddressTypeOutput, error) {
+	defer c.invalidate(StringValue(i.LoadBalancerArn))
+	return c.CloudAPI.SetIpAddressTypeWithContext(ctx, i)
+}
+
+func (c *cachedCloud) CreateListenerWithContext(ctx context.Context, i *elbv2.CreateListenerInput) (*elbv2.CreateListenerOutput, error) {
+	defer c.invalidate(StringValue(i.LoadBalancerArn))
+	return c.CloudAPI.CreateListenerWithContext(ctx, i)
+}
+
+func (c *cachedCloud) ModifyListenerWithContext(ctx context.Context, i *elbv2.ModifyListenerInput) (*elbv2.ModifyListenerOutput, error) {
+	defer c.invalidate(LoadBalancerArn(StringValue(i.ListenerArn)))
+	return c.CloudAPI.ModifyListenerWithContext(ctx, i)
+}
+
+func (c *cachedCloud) DeleteListenersByArn(ctx context.Context, lsArn string) error {
+	defer c.invalidate(LoadBalancerArn(lsArn))
+	return c.CloudAPI.DeleteListenersByArn(ctx, lsArn)
+}
+
+func (c *cachedCloud) AddListenerCertificates(ctx context.Context, i *elbv2.AddListenerCertificatesInput) (*elbv2.AddListenerCertificatesOutput, error) {
+	defer c.invalidate(LoadBalancerArn(StringValue(i.ListenerArn)))
+	return c.CloudAPI.AddListenerCertificates(ctx, i)
+}
+
+func (c *cachedCloud) RemoveListenerCertificates(ctx context.Context, i *elbv2.RemoveListenerCertificatesInput) (*elbv2.RemoveListenerCertificatesOutput, error) {
+	defer c.invalidate(LoadBalancerArn(StringValue(i.ListenerArn)))
+	return c.CloudAPI.RemoveListenerCertificates(ctx, i)
+}
+
+func (c *cachedCloud) CreateRuleWithContext(ctx context.Context, i *elbv2.CreateRuleInput) (*elbv2.CreateRuleOutput, error) {
+	defer c.invalidate(LoadBalancerArn(StringValue(i.ListenerArn)))
+	return c.CloudAPI.CreateRuleWithContext(ctx, i)
+}
+
+func (c *cachedCloud) ModifyRuleWithContext(ctx context.Context, i *elbv2.ModifyRuleInput) (*elbv2.ModifyRuleOutput, error) {
+	defer c.invalidate(LoadBalancerArn(StringValue(i.RuleArn)))
+	return c.CloudAPI.ModifyRuleWithContext(ctx, i)
+}
+
+func (c *cachedCloud) DeleteRuleWithContext(ctx context.Context, i *elbv2.DeleteRuleInput) (*elbv2.DeleteRuleOutput, error) {
+	defer c.invalidate(LoadBalancerArn(StringValue(i.RuleArn)))
+	return c.CloudAPI.DeleteRuleWithContext(ctx, i)
+}
+
+func (c *cachedCloud) AddELBV2TagsWithContext(ctx context.Context, i *elbv2.AddTagsInput) (*elbv2.AddTagsOutput, error) {
+	defer c.invalidate(StringValueSlice(i.ResourceArns)...)
+	return c.CloudAPI.AddELBV2TagsWithContext(ctx, i)
+}
+
+func (c *cachedCloud) RemoveELBV2TagsWithContext(ctx context.Context, i *elbv2.RemoveTagsInput) (*elbv2.RemoveTagsOutput, error) {
+	defer c.invalidate(StringValueSlice(i.ResourceArns)...)
+	return c.CloudAPI.RemoveELBV2TagsWithContext(ctx, i)
+}
+
+func (c *cachedCloud) ModifyTargetGroupWithContext(ctx context.Context, i *elbv2.ModifyTargetGroupInput) (*elbv2.ModifyTargetGroupOutput, error) {
+	defer c.invalidate(StringValue(i.TargetGroupArn))
+	return c.CloudAPI.ModifyTargetGroupWithContext(ctx, i)
+}
+
+func (c *cachedCloud) DeleteTargetGroupByArn(ctx context.Context, arn string) error {
+	defer c.invalidate(arn)
+	return c.CloudAPI.DeleteTargetGroupByArn(ctx, arn)
+}
+
+func (c *cachedCloud) RegisterTargetsWithContext(ctx context.Context, i *elbv2.RegisterTargetsInput) (*elbv2.RegisterTargetsOutput, error) {
+	defer c.invalidate(StringValue(i.TargetGroupArn))
+	return c.CloudAPI.RegisterTargetsWithContext(ctx, i)
+}
+
+func (c *cachedCloud) DeregisterTargetsWithContext(ctx context.Context, i *elbv2.DeregisterTargetsInput) (*elbv2.DeregisterTargetsOutput, error) {
+	defer c.invalidate(StringValue(i.TargetGroupArn))
+	return c.CloudAPI.DeregisterTargetsWithContext(ctx, i)
+}
+
+// describedLoadBalancer wraps instance, which may be nil, into a response that can be cached.
+func describedLoadBalancer(instance *elbv2.LoadBalancer) *elbv2.DescribeLoadBalancersOutput {
+	if instance == nil {
+		return &elbv2.DescribeLoadBalancersOutput{}
+	}
+	return &elbv2.DescribeLoadBalancersOutput{LoadBalancers: []*elbv2.LoadBalancer{instance}}
+}
+
+// loadBalancerScopes returns the scopes of a response describing instance, which was looked up by scope.
+// LoadBalancers that are provisioning aren't cached, so that they're seen as active as soon as they are.
+func loadBalancerScopes(instance *elbv2.LoadBalancer, scope string) []string {
+	if instance == nil {
+		return []string{scope}
+	}
+	if instance.State == nil || StringValue(instance.State.Code) != elbv2.LoadBalancerStateEnumActive {
+		return nil
+	}
+	return []string{scope, StringValue(instance.LoadBalancerArn)}
+}
+
+func firstLoadBalancer(resp *elbv2.DescribeLoadBalancersOutput) *elbv2.LoadBalancer {
+	if len(resp.LoadBalancers) == 0 {
+		return nil
+	}
+	return resp.LoadBalancers[0]
+}
@@ -0,0 +1,103 @@
+package aws
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	cachedLBArn       = "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/my-lb/50dc6c495c0c9188"
+	cachedListenerArn = "arn:aws:elasticloadbalancing:us-west-2:123456789012:listener/app/my-lb/50dc6c495c0c9188/f2f7dc8efc522ab2"
+	cachedRuleArn     = "arn:aws:elasticloadbalancing:us-west-2:123456789012:listener-rule/app/my-lb/50dc6c495c0c9188/f2f7dc8efc522ab2/9683b2d02a6cabee"
+)
+
+func TestCachedCloud_GetRules(t *testing.T) {
+	ctx := context.Background()
+	inner := &mocks.CloudAPI{}
+	inner.On("GetRules", ctx, cachedListenerArn).Return([]*elbv2.Rule{{RuleArn: aws.String(cachedRuleArn), Priority: aws.String("1")}}, nil).Twice()
+	inner.On("ModifyRuleWithContext", ctx, &elbv2.ModifyRuleInput{RuleArn: aws.String(cachedRuleArn)}).Return(&elbv2.ModifyRuleOutput{}, nil)
+	cloud := newCachedCloud(inner, time.Minute, 0)
+
+	rules, err := cloud.GetRules(ctx, cachedListenerArn)
+	assert.NoError(t, err)
+	// callers modifying responses don't modify the cached ones.
+	rules[0].Priority = aws.String("2")
+	rules, err = cloud.GetRules(ctx, cachedListenerArn)
+	assert.NoError(t, err)
+	assert.Equal(t, "1", aws.StringValue(rules[0].Priority))
+
+	_, err = cloud.ModifyRuleWithContext(ctx, &elbv2.ModifyRuleInput{RuleArn: aws.String(cachedRuleArn)})
+	assert.NoError(t, err)
+	_, err = cloud.GetRules(ctx, cachedListenerArn)
+	assert.NoError(t, err)
+	inner.AssertExpectations(t)
+}
+
+func TestCachedCloud_GetLoadBalancerByArn(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		state         string
+		expectedCalls int
+	}{
+		{
+			name:          "active LoadBalancers are cached",
+			state:         elbv2.LoadBalancerStateEnumActive,
+			expectedCalls: 1,
+		},
+		{
+			name:          "provisioning LoadBalancers aren't cached",
+			state:         elbv2.LoadBalancerStateEnumProvisioning,
+			expectedCalls: 2,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			inner := &mocks.CloudAPI{}
+			inner.On("GetLoadBalancerByArn", ctx, cachedLBArn).Return(&elbv2.LoadBalancer{
+				LoadBalancerArn: aws.String(cachedLBArn),
+				State:           &elbv2.LoadBalancerState{Code: aws.String(tc.state)},
+			}, nil).Times(tc.expectedCalls)
+			cloud := newCachedCloud(inner, time.Minute, 0)
+
+			for i := 0; i < 2; i++ {
+				instance, err := cloud.GetLoadBalancerByArn(ctx, cachedLBArn)
+				assert.NoError(t, err)
+				assert.Equal(t, cachedLBArn, aws.StringValue(instance.LoadBalancerArn))
+			}
+			inner.AssertExpectations(t)
+		})
+	}
+}
+
+func TestResponseCache(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache := newResponseCache(time.Minute)
+	cache.now = func() time.Time { return now }
+	fetches := 0
+	fetch := func() (interface{}, []string, error) {
+		fetches++
+		return &elbv2.DescribeTagsOutput{}, []string{"tgArn"}, nil
+	}
+
+	_, _ = cache.load("key", fetch)
+	_, _ = cache.load("key", fetch)
+	assert.Equal(t, 1, fetches)
+
+	cache.invalidate("otherArn")
+	_, _ = cache.load("key", fetch)
+	assert.Equal(t, 1, fetches)
+
+	cache.invalidate("tgArn")
+	_, _ = cache.load("key", fetch)
+	assert.Equal(t, 2, fetches)
+
+	now = now.Add(time.Minute)
+	_, _ = cache.load("key", fetch)
+	assert.Equal(t, 3, fetches)
+}
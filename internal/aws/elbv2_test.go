@@ -3,12 +3,14 @@ package aws
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -40,7 +42,11 @@ func TestCloud_StatusELBV2(t *testing.T) {
 			}
 
 			err := cloud.StatusELBV2()()
-			assert.Equal(t, tc.ExpectedError, err)
+			if tc.ExpectedError != nil {
+				assert.EqualError(t, err, tc.ExpectedError.Error())
+			} else {
+				assert.NoError(t, err)
+			}
 			elbv2svc.AssertExpectations(t)
 		})
 	}
@@ -310,6 +316,101 @@ func TestCloud_GetLoadBalancerByName(t *testing.T) {
 	}
 }
 
+func TestCloud_GetLoadBalancersByTags(t *testing.T) {
+	lb1 := &elbv2.LoadBalancer{LoadBalancerArn: aws.String("lbArn1")}
+	lb2 := &elbv2.LoadBalancer{LoadBalancerArn: aws.String("lbArn2")}
+	tagFilters := map[string][]string{"ingress.k8s.aws/stack": {"namespace/ingress"}}
+	for _, tc := range []struct {
+		Name                  string
+		TaggedArns            []string
+		DescribeCalls         map[string]error
+		ExpectedLoadBalancers []*elbv2.LoadBalancer
+		ExpectedError         error
+	}{
+		{
+			Name:       "Tagged load balancers are returned",
+			TaggedArns: []string{"lbArn1", "lbArn2"},
+			DescribeCalls: map[string]error{
+				"lbArn1,lbArn2": nil,
+			},
+			ExpectedLoadBalancers: []*elbv2.LoadBalancer{lb1, lb2},
+		},
+		{
+			Name: "No tagged load balancers",
+		},
+		{
+			Name:       "Deleted load balancers are ignored",
+			TaggedArns: []string{"lbArn1", "lbArn2"},
+			DescribeCalls: map[string]error{
+				"lbArn1,lbArn2": awserr.New(elbv2.ErrCodeLoadBalancerNotFoundException, "not found!", nil),
+				"lbArn1":        nil,
+				"lbArn2":        awserr.New(elbv2.ErrCodeLoadBalancerNotFoundException, "not found!", nil),
+			},
+			ExpectedLoadBalancers: []*elbv2.LoadBalancer{lb1},
+		},
+		{
+			Name:       "API throws an error",
+			TaggedArns: []string{"lbArn1"},
+			DescribeCalls: map[string]error{
+				"lbArn1": awserr.New(request.ErrCodeResponseTimeout, "timeout", nil),
+			},
+			ExpectedError: awserr.New(request.ErrCodeResponseTimeout, "timeout", nil),
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			ctx := context.Background()
+			rgtsvc := &mocks.ResourceGroupsTaggingAPIAPI{}
+			elbv2svc := &mocks.ELBV2API{}
+
+			var mappings []*resourcegroupstaggingapi.ResourceTagMapping
+			for _, arn := range tc.TaggedArns {
+				mappings = append(mappings, &resourcegroupstaggingapi.ResourceTagMapping{ResourceARN: aws.String(arn)})
+			}
+			rgtsvc.On("GetResourcesPages",
+				&resourcegroupstaggingapi.GetResourcesInput{
+					ResourceTypeFilters: aws.StringSlice([]string{ResourceTypeEnumELBLoadBalancer}),
+					TagFilters: []*resourcegroupstaggingapi.TagFilter{
+						{Key: aws.String("ingress.k8s.aws/stack"), Values: aws.StringSlice([]string{"namespace/ingress"})},
+					},
+				},
+				mock.AnythingOfType("func(*resourcegroupstaggingapi.GetResourcesOutput, bool) bool"),
+			).Return(nil).Run(func(args mock.Arguments) {
+				arg := args.Get(1).(func(*resourcegroupstaggingapi.GetResourcesOutput, bool) bool)
+				arg(&resourcegroupstaggingapi.GetResourcesOutput{ResourceTagMappingList: mappings}, true)
+			})
+			for arns, err := range tc.DescribeCalls {
+				var loadBalancers []*elbv2.LoadBalancer
+				if err == nil {
+					for _, lb := range []*elbv2.LoadBalancer{lb1, lb2} {
+						if strings.Contains(arns, aws.StringValue(lb.LoadBalancerArn)) {
+							loadBalancers = append(loadBalancers, lb)
+						}
+					}
+				}
+				elbv2svc.On("DescribeLoadBalancersPages",
+					&elbv2.DescribeLoadBalancersInput{
+						LoadBalancerArns: aws.StringSlice(strings.Split(arns, ",")),
+					},
+					mock.AnythingOfType("func(*elbv2.DescribeLoadBalancersOutput, bool) bool"),
+				).Return(err).Run(func(args mock.Arguments) {
+					arg := args.Get(1).(func(*elbv2.DescribeLoadBalancersOutput, bool) bool)
+					arg(&elbv2.DescribeLoadBalancersOutput{LoadBalancers: loadBalancers}, true)
+				})
+			}
+
+			cloud := &Cloud{
+				elbv2: elbv2svc,
+				rgt:   rgtsvc,
+			}
+			loadBalancers, err := cloud.GetLoadBalancersByTags(ctx, tagFilters)
+			assert.Equal(t, tc.ExpectedLoadBalancers, loadBalancers)
+			assert.Equal(t, tc.ExpectedError, err)
+			rgtsvc.AssertExpectations(t)
+			elbv2svc.AssertExpectations(t)
+		})
+	}
+}
+
 func TestCloud_DeleteLoadBalancerByArn(t *testing.T) {
 	t.Run("Delete a load balancer", func(t *testing.T) {
 		lbArn := "loadbalancerArn"
@@ -12,6 +12,9 @@ import (
 type IAMAPI interface {
 	// StatusIAM validates IAM  connectivity
 	StatusIAM() func() error
+
+	// ListServerCertificates returns the metadata of all server certificates in IAM.
+	ListServerCertificates(ctx context.Context) ([]*iam.ServerCertificateMetadata, error)
 }
 
 // Status validates IAM connectivity
@@ -20,8 +23,17 @@ func (c *Cloud) StatusIAM() func() error {
 		in := &iam.ListServerCertificatesInput{MaxItems: aws.Int64(1)}
 
 		if _, err := c.iam.ListServerCertificatesWithContext(context.TODO(), in); err != nil {
-			return fmt.Errorf("[iam.ListServerCertificatesWithContext]: %v", err)
+			return fmt.Errorf("[iam.ListServerCertificatesWithContext]: %w", err)
 		}
 		return nil
 	}
 }
+
+func (c *Cloud) ListServerCertificates(ctx context.Context) ([]*iam.ServerCertificateMetadata, error) {
+	var result []*iam.ServerCertificateMetadata
+	err := c.iam.ListServerCertificatesPagesWithContext(ctx, &iam.ListServerCertificatesInput{}, func(output *iam.ListServerCertificatesOutput, _ bool) bool {
+		result = append(result, output.ServerCertificateMetadataList...)
+		return true
+	})
+	return result, err
+}
@@ -37,7 +37,11 @@ func TestCloud_StatusIAM(t *testing.T) {
 			}
 
 			err := cloud.StatusIAM()()
-			assert.Equal(t, tc.ExpectedError, err)
+			if tc.ExpectedError != nil {
+				assert.EqualError(t, err, tc.ExpectedError.Error())
+			} else {
+				assert.NoError(t, err)
+			}
 			iamsvc.AssertExpectations(t)
 		})
 	}
@@ -0,0 +1,42 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/lambda"
+)
+
+type LambdaAPI interface {
+	// AddLambdaInvokePermission allows principal to invoke the function from sourceArn, it's a no-op if a permission with statementID exists.
+	AddLambdaInvokePermission(ctx context.Context, functionArn string, statementID string, principal string, sourceArn string) error
+
+	// RemoveLambdaInvokePermission removes the permission with statementID from the function, if it exists.
+	RemoveLambdaInvokePermission(ctx context.Context, functionArn string, statementID string) error
+}
+
+func (c *Cloud) AddLambdaInvokePermission(ctx context.Context, functionArn string, statementID string, principal string, sourceArn string) error {
+	_, err := c.lambda.AddPermissionWithContext(ctx, &lambda.AddPermissionInput{
+		FunctionName: aws.String(functionArn),
+		StatementId:  aws.String(statementID),
+		Action:       aws.String("lambda:InvokeFunction"),
+		Principal:    aws.String(principal),
+		SourceArn:    aws.String(sourceArn),
+	})
+	if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == lambda.ErrCodeResourceConflictException {
+		return nil
+	}
+	return err
+}
+
+func (c *Cloud) RemoveLambdaInvokePermission(ctx context.Context, functionArn string, statementID string) error {
+	_, err := c.lambda.RemovePermissionWithContext(ctx, &lambda.RemovePermissionInput{
+		FunctionName: aws.String(functionArn),
+		StatementId:  aws.String(statementID),
+	})
+	if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == lambda.ErrCodeResourceNotFoundException {
+		return nil
+	}
+	return err
+}
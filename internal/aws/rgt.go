@@ -11,6 +11,7 @@ const (
 	ResourceTypeEnumELBLoadBalancer  = "elasticloadbalancing:loadbalancer"
 	ResourceTypeEnumELBTargetGroup   = "elasticloadbalancing:targetgroup"
 	ResourceTypeEnumEC2SecurityGroup = "ec2:security-group"
+	ResourceTypeEnumEC2ElasticIP     = "ec2:elastic-ip"
 )
 
 type ResourceGroupsTaggingAPIAPI interface {
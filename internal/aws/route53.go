@@ -0,0 +1,75 @@
+package aws
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/route53"
+)
+
+// Route53API is our wrapper Route53 API interface
+type Route53API interface {
+	// GetResourceRecordSets returns the record sets in hostedZoneID that have the specified recordName.
+	GetResourceRecordSets(ctx context.Context, hostedZoneID string, recordName string) ([]*route53.ResourceRecordSet, error)
+
+	// ListHostedZones returns all hosted zones of the account.
+	ListHostedZones(ctx context.Context) ([]*route53.HostedZone, error)
+
+	// ListAllResourceRecordSets returns all record sets in hostedZoneID.
+	ListAllResourceRecordSets(ctx context.Context, hostedZoneID string) ([]*route53.ResourceRecordSet, error)
+
+	ChangeResourceRecordSetsWithContext(context.Context, *route53.ChangeResourceRecordSetsInput) (*route53.ChangeResourceRecordSetsOutput, error)
+}
+
+func (c *Cloud) ChangeResourceRecordSetsWithContext(ctx context.Context, i *route53.ChangeResourceRecordSetsInput) (*route53.ChangeResourceRecordSetsOutput, error) {
+	return c.route53.ChangeResourceRecordSetsWithContext(ctx, i)
+}
+
+func (c *Cloud) GetResourceRecordSets(ctx context.Context, hostedZoneID string, recordName string) ([]*route53.ResourceRecordSet, error) {
+	fqdn := canonicalRecordName(recordName)
+	var result []*route53.ResourceRecordSet
+	err := c.route53.ListResourceRecordSetsPagesWithContext(ctx, &route53.ListResourceRecordSetsInput{
+		HostedZoneId:    aws.String(hostedZoneID),
+		StartRecordName: aws.String(fqdn),
+	}, func(output *route53.ListResourceRecordSetsOutput, _ bool) bool {
+		for _, recordSet := range output.ResourceRecordSets {
+			// record sets are sorted by name, so there won't be any match after the first different name.
+			if canonicalRecordName(aws.StringValue(recordSet.Name)) != fqdn {
+				return false
+			}
+			result = append(result, recordSet)
+		}
+		return true
+	})
+	return result, err
+}
+
+func (c *Cloud) ListHostedZones(ctx context.Context) ([]*route53.HostedZone, error) {
+	var result []*route53.HostedZone
+	err := c.route53.ListHostedZonesPagesWithContext(ctx, &route53.ListHostedZonesInput{}, func(output *route53.ListHostedZonesOutput, _ bool) bool {
+		result = append(result, output.HostedZones...)
+		return true
+	})
+	return result, err
+}
+
+func (c *Cloud) ListAllResourceRecordSets(ctx context.Context, hostedZoneID string) ([]*route53.ResourceRecordSet, error) {
+	var result []*route53.ResourceRecordSet
+	err := c.route53.ListResourceRecordSetsPagesWithContext(ctx, &route53.ListResourceRecordSetsInput{
+		HostedZoneId: aws.String(hostedZoneID),
+	}, func(output *route53.ListResourceRecordSetsOutput, _ bool) bool {
+		result = append(result, output.ResourceRecordSets...)
+		return true
+	})
+	return result, err
+}
+
+// canonicalRecordName returns the fully qualified, lower cased form of a DNS name as returned by Route53.
+func canonicalRecordName(name string) string {
+	name = strings.ToLower(name)
+	if !strings.HasSuffix(name, ".") {
+		name = name + "."
+	}
+	return name
+}
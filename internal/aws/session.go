@@ -2,27 +2,54 @@ package aws
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/golang/glog"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/metric"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/tracing"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/log"
 	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
-// NewSession returns an AWS session based off of the provided AWS config
-func NewSession(awsconfig *aws.Config, AWSDebug bool, mc metric.Collector) *session.Session {
+// NewSession returns an AWS session based off of the provided AWS config, mutating requests are not sent when dryRun is set.
+// Once a request is throttled, requests to the same service back off for up to throttleMaxBackoff.
+func NewSession(awsconfig *aws.Config, AWSDebug bool, dryRun bool, throttleMaxBackoff time.Duration, mc metric.Collector) *session.Session {
 	session, err := session.NewSession(awsconfig)
 	if err != nil {
-		mc.IncAPIErrorCount(prometheus.Labels{"service": "AWS", "request": "NewSession"})
+		mc.IncAPIErrorCount(prometheus.Labels{"service": "AWS", "operation": "NewSession", "error_code": errorCode(err)})
 		glog.ErrorDepth(4, fmt.Sprintf("Failed to create AWS session: %s", err.Error()))
 		return nil
 	}
 
+	session.Handlers.Validate.PushFront(func(r *request.Request) {
+		ctx, _ := tracing.Start(r.Context(), fmt.Sprintf("%s/%s", r.ClientInfo.ServiceName, r.Operation.Name),
+			attribute.String("aws.service", r.ClientInfo.ServiceName),
+			attribute.String("aws.operation", r.Operation.Name),
+		)
+		r.SetContext(ctx)
+	})
+	session.Handlers.Validate.PushBack(newDryRunHandler(dryRun))
+
+	backoff := newThrottleBackoff(throttleMaxBackoff)
+	session.Handlers.Sign.PushFront(func(r *request.Request) {
+		if err := backoff.wait(r.Context(), r.ClientInfo.ServiceName); err != nil {
+			r.Error = err
+		}
+	})
+
 	session.Handlers.Retry.PushFront(func(r *request.Request) {
 		mc.IncAPIRetryCount(prometheus.Labels{"service": r.ClientInfo.ServiceName, "operation": r.Operation.Name})
+		if request.IsErrorThrottle(r.Error) {
+			mc.IncAPIThrottleCount(prometheus.Labels{"service": r.ClientInfo.ServiceName, "operation": r.Operation.Name})
+			backoff.throttled(r.ClientInfo.ServiceName)
+		}
 	})
 
 	session.Handlers.Send.PushFront(func(r *request.Request) {
@@ -33,16 +60,44 @@ func NewSession(awsconfig *aws.Config, AWSDebug bool, mc metric.Collector) *sess
 	})
 
 	session.Handlers.Complete.PushFront(func(r *request.Request) {
-		if r.Error != nil {
-			mc.IncAPIErrorCount(prometheus.Labels{"service": r.ClientInfo.ServiceName, "operation": r.Operation.Name})
+		mc.ObserveAPIRequestDuration(prometheus.Labels{"service": r.ClientInfo.ServiceName, "operation": r.Operation.Name}, time.Since(r.Time))
+		if r.Error != nil && !IsDryRunError(r.Error) {
+			mc.IncAPIErrorCount(prometheus.Labels{"service": r.ClientInfo.ServiceName, "operation": r.Operation.Name, "error_code": errorCode(r.Error)})
+			if failures := albctx.GetAWSFailures(r.Context()); failures != nil {
+				operation := fmt.Sprintf("%s/%s", r.ClientInfo.ServiceName, r.Operation.Name)
+				if request.IsErrorThrottle(r.Error) {
+					failures.RecordThrottled(operation, r.Error)
+				} else {
+					failures.Record(operation, r.Error)
+				}
+			}
 			if AWSDebug {
 				glog.ErrorDepth(4, fmt.Sprintf("Failed request: %s/%s, Payload: %s, Error: %s", r.ClientInfo.ServiceName, r.Operation.Name, log.Prettify(r.Params), r.Error))
 			}
 		} else {
+			if r.Error == nil {
+				backoff.succeeded(r.ClientInfo.ServiceName)
+				if failures := albctx.GetAWSFailures(r.Context()); failures != nil {
+					failures.RecordSucceeded()
+				}
+			}
 			if AWSDebug {
 				glog.InfoDepth(4, fmt.Sprintf("Response: %s/%s, Body: %s", r.ClientInfo.ServiceName, r.Operation.Name, log.Prettify(r.Data)))
 			}
 		}
 	})
+	session.Handlers.Complete.PushBack(func(r *request.Request) {
+		span := trace.SpanFromContext(r.Context())
+		span.SetAttributes(attribute.Int("aws.retries", r.RetryCount))
+		tracing.End(span, r.Error)
+	})
 	return session
 }
+
+// errorCode returns the AWS error code of err, e.g. Throttling, so that throttled requests can be told apart from other failures.
+func errorCode(err error) string {
+	if awsErr, ok := err.(awserr.Error); ok {
+		return awsErr.Code()
+	}
+	return "Unknown"
+}
@@ -0,0 +1,60 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/aws/aws-sdk-go/service/shield"
+)
+
+type ShieldAPI interface {
+	GetShieldProtection(ctx context.Context, resourceArn *string) (*shield.Protection, error)
+	CreateShieldProtection(ctx context.Context, name *string, resourceArn *string) (*shield.CreateProtectionOutput, error)
+	DeleteShieldProtection(ctx context.Context, protectionID *string) (*shield.DeleteProtectionOutput, error)
+}
+
+// shieldRegion returns the region of the Shield Advanced API for resources in region.
+// The API is global to the partition of region, e.g. us-east-1 for the aws partition, and regional in partitions it isn't known to be global in.
+func shieldRegion(region string) string {
+	partition, ok := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), region)
+	if !ok {
+		return region
+	}
+	endpoint, err := partition.EndpointFor(shield.EndpointsID, region)
+	if err != nil || endpoint.SigningRegion == "" {
+		return region
+	}
+	return endpoint.SigningRegion
+}
+
+// GetShieldProtection returns the Shield Advanced protection of resource, or nil if it isn't protected.
+func (c *Cloud) GetShieldProtection(ctx context.Context, resourceArn *string) (*shield.Protection, error) {
+	result, err := c.shield.DescribeProtectionWithContext(ctx, &shield.DescribeProtectionInput{
+		ResourceArn: resourceArn,
+	})
+
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == shield.ErrCodeResourceNotFoundException {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return result.Protection, nil
+}
+
+// CreateShieldProtection enables Shield Advanced protection of resource.
+func (c *Cloud) CreateShieldProtection(ctx context.Context, name *string, resourceArn *string) (*shield.CreateProtectionOutput, error) {
+	return c.shield.CreateProtectionWithContext(ctx, &shield.CreateProtectionInput{
+		Name:        name,
+		ResourceArn: resourceArn,
+	})
+}
+
+// DeleteShieldProtection disables Shield Advanced protection.
+func (c *Cloud) DeleteShieldProtection(ctx context.Context, protectionID *string) (*shield.DeleteProtectionOutput, error) {
+	return c.shield.DeleteProtectionWithContext(ctx, &shield.DeleteProtectionInput{
+		ProtectionId: protectionID,
+	})
+}
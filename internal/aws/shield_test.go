@@ -0,0 +1,23 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_shieldRegion(t *testing.T) {
+	for _, tc := range []struct {
+		region   string
+		expected string
+	}{
+		{region: "us-east-1", expected: "us-east-1"},
+		{region: "eu-west-1", expected: "us-east-1"},
+		{region: "cn-north-1", expected: "cn-north-1"},
+		{region: "us-gov-west-1", expected: "us-gov-west-1"},
+	} {
+		t.Run(tc.region, func(t *testing.T) {
+			assert.Equal(t, tc.expected, shieldRegion(tc.region))
+		})
+	}
+}
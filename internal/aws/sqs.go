@@ -0,0 +1,24 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/service/sqs"
+)
+
+// SQSAPI is our wrapper SQS API interface
+type SQSAPI interface {
+	// ReceiveSQSMessagesWithContext receives messages of a queue, long polling if requested.
+	ReceiveSQSMessagesWithContext(context.Context, *sqs.ReceiveMessageInput) (*sqs.ReceiveMessageOutput, error)
+
+	// DeleteSQSMessageWithContext deletes a received message from its queue.
+	DeleteSQSMessageWithContext(context.Context, *sqs.DeleteMessageInput) (*sqs.DeleteMessageOutput, error)
+}
+
+func (c *Cloud) ReceiveSQSMessagesWithContext(ctx context.Context, i *sqs.ReceiveMessageInput) (*sqs.ReceiveMessageOutput, error) {
+	return c.sqs.ReceiveMessageWithContext(ctx, i)
+}
+
+func (c *Cloud) DeleteSQSMessageWithContext(ctx context.Context, i *sqs.DeleteMessageInput) (*sqs.DeleteMessageOutput, error) {
+	return c.sqs.DeleteMessageWithContext(ctx, i)
+}
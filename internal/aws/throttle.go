@@ -0,0 +1,110 @@
+package aws
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+)
+
+// throttleMinBackoff is the backoff after the first throttled request to a service, it doubles up to the max backoff while requests keep being throttled.
+const throttleMinBackoff = 250 * time.Millisecond
+
+// throttleBackoff delays requests to an AWS service while it throttles the controller. AWS rate limits are per account and service,
+// so all workers back off together instead of each retrying on its own schedule and prolonging the throttling.
+type throttleBackoff struct {
+	maxBackoff time.Duration
+	now        func() time.Time
+
+	mutex    sync.Mutex
+	services map[string]*serviceBackoff
+}
+
+type serviceBackoff struct {
+	backoff time.Duration
+	until   time.Time
+}
+
+// newThrottleBackoff constructs a throttleBackoff whose backoff never exceeds maxBackoff, it's disabled when maxBackoff is zero.
+func newThrottleBackoff(maxBackoff time.Duration) *throttleBackoff {
+	return &throttleBackoff{
+		maxBackoff: maxBackoff,
+		now:        time.Now,
+		services:   make(map[string]*serviceBackoff),
+	}
+}
+
+// throttled records that a request to service was throttled. The backoff doubles unless requests are already backing off,
+// since those throttled meanwhile were sent before it started.
+func (b *throttleBackoff) throttled(service string) {
+	if b.maxBackoff == 0 {
+		return
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	now := b.now()
+	s, ok := b.services[service]
+	if !ok {
+		s = &serviceBackoff{}
+		b.services[service] = s
+	}
+	if now.Before(s.until) {
+		return
+	}
+	s.backoff *= 2
+	if s.backoff < throttleMinBackoff {
+		s.backoff = throttleMinBackoff
+	}
+	if s.backoff > b.maxBackoff {
+		s.backoff = b.maxBackoff
+	}
+	// equal jitter, so that waiting requests aren't all sent at once.
+	s.until = now.Add(s.backoff/2 + time.Duration(rand.Int63n(int64(s.backoff/2)+1)))
+}
+
+// succeeded records that a request to service succeeded, which halves the backoff until it's cleared.
+func (b *throttleBackoff) succeeded(service string) {
+	if b.maxBackoff == 0 {
+		return
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	s, ok := b.services[service]
+	if !ok {
+		return
+	}
+	s.backoff /= 2
+	if s.backoff < throttleMinBackoff {
+		delete(b.services, service)
+	}
+}
+
+// delay returns how long requests to service must wait before being sent.
+func (b *throttleBackoff) delay(service string) time.Duration {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	s, ok := b.services[service]
+	if !ok {
+		return 0
+	}
+	return s.until.Sub(b.now())
+}
+
+// wait blocks until requests to service can be sent, or ctx is done.
+func (b *throttleBackoff) wait(ctx context.Context, service string) error {
+	delay := b.delay(service)
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return awserr.New(request.CanceledErrorCode, "request context canceled while backing off from throttling", ctx.Err())
+	}
+}
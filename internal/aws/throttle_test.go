@@ -0,0 +1,75 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/metric"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThrottleBackoff(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	backoff := newThrottleBackoff(time.Second)
+	backoff.now = func() time.Time { return now }
+
+	backoff.throttled("elasticloadbalancing")
+	delay := backoff.delay("elasticloadbalancing")
+	assert.True(t, delay >= throttleMinBackoff/2 && delay <= throttleMinBackoff, "delay %v", delay)
+	assert.Equal(t, time.Duration(0), backoff.delay("ec2"))
+
+	// requests throttled while backing off were sent before it started.
+	backoff.throttled("elasticloadbalancing")
+	assert.Equal(t, delay, backoff.delay("elasticloadbalancing"))
+
+	for i := 0; i < 5; i++ {
+		now = now.Add(time.Second)
+		backoff.throttled("elasticloadbalancing")
+	}
+	delay = backoff.delay("elasticloadbalancing")
+	assert.True(t, delay >= time.Second/2 && delay <= time.Second, "delay %v", delay)
+
+	for i := 0; i < 3; i++ {
+		backoff.succeeded("elasticloadbalancing")
+	}
+	assert.Equal(t, time.Duration(0), backoff.delay("elasticloadbalancing"))
+}
+
+func TestNewSession_throttled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`<ErrorResponse><Error><Type>Sender</Type><Code>Throttling</Code><Message>Rate exceeded</Message></Error><RequestId>id</RequestId></ErrorResponse>`))
+	}))
+	defer server.Close()
+	session := NewSession(&aws.Config{
+		Region:      aws.String("us-west-2"),
+		Endpoint:    aws.String(server.URL),
+		Credentials: credentials.NewStaticCredentials("id", "secret", ""),
+		MaxRetries:  aws.Int(0),
+	}, false, false, time.Minute, metric.DummyCollector{})
+	svc := elbv2.New(session)
+	failures := &albctx.AWSFailures{}
+	ctx := albctx.SetAWSFailures(context.Background(), failures)
+
+	_, err := svc.DescribeLoadBalancersWithContext(ctx, &elbv2.DescribeLoadBalancersInput{})
+	assert.Error(t, err)
+	assert.Equal(t, "elasticloadbalancing/DescribeLoadBalancers", failures.ThrottledCause(fmt.Errorf("failed to find LoadBalancer due to %w", err)))
+	assert.Equal(t, "", failures.ThrottledCause(errors.New("failed to get ingress")))
+	// throttling doesn't count towards the circuit breaker.
+	assert.Equal(t, "", failures.FailedOperation(err))
+
+	// further requests back off until ctx is done.
+	cancelCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = svc.DescribeLoadBalancersWithContext(cancelCtx, &elbv2.DescribeLoadBalancersInput{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,63 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/aws/aws-sdk-go/service/wafv2"
+)
+
+type WAFV2API interface {
+	GetWAFv2WebACLForResource(ctx context.Context, resourceArn *string) (*wafv2.WebACL, error)
+	AssociateWAFv2(ctx context.Context, resourceArn *string, webACLArn *string) (*wafv2.AssociateWebACLOutput, error)
+	DisassociateWAFv2(ctx context.Context, resourceArn *string) (*wafv2.DisassociateWebACLOutput, error)
+
+	// WAFv2Available whether WAFv2 service are available.
+	WAFv2Available() bool
+}
+
+// GetWAFv2WebACLForResource returns the WAFv2 webACL associated with resource, or nil if there is none.
+func (c *Cloud) GetWAFv2WebACLForResource(ctx context.Context, resourceArn *string) (*wafv2.WebACL, error) {
+	result, err := c.wafv2.GetWebACLForResourceWithContext(ctx, &wafv2.GetWebACLForResourceInput{
+		ResourceArn: resourceArn,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result.WebACL, nil
+}
+
+// AssociateWAFv2 WAFv2 webACL to resource.
+func (c *Cloud) AssociateWAFv2(ctx context.Context, resourceArn *string, webACLArn *string) (*wafv2.AssociateWebACLOutput, error) {
+	result, err := c.wafv2.AssociateWebACLWithContext(ctx, &wafv2.AssociateWebACLInput{
+		ResourceArn: resourceArn,
+		WebACLArn:   webACLArn,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// DisassociateWAFv2 WAFv2 webACL from resource.
+func (c *Cloud) DisassociateWAFv2(ctx context.Context, resourceArn *string) (*wafv2.DisassociateWebACLOutput, error) {
+	result, err := c.wafv2.DisassociateWebACLWithContext(ctx, &wafv2.DisassociateWebACLInput{
+		ResourceArn: resourceArn,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (c *Cloud) WAFv2Available() bool {
+	resolver := endpoints.DefaultResolver()
+	_, err := resolver.EndpointFor(wafv2.EndpointsID, c.region, endpoints.StrictMatchingOption)
+	return err == nil
+}
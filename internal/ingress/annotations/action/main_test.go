@@ -145,6 +145,36 @@ func TestInvalidIngressActions(t *testing.T) {
 			actionJSON:  `{"Type": "forward", "TargetGroupArn": "tg-1", "ForwardConfig": {"TargetGroups": [{"TargetGroupArn": "tg-2", "weight": 10}]}}`,
 			expectedErr: "precisely one of TargetGroupArn and ForwardConfig can be specified",
 		},
+		{
+			name:        "should error if both ServiceName and LambdaFunctionArn are specified for TargetGroupTuple",
+			actionJSON:  `{"Type": "forward", "ForwardConfig": {"TargetGroups": [{"ServiceName": "svc", "ServicePort": "80", "LambdaFunctionArn": "arn:aws:lambda:us-west-2:123456789012:function:fn"}]}}`,
+			expectedErr: "invalid ForwardConfig: invalid TargetGroupTuple: precisely one of TargetGroupArn, ServiceName and LambdaFunctionArn can be specified",
+		},
+		{
+			name:        "should error if LambdaFunctionArn isn't the ARN of a Lambda function",
+			actionJSON:  `{"Type": "forward", "ForwardConfig": {"TargetGroups": [{"LambdaFunctionArn": "fn"}]}}`,
+			expectedErr: "invalid ForwardConfig: invalid TargetGroupTuple: invalid LambdaFunctionArn: fn",
+		},
+		{
+			name:        "should error if Enabled absent for TargetGroupStickinessConfig",
+			actionJSON:  `{"Type": "forward", "ForwardConfig": {"TargetGroups": [{"TargetGroupArn": "tg-1", "weight": 90}, {"TargetGroupArn": "tg-2", "weight": 10}], "TargetGroupStickinessConfig": {"DurationSeconds": 100}}}`,
+			expectedErr: "invalid ForwardConfig: invalid TargetGroupStickinessConfig: Enabled is required",
+		},
+		{
+			name:        "should error if DurationSeconds absent for enabled TargetGroupStickinessConfig",
+			actionJSON:  `{"Type": "forward", "ForwardConfig": {"TargetGroups": [{"TargetGroupArn": "tg-1", "weight": 90}, {"TargetGroupArn": "tg-2", "weight": 10}], "TargetGroupStickinessConfig": {"Enabled": true}}}`,
+			expectedErr: "invalid ForwardConfig: invalid TargetGroupStickinessConfig: DurationSeconds is required when Enabled is true",
+		},
+		{
+			name:        "should error if DurationSeconds out of range for TargetGroupStickinessConfig",
+			actionJSON:  `{"Type": "forward", "ForwardConfig": {"TargetGroups": [{"TargetGroupArn": "tg-1", "weight": 90}, {"TargetGroupArn": "tg-2", "weight": 10}], "TargetGroupStickinessConfig": {"Enabled": true, "DurationSeconds": 604801}}}`,
+			expectedErr: "invalid ForwardConfig: invalid TargetGroupStickinessConfig: DurationSeconds must be within 1-604800, got 604801",
+		},
+		{
+			name:        "should error if DurationSeconds specified for disabled TargetGroupStickinessConfig",
+			actionJSON:  `{"Type": "forward", "ForwardConfig": {"TargetGroups": [{"TargetGroupArn": "tg-1", "weight": 90}, {"TargetGroupArn": "tg-2", "weight": 10}], "TargetGroupStickinessConfig": {"Enabled": false, "DurationSeconds": 100}}}`,
+			expectedErr: "invalid ForwardConfig: invalid TargetGroupStickinessConfig: DurationSeconds can only be specified when Enabled is true",
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			ing := dummy.NewIngress()
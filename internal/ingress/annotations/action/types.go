@@ -1,6 +1,7 @@
 package action
 
 import (
+	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
 	"github.com/pkg/errors"
@@ -88,6 +89,25 @@ type TargetGroupStickinessConfig struct {
 	Enabled *bool
 }
 
+func (c *TargetGroupStickinessConfig) validate() error {
+	if c.Enabled == nil {
+		return errors.New("Enabled is required")
+	}
+	if !aws.BoolValue(c.Enabled) {
+		if c.DurationSeconds != nil {
+			return errors.New("DurationSeconds can only be specified when Enabled is true")
+		}
+		return nil
+	}
+	if c.DurationSeconds == nil {
+		return errors.New("DurationSeconds is required when Enabled is true")
+	}
+	if duration := aws.Int64Value(c.DurationSeconds); duration < 1 || duration > 604800 {
+		return errors.Errorf("DurationSeconds must be within 1-604800, got %v", duration)
+	}
+	return nil
+}
+
 // Information about how traffic will be distributed between multiple target
 // groups in a forward rule.
 type TargetGroupTuple struct {
@@ -100,13 +120,28 @@ type TargetGroupTuple struct {
 	// the K8s service port
 	ServicePort *string
 
+	// The Amazon Resource Name (ARN) of a Lambda function, which is invoked through a targetGroup
+	// managed by the controller.
+	LambdaFunctionArn *string
+
 	// The weight. The range is 0 to 999.
 	Weight *int64
 }
 
 func (t *TargetGroupTuple) validate() error {
-	if (t.TargetGroupArn != nil) == (t.ServiceName != nil) {
-		return errors.New("precisely one of TargetGroupArn and ServiceName can be specified")
+	specified := 0
+	for _, field := range []*string{t.TargetGroupArn, t.ServiceName, t.LambdaFunctionArn} {
+		if field != nil {
+			specified++
+		}
+	}
+	if specified != 1 {
+		return errors.New("precisely one of TargetGroupArn, ServiceName and LambdaFunctionArn can be specified")
+	}
+	if t.LambdaFunctionArn != nil {
+		if parsed, err := arn.Parse(*t.LambdaFunctionArn); err != nil || parsed.Service != "lambda" {
+			return errors.Errorf("invalid LambdaFunctionArn: %v", *t.LambdaFunctionArn)
+		}
 	}
 
 	if t.ServiceName != nil && t.ServicePort == nil {
@@ -138,6 +173,11 @@ func (c *ForwardActionConfig) validate() error {
 			}
 		}
 	}
+	if c.TargetGroupStickinessConfig != nil {
+		if err := c.TargetGroupStickinessConfig.validate(); err != nil {
+			return errors.Wrap(err, "invalid TargetGroupStickinessConfig")
+		}
+	}
 	return nil
 }
 
@@ -33,8 +33,10 @@ import (
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/action"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/conditions"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/healthcheck"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/ipfilter"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/loadbalancer"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/priority"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/tags"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/targetgroup"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/errors"
@@ -44,11 +46,14 @@ import (
 
 // Ingress defines the valid annotations present in one AWS ALB Ingress rule
 type Ingress struct {
-	// TODO: found out why the ObjectMeta is needed?
+	// ObjectMeta only holds the namespace and name of the ingress, which key the annotations in store.
+	// The rest of the metadata is already cached by the informer and isn't copied here.
 	metav1.ObjectMeta
 	Action       *action.Config
 	Conditions   *conditions.Config
 	HealthCheck  *healthcheck.Config
+	IPFilter     *ipfilter.Config
+	Priority     *priority.Config
 	TargetGroup  *targetgroup.Config
 	LoadBalancer *loadbalancer.Config
 	Tags         *tags.Config
@@ -74,6 +79,8 @@ func (s *Service) Merge(b *Ingress, cfg *config.Configuration) *Service {
 		ObjectMeta:   s.ObjectMeta,
 		Action:       s.Action,
 		Conditions:   s.Conditions,
+		IPFilter:     s.IPFilter,
+		Priority:     s.Priority,
 		LoadBalancer: s.LoadBalancer,
 		Tags:         s.Tags,
 		Error:        s.Error,
@@ -102,6 +109,8 @@ func NewIngressAnnotationExtractor(cfg resolver.Resolver) Extractor {
 			"Action":       action.NewParser(cfg),
 			"Conditions":   conditions.NewParser(),
 			"HealthCheck":  healthcheck.NewParser(cfg),
+			"IPFilter":     ipfilter.NewParser(),
+			"Priority":     priority.NewParser(),
 			"TargetGroup":  targetgroup.NewParser(cfg),
 			"LoadBalancer": loadbalancer.NewParser(cfg),
 			"Tags":         tags.NewParser(cfg),
@@ -123,7 +132,7 @@ func NewServiceAnnotationExtractor(cfg resolver.Resolver) Extractor {
 // ExtractIngress extracts the annotations from an Ingress
 func (e Extractor) ExtractIngress(ing *extensions.Ingress) *Ingress {
 	pia := &Ingress{
-		ObjectMeta: ing.ObjectMeta,
+		ObjectMeta: keyMeta(ing),
 	}
 
 	i, err := e.extract(pia, ing)
@@ -134,13 +143,21 @@ func (e Extractor) ExtractIngress(ing *extensions.Ingress) *Ingress {
 // ExtractService extracts the annotations from a Service
 func (e Extractor) ExtractService(svc *corev1.Service) *Service {
 	psa := &Service{
-		ObjectMeta: svc.ObjectMeta,
+		ObjectMeta: keyMeta(svc),
 	}
 	s, err := e.extract(psa, svc)
 	psa.Error = err
 	return s.(*Service)
 }
 
+// keyMeta returns the metadata of o needed to key its annotations in store.
+func keyMeta(o metav1.Object) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Namespace: o.GetNamespace(),
+		Name:      o.GetName(),
+	}
+}
+
 // Extract extracts the annotations from metadata
 // TODO put kind in log message
 func (e Extractor) extract(dst interface{}, o metav1.Object) (interface{}, error) {
@@ -215,6 +232,21 @@ func LoadInt64Annotation(annotation string, value *int64, annotations ...map[str
 	return true, nil
 }
 
+// LoadBoolAnnotation loads annotation into value of type bool from list of annotations by priority.
+func LoadBoolAnnotation(annotation string, value *bool, annotations ...map[string]string) (bool, error) {
+	key := parser.GetAnnotationWithPrefix(annotation)
+	raw, ok := utils.MapFindFirst(key, annotations...)
+	if !ok {
+		return false, nil
+	}
+	b, err := strconv.ParseBool(raw)
+	if err != nil {
+		return true, pkgerrors.Wrapf(err, "failed to parse annotation, %v: %v", key, raw)
+	}
+	*value = b
+	return true, nil
+}
+
 // LoadInt64Annotation loads annotation into value of type JSON from list of annotations by priority.
 func LoadJSONAnnotation(annotation string, value interface{}, annotations ...map[string]string) (bool, error) {
 	key := parser.GetAnnotationWithPrefix(annotation)
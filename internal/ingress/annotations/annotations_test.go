@@ -131,6 +131,23 @@ func TestHealthCheck(t *testing.T) {
 	}
 }
 
+func TestExtractIngress_keepsOnlyKeyMeta(t *testing.T) {
+	ec := Extractor{
+		map[string]parser.IngressAnnotation{
+			"HealthCheck": healthcheck.NewParser(mockCfg{}),
+		},
+	}
+	ing := buildIngress()
+	ing.SetAnnotations(map[string]string{annotationHealthcheckIntervalSeconds: "15"})
+	ing.SetLabels(map[string]string{"app": "foo"})
+	ing.SetUID("uid")
+
+	r := ec.ExtractIngress(ing)
+	assert.NoError(t, r.Error)
+	assert.Equal(t, metav1.ObjectMeta{Name: "foo", Namespace: apiv1.NamespaceDefault}, r.ObjectMeta)
+	assert.Equal(t, int64(15), *r.HealthCheck.IntervalSeconds)
+}
+
 func TestMerge(t *testing.T) {
 	for _, tc := range []struct {
 		Source         *Service
@@ -40,6 +40,14 @@ func IsValidIngress(ingressClass string, ingress *extensions.Ingress) bool {
 	return actualIngressClass == ingressClass
 }
 
+// GetIngressClass returns the class of ingress, ingresses without class annotation are of the `alb` class.
+func GetIngressClass(ingress *extensions.Ingress) string {
+	if ingressClass := ingress.GetAnnotations()[annotationKubernetesIngressClass]; ingressClass != "" {
+		return ingressClass
+	}
+	return defaultIngressClass
+}
+
 // TODO: change this to in-sync with https://github.com/kubernetes/kubernetes/blob/13705ac81e00f154434b5c66c1ad92ac84960d7f/pkg/controller/service/service_controller.go#L592(relies on node's ready condition instead of AWS API)
 // IsValidNode returns true if the given Node has valid annotations
 func IsValidNode(n *corev1.Node) bool {
@@ -128,6 +128,11 @@ func TestConditionsParse_Invalid(t *testing.T) {
 			conditionsJSON: `[{"Field": "host-header"}]`,
 			expectedErr:    "missing HostHeaderConfig",
 		},
+		{
+			name:           "should error if host-header value contains invalid characters",
+			conditionsJSON: `[{"Field": "host-header", "HostHeaderConfig": {"Values": ["www_example.com"]}}]`,
+			expectedErr:    "invalid HostHeaderConfig: host www_example.com contains invalid character '_'",
+		},
 		{
 			name:           "should error if PathPatternConfig absent for path-pattern condition",
 			conditionsJSON: `[{"Field": "path-pattern"}]`,
@@ -1,6 +1,8 @@
 package conditions
 
 import (
+	"strings"
+
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
 	"github.com/pkg/errors"
 )
@@ -29,6 +31,28 @@ func (c *HostHeaderConditionConfig) validate() error {
 	if len(c.Values) == 0 {
 		return errors.New("Values cannot be empty")
 	}
+	for _, value := range c.Values {
+		if err := ValidateHost(aws.StringValue(value)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// maxHostLength is the maximum size of host names in host-header conditions.
+const maxHostLength = 128
+
+// ValidateHost checks whether host is valid in host-header conditions. Besides letters, digits, `-` and `.`,
+// hosts may contain the wildcards `*`(matches 0 or more characters) and `?`(matches exactly 1 character).
+func ValidateHost(host string) error {
+	if len(host) > maxHostLength {
+		return errors.Errorf("host %v is longer than %v characters", host, maxHostLength)
+	}
+	for _, r := range host {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("-.*?", r)) {
+			return errors.Errorf("host %v contains invalid character %q", host, r)
+		}
+	}
 	return nil
 }
 
@@ -23,12 +23,15 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/targetgroup"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/errors"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/resolver"
 )
 
 const (
-	DefaultPath            = "/"
+	DefaultPath = "/"
+	// DefaultGRPCPath is the gRPC method AWS checks the health of GRPC targetGroups with.
+	DefaultGRPCPath        = "/AWS.ALB/healthcheck"
 	DefaultPort            = "traffic-port"
 	DefaultIntervalSeconds = 15
 	DefaultTimeoutSeconds  = 5
@@ -68,6 +71,9 @@ func (hc healthCheck) Parse(ing parser.AnnotationInterface) (interface{}, error)
 	path, err := parser.GetStringAnnotation("healthcheck-path", ing)
 	if err != nil {
 		path = aws.String(DefaultPath)
+		if protocolVersion, err := parser.GetStringAnnotation("backend-protocol-version", ing); err == nil && *protocolVersion == targetgroup.ProtocolVersionGRPC {
+			path = aws.String(DefaultGRPCPath)
+		}
 	}
 
 	port, err := parser.GetStringAnnotation("healthcheck-port", ing)
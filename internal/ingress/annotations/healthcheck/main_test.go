@@ -92,6 +92,22 @@ func TestIngressHealthCheck(t *testing.T) {
 	}
 }
 
+func TestIngressHealthCheck_grpc(t *testing.T) {
+	ing := buildIngress()
+	ing.SetAnnotations(map[string]string{parser.GetAnnotationWithPrefix("backend-protocol-version"): "GRPC"})
+	hzi, err := NewParser(mockBackend{}).Parse(ing)
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultGRPCPath, aws.StringValue(hzi.(*Config).Path))
+
+	ing.SetAnnotations(map[string]string{
+		parser.GetAnnotationWithPrefix("backend-protocol-version"): "GRPC",
+		parser.GetAnnotationWithPrefix("healthcheck-path"):         "/helloworld.Greeter/SayHello",
+	})
+	hzi, err = NewParser(mockBackend{}).Parse(ing)
+	assert.NoError(t, err)
+	assert.Equal(t, "/helloworld.Greeter/SayHello", aws.StringValue(hzi.(*Config).Path))
+}
+
 func TestMerge(t *testing.T) {
 	for _, tc := range []struct {
 		Source         *Config
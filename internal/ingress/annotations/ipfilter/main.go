@@ -0,0 +1,86 @@
+package ipfilter
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/errors"
+	pkgerrors "github.com/pkg/errors"
+)
+
+// AnyHost is the host of filter applied to ingress rules without a filter of their own host.
+const AnyHost = "*"
+
+// Filter restricts the source IPs of requests to a host.
+type Filter struct {
+	// Allow are the only CIDRs allowed to access the host, all sources are allowed if empty.
+	Allow []string
+
+	// Deny are the CIDRs denied from accessing the host, which takes precedence over Allow.
+	Deny []string
+}
+
+func (f *Filter) validate() error {
+	if len(f.Allow) == 0 && len(f.Deny) == 0 {
+		return fmt.Errorf("at least one of Allow and Deny must be specified")
+	}
+	for _, cidr := range append(append([]string(nil), f.Allow...), f.Deny...) {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return fmt.Errorf("invalid CIDR %v", cidr)
+		}
+	}
+	return nil
+}
+
+type Config struct {
+	// Filters are the filters keyed by host of ingress rules.
+	Filters map[string]Filter
+}
+
+// NewParser creates a new ip filter annotation parser
+func NewParser() parser.IngressAnnotation {
+	return &ipFilterParser{}
+}
+
+type ipFilterParser struct {
+}
+
+// Parse parses the annotations contained in the resource
+func (p *ipFilterParser) Parse(ing parser.AnnotationInterface) (interface{}, error) {
+	raw, err := parser.GetStringAnnotation("ip-filters", ing)
+	if err != nil {
+		if errors.IsMissingAnnotations(err) {
+			return &Config{}, nil
+		}
+		return nil, err
+	}
+
+	filters := make(map[string]Filter)
+	if err := json.Unmarshal([]byte(*raw), &filters); err != nil {
+		return nil, pkgerrors.Wrap(err, "failed to parse ip-filters")
+	}
+	for host, filter := range filters {
+		if err := filter.validate(); err != nil {
+			return nil, pkgerrors.Wrapf(err, "invalid ip filter for host %v", host)
+		}
+	}
+	return &Config{
+		Filters: filters,
+	}, nil
+}
+
+// GetFilter returns the filter applied to ingress rules of host, or nil if there is none.
+func (c *Config) GetFilter(host string) *Filter {
+	if c == nil {
+		return nil
+	}
+	if filter, ok := c.Filters[host]; ok {
+		return &filter
+	}
+	if filter, ok := c.Filters[AnyHost]; ok {
+		return &filter
+	}
+	return nil
+}
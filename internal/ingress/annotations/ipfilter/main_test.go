@@ -0,0 +1,87 @@
+package ipfilter
+
+import (
+	"testing"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/dummy"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIPFilterParse(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		filtersJSON *string
+		expected    *Config
+		expectedErr string
+	}{
+		{
+			name:     "annotation absent",
+			expected: &Config{},
+		},
+		{
+			name:        "allow and deny",
+			filtersJSON: stringPtr(`{"www.example.com": {"Allow": ["10.0.0.0/8"], "Deny": ["10.1.0.0/16"]}, "*": {"Deny": ["192.168.0.0/16", "2001:db8::/32"]}}`),
+			expected: &Config{
+				Filters: map[string]Filter{
+					"www.example.com": {Allow: []string{"10.0.0.0/8"}, Deny: []string{"10.1.0.0/16"}},
+					"*":               {Deny: []string{"192.168.0.0/16", "2001:db8::/32"}},
+				},
+			},
+		},
+		{
+			name:        "malformed JSON",
+			filtersJSON: stringPtr(`{"www.example.com": ["10.0.0.0/8"]}`),
+			// the rest of the message comes from encoding/json, whose wording changes between Go versions.
+			expectedErr: "failed to parse ip-filters: ",
+		},
+		{
+			name:        "empty filter",
+			filtersJSON: stringPtr(`{"www.example.com": {}}`),
+			expectedErr: "invalid ip filter for host www.example.com: at least one of Allow and Deny must be specified",
+		},
+		{
+			name:        "invalid CIDR",
+			filtersJSON: stringPtr(`{"www.example.com": {"Allow": ["10.0.0.1"]}}`),
+			expectedErr: "invalid ip filter for host www.example.com: invalid CIDR 10.0.0.1",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ing := dummy.NewIngress()
+			data := map[string]string{}
+			if tc.filtersJSON != nil {
+				data[parser.GetAnnotationWithPrefix("ip-filters")] = *tc.filtersJSON
+			}
+			ing.SetAnnotations(data)
+			cfg, err := NewParser().Parse(ing)
+			if tc.expectedErr != "" {
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tc.expectedErr)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, cfg)
+			}
+		})
+	}
+}
+
+func TestConfig_GetFilter(t *testing.T) {
+	cfg := &Config{
+		Filters: map[string]Filter{
+			"www.example.com": {Allow: []string{"10.0.0.0/8"}},
+			"*":               {Deny: []string{"192.168.0.0/16"}},
+		},
+	}
+	assert.Equal(t, &Filter{Allow: []string{"10.0.0.0/8"}}, cfg.GetFilter("www.example.com"))
+	assert.Equal(t, &Filter{Deny: []string{"192.168.0.0/16"}}, cfg.GetFilter("api.example.com"))
+	assert.Equal(t, &Filter{Deny: []string{"192.168.0.0/16"}}, cfg.GetFilter(""))
+	assert.Nil(t, (&Config{}).GetFilter("www.example.com"))
+
+	var nilCfg *Config
+	assert.Nil(t, nilCfg.GetFilter("www.example.com"))
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
@@ -20,6 +20,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net"
+	"strconv"
 	"strings"
 
 	"github.com/golang/glog"
@@ -29,6 +30,7 @@ import (
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/errors"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/resolver"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 type PortData struct {
@@ -36,16 +38,136 @@ type PortData struct {
 	Scheme string
 }
 
+// FailoverConfig configures an active/passive pair of ALBs behind Route 53 failover records.
+type FailoverConfig struct {
+	HostedZoneID string
+	RecordName   string
+
+	// Subnets of the secondary ALB, defaults to the subnets of the primary ALB.
+	Subnets []string
+}
+
+// StaticIPConfig configures a NetworkLoadBalancer with Elastic IPs in front of the ALB.
+type StaticIPConfig struct {
+	// AllocationIDs of the Elastic IPs, one per subnet. Elastic IPs are allocated by the controller when empty.
+	AllocationIDs []string
+
+	// EndpointService exposes the NetworkLoadBalancer over PrivateLink when set.
+	EndpointService *EndpointServiceConfig
+}
+
+// EndpointServiceConfig configures the VPC endpoint service of the NetworkLoadBalancer.
+type EndpointServiceConfig struct {
+	// AcceptanceRequired specifies whether connection requests to the endpoint service must be accepted manually.
+	AcceptanceRequired bool
+	// AllowedPrincipals are the ARNs of principals allowed to discover and connect to the endpoint service.
+	AllowedPrincipals []string
+}
+
+// ExistingLoadBalancerConfig points an ingress at an ALB created outside of the controller, instead of creating one.
+type ExistingLoadBalancerConfig struct {
+	// NameOrArn identifies the existing ALB.
+	NameOrArn string
+	// Scope is the part of the existing ALB managed by the controller, AdoptionScopeListeners or AdoptionScopeLoadBalancer.
+	Scope string
+}
+
 type Config struct {
 	Scheme        *string
 	IPAddressType *string
 
 	InboundCidrs   []string
 	InboundV6CIDRs []string
-	Ports          []PortData
-	SecurityGroups []string
-	Subnets        []string
-	Attributes     []*elbv2.LoadBalancerAttribute
+	// InboundPrefixLists are the IDs of managed prefix lists allowed to access LoadBalancer, along with InboundCidrs and InboundV6CIDRs.
+	InboundPrefixLists []string
+	// InboundCIDRsByPort are the CIDRs and prefix lists allowed to access specific listen ports, instead of the ones above.
+	InboundCIDRsByPort map[int64][]string
+	Ports              []PortData
+	SecurityGroups     []string
+	Subnets            []string
+	Attributes         []*elbv2.LoadBalancerAttribute
+
+	// ShardMaxRules enables sharding of the ingress across multiple ALBs when set,
+	// limiting the number of rules placed on each ALB.
+	ShardMaxRules *int64
+	// ShardMaxCertificates limits the number of TLS hosts placed on each ALB when sharding.
+	ShardMaxCertificates *int64
+
+	Failover *FailoverConfig
+
+	// ActiveStack selects the stack(blue or green) serving traffic for blue/green deployments of the ALB.
+	ActiveStack *string
+
+	StaticIP *StaticIPConfig
+
+	// ExistingLoadBalancer adopts an existing ALB when set.
+	ExistingLoadBalancer *ExistingLoadBalancerConfig
+
+	// DeletionPolicy decides whether the ALB is deleted along with the ingress, DeletionPolicyDelete or DeletionPolicyRetain.
+	DeletionPolicy string
+
+	// DegradedThreshold is the number or percentage of unhealthy targets, above which the ingress is Degraded.
+	// Target health is not evaluated when nil.
+	DegradedThreshold *intstr.IntOrString
+
+	// VerificationSuccessCodes are the status codes expected from synthetic requests against the LoadBalancers after reconcile.
+	// Requests are not issued when nil.
+	VerificationSuccessCodes StatusCodes
+
+	// ExternalRulePriorities are the rule priorities reserved for rules managed outside of the controller.
+	// Rules at these priorities are never changed by the controller.
+	ExternalRulePriorities PriorityRanges
+
+	// SSLRedirect is the HTTPS listen port that HTTP listeners redirect requests to, instead of routing them by rules.
+	SSLRedirect *int64
+
+	// DryRun plans the AWS operations to reconcile the ingress without performing them.
+	DryRun bool
+
+	// DriftRemediation decides whether drift found by periodic drift detection is corrected, DriftRemediationEnforce or DriftRemediationWarn.
+	DriftRemediation string
+
+	// Paused skips AWS mutations of the ingress, the operations that would converge its AWS resources are reported as drift instead.
+	// Unlike DryRun, deleting the ingress doesn't delete its AWS resources until it's resumed.
+	Paused bool
+}
+
+// PriorityRanges are rule priorities, as a list of single priorities or ranges.
+type PriorityRanges []PriorityRange
+
+// PriorityRange is an inclusive range of rule priorities.
+type PriorityRange struct {
+	From int
+	To   int
+}
+
+// Contains returns whether priority is within ranges.
+func (ranges PriorityRanges) Contains(priority int) bool {
+	for _, priorityRange := range ranges {
+		if priority >= priorityRange.From && priority <= priorityRange.To {
+			return true
+		}
+	}
+	return false
+}
+
+// StatusCodes are HTTP status codes, as a list of single codes or ranges.
+type StatusCodes []StatusCodeRange
+
+// StatusCodeRange is an inclusive range of HTTP status codes.
+type StatusCodeRange struct {
+	From int
+	To   int
+}
+
+// Matches returns whether code is one of codes.
+func (codes StatusCodes) Matches(code int) bool {
+	for _, codeRange := range codes {
+		if code >= codeRange.From && code <= codeRange.To {
+			return true
+		}
+	}
+	return false
 }
 
 type loadBalancer struct {
@@ -55,6 +177,32 @@ type loadBalancer struct {
 const (
 	DefaultIPAddressType = elbv2.IpAddressTypeIpv4
 	DefaultScheme        = elbv2.LoadBalancerSchemeEnumInternal
+
+	StackBlue  = "blue"
+	StackGreen = "green"
+
+	// AdoptionScopeListeners manages the listeners on listen-ports of an existing ALB, together with their rules and targetGroups.
+	AdoptionScopeListeners = "listeners"
+	// AdoptionScopeLoadBalancer manages every listener of an existing ALB, as well as its subnets, IP address type, attributes, WAF and Shield.
+	AdoptionScopeLoadBalancer = "load-balancer"
+
+	// DeletionPolicyDelete deletes the ALB and its targetGroups along with the ingress.
+	DeletionPolicyDelete = "Delete"
+	// DeletionPolicyRetain keeps the ALB and its targetGroups once the ingress is deleted, only removing their ownership tags.
+	DeletionPolicyRetain = "Retain"
+
+	// DriftRemediationEnforce reconciles the ingress once its AWS resources drifted from it.
+	DriftRemediationEnforce = "Enforce"
+	// DriftRemediationWarn only reports drift of the AWS resources of the ingress.
+	DriftRemediationWarn = "Warn"
+
+	// ReconcileActive reconciles the AWS resources of the ingress.
+	ReconcileActive = "active"
+	// ReconcilePaused freezes the AWS resources of the ingress.
+	ReconcilePaused = "paused"
+
+	// DefaultShardMaxCertificates is the number of certificates an ALB listener supports besides the default one.
+	DefaultShardMaxCertificates = 25
 )
 
 // NewParser creates a new target group annotation parser
@@ -95,7 +243,98 @@ func (lb loadBalancer) Parse(ing parser.AnnotationInterface) (interface{}, error
 	securityGroups := parser.GetStringSliceAnnotation("security-groups", ing)
 	subnets := parser.GetStringSliceAnnotation("subnets", ing)
 
-	v4CIDRs, v6CIDRs, err := parseCidrs(ing)
+	v4CIDRs, v6CIDRs, prefixLists, cidrsByPort, err := parseCidrs(ing, ports)
+	if err != nil {
+		return nil, err
+	}
+
+	shardMaxRules, shardMaxCertificates, err := parseShardLimits(ing)
+	if err != nil {
+		return nil, err
+	}
+
+	failover, err := parseFailover(ing)
+	if err != nil {
+		return nil, err
+	}
+	if failover != nil && shardMaxRules != nil {
+		return nil, errors.NewInvalidAnnotationContentReason("failover cannot be used together with sharding")
+	}
+
+	activeStack, err := parser.GetStringAnnotation("active-stack", ing)
+	if err == nil {
+		if *activeStack != StackBlue && *activeStack != StackGreen {
+			return nil, errors.NewInvalidAnnotationContentReason(fmt.Sprintf("active-stack must be either `%v` or `%v`", StackBlue, StackGreen))
+		}
+		if failover != nil || shardMaxRules != nil {
+			return nil, errors.NewInvalidAnnotationContentReason("active-stack cannot be used together with failover or sharding")
+		}
+	}
+
+	staticIP, err := parseStaticIP(ing)
+	if err != nil {
+		return nil, err
+	}
+	if staticIP == nil {
+		if endpointService, err := parser.GetBoolAnnotation("endpoint-service", ing); err == nil && *endpointService {
+			return nil, errors.NewInvalidAnnotationContentReason("endpoint-service requires static-ip to be enabled")
+		}
+	} else {
+		if *scheme != elbv2.LoadBalancerSchemeEnumInternetFacing {
+			return nil, errors.NewInvalidAnnotationContentReason(fmt.Sprintf("static-ip requires scheme to be `%v`", elbv2.LoadBalancerSchemeEnumInternetFacing))
+		}
+		if failover != nil || shardMaxRules != nil || activeStack != nil {
+			return nil, errors.NewInvalidAnnotationContentReason("static-ip cannot be used together with failover, sharding or active-stack")
+		}
+	}
+
+	existingLoadBalancer, err := parseExistingLoadBalancer(ing)
+	if err != nil {
+		return nil, err
+	}
+	if existingLoadBalancer != nil && (failover != nil || shardMaxRules != nil || activeStack != nil || staticIP != nil) {
+		return nil, errors.NewInvalidAnnotationContentReason("existing-load-balancer cannot be used together with failover, sharding, active-stack or static-ip")
+	}
+
+	deletionPolicy, err := parseDeletionPolicy(ing)
+	if err != nil {
+		return nil, err
+	}
+	if deletionPolicy == DeletionPolicyRetain && (failover != nil || shardMaxRules != nil || activeStack != nil || staticIP != nil) {
+		return nil, errors.NewInvalidAnnotationContentReason(fmt.Sprintf("deletion-policy `%v` cannot be used together with failover, sharding, active-stack or static-ip", DeletionPolicyRetain))
+	}
+
+	degradedThreshold, err := parseDegradedThreshold(ing)
+	if err != nil {
+		return nil, err
+	}
+
+	verificationSuccessCodes, err := parseVerificationSuccessCodes(ing)
+	if err != nil {
+		return nil, err
+	}
+
+	externalRulePriorities, err := parseExternalRulePriorities(ing)
+	if err != nil {
+		return nil, err
+	}
+
+	sslRedirect, err := parseSSLRedirect(ing, ports)
+	if err != nil {
+		return nil, err
+	}
+
+	var dryRun bool
+	if v, err := parser.GetBoolAnnotation("dry-run", ing); err == nil {
+		dryRun = *v
+	}
+
+	paused, err := parsePaused(ing)
+	if err != nil {
+		return nil, err
+	}
+
+	driftRemediation, err := parseDriftRemediation(ing)
 	if err != nil {
 		return nil, err
 	}
@@ -104,16 +343,287 @@ func (lb loadBalancer) Parse(ing parser.AnnotationInterface) (interface{}, error
 		Scheme:        scheme,
 		IPAddressType: ipAddressType,
 
-		Attributes:     attributes,
-		InboundCidrs:   v4CIDRs,
-		InboundV6CIDRs: v6CIDRs,
-		Ports:          ports,
+		Attributes:         attributes,
+		InboundCidrs:       v4CIDRs,
+		InboundV6CIDRs:     v6CIDRs,
+		InboundPrefixLists: prefixLists,
+		InboundCIDRsByPort: cidrsByPort,
+		Ports:              ports,
 
 		Subnets:        subnets,
 		SecurityGroups: securityGroups,
+
+		ShardMaxRules:        shardMaxRules,
+		ShardMaxCertificates: shardMaxCertificates,
+
+		Failover:    failover,
+		ActiveStack: activeStack,
+		StaticIP:    staticIP,
+
+		ExistingLoadBalancer: existingLoadBalancer,
+		DeletionPolicy:       deletionPolicy,
+
+		DegradedThreshold:        degradedThreshold,
+		VerificationSuccessCodes: verificationSuccessCodes,
+		ExternalRulePriorities:   externalRulePriorities,
+		SSLRedirect:              sslRedirect,
+		DryRun:                   dryRun,
+		Paused:                   paused,
+		DriftRemediation:         driftRemediation,
 	}, nil
 }
 
+// parsePaused parses whether reconciliation of the ingress is paused, it's active unless `reconcile` is ReconcilePaused.
+func parsePaused(ing parser.AnnotationInterface) (bool, error) {
+	state, err := parser.GetStringAnnotation("reconcile", ing)
+	if err != nil {
+		return false, nil
+	}
+	if *state != ReconcileActive && *state != ReconcilePaused {
+		return false, errors.NewInvalidAnnotationContentReason(fmt.Sprintf("reconcile must be either `%v` or `%v`", ReconcileActive, ReconcilePaused))
+	}
+	return *state == ReconcilePaused, nil
+}
+
+// parseDriftRemediation parses how drift of the AWS resources of the ingress is remediated, which defaults to DriftRemediationEnforce.
+func parseDriftRemediation(ing parser.AnnotationInterface) (string, error) {
+	remediation, err := parser.GetStringAnnotation("drift-remediation", ing)
+	if err != nil {
+		return DriftRemediationEnforce, nil
+	}
+	if *remediation != DriftRemediationEnforce && *remediation != DriftRemediationWarn {
+		return "", errors.NewInvalidAnnotationContentReason(fmt.Sprintf("drift-remediation must be either `%v` or `%v`", DriftRemediationEnforce, DriftRemediationWarn))
+	}
+	return *remediation, nil
+}
+
+// parseDeletionPolicy parses the deletion policy of the ALB, which defaults to DeletionPolicyDelete.
+func parseDeletionPolicy(ing parser.AnnotationInterface) (string, error) {
+	deletionPolicy, err := parser.GetStringAnnotation("deletion-policy", ing)
+	if err != nil {
+		return DeletionPolicyDelete, nil
+	}
+	if *deletionPolicy != DeletionPolicyDelete && *deletionPolicy != DeletionPolicyRetain {
+		return "", errors.NewInvalidAnnotationContentReason(fmt.Sprintf("deletion-policy must be either `%v` or `%v`", DeletionPolicyDelete, DeletionPolicyRetain))
+	}
+	return *deletionPolicy, nil
+}
+
+// parseExistingLoadBalancer parses the existing ALB to adopt, no ALB is adopted(nil) unless `existing-load-balancer` is present.
+func parseExistingLoadBalancer(ing parser.AnnotationInterface) (*ExistingLoadBalancerConfig, error) {
+	nameOrArn, err := parser.GetStringAnnotation("existing-load-balancer", ing)
+	if err != nil {
+		return nil, nil
+	}
+	scope := AdoptionScopeListeners
+	if v, err := parser.GetStringAnnotation("existing-load-balancer-scope", ing); err == nil {
+		scope = strings.TrimSpace(*v)
+	}
+	if scope != AdoptionScopeListeners && scope != AdoptionScopeLoadBalancer {
+		return nil, errors.NewInvalidAnnotationContentReason(fmt.Sprintf("existing-load-balancer-scope must be either `%v` or `%v`", AdoptionScopeListeners, AdoptionScopeLoadBalancer))
+	}
+	return &ExistingLoadBalancerConfig{
+		NameOrArn: strings.TrimSpace(*nameOrArn),
+		Scope:     scope,
+	}, nil
+}
+
+// parseSSLRedirect parses the HTTPS port that HTTP listeners redirect to, which must be one of the HTTPS listen ports.
+func parseSSLRedirect(ing parser.AnnotationInterface, ports []PortData) (*int64, error) {
+	raw, err := parser.GetStringAnnotation("ssl-redirect", ing)
+	if err != nil {
+		return nil, nil
+	}
+	sslPort, err := strconv.ParseInt(strings.TrimSpace(*raw), 10, 64)
+	if err != nil {
+		return nil, errors.NewInvalidAnnotationContentReason(fmt.Sprintf("ssl-redirect must be a port, got `%v`", *raw))
+	}
+	for _, port := range ports {
+		if port.Scheme == elbv2.ProtocolEnumHttps && port.Port == sslPort {
+			return &sslPort, nil
+		}
+	}
+	return nil, errors.NewInvalidAnnotationContentReason(fmt.Sprintf("ssl-redirect port %v must be an HTTPS port in listen-ports", sslPort))
+}
+
+// parseExternalRulePriorities parses the rule priorities reserved for external rules, like `1-10,50000`.
+func parseExternalRulePriorities(ing parser.AnnotationInterface) (PriorityRanges, error) {
+	raw, err := parser.GetStringAnnotation("external-rule-priorities", ing)
+	if err != nil {
+		return nil, nil
+	}
+	var ranges PriorityRanges
+	for _, part := range strings.Split(*raw, ",") {
+		from, to, err := parseRange(part)
+		if err != nil || from < 1 || to > 50000 || from > to {
+			return nil, errors.NewInvalidAnnotationContentReason(fmt.Sprintf("external-rule-priorities must be priorities or ranges of priorities between 1 and 50000, got `%v`", part))
+		}
+		ranges = append(ranges, PriorityRange{From: from, To: to})
+	}
+	return ranges, nil
+}
+
+// parseRange parses a single integer like `200`, or an inclusive range of integers like `200-299`.
+func parseRange(raw string) (int, int, error) {
+	bounds := strings.SplitN(strings.TrimSpace(raw), "-", 2)
+	from, err := strconv.Atoi(strings.TrimSpace(bounds[0]))
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(bounds) == 1 {
+		return from, from, nil
+	}
+	to, err := strconv.Atoi(strings.TrimSpace(bounds[1]))
+	if err != nil {
+		return 0, 0, err
+	}
+	return from, to, nil
+}
+
+// parseVerificationSuccessCodes parses the status codes expected by verification requests, like `200,301-302`.
+// Verification is disabled(nil codes) unless `verification-success-codes` is present.
+func parseVerificationSuccessCodes(ing parser.AnnotationInterface) (StatusCodes, error) {
+	raw, err := parser.GetStringAnnotation("verification-success-codes", ing)
+	if err != nil {
+		return nil, nil
+	}
+	var codes StatusCodes
+	for _, part := range strings.Split(*raw, ",") {
+		from, to, err := parseRange(part)
+		if err != nil || from < 100 || to > 599 || from > to {
+			return nil, errors.NewInvalidAnnotationContentReason(fmt.Sprintf("verification-success-codes must be status codes or ranges of status codes, got `%v`", part))
+		}
+		codes = append(codes, StatusCodeRange{From: from, To: to})
+	}
+	return codes, nil
+}
+
+// parseDegradedThreshold parses the threshold of unhealthy targets, either a count like `3` or a percentage like `25%`.
+func parseDegradedThreshold(ing parser.AnnotationInterface) (*intstr.IntOrString, error) {
+	raw, err := parser.GetStringAnnotation("degraded-threshold", ing)
+	if err != nil {
+		return nil, nil
+	}
+	threshold := intstr.Parse(strings.TrimSpace(*raw))
+	if threshold.Type == intstr.Int {
+		if threshold.IntVal < 0 {
+			return nil, errors.NewInvalidAnnotationContentReason("degraded-threshold must not be negative")
+		}
+		return &threshold, nil
+	}
+	percent, err := strconv.Atoi(strings.TrimSuffix(threshold.StrVal, "%"))
+	if err != nil || !strings.HasSuffix(threshold.StrVal, "%") || percent < 0 || percent > 100 {
+		return nil, errors.NewInvalidAnnotationContentReason(fmt.Sprintf("degraded-threshold must be a count or a percentage between 0%% and 100%%, got `%v`", threshold.StrVal))
+	}
+	return &threshold, nil
+}
+
+// parseStaticIP parses the static IP configuration, static IP is disabled(nil) unless `static-ip` is true.
+func parseStaticIP(ing parser.AnnotationInterface) (*StaticIPConfig, error) {
+	enabled, err := parser.GetBoolAnnotation("static-ip", ing)
+	if err != nil {
+		if errors.IsMissingAnnotations(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if !*enabled {
+		return nil, nil
+	}
+
+	allocationIDs := parser.GetStringSliceAnnotation("static-ip-allocation-ids", ing)
+	for _, allocationID := range allocationIDs {
+		if !strings.HasPrefix(allocationID, "eipalloc-") {
+			return nil, errors.NewInvalidAnnotationContentReason(fmt.Sprintf("invalid Elastic IP allocation ID `%v`", allocationID))
+		}
+	}
+	endpointService, err := parseEndpointService(ing)
+	if err != nil {
+		return nil, err
+	}
+	return &StaticIPConfig{
+		AllocationIDs:   allocationIDs,
+		EndpointService: endpointService,
+	}, nil
+}
+
+// parseEndpointService parses the VPC endpoint service configuration, which is disabled(nil) unless `endpoint-service` is true.
+func parseEndpointService(ing parser.AnnotationInterface) (*EndpointServiceConfig, error) {
+	enabled, err := parser.GetBoolAnnotation("endpoint-service", ing)
+	if err != nil {
+		if errors.IsMissingAnnotations(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if !*enabled {
+		return nil, nil
+	}
+
+	acceptanceRequired, err := parser.GetBoolAnnotation("endpoint-service-acceptance-required", ing)
+	if err != nil {
+		if !errors.IsMissingAnnotations(err) {
+			return nil, err
+		}
+		acceptanceRequired = aws.Bool(true)
+	}
+	return &EndpointServiceConfig{
+		AcceptanceRequired: *acceptanceRequired,
+		AllowedPrincipals:  parser.GetStringSliceAnnotation("endpoint-service-allowed-principals", ing),
+	}, nil
+}
+
+// parseFailover parses the failover configuration, failover is disabled(nil) unless `failover-record-name` is present.
+func parseFailover(ing parser.AnnotationInterface) (*FailoverConfig, error) {
+	recordName, err := parser.GetStringAnnotation("failover-record-name", ing)
+	if err != nil {
+		if errors.IsMissingAnnotations(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	hostedZoneID, err := parser.GetStringAnnotation("failover-hosted-zone-id", ing)
+	if err != nil {
+		if errors.IsMissingAnnotations(err) {
+			return nil, errors.NewInvalidAnnotationContentReason("failover-hosted-zone-id must be specified when failover-record-name is present")
+		}
+		return nil, err
+	}
+
+	return &FailoverConfig{
+		HostedZoneID: *hostedZoneID,
+		RecordName:   *recordName,
+		Subnets:      parser.GetStringSliceAnnotation("failover-subnets", ing),
+	}, nil
+}
+
+// parseShardLimits parses the limits used to shard an ingress across multiple ALBs.
+// Sharding is disabled(nil limits) unless `shard-max-rules` is present.
+func parseShardLimits(ing parser.AnnotationInterface) (*int64, *int64, error) {
+	maxRules, err := parser.GetInt64Annotation("shard-max-rules", ing)
+	if err != nil {
+		if errors.IsMissingAnnotations(err) {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+	if *maxRules < 1 {
+		return nil, nil, errors.NewInvalidAnnotationContentReason("shard-max-rules must be greater than 0")
+	}
+
+	maxCertificates, err := parser.GetInt64Annotation("shard-max-certificates", ing)
+	if err != nil {
+		if !errors.IsMissingAnnotations(err) {
+			return nil, nil, err
+		}
+		maxCertificates = aws.Int64(DefaultShardMaxCertificates)
+	}
+	if *maxCertificates < 1 {
+		return nil, nil, errors.NewInvalidAnnotationContentReason("shard-max-certificates must be greater than 0")
+	}
+	return maxRules, maxCertificates, nil
+}
+
 func parseAttributes(ing parser.AnnotationInterface) ([]*elbv2.LoadBalancerAttribute, error) {
 	var badAttrs []string
 	var lbattrs []*elbv2.LoadBalancerAttribute
@@ -196,7 +706,8 @@ func parsePorts(ing parser.AnnotationInterface) ([]PortData, error) {
 	return lps, nil
 }
 
-func parseCidrs(ing parser.AnnotationInterface) (v4CIDRs, v6CIDRs []string, err error) {
+// parseCidrs parses CIDRs and prefix lists allowed to access all listen ports, and the ones allowed to access specific ports like `443=10.0.0.0/8`.
+func parseCidrs(ing parser.AnnotationInterface, ports []PortData) (v4CIDRs, v6CIDRs, prefixLists []string, cidrsByPort map[int64][]string, err error) {
 	cidrConfig := parser.GetStringSliceAnnotation("security-group-inbound-cidrs", ing)
 	if len(cidrConfig) != 0 {
 		glog.Warningf("`security-group-inbound-cidrs` annotation is deprecated, use `inbound-cidrs` instead")
@@ -205,19 +716,36 @@ func parseCidrs(ing parser.AnnotationInterface) (v4CIDRs, v6CIDRs []string, err
 	}
 
 	for _, inboundCidr := range cidrConfig {
-		_, _, err := net.ParseCIDR(inboundCidr)
-		if err != nil {
-			return v4CIDRs, v6CIDRs, err
+		if parts := strings.SplitN(inboundCidr, "=", 2); len(parts) == 2 {
+			port, err := strconv.ParseInt(strings.TrimSpace(parts[0]), 10, 64)
+			if err != nil || !isListenPort(ports, port) {
+				return nil, nil, nil, nil, errors.NewInvalidAnnotationContentReason(fmt.Sprintf("inbound-cidrs port %v must be a port in listen-ports", parts[0]))
+			}
+			cidr := strings.TrimSpace(parts[1])
+			if err := validateInboundSource(cidr); err != nil {
+				return nil, nil, nil, nil, err
+			}
+			if cidrsByPort == nil {
+				cidrsByPort = make(map[int64][]string)
+			}
+			cidrsByPort[port] = append(cidrsByPort[port], cidr)
+			continue
 		}
 
-		if strings.Contains(inboundCidr, ":") {
+		if err := validateInboundSource(inboundCidr); err != nil {
+			return v4CIDRs, v6CIDRs, prefixLists, nil, err
+		}
+
+		if IsPrefixListID(inboundCidr) {
+			prefixLists = append(prefixLists, inboundCidr)
+		} else if strings.Contains(inboundCidr, ":") {
 			v6CIDRs = append(v6CIDRs, inboundCidr)
 		} else {
 			v4CIDRs = append(v4CIDRs, inboundCidr)
 		}
 	}
 
-	if len(v4CIDRs) == 0 && len(v6CIDRs) == 0 {
+	if len(v4CIDRs) == 0 && len(v6CIDRs) == 0 && len(prefixLists) == 0 {
 		v4CIDRs = append(v4CIDRs, "0.0.0.0/0")
 
 		addrType, _ := parser.GetStringAnnotation("ip-address-type", ing)
@@ -226,7 +754,29 @@ func parseCidrs(ing parser.AnnotationInterface) (v4CIDRs, v6CIDRs []string, err
 		}
 	}
 
-	return v4CIDRs, v6CIDRs, nil
+	return v4CIDRs, v6CIDRs, prefixLists, cidrsByPort, nil
+}
+
+// IsPrefixListID returns whether an entry of inbound-cidrs is the ID of a managed prefix list instead of a CIDR.
+func IsPrefixListID(source string) bool {
+	return strings.HasPrefix(source, "pl-")
+}
+
+func validateInboundSource(source string) error {
+	if IsPrefixListID(source) {
+		return nil
+	}
+	_, _, err := net.ParseCIDR(source)
+	return err
+}
+
+func isListenPort(ports []PortData, port int64) bool {
+	for _, p := range ports {
+		if p.Port == port {
+			return true
+		}
+	}
+	return false
 }
 
 func Dummy() *Config {
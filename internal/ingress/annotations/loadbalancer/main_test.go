@@ -1 +1,273 @@
 package loadbalancer
+
+import (
+	"testing"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/dummy"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseVerificationSuccessCodes(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		codes       *string
+		expected    StatusCodes
+		expectedErr string
+	}{
+		{
+			name: "annotation absent",
+		},
+		{
+			name:     "codes and ranges",
+			codes:    stringPtr("200, 301-302"),
+			expected: StatusCodes{{From: 200, To: 200}, {From: 301, To: 302}},
+		},
+		{
+			name:        "invalid code",
+			codes:       stringPtr("2xx"),
+			expectedErr: "verification-success-codes must be status codes or ranges of status codes, got `2xx`",
+		},
+		{
+			name:        "inverted range",
+			codes:       stringPtr("399-200"),
+			expectedErr: "verification-success-codes must be status codes or ranges of status codes, got `399-200`",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ing := dummy.NewIngress()
+			data := map[string]string{}
+			if tc.codes != nil {
+				data[parser.GetAnnotationWithPrefix("verification-success-codes")] = *tc.codes
+			}
+			ing.SetAnnotations(data)
+			codes, err := parseVerificationSuccessCodes(ing)
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, codes)
+			}
+		})
+	}
+}
+
+func Test_parseExternalRulePriorities(t *testing.T) {
+	ing := dummy.NewIngress()
+	ing.SetAnnotations(map[string]string{
+		parser.GetAnnotationWithPrefix("external-rule-priorities"): "1-10, 50000",
+	})
+	ranges, err := parseExternalRulePriorities(ing)
+	assert.NoError(t, err)
+	assert.Equal(t, PriorityRanges{{From: 1, To: 10}, {From: 50000, To: 50000}}, ranges)
+	assert.True(t, ranges.Contains(10))
+	assert.False(t, ranges.Contains(11))
+
+	ing.SetAnnotations(map[string]string{
+		parser.GetAnnotationWithPrefix("external-rule-priorities"): "0-10",
+	})
+	_, err = parseExternalRulePriorities(ing)
+	assert.EqualError(t, err, "external-rule-priorities must be priorities or ranges of priorities between 1 and 50000, got `0-10`")
+}
+
+func Test_parseExistingLoadBalancer(t *testing.T) {
+	ing := dummy.NewIngress()
+	ing.SetAnnotations(map[string]string{
+		parser.GetAnnotationWithPrefix("existing-load-balancer"): "my-alb",
+	})
+	existing, err := parseExistingLoadBalancer(ing)
+	assert.NoError(t, err)
+	assert.Equal(t, &ExistingLoadBalancerConfig{NameOrArn: "my-alb", Scope: AdoptionScopeListeners}, existing)
+
+	ing.SetAnnotations(map[string]string{
+		parser.GetAnnotationWithPrefix("existing-load-balancer"):       "arn:aws:elasticloadbalancing:us-west-2:123456789012:loadbalancer/app/my-alb/50dc6c495c0c9188",
+		parser.GetAnnotationWithPrefix("existing-load-balancer-scope"): "load-balancer",
+	})
+	existing, err = parseExistingLoadBalancer(ing)
+	assert.NoError(t, err)
+	assert.Equal(t, AdoptionScopeLoadBalancer, existing.Scope)
+
+	ing.SetAnnotations(map[string]string{
+		parser.GetAnnotationWithPrefix("existing-load-balancer"):       "my-alb",
+		parser.GetAnnotationWithPrefix("existing-load-balancer-scope"): "rules",
+	})
+	_, err = parseExistingLoadBalancer(ing)
+	assert.EqualError(t, err, "existing-load-balancer-scope must be either `listeners` or `load-balancer`")
+
+	ing.SetAnnotations(map[string]string{})
+	existing, err = parseExistingLoadBalancer(ing)
+	assert.NoError(t, err)
+	assert.Nil(t, existing)
+}
+
+func Test_parsePaused(t *testing.T) {
+	for _, tc := range []struct {
+		value       string
+		expected    bool
+		expectedErr string
+	}{
+		{value: "paused", expected: true},
+		{value: "active", expected: false},
+		{value: "frozen", expectedErr: "reconcile must be either `active` or `paused`"},
+	} {
+		t.Run(tc.value, func(t *testing.T) {
+			ing := dummy.NewIngress()
+			ing.SetAnnotations(map[string]string{
+				parser.GetAnnotationWithPrefix("reconcile"): tc.value,
+			})
+			paused, err := parsePaused(ing)
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, paused)
+			}
+		})
+	}
+}
+
+func Test_parseDriftRemediation(t *testing.T) {
+	ing := dummy.NewIngress()
+	ing.SetAnnotations(map[string]string{})
+	remediation, err := parseDriftRemediation(ing)
+	assert.NoError(t, err)
+	assert.Equal(t, DriftRemediationEnforce, remediation)
+
+	ing.SetAnnotations(map[string]string{
+		parser.GetAnnotationWithPrefix("drift-remediation"): "Warn",
+	})
+	remediation, err = parseDriftRemediation(ing)
+	assert.NoError(t, err)
+	assert.Equal(t, DriftRemediationWarn, remediation)
+
+	ing.SetAnnotations(map[string]string{
+		parser.GetAnnotationWithPrefix("drift-remediation"): "Ignore",
+	})
+	_, err = parseDriftRemediation(ing)
+	assert.EqualError(t, err, "drift-remediation must be either `Enforce` or `Warn`")
+}
+
+func Test_parseDeletionPolicy(t *testing.T) {
+	ing := dummy.NewIngress()
+	ing.SetAnnotations(map[string]string{})
+	deletionPolicy, err := parseDeletionPolicy(ing)
+	assert.NoError(t, err)
+	assert.Equal(t, DeletionPolicyDelete, deletionPolicy)
+
+	ing.SetAnnotations(map[string]string{
+		parser.GetAnnotationWithPrefix("deletion-policy"): "Retain",
+	})
+	deletionPolicy, err = parseDeletionPolicy(ing)
+	assert.NoError(t, err)
+	assert.Equal(t, DeletionPolicyRetain, deletionPolicy)
+
+	ing.SetAnnotations(map[string]string{
+		parser.GetAnnotationWithPrefix("deletion-policy"): "retain",
+	})
+	_, err = parseDeletionPolicy(ing)
+	assert.EqualError(t, err, "deletion-policy must be either `Delete` or `Retain`")
+}
+
+func Test_parseSSLRedirect(t *testing.T) {
+	ports := []PortData{{Port: 80, Scheme: "HTTP"}, {Port: 443, Scheme: "HTTPS"}}
+	ing := dummy.NewIngress()
+	ing.SetAnnotations(map[string]string{
+		parser.GetAnnotationWithPrefix("ssl-redirect"): "443",
+	})
+	sslPort, err := parseSSLRedirect(ing, ports)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(443), *sslPort)
+
+	ing.SetAnnotations(map[string]string{
+		parser.GetAnnotationWithPrefix("ssl-redirect"): "80",
+	})
+	_, err = parseSSLRedirect(ing, ports)
+	assert.EqualError(t, err, "ssl-redirect port 80 must be an HTTPS port in listen-ports")
+
+	ing.SetAnnotations(map[string]string{})
+	sslPort, err = parseSSLRedirect(ing, ports)
+	assert.NoError(t, err)
+	assert.Nil(t, sslPort)
+}
+
+func TestStatusCodes_Matches(t *testing.T) {
+	codes := StatusCodes{{From: 200, To: 200}, {From: 301, To: 302}}
+	assert.True(t, codes.Matches(200))
+	assert.True(t, codes.Matches(302))
+	assert.False(t, codes.Matches(503))
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func Test_parseCidrs(t *testing.T) {
+	ports := []PortData{{Port: 80, Scheme: "HTTP"}, {Port: 443, Scheme: "HTTPS"}}
+	for _, tc := range []struct {
+		name                string
+		cidrs               string
+		expectedV4CIDRs     []string
+		expectedV6CIDRs     []string
+		expectedPrefixLists []string
+		expectedCIDRsByPort map[int64][]string
+		expectedErr         string
+	}{
+		{
+			name:            "annotation absent",
+			expectedV4CIDRs: []string{"0.0.0.0/0"},
+		},
+		{
+			name:            "CIDRs for all ports",
+			cidrs:           "10.0.0.0/8, 2001:db8::/32",
+			expectedV4CIDRs: []string{"10.0.0.0/8"},
+			expectedV6CIDRs: []string{"2001:db8::/32"},
+		},
+		{
+			name:                "CIDRs for specific ports",
+			cidrs:               "10.0.0.0/8, 443=192.168.0.0/16, 443=2001:db8::/32",
+			expectedV4CIDRs:     []string{"10.0.0.0/8"},
+			expectedCIDRsByPort: map[int64][]string{443: {"192.168.0.0/16", "2001:db8::/32"}},
+		},
+		{
+			name:                "CIDRs for specific ports only",
+			cidrs:               "443=192.168.0.0/16",
+			expectedV4CIDRs:     []string{"0.0.0.0/0"},
+			expectedCIDRsByPort: map[int64][]string{443: {"192.168.0.0/16"}},
+		},
+		{
+			name:                "prefix lists",
+			cidrs:               "pl-00000001, 443=pl-00000002",
+			expectedPrefixLists: []string{"pl-00000001"},
+			expectedCIDRsByPort: map[int64][]string{443: {"pl-00000002"}},
+		},
+		{
+			name:        "port not in listen-ports",
+			cidrs:       "8443=192.168.0.0/16",
+			expectedErr: "inbound-cidrs port 8443 must be a port in listen-ports",
+		},
+		{
+			name:        "invalid CIDR for specific port",
+			cidrs:       "443=192.168.0.0",
+			expectedErr: "invalid CIDR address: 192.168.0.0",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ing := dummy.NewIngress()
+			data := map[string]string{}
+			if tc.cidrs != "" {
+				data[parser.GetAnnotationWithPrefix("inbound-cidrs")] = tc.cidrs
+			}
+			ing.SetAnnotations(data)
+			v4CIDRs, v6CIDRs, prefixLists, cidrsByPort, err := parseCidrs(ing, ports)
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedV4CIDRs, v4CIDRs)
+				assert.Equal(t, tc.expectedV6CIDRs, v6CIDRs)
+				assert.Equal(t, tc.expectedPrefixLists, prefixLists)
+				assert.Equal(t, tc.expectedCIDRsByPort, cidrsByPort)
+			}
+		})
+	}
+}
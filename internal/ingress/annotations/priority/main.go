@@ -0,0 +1,59 @@
+package priority
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/errors"
+)
+
+// MaxPriority is the highest priority of listener rules.
+const MaxPriority = 50000
+
+type Config struct {
+	// Priorities are the pinned rule priorities keyed by backend name.
+	Priorities map[string]int
+}
+
+// NewParser creates a new rule priority annotation parser
+func NewParser() parser.IngressAnnotation {
+	return &priorityParser{}
+}
+
+type priorityParser struct {
+}
+
+// Parse parses the annotations contained in the resource
+func (p *priorityParser) Parse(ing parser.AnnotationInterface) (interface{}, error) {
+	annos, err := parser.GetStringAnnotations("priority", ing)
+	if err != nil {
+		if errors.IsMissingAnnotations(err) {
+			return &Config{}, nil
+		}
+		return nil, err
+	}
+
+	priorities := make(map[string]int)
+	for name, raw := range annos {
+		priority, err := strconv.Atoi(raw)
+		if err != nil || priority < 1 || priority > MaxPriority {
+			return nil, errors.NewInvalidAnnotationContentReason(fmt.Sprintf("priority.%v must be a priority between 1 and %v, got `%v`", name, MaxPriority, raw))
+		}
+		priorities[name] = priority
+	}
+	return &Config{
+		Priorities: priorities,
+	}, nil
+}
+
+// GetPriority returns the priority pinned for rules of the backend named name, or nil if they're prioritized by order.
+func (c *Config) GetPriority(name string) *int {
+	if c == nil {
+		return nil
+	}
+	if priority, ok := c.Priorities[name]; ok {
+		return &priority
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package priority
+
+import (
+	"testing"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/dummy"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPriorityParse(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		annotations map[string]string
+		expected    *Config
+		expectedErr string
+	}{
+		{
+			name:     "annotation absent",
+			expected: &Config{},
+		},
+		{
+			name: "priorities of backends",
+			annotations: map[string]string{
+				parser.GetAnnotationWithPrefix("priority.service-a"): "10",
+				parser.GetAnnotationWithPrefix("priority.service-b"): "50000",
+			},
+			expected: &Config{
+				Priorities: map[string]int{"service-a": 10, "service-b": 50000},
+			},
+		},
+		{
+			name: "priority not a number",
+			annotations: map[string]string{
+				parser.GetAnnotationWithPrefix("priority.service-a"): "first",
+			},
+			expectedErr: "priority.service-a must be a priority between 1 and 50000, got `first`",
+		},
+		{
+			name: "priority out of range",
+			annotations: map[string]string{
+				parser.GetAnnotationWithPrefix("priority.service-a"): "0",
+			},
+			expectedErr: "priority.service-a must be a priority between 1 and 50000, got `0`",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ing := dummy.NewIngress()
+			ing.SetAnnotations(tc.annotations)
+			cfg, err := NewParser().Parse(ing)
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, cfg)
+			}
+		})
+	}
+}
+
+func TestConfig_GetPriority(t *testing.T) {
+	cfg := &Config{Priorities: map[string]int{"service-a": 10}}
+	assert.Equal(t, 10, *cfg.GetPriority("service-a"))
+	assert.Nil(t, cfg.GetPriority("service-b"))
+
+	var absent *Config
+	assert.Nil(t, absent.GetPriority("service-a"))
+}
@@ -32,7 +32,9 @@ import (
 type Config struct {
 	Attributes              []*elbv2.TargetGroupAttribute
 	BackendProtocol         *string
+	ProtocolVersion         *string
 	HealthyThresholdCount   *int64
+	ManageNodePort          *bool
 	SuccessCodes            *string
 	TargetType              *string
 	UnhealthyThresholdCount *int64
@@ -47,6 +49,16 @@ const (
 	DefaultHealthyThresholdCount   = 2
 	DefaultUnhealthyThresholdCount = 2
 	DefaultSuccessCodes            = "200"
+	DefaultProtocolVersion         = ProtocolVersionHTTP1
+	// DefaultGRPCSuccessCodes are the gRPC status codes of healthy targets of GRPC targetGroups.
+	DefaultGRPCSuccessCodes = "12"
+)
+
+// protocol versions of targetGroups, they can't be modified once a targetGroup is created.
+const (
+	ProtocolVersionHTTP1 = "HTTP1"
+	ProtocolVersionHTTP2 = "HTTP2"
+	ProtocolVersionGRPC  = "GRPC"
 )
 
 // NewParser creates a new target group annotation parser
@@ -72,6 +84,17 @@ func (tg targetGroup) Parse(ing parser.AnnotationInterface) (interface{}, error)
 		backendProtocol = aws.String(DefaultBackendProtocol)
 	}
 
+	protocolVersion, err := parser.GetStringAnnotation("backend-protocol-version", ing)
+	if err != nil {
+		protocolVersion = aws.String(DefaultProtocolVersion)
+	}
+
+	switch *protocolVersion {
+	case ProtocolVersionHTTP1, ProtocolVersionHTTP2, ProtocolVersionGRPC:
+	default:
+		return nil, errors.NewInvalidAnnotationContent("backend-protocol-version", *protocolVersion)
+	}
+
 	healthyThresholdCount, err := parser.GetInt64Annotation("healthy-threshold-count", ing)
 	if err != nil {
 		healthyThresholdCount = aws.Int64(DefaultHealthyThresholdCount)
@@ -86,6 +109,9 @@ func (tg targetGroup) Parse(ing parser.AnnotationInterface) (interface{}, error)
 	successCodes, err := parser.GetStringAnnotation("successCodes", ing)
 	if err != nil {
 		successCodes = aws.String(DefaultSuccessCodes)
+		if *protocolVersion == ProtocolVersionGRPC {
+			successCodes = aws.String(DefaultGRPCSuccessCodes)
+		}
 	}
 
 	s, err := parser.GetStringAnnotation("success-codes", ing)
@@ -98,13 +124,20 @@ func (tg targetGroup) Parse(ing parser.AnnotationInterface) (interface{}, error)
 		return nil, err
 	}
 
+	manageNodePort, err := parser.GetBoolAnnotation("manage-node-port", ing)
+	if err != nil && !errors.IsMissingAnnotations(err) {
+		return nil, err
+	}
+
 	return &Config{
 		TargetType:              targetType,
 		BackendProtocol:         backendProtocol,
+		ProtocolVersion:         protocolVersion,
 		HealthyThresholdCount:   healthyThresholdCount,
 		UnhealthyThresholdCount: unhealthyThresholdCount,
 		SuccessCodes:            successCodes,
 		Attributes:              attributes,
+		ManageNodePort:          manageNodePort,
 	}, nil
 }
 
@@ -118,10 +151,12 @@ func (a *Config) Merge(b *Config, cfg *config.Configuration) *Config {
 	return &Config{
 		Attributes:              attributes,
 		BackendProtocol:         parser.MergeString(a.BackendProtocol, b.BackendProtocol, DefaultBackendProtocol),
+		ProtocolVersion:         parser.MergeString(a.ProtocolVersion, b.ProtocolVersion, DefaultProtocolVersion),
 		TargetType:              parser.MergeString(a.TargetType, b.TargetType, cfg.DefaultTargetType),
 		SuccessCodes:            parser.MergeString(a.SuccessCodes, b.SuccessCodes, DefaultSuccessCodes),
 		HealthyThresholdCount:   parser.MergeInt64(a.HealthyThresholdCount, b.HealthyThresholdCount, DefaultHealthyThresholdCount),
 		UnhealthyThresholdCount: parser.MergeInt64(a.UnhealthyThresholdCount, b.UnhealthyThresholdCount, DefaultUnhealthyThresholdCount),
+		ManageNodePort:          parser.MergeBool(a.ManageNodePort, b.ManageNodePort, false),
 	}
 }
 
@@ -154,6 +189,7 @@ func parseAttributes(ing parser.AnnotationInterface) ([]*elbv2.TargetGroupAttrib
 func Dummy() *Config {
 	return &Config{
 		BackendProtocol:         aws.String(elbv2.ProtocolEnumHttp),
+		ProtocolVersion:         aws.String(ProtocolVersionHTTP1),
 		HealthyThresholdCount:   aws.Int64(2),
 		SuccessCodes:            aws.String("200"),
 		TargetType:              aws.String(elbv2.TargetTypeEnumInstance),
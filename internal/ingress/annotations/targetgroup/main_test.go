@@ -5,8 +5,12 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/config"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/resolver"
 	"github.com/stretchr/testify/assert"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestMerge(t *testing.T) {
@@ -104,3 +108,52 @@ func TestMerge(t *testing.T) {
 		assert.Equal(t, tc.ExpectedResult, actualResult)
 	}
 }
+
+func TestParse_protocolVersion(t *testing.T) {
+	for _, tc := range []struct {
+		name                    string
+		annotations             map[string]string
+		expectedProtocolVersion string
+		expectedSuccessCodes    string
+		expectedErr             string
+	}{
+		{
+			name:                    "defaults to HTTP1",
+			expectedProtocolVersion: ProtocolVersionHTTP1,
+			expectedSuccessCodes:    DefaultSuccessCodes,
+		},
+		{
+			name:                    "GRPC defaults to gRPC success codes",
+			annotations:             map[string]string{"backend-protocol-version": "GRPC"},
+			expectedProtocolVersion: ProtocolVersionGRPC,
+			expectedSuccessCodes:    DefaultGRPCSuccessCodes,
+		},
+		{
+			name:                    "GRPC with success codes",
+			annotations:             map[string]string{"backend-protocol-version": "GRPC", "success-codes": "0-99"},
+			expectedProtocolVersion: ProtocolVersionGRPC,
+			expectedSuccessCodes:    "0-99",
+		},
+		{
+			name:        "unknown protocol version",
+			annotations: map[string]string{"backend-protocol-version": "HTTP3"},
+			expectedErr: "the annotation backend-protocol-version does not contain a valid value (HTTP3)",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			annotations := map[string]string{parser.GetAnnotationWithPrefix("target-type"): "ip"}
+			for k, v := range tc.annotations {
+				annotations[parser.GetAnnotationWithPrefix(k)] = v
+			}
+			ing := &extensions.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "ingress", Annotations: annotations}}
+			cfg, err := NewParser(resolver.Mock{}).Parse(ing)
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedProtocolVersion, aws.StringValue(cfg.(*Config).ProtocolVersion))
+			assert.Equal(t, tc.expectedSuccessCodes, aws.StringValue(cfg.(*Config).SuccessCodes))
+		})
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/action"
+	ingressbackend "github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/backend"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/secretref"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	extensions "k8s.io/api/extensions/v1beta1"
@@ -48,14 +50,16 @@ type Module interface {
 }
 
 // NewModule constructs new Authentication module
-func NewModule(cache cache.Cache) Module {
+func NewModule(cache cache.Cache, secretRefResolver secretref.Resolver) Module {
 	return &defaultModule{
-		cache: cache,
+		cache:             cache,
+		secretRefResolver: secretRefResolver,
 	}
 }
 
 type defaultModule struct {
-	cache cache.Cache
+	cache             cache.Cache
+	secretRefResolver secretref.Resolver
 }
 
 func (m *defaultModule) Init(controller controller.Controller, ingressChan chan<- event.GenericEvent, serviceChan chan<- event.GenericEvent) error {
@@ -98,18 +102,21 @@ func (m *defaultModule) NewConfig(ctx context.Context, ingress *extensions.Ingre
 		SessionTimeout:           DefaultAuthSessionTimeout,
 	}
 
-	ingressAnnos := ingress.Annotations
+	ingressAnnos, err := m.secretRefResolver.Resolve(ctx, ingress.Namespace, ingress.Annotations)
+	if err != nil {
+		return Config{}, err
+	}
 	var serviceAnnos map[string]string
 	if !action.Use(backend.ServicePort.String()) {
-		serviceKey := types.NamespacedName{
-			Namespace: ingress.Namespace,
-			Name:      backend.ServiceName,
-		}
+		serviceKey := ingressbackend.ServiceKey(ingress, backend)
 		service := corev1.Service{}
 		if err := m.cache.Get(ctx, serviceKey, &service); err != nil {
 			return Config{}, errors.Wrapf(err, "failed to get service %v", serviceKey)
 		}
-		serviceAnnos = service.Annotations
+		serviceAnnos, err = m.secretRefResolver.Resolve(ctx, service.Namespace, service.Annotations)
+		if err != nil {
+			return Config{}, err
+		}
 	}
 	_ = annotations.LoadStringAnnotation(AnnotationAuthType, (*string)(&cfg.Type), serviceAnnos, ingressAnnos)
 	_ = annotations.LoadStringAnnotation(AnnotationAuthOnUnauthenticatedRequest, (*string)(&cfg.OnUnauthenticatedRequest), serviceAnnos, ingressAnnos)
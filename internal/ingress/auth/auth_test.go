@@ -6,6 +6,7 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/secretref"
 	mock_cache "github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks/controller-runtime/cache"
 	mock_controller "github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks/controller-runtime/controller"
 	"github.com/stretchr/testify/assert"
@@ -34,7 +35,7 @@ func TestDefaultModule_Init(t *testing.T) {
 		Cache:       mockCache,
 	})
 
-	module := &defaultModule{mockCache}
+	module := &defaultModule{cache: mockCache}
 	assert.NoError(t, module.Init(mockController, ingressChan, serviceChan))
 }
 
@@ -386,7 +387,7 @@ func TestDefaultModule_NewConfig(t *testing.T) {
 					Name:      tc.secret.Name,
 				}, gomock.Any()).SetArg(2, *tc.secret)
 			}
-			module := &defaultModule{mockCache}
+			module := &defaultModule{cache: mockCache, secretRefResolver: secretref.NewResolver(mockCache)}
 
 			authCfg, err := module.NewConfig(context.Background(), tc.ingress, tc.backend, tc.protocol)
 			assert.Equal(t, authCfg, tc.expectedAuthCfg)
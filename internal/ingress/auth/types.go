@@ -1,5 +1,13 @@
 package auth
 
+import "github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/log"
+
+func init() {
+	// the OIDC client secret must never be logged, e.g. as part of the desired state of ingresses.
+	log.RegisterSensitiveType(IDPOIDC{})
+	log.RegisterSensitiveType(AnnotationSchemaIDPOIDC{})
+}
+
 // authentication type
 type Type string
 
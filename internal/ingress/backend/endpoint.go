@@ -55,13 +55,22 @@ func (resolver *endpointResolver) Resolve(ingress *extensions.Ingress, backend *
 }
 
 func (resolver *endpointResolver) resolveInstance(ingress *extensions.Ingress, backend *extensions.IngressBackend) ([]*elbv2.TargetDescription, error) {
-	service, servicePort, err := findServiceAndPort(resolver.store, ingress.Namespace, backend.ServiceName, backend.ServicePort)
+	serviceKey := ServiceKey(ingress, *backend)
+	service, _, err := findServiceAndPort(resolver.store, serviceKey.Namespace, serviceKey.Name, backend.ServicePort)
+	if err != nil {
+		return nil, err
+	}
+	service, err = ResolveNodePortService(resolver.store, service)
 	if err != nil {
 		return nil, err
 	}
 	if service.Spec.Type != corev1.ServiceTypeNodePort && service.Spec.Type != corev1.ServiceTypeLoadBalancer {
 		return nil, fmt.Errorf("%v service is not of type NodePort or LoadBalancer and target-type is instance", service.Name)
 	}
+	servicePort, err := k8s.LookupServicePort(service, backend.ServicePort)
+	if err != nil {
+		return nil, err
+	}
 	nodePort := servicePort.NodePort
 
 	var result []*elbv2.TargetDescription
@@ -83,16 +92,17 @@ func (resolver *endpointResolver) resolveInstance(ingress *extensions.Ingress, b
 }
 
 func (resolver *endpointResolver) resolveIP(ingress *extensions.Ingress, backend *extensions.IngressBackend) ([]*elbv2.TargetDescription, error) {
-	service, servicePort, err := findServiceAndPort(resolver.store, ingress.Namespace, backend.ServiceName, backend.ServicePort)
+	serviceKey := ServiceKey(ingress, *backend)
+	_, servicePort, err := findServiceAndPort(resolver.store, serviceKey.Namespace, serviceKey.Name, backend.ServicePort)
 	if err != nil {
 		return nil, err
 	}
-	serviceKey := ingress.Namespace + "/" + service.Name
-	eps, err := resolver.store.GetServiceEndpoints(serviceKey)
+	eps, err := resolver.store.GetServiceEndpoints(serviceKey.String())
 	if err != nil {
 		return nil, fmt.Errorf("Unable to find service endpoints for %s: %v", serviceKey, err.Error())
 	}
 
+	conditionType := TargetHealthConditionType(ingress, *backend)
 	var result []*elbv2.TargetDescription
 	for _, epSubset := range eps.Subsets {
 		for _, epPort := range epSubset.Ports {
@@ -106,12 +116,33 @@ func (resolver *endpointResolver) resolveIP(ingress *extensions.Ingress, backend
 					Port: aws.Int64(int64(epPort.Port)),
 				})
 			}
+			// pods gated on their target health stay NotReady until they are registered and healthy.
+			for _, epAddr := range epSubset.NotReadyAddresses {
+				if !resolver.awaitsTargetHealth(epAddr, conditionType) {
+					continue
+				}
+				result = append(result, &elbv2.TargetDescription{
+					Id:   aws.String(epAddr.IP),
+					Port: aws.Int64(int64(epPort.Port)),
+				})
+			}
 		}
 	}
 
 	return result, nil
 }
 
+// awaitsTargetHealth returns whether the NotReady endpoint address is a pod whose containers are ready,
+// but which is gated on the conditionType target health condition.
+func (resolver *endpointResolver) awaitsTargetHealth(epAddr corev1.EndpointAddress, conditionType corev1.PodConditionType) bool {
+	pod := addressPod(resolver.store, epAddr)
+	if pod == nil || !hasReadinessGate(pod, conditionType) {
+		return false
+	}
+	containersReady := podCondition(pod, corev1.ContainersReady)
+	return containersReady != nil && containersReady.Status == corev1.ConditionTrue
+}
+
 // findServiceAndPort returns the service & servicePort by name
 func findServiceAndPort(store store.Storer, namespace string, serviceName string, servicePort intstr.IntOrString) (*corev1.Service, *corev1.ServicePort, error) {
 	serviceKey := namespace + "/" + serviceName
@@ -44,6 +44,7 @@ func TestResolveWithModeInstance(t *testing.T) {
 		name            string
 		ingress         *extensions.Ingress
 		service         *api_v1.Service
+		nodePortService *api_v1.Service
 		nodes           []*api_v1.Node
 		nodeHealthProbe func(string) (bool, error)
 		expectedTargets []*elbv2.TargetDescription
@@ -167,6 +168,67 @@ func TestResolveWithModeInstance(t *testing.T) {
 			},
 			expectedError: false,
 		},
+		{
+			name: "success scenario by companion NodePort service of ClusterIP service",
+			ingress: &extensions.Ingress{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "ingress",
+					Namespace: api_v1.NamespaceDefault,
+				},
+				Spec: extensions.IngressSpec{
+					Backend: &extensions.IngressBackend{
+						ServiceName: "service",
+						ServicePort: intstr.FromString("http"),
+					},
+				},
+			},
+			service: &api_v1.Service{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "service",
+					Namespace: api_v1.NamespaceDefault,
+				},
+				Spec: api_v1.ServiceSpec{
+					Type: api_v1.ServiceTypeClusterIP,
+					Ports: []api_v1.ServicePort{
+						{
+							Name: "http",
+							Port: 8080,
+						},
+					},
+				},
+			},
+			nodePortService: &api_v1.Service{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "service-alb-nodeport",
+					Namespace: api_v1.NamespaceDefault,
+				},
+				Spec: api_v1.ServiceSpec{
+					Type: api_v1.ServiceTypeNodePort,
+					Ports: []api_v1.ServicePort{
+						{
+							Name:     "http",
+							Port:     8080,
+							NodePort: nodePort,
+						},
+					},
+				},
+			},
+			nodes: []*api_v1.Node{
+				{
+					Spec: api_v1.NodeSpec{
+						ProviderID: nodeName1,
+					},
+				},
+			},
+			nodeHealthProbe: func(instanceID string) (bool, error) { return true, nil },
+			expectedTargets: []*elbv2.TargetDescription{
+				{
+					Id:   aws.String(nodeName1),
+					Port: aws.Int64(nodePort),
+				},
+			},
+			expectedError: false,
+		},
 		{
 			name: "failure scenario by service not found",
 			ingress: &extensions.Ingress{
@@ -300,7 +362,10 @@ func TestResolveWithModeInstance(t *testing.T) {
 			}
 
 			store := store.NewDummy()
-			store.GetServiceFunc = func(string) (*api_v1.Service, error) {
+			store.GetServiceFunc = func(key string) (*api_v1.Service, error) {
+				if tc.nodePortService != nil && key == tc.nodePortService.Namespace+"/"+tc.nodePortService.Name {
+					return tc.nodePortService, nil
+				}
 				if tc.service != nil {
 					return tc.service, nil
 				}
@@ -343,6 +408,7 @@ func TestResolveWithModeIP(t *testing.T) {
 		ingress         *extensions.Ingress
 		service         *api_v1.Service
 		endpoints       *api_v1.Endpoints
+		pods            []*api_v1.Pod
 		expectedTargets []*elbv2.TargetDescription
 		expectedError   bool
 	}{
@@ -509,6 +575,92 @@ func TestResolveWithModeIP(t *testing.T) {
 			},
 			expectedError: false,
 		},
+		{
+			name: "success scenario with NotReady pods gated on target health",
+			ingress: &extensions.Ingress{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "ingress",
+					Namespace: api_v1.NamespaceDefault,
+				},
+				Spec: extensions.IngressSpec{
+					Backend: &extensions.IngressBackend{
+						ServiceName: "service",
+						ServicePort: intstr.FromInt(8080),
+					},
+				},
+			},
+			service: &api_v1.Service{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:      "service",
+					Namespace: api_v1.NamespaceDefault,
+				},
+				Spec: api_v1.ServiceSpec{
+					Type: api_v1.ServiceTypeClusterIP,
+					Ports: []api_v1.ServicePort{
+						{
+							Port: portHTTP,
+						},
+					},
+				},
+			},
+			endpoints: &api_v1.Endpoints{
+				Subsets: []api_v1.EndpointSubset{
+					{
+						Addresses: []api_v1.EndpointAddress{
+							{
+								IP: ip1,
+							},
+						},
+						NotReadyAddresses: []api_v1.EndpointAddress{
+							{
+								IP:        ip2,
+								TargetRef: &api_v1.ObjectReference{Kind: "Pod", Namespace: api_v1.NamespaceDefault, Name: "gated"},
+							},
+							{
+								IP:        ip3,
+								TargetRef: &api_v1.ObjectReference{Kind: "Pod", Namespace: api_v1.NamespaceDefault, Name: "starting"},
+							},
+						},
+						Ports: []api_v1.EndpointPort{
+							{
+								Port: portHTTP,
+							},
+						},
+					},
+				},
+			},
+			pods: []*api_v1.Pod{
+				{
+					ObjectMeta: meta_v1.ObjectMeta{Namespace: api_v1.NamespaceDefault, Name: "gated"},
+					Spec: api_v1.PodSpec{
+						ReadinessGates: []api_v1.PodReadinessGate{{ConditionType: "target-health.alb.ingress.k8s.aws/ingress_service_8080"}},
+					},
+					Status: api_v1.PodStatus{
+						Conditions: []api_v1.PodCondition{{Type: api_v1.ContainersReady, Status: api_v1.ConditionTrue}},
+					},
+				},
+				{
+					ObjectMeta: meta_v1.ObjectMeta{Namespace: api_v1.NamespaceDefault, Name: "starting"},
+					Spec: api_v1.PodSpec{
+						ReadinessGates: []api_v1.PodReadinessGate{{ConditionType: "target-health.alb.ingress.k8s.aws/ingress_service_8080"}},
+					},
+					Status: api_v1.PodStatus{
+						Conditions: []api_v1.PodCondition{{Type: api_v1.ContainersReady, Status: api_v1.ConditionFalse}},
+					},
+				},
+			},
+			expectedTargets: []*elbv2.TargetDescription{
+				{
+					Id:   aws.String(ip1),
+					Port: aws.Int64(portHTTP),
+				},
+				{
+					Id:   aws.String(ip2),
+					Port: aws.Int64(portHTTP),
+				},
+			},
+			expectedError: false,
+		},
 		{
 			name: "failure scenario by no endpoint found",
 			ingress: &extensions.Ingress{
@@ -577,6 +729,14 @@ func TestResolveWithModeIP(t *testing.T) {
 				}
 				return nil, fmt.Errorf("No such endpoints")
 			}
+			store.GetPodFunc = func(key string) (*api_v1.Pod, error) {
+				for _, pod := range tc.pods {
+					if pod.Namespace+"/"+pod.Name == key {
+						return pod, nil
+					}
+				}
+				return nil, fmt.Errorf("No such pod")
+			}
 
 			resolver := NewEndpointResolver(store, cloud)
 			targets, err := resolver.Resolve(tc.ingress, tc.ingress.Spec.Backend, elbv2.TargetTypeEnumIp)
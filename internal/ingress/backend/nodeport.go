@@ -0,0 +1,177 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// nodePortServiceSuffix is appended to the name of ClusterIP services to name their companion NodePort services.
+const nodePortServiceSuffix = "-alb-nodeport"
+
+// NodePortManager manages companion NodePort services, which expose ClusterIP services to instance targets.
+type NodePortManager interface {
+	// Reconcile ensures service in namespace can be targeted with target-type instance.
+	// If service is a ClusterIP service, a companion NodePort service is maintained when manage is true,
+	// otherwise the companion NodePort service is deleted and an error is returned with the steps to fix the service.
+	Reconcile(ctx context.Context, namespace string, serviceName string, manage bool) error
+}
+
+// NewNodePortManager constructs new NodePortManager
+func NewNodePortManager(client client.Client, store store.Storer) NodePortManager {
+	return &defaultNodePortManager{
+		client: client,
+		store:  store,
+	}
+}
+
+type defaultNodePortManager struct {
+	client client.Client
+	store  store.Storer
+}
+
+func (m *defaultNodePortManager) Reconcile(ctx context.Context, namespace string, serviceName string, manage bool) error {
+	serviceKey := namespace + "/" + serviceName
+	service, err := m.store.GetService(serviceKey)
+	if err != nil {
+		return fmt.Errorf("Unable to find the %s service: %w", serviceKey, err)
+	}
+	if service.Spec.Type != corev1.ServiceTypeClusterIP {
+		return nil
+	}
+	if !manage {
+		if err := m.deleteNodePortService(ctx, service); err != nil {
+			return err
+		}
+		albctx.GetEventf(ctx)(corev1.EventTypeWarning, "ERROR", "%v service is of type ClusterIP and can't be used with target-type instance. "+
+			"Change the service to type NodePort, use target-type ip, or set the %v annotation to true to have a companion NodePort service managed",
+			service.Name, parser.GetAnnotationWithPrefix("manage-node-port"))
+		return fmt.Errorf("%v service is of type ClusterIP and target-type is instance", service.Name)
+	}
+
+	desired := buildNodePortService(service)
+	current := &corev1.Service{}
+	err = m.client.Get(ctx, types.NamespacedName{Namespace: desired.Namespace, Name: desired.Name}, current)
+	if apierrors.IsNotFound(err) {
+		albctx.GetLogger(ctx).Infof("creating NodePort service %v/%v for %v service", desired.Namespace, desired.Name, service.Name)
+		if err := m.client.Create(ctx, desired); err != nil {
+			return fmt.Errorf("failed to create NodePort service %v due to %w", desired.Name, err)
+		}
+		albctx.GetEventf(ctx)(corev1.EventTypeNormal, "CREATE", "NodePort service %v created for %v service", desired.Name, service.Name)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get NodePort service %v due to %w", desired.Name, err)
+	}
+	if !metav1.IsControlledBy(current, service) {
+		return fmt.Errorf("service %v already exists and isn't managed for %v service", desired.Name, service.Name)
+	}
+
+	desired.Spec.Ports = preserveNodePorts(desired.Spec.Ports, current.Spec.Ports)
+	if current.Spec.Type == desired.Spec.Type &&
+		reflect.DeepEqual(current.Spec.Selector, desired.Spec.Selector) &&
+		reflect.DeepEqual(current.Spec.Ports, desired.Spec.Ports) {
+		return nil
+	}
+	albctx.GetLogger(ctx).Infof("modifying NodePort service %v/%v for %v service", desired.Namespace, desired.Name, service.Name)
+	updated := current.DeepCopy()
+	updated.Spec.Type = desired.Spec.Type
+	updated.Spec.Selector = desired.Spec.Selector
+	updated.Spec.Ports = desired.Spec.Ports
+	if err := m.client.Update(ctx, updated); err != nil {
+		return fmt.Errorf("failed to update NodePort service %v due to %w", desired.Name, err)
+	}
+	return nil
+}
+
+// deleteNodePortService deletes the companion NodePort service of service, if it was created for it.
+func (m *defaultNodePortManager) deleteNodePortService(ctx context.Context, service *corev1.Service) error {
+	name := NodePortServiceName(service.Name)
+	current := &corev1.Service{}
+	err := m.client.Get(ctx, types.NamespacedName{Namespace: service.Namespace, Name: name}, current)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get NodePort service %v due to %w", name, err)
+	}
+	if !metav1.IsControlledBy(current, service) {
+		return nil
+	}
+	albctx.GetLogger(ctx).Infof("deleting NodePort service %v/%v for %v service", service.Namespace, name, service.Name)
+	if err := m.client.Delete(ctx, current); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete NodePort service %v due to %w", name, err)
+	}
+	albctx.GetEventf(ctx)(corev1.EventTypeNormal, "DELETE", "NodePort service %v deleted for %v service", name, service.Name)
+	return nil
+}
+
+// NodePortServiceName returns the name of the companion NodePort service of ClusterIP service serviceName.
+func NodePortServiceName(serviceName string) string {
+	return serviceName + nodePortServiceSuffix
+}
+
+// ResolveNodePortService returns the service that exposes service on node ports.
+// It's the companion NodePort service for ClusterIP services, and service itself otherwise.
+func ResolveNodePortService(store store.Storer, service *corev1.Service) (*corev1.Service, error) {
+	if service.Spec.Type != corev1.ServiceTypeClusterIP {
+		return service, nil
+	}
+	nodePortServiceKey := service.Namespace + "/" + NodePortServiceName(service.Name)
+	nodePortService, err := store.GetService(nodePortServiceKey)
+	if err != nil {
+		return nil, fmt.Errorf("%v service is of type ClusterIP and NodePort service %v isn't available: %w", service.Name, nodePortServiceKey, err)
+	}
+	return nodePortService, nil
+}
+
+// buildNodePortService builds the companion NodePort service of service, it's owned by service so that it's garbage collected together.
+func buildNodePortService(service *corev1.Service) *corev1.Service {
+	var ports []corev1.ServicePort
+	for _, port := range service.Spec.Ports {
+		ports = append(ports, corev1.ServicePort{
+			Name:       port.Name,
+			Protocol:   port.Protocol,
+			Port:       port.Port,
+			TargetPort: port.TargetPort,
+		})
+	}
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: service.Namespace,
+			Name:      NodePortServiceName(service.Name),
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(service, corev1.SchemeGroupVersion.WithKind("Service")),
+			},
+		},
+		Spec: corev1.ServiceSpec{
+			Type:     corev1.ServiceTypeNodePort,
+			Selector: service.Spec.Selector,
+			Ports:    ports,
+		},
+	}
+}
+
+// preserveNodePorts keeps the nodePorts already allocated for the same ports.
+func preserveNodePorts(desired []corev1.ServicePort, current []corev1.ServicePort) []corev1.ServicePort {
+	var result []corev1.ServicePort
+	for _, port := range desired {
+		for _, currentPort := range current {
+			if currentPort.Name == port.Name && currentPort.Port == port.Port && currentPort.Protocol == port.Protocol {
+				port.NodePort = currentPort.NodePort
+				break
+			}
+		}
+		result = append(result, port)
+	}
+	return result
+}
@@ -0,0 +1,156 @@
+package backend
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestDefaultNodePortManager_Reconcile(t *testing.T) {
+	clusterIPService := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "namespace",
+			Name:      "service",
+			UID:       "service-uid",
+		},
+		Spec: corev1.ServiceSpec{
+			Type:     corev1.ServiceTypeClusterIP,
+			Selector: map[string]string{"app": "app"},
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "http",
+					Protocol:   corev1.ProtocolTCP,
+					Port:       80,
+					TargetPort: intstr.FromInt(8080),
+				},
+				{
+					Name:       "https",
+					Protocol:   corev1.ProtocolTCP,
+					Port:       443,
+					TargetPort: intstr.FromInt(8443),
+				},
+			},
+		},
+	}
+	nodePortService := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "namespace",
+			Name:      "service",
+		},
+		Spec: corev1.ServiceSpec{
+			Type: corev1.ServiceTypeNodePort,
+		},
+	}
+	ownedNodePortService := buildNodePortService(clusterIPService)
+	ownedNodePortService.Spec.Ports = []corev1.ServicePort{
+		{
+			Name:       "http",
+			Protocol:   corev1.ProtocolTCP,
+			Port:       80,
+			TargetPort: intstr.FromInt(8080),
+			NodePort:   30080,
+		},
+	}
+	unownedNodePortService := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "namespace",
+			Name:      "service-alb-nodeport",
+		},
+	}
+
+	for _, tc := range []struct {
+		Name          string
+		Service       *corev1.Service
+		Manage        bool
+		Existing      []runtime.Object
+		ExpectedPorts []corev1.ServicePort
+		ExpectedError string
+	}{
+		{
+			Name:    "NodePort service is untouched",
+			Service: nodePortService,
+			Manage:  true,
+		},
+		{
+			Name:          "ClusterIP service without manage-node-port",
+			Service:       clusterIPService,
+			Manage:        false,
+			ExpectedError: "service service is of type ClusterIP and target-type is instance",
+		},
+		{
+			Name:          "ClusterIP service without manage-node-port deletes NodePort service",
+			Service:       clusterIPService,
+			Manage:        false,
+			Existing:      []runtime.Object{ownedNodePortService},
+			ExpectedError: "service service is of type ClusterIP and target-type is instance",
+		},
+		{
+			Name:    "ClusterIP service creates NodePort service",
+			Service: clusterIPService,
+			Manage:  true,
+			ExpectedPorts: []corev1.ServicePort{
+				{Name: "http", Protocol: corev1.ProtocolTCP, Port: 80, TargetPort: intstr.FromInt(8080)},
+				{Name: "https", Protocol: corev1.ProtocolTCP, Port: 443, TargetPort: intstr.FromInt(8443)},
+			},
+		},
+		{
+			Name:     "ClusterIP service updates NodePort service and keeps allocated nodePorts",
+			Service:  clusterIPService,
+			Manage:   true,
+			Existing: []runtime.Object{ownedNodePortService},
+			ExpectedPorts: []corev1.ServicePort{
+				{Name: "http", Protocol: corev1.ProtocolTCP, Port: 80, TargetPort: intstr.FromInt(8080), NodePort: 30080},
+				{Name: "https", Protocol: corev1.ProtocolTCP, Port: 443, TargetPort: intstr.FromInt(8443)},
+			},
+		},
+		{
+			Name:          "ClusterIP service conflicts with unmanaged service",
+			Service:       clusterIPService,
+			Manage:        true,
+			Existing:      []runtime.Object{unownedNodePortService},
+			ExpectedError: "service service-alb-nodeport already exists and isn't managed for service service",
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			ctx := context.Background()
+			client := fake.NewFakeClient(tc.Existing...)
+			mockStore := store.NewDummy()
+			mockStore.GetServiceFunc = func(string) (*corev1.Service, error) {
+				return tc.Service, nil
+			}
+
+			m := NewNodePortManager(client, mockStore)
+			err := m.Reconcile(ctx, "namespace", "service", tc.Manage)
+			if tc.ExpectedError != "" {
+				assert.EqualError(t, err, tc.ExpectedError)
+				if !tc.Manage {
+					err = client.Get(ctx, types.NamespacedName{Namespace: "namespace", Name: "service-alb-nodeport"}, &corev1.Service{})
+					assert.True(t, apierrors.IsNotFound(err))
+				}
+				return
+			}
+			assert.NoError(t, err)
+
+			companion := &corev1.Service{}
+			err = client.Get(ctx, types.NamespacedName{Namespace: "namespace", Name: "service-alb-nodeport"}, companion)
+			if tc.ExpectedPorts == nil {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, corev1.ServiceTypeNodePort, companion.Spec.Type)
+			assert.Equal(t, tc.Service.Spec.Selector, companion.Spec.Selector)
+			assert.Equal(t, tc.ExpectedPorts, companion.Spec.Ports)
+			assert.True(t, metav1.IsControlledBy(companion, tc.Service))
+		})
+	}
+}
@@ -0,0 +1,276 @@
+package backend
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/k8s"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/log"
+	corev1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// TargetHealthConditionTypePrefix is the prefix of pod readiness gates that reflect the health of pods as ALB targets.
+const TargetHealthConditionTypePrefix = "target-health.alb.ingress.k8s.aws"
+
+// reasonTargetNotRegistered is the condition reason of pods that aren't registered in the targetGroup yet.
+const reasonTargetNotRegistered = "TargetNotRegistered"
+
+// conditionNameMaxLength is the maximum length of the name part of a pod condition type, which is a qualified name.
+const conditionNameMaxLength = 63
+
+const (
+	// targetHealthRefreshMinInterval is the interval of the first target health refresh after a reconcile, it doubles for each further refresh.
+	targetHealthRefreshMinInterval = 10 * time.Second
+	// targetHealthRefreshMaxInterval is the maximum interval of target health refreshes.
+	targetHealthRefreshMaxInterval = 2 * time.Minute
+	// targetHealthMaxRefreshes is the number of refreshes after a reconcile, before pods still unhealthy are left to the next one.
+	targetHealthMaxRefreshes = 15
+)
+
+// TargetHealthConditionType returns the pod condition type that reflects the health of pods as targets of backend of ingress.
+// Pods declaring it as readiness gate are kept NotReady until their target is healthy.
+// Names longer than a condition type allows are truncated and suffixed with a hash of the full name.
+func TargetHealthConditionType(ingress *extensions.Ingress, backend extensions.IngressBackend) corev1.PodConditionType {
+	// backends of merged IngressGroup ingresses are qualified like `namespace/ingress/service-name`.
+	serviceName := strings.Replace(backend.ServiceName, "/", "_", -1)
+	name := fmt.Sprintf("%s_%s_%s", ingress.Name, serviceName, backend.ServicePort.String())
+	if len(name) > conditionNameMaxLength {
+		hash := sha256.Sum256([]byte(name))
+		suffix := hex.EncodeToString(hash[:])[:8]
+		name = name[:conditionNameMaxLength-len(suffix)-1] + "_" + suffix
+	}
+	return corev1.PodConditionType(TargetHealthConditionTypePrefix + "/" + name)
+}
+
+// PodConditionManager maintains the target health conditions of pods registered as ip targets.
+type PodConditionManager interface {
+	// Reconcile updates the target health condition of pods targeted by backend of ingress in the targetGroup with tgArn,
+	// for pods that declare it as readiness gate. While any of them isn't healthy, the conditions are refreshed in the background
+	// with an increasing interval, without reconciling the ingress again.
+	Reconcile(ctx context.Context, ingress *extensions.Ingress, backend extensions.IngressBackend, tgArn string) error
+}
+
+// NewPodConditionManager constructs new PodConditionManager
+func NewPodConditionManager(client client.Client, store store.Storer, cloud aws.CloudAPI) PodConditionManager {
+	return &defaultPodConditionManager{
+		client:    client,
+		store:     store,
+		cloud:     cloud,
+		refreshes: make(map[string]*targetHealthRefresh),
+	}
+}
+
+type defaultPodConditionManager struct {
+	client client.Client
+	store  store.Storer
+	cloud  aws.CloudAPI
+
+	// refreshes are the scheduled refreshes by targetGroup and condition type.
+	mutex     sync.Mutex
+	refreshes map[string]*targetHealthRefresh
+}
+
+// targetHealthRefresh is a scheduled refresh of target health conditions.
+type targetHealthRefresh struct {
+	timer *time.Timer
+}
+
+func (m *defaultPodConditionManager) Reconcile(ctx context.Context, ingress *extensions.Ingress, backend extensions.IngressBackend, tgArn string) error {
+	key := tgArn + " " + string(TargetHealthConditionType(ingress, backend))
+	pending, err := m.reconcile(ctx, ingress, backend, tgArn)
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	// the reconcile takes over from refreshes scheduled before, and starts over with the minimum interval.
+	if refresh, ok := m.refreshes[key]; ok {
+		refresh.timer.Stop()
+		delete(m.refreshes, key)
+	}
+	if err == nil && pending {
+		m.scheduleRefresh(key, ingress, backend, tgArn, 0)
+	}
+	return err
+}
+
+// scheduleRefresh schedules the refresh after attempts refreshes, unless there were targetHealthMaxRefreshes already. The caller must hold mutex.
+func (m *defaultPodConditionManager) scheduleRefresh(key string, ingress *extensions.Ingress, backend extensions.IngressBackend, tgArn string, attempts int) {
+	if attempts >= targetHealthMaxRefreshes {
+		return
+	}
+	interval := targetHealthRefreshMinInterval << uint(attempts)
+	if interval > targetHealthRefreshMaxInterval {
+		interval = targetHealthRefreshMaxInterval
+	}
+	refresh := &targetHealthRefresh{}
+	refresh.timer = time.AfterFunc(interval, func() {
+		m.refresh(key, refresh, ingress, backend, tgArn, attempts+1)
+	})
+	m.refreshes[key] = refresh
+}
+
+// refresh updates the target health conditions, and schedules the next refresh while any gated pod isn't healthy.
+func (m *defaultPodConditionManager) refresh(key string, refresh *targetHealthRefresh, ingress *extensions.Ingress, backend extensions.IngressBackend, tgArn string, attempts int) {
+	ctx, cancel := context.WithTimeout(context.Background(), targetHealthRefreshMaxInterval)
+	defer cancel()
+	ctx = albctx.SetLogger(ctx, log.New(k8s.MetaNamespaceKey(ingress)).WithSubsystem("target-health"))
+	pending, err := m.reconcile(ctx, ingress, backend, tgArn)
+	if err != nil {
+		// e.g. the service was deleted, the next reconcile of the ingress schedules refreshes again if needed.
+		albctx.GetLogger(ctx).Errorf("failed to refresh target health conditions of targetGroup %v due to %v", tgArn, err)
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if m.refreshes[key] != refresh {
+		return
+	}
+	delete(m.refreshes, key)
+	if err == nil && pending {
+		m.scheduleRefresh(key, ingress, backend, tgArn, attempts)
+	}
+}
+
+// reconcile updates the target health conditions of gated pods, and returns whether any of them isn't healthy.
+func (m *defaultPodConditionManager) reconcile(ctx context.Context, ingress *extensions.Ingress, backend extensions.IngressBackend, tgArn string) (bool, error) {
+	conditionType := TargetHealthConditionType(ingress, backend)
+	pods, err := gatedPods(m.store, ingress, backend, conditionType)
+	if err != nil {
+		return false, err
+	}
+	if len(pods) == 0 {
+		return false, nil
+	}
+
+	resp, err := m.cloud.DescribeTargetHealthWithContext(ctx, &elbv2.DescribeTargetHealthInput{TargetGroupArn: aws.String(tgArn)})
+	if err != nil {
+		return false, err
+	}
+	targetHealth := make(map[string]*elbv2.TargetHealth)
+	for _, thd := range resp.TargetHealthDescriptions {
+		targetHealth[targetKey(aws.StringValue(thd.Target.Id), aws.Int64Value(thd.Target.Port))] = thd.TargetHealth
+	}
+
+	pending := false
+	for key, pod := range pods {
+		health := targetHealth[key]
+		if health == nil || aws.StringValue(health.State) != elbv2.TargetHealthStateEnumHealthy {
+			pending = true
+		}
+		if err := m.updatePodCondition(ctx, pod, conditionType, health); err != nil {
+			return false, fmt.Errorf("failed to update condition %v of pod %v/%v due to %w", conditionType, pod.Namespace, pod.Name, err)
+		}
+	}
+	return pending, nil
+}
+
+// updatePodCondition sets the conditionType condition of pod according to the health of its target.
+func (m *defaultPodConditionManager) updatePodCondition(ctx context.Context, pod *corev1.Pod, conditionType corev1.PodConditionType, targetHealth *elbv2.TargetHealth) error {
+	desired := corev1.PodCondition{
+		Type:   conditionType,
+		Status: corev1.ConditionFalse,
+		Reason: reasonTargetNotRegistered,
+	}
+	if targetHealth != nil {
+		if aws.StringValue(targetHealth.State) == elbv2.TargetHealthStateEnumHealthy {
+			desired.Status = corev1.ConditionTrue
+		}
+		desired.Reason = aws.StringValue(targetHealth.Reason)
+		desired.Message = aws.StringValue(targetHealth.Description)
+	}
+
+	now := metav1.Now()
+	desired.LastProbeTime = now
+	desired.LastTransitionTime = now
+	updated := pod.DeepCopy()
+	if current := podCondition(updated, conditionType); current != nil {
+		if current.Status == desired.Status && current.Reason == desired.Reason && current.Message == desired.Message {
+			return nil
+		}
+		if current.Status == desired.Status {
+			desired.LastTransitionTime = current.LastTransitionTime
+		}
+		*current = desired
+	} else {
+		updated.Status.Conditions = append(updated.Status.Conditions, desired)
+	}
+
+	albctx.GetLogger(ctx).Infof("setting condition %v of pod %v/%v to %v", conditionType, pod.Namespace, pod.Name, desired.Status)
+	return m.client.Status().Update(ctx, updated)
+}
+
+// gatedPods returns the pods behind backend of ingress that declare conditionType as readiness gate, keyed by their target.
+func gatedPods(store store.Storer, ingress *extensions.Ingress, backend extensions.IngressBackend, conditionType corev1.PodConditionType) (map[string]*corev1.Pod, error) {
+	serviceKey := ServiceKey(ingress, backend)
+	_, servicePort, err := findServiceAndPort(store, serviceKey.Namespace, serviceKey.Name, backend.ServicePort)
+	if err != nil {
+		return nil, err
+	}
+	eps, err := store.GetServiceEndpoints(serviceKey.String())
+	if err != nil {
+		return nil, fmt.Errorf("Unable to find service endpoints for %s: %v", serviceKey, err.Error())
+	}
+
+	pods := make(map[string]*corev1.Pod)
+	for _, epSubset := range eps.Subsets {
+		for _, epPort := range epSubset.Ports {
+			if servicePort.Name != "" && servicePort.Name != epPort.Name {
+				continue
+			}
+			addresses := append(append([]corev1.EndpointAddress{}, epSubset.Addresses...), epSubset.NotReadyAddresses...)
+			for _, epAddr := range addresses {
+				pod := addressPod(store, epAddr)
+				if pod == nil || !hasReadinessGate(pod, conditionType) {
+					continue
+				}
+				pods[targetKey(epAddr.IP, int64(epPort.Port))] = pod
+			}
+		}
+	}
+	return pods, nil
+}
+
+// addressPod returns the pod referenced by an endpoint address, or nil if it doesn't reference a known pod.
+func addressPod(store store.Storer, epAddr corev1.EndpointAddress) *corev1.Pod {
+	if epAddr.TargetRef == nil || epAddr.TargetRef.Kind != "Pod" {
+		return nil
+	}
+	pod, err := store.GetPod(epAddr.TargetRef.Namespace + "/" + epAddr.TargetRef.Name)
+	if err != nil {
+		return nil
+	}
+	return pod
+}
+
+func hasReadinessGate(pod *corev1.Pod, conditionType corev1.PodConditionType) bool {
+	for _, gate := range pod.Spec.ReadinessGates {
+		if gate.ConditionType == conditionType {
+			return true
+		}
+	}
+	return false
+}
+
+func podCondition(pod *corev1.Pod, conditionType corev1.PodConditionType) *corev1.PodCondition {
+	for i := range pod.Status.Conditions {
+		if pod.Status.Conditions[i].Type == conditionType {
+			return &pod.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+func targetKey(ip string, port int64) string {
+	return fmt.Sprintf("%v:%v", ip, port)
+}
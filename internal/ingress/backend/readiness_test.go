@@ -0,0 +1,162 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestTargetHealthConditionType(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		ingress  *extensions.Ingress
+		backend  extensions.IngressBackend
+		expected corev1.PodConditionType
+	}{
+		{
+			name:     "numeric service port",
+			ingress:  &extensions.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "namespace", Name: "ingress"}},
+			backend:  extensions.IngressBackend{ServiceName: "service", ServicePort: intstr.FromInt(80)},
+			expected: "target-health.alb.ingress.k8s.aws/ingress_service_80",
+		},
+		{
+			name:     "named service port",
+			ingress:  &extensions.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "namespace", Name: "ingress"}},
+			backend:  extensions.IngressBackend{ServiceName: "service", ServicePort: intstr.FromString("http")},
+			expected: "target-health.alb.ingress.k8s.aws/ingress_service_http",
+		},
+		{
+			name:     "long names",
+			ingress:  &extensions.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "namespace", Name: "my-very-long-ingress-name-for-the-storefront"}},
+			backend:  extensions.IngressBackend{ServiceName: "storefront-checkout-service", ServicePort: intstr.FromString("https")},
+			expected: "target-health.alb.ingress.k8s.aws/my-very-long-ingress-name-for-the-storefront_storefron_d6fae86b",
+		},
+		{
+			name:     "backend of merged IngressGroup ingress",
+			ingress:  &extensions.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "namespace", Name: "group"}},
+			backend:  extensions.IngressBackend{ServiceName: "namespace/ingress/service", ServicePort: intstr.FromInt(80)},
+			expected: "target-health.alb.ingress.k8s.aws/group_namespace_ingress_service_80",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, TargetHealthConditionType(tc.ingress, tc.backend))
+		})
+	}
+}
+
+func Test_defaultPodConditionManager_Reconcile(t *testing.T) {
+	const conditionType = "target-health.alb.ingress.k8s.aws/ingress_service_80"
+	ingress := &extensions.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "namespace", Name: "ingress"}}
+	backend := extensions.IngressBackend{ServiceName: "service", ServicePort: intstr.FromInt(80)}
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "namespace", Name: "service"},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{{Port: 80}},
+		},
+	}
+	endpoints := &corev1.Endpoints{
+		Subsets: []corev1.EndpointSubset{
+			{
+				NotReadyAddresses: []corev1.EndpointAddress{
+					{IP: "192.168.1.1", TargetRef: &corev1.ObjectReference{Kind: "Pod", Namespace: "namespace", Name: "pod"}},
+				},
+				Ports: []corev1.EndpointPort{{Port: 8080}},
+			},
+		},
+	}
+
+	for _, tc := range []struct {
+		name              string
+		pod               *corev1.Pod
+		targetHealth      *elbv2.TargetHealth
+		expectedCondition *corev1.PodCondition
+		expectedRefresh   bool
+	}{
+		{
+			name: "pod without readiness gate",
+			pod:  &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "namespace", Name: "pod"}},
+		},
+		{
+			name: "pod gated with unregistered target",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "namespace", Name: "pod"},
+				Spec:       corev1.PodSpec{ReadinessGates: []corev1.PodReadinessGate{{ConditionType: conditionType}}},
+			},
+			expectedCondition: &corev1.PodCondition{Type: conditionType, Status: corev1.ConditionFalse, Reason: reasonTargetNotRegistered},
+			expectedRefresh:   true,
+		},
+		{
+			name: "pod gated with unhealthy target",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "namespace", Name: "pod"},
+				Spec:       corev1.PodSpec{ReadinessGates: []corev1.PodReadinessGate{{ConditionType: conditionType}}},
+			},
+			targetHealth:      &elbv2.TargetHealth{State: aws.String(elbv2.TargetHealthStateEnumInitial), Reason: aws.String(elbv2.TargetHealthReasonEnumElbInitialHealthChecking)},
+			expectedCondition: &corev1.PodCondition{Type: conditionType, Status: corev1.ConditionFalse, Reason: elbv2.TargetHealthReasonEnumElbInitialHealthChecking},
+			expectedRefresh:   true,
+		},
+		{
+			name: "pod gated with healthy target",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "namespace", Name: "pod"},
+				Spec:       corev1.PodSpec{ReadinessGates: []corev1.PodReadinessGate{{ConditionType: conditionType}}},
+			},
+			targetHealth:      &elbv2.TargetHealth{State: aws.String(elbv2.TargetHealthStateEnumHealthy)},
+			expectedCondition: &corev1.PodCondition{Type: conditionType, Status: corev1.ConditionTrue},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			store := store.NewDummy()
+			store.GetServiceFunc = func(string) (*corev1.Service, error) { return service, nil }
+			store.GetServiceEndpointsFunc = func(string) (*corev1.Endpoints, error) { return endpoints, nil }
+			store.GetPodFunc = func(key string) (*corev1.Pod, error) {
+				if key == "namespace/pod" {
+					return tc.pod, nil
+				}
+				return nil, fmt.Errorf("No such pod")
+			}
+			resp := &elbv2.DescribeTargetHealthOutput{}
+			if tc.targetHealth != nil {
+				resp.TargetHealthDescriptions = []*elbv2.TargetHealthDescription{
+					{Target: &elbv2.TargetDescription{Id: aws.String("192.168.1.1"), Port: aws.Int64(8080)}, TargetHealth: tc.targetHealth},
+				}
+			}
+			cloud := &mocks.CloudAPI{}
+			cloud.On("DescribeTargetHealthWithContext", ctx, &elbv2.DescribeTargetHealthInput{TargetGroupArn: aws.String("tgArn")}).Return(resp, nil)
+			client := fake.NewFakeClient(tc.pod.DeepCopy())
+
+			m := NewPodConditionManager(client, store, cloud).(*defaultPodConditionManager)
+			assert.NoError(t, m.Reconcile(ctx, ingress, backend, "tgArn"))
+			_, scheduled := m.refreshes["tgArn "+conditionType]
+			assert.Equal(t, tc.expectedRefresh, scheduled)
+			for _, refresh := range m.refreshes {
+				refresh.timer.Stop()
+			}
+
+			pod := &corev1.Pod{}
+			assert.NoError(t, client.Get(ctx, types.NamespacedName{Namespace: "namespace", Name: "pod"}, pod))
+			condition := podCondition(pod, conditionType)
+			if tc.expectedCondition == nil {
+				assert.Nil(t, condition)
+				return
+			}
+			if assert.NotNil(t, condition) {
+				assert.Equal(t, tc.expectedCondition.Status, condition.Status)
+				assert.Equal(t, tc.expectedCondition.Reason, condition.Reason)
+			}
+		})
+	}
+}
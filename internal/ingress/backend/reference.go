@@ -0,0 +1,201 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/action"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
+	corev1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ReferenceGrantConfigMap is the name of configMaps that grant ingresses in other namespaces access to services.
+// The Key:Value pair are interpreted as "ingress namespace: comma-separated list of serviceNames", where `*` grants all services.
+const ReferenceGrantConfigMap = "alb-ingress-controller-reference-grants"
+
+const referenceGrantAll = "*"
+
+// ReferenceNotGranted error is returned for services that aren't granted to ingresses in the namespace of the ingress.
+type ReferenceNotGranted struct {
+	Reason string
+}
+
+func (e ReferenceNotGranted) Error() string {
+	return e.Reason
+}
+
+// IsReferenceNotGranted checks if err indicates a service isn't granted to an ingress.
+func IsReferenceNotGranted(err error) bool {
+	_, ok := err.(ReferenceNotGranted)
+	return ok
+}
+
+// ServiceKey returns the key of the service referenced by backend of ingress.
+// The service is in the namespace of ingress, unless specified by the `service-namespace.${service-name}` annotation.
+// Backends of merged IngressGroup ingresses are qualified by their member ingress, like `namespace/ingress/service-name`.
+func ServiceKey(ingress *extensions.Ingress, backend extensions.IngressBackend) types.NamespacedName {
+	namespace := ingress.Namespace
+	if serviceNamespace := ingress.Annotations[parser.GetAnnotationWithPrefix("service-namespace."+backend.ServiceName)]; serviceNamespace != "" {
+		namespace = strings.TrimSpace(serviceNamespace)
+	}
+	name := backend.ServiceName
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	return types.NamespacedName{Namespace: namespace, Name: name}
+}
+
+// ServiceKeys returns the keys of services referenced by backends of ingress, or forwarded to by its actions, in sorted order.
+func ServiceKeys(ingress *extensions.Ingress) []types.NamespacedName {
+	serviceKeys := make(map[types.NamespacedName]bool)
+	addBackend := func(backend *extensions.IngressBackend) {
+		if backend == nil || action.Use(backend.ServicePort.String()) {
+			return
+		}
+		serviceKeys[ServiceKey(ingress, *backend)] = true
+	}
+
+	addBackend(ingress.Spec.Backend)
+	for _, rule := range ingress.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for i := range rule.HTTP.Paths {
+			addBackend(&rule.HTTP.Paths[i].Backend)
+		}
+	}
+	// malformed actions fail the reconcile of ingress, so they reference no service until fixed.
+	if cfg, err := action.NewParser(nil).Parse(ingress); err == nil {
+		for _, act := range cfg.(*action.Config).Actions {
+			if act.ForwardConfig == nil {
+				continue
+			}
+			for _, tgt := range act.ForwardConfig.TargetGroups {
+				if tgt.ServiceName != nil {
+					addBackend(&extensions.IngressBackend{
+						ServiceName: aws.StringValue(tgt.ServiceName),
+						ServicePort: intstr.Parse(aws.StringValue(tgt.ServicePort)),
+					})
+				}
+			}
+		}
+	}
+
+	var result []types.NamespacedName
+	for serviceKey := range serviceKeys {
+		result = append(result, serviceKey)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].String() < result[j].String()
+	})
+	return result
+}
+
+// ReferencesOtherNamespaces returns whether ingress references services in other namespaces than its own.
+func ReferencesOtherNamespaces(ingress *extensions.Ingress) bool {
+	for _, serviceKey := range ServiceKeys(ingress) {
+		if serviceKey.Namespace != ingress.Namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckReferenceGrant returns an error unless ingress is granted access to service with serviceKey.
+// Services in the namespace of ingress are always accessible.
+func CheckReferenceGrant(ctx context.Context, reader client.Reader, ingress *extensions.Ingress, serviceKey types.NamespacedName) error {
+	if serviceKey.Namespace == ingress.Namespace {
+		return nil
+	}
+	configMap := &corev1.ConfigMap{}
+	configMapKey := types.NamespacedName{Namespace: serviceKey.Namespace, Name: ReferenceGrantConfigMap}
+	if err := reader.Get(ctx, configMapKey, configMap); err != nil {
+		if errors.IsNotFound(err) {
+			return ReferenceNotGranted{Reason: fmt.Sprintf("service %v is not granted to ingresses in namespace %v, no configMap %v", serviceKey, ingress.Namespace, configMapKey)}
+		}
+		return fmt.Errorf("failed to load reference grants %v due to %w", configMapKey, err)
+	}
+	for _, serviceName := range strings.Split(configMap.Data[ingress.Namespace], ",") {
+		serviceName = strings.TrimSpace(serviceName)
+		if serviceName == referenceGrantAll || serviceName == serviceKey.Name {
+			return nil
+		}
+	}
+	return ReferenceNotGranted{Reason: fmt.Sprintf("service %v is not granted to ingresses in namespace %v by configMap %v", serviceKey, ingress.Namespace, configMapKey)}
+}
+
+// PruneUngrantedBackends returns a copy of ingress without the backends, paths and actions that route to services not granted to it,
+// so that their rules and targetGroups are removed once a grant is revoked. The ReferenceNotGranted errors of those services are returned as well.
+// ingress itself is returned if all services are granted.
+func PruneUngrantedBackends(ctx context.Context, reader client.Reader, ingress *extensions.Ingress) (*extensions.Ingress, []error, error) {
+	ungranted := make(map[types.NamespacedName]bool)
+	var notGrantedErrs []error
+	for _, serviceKey := range ServiceKeys(ingress) {
+		if err := CheckReferenceGrant(ctx, reader, ingress, serviceKey); err != nil {
+			if !IsReferenceNotGranted(err) {
+				return nil, nil, err
+			}
+			ungranted[serviceKey] = true
+			notGrantedErrs = append(notGrantedErrs, err)
+		}
+	}
+	if len(ungranted) == 0 {
+		return ingress, nil, nil
+	}
+
+	pruned := ingress.DeepCopy()
+	ungrantedActions := sets.NewString()
+	if cfg, err := action.NewParser(nil).Parse(ingress); err == nil {
+		for actionName, act := range cfg.(*action.Config).Actions {
+			if act.ForwardConfig == nil {
+				continue
+			}
+			for _, tgt := range act.ForwardConfig.TargetGroups {
+				if tgt.ServiceName != nil && ungranted[ServiceKey(ingress, extensions.IngressBackend{ServiceName: aws.StringValue(tgt.ServiceName)})] {
+					ungrantedActions.Insert(actionName)
+					delete(pruned.Annotations, parser.GetAnnotationWithPrefix("actions."+actionName))
+					break
+				}
+			}
+		}
+	}
+	granted := func(backend extensions.IngressBackend) bool {
+		if action.Use(backend.ServicePort.String()) {
+			return !ungrantedActions.Has(backend.ServiceName)
+		}
+		return !ungranted[ServiceKey(ingress, backend)]
+	}
+
+	if pruned.Spec.Backend != nil && !granted(*pruned.Spec.Backend) {
+		pruned.Spec.Backend = nil
+	}
+	var rules []extensions.IngressRule
+	for _, rule := range pruned.Spec.Rules {
+		if rule.HTTP == nil {
+			rules = append(rules, rule)
+			continue
+		}
+		var paths []extensions.HTTPIngressPath
+		for _, path := range rule.HTTP.Paths {
+			if granted(path.Backend) {
+				paths = append(paths, path)
+			}
+		}
+		if len(paths) == 0 {
+			continue
+		}
+		rule.HTTP.Paths = paths
+		rules = append(rules, rule)
+	}
+	pruned.Spec.Rules = rules
+	return pruned, notGrantedErrs, nil
+}
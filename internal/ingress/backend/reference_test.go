@@ -0,0 +1,218 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	mock_cache "github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks/controller-runtime/cache"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestServiceKeys(t *testing.T) {
+	ingress := &extensions.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "team",
+			Name:      "ingress",
+			Annotations: map[string]string{
+				"alb.ingress.kubernetes.io/service-namespace.shared": "platform",
+				"alb.ingress.kubernetes.io/actions.weighted": `{"Type": "forward", "ForwardConfig": {"TargetGroups": [` +
+					`{"ServiceName": "canary", "ServicePort": "80", "Weight": 10}, {"ServiceName": "web", "ServicePort": "80", "Weight": 90}]}}`,
+				"alb.ingress.kubernetes.io/actions.redirect": `{"Type": "redirect", "RedirectConfig": {"Protocol": "HTTPS", "StatusCode": "HTTP_301"}}`,
+			},
+		},
+		Spec: extensions.IngressSpec{
+			Backend: &extensions.IngressBackend{ServiceName: "web", ServicePort: intstr.FromInt(80)},
+			Rules: []extensions.IngressRule{
+				{
+					IngressRuleValue: extensions.IngressRuleValue{
+						HTTP: &extensions.HTTPIngressRuleValue{
+							Paths: []extensions.HTTPIngressPath{
+								{Path: "/shared", Backend: extensions.IngressBackend{ServiceName: "shared", ServicePort: intstr.FromInt(80)}},
+								{Path: "/web", Backend: extensions.IngressBackend{ServiceName: "web", ServicePort: intstr.FromInt(8080)}},
+								{Path: "/redirect", Backend: extensions.IngressBackend{ServiceName: "redirect", ServicePort: intstr.FromString("use-annotation")}},
+								{Path: "/weighted", Backend: extensions.IngressBackend{ServiceName: "weighted", ServicePort: intstr.FromString("use-annotation")}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	assert.Equal(t, []types.NamespacedName{
+		{Namespace: "platform", Name: "shared"},
+		{Namespace: "team", Name: "canary"},
+		{Namespace: "team", Name: "web"},
+	}, ServiceKeys(ingress))
+}
+
+func TestCheckReferenceGrant(t *testing.T) {
+	ingress := &extensions.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "team", Name: "ingress"},
+	}
+	grantKey := types.NamespacedName{Namespace: "platform", Name: ReferenceGrantConfigMap}
+	for _, tc := range []struct {
+		name        string
+		serviceKey  types.NamespacedName
+		grants      *corev1.ConfigMap
+		getErr      error
+		expectedErr string
+	}{
+		{
+			name:       "service in same namespace",
+			serviceKey: types.NamespacedName{Namespace: "team", Name: "web"},
+		},
+		{
+			name:       "service granted",
+			serviceKey: types.NamespacedName{Namespace: "platform", Name: "shared"},
+			grants:     &corev1.ConfigMap{Data: map[string]string{"team": "other, shared"}},
+		},
+		{
+			name:       "all services granted",
+			serviceKey: types.NamespacedName{Namespace: "platform", Name: "shared"},
+			grants:     &corev1.ConfigMap{Data: map[string]string{"team": "*"}},
+		},
+		{
+			name:        "service not granted",
+			serviceKey:  types.NamespacedName{Namespace: "platform", Name: "shared"},
+			grants:      &corev1.ConfigMap{Data: map[string]string{"other-team": "shared"}},
+			expectedErr: "service platform/shared is not granted to ingresses in namespace team by configMap platform/alb-ingress-controller-reference-grants",
+		},
+		{
+			name:        "no reference grants",
+			serviceKey:  types.NamespacedName{Namespace: "platform", Name: "shared"},
+			getErr:      apierrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, ReferenceGrantConfigMap),
+			expectedErr: "service platform/shared is not granted to ingresses in namespace team, no configMap platform/alb-ingress-controller-reference-grants",
+		},
+		{
+			name:        "reference grants cannot be loaded",
+			serviceKey:  types.NamespacedName{Namespace: "platform", Name: "shared"},
+			getErr:      errors.New("timeout"),
+			expectedErr: "failed to load reference grants platform/alb-ingress-controller-reference-grants due to timeout",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockCache := mock_cache.NewMockCache(ctrl)
+			if tc.grants != nil {
+				mockCache.EXPECT().Get(gomock.Any(), grantKey, gomock.Any()).SetArg(2, *tc.grants)
+			}
+			if tc.getErr != nil {
+				mockCache.EXPECT().Get(gomock.Any(), grantKey, gomock.Any()).Return(tc.getErr)
+			}
+
+			err := CheckReferenceGrant(context.Background(), mockCache, ingress, tc.serviceKey)
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPruneUngrantedBackends(t *testing.T) {
+	ingress := &extensions.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "team",
+			Name:      "ingress",
+			Annotations: map[string]string{
+				"alb.ingress.kubernetes.io/service-namespace.shared": "platform",
+				"alb.ingress.kubernetes.io/actions.weighted": `{"Type": "forward", "ForwardConfig": {"TargetGroups": [` +
+					`{"ServiceName": "shared", "ServicePort": "80", "Weight": 10}, {"ServiceName": "web", "ServicePort": "80", "Weight": 90}]}}`,
+				"alb.ingress.kubernetes.io/actions.redirect": `{"Type": "redirect", "RedirectConfig": {"Protocol": "HTTPS", "StatusCode": "HTTP_301"}}`,
+			},
+		},
+		Spec: extensions.IngressSpec{
+			Backend: &extensions.IngressBackend{ServiceName: "shared", ServicePort: intstr.FromInt(80)},
+			Rules: []extensions.IngressRule{
+				{
+					Host: "shared.example.com",
+					IngressRuleValue: extensions.IngressRuleValue{
+						HTTP: &extensions.HTTPIngressRuleValue{
+							Paths: []extensions.HTTPIngressPath{
+								{Path: "/shared", Backend: extensions.IngressBackend{ServiceName: "shared", ServicePort: intstr.FromInt(80)}},
+							},
+						},
+					},
+				},
+				{
+					Host: "web.example.com",
+					IngressRuleValue: extensions.IngressRuleValue{
+						HTTP: &extensions.HTTPIngressRuleValue{
+							Paths: []extensions.HTTPIngressPath{
+								{Path: "/shared", Backend: extensions.IngressBackend{ServiceName: "shared", ServicePort: intstr.FromInt(80)}},
+								{Path: "/web", Backend: extensions.IngressBackend{ServiceName: "web", ServicePort: intstr.FromInt(80)}},
+								{Path: "/redirect", Backend: extensions.IngressBackend{ServiceName: "redirect", ServicePort: intstr.FromString("use-annotation")}},
+								{Path: "/weighted", Backend: extensions.IngressBackend{ServiceName: "weighted", ServicePort: intstr.FromString("use-annotation")}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	grantKey := types.NamespacedName{Namespace: "platform", Name: ReferenceGrantConfigMap}
+
+	t.Run("granted", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockCache := mock_cache.NewMockCache(ctrl)
+		mockCache.EXPECT().Get(gomock.Any(), grantKey, gomock.Any()).SetArg(2, corev1.ConfigMap{Data: map[string]string{"team": "shared"}})
+
+		pruned, notGrantedErrs, err := PruneUngrantedBackends(context.Background(), mockCache, ingress)
+		assert.NoError(t, err)
+		assert.Empty(t, notGrantedErrs)
+		assert.True(t, pruned == ingress)
+	})
+
+	t.Run("grant revoked", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockCache := mock_cache.NewMockCache(ctrl)
+		mockCache.EXPECT().Get(gomock.Any(), grantKey, gomock.Any()).SetArg(2, corev1.ConfigMap{Data: map[string]string{"team": "other"}})
+
+		pruned, notGrantedErrs, err := PruneUngrantedBackends(context.Background(), mockCache, ingress)
+		assert.NoError(t, err)
+		assert.Len(t, notGrantedErrs, 1)
+		assert.Nil(t, pruned.Spec.Backend)
+		assert.Equal(t, []extensions.IngressRule{
+			{
+				Host: "web.example.com",
+				IngressRuleValue: extensions.IngressRuleValue{
+					HTTP: &extensions.HTTPIngressRuleValue{
+						Paths: []extensions.HTTPIngressPath{
+							{Path: "/web", Backend: extensions.IngressBackend{ServiceName: "web", ServicePort: intstr.FromInt(80)}},
+							{Path: "/redirect", Backend: extensions.IngressBackend{ServiceName: "redirect", ServicePort: intstr.FromString("use-annotation")}},
+						},
+					},
+				},
+			},
+		}, pruned.Spec.Rules)
+		assert.NotContains(t, pruned.Annotations, "alb.ingress.kubernetes.io/actions.weighted")
+		assert.Contains(t, pruned.Annotations, "alb.ingress.kubernetes.io/actions.redirect")
+		// ingress itself is left untouched.
+		assert.Len(t, ingress.Spec.Rules, 2)
+		assert.Contains(t, ingress.Annotations, "alb.ingress.kubernetes.io/actions.weighted")
+	})
+
+	t.Run("grants cannot be loaded", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockCache := mock_cache.NewMockCache(ctrl)
+		mockCache.EXPECT().Get(gomock.Any(), grantKey, gomock.Any()).Return(errors.New("timeout"))
+
+		_, _, err := PruneUngrantedBackends(context.Background(), mockCache, ingress)
+		assert.EqualError(t, err, "failed to load reference grants platform/alb-ingress-controller-reference-grants due to timeout")
+	})
+}
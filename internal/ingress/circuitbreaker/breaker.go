@@ -0,0 +1,131 @@
+package circuitbreaker
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
+	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Breaker stops reconciling an ingress for a cool-down window after it fails the same AWS operation repeatedly,
+// so a single broken ingress cannot exhaust AWS API quotas shared by the whole cluster.
+type Breaker interface {
+	// Check returns whether the circuit of ingress is open, and the time it closes.
+	// A circuit opened for an earlier version of ingress is closed, since its spec or annotations may have been fixed.
+	Check(ingressKey types.NamespacedName, version string) (bool, time.Time)
+
+	// RecordFailure records that reconcile of ingress failed on operation, and returns whether the circuit is opened.
+	RecordFailure(ingressKey types.NamespacedName, version string, operation string) bool
+
+	// RecordSuccess closes the circuit of ingress.
+	RecordSuccess(ingressKey types.NamespacedName)
+}
+
+// NewBreaker constructs a Breaker which opens after threshold consecutive failures of the same operation for coolDown.
+// The Breaker never opens if threshold is zero.
+func NewBreaker(threshold int, coolDown time.Duration) Breaker {
+	return &defaultBreaker{
+		threshold: threshold,
+		coolDown:  coolDown,
+		circuits:  make(map[types.NamespacedName]*circuit),
+		now:       time.Now,
+	}
+}
+
+type circuit struct {
+	version   string
+	operation string
+	failures  int
+	openUntil time.Time
+
+	// halfOpen is true once the cool-down passed, a single failure reopens the circuit.
+	halfOpen bool
+}
+
+type defaultBreaker struct {
+	threshold int
+	coolDown  time.Duration
+
+	mutex    sync.Mutex
+	circuits map[types.NamespacedName]*circuit
+
+	now func() time.Time
+}
+
+func (b *defaultBreaker) Check(ingressKey types.NamespacedName, version string) (bool, time.Time) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	c, ok := b.circuits[ingressKey]
+	if !ok {
+		return false, time.Time{}
+	}
+	if c.version != version {
+		delete(b.circuits, ingressKey)
+		return false, time.Time{}
+	}
+	if c.openUntil.IsZero() {
+		return false, time.Time{}
+	}
+	if b.now().Before(c.openUntil) {
+		return true, c.openUntil
+	}
+	c.openUntil = time.Time{}
+	c.halfOpen = true
+	return false, time.Time{}
+}
+
+func (b *defaultBreaker) RecordFailure(ingressKey types.NamespacedName, version string, operation string) bool {
+	if b.threshold <= 0 {
+		return false
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	c, ok := b.circuits[ingressKey]
+	if !ok || c.version != version || c.operation != operation {
+		c = &circuit{
+			version:   version,
+			operation: operation,
+		}
+		b.circuits[ingressKey] = c
+	}
+	c.failures++
+	if c.halfOpen || c.failures >= b.threshold {
+		c.openUntil = b.now().Add(b.coolDown)
+		c.failures = 0
+		c.halfOpen = false
+		return true
+	}
+	return false
+}
+
+func (b *defaultBreaker) RecordSuccess(ingressKey types.NamespacedName) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	delete(b.circuits, ingressKey)
+}
+
+// Version returns the version of ingress for Breaker, which changes with its spec and its annotations configuring the controller.
+// Other annotations, like the conditions recorded by the controller, don't fix failures.
+func Version(ingress *extensions.Ingress) string {
+	var keys []string
+	for k := range ingress.Annotations {
+		if strings.HasPrefix(k, parser.AnnotationsPrefix+"/") {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	hasher := sha256.New()
+	for _, k := range keys {
+		_, _ = hasher.Write([]byte(k + "=" + ingress.Annotations[k] + "\n"))
+	}
+	return strconv.FormatInt(ingress.Generation, 10) + "/" + hex.EncodeToString(hasher.Sum(nil))[:16]
+}
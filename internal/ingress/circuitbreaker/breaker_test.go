@@ -0,0 +1,105 @@
+package circuitbreaker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func newTestBreaker(threshold int, coolDown time.Duration, now *time.Time) *defaultBreaker {
+	b := NewBreaker(threshold, coolDown).(*defaultBreaker)
+	b.now = func() time.Time { return *now }
+	return b
+}
+
+func TestDefaultBreaker(t *testing.T) {
+	ingressKey := types.NamespacedName{Namespace: "namespace", Name: "ingress"}
+	start := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("opens after consecutive failures of same operation", func(t *testing.T) {
+		now := start
+		b := newTestBreaker(3, 10*time.Minute, &now)
+		assert.False(t, b.RecordFailure(ingressKey, "1", "elasticloadbalancing/CreateLoadBalancer"))
+		assert.False(t, b.RecordFailure(ingressKey, "1", "elasticloadbalancing/CreateLoadBalancer"))
+		assert.True(t, b.RecordFailure(ingressKey, "1", "elasticloadbalancing/CreateLoadBalancer"))
+
+		open, until := b.Check(ingressKey, "1")
+		assert.True(t, open)
+		assert.Equal(t, start.Add(10*time.Minute), until)
+	})
+
+	t.Run("failures of different operations are not consecutive", func(t *testing.T) {
+		now := start
+		b := newTestBreaker(2, 10*time.Minute, &now)
+		assert.False(t, b.RecordFailure(ingressKey, "1", "elasticloadbalancing/CreateLoadBalancer"))
+		assert.False(t, b.RecordFailure(ingressKey, "1", "ec2/CreateSecurityGroup"))
+		assert.True(t, b.RecordFailure(ingressKey, "1", "ec2/CreateSecurityGroup"))
+	})
+
+	t.Run("success resets failures", func(t *testing.T) {
+		now := start
+		b := newTestBreaker(2, 10*time.Minute, &now)
+		assert.False(t, b.RecordFailure(ingressKey, "1", "elasticloadbalancing/CreateLoadBalancer"))
+		b.RecordSuccess(ingressKey)
+		assert.False(t, b.RecordFailure(ingressKey, "1", "elasticloadbalancing/CreateLoadBalancer"))
+	})
+
+	t.Run("half open after cool-down", func(t *testing.T) {
+		now := start
+		b := newTestBreaker(2, 10*time.Minute, &now)
+		b.RecordFailure(ingressKey, "1", "elasticloadbalancing/CreateLoadBalancer")
+		b.RecordFailure(ingressKey, "1", "elasticloadbalancing/CreateLoadBalancer")
+
+		now = start.Add(10 * time.Minute)
+		open, _ := b.Check(ingressKey, "1")
+		assert.False(t, open)
+		assert.True(t, b.RecordFailure(ingressKey, "1", "elasticloadbalancing/CreateLoadBalancer"))
+		open, until := b.Check(ingressKey, "1")
+		assert.True(t, open)
+		assert.Equal(t, start.Add(20*time.Minute), until)
+	})
+
+	t.Run("new version closes circuit", func(t *testing.T) {
+		now := start
+		b := newTestBreaker(1, 10*time.Minute, &now)
+		assert.True(t, b.RecordFailure(ingressKey, "1", "elasticloadbalancing/CreateLoadBalancer"))
+		open, _ := b.Check(ingressKey, "2")
+		assert.False(t, open)
+		open, _ = b.Check(ingressKey, "1")
+		assert.False(t, open)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		now := start
+		b := newTestBreaker(0, 10*time.Minute, &now)
+		assert.False(t, b.RecordFailure(ingressKey, "1", "elasticloadbalancing/CreateLoadBalancer"))
+		open, _ := b.Check(ingressKey, "1")
+		assert.False(t, open)
+	})
+}
+
+func TestVersion(t *testing.T) {
+	ingress := &extensions.Ingress{ObjectMeta: metav1.ObjectMeta{
+		Generation: 1,
+		Annotations: map[string]string{
+			"alb.ingress.kubernetes.io/scheme": "internal",
+		},
+	}}
+	version := Version(ingress)
+
+	conditions := ingress.DeepCopy()
+	conditions.Annotations["ingress.k8s.aws/conditions"] = "[]"
+	assert.Equal(t, version, Version(conditions))
+
+	annotated := ingress.DeepCopy()
+	annotated.Annotations["alb.ingress.kubernetes.io/scheme"] = "internet-facing"
+	assert.NotEqual(t, version, Version(annotated))
+
+	generation := ingress.DeepCopy()
+	generation.Generation = 2
+	assert.NotEqual(t, version, Version(generation))
+}
@@ -0,0 +1,351 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/health"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/lb"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/verify"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/loadbalancer"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/circuitbreaker"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/k8s"
+	corev1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// AnnotationConditions records the conditions of an ingress as JSON, since extensions/v1beta1 ingresses have no status conditions.
+const AnnotationConditions = "ingress.k8s.aws/conditions"
+
+// ConditionTypeDegraded is true when unhealthy targets of an ingress exceed the degraded threshold.
+const ConditionTypeDegraded = "Degraded"
+
+// ConditionTypeReady is true when synthetic requests against the LoadBalancers of an ingress succeeded after reconcile.
+const ConditionTypeReady = "Ready"
+
+// ConditionTypeCircuitOpen is true when reconcile of an ingress is paused after repeated failures of an AWS operation.
+const ConditionTypeCircuitOpen = "CircuitOpen"
+
+// ConditionTypeReconciled is true when the last reconcile of an ingress succeeded, otherwise its message is the error.
+const ConditionTypeReconciled = "Reconciled"
+
+// ConditionTypeLoadBalancerReady is true when all LoadBalancers of an ingress are active.
+const ConditionTypeLoadBalancerReady = "LoadBalancerReady"
+
+// ConditionTypeCertificateAttached is true when certificates are attached to the HTTPS listeners of an ingress,
+// it doesn't exist for ingresses without HTTPS listeners.
+const ConditionTypeCertificateAttached = "CertificateAttached"
+
+// ConditionTypeTargetsHealthy is true when no target behind the LoadBalancers of an ingress is unhealthy.
+const ConditionTypeTargetsHealthy = "TargetsHealthy"
+
+const (
+	reasonRepeatedFailures       = "RepeatedFailures"
+	reasonUnhealthyTargets       = "UnhealthyTargets"
+	reasonHealthyTargets         = "HealthyTargets"
+	reasonVerified               = "VerificationSucceeded"
+	reasonNotVerified            = "VerificationFailed"
+	reasonReconcileSucceeded     = "ReconcileSucceeded"
+	reasonReconcileFailed        = "ReconcileFailed"
+	reasonLoadBalancerActive     = "LoadBalancerActive"
+	reasonLoadBalancerNotActive  = "LoadBalancerNotActive"
+	reasonCertificatesAttached   = "CertificatesAttached"
+	reasonCertificatesUnresolved = "CertificatesUnresolved"
+)
+
+// healthRequeueInterval is the interval to re-evaluate target health of ingresses with a degraded threshold.
+const healthRequeueInterval = 1 * time.Minute
+
+// verificationRequeueInterval is the interval to retry verification requests of ingresses that aren't Ready.
+const verificationRequeueInterval = 30 * time.Second
+
+// provisioningRequeueInterval is the interval to refresh the LoadBalancerReady condition of ingresses whose LoadBalancers aren't active yet.
+const provisioningRequeueInterval = 30 * time.Second
+
+// IngressCondition is a condition of an ingress, in the same shape as conditions of other k8s objects.
+type IngressCondition struct {
+	Type               string                 `json:"type"`
+	Status             corev1.ConditionStatus `json:"status"`
+	LastTransitionTime metav1.Time            `json:"lastTransitionTime,omitempty"`
+	Reason             string                 `json:"reason,omitempty"`
+	Message            string                 `json:"message,omitempty"`
+}
+
+// statusReport is the state of an ingress observed during reconcile, from which its status conditions are derived.
+type statusReport struct {
+	// lbInfos are the LoadBalancers of the ingress, nil if reconcile failed before they were reconciled.
+	lbInfos []*lb.LoadBalancer
+	// health is the health of targets behind lbInfos, nil if it couldn't be checked.
+	health *health.Result
+	// certificates are the certificates resolved for HTTPS listeners of the ingress.
+	certificates *albctx.Certificates
+}
+
+func newStatusReport() *statusReport {
+	return &statusReport{certificates: &albctx.Certificates{}}
+}
+
+// checkHealth records the health of targets behind lbInfos into report, failures are returned only if they're required by threshold.
+func (r *Reconciler) checkHealth(ctx context.Context, report *statusReport, threshold *intstr.IntOrString, lbInfos []*lb.LoadBalancer) error {
+	result, err := r.healthChecker.Check(ctx, lbInfos)
+	if err != nil {
+		if threshold != nil {
+			return err
+		}
+		albctx.GetLogger(ctx).Warnf("failed to check target health due to %v", err)
+		return nil
+	}
+	report.health = &result
+	return nil
+}
+
+// reconcileStatusConditions sets the Reconciled, LoadBalancerReady, CertificateAttached and TargetsHealthy conditions of ingress
+// from report and the result of its reconcile, with a single update. Conditions that report knows nothing about are left untouched.
+func (r *Reconciler) reconcileStatusConditions(ctx context.Context, ingress *extensions.Ingress, report *statusReport, reconcileErr error) error {
+	current := getIngressConditions(ingress)
+	conditions := append([]IngressCondition(nil), current...)
+
+	reconciled := IngressCondition{Type: ConditionTypeReconciled, Status: corev1.ConditionTrue, Reason: reasonReconcileSucceeded}
+	if reconcileErr != nil {
+		reconciled = IngressCondition{Type: ConditionTypeReconciled, Status: corev1.ConditionFalse, Reason: reasonReconcileFailed, Message: reconcileErr.Error()}
+	}
+	conditions = setIngressCondition(conditions, reconciled)
+
+	if report.lbInfos != nil {
+		lbReady := IngressCondition{Type: ConditionTypeLoadBalancerReady, Status: corev1.ConditionTrue, Reason: reasonLoadBalancerActive}
+		if notActive := inactiveLoadBalancers(report.lbInfos); len(notActive) != 0 {
+			lbReady = IngressCondition{Type: ConditionTypeLoadBalancerReady, Status: corev1.ConditionFalse, Reason: reasonLoadBalancerNotActive,
+				Message: strings.Join(notActive, ", ")}
+		}
+		conditions = setIngressCondition(conditions, lbReady)
+	}
+
+	switch certArns := sets.NewString(report.certificates.Attached()...); {
+	case report.certificates.Failure() != "":
+		conditions = setIngressCondition(conditions, IngressCondition{Type: ConditionTypeCertificateAttached, Status: corev1.ConditionFalse,
+			Reason: reasonCertificatesUnresolved, Message: report.certificates.Failure()})
+	case certArns.Len() != 0:
+		conditions = setIngressCondition(conditions, IngressCondition{Type: ConditionTypeCertificateAttached, Status: corev1.ConditionTrue,
+			Reason: reasonCertificatesAttached, Message: strings.Join(certArns.List(), ", ")})
+	case reconcileErr == nil:
+		conditions = removeIngressCondition(conditions, ConditionTypeCertificateAttached)
+	}
+
+	if report.health != nil {
+		targetsHealthy := IngressCondition{Type: ConditionTypeTargetsHealthy, Status: corev1.ConditionTrue, Reason: reasonHealthyTargets, Message: report.health.String()}
+		if report.health.UnhealthyTargets != 0 {
+			targetsHealthy.Status = corev1.ConditionFalse
+			targetsHealthy.Reason = reasonUnhealthyTargets
+		}
+		conditions = setIngressCondition(conditions, targetsHealthy)
+	}
+
+	if equalIngressConditions(current, conditions) {
+		return nil
+	}
+	return r.updateIngressConditions(ctx, ingress, conditions)
+}
+
+// inactiveLoadBalancers describes the LoadBalancers of lbInfos that aren't active.
+func inactiveLoadBalancers(lbInfos []*lb.LoadBalancer) []string {
+	var notActive []string
+	for _, lbInfo := range lbInfos {
+		if lbInfo.State != elbv2.LoadBalancerStateEnumActive {
+			notActive = append(notActive, fmt.Sprintf("%v is %v", lbInfo.DNSName, lbInfo.State))
+		}
+	}
+	return notActive
+}
+
+// reconcileDegradedCondition sets the Degraded condition of ingress by the health of targets behind its LoadBalancers,
+// and emits an event when the ingress becomes Degraded or recovers. The condition is removed when threshold is nil.
+func (r *Reconciler) reconcileDegradedCondition(ctx context.Context, ingress *extensions.Ingress, threshold *intstr.IntOrString, report *statusReport) error {
+	conditions := getIngressConditions(ingress)
+	current := findIngressCondition(conditions, ConditionTypeDegraded)
+	if threshold == nil {
+		if current == nil {
+			return nil
+		}
+		return r.updateIngressConditions(ctx, ingress, removeIngressCondition(conditions, ConditionTypeDegraded))
+	}
+
+	result := *report.health
+	desired := IngressCondition{
+		Type:    ConditionTypeDegraded,
+		Status:  corev1.ConditionFalse,
+		Reason:  reasonHealthyTargets,
+		Message: result.String(),
+	}
+	if result.Exceeds(*threshold) {
+		desired.Status = corev1.ConditionTrue
+		desired.Reason = reasonUnhealthyTargets
+	}
+
+	wasDegraded := current != nil && current.Status == corev1.ConditionTrue
+	switch {
+	case desired.Status == corev1.ConditionTrue && !wasDegraded:
+		albctx.GetLogger(ctx).Warnf("ingress degraded, %v", result)
+		albctx.GetEventf(ctx)(corev1.EventTypeWarning, "DEGRADED", "ingress degraded, %v", result)
+	case desired.Status == corev1.ConditionFalse && wasDegraded:
+		albctx.GetLogger(ctx).Infof("ingress recovered, %v", result)
+		albctx.GetEventf(ctx)(corev1.EventTypeNormal, "RECOVERED", "ingress recovered, %v", result)
+	}
+
+	if current != nil && current.Status == desired.Status && current.Reason == desired.Reason && current.Message == desired.Message {
+		return nil
+	}
+	return r.updateIngressConditions(ctx, ingress, setIngressCondition(conditions, desired))
+}
+
+// reconcileReadyCondition sets the Ready condition of ingress by issuing verification requests against lbInfos, which serve lbIngresses.
+// The condition is removed when verification isn't configured, in which case the ingress is considered ready.
+func (r *Reconciler) reconcileReadyCondition(ctx context.Context, ingress *extensions.Ingress, lbAnnos *loadbalancer.Config,
+	lbIngresses []*extensions.Ingress, lbInfos []*lb.LoadBalancer) (bool, error) {
+	conditions := getIngressConditions(ingress)
+	current := findIngressCondition(conditions, ConditionTypeReady)
+	if lbAnnos.VerificationSuccessCodes == nil {
+		if current == nil {
+			return true, nil
+		}
+		return true, r.updateIngressConditions(ctx, ingress, removeIngressCondition(conditions, ConditionTypeReady))
+	}
+
+	targets := make([]verify.Target, 0, len(lbInfos))
+	for i, lbInfo := range lbInfos {
+		targets = append(targets, verify.Target{Ingress: lbIngresses[i], LoadBalancer: lbInfo})
+	}
+	result := r.verifier.Verify(ctx, targets, lbAnnos.Ports, lbAnnos.VerificationSuccessCodes)
+	desired := IngressCondition{
+		Type:    ConditionTypeReady,
+		Status:  corev1.ConditionTrue,
+		Reason:  reasonVerified,
+		Message: result.String(),
+	}
+	if !result.Succeeded() {
+		desired.Status = corev1.ConditionFalse
+		desired.Reason = reasonNotVerified
+		albctx.GetLogger(ctx).Warnf("verification failed, %v", result)
+		if current == nil || current.Status != corev1.ConditionFalse {
+			albctx.GetEventf(ctx)(corev1.EventTypeWarning, "VERIFICATION_FAILED", "verification failed, %v", result)
+		}
+	}
+
+	if current != nil && current.Status == desired.Status && current.Reason == desired.Reason && current.Message == desired.Message {
+		return result.Succeeded(), nil
+	}
+	return result.Succeeded(), r.updateIngressConditions(ctx, ingress, setIngressCondition(conditions, desired))
+}
+
+// openCircuit records the CircuitOpen condition on ingress after reconcile failed repeatedly on operation.
+func (r *Reconciler) openCircuit(ctx context.Context, ingress *extensions.Ingress, operation string, reconcileErr error) {
+	_, until := r.circuitBreaker.Check(k8s.NamespacedName(ingress), circuitbreaker.Version(ingress))
+	message := fmt.Sprintf("%v failed repeatedly, reconcile paused until %v: %v", operation, until.Format(time.RFC3339), reconcileErr)
+	albctx.GetLogger(ctx).Warnf("circuit opened, %v", message)
+	albctx.GetEventf(ctx)(corev1.EventTypeWarning, "CIRCUIT_OPEN", "%v", message)
+
+	conditions := setIngressCondition(getIngressConditions(ingress), IngressCondition{
+		Type:    ConditionTypeCircuitOpen,
+		Status:  corev1.ConditionTrue,
+		Reason:  reasonRepeatedFailures,
+		Message: message,
+	})
+	if err := r.updateIngressConditions(ctx, ingress, conditions); err != nil {
+		albctx.GetLogger(ctx).Warnf("failed to record %v condition due to %v", ConditionTypeCircuitOpen, err)
+	}
+}
+
+// closeCircuit removes the CircuitOpen condition from ingress.
+func (r *Reconciler) closeCircuit(ctx context.Context, ingress *extensions.Ingress) error {
+	conditions := getIngressConditions(ingress)
+	if findIngressCondition(conditions, ConditionTypeCircuitOpen) == nil {
+		return nil
+	}
+	albctx.GetLogger(ctx).Infof("circuit closed")
+	return r.updateIngressConditions(ctx, ingress, removeIngressCondition(conditions, ConditionTypeCircuitOpen))
+}
+
+func (r *Reconciler) updateIngressConditions(ctx context.Context, ingress *extensions.Ingress, conditions []IngressCondition) error {
+	if len(conditions) == 0 {
+		delete(ingress.Annotations, AnnotationConditions)
+	} else {
+		payload, err := json.Marshal(conditions)
+		if err != nil {
+			return err
+		}
+		if ingress.Annotations == nil {
+			ingress.Annotations = make(map[string]string)
+		}
+		ingress.Annotations[AnnotationConditions] = string(payload)
+	}
+	return r.client.Update(ctx, ingress)
+}
+
+// getIngressConditions returns the conditions recorded on ingress, malformed conditions are ignored.
+func getIngressConditions(ingress *extensions.Ingress) []IngressCondition {
+	raw, ok := ingress.Annotations[AnnotationConditions]
+	if !ok {
+		return nil
+	}
+	var conditions []IngressCondition
+	if err := json.Unmarshal([]byte(raw), &conditions); err != nil {
+		return nil
+	}
+	return conditions
+}
+
+// findIngressCondition returns the condition of conditionType, or nil if it doesn't exist.
+func findIngressCondition(conditions []IngressCondition, conditionType string) *IngressCondition {
+	for i := range conditions {
+		if conditions[i].Type == conditionType {
+			return &conditions[i]
+		}
+	}
+	return nil
+}
+
+// setIngressCondition returns conditions with condition added or replaced, LastTransitionTime is only updated when the status changes.
+func setIngressCondition(conditions []IngressCondition, condition IngressCondition) []IngressCondition {
+	condition.LastTransitionTime = metav1.Now()
+	if current := findIngressCondition(conditions, condition.Type); current != nil {
+		if current.Status == condition.Status {
+			condition.LastTransitionTime = current.LastTransitionTime
+		}
+		*current = condition
+		return conditions
+	}
+	return append(conditions, condition)
+}
+
+// equalIngressConditions returns whether conditions a and b are the same, regardless of order.
+func equalIngressConditions(a []IngressCondition, b []IngressCondition) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for _, condition := range a {
+		other := findIngressCondition(b, condition.Type)
+		if other == nil || !other.LastTransitionTime.Equal(&condition.LastTransitionTime) ||
+			other.Status != condition.Status || other.Reason != condition.Reason || other.Message != condition.Message {
+			return false
+		}
+	}
+	return true
+}
+
+// removeIngressCondition returns conditions without condition of conditionType.
+func removeIngressCondition(conditions []IngressCondition, conditionType string) []IngressCondition {
+	var result []IngressCondition
+	for _, condition := range conditions {
+		if condition.Type != conditionType {
+			result = append(result, condition)
+		}
+	}
+	return result
+}
@@ -0,0 +1,96 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/health"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/lb"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReconciler_reconcileStatusConditions(t *testing.T) {
+	for _, tc := range []struct {
+		Name               string
+		Report             func() *statusReport
+		ReconcileErr       error
+		ExpectedConditions map[string]corev1.ConditionStatus
+	}{
+		{
+			Name: "reconciled with active LoadBalancer and attached certificates",
+			Report: func() *statusReport {
+				report := newStatusReport()
+				report.lbInfos = []*lb.LoadBalancer{{DNSName: "lb.elb.amazonaws.com", State: elbv2.LoadBalancerStateEnumActive}}
+				report.health = &health.Result{TotalTargets: 2}
+				report.certificates.RecordAttached([]string{"certArn"})
+				return report
+			},
+			ExpectedConditions: map[string]corev1.ConditionStatus{
+				ConditionTypeReconciled:          corev1.ConditionTrue,
+				ConditionTypeLoadBalancerReady:   corev1.ConditionTrue,
+				ConditionTypeCertificateAttached: corev1.ConditionTrue,
+				ConditionTypeTargetsHealthy:      corev1.ConditionTrue,
+			},
+		},
+		{
+			Name: "provisioning LoadBalancer with unhealthy targets",
+			Report: func() *statusReport {
+				report := newStatusReport()
+				report.lbInfos = []*lb.LoadBalancer{{DNSName: "lb.elb.amazonaws.com", State: elbv2.LoadBalancerStateEnumProvisioning}}
+				report.health = &health.Result{TotalTargets: 2, UnhealthyTargets: 1}
+				return report
+			},
+			ExpectedConditions: map[string]corev1.ConditionStatus{
+				ConditionTypeReconciled:        corev1.ConditionTrue,
+				ConditionTypeLoadBalancerReady: corev1.ConditionFalse,
+				ConditionTypeTargetsHealthy:    corev1.ConditionFalse,
+			},
+		},
+		{
+			Name: "failed to resolve certificates",
+			Report: func() *statusReport {
+				report := newStatusReport()
+				report.certificates.RecordFailure(errors.New("no matching certificates"))
+				return report
+			},
+			ReconcileErr: errors.New("no matching certificates"),
+			ExpectedConditions: map[string]corev1.ConditionStatus{
+				ConditionTypeReconciled:          corev1.ConditionFalse,
+				ConditionTypeCertificateAttached: corev1.ConditionFalse,
+			},
+		},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			ctx := context.Background()
+			ingress := &extensions.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "namespace", Name: "ingress"}}
+			r := &Reconciler{client: fake.NewFakeClient(ingress.DeepCopy())}
+
+			assert.NoError(t, r.reconcileStatusConditions(ctx, ingress, tc.Report(), tc.ReconcileErr))
+			stored := &extensions.Ingress{}
+			assert.NoError(t, r.client.Get(ctx, types.NamespacedName{Namespace: "namespace", Name: "ingress"}, stored))
+			conditions := make(map[string]corev1.ConditionStatus)
+			for _, condition := range getIngressConditions(stored) {
+				conditions[condition.Type] = condition.Status
+			}
+			assert.Equal(t, tc.ExpectedConditions, conditions)
+		})
+	}
+}
+
+func Test_equalIngressConditions(t *testing.T) {
+	now := metav1.Now()
+	ready := IngressCondition{Type: ConditionTypeReconciled, Status: corev1.ConditionTrue, Reason: reasonReconcileSucceeded, LastTransitionTime: now}
+	healthy := IngressCondition{Type: ConditionTypeTargetsHealthy, Status: corev1.ConditionTrue, Reason: reasonHealthyTargets, LastTransitionTime: now}
+	unhealthy := IngressCondition{Type: ConditionTypeTargetsHealthy, Status: corev1.ConditionFalse, Reason: reasonUnhealthyTargets, LastTransitionTime: now}
+
+	assert.True(t, equalIngressConditions([]IngressCondition{ready, healthy}, []IngressCondition{healthy, ready}))
+	assert.False(t, equalIngressConditions([]IngressCondition{ready, healthy}, []IngressCondition{ready, unhealthy}))
+	assert.False(t, equalIngressConditions([]IngressCondition{ready}, []IngressCondition{ready, healthy}))
+}
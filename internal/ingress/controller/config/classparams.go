@@ -0,0 +1,79 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/apis/elbv2/v1alpha1"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+func (cfg *Configuration) initIngressClassParams(kubeClient client.Client) error {
+	paramsList := &v1alpha1.IngressClassParamsList{}
+	if err := kubeClient.List(context.Background(), nil, paramsList); err != nil {
+		return err
+	}
+	for i := range paramsList.Items {
+		params := &paramsList.Items[i]
+		cfg.AnnotationOverrides.Set(params.Name, ingressClassParamsAnnotations(params.Spec))
+	}
+	return nil
+}
+
+// watchIngressClassParams reloads the locked annotations when IngressClassParams changes, and requeues ingresses of the affected ingress class.
+func (cfg *Configuration) watchIngressClassParams(c controller.Controller, cache cache.Cache) error {
+	reload := func(params *v1alpha1.IngressClassParams, queue workqueue.RateLimitingInterface) {
+		glog.Infof("reloading IngressClassParams for ingress class %v", params.Name)
+		cfg.AnnotationOverrides.Set(params.Name, ingressClassParamsAnnotations(params.Spec))
+		cfg.enqueueIngressesOfClass(cache, params.Name, queue)
+	}
+	return c.Watch(&source.Kind{Type: &v1alpha1.IngressClassParams{}}, &handler.Funcs{
+		CreateFunc: func(e event.CreateEvent, queue workqueue.RateLimitingInterface) {
+			reload(e.Object.(*v1alpha1.IngressClassParams), queue)
+		},
+		UpdateFunc: func(e event.UpdateEvent, queue workqueue.RateLimitingInterface) {
+			if !reflect.DeepEqual(e.ObjectOld.(*v1alpha1.IngressClassParams).Spec, e.ObjectNew.(*v1alpha1.IngressClassParams).Spec) {
+				reload(e.ObjectNew.(*v1alpha1.IngressClassParams), queue)
+			}
+		},
+		DeleteFunc: func(e event.DeleteEvent, queue workqueue.RateLimitingInterface) {
+			params := e.Object.(*v1alpha1.IngressClassParams).DeepCopy()
+			params.Spec = v1alpha1.IngressClassParamsSpec{}
+			reload(params, queue)
+		},
+	})
+}
+
+// ingressClassParamsAnnotations returns the annotations equivalent to the settings of spec.
+func ingressClassParamsAnnotations(spec v1alpha1.IngressClassParamsSpec) map[string]string {
+	annotations := make(map[string]string)
+	set := func(name string, value string) {
+		if value != "" {
+			annotations[parser.GetAnnotationWithPrefix(name)] = value
+		}
+	}
+	set("scheme", spec.Scheme)
+	set("subnets", strings.Join(spec.Subnets, ","))
+	set("security-groups", strings.Join(spec.SecurityGroups, ","))
+	set("inbound-cidrs", strings.Join(spec.InboundCIDRs, ","))
+	set("ssl-policy", spec.SSLPolicy)
+
+	var tags []string
+	for key, value := range spec.Tags {
+		tags = append(tags, fmt.Sprintf("%v=%v", key, value))
+	}
+	sort.Strings(tags)
+	set("tags", strings.Join(tags, ","))
+	return annotations
+}
@@ -0,0 +1,45 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/apis/elbv2/v1alpha1"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ingressClassParamsAnnotations(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		spec     v1alpha1.IngressClassParamsSpec
+		expected map[string]string
+	}{
+		{
+			name:     "empty spec",
+			spec:     v1alpha1.IngressClassParamsSpec{},
+			expected: map[string]string{},
+		},
+		{
+			name: "all settings",
+			spec: v1alpha1.IngressClassParamsSpec{
+				Scheme:         "internal",
+				Subnets:        []string{"subnet-1", "subnet-2"},
+				SecurityGroups: []string{"sg-1"},
+				InboundCIDRs:   []string{"10.0.0.0/8"},
+				SSLPolicy:      "ELBSecurityPolicy-TLS-1-2-2017-01",
+				Tags:           map[string]string{"team": "platform", "env": "prod"},
+			},
+			expected: map[string]string{
+				"alb.ingress.kubernetes.io/scheme":          "internal",
+				"alb.ingress.kubernetes.io/subnets":         "subnet-1,subnet-2",
+				"alb.ingress.kubernetes.io/security-groups": "sg-1",
+				"alb.ingress.kubernetes.io/inbound-cidrs":   "10.0.0.0/8",
+				"alb.ingress.kubernetes.io/ssl-policy":      "ELBSecurityPolicy-TLS-1-2-2017-01",
+				"alb.ingress.kubernetes.io/tags":            "env=prod,team=platform",
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, ingressClassParamsAnnotations(tc.spec))
+		})
+	}
+}
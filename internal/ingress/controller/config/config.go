@@ -6,24 +6,41 @@ import (
 	"hash/crc32"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/golang/glog"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
 	"github.com/spf13/pflag"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 const (
-	defaultIngressClass            = ""
-	defaultAnnotationPrefix        = "alb.ingress.kubernetes.io"
-	defaultALBNamePrefix           = ""
-	defaultTargetType              = elbv2.TargetTypeEnumInstance
-	defaultBackendProtocol         = elbv2.ProtocolEnumHttp
-	defaultRestrictScheme          = false
-	defaultRestrictSchemeNamespace = corev1.NamespaceDefault
-	defaultSyncRateLimit           = 0.3
-	defaultMaxConcurrentReconciles = 1
+	defaultIngressClass                    = ""
+	defaultAnnotationPrefix                = "alb.ingress.kubernetes.io"
+	defaultALBNamePrefix                   = ""
+	defaultTargetType                      = elbv2.TargetTypeEnumInstance
+	defaultBackendProtocol                 = elbv2.ProtocolEnumHttp
+	defaultRestrictScheme                  = false
+	defaultRestrictSchemeNamespace         = corev1.NamespaceDefault
+	defaultRestrictIngressGroups           = false
+	defaultSyncRateLimit                   = 0.3
+	defaultMaxConcurrentReconciles         = 5
+	defaultMaxConcurrentResourceReconciles = 5
+	defaultLCUMetricsInterval              = 0
+	defaultCertificateCheckInterval        = 0
+	defaultCertificateExpiryWarning        = 30 * 24 * time.Hour
+	defaultOrphanSweepInterval             = 0
+	defaultOrphanSweepMode                 = "audit"
+	defaultOrphanSweepGracePeriod          = 24 * time.Hour
+	defaultDriftDetectionInterval          = 0
+	defaultFullSyncInterval                = 6 * time.Hour
+	defaultAnnotationDefaultsNamespace     = metav1.NamespaceSystem
+	defaultCircuitBreakerThreshold         = 0
+	defaultCircuitBreakerCoolDown          = 10 * time.Minute
+	defaultIngressDebounceWindow           = 0
+	defaultBackendSecurityGroup            = false
 )
 
 var (
@@ -46,19 +63,77 @@ type Configuration struct {
 	SyncRateLimit           float32
 	MaxConcurrentReconciles int
 
+	// IngressDebounceWindow is the duration to coalesce events of an ingress into a single reconcile, it's disabled when zero.
+	IngressDebounceWindow time.Duration
+
+	// MaxConcurrentResourceReconciles is the maximum number of listeners or targetGroups reconciled concurrently for a single ingress.
+	MaxConcurrentResourceReconciles int
+
 	RestrictScheme          bool
 	RestrictSchemeNamespace string
 
+	// RestrictIngressGroups limits the namespaces of IngressGroup members to those allowed per group by a configMap.
+	RestrictIngressGroups bool
+
+	// BackendSecurityGroup enables a securityGroup shared by managed LoadBalancers, which worker nodes allow inbound traffic from.
+	BackendSecurityGroup bool
+
+	// LCUMetricsInterval is the interval to estimate LCU consumption of ALBs, it's disabled when zero.
+	LCUMetricsInterval time.Duration
+
+	// CertificateCheckInterval is the interval to check expiry of certificates on the listeners of ALBs, it's disabled when zero.
+	CertificateCheckInterval time.Duration
+	// CertificateExpiryWarning is how long before their certificates expire ingresses get warning events.
+	CertificateExpiryWarning time.Duration
+
+	// OrphanSweepInterval is the interval to look for AWS resources tagged for the cluster whose ingress no longer exists, it's disabled when zero.
+	OrphanSweepInterval time.Duration
+	// OrphanSweepMode is whether orphaned resources are only reported(audit) or deleted(delete).
+	OrphanSweepMode string
+	// OrphanSweepGracePeriod is how long resources must be found orphaned before they're deleted.
+	OrphanSweepGracePeriod time.Duration
+
+	// DriftDetectionInterval is the interval to compare ingresses against live AWS state without changes to the ingresses, it's disabled when zero.
+	DriftDetectionInterval time.Duration
+
+	// FullSyncInterval is the interval to reconcile every ingress regardless of changes, it's disabled when zero.
+	FullSyncInterval time.Duration
+
+	// ChangeEventsQueueURL is the URL of an SQS queue receiving CloudTrail events of ELBv2 and EC2 API calls from EventBridge,
+	// ingresses whose AWS resources are changed outside of the controller are reconciled upon these events. It's disabled when empty.
+	ChangeEventsQueueURL string
+
+	// CircuitBreakerThreshold is the number of consecutive failures of the same AWS operation, after which reconcile of an ingress is paused.
+	// The circuit breaker is disabled when zero.
+	CircuitBreakerThreshold int
+	// CircuitBreakerCoolDown is the duration reconcile of an ingress is paused for.
+	CircuitBreakerCoolDown time.Duration
+
+	// DryRun stops the controller from mutating AWS resources, the AWS operations it would perform are logged instead.
+	DryRun bool
+
+	// AnnotationDefaultsNamespace is the namespace with the configMaps containing default annotations per ingress class.
+	AnnotationDefaultsNamespace string
+	// AnnotationDefaults is an dynamic setting that can be updated by configMaps
+	AnnotationDefaults *AnnotationDefaults
+	// AnnotationOverrides are the annotations locked by IngressClassParams per ingress class, which take precedence over annotations of ingresses.
+	AnnotationOverrides *AnnotationDefaults
+
 	// InternetFacingIngresses is an dynamic setting that can be updated by configMaps
 	InternetFacingIngresses map[string][]string
 
+	// IngressGroupNamespaces is an dynamic setting that can be updated by configMaps, it maps group names to the namespaces allowed to join them.
+	IngressGroupNamespaces map[string][]string
+
 	FeatureGate FeatureGate
 }
 
 // NewConfiguration constructs new Configuration obj.
 func NewConfiguration() Configuration {
 	return Configuration{
-		FeatureGate: NewFeatureGate(),
+		FeatureGate:         NewFeatureGate(),
+		AnnotationDefaults:  NewAnnotationDefaults(),
+		AnnotationOverrides: NewAnnotationDefaults(),
 	}
 }
 
@@ -83,11 +158,45 @@ func (cfg *Configuration) BindFlags(fs *pflag.FlagSet) {
 	fs.Float32Var(&cfg.SyncRateLimit, "sync-rate-limit", defaultSyncRateLimit,
 		`Define the sync frequency upper limit`)
 	fs.IntVar(&cfg.MaxConcurrentReconciles, "max-concurrent-reconciles", defaultMaxConcurrentReconciles,
-		`Define the maximum of number concurrently running reconcile loops`)
+		`Define the maximum number of ingresses reconciled concurrently, an ALB is never reconciled by two loops at once`)
+	fs.DurationVar(&cfg.IngressDebounceWindow, "ingress-debounce-window", defaultIngressDebounceWindow,
+		`Duration to coalesce a burst of changes to an ingress into a single reconcile. Changes are reconciled immediately if zero.`)
+	fs.IntVar(&cfg.MaxConcurrentResourceReconciles, "max-concurrent-resource-reconciles", defaultMaxConcurrentResourceReconciles,
+		`Define the maximum number of listeners or target groups reconciled concurrently within a single reconcile loop`)
 	fs.BoolVar(&cfg.RestrictScheme, "restrict-scheme", defaultRestrictScheme,
 		`Restrict the scheme to internal except for whitelisted namespaces`)
 	fs.StringVar(&cfg.RestrictSchemeNamespace, "restrict-scheme-namespace", defaultRestrictSchemeNamespace,
-		`The namespace with the ConfigMap containing the allowed ingresses. Only respected when restrict-scheme is true.`)
+		`The namespace with the ConfigMaps containing the allowed ingresses and IngressGroup namespaces. Only respected when restrict-scheme or restrict-ingress-groups is true.`)
+	fs.BoolVar(&cfg.RestrictIngressGroups, "restrict-ingress-groups", defaultRestrictIngressGroups,
+		`Restrict the members of each IngressGroup to the namespaces whitelisted for the group`)
+	fs.BoolVar(&cfg.BackendSecurityGroup, "backend-security-group", defaultBackendSecurityGroup,
+		`Attach a securityGroup shared by all managed ALBs, so that worker node securityGroups need a single rule for all ALBs`)
+	fs.DurationVar(&cfg.LCUMetricsInterval, "lcu-metrics-interval", defaultLCUMetricsInterval,
+		`Interval to estimate LCU consumption of ALBs from CloudWatch metrics. LCU metrics are disabled if zero.`)
+	fs.DurationVar(&cfg.CertificateCheckInterval, "certificate-check-interval", defaultCertificateCheckInterval,
+		`Interval to check expiry of ACM and IAM certificates on the listeners of ALBs. Certificate checks are disabled if zero.`)
+	fs.DurationVar(&cfg.CertificateExpiryWarning, "certificate-expiry-warning", defaultCertificateExpiryWarning,
+		`Duration before expiry of their certificates at which ingresses get warning events.`)
+	fs.DurationVar(&cfg.OrphanSweepInterval, "orphan-sweep-interval", defaultOrphanSweepInterval,
+		`Interval to look for ALBs, target groups and security groups tagged for the cluster whose ingress no longer exists. Sweeping is disabled if zero.`)
+	fs.StringVar(&cfg.OrphanSweepMode, "orphan-sweep-mode", defaultOrphanSweepMode,
+		`Whether orphaned resources are only reported ("audit") or deleted ("delete").`)
+	fs.DurationVar(&cfg.OrphanSweepGracePeriod, "orphan-sweep-grace-period", defaultOrphanSweepGracePeriod,
+		`Duration resources must be found orphaned by every sweep before they're deleted in "delete" mode.`)
+	fs.DurationVar(&cfg.DriftDetectionInterval, "drift-detection-interval", defaultDriftDetectionInterval,
+		`Interval to check AWS resources of every ingress for changes made outside of the controller. Drift detection is disabled if zero.`)
+	fs.DurationVar(&cfg.FullSyncInterval, "full-sync-interval", defaultFullSyncInterval,
+		`Interval to reconcile every ingress, as a safety net for missed changes. Ingresses are otherwise only reconciled when they or their services, endpoints or nodes change. Full sync is disabled if zero.`)
+	fs.StringVar(&cfg.ChangeEventsQueueURL, "change-events-queue-url", "",
+		`URL of an SQS queue receiving CloudTrail events of ELBv2 and EC2 API calls from EventBridge. Ingresses whose ALB, listeners, rules, target groups or security groups are changed outside of the controller are reconciled upon these events.`)
+	fs.StringVar(&cfg.AnnotationDefaultsNamespace, "annotation-defaults-namespace", defaultAnnotationDefaultsNamespace,
+		`The namespace with the ConfigMaps containing default annotations per ingress class.`)
+	fs.IntVar(&cfg.CircuitBreakerThreshold, "circuit-breaker-threshold", defaultCircuitBreakerThreshold,
+		`Number of consecutive failures of the same AWS operation, after which reconcile of an ingress is paused. The circuit breaker is disabled if zero.`)
+	fs.DurationVar(&cfg.CircuitBreakerCoolDown, "circuit-breaker-cool-down", defaultCircuitBreakerCoolDown,
+		`Duration to pause reconcile of an ingress once its circuit is opened.`)
+	fs.BoolVar(&cfg.DryRun, "dry-run", false,
+		`Log and emit events for the AWS operations the controller would perform, without performing any of them.`)
 
 	cfg.FeatureGate.BindFlags(fs)
 }
@@ -124,6 +233,42 @@ func (cfg *Configuration) Validate() error {
 	if len(cfg.ClusterName) == 0 {
 		return fmt.Errorf("clusterName must be specified")
 	}
+	if cfg.MaxConcurrentReconciles < 1 {
+		return fmt.Errorf("MaxConcurrentReconciles must be positive")
+	}
+	if cfg.MaxConcurrentResourceReconciles < 1 {
+		return fmt.Errorf("MaxConcurrentResourceReconciles must be positive")
+	}
+	if cfg.LCUMetricsInterval < 0 {
+		return fmt.Errorf("LCUMetricsInterval must be non-negative")
+	}
+	if cfg.CertificateCheckInterval < 0 {
+		return fmt.Errorf("CertificateCheckInterval must be non-negative")
+	}
+	if cfg.OrphanSweepInterval < 0 {
+		return fmt.Errorf("OrphanSweepInterval must be non-negative")
+	}
+	if cfg.OrphanSweepMode != "audit" && cfg.OrphanSweepMode != "delete" {
+		return fmt.Errorf("OrphanSweepMode must be audit or delete")
+	}
+	if cfg.OrphanSweepGracePeriod < 0 {
+		return fmt.Errorf("OrphanSweepGracePeriod must be non-negative")
+	}
+	if cfg.DriftDetectionInterval < 0 {
+		return fmt.Errorf("DriftDetectionInterval must be non-negative")
+	}
+	if cfg.FullSyncInterval < 0 {
+		return fmt.Errorf("FullSyncInterval must be non-negative")
+	}
+	if cfg.IngressDebounceWindow < 0 {
+		return fmt.Errorf("IngressDebounceWindow must be non-negative")
+	}
+	if cfg.CircuitBreakerThreshold < 0 {
+		return fmt.Errorf("CircuitBreakerThreshold must be non-negative")
+	}
+	if cfg.CircuitBreakerCoolDown <= 0 {
+		return fmt.Errorf("CircuitBreakerCoolDown must be positive")
+	}
 	if len(cfg.ALBNamePrefix) > 12 {
 		return fmt.Errorf("ALBNamePrefix must be 12 characters or less")
 	}
@@ -0,0 +1,84 @@
+package config
+
+import (
+	"sync"
+)
+
+// AnnotationDefaults holds the default annotations of ingresses per ingress class, which are merged under the annotations of each ingress.
+type AnnotationDefaults struct {
+	mutex     sync.RWMutex
+	defaults  map[string]map[string]string
+	listeners []func()
+}
+
+// NewAnnotationDefaults constructs an empty AnnotationDefaults.
+func NewAnnotationDefaults() *AnnotationDefaults {
+	return &AnnotationDefaults{
+		defaults: make(map[string]map[string]string),
+	}
+}
+
+// Get returns the default annotations for ingresses of ingressClass.
+func (d *AnnotationDefaults) Get(ingressClass string) map[string]string {
+	if d == nil {
+		return nil
+	}
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+	return d.defaults[ingressClass]
+}
+
+// Merge returns annotations merged over the default annotations for ingressClass.
+func (d *AnnotationDefaults) Merge(ingressClass string, annotations map[string]string) map[string]string {
+	defaults := d.Get(ingressClass)
+	if len(defaults) == 0 {
+		return annotations
+	}
+	merged := make(map[string]string, len(defaults)+len(annotations))
+	for key, value := range defaults {
+		merged[key] = value
+	}
+	for key, value := range annotations {
+		merged[key] = value
+	}
+	return merged
+}
+
+// Override returns the annotations for ingressClass merged over annotations, for annotations that can't be overridden by ingresses.
+func (d *AnnotationDefaults) Override(ingressClass string, annotations map[string]string) map[string]string {
+	overrides := d.Get(ingressClass)
+	if len(overrides) == 0 {
+		return annotations
+	}
+	merged := make(map[string]string, len(overrides)+len(annotations))
+	for key, value := range annotations {
+		merged[key] = value
+	}
+	for key, value := range overrides {
+		merged[key] = value
+	}
+	return merged
+}
+
+// OnChange registers listener to be called after default annotations changed.
+func (d *AnnotationDefaults) OnChange(listener func()) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.listeners = append(d.listeners, listener)
+}
+
+// Set replaces the default annotations for ingressClass, defaults are removed if annotations is empty.
+func (d *AnnotationDefaults) Set(ingressClass string, annotations map[string]string) {
+	d.mutex.Lock()
+	if len(annotations) == 0 {
+		delete(d.defaults, ingressClass)
+	} else {
+		d.defaults[ingressClass] = annotations
+	}
+	listeners := d.listeners
+	d.mutex.Unlock()
+
+	for _, listener := range listeners {
+		listener()
+	}
+}
@@ -0,0 +1,69 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAnnotationDefaults(t *testing.T) {
+	defaults := NewAnnotationDefaults()
+	changes := 0
+	defaults.OnChange(func() { changes++ })
+
+	annotations := map[string]string{"alb.ingress.kubernetes.io/scheme": "internet-facing"}
+	assert.Equal(t, annotations, defaults.Merge("alb", annotations))
+
+	defaults.Set("alb", map[string]string{
+		"alb.ingress.kubernetes.io/scheme":     "internal",
+		"alb.ingress.kubernetes.io/ssl-policy": "ELBSecurityPolicy-TLS-1-2-2017-01",
+	})
+	assert.Equal(t, 1, changes)
+	assert.Equal(t, map[string]string{
+		"alb.ingress.kubernetes.io/scheme":     "internet-facing",
+		"alb.ingress.kubernetes.io/ssl-policy": "ELBSecurityPolicy-TLS-1-2-2017-01",
+	}, defaults.Merge("alb", annotations))
+	assert.Equal(t, annotations, defaults.Merge("other", annotations))
+
+	assert.Equal(t, map[string]string{
+		"alb.ingress.kubernetes.io/scheme":     "internal",
+		"alb.ingress.kubernetes.io/ssl-policy": "ELBSecurityPolicy-TLS-1-2-2017-01",
+	}, defaults.Override("alb", annotations))
+	assert.Equal(t, annotations, defaults.Override("other", annotations))
+
+	defaults.Set("alb", nil)
+	assert.Equal(t, 2, changes)
+	assert.Nil(t, defaults.Get("alb"))
+}
+
+func TestConfiguration_annotationDefaultsIngressClass(t *testing.T) {
+	cfg := &Configuration{AnnotationDefaultsNamespace: "kube-system"}
+	for _, tc := range []struct {
+		name          string
+		meta          metav1.ObjectMeta
+		expectedClass string
+		expectedOK    bool
+	}{
+		{
+			name:          "default annotations",
+			meta:          metav1.ObjectMeta{Namespace: "kube-system", Name: "alb-ingress-controller-annotation-defaults-alb"},
+			expectedClass: "alb",
+			expectedOK:    true,
+		},
+		{
+			name: "other namespace",
+			meta: metav1.ObjectMeta{Namespace: "default", Name: "alb-ingress-controller-annotation-defaults-alb"},
+		},
+		{
+			name: "other configMap",
+			meta: metav1.ObjectMeta{Namespace: "kube-system", Name: "alb-ingress-controller-internet-facing-ingresses"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ingressClass, ok := cfg.annotationDefaultsIngressClass(&tc.meta)
+			assert.Equal(t, tc.expectedClass, ingressClass)
+			assert.Equal(t, tc.expectedOK, ok)
+		})
+	}
+}
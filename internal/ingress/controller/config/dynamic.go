@@ -2,23 +2,36 @@ package config
 
 import (
 	"context"
+	"reflect"
 	"strings"
 
+	"github.com/golang/glog"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/class"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/apis/elbv2/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 const restrictIngressConfigMap = "alb-ingress-controller-internet-facing-ingresses"
 
+// ingressGroupNamespacesConfigMap maps IngressGroup names to comma-separated lists of the namespaces allowed to join them.
+const ingressGroupNamespacesConfigMap = "alb-ingress-controller-ingress-group-namespaces"
+
+// annotationDefaultsConfigMapPrefix is the name prefix of configMaps containing default annotations, followed by the ingress class.
+const annotationDefaultsConfigMapPrefix = "alb-ingress-controller-annotation-defaults-"
+
 // TODO: I'd prefer to keep config an plain data structure, and move this logic into the object that manages configuration, like current "store" object. Will move this logic there once i clean up the store object.
 // BindDynamicSettings will force initial load of these dynamic settings from configMaps, and setup watcher for configMap changes.
 func (cfg *Configuration) BindDynamicSettings(mgr manager.Manager, c controller.Controller, cloud aws.CloudAPI) error {
@@ -30,9 +43,37 @@ func (cfg *Configuration) BindDynamicSettings(mgr manager.Manager, c controller.
 			return err
 		}
 	}
+	if cfg.RestrictIngressGroups {
+		if err := cfg.initIngressGroupNamespaces(mgr.GetClient()); err != nil {
+			return err
+		}
+		if err := cfg.watchIngressGroupNamespaces(c, mgr.GetCache()); err != nil {
+			return err
+		}
+	}
+	if err := cfg.initAnnotationDefaults(mgr.GetClient()); err != nil {
+		return err
+	}
+	if err := cfg.watchAnnotationDefaults(c, mgr.GetCache()); err != nil {
+		return err
+	}
+	if cfg.FeatureGate.Enabled(IngressClassParams) {
+		if err := v1alpha1.AddToScheme(mgr.GetScheme()); err != nil {
+			return err
+		}
+		if err := cfg.initIngressClassParams(mgr.GetClient()); err != nil {
+			return err
+		}
+		if err := cfg.watchIngressClassParams(c, mgr.GetCache()); err != nil {
+			return err
+		}
+	}
 	if cfg.FeatureGate.Enabled(WAF) && !cloud.WAFRegionalAvailable() {
 		cfg.FeatureGate.Disable(WAF)
 	}
+	if cfg.FeatureGate.Enabled(WAFV2) && !cloud.WAFv2Available() {
+		cfg.FeatureGate.Disable(WAFV2)
+	}
 
 	return nil
 }
@@ -94,3 +135,166 @@ func (cfg *Configuration) isRestrictIngressConfigMap(meta metav1.Object) bool {
 	return (meta.GetNamespace() == cfg.RestrictSchemeNamespace) &&
 		(meta.GetName() == restrictIngressConfigMap)
 }
+
+func (cfg *Configuration) initIngressGroupNamespaces(client client.Client) error {
+	configMap := &corev1.ConfigMap{}
+	configMapKey := types.NamespacedName{
+		Namespace: cfg.RestrictSchemeNamespace,
+		Name:      ingressGroupNamespacesConfigMap,
+	}
+	if err := client.Get(context.Background(), configMapKey, configMap); err != nil {
+		cfg.loadIngressGroupNamespaces(nil)
+		return nil
+	}
+	cfg.loadIngressGroupNamespaces(configMap)
+	return nil
+}
+
+// watchIngressGroupNamespaces reloads the namespaces allowed to join IngressGroups when the configMap changes,
+// and requeues ingresses so that groups admit or release members right away.
+func (cfg *Configuration) watchIngressGroupNamespaces(c controller.Controller, cache cache.Cache) error {
+	reload := func(meta metav1.Object, configMap *corev1.ConfigMap, queue workqueue.RateLimitingInterface) {
+		if meta.GetNamespace() != cfg.RestrictSchemeNamespace || meta.GetName() != ingressGroupNamespacesConfigMap {
+			return
+		}
+		glog.Infof("reloading namespaces allowed to join IngressGroups")
+		cfg.loadIngressGroupNamespaces(configMap)
+		cfg.enqueueIngresses(cache, queue)
+	}
+	if err := c.Watch(&source.Kind{Type: &corev1.ConfigMap{}}, &handler.Funcs{
+		CreateFunc: func(e event.CreateEvent, queue workqueue.RateLimitingInterface) {
+			reload(e.Meta, e.Object.(*corev1.ConfigMap), queue)
+		},
+		UpdateFunc: func(e event.UpdateEvent, queue workqueue.RateLimitingInterface) {
+			if !reflect.DeepEqual(e.ObjectOld.(*corev1.ConfigMap).Data, e.ObjectNew.(*corev1.ConfigMap).Data) {
+				reload(e.MetaNew, e.ObjectNew.(*corev1.ConfigMap), queue)
+			}
+		},
+		DeleteFunc: func(e event.DeleteEvent, queue workqueue.RateLimitingInterface) {
+			reload(e.Meta, nil, queue)
+		},
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// loadIngressGroupNamespaces loads the IngressGroupNamespaces settings from configMap.
+// The Key:Value pairs are interpreted as "group name: comma-separated list of namespaces"
+func (cfg *Configuration) loadIngressGroupNamespaces(configMap *corev1.ConfigMap) {
+	groupNamespaces := make(map[string][]string)
+	if configMap != nil {
+		for groupName, configLine := range configMap.Data {
+			configLine := strings.Replace(configLine, " ", "", -1)
+			groupNamespaces[groupName] = strings.Split(configLine, ",")
+		}
+	}
+	cfg.IngressGroupNamespaces = groupNamespaces
+}
+
+// IngressGroupAllowsNamespace returns whether ingresses in namespace may join the IngressGroup named groupName.
+func (cfg *Configuration) IngressGroupAllowsNamespace(groupName string, namespace string) bool {
+	if !cfg.RestrictIngressGroups {
+		return true
+	}
+	for _, allowed := range cfg.IngressGroupNamespaces[groupName] {
+		if allowed == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+func (cfg *Configuration) initAnnotationDefaults(kubeClient client.Client) error {
+	configMapList := &corev1.ConfigMapList{}
+	if err := kubeClient.List(context.Background(), client.InNamespace(cfg.AnnotationDefaultsNamespace), configMapList); err != nil {
+		return err
+	}
+	for i := range configMapList.Items {
+		configMap := &configMapList.Items[i]
+		if ingressClass, ok := cfg.annotationDefaultsIngressClass(configMap); ok {
+			cfg.AnnotationDefaults.Set(ingressClass, configMap.Data)
+		}
+	}
+	return nil
+}
+
+// watchAnnotationDefaults reloads default annotations when configMaps changes, and requeues ingresses of the affected ingress class.
+func (cfg *Configuration) watchAnnotationDefaults(c controller.Controller, cache cache.Cache) error {
+	reload := func(meta metav1.Object, data map[string]string, queue workqueue.RateLimitingInterface) {
+		ingressClass, ok := cfg.annotationDefaultsIngressClass(meta)
+		if !ok {
+			return
+		}
+		glog.Infof("reloading default annotations for ingress class %v", ingressClass)
+		cfg.AnnotationDefaults.Set(ingressClass, data)
+		cfg.enqueueIngressesOfClass(cache, ingressClass, queue)
+	}
+	if err := c.Watch(&source.Kind{Type: &corev1.ConfigMap{}}, &handler.Funcs{
+		CreateFunc: func(e event.CreateEvent, queue workqueue.RateLimitingInterface) {
+			reload(e.Meta, e.Object.(*corev1.ConfigMap).Data, queue)
+		},
+		UpdateFunc: func(e event.UpdateEvent, queue workqueue.RateLimitingInterface) {
+			if !reflect.DeepEqual(e.ObjectOld.(*corev1.ConfigMap).Data, e.ObjectNew.(*corev1.ConfigMap).Data) {
+				reload(e.MetaNew, e.ObjectNew.(*corev1.ConfigMap).Data, queue)
+			}
+		},
+		DeleteFunc: func(e event.DeleteEvent, queue workqueue.RateLimitingInterface) {
+			reload(e.Meta, nil, queue)
+		},
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (cfg *Configuration) enqueueIngressesOfClass(cache cache.Cache, ingressClass string, queue workqueue.RateLimitingInterface) {
+	ingressList := &extensions.IngressList{}
+	if err := cache.List(context.Background(), nil, ingressList); err != nil {
+		glog.Errorf("failed to fetch ingresses of class %v due to %v", ingressClass, err)
+		return
+	}
+	for i := range ingressList.Items {
+		ingress := &ingressList.Items[i]
+		if !class.IsValidIngress(cfg.IngressClass, ingress) || class.GetIngressClass(ingress) != ingressClass {
+			continue
+		}
+		queue.Add(reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Namespace: ingress.Namespace,
+				Name:      ingress.Name,
+			},
+		})
+	}
+}
+
+// enqueueIngresses enqueues every ingress of this controller, requests of IngressGroup members are reconciled as their group.
+func (cfg *Configuration) enqueueIngresses(cache cache.Cache, queue workqueue.RateLimitingInterface) {
+	ingressList := &extensions.IngressList{}
+	if err := cache.List(context.Background(), nil, ingressList); err != nil {
+		glog.Errorf("failed to fetch ingresses due to %v", err)
+		return
+	}
+	for i := range ingressList.Items {
+		ingress := &ingressList.Items[i]
+		if !class.IsValidIngress(cfg.IngressClass, ingress) {
+			continue
+		}
+		queue.Add(reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Namespace: ingress.Namespace,
+				Name:      ingress.Name,
+			},
+		})
+	}
+}
+
+// annotationDefaultsIngressClass returns the ingress class of configMap, if it contains default annotations.
+func (cfg *Configuration) annotationDefaultsIngressClass(meta metav1.Object) (string, bool) {
+	if meta.GetNamespace() != cfg.AnnotationDefaultsNamespace || !strings.HasPrefix(meta.GetName(), annotationDefaultsConfigMapPrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(meta.GetName(), annotationDefaultsConfigMapPrefix), true
+}
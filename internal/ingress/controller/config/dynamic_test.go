@@ -0,0 +1,25 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestConfiguration_IngressGroupAllowsNamespace(t *testing.T) {
+	cfg := &Configuration{}
+	assert.True(t, cfg.IngressGroupAllowsNamespace("shop", "anywhere"))
+
+	cfg.RestrictIngressGroups = true
+	cfg.loadIngressGroupNamespaces(&corev1.ConfigMap{
+		Data: map[string]string{"shop": "frontend, checkout"},
+	})
+	assert.True(t, cfg.IngressGroupAllowsNamespace("shop", "frontend"))
+	assert.True(t, cfg.IngressGroupAllowsNamespace("shop", "checkout"))
+	assert.False(t, cfg.IngressGroupAllowsNamespace("shop", "other"))
+	assert.False(t, cfg.IngressGroupAllowsNamespace("blog", "frontend"))
+
+	cfg.loadIngressGroupNamespaces(nil)
+	assert.False(t, cfg.IngressGroupAllowsNamespace("shop", "frontend"))
+}
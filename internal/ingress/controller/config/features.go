@@ -27,7 +27,23 @@ import (
 type Feature string
 
 const (
-	WAF Feature = "waf"
+	WAF   Feature = "waf"
+	WAFV2 Feature = "wafv2"
+
+	// TargetGroupBinding enables the TargetGroupBinding custom resource, whose definition must be installed.
+	TargetGroupBinding Feature = "target-group-binding"
+
+	// IngressClassParams enables the IngressClassParams custom resource, whose definition must be installed.
+	IngressClassParams Feature = "ingress-class-params"
+
+	// Route53Records enables managing Route 53 alias records for the hosts of ingresses.
+	Route53Records Feature = "route53-records"
+
+	// TLSSecretImport enables importing kubernetes.io/tls secrets referenced by ingresses into ACM.
+	TLSSecretImport Feature = "tls-secret-import"
+
+	// EndpointSlices enables resolving endpoints from discovery.k8s.io/v1beta1 EndpointSlices, falling back to Endpoints if the cluster doesn't serve them.
+	EndpointSlices Feature = "endpoint-slices"
 )
 
 type FeatureGate interface {
@@ -55,7 +71,13 @@ type defaultFeatureGate struct {
 func NewFeatureGate() FeatureGate {
 	return &defaultFeatureGate{
 		featureState: map[Feature]bool{
-			WAF: true,
+			WAF:                true,
+			WAFV2:              true,
+			TargetGroupBinding: false,
+			IngressClassParams: false,
+			Route53Records:     false,
+			TLSSecretImport:    false,
+			EndpointSlices:     false,
 		},
 	}
 }
@@ -87,7 +109,7 @@ func (f *defaultFeatureGate) String() string {
 func (f *defaultFeatureGate) Set(value string) error {
 	settings, err := utils.SplitMapStringBool(value)
 	if err != nil {
-		return fmt.Errorf("failed to parse feature-gate settings due to %v", err)
+		return fmt.Errorf("failed to parse feature-gate settings due to %w", err)
 	}
 	for k, v := range settings {
 		_, ok := f.featureState[Feature(k)]
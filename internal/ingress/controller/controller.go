@@ -2,34 +2,54 @@ package controller
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/auth"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/bluegreen"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/certexpiry"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/changeevents"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/dns"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/failover"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/generator"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/health"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/lb"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/lcu"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/ls"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/orphan"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/sg"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/staticip"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tags"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tg"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/verify"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/backend"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/circuitbreaker"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/config"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/handlers"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/election"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/inventory"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/metric"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/secretref"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/tgbinding"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/k8s"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/utils"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/apis/elbv2/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
 	extensions "k8s.io/api/extensions/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
-	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
-func Initialize(config *config.Configuration, mgr manager.Manager, mc metric.Collector, cloud aws.CloudAPI) error {
-	authModule := auth.NewModule(mgr.GetCache())
-	reconciler, err := newReconciler(config, mgr, mc, cloud, authModule)
+// Initialize adds the controllers of ingresses and TargetGroupBindings to mgr, which only mutate AWS resources while elector leads.
+func Initialize(config *config.Configuration, mgr manager.Manager, mc metric.Collector, cloud aws.CloudAPI, inv inventory.Inventory, elector election.Elector) error {
+	secretRefResolver := secretref.NewResolver(mgr.GetCache())
+	authModule := auth.NewModule(mgr.GetCache(), secretRefResolver)
+	reconciler, err := newReconciler(config, mgr, mc, cloud, authModule, inv, elector)
 	if err != nil {
 		return err
 	}
@@ -44,42 +64,132 @@ func Initialize(config *config.Configuration, mgr manager.Manager, mc metric.Col
 	ingressChan := make(chan event.GenericEvent)
 	serviceChan := make(chan event.GenericEvent)
 	if err := authModule.Init(c, ingressChan, serviceChan); err != nil {
-		return fmt.Errorf("failed to init auth module due to %v", err)
+		return fmt.Errorf("failed to init auth module due to %w", err)
 	}
-	if err := watchClusterEvents(c, mgr.GetCache(), ingressChan, serviceChan, config.IngressClass); err != nil {
-		return fmt.Errorf("failed to watch cluster events due to %v", err)
+	if err := secretRefResolver.Init(c, ingressChan, serviceChan); err != nil {
+		return fmt.Errorf("failed to init secret reference resolver due to %w", err)
+	}
+	if err := watchClusterEvents(c, mgr.GetCache(), ingressChan, serviceChan, config.IngressClass, config.IngressDebounceWindow, config.FeatureGate); err != nil {
+		return fmt.Errorf("failed to watch cluster events due to %w", err)
+	}
+	if config.LCUMetricsInterval > 0 {
+		if err := mgr.Add(election.LeaderOnly(elector, lcu.NewEstimator(cloud, mc, config.ClusterName, config.LCUMetricsInterval))); err != nil {
+			return fmt.Errorf("failed to add LCU estimator due to %w", err)
+		}
+	}
+	if config.CertificateCheckInterval > 0 {
+		monitor := certexpiry.NewMonitor(cloud, mc, mgr.GetCache(), reconciler.recorder, ingressChan,
+			config.ClusterName, config.CertificateCheckInterval, config.CertificateExpiryWarning)
+		if err := mgr.Add(election.LeaderOnly(elector, monitor)); err != nil {
+			return fmt.Errorf("failed to add certificate monitor due to %w", err)
+		}
+	}
+	if config.OrphanSweepInterval > 0 {
+		sweeper := orphan.NewSweeper(cloud, mc, mgr.GetCache(), config.ClusterName, config.OrphanSweepInterval, config.OrphanSweepMode, config.OrphanSweepGracePeriod)
+		if err := mgr.Add(election.LeaderOnly(elector, sweeper)); err != nil {
+			return fmt.Errorf("failed to add orphan sweeper due to %w", err)
+		}
+	}
+	if config.DriftDetectionInterval > 0 {
+		if err := mgr.Add(election.LeaderOnly(elector, newDriftDetector(reconciler, ingressChan, config.DriftDetectionInterval))); err != nil {
+			return fmt.Errorf("failed to add drift detector due to %w", err)
+		}
+	}
+	if config.FullSyncInterval > 0 {
+		if err := mgr.Add(election.LeaderOnly(elector, newFullSync(mgr.GetCache(), ingressChan, config.FullSyncInterval))); err != nil {
+			return fmt.Errorf("failed to add full sync due to %w", err)
+		}
+	}
+	if config.ChangeEventsQueueURL != "" {
+		consumer := changeevents.NewConsumer(cloud, mgr.GetCache(), ingressChan, config.ClusterName, config.ChangeEventsQueueURL)
+		if err := mgr.Add(election.LeaderOnly(elector, consumer)); err != nil {
+			return fmt.Errorf("failed to add change events consumer due to %w", err)
+		}
+	}
+	if err := initTargetGroupBindings(config, mgr, cloud, reconciler.store, elector); err != nil {
+		return fmt.Errorf("failed to init TargetGroupBinding controller due to %w", err)
 	}
 
 	return nil
 }
 
-func newReconciler(config *config.Configuration, mgr manager.Manager, mc metric.Collector, cloud aws.CloudAPI, authModule auth.Module) (reconcile.Reconciler, error) {
-	store, err := store.New(mgr, config)
+// initTargetGroupBindings adds a controller of TargetGroupBindings to mgr, if enabled by the feature gate.
+func initTargetGroupBindings(cfg *config.Configuration, mgr manager.Manager, cloud aws.CloudAPI, store store.Storer, elector election.Elector) error {
+	if !cfg.FeatureGate.Enabled(config.TargetGroupBinding) {
+		return nil
+	}
+	if err := v1alpha1.AddToScheme(mgr.GetScheme()); err != nil {
+		return err
+	}
+	endpointResolver := backend.NewEndpointResolver(store, cloud)
+	podConditionManager := backend.NewPodConditionManager(mgr.GetClient(), store, cloud)
+	targetsController := tg.NewTargetsController(cloud, endpointResolver, podConditionManager)
+	reconciler := tgbinding.NewReconciler(mgr.GetClient(), mgr.GetRecorder("targetgroupbinding-controller"), cloud, store, targetsController, elector)
+	c, err := controller.New("targetgroupbinding-controller", mgr, controller.Options{Reconciler: reconciler, MaxConcurrentReconciles: cfg.MaxConcurrentReconciles})
+	if err != nil {
+		return err
+	}
+	return tgbinding.Init(c, mgr.GetCache(), cfg.FeatureGate.Enabled(config.EndpointSlices))
+}
+
+func newReconciler(cfg *config.Configuration, mgr manager.Manager, mc metric.Collector, cloud aws.CloudAPI, authModule auth.Module, inv inventory.Inventory, elector election.Elector) (*Reconciler, error) {
+	store, err := store.New(mgr, cfg)
 	if err != nil {
 		return nil, err
 	}
-	nameTagGenerator := generator.NewNameTagGenerator(*config)
+	nameTagGenerator := generator.NewNameTagGenerator(*cfg)
 	tagsController := tags.NewController(cloud)
 	endpointResolver := backend.NewEndpointResolver(store, cloud)
-	tgGroupController := tg.NewGroupController(cloud, store, nameTagGenerator, tagsController, endpointResolver)
-	lsGroupController := ls.NewGroupController(store, cloud, authModule)
+	nodePortManager := backend.NewNodePortManager(mgr.GetClient(), store)
+	podConditionManager := backend.NewPodConditionManager(mgr.GetClient(), store, cloud)
+	tgGroupController := tg.NewGroupController(cloud, store, nameTagGenerator, tagsController, endpointResolver, nodePortManager, podConditionManager)
+	var certImporter ls.CertImporter
+	if cfg.FeatureGate.Enabled(config.TLSSecretImport) {
+		certImporter = ls.NewACMCertImporter(cloud, cfg.ClusterName)
+	}
+	lsGroupController := ls.NewGroupController(store, cloud, authModule, mgr.GetCache(), certImporter)
 	sgAssociationController := sg.NewAssociationController(store, cloud, tagsController, nameTagGenerator)
 	lbController := lb.NewController(cloud, store,
-		nameTagGenerator, tgGroupController, lsGroupController, sgAssociationController, tagsController)
+		nameTagGenerator, tgGroupController, lsGroupController, sgAssociationController, tagsController, certImporter)
+	failoverController := failover.NewController(cloud, store, nameTagGenerator, lbController)
+	blueGreenController := bluegreen.NewController(cloud, store, nameTagGenerator, lbController)
+	staticIPController := staticip.NewController(cloud, store, nameTagGenerator, tagsController)
+	var dnsController dns.Controller
+	if cfg.FeatureGate.Enabled(config.Route53Records) {
+		dnsController = dns.NewController(cloud, store)
+	}
 
 	return &Reconciler{
-		client:          mgr.GetClient(),
-		cache:           mgr.GetCache(),
-		recorder:        mgr.GetRecorder("alb-ingress-controller"),
-		store:           store,
-		lbController:    lbController,
-		metricCollector: mc,
+		client:              mgr.GetClient(),
+		cache:               mgr.GetCache(),
+		recorder:            mgr.GetRecorder("alb-ingress-controller"),
+		store:               store,
+		lbController:        lbController,
+		failoverController:  failoverController,
+		blueGreenController: blueGreenController,
+		staticIPController:  staticIPController,
+		dnsController:       dnsController,
+		healthChecker:       health.NewChecker(cloud),
+		circuitBreaker:      circuitbreaker.NewBreaker(cfg.CircuitBreakerThreshold, cfg.CircuitBreakerCoolDown),
+		verifier:            verify.NewVerifier(),
+		inventory:           inv,
+		elector:             elector,
+		groupLocks:          utils.NewKeyedMutex(),
+		metricCollector:     mc,
 	}, nil
 }
 
-func watchClusterEvents(c controller.Controller, cache cache.Cache, ingressChan <-chan event.GenericEvent, serviceChan <-chan event.GenericEvent, ingressClass string) error {
+func watchClusterEvents(c controller.Controller, cache cache.Cache, ingressChan <-chan event.GenericEvent, serviceChan <-chan event.GenericEvent, ingressClass string, ingressDebounceWindow time.Duration, featureGate config.FeatureGate) error {
+	if err := cache.IndexField(&extensions.Ingress{}, handlers.FieldServiceName, handlers.IndexIngressByServiceName); err != nil {
+		return err
+	}
+	if err := cache.IndexField(&extensions.Ingress{}, handlers.FieldTLSSecretName, handlers.IndexIngressByTLSSecretName); err != nil {
+		return err
+	}
+
 	if err := c.Watch(&source.Kind{Type: &extensions.Ingress{}}, &handlers.EnqueueRequestsForIngressEvent{
-		IngressClass: ingressClass,
+		IngressClass:   ingressClass,
+		DebounceWindow: ingressDebounceWindow,
 	}); err != nil {
 		return err
 	}
@@ -108,12 +218,34 @@ func watchClusterEvents(c controller.Controller, cache cache.Cache, ingressChan
 	}); err != nil {
 		return err
 	}
+	if featureGate.Enabled(config.EndpointSlices) {
+		if err := c.Watch(&source.Kind{Type: k8s.NewEndpointSlice()}, &handlers.EnqueueRequestsForEndpointSliceEvent{
+			EnqueueRequestsForEndpointsEvent: handlers.EnqueueRequestsForEndpointsEvent{
+				IngressClass: ingressClass,
+				Cache:        cache,
+			},
+		}); err != nil {
+			return err
+		}
+	}
 	if err := c.Watch(&source.Kind{Type: &corev1.Node{}}, &handlers.EnqueueRequestsForNodeEvent{
 		IngressClass: ingressClass,
 		Cache:        cache,
 	}); err != nil {
 		return err
 	}
+	if err := c.Watch(&source.Kind{Type: &corev1.Secret{}}, &handlers.EnqueueRequestsForSecretEvent{
+		IngressClass: ingressClass,
+		Cache:        cache,
+	}); err != nil {
+		return err
+	}
+	if err := c.Watch(&source.Kind{Type: &corev1.ConfigMap{}}, &handlers.EnqueueRequestsForReferenceGrantEvent{
+		IngressClass: ingressClass,
+		Cache:        cache,
+	}); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -0,0 +1,133 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/class"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/loadbalancer"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/group"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/k8s"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/log"
+	corev1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// newDriftDetector constructs a runnable that plans the reconcile of every ingress against live AWS state at each interval,
+// so that changes made to AWS resources outside of the controller are noticed while the ingresses don't change.
+// Ingresses with drift get a DriftDetected event, and are reconciled unless their drift remediation is DriftRemediationWarn.
+func newDriftDetector(r *Reconciler, ingressChan chan<- event.GenericEvent, interval time.Duration) manager.Runnable {
+	return &driftDetector{
+		reconciler:  r,
+		ingressChan: ingressChan,
+		interval:    interval,
+		logger:      log.New("drift-detector"),
+	}
+}
+
+type driftDetector struct {
+	reconciler  *Reconciler
+	ingressChan chan<- event.GenericEvent
+	interval    time.Duration
+	logger      *log.Logger
+}
+
+// Start implements manager.Runnable
+func (d *driftDetector) Start(stop <-chan struct{}) error {
+	wait.Until(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), d.interval)
+		defer cancel()
+		if err := d.detect(ctx); err != nil {
+			d.logger.Errorf("failed to detect drift due to %v", err)
+		}
+	}, d.interval, stop)
+	return nil
+}
+
+func (d *driftDetector) detect(ctx context.Context) error {
+	ingressList := &extensions.IngressList{}
+	if err := d.reconciler.cache.List(ctx, nil, ingressList); err != nil {
+		return err
+	}
+	var keys []types.NamespacedName
+	membersByKey := make(map[types.NamespacedName][]*extensions.Ingress)
+	for i := range ingressList.Items {
+		ingress := &ingressList.Items[i]
+		if !class.IsValidIngress(d.reconciler.store.GetConfig().IngressClass, ingress) || ingress.DeletionTimestamp != nil {
+			continue
+		}
+		key := k8s.NamespacedName(ingress)
+		if groupName := group.Name(ingress); groupName != "" {
+			key = group.Key(groupName)
+		}
+		if _, ok := membersByKey[key]; !ok {
+			keys = append(keys, key)
+		}
+		membersByKey[key] = append(membersByKey[key], ingress)
+	}
+
+	for _, key := range keys {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		d.detectIngress(ctx, key, membersByKey[key])
+	}
+	return nil
+}
+
+// detectIngress plans the reconcile of the ingress or IngressGroup with key, whose members are members.
+// Failures are logged, so that a single broken ingress doesn't stop drift detection of the others.
+func (d *driftDetector) detectIngress(ctx context.Context, key types.NamespacedName, members []*extensions.Ingress) {
+	remediation := loadbalancer.DriftRemediationEnforce
+	for _, member := range members {
+		// dry-run ingresses report their plan on every reconcile already.
+		if d.reconciler.dryRun(member) {
+			return
+		}
+		if d.reconciler.paused(member) || d.driftRemediation(member) == loadbalancer.DriftRemediationWarn {
+			remediation = loadbalancer.DriftRemediationWarn
+		}
+	}
+
+	plan := &albctx.Plan{}
+	var err error
+	if group.IsKey(key) {
+		groupCtx := d.reconciler.buildGroupReconcileContext(ctx, key, members)
+		_, err = d.reconciler.reconcileGroup(albctx.SetPlan(groupCtx, plan), key, members, newStatusReport())
+		ctx = groupCtx
+	} else {
+		ctx = d.reconciler.buildReconcileContext(ctx, key, members[0])
+		_, err = d.reconciler.reconcileLoadBalancers(albctx.SetPlan(ctx, plan), key, members[0], newStatusReport())
+	}
+	if err != nil && !plan.Halted() {
+		d.logger.Warnf("failed to detect drift of ingress %v due to %v", key, err)
+		return
+	}
+	message := describePlan(plan)
+	if message == "" {
+		return
+	}
+	d.logger.Infof("drift detected for ingress %v: %v", key, message)
+	if remediation == loadbalancer.DriftRemediationWarn {
+		albctx.GetEventf(ctx)(corev1.EventTypeWarning, "DriftDetected", "%v", message)
+		return
+	}
+	albctx.GetEventf(ctx)(corev1.EventTypeWarning, "DriftDetected", "%v, reconciling to correct it", message)
+	d.ingressChan <- event.GenericEvent{
+		Meta:   members[0],
+		Object: members[0],
+	}
+}
+
+func (d *driftDetector) driftRemediation(ingress *extensions.Ingress) string {
+	ingressAnnos, err := d.reconciler.store.GetIngressAnnotations(k8s.MetaNamespaceKey(ingress))
+	if err != nil {
+		return loadbalancer.DriftRemediationEnforce
+	}
+	return ingressAnnos.LoadBalancer.DriftRemediation
+}
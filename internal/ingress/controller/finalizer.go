@@ -0,0 +1,74 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/class"
+	extensions "k8s.io/api/extensions/v1beta1"
+)
+
+// IngressFinalizer is added to ingresses before their AWS resources are created, so that deleting an ingress while the
+// controller is down doesn't orphan its LoadBalancer, targetGroups, security groups and DNS records.
+const IngressFinalizer = "ingress.k8s.aws/resources"
+
+// addIngressFinalizer adds IngressFinalizer to ingress, unless already present.
+func (r *Reconciler) addIngressFinalizer(ctx context.Context, ingress *extensions.Ingress) error {
+	if hasIngressFinalizer(ingress) {
+		return nil
+	}
+	ingress.Finalizers = append(ingress.Finalizers, IngressFinalizer)
+	return r.client.Update(ctx, ingress)
+}
+
+// removeIngressFinalizer removes IngressFinalizer from ingress once its AWS resources are deleted, which lets the deletion complete.
+func (r *Reconciler) removeIngressFinalizer(ctx context.Context, ingress *extensions.Ingress) error {
+	if !hasIngressFinalizer(ingress) {
+		return nil
+	}
+	var finalizers []string
+	for _, f := range ingress.Finalizers {
+		if f != IngressFinalizer {
+			finalizers = append(finalizers, f)
+		}
+	}
+	ingress.Finalizers = finalizers
+	return r.client.Update(ctx, ingress)
+}
+
+// removeIngressFinalizers removes IngressFinalizer from each of ingresses.
+func (r *Reconciler) removeIngressFinalizers(ctx context.Context, ingresses []*extensions.Ingress) error {
+	for _, ingress := range ingresses {
+		if err := r.removeIngressFinalizer(ctx, ingress); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func hasIngressFinalizer(ingress *extensions.Ingress) bool {
+	for _, f := range ingress.Finalizers {
+		if f == IngressFinalizer {
+			return true
+		}
+	}
+	return false
+}
+
+// departedIngress returns whether ingress was reconciled by the controller, but has since been moved to another ingress class than ingressClass.
+// Its finalizer must be removed, since the controller won't reconcile its deletion later on.
+func departedIngress(ingressClass string, ingress *extensions.Ingress) bool {
+	return hasIngressFinalizer(ingress) && !class.IsValidIngress(ingressClass, ingress)
+}
+
+// splitDeletingIngresses splits ingresses into those still live and those being deleted.
+func splitDeletingIngresses(ingresses []*extensions.Ingress) ([]*extensions.Ingress, []*extensions.Ingress) {
+	var live, deleting []*extensions.Ingress
+	for _, ingress := range ingresses {
+		if ingress.DeletionTimestamp != nil {
+			deleting = append(deleting, ingress)
+		} else {
+			live = append(live, ingress)
+		}
+	}
+	return live, deleting
+}
@@ -0,0 +1,51 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReconciler_ingressFinalizer(t *testing.T) {
+	ctx := context.Background()
+	ingressKey := types.NamespacedName{Namespace: "namespace", Name: "ingress"}
+	ingress := &extensions.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "namespace", Name: "ingress", Finalizers: []string{"other"}}}
+	r := &Reconciler{client: fake.NewFakeClient(ingress.DeepCopy())}
+
+	stored := &extensions.Ingress{}
+	assert.NoError(t, r.addIngressFinalizer(ctx, ingress))
+	assert.NoError(t, r.addIngressFinalizer(ctx, ingress))
+	assert.NoError(t, r.client.Get(ctx, ingressKey, stored))
+	assert.Equal(t, []string{"other", IngressFinalizer}, stored.Finalizers)
+
+	assert.NoError(t, r.removeIngressFinalizer(ctx, ingress))
+	assert.NoError(t, r.client.Get(ctx, ingressKey, stored))
+	assert.Equal(t, []string{"other"}, stored.Finalizers)
+}
+
+func Test_splitDeletingIngresses(t *testing.T) {
+	now := metav1.Now()
+	live := &extensions.Ingress{ObjectMeta: metav1.ObjectMeta{Name: "live"}}
+	deleting := &extensions.Ingress{ObjectMeta: metav1.ObjectMeta{Name: "deleting", DeletionTimestamp: &now}}
+
+	actualLive, actualDeleting := splitDeletingIngresses([]*extensions.Ingress{live, deleting})
+	assert.Equal(t, []*extensions.Ingress{live}, actualLive)
+	assert.Equal(t, []*extensions.Ingress{deleting}, actualDeleting)
+}
+
+func Test_departedIngress(t *testing.T) {
+	withClass := func(ingressClass string, finalizers ...string) *extensions.Ingress {
+		return &extensions.Ingress{ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{"kubernetes.io/ingress.class": ingressClass},
+			Finalizers:  finalizers,
+		}}
+	}
+	assert.False(t, departedIngress("alb", withClass("alb", IngressFinalizer)))
+	assert.True(t, departedIngress("alb", withClass("nginx", IngressFinalizer)))
+	assert.False(t, departedIngress("alb", withClass("nginx")))
+}
@@ -0,0 +1,64 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/log"
+	extensions "k8s.io/api/extensions/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// newFullSync constructs a runnable that enqueues every ingress at each interval. Ingresses are otherwise only reconciled once they,
+// or the services, endpoints and nodes they depend on, change, so the full sync only catches up on changes that were missed.
+func newFullSync(cache client.Reader, ingressChan chan<- event.GenericEvent, interval time.Duration) manager.Runnable {
+	return &fullSync{
+		cache:       cache,
+		ingressChan: ingressChan,
+		interval:    interval,
+		logger:      log.New("full-sync"),
+	}
+}
+
+type fullSync struct {
+	cache       client.Reader
+	ingressChan chan<- event.GenericEvent
+	interval    time.Duration
+	logger      *log.Logger
+}
+
+// Start implements manager.Runnable, the first sync happens after interval since every ingress is reconciled once the controller starts.
+func (s *fullSync) Start(stop <-chan struct{}) error {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return nil
+		case <-ticker.C:
+			if err := s.sync(stop); err != nil {
+				s.logger.Errorf("failed to sync ingresses due to %v", err)
+			}
+		}
+	}
+}
+
+// sync enqueues every ingress, the ingress class and IngressGroups are resolved by the event handler.
+func (s *fullSync) sync(stop <-chan struct{}) error {
+	ingressList := &extensions.IngressList{}
+	if err := s.cache.List(context.Background(), nil, ingressList); err != nil {
+		return err
+	}
+	s.logger.Infof("reconciling all %d ingresses", len(ingressList.Items))
+	for i := range ingressList.Items {
+		ingress := &ingressList.Items[i]
+		select {
+		case s.ingressChan <- event.GenericEvent{Meta: ingress, Object: ingress}:
+		case <-stop:
+			return nil
+		}
+	}
+	return nil
+}
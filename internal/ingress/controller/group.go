@@ -0,0 +1,238 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/lb"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/class"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/loadbalancer"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/group"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/k8s"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/log"
+	corev1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// reconcileGroupRequest reconciles the IngressGroup with groupKey, or deletes its LoadBalancer once it has no members.
+// Requests queued for a member before it joined the group are reconciled as the group too, so the group is locked against concurrent workers.
+func (r *Reconciler) reconcileGroupRequest(ctx context.Context, groupKey types.NamespacedName) (reconcile.Result, error) {
+	r.groupLocks.Lock(groupKey.String())
+	defer r.groupLocks.Unlock(groupKey.String())
+	members, departed, err := r.listGroupMembers(ctx, groupKey.Name)
+	if err != nil {
+		r.metricCollector.IncReconcileErrorCount(groupKey.String())
+		return reconcile.Result{}, err
+	}
+	// members being deleted, or moved to another ingress class, leave the group, their finalizer is removed once the group no longer serves them.
+	members, deleting := splitDeletingIngresses(members)
+	deleting = append(deleting, departed...)
+	if len(members) == 0 {
+		for _, member := range deleting {
+			if r.paused(member) {
+				albctx.GetLogger(r.buildGroupReconcileContext(ctx, groupKey, deleting)).Infof("reconcile paused by ingress %v/%v, AWS resources are deleted once resumed", member.Namespace, member.Name)
+				return reconcile.Result{}, nil
+			}
+		}
+		if err := r.deleteIngress(ctx, groupKey); err != nil {
+			r.metricCollector.IncReconcileErrorCount(groupKey.String())
+			return reconcile.Result{}, err
+		}
+		r.store.DeleteDerivedIngress(&extensions.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Namespace: groupKey.Namespace, Name: groupKey.Name},
+		})
+		if err := r.removeIngressFinalizers(ctx, deleting); err != nil {
+			r.metricCollector.IncReconcileErrorCount(groupKey.String())
+			return reconcile.Result{}, err
+		}
+		r.inventory.Forget(groupKey)
+		r.metricCollector.RemoveMetrics(groupKey.String())
+
+		r.metricCollector.IncReconcileCount()
+		return reconcile.Result{}, nil
+	}
+
+	start := time.Now()
+	r.inventory.BeginReconcile(groupKey)
+	result, err := r.reconcileGroupMembers(r.buildGroupReconcileContext(ctx, groupKey, members), groupKey, members)
+	r.metricCollector.ObserveReconcileDuration(groupKey.String(), time.Since(start))
+	r.inventory.RecordReconcile(groupKey, err)
+	if err != nil {
+		r.metricCollector.IncReconcileErrorCount(groupKey.String())
+		return reconcile.Result{}, err
+	}
+	if err := r.removeIngressFinalizers(ctx, deleting); err != nil {
+		r.metricCollector.IncReconcileErrorCount(groupKey.String())
+		return reconcile.Result{}, err
+	}
+
+	r.metricCollector.IncReconcileCount()
+	return result, nil
+}
+
+// reconcileGroupMembers reconciles the IngressGroup of members, and records the observed state as status conditions of every member.
+// The group is paused, or reconciled in dry-run, if any member is.
+func (r *Reconciler) reconcileGroupMembers(ctx context.Context, groupKey types.NamespacedName, members []*extensions.Ingress) (reconcile.Result, error) {
+	for _, member := range members {
+		if r.paused(member) {
+			plan := &albctx.Plan{}
+			result, err := r.reconcileGroup(albctx.SetPlan(ctx, plan), groupKey, members, newStatusReport())
+			return r.reportDrift(ctx, plan, result, err)
+		}
+	}
+	for _, member := range members {
+		if r.dryRun(member) {
+			plan := &albctx.Plan{}
+			result, err := r.reconcileGroup(albctx.SetPlan(ctx, plan), groupKey, members, newStatusReport())
+			return r.reportPlan(ctx, plan, result, err)
+		}
+	}
+
+	for _, member := range members {
+		if err := r.addIngressFinalizer(ctx, member); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+	failures := &albctx.AWSFailures{}
+	report := newStatusReport()
+	result, err := r.reconcileGroup(albctx.SetCertificates(albctx.SetAWSFailures(ctx, failures), report.certificates), groupKey, members, report)
+	for _, member := range members {
+		if statusErr := r.reconcileStatusConditions(ctx, member, report, err); statusErr != nil {
+			albctx.GetLogger(ctx).Errorf("failed to update status conditions of ingress %v/%v due to %v", member.Namespace, member.Name, statusErr)
+		}
+	}
+	if err != nil {
+		if operation := failures.ThrottledCause(err); operation != "" {
+			return requeueThrottled(ctx, operation, err), nil
+		}
+		return reconcile.Result{}, err
+	}
+	if len(inactiveLoadBalancers(report.lbInfos)) != 0 && (result.RequeueAfter == 0 || result.RequeueAfter > provisioningRequeueInterval) {
+		result.RequeueAfter = provisioningRequeueInterval
+	}
+	return result, nil
+}
+
+// reconcileGroup reconciles a single LoadBalancer for the merged ingress of members, the observed state is recorded into report.
+func (r *Reconciler) reconcileGroup(ctx context.Context, groupKey types.NamespacedName, members []*extensions.Ingress, report *statusReport) (reconcile.Result, error) {
+	routedMembers := make([]*extensions.Ingress, 0, len(members))
+	for _, member := range members {
+		routed, err := r.pruneUngrantedBackends(ctx, member)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		routedMembers = append(routedMembers, routed)
+	}
+	merged, err := group.Merge(groupKey.Name, routedMembers)
+	if err != nil {
+		albctx.GetEventf(ctx)(corev1.EventTypeWarning, "ERROR", "%v", err)
+		return reconcile.Result{}, err
+	}
+	r.store.UpdateDerivedIngress(merged)
+	ingressAnnos, err := r.store.GetIngressAnnotations(k8s.MetaNamespaceKey(merged))
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	r.inventory.RecordDesired(groupKey, ingressAnnos)
+	if err := checkGroupLoadBalancerAnnotations(ingressAnnos.LoadBalancer); err != nil {
+		albctx.GetEventf(ctx)(corev1.EventTypeWarning, "ERROR", "%v", err)
+		return reconcile.Result{}, err
+	}
+
+	lbInfo, err := r.lbController.Reconcile(ctx, merged)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	report.lbInfos = []*lb.LoadBalancer{lbInfo}
+	r.inventory.RecordCurrent(groupKey, report.lbInfos)
+	if err := r.checkHealth(ctx, report, nil, report.lbInfos); err != nil {
+		return reconcile.Result{}, err
+	}
+	for _, member := range members {
+		// members that joined the group may still have a LoadBalancer of their own.
+		if len(member.Status.LoadBalancer.Ingress) != 0 && member.Status.LoadBalancer.Ingress[0].Hostname != lbInfo.DNSName {
+			albctx.GetLogger(ctx).Infof("deleting LoadBalancer of ingress %v/%v, which joined the group", member.Namespace, member.Name)
+			if err := r.deleteIngress(ctx, types.NamespacedName{Namespace: member.Namespace, Name: member.Name}); err != nil {
+				return reconcile.Result{}, err
+			}
+		}
+		if err := r.updateIngressStatus(ctx, member, []*lb.LoadBalancer{lbInfo}); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+	r.metricCollector.SetManagedResources(groupKey.String(), managedResourceCounts([]*lb.LoadBalancer{lbInfo}))
+	if r.dnsController != nil {
+		if err := r.dnsController.Reconcile(ctx, merged, []*extensions.Ingress{merged}, []*lb.LoadBalancer{lbInfo}); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+	return reconcile.Result{}, nil
+}
+
+// listGroupMembers returns the ingresses of this controller that belong to the group,
+// along with those of the group that still have IngressFinalizer but were moved to another ingress class,
+// or are in a namespace no longer allowed to join the group.
+func (r *Reconciler) listGroupMembers(ctx context.Context, groupName string) ([]*extensions.Ingress, []*extensions.Ingress, error) {
+	ingressList := &extensions.IngressList{}
+	if err := r.cache.List(ctx, nil, ingressList); err != nil {
+		return nil, nil, err
+	}
+	var members, departed []*extensions.Ingress
+	for i := range ingressList.Items {
+		ingress := &ingressList.Items[i]
+		if group.Name(ingress) != groupName {
+			continue
+		}
+		if departedIngress(r.store.GetConfig().IngressClass, ingress) {
+			departed = append(departed, ingress)
+		}
+		if !class.IsValidIngress(r.store.GetConfig().IngressClass, ingress) {
+			continue
+		}
+		// otherwise any user able to create ingresses could route traffic of another team's ALB to their services.
+		if !r.store.GetConfig().IngressGroupAllowsNamespace(groupName, ingress.Namespace) {
+			r.recorder.Eventf(ingress, corev1.EventTypeWarning, "ERROR", "namespace %v isn't allowed to join IngressGroup %v", ingress.Namespace, groupName)
+			if hasIngressFinalizer(ingress) {
+				departed = append(departed, ingress)
+			}
+			continue
+		}
+		members = append(members, ingress)
+	}
+	return members, departed, nil
+}
+
+// checkGroupLoadBalancerAnnotations rejects annotations that manage more than a single LoadBalancer, which are not supported for IngressGroups.
+func checkGroupLoadBalancerAnnotations(lbAnnos *loadbalancer.Config) error {
+	for _, annotation := range []struct {
+		name string
+		set  bool
+	}{
+		{"shard-max-rules", lbAnnos.ShardMaxRules != nil},
+		{"failover-record-name", lbAnnos.Failover != nil},
+		{"active-stack", lbAnnos.ActiveStack != nil},
+		{"static-ip", lbAnnos.StaticIP != nil},
+		{"degraded-threshold", lbAnnos.DegradedThreshold != nil},
+		{"verification-success-codes", lbAnnos.VerificationSuccessCodes != nil},
+	} {
+		if annotation.set {
+			return fmt.Errorf("annotation %v is not supported for IngressGroups", annotation.name)
+		}
+	}
+	return nil
+}
+
+// buildGroupReconcileContext builds the context to reconcile group with, events are recorded on every member.
+func (r *Reconciler) buildGroupReconcileContext(ctx context.Context, groupKey types.NamespacedName, members []*extensions.Ingress) context.Context {
+	ctx = albctx.SetLogger(ctx, log.New(groupKey.String()).WithSubsystem("reconcile").WithValues("ingress", groupKey.String()))
+	return albctx.SetEventf(ctx, func(eventType string, reason string, messageFmt string, args ...interface{}) {
+		for _, member := range members {
+			r.recorder.Eventf(member, eventType, reason, messageFmt, log.RedactArgs(args)...)
+		}
+	})
+}
@@ -14,7 +14,6 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
-	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 var _ handler.EventHandler = (*EnqueueRequestsForEndpointsEvent)(nil)
@@ -26,7 +25,7 @@ type EnqueueRequestsForEndpointsEvent struct {
 
 // Create is called in response to an create event - e.g. Pod Creation.
 func (h *EnqueueRequestsForEndpointsEvent) Create(e event.CreateEvent, queue workqueue.RateLimitingInterface) {
-	h.enqueueImpactedIngresses(e.Object.(*corev1.Endpoints), queue)
+	h.enqueueImpactedIngresses(endpointsServiceKey(e.Object.(*corev1.Endpoints)), queue)
 }
 
 // Update is called in response to an update event -  e.g. Pod Updated.
@@ -34,13 +33,13 @@ func (h *EnqueueRequestsForEndpointsEvent) Update(e event.UpdateEvent, queue wor
 	epOld := e.ObjectOld.(*corev1.Endpoints)
 	epNew := e.ObjectNew.(*corev1.Endpoints)
 	if !reflect.DeepEqual(epOld.Subsets, epNew.Subsets) {
-		h.enqueueImpactedIngresses(epNew, queue)
+		h.enqueueImpactedIngresses(endpointsServiceKey(epNew), queue)
 	}
 }
 
 // Delete is called in response to a delete event - e.g. Pod Deleted.
 func (h *EnqueueRequestsForEndpointsEvent) Delete(e event.DeleteEvent, queue workqueue.RateLimitingInterface) {
-	h.enqueueImpactedIngresses(e.Object.(*corev1.Endpoints), queue)
+	h.enqueueImpactedIngresses(endpointsServiceKey(e.Object.(*corev1.Endpoints)), queue)
 }
 
 // Generic is called in response to an event of an unknown type or a synthetic event triggered as a cron or
@@ -48,10 +47,14 @@ func (h *EnqueueRequestsForEndpointsEvent) Delete(e event.DeleteEvent, queue wor
 func (h *EnqueueRequestsForEndpointsEvent) Generic(event.GenericEvent, workqueue.RateLimitingInterface) {
 }
 
-//TODO: this can be further optimized to only included ingresses referenced this endpoints(service) :D
-func (h *EnqueueRequestsForEndpointsEvent) enqueueImpactedIngresses(endpoints *corev1.Endpoints, queue workqueue.RateLimitingInterface) {
+func endpointsServiceKey(endpoints *corev1.Endpoints) types.NamespacedName {
+	return types.NamespacedName{Namespace: endpoints.Namespace, Name: endpoints.Name}
+}
+
+// enqueueImpactedIngresses enqueues ingresses with backends referencing the service of endpoints.
+func (h *EnqueueRequestsForEndpointsEvent) enqueueImpactedIngresses(serviceKey types.NamespacedName, queue workqueue.RateLimitingInterface) {
 	ingressList := &extensions.IngressList{}
-	if err := h.Cache.List(context.Background(), client.InNamespace(endpoints.Namespace), ingressList); err != nil {
+	if err := h.Cache.List(context.Background(), client.MatchingField(FieldServiceName, serviceKey.String()), ingressList); err != nil {
 		glog.Errorf("failed to fetch impacted ingresses by endpoints due to %v", err)
 		return
 	}
@@ -60,11 +63,6 @@ func (h *EnqueueRequestsForEndpointsEvent) enqueueImpactedIngresses(endpoints *c
 		if !class.IsValidIngress(h.IngressClass, &ingress) {
 			continue
 		}
-		queue.Add(reconcile.Request{
-			NamespacedName: types.NamespacedName{
-				Namespace: ingress.Namespace,
-				Name:      ingress.Name,
-			},
-		})
+		queue.Add(requestForIngress(&ingress))
 	}
 }
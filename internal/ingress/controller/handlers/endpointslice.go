@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"reflect"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/k8s"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+)
+
+var _ handler.EventHandler = (*EnqueueRequestsForEndpointSliceEvent)(nil)
+
+// EnqueueRequestsForEndpointSliceEvent enqueues the ingresses of the Service an EndpointSlice belongs to, upon changes of its endpoints.
+type EnqueueRequestsForEndpointSliceEvent struct {
+	EnqueueRequestsForEndpointsEvent
+}
+
+// Create is called in response to an create event - e.g. Pod Creation.
+func (h *EnqueueRequestsForEndpointSliceEvent) Create(e event.CreateEvent, queue workqueue.RateLimitingInterface) {
+	h.enqueueImpactedIngresses(e.Object.(*unstructured.Unstructured), queue)
+}
+
+// Update is called in response to an update event -  e.g. Pod Updated.
+func (h *EnqueueRequestsForEndpointSliceEvent) Update(e event.UpdateEvent, queue workqueue.RateLimitingInterface) {
+	sliceOld := e.ObjectOld.(*unstructured.Unstructured).UnstructuredContent()
+	sliceNew := e.ObjectNew.(*unstructured.Unstructured).UnstructuredContent()
+	if !reflect.DeepEqual(sliceOld["endpoints"], sliceNew["endpoints"]) || !reflect.DeepEqual(sliceOld["ports"], sliceNew["ports"]) {
+		h.enqueueImpactedIngresses(e.ObjectNew.(*unstructured.Unstructured), queue)
+	}
+}
+
+// Delete is called in response to a delete event - e.g. Pod Deleted.
+func (h *EnqueueRequestsForEndpointSliceEvent) Delete(e event.DeleteEvent, queue workqueue.RateLimitingInterface) {
+	h.enqueueImpactedIngresses(e.Object.(*unstructured.Unstructured), queue)
+}
+
+// Generic is called in response to an event of an unknown type or a synthetic event triggered as a cron or
+// external trigger request - e.g. reconcile Autoscaling, or a Webhook.
+func (h *EnqueueRequestsForEndpointSliceEvent) Generic(event.GenericEvent, workqueue.RateLimitingInterface) {
+}
+
+// enqueueImpactedIngresses enqueues ingresses with backends referencing the service of slice.
+func (h *EnqueueRequestsForEndpointSliceEvent) enqueueImpactedIngresses(slice *unstructured.Unstructured, queue workqueue.RateLimitingInterface) {
+	serviceKey, ok := k8s.EndpointSliceServiceKey(slice)
+	if !ok {
+		return
+	}
+	h.EnqueueRequestsForEndpointsEvent.enqueueImpactedIngresses(serviceKey, queue)
+}
@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/ls"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/backend"
+	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// FieldServiceName is the index of ingresses by the services(in namespace/name format) referenced by their backends,
+// including services in other namespaces.
+const FieldServiceName = "serviceName"
+
+// IndexIngressByServiceName is the IndexerFunc for FieldServiceName.
+func IndexIngressByServiceName(obj runtime.Object) []string {
+	ingress := obj.(*extensions.Ingress)
+	var serviceKeys []string
+	for _, serviceKey := range backend.ServiceKeys(ingress) {
+		serviceKeys = append(serviceKeys, serviceKey.String())
+	}
+	return serviceKeys
+}
+
+// FieldTLSSecretName is the index of ingresses by the secrets(in namespace/name format) referenced by the secretName of their TLS entries.
+const FieldTLSSecretName = "tlsSecretName"
+
+// IndexIngressByTLSSecretName is the IndexerFunc for FieldTLSSecretName.
+func IndexIngressByTLSSecretName(obj runtime.Object) []string {
+	ingress := obj.(*extensions.Ingress)
+	return ls.TLSSecretKeys(ingress).List()
+}
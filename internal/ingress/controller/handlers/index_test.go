@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestIndexIngressByServiceName(t *testing.T) {
+	for _, tc := range []struct {
+		name            string
+		annotations     map[string]string
+		spec            extensions.IngressSpec
+		expectedIndexes []string
+	}{
+		{
+			name:            "no backends",
+			spec:            extensions.IngressSpec{},
+			expectedIndexes: nil,
+		},
+		{
+			name: "default backend and rules",
+			spec: extensions.IngressSpec{
+				Backend: &extensions.IngressBackend{ServiceName: "default", ServicePort: intstr.FromInt(80)},
+				Rules: []extensions.IngressRule{
+					{
+						IngressRuleValue: extensions.IngressRuleValue{
+							HTTP: &extensions.HTTPIngressRuleValue{
+								Paths: []extensions.HTTPIngressPath{
+									{Path: "/a", Backend: extensions.IngressBackend{ServiceName: "service-b", ServicePort: intstr.FromInt(80)}},
+									{Path: "/b", Backend: extensions.IngressBackend{ServiceName: "service-a", ServicePort: intstr.FromString("http")}},
+									{Path: "/c", Backend: extensions.IngressBackend{ServiceName: "service-a", ServicePort: intstr.FromInt(8080)}},
+								},
+							},
+						},
+					},
+					{Host: "no-http.example.com"},
+				},
+			},
+			expectedIndexes: []string{"namespace/default", "namespace/service-a", "namespace/service-b"},
+		},
+		{
+			name: "services in other namespaces",
+			annotations: map[string]string{
+				"alb.ingress.kubernetes.io/service-namespace.shared": "platform",
+			},
+			spec: extensions.IngressSpec{
+				Backend: &extensions.IngressBackend{ServiceName: "shared", ServicePort: intstr.FromInt(80)},
+			},
+			expectedIndexes: []string{"platform/shared"},
+		},
+		{
+			name: "actions are not services",
+			spec: extensions.IngressSpec{
+				Rules: []extensions.IngressRule{
+					{
+						IngressRuleValue: extensions.IngressRuleValue{
+							HTTP: &extensions.HTTPIngressRuleValue{
+								Paths: []extensions.HTTPIngressPath{
+									{Path: "/redirect", Backend: extensions.IngressBackend{ServiceName: "redirect", ServicePort: intstr.FromString("use-annotation")}},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedIndexes: nil,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ingress := &extensions.Ingress{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "namespace", Name: "ingress", Annotations: tc.annotations},
+				Spec:       tc.spec,
+			}
+			assert.Equal(t, tc.expectedIndexes, IndexIngressByServiceName(ingress))
+		})
+	}
+}
+
+func TestIndexIngressByTLSSecretName(t *testing.T) {
+	ingress := &extensions.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "namespace", Name: "ingress"},
+		Spec: extensions.IngressSpec{
+			TLS: []extensions.IngressTLS{
+				{SecretName: "tls-b"},
+				{SecretName: "arn:aws:acm:us-west-2:123456789012:certificate/11111111-1111-1111-1111-111111111111"},
+				{SecretName: "tls-a"},
+				{Hosts: []string{"no-secret.example.com"}},
+			},
+		},
+	}
+	assert.Equal(t, []string{"namespace/tls-a", "namespace/tls-b"}, IndexIngressByTLSSecretName(ingress))
+}
@@ -1,7 +1,12 @@
 package handlers
 
 import (
+	"reflect"
+	"strings"
+	"time"
+
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/class"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/group"
 	extensions "k8s.io/api/extensions/v1beta1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/workqueue"
@@ -12,8 +17,15 @@ import (
 
 var _ handler.EventHandler = (*EnqueueRequestsForIngressEvent)(nil)
 
+// controllerAnnotationPrefix is the prefix of the annotations the controller records on ingresses.
+const controllerAnnotationPrefix = "ingress.k8s.aws/"
+
 type EnqueueRequestsForIngressEvent struct {
 	IngressClass string
+
+	// DebounceWindow delays reconcile of an ingress after its first event, so that a burst of events within the window
+	// is coalesced into a single reconcile. Events are enqueued immediately when zero.
+	DebounceWindow time.Duration
 }
 
 // Create is called in response to an create event - e.g. Pod Creation.
@@ -22,9 +34,16 @@ func (h *EnqueueRequestsForIngressEvent) Create(e event.CreateEvent, queue workq
 }
 
 // Update is called in response to an update event -  e.g. Pod Updated.
+// Resyncs of the cache and updates of status or of the annotations recorded by the controller don't change the desired state of the ingress,
+// so they aren't reconciled.
 func (h *EnqueueRequestsForIngressEvent) Update(e event.UpdateEvent, queue workqueue.RateLimitingInterface) {
-	h.enqueueIfIngressClassMatched(e.ObjectOld.(*extensions.Ingress), queue)
-	h.enqueueIfIngressClassMatched(e.ObjectNew.(*extensions.Ingress), queue)
+	ingressOld := e.ObjectOld.(*extensions.Ingress)
+	ingressNew := e.ObjectNew.(*extensions.Ingress)
+	if !ingressChanged(ingressOld, ingressNew) {
+		return
+	}
+	h.enqueueIfIngressClassMatched(ingressOld, queue)
+	h.enqueueIfIngressClassMatched(ingressNew, queue)
 }
 
 // Delete is called in response to a delete event - e.g. Pod Deleted.
@@ -42,10 +61,44 @@ func (h *EnqueueRequestsForIngressEvent) enqueueIfIngressClassMatched(ingress *e
 	if !class.IsValidIngress(h.IngressClass, ingress) {
 		return
 	}
-	queue.Add(reconcile.Request{
+	request := requestForIngress(ingress)
+	if h.DebounceWindow > 0 {
+		// the delaying queue keeps the earliest time a request is added after, so later events within the window are coalesced.
+		queue.AddAfter(request, h.DebounceWindow)
+		return
+	}
+	queue.Add(request)
+}
+
+// ingressChanged returns whether the update from ingressOld to ingressNew may change its AWS resources.
+func ingressChanged(ingressOld *extensions.Ingress, ingressNew *extensions.Ingress) bool {
+	return !reflect.DeepEqual(ingressOld.Spec, ingressNew.Spec) ||
+		!reflect.DeepEqual(ingressOld.Labels, ingressNew.Labels) ||
+		!reflect.DeepEqual(userAnnotations(ingressOld), userAnnotations(ingressNew)) ||
+		(ingressOld.DeletionTimestamp == nil) != (ingressNew.DeletionTimestamp == nil)
+}
+
+// userAnnotations returns the annotations of ingress except those recorded by the controller, like its conditions.
+func userAnnotations(ingress *extensions.Ingress) map[string]string {
+	annotations := make(map[string]string, len(ingress.Annotations))
+	for key, value := range ingress.Annotations {
+		if !strings.HasPrefix(key, controllerAnnotationPrefix) {
+			annotations[key] = value
+		}
+	}
+	return annotations
+}
+
+// requestForIngress returns the reconcile request of ingress. Members of an IngressGroup are reconciled together under the key of the group,
+// so that leaving the group or deletion updates the group as well, and concurrent workers never reconcile the same LoadBalancer.
+func requestForIngress(ingress *extensions.Ingress) reconcile.Request {
+	if groupName := group.Name(ingress); groupName != "" {
+		return reconcile.Request{NamespacedName: group.Key(groupName)}
+	}
+	return reconcile.Request{
 		NamespacedName: types.NamespacedName{
 			Namespace: ingress.Namespace,
 			Name:      ingress.Name,
 		},
-	})
+	}
 }
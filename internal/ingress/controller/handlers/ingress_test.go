@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/group"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestEnqueueRequestsForIngressEvent_Update(t *testing.T) {
+	ingress := &extensions.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "ingress"},
+	}
+	updated := ingress.DeepCopy()
+	updated.Annotations = map[string]string{"alb.ingress.kubernetes.io/scheme": "internal"}
+	for _, tc := range []struct {
+		name           string
+		debounceWindow time.Duration
+		expectedDelay  bool
+	}{
+		{
+			name:           "debounce disabled",
+			debounceWindow: 0,
+			expectedDelay:  false,
+		},
+		{
+			name:           "debounce enabled",
+			debounceWindow: 100 * time.Millisecond,
+			expectedDelay:  true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+			defer queue.ShutDown()
+			h := &EnqueueRequestsForIngressEvent{DebounceWindow: tc.debounceWindow}
+			for i := 0; i < 3; i++ {
+				h.Update(event.UpdateEvent{ObjectOld: ingress, ObjectNew: updated}, queue)
+			}
+			if tc.expectedDelay {
+				assert.Equal(t, 0, queue.Len())
+				time.Sleep(3 * tc.debounceWindow)
+			}
+			assert.Equal(t, 1, queue.Len())
+		})
+	}
+}
+
+func TestEnqueueRequestsForIngressEvent_UpdateUnchanged(t *testing.T) {
+	ingress := &extensions.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       "default",
+			Name:            "ingress",
+			ResourceVersion: "1",
+			Annotations:     map[string]string{"alb.ingress.kubernetes.io/scheme": "internal"},
+		},
+	}
+	for _, tc := range []struct {
+		name   string
+		update func(ingress *extensions.Ingress)
+	}{
+		{
+			name:   "resync",
+			update: func(ingress *extensions.Ingress) {},
+		},
+		{
+			name: "conditions recorded by the controller",
+			update: func(ingress *extensions.Ingress) {
+				ingress.ResourceVersion = "2"
+				ingress.Annotations["ingress.k8s.aws/conditions"] = `[{"type":"Ready","status":"True"}]`
+			},
+		},
+		{
+			name: "status",
+			update: func(ingress *extensions.Ingress) {
+				ingress.ResourceVersion = "2"
+				ingress.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{Hostname: "my-lb.us-west-2.elb.amazonaws.com"}}
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+			defer queue.ShutDown()
+			updated := ingress.DeepCopy()
+			tc.update(updated)
+			h := &EnqueueRequestsForIngressEvent{}
+			h.Update(event.UpdateEvent{ObjectOld: ingress, ObjectNew: updated}, queue)
+			assert.Equal(t, 0, queue.Len())
+		})
+	}
+}
+
+func TestEnqueueRequestsForIngressEvent_UpdateGroupMember(t *testing.T) {
+	oldIngress := &extensions.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "ingress"},
+	}
+	newIngress := &extensions.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "default",
+			Name:        "ingress",
+			Annotations: map[string]string{"alb.ingress.kubernetes.io/group.name": "my-group"},
+		},
+	}
+	queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	defer queue.ShutDown()
+	h := &EnqueueRequestsForIngressEvent{}
+	h.Update(event.UpdateEvent{ObjectOld: oldIngress, ObjectNew: newIngress}, queue)
+
+	assert.Equal(t, 2, queue.Len())
+	first, _ := queue.Get()
+	second, _ := queue.Get()
+	assert.Equal(t, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "ingress"}}, first)
+	assert.Equal(t, reconcile.Request{NamespacedName: group.Key("my-group")}, second)
+}
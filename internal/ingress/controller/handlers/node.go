@@ -5,13 +5,12 @@ import (
 
 	"github.com/golang/glog"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/class"
+	corev1 "k8s.io/api/core/v1"
 	extensions "k8s.io/api/extensions/v1beta1"
-	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
-	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 var _ handler.EventHandler = (*EnqueueRequestsForNodeEvent)(nil)
@@ -32,15 +31,14 @@ func (h *EnqueueRequestsForNodeEvent) Delete(e event.DeleteEvent, queue workqueu
 	h.enqueueImpactedIngresses(queue)
 }
 
-// TODO: change this to only enqueue ingresses when available node set is changed.(rely on node's ready condition)
-// We can store an copy of previous known valid nodeSet inside this class, and compare them when events occurs.
-// Pending work:
-//    1. rely on node's ready condition instead of aws.IsNodeHealth API
-//    1. when modify/detach instance sg, rely on describeNetworkInterface API to get enis attached, to avoid edge cases like node turned into unhealthy or excluded by "alpha.service-controller.kubernetes.io/exclude-balancer"
-
 // Update is called in response to an update event -  e.g. Pod Updated.
+// Ingresses are only enqueued when the node joins or leaves the set of nodes eligible as instance targets.
 func (h *EnqueueRequestsForNodeEvent) Update(e event.UpdateEvent, queue workqueue.RateLimitingInterface) {
-	//h.enqueueImpactedIngresses(queue)
+	nodeOld := e.ObjectOld.(*corev1.Node)
+	nodeNew := e.ObjectNew.(*corev1.Node)
+	if class.IsValidNode(nodeOld) != class.IsValidNode(nodeNew) || isNodeReady(nodeOld) != isNodeReady(nodeNew) {
+		h.enqueueImpactedIngresses(queue)
+	}
 }
 
 // Generic is called in response to an event of an unknown type or a synthetic event triggered as a cron or
@@ -48,6 +46,16 @@ func (h *EnqueueRequestsForNodeEvent) Update(e event.UpdateEvent, queue workqueu
 func (h *EnqueueRequestsForNodeEvent) Generic(event.GenericEvent, workqueue.RateLimitingInterface) {
 }
 
+// isNodeReady returns whether the Ready condition of node is true.
+func isNodeReady(node *corev1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
 // Ideally this should only enqueue ingresses that have changed
 func (h *EnqueueRequestsForNodeEvent) enqueueImpactedIngresses(queue workqueue.RateLimitingInterface) {
 	ingressList := &extensions.IngressList{}
@@ -60,11 +68,6 @@ func (h *EnqueueRequestsForNodeEvent) enqueueImpactedIngresses(queue workqueue.R
 		if !class.IsValidIngress(h.IngressClass, &ingress) {
 			continue
 		}
-		queue.Add(reconcile.Request{
-			NamespacedName: types.NamespacedName{
-				Namespace: ingress.Namespace,
-				Name:      ingress.Name,
-			},
-		})
+		queue.Add(requestForIngress(&ingress))
 	}
 }
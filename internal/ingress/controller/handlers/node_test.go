@@ -1 +1,105 @@
 package handlers
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/group"
+	mock_cache "github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks/controller-runtime/cache"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func newNode(ready corev1.ConditionStatus, labels map[string]string) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node", Labels: labels},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: ready}},
+		},
+	}
+}
+
+func TestEnqueueRequestsForNodeEvent_Update(t *testing.T) {
+	for _, tc := range []struct {
+		name            string
+		nodeOld         *corev1.Node
+		nodeNew         *corev1.Node
+		expectedEnqueue bool
+	}{
+		{
+			name:            "unrelated change",
+			nodeOld:         newNode(corev1.ConditionTrue, nil),
+			nodeNew:         newNode(corev1.ConditionTrue, map[string]string{"app": "web"}),
+			expectedEnqueue: false,
+		},
+		{
+			name:            "node becomes not ready",
+			nodeOld:         newNode(corev1.ConditionTrue, nil),
+			nodeNew:         newNode(corev1.ConditionFalse, nil),
+			expectedEnqueue: true,
+		},
+		{
+			name:            "node excluded from LoadBalancers",
+			nodeOld:         newNode(corev1.ConditionTrue, nil),
+			nodeNew:         newNode(corev1.ConditionTrue, map[string]string{"alpha.service-controller.kubernetes.io/exclude-balancer": "true"}),
+			expectedEnqueue: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockCache := mock_cache.NewMockCache(ctrl)
+			if tc.expectedEnqueue {
+				mockCache.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, extensions.IngressList{
+					Items: []extensions.Ingress{
+						{ObjectMeta: metav1.ObjectMeta{Namespace: "namespace", Name: "ingress"}},
+					},
+				})
+			}
+
+			queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+			defer queue.ShutDown()
+			h := &EnqueueRequestsForNodeEvent{Cache: mockCache}
+			h.Update(event.UpdateEvent{ObjectOld: tc.nodeOld, ObjectNew: tc.nodeNew}, queue)
+			if tc.expectedEnqueue {
+				assert.Equal(t, 1, queue.Len())
+			} else {
+				assert.Equal(t, 0, queue.Len())
+			}
+		})
+	}
+}
+
+func TestEnqueueRequestsForNodeEvent_groupMembers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	groupAnnotations := map[string]string{"alb.ingress.kubernetes.io/group.name": "my-group"}
+	mockCache := mock_cache.NewMockCache(ctrl)
+	mockCache.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, extensions.IngressList{
+		Items: []extensions.Ingress{
+			{ObjectMeta: metav1.ObjectMeta{Namespace: "namespace", Name: "ingress"}},
+			{ObjectMeta: metav1.ObjectMeta{Namespace: "namespace", Name: "member-1", Annotations: groupAnnotations}},
+			{ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "member-2", Annotations: groupAnnotations}},
+		},
+	})
+
+	queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	defer queue.ShutDown()
+	h := &EnqueueRequestsForNodeEvent{Cache: mockCache}
+	h.Create(event.CreateEvent{Object: newNode(corev1.ConditionTrue, nil)}, queue)
+
+	// members of a group are a single request, so that workers never reconcile the group concurrently.
+	assert.Equal(t, 2, queue.Len())
+	first, _ := queue.Get()
+	second, _ := queue.Get()
+	assert.Equal(t, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "namespace", Name: "ingress"}}, first)
+	assert.Equal(t, reconcile.Request{NamespacedName: group.Key("my-group")}, second)
+}
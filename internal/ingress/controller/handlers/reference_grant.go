@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/golang/glog"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/class"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/backend"
+	corev1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+)
+
+var _ handler.EventHandler = (*EnqueueRequestsForReferenceGrantEvent)(nil)
+
+// EnqueueRequestsForReferenceGrantEvent enqueues ingresses referencing services in the namespace of changed reference grants.
+type EnqueueRequestsForReferenceGrantEvent struct {
+	IngressClass string
+
+	Cache cache.Cache
+}
+
+// Create is called in response to an create event - e.g. Pod Creation.
+func (h *EnqueueRequestsForReferenceGrantEvent) Create(e event.CreateEvent, queue workqueue.RateLimitingInterface) {
+	h.enqueueImpactedIngresses(e.Object.(*corev1.ConfigMap), queue)
+}
+
+// Update is called in response to an update event -  e.g. Pod Updated.
+func (h *EnqueueRequestsForReferenceGrantEvent) Update(e event.UpdateEvent, queue workqueue.RateLimitingInterface) {
+	h.enqueueImpactedIngresses(e.ObjectNew.(*corev1.ConfigMap), queue)
+}
+
+// Delete is called in response to a delete event - e.g. Pod Deleted.
+func (h *EnqueueRequestsForReferenceGrantEvent) Delete(e event.DeleteEvent, queue workqueue.RateLimitingInterface) {
+	h.enqueueImpactedIngresses(e.Object.(*corev1.ConfigMap), queue)
+}
+
+// Generic is called in response to an event of an unknown type or a synthetic event triggered as a cron or
+// external trigger request - e.g. reconcile Autoscaling, or a Webhook.
+func (h *EnqueueRequestsForReferenceGrantEvent) Generic(event.GenericEvent, workqueue.RateLimitingInterface) {
+}
+
+func (h *EnqueueRequestsForReferenceGrantEvent) enqueueImpactedIngresses(configMap *corev1.ConfigMap, queue workqueue.RateLimitingInterface) {
+	if configMap.Name != backend.ReferenceGrantConfigMap {
+		return
+	}
+	ingressList := &extensions.IngressList{}
+	if err := h.Cache.List(context.Background(), nil, ingressList); err != nil {
+		glog.Errorf("failed to fetch impacted ingresses by reference grants due to %v", err)
+		return
+	}
+
+	for i := range ingressList.Items {
+		ingress := &ingressList.Items[i]
+		if ingress.Namespace == configMap.Namespace || !class.IsValidIngress(h.IngressClass, ingress) {
+			continue
+		}
+		for _, serviceKey := range backend.ServiceKeys(ingress) {
+			if serviceKey.Namespace == configMap.Namespace {
+				queue.Add(requestForIngress(ingress))
+				break
+			}
+		}
+	}
+}
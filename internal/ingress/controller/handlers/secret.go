@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/golang/glog"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/class"
+	corev1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+)
+
+var _ handler.EventHandler = (*EnqueueRequestsForSecretEvent)(nil)
+
+// EnqueueRequestsForSecretEvent enqueues ingresses whose TLS entries reference changed secrets,
+// so that their listeners pick up the certificates referenced by, or imported from, the secrets.
+type EnqueueRequestsForSecretEvent struct {
+	IngressClass string
+
+	Cache cache.Cache
+}
+
+// Create is called in response to an create event - e.g. Pod Creation.
+func (h *EnqueueRequestsForSecretEvent) Create(e event.CreateEvent, queue workqueue.RateLimitingInterface) {
+	h.enqueueImpactedIngresses(e.Object.(*corev1.Secret), queue)
+}
+
+// Update is called in response to an update event -  e.g. Pod Updated.
+// Ingresses are only enqueued when the content of the secret changes, not upon resyncs of the cache.
+func (h *EnqueueRequestsForSecretEvent) Update(e event.UpdateEvent, queue workqueue.RateLimitingInterface) {
+	secretOld := e.ObjectOld.(*corev1.Secret)
+	secretNew := e.ObjectNew.(*corev1.Secret)
+	if reflect.DeepEqual(secretOld.Data, secretNew.Data) {
+		return
+	}
+	h.enqueueImpactedIngresses(secretNew, queue)
+}
+
+// Delete is called in response to a delete event - e.g. Pod Deleted.
+func (h *EnqueueRequestsForSecretEvent) Delete(e event.DeleteEvent, queue workqueue.RateLimitingInterface) {
+	h.enqueueImpactedIngresses(e.Object.(*corev1.Secret), queue)
+}
+
+// Generic is called in response to an event of an unknown type or a synthetic event triggered as a cron or
+// external trigger request - e.g. reconcile Autoscaling, or a Webhook.
+func (h *EnqueueRequestsForSecretEvent) Generic(event.GenericEvent, workqueue.RateLimitingInterface) {
+}
+
+// enqueueImpactedIngresses enqueues ingresses with TLS entries referencing the secret.
+func (h *EnqueueRequestsForSecretEvent) enqueueImpactedIngresses(secret *corev1.Secret, queue workqueue.RateLimitingInterface) {
+	secretKey := types.NamespacedName{Namespace: secret.Namespace, Name: secret.Name}
+	ingressList := &extensions.IngressList{}
+	if err := h.Cache.List(context.Background(), client.MatchingField(FieldTLSSecretName, secretKey.String()), ingressList); err != nil {
+		glog.Errorf("failed to fetch impacted ingresses by secret due to %v", err)
+		return
+	}
+	for i := range ingressList.Items {
+		ingress := &ingressList.Items[i]
+		if !class.IsValidIngress(h.IngressClass, ingress) {
+			continue
+		}
+		queue.Add(requestForIngress(ingress))
+	}
+}
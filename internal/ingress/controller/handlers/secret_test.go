@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	mock_cache "github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks/controller-runtime/cache"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+func newSecret(data map[string][]byte) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "namespace", Name: "tls-secret"},
+		Data:       data,
+	}
+}
+
+func TestEnqueueRequestsForSecretEvent_Update(t *testing.T) {
+	for _, tc := range []struct {
+		name            string
+		secretOld       *corev1.Secret
+		secretNew       *corev1.Secret
+		expectedEnqueue bool
+	}{
+		{
+			name:            "resync",
+			secretOld:       newSecret(map[string][]byte{"tls.crt": []byte("cert"), "tls.key": []byte("key")}),
+			secretNew:       newSecret(map[string][]byte{"tls.crt": []byte("cert"), "tls.key": []byte("key")}),
+			expectedEnqueue: false,
+		},
+		{
+			name:            "secret rotated",
+			secretOld:       newSecret(map[string][]byte{"tls.crt": []byte("cert"), "tls.key": []byte("key")}),
+			secretNew:       newSecret(map[string][]byte{"tls.crt": []byte("cert-2"), "tls.key": []byte("key-2")}),
+			expectedEnqueue: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockCache := mock_cache.NewMockCache(ctrl)
+			if tc.expectedEnqueue {
+				mockCache.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).SetArg(2, extensions.IngressList{
+					Items: []extensions.Ingress{
+						{
+							ObjectMeta: metav1.ObjectMeta{Namespace: "namespace", Name: "ingress"},
+							Spec: extensions.IngressSpec{
+								TLS: []extensions.IngressTLS{{SecretName: "tls-secret"}},
+							},
+						},
+					},
+				})
+			}
+
+			queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+			defer queue.ShutDown()
+			h := &EnqueueRequestsForSecretEvent{Cache: mockCache}
+			h.Update(event.UpdateEvent{ObjectOld: tc.secretOld, ObjectNew: tc.secretNew}, queue)
+			if tc.expectedEnqueue {
+				assert.Equal(t, 1, queue.Len())
+			} else {
+				assert.Equal(t, 0, queue.Len())
+			}
+		})
+	}
+}
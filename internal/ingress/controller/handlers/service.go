@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"reflect"
 
 	"github.com/golang/glog"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/class"
@@ -13,7 +14,6 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
-	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 var _ handler.EventHandler = (*EnqueueRequestsForServiceEvent)(nil)
@@ -30,9 +30,15 @@ func (h *EnqueueRequestsForServiceEvent) Create(e event.CreateEvent, queue workq
 }
 
 // Update is called in response to an update event -  e.g. Pod Updated.
+// Ingresses are only enqueued when the spec or annotations of the service change, not upon resyncs of the cache or status updates.
 func (h *EnqueueRequestsForServiceEvent) Update(e event.UpdateEvent, queue workqueue.RateLimitingInterface) {
-	h.enqueueImpactedIngresses(e.ObjectOld.(*corev1.Service), queue)
-	h.enqueueImpactedIngresses(e.ObjectNew.(*corev1.Service), queue)
+	serviceOld := e.ObjectOld.(*corev1.Service)
+	serviceNew := e.ObjectNew.(*corev1.Service)
+	if reflect.DeepEqual(serviceOld.Spec, serviceNew.Spec) && reflect.DeepEqual(serviceOld.Annotations, serviceNew.Annotations) {
+		return
+	}
+	h.enqueueImpactedIngresses(serviceOld, queue)
+	h.enqueueImpactedIngresses(serviceNew, queue)
 }
 
 // Delete is called in response to a delete event - e.g. Pod Deleted.
@@ -46,10 +52,11 @@ func (h *EnqueueRequestsForServiceEvent) Generic(e event.GenericEvent, queue wor
 	h.enqueueImpactedIngresses(e.Object.(*corev1.Service), queue)
 }
 
-//TODO: this can be further optimized to only included ingresses referenced this service :D
+// enqueueImpactedIngresses enqueues ingresses with backends referencing the service.
 func (h *EnqueueRequestsForServiceEvent) enqueueImpactedIngresses(service *corev1.Service, queue workqueue.RateLimitingInterface) {
+	serviceKey := types.NamespacedName{Namespace: service.Namespace, Name: service.Name}
 	ingressList := &extensions.IngressList{}
-	if err := h.Cache.List(context.Background(), client.InNamespace(service.Namespace), ingressList); err != nil {
+	if err := h.Cache.List(context.Background(), client.MatchingField(FieldServiceName, serviceKey.String()), ingressList); err != nil {
 		glog.Errorf("failed to fetch impacted ingresses by service due to %v", err)
 		return
 	}
@@ -57,11 +64,6 @@ func (h *EnqueueRequestsForServiceEvent) enqueueImpactedIngresses(service *corev
 		if !class.IsValidIngress(h.IngressClass, &ingress) {
 			continue
 		}
-		queue.Add(reconcile.Request{
-			NamespacedName: types.NamespacedName{
-				Namespace: ingress.Namespace,
-				Name:      ingress.Name,
-			},
-		})
+		queue.Add(requestForIngress(&ingress))
 	}
 }
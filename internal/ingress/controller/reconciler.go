@@ -2,22 +2,56 @@ package controller
 
 import (
 	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
 
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/bluegreen"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/dns"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/failover"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/health"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/lb"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/staticip"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/verify"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/class"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/loadbalancer"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/backend"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/circuitbreaker"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/election"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/group"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/inventory"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/metric"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/shard"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/k8s"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/tracing"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/utils"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/log"
+	"go.opentelemetry.io/otel/attribute"
 	corev1 "k8s.io/api/core/v1"
 	extensions "k8s.io/api/extensions/v1beta1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
+// AnnotationHostedZoneID records the canonical hosted zone IDs of the LoadBalancers of an ingress in the order of its status,
+// so that alias records can be created for the published DNS names.
+const AnnotationHostedZoneID = "ingress.k8s.aws/hosted-zone-id"
+
+// blueGreenRequeueInterval is the interval to check progress of a blue/green swap.
+const blueGreenRequeueInterval = 30 * time.Second
+
+// throttledRequeueInterval is the minimum interval to retry a reconcile that failed because AWS throttled it, it's jittered by up to the same interval.
+const throttledRequeueInterval = 30 * time.Second
+
 // Reconciler reconciles an single ingress object
 type Reconciler struct {
 	client   client.Client
@@ -27,79 +61,410 @@ type Reconciler struct {
 	// TODO: move things out of store, and start to rely on functionality provided by client & cache
 	store store.Storer
 
-	lbController lb.Controller
+	lbController        lb.Controller
+	failoverController  failover.Controller
+	blueGreenController bluegreen.Controller
+	staticIPController  staticip.Controller
+	dnsController       dns.Controller
+	healthChecker       health.Checker
+	circuitBreaker      circuitbreaker.Breaker
+	verifier            verify.Verifier
+	inventory           inventory.Inventory
+	elector             election.Elector
+
+	// groupLocks serializes reconciles of each IngressGroup, since requests of its members may be processed by different workers.
+	groupLocks utils.KeyedMutex
 
 	metricCollector metric.Collector
 }
 
 // Reconcile will reconcile the aws resources with k8s state of ingress.
 func (r *Reconciler) Reconcile(request reconcile.Request) (reconcile.Result, error) {
-	ctx := context.Background()
+	if !r.elector.BeginReconcile() {
+		return reconcile.Result{RequeueAfter: election.StandbyRequeueInterval}, nil
+	}
+	defer r.elector.EndReconcile()
+	ctx, span := tracing.Start(context.Background(), "Reconcile", attribute.String("k8s.ingress", request.NamespacedName.String()))
+	result, err := r.reconcileRequest(ctx, request)
+	tracing.End(span, err)
+	return result, err
+}
+
+func (r *Reconciler) reconcileRequest(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	if group.IsKey(request.NamespacedName) {
+		return r.reconcileGroupRequest(ctx, request.NamespacedName)
+	}
 	ingress := &extensions.Ingress{}
 	if err := r.cache.Get(ctx, request.NamespacedName, ingress); err != nil {
 		if !errors.IsNotFound(err) {
 			r.metricCollector.IncReconcileErrorCount(request.NamespacedName.String())
 			return reconcile.Result{}, err
 		}
-
-		if err := r.deleteIngress(ctx, request.NamespacedName); err != nil {
-			r.metricCollector.IncReconcileErrorCount(request.NamespacedName.String())
-			return reconcile.Result{}, err
+		if shard.IsReserved(request.Name) {
+			// AWS resources named after the ingress are derived from another ingress, see rejectReservedName.
+			return reconcile.Result{}, nil
 		}
-
-		r.metricCollector.IncReconcileCount()
-		return reconcile.Result{}, nil
+		return r.reconcileDeletion(ctx, request.NamespacedName, nil)
+	}
+	if groupName := group.Name(ingress); groupName != "" {
+		return r.reconcileGroupRequest(ctx, group.Key(groupName))
+	}
+	if shard.IsReserved(ingress.Name) {
+		return reconcile.Result{}, r.rejectReservedName(ctx, request.NamespacedName, ingress)
+	}
+	// an ingress moved to another ingress class is no longer served by the controller, like a deleted one.
+	if ingress.DeletionTimestamp != nil || !class.IsValidIngress(r.store.GetConfig().IngressClass, ingress) {
+		return r.reconcileDeletion(ctx, request.NamespacedName, ingress)
 	}
 
-	if err := r.reconcileIngress(ctx, request.NamespacedName, ingress); err != nil {
+	start := time.Now()
+	r.inventory.BeginReconcile(request.NamespacedName)
+	result, err := r.reconcileIngress(ctx, request.NamespacedName, ingress)
+	r.metricCollector.ObserveReconcileDuration(request.NamespacedName.String(), time.Since(start))
+	r.inventory.RecordReconcile(request.NamespacedName, err)
+	if err != nil {
 		r.metricCollector.IncReconcileErrorCount(request.NamespacedName.String())
 		return reconcile.Result{}, err
 	}
 
+	r.metricCollector.IncReconcileCount()
+	return result, nil
+}
+
+// reconcileDeletion deletes the AWS resources of a deleted ingress, then removes the finalizer of ingress if it still exists.
+func (r *Reconciler) reconcileDeletion(ctx context.Context, ingressKey types.NamespacedName, ingress *extensions.Ingress) (reconcile.Result, error) {
+	if ingress != nil && r.paused(ingress) {
+		albctx.GetLogger(r.buildReconcileContext(ctx, ingressKey, ingress)).Infof("reconcile paused, AWS resources are deleted once resumed")
+		return reconcile.Result{}, nil
+	}
+	if err := r.deleteIngress(ctx, ingressKey); err != nil {
+		r.metricCollector.IncReconcileErrorCount(ingressKey.String())
+		return reconcile.Result{}, err
+	}
+	if ingress != nil {
+		if err := r.removeIngressFinalizer(ctx, ingress); err != nil {
+			r.metricCollector.IncReconcileErrorCount(ingressKey.String())
+			return reconcile.Result{}, err
+		}
+	}
+	r.inventory.Forget(ingressKey)
+	r.metricCollector.RemoveMetrics(ingressKey.String())
+
 	r.metricCollector.IncReconcileCount()
 	return reconcile.Result{}, nil
 }
 
-func (r *Reconciler) reconcileIngress(ctx context.Context, ingressKey types.NamespacedName, ingress *extensions.Ingress) error {
+// rejectReservedName refuses to serve ingress, whose name is reserved for the shards, secondary ALB or green stack of another ingress.
+// AWS resources named after ingress would be those of that other ingress, so they are neither reconciled nor deleted,
+// only the finalizer of ingress is removed so that its deletion isn't blocked.
+func (r *Reconciler) rejectReservedName(ctx context.Context, ingressKey types.NamespacedName, ingress *extensions.Ingress) error {
+	ctx = r.buildReconcileContext(ctx, ingressKey, ingress)
+	if ingress.DeletionTimestamp == nil {
+		albctx.GetLogger(ctx).Warnf("ingress name is reserved, ingress is ignored")
+		albctx.GetEventf(ctx)(corev1.EventTypeWarning, "ERROR", "ingress name %v is reserved, names ending in .shard-N, .failover or .green are ignored", ingress.Name)
+	}
+	return r.removeIngressFinalizer(ctx, ingress)
+}
+
+func (r *Reconciler) reconcileIngress(ctx context.Context, ingressKey types.NamespacedName, ingress *extensions.Ingress) (reconcile.Result, error) {
 	ctx = r.buildReconcileContext(ctx, ingressKey, ingress)
-	lbInfo, err := r.lbController.Reconcile(ctx, ingress)
+	if r.paused(ingress) {
+		plan := &albctx.Plan{}
+		result, err := r.reconcileLoadBalancers(albctx.SetPlan(ctx, plan), ingressKey, ingress, newStatusReport())
+		return r.reportDrift(ctx, plan, result, err)
+	}
+	if open, until := r.circuitBreaker.Check(ingressKey, circuitbreaker.Version(ingress)); open {
+		albctx.GetLogger(ctx).Infof("circuit open until %v, skipping reconcile", until.Format(time.RFC3339))
+		return reconcile.Result{RequeueAfter: time.Until(until)}, nil
+	}
+
+	if r.dryRun(ingress) {
+		plan := &albctx.Plan{}
+		result, err := r.reconcileLoadBalancers(albctx.SetPlan(ctx, plan), ingressKey, ingress, newStatusReport())
+		return r.reportPlan(ctx, plan, result, err)
+	}
+
+	if err := r.addIngressFinalizer(ctx, ingress); err != nil {
+		return reconcile.Result{}, err
+	}
+	failures := &albctx.AWSFailures{}
+	report := newStatusReport()
+	result, err := r.reconcileLoadBalancers(albctx.SetCertificates(albctx.SetAWSFailures(ctx, failures), report.certificates), ingressKey, ingress, report)
+	if statusErr := r.reconcileStatusConditions(ctx, ingress, report, err); statusErr != nil {
+		albctx.GetLogger(ctx).Errorf("failed to update status conditions due to %v", statusErr)
+	}
 	if err != nil {
-		return err
+		if operation := failures.ThrottledCause(err); operation != "" {
+			return requeueThrottled(ctx, operation, err), nil
+		}
+		if operation := failures.FailedOperation(err); operation != "" && r.circuitBreaker.RecordFailure(ingressKey, circuitbreaker.Version(ingress), operation) {
+			r.openCircuit(ctx, ingress, operation, err)
+		}
+		return reconcile.Result{}, err
 	}
-	if err := r.updateIngressStatus(ctx, ingress, lbInfo); err != nil {
-		return err
+	r.circuitBreaker.RecordSuccess(ingressKey)
+	if err := r.closeCircuit(ctx, ingress); err != nil {
+		return reconcile.Result{}, err
+	}
+	if len(inactiveLoadBalancers(report.lbInfos)) != 0 && (result.RequeueAfter == 0 || result.RequeueAfter > provisioningRequeueInterval) {
+		result.RequeueAfter = provisioningRequeueInterval
+	}
+	return result, nil
+}
+
+// requeueThrottled returns the result of a reconcile that failed because AWS throttled operation even after retries.
+// Throttling is transient and shared by every ingress in the account, so it doesn't fail the reconcile, which would count as an ingress error
+// and retry it with the rate limiting of the queue instead of a jittered interval.
+func requeueThrottled(ctx context.Context, operation string, err error) reconcile.Result {
+	requeueAfter := wait.Jitter(throttledRequeueInterval, 1)
+	albctx.GetLogger(ctx).Warnf("%v throttled by AWS, retrying reconcile in %v: %v", operation, requeueAfter.Round(time.Second), err)
+	return reconcile.Result{RequeueAfter: requeueAfter}
+}
+
+// reconcileLoadBalancers reconciles the LoadBalancers of ingress with its k8s state, the observed state is recorded into report.
+func (r *Reconciler) reconcileLoadBalancers(ctx context.Context, ingressKey types.NamespacedName, ingress *extensions.Ingress, report *statusReport) (reconcile.Result, error) {
+	routed, err := r.pruneUngrantedBackends(ctx, ingress)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if routed != ingress || backend.ReferencesOtherNamespaces(ingress) {
+		// actions of routed may have been pruned, and must be restored once the grant is back.
+		r.store.UpdateDerivedIngress(routed)
+	}
+	ingressAnnos, err := r.store.GetIngressAnnotations(k8s.MetaNamespaceKey(ingress))
+	if err != nil {
+		return reconcile.Result{}, err
 	}
+	r.inventory.RecordDesired(ingressKey, ingressAnnos)
 
-	return nil
+	result := reconcile.Result{}
+	var lbInfos []*lb.LoadBalancer
+	// lbIngresses are the ingresses served by each of lbInfos.
+	var lbIngresses []*extensions.Ingress
+	blueGreen := ingressAnnos.LoadBalancer.ActiveStack != nil
+	if !blueGreen {
+		// removing the annotation switches back to the blue stack, so that a green stack still serving traffic is retired rather than leaked.
+		if blueGreen, err = r.blueGreenController.HasGreenStack(ctx, ingressKey); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+	if blueGreen {
+		lbInfo, swapped, err := r.blueGreenController.Reconcile(ctx, routed)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if !swapped {
+			result.RequeueAfter = blueGreenRequeueInterval
+		}
+		lbInfos = append(lbInfos, lbInfo)
+		lbIngresses = append(lbIngresses, routed)
+	} else {
+		shards := r.shardIngress(ctx, routed, ingressAnnos.LoadBalancer)
+		for _, shardIngress := range shards {
+			lbInfo, err := r.lbController.Reconcile(ctx, shardIngress)
+			if err != nil {
+				return reconcile.Result{}, err
+			}
+			lbInfos = append(lbInfos, lbInfo)
+			lbIngresses = append(lbIngresses, shardIngress)
+		}
+		if len(ingress.Status.LoadBalancer.Ingress) > len(shards) {
+			albctx.GetLogger(ctx).Infof("deleting LoadBalancers of shards no longer needed, starting from shard %d", len(shards))
+			if err := r.lbController.Delete(ctx, shard.Key(ingressKey, len(shards))); err != nil {
+				return reconcile.Result{}, err
+			}
+		}
+	}
+	if err := r.failoverController.Reconcile(ctx, routed, lbInfos[0]); err != nil {
+		return reconcile.Result{}, err
+	}
+	if err := r.checkHealth(ctx, report, ingressAnnos.LoadBalancer.DegradedThreshold, lbInfos); err != nil {
+		return reconcile.Result{}, err
+	}
+	if err := r.reconcileDegradedCondition(ctx, ingress, ingressAnnos.LoadBalancer.DegradedThreshold, report); err != nil {
+		return reconcile.Result{}, err
+	}
+	if ingressAnnos.LoadBalancer.DegradedThreshold != nil && (result.RequeueAfter == 0 || result.RequeueAfter > healthRequeueInterval) {
+		result.RequeueAfter = healthRequeueInterval
+	}
+	if lbInfos[0], err = r.staticIPController.Reconcile(ctx, ingress, lbInfos[0]); err != nil {
+		return reconcile.Result{}, err
+	}
+	report.lbInfos = lbInfos
+	r.inventory.RecordCurrent(ingressKey, lbInfos)
+	if err := r.updateIngressStatus(ctx, ingress, lbInfos); err != nil {
+		return reconcile.Result{}, err
+	}
+	r.metricCollector.SetManagedResources(ingressKey.String(), managedResourceCounts(lbInfos))
+	if r.dnsController != nil {
+		if err := r.dnsController.Reconcile(ctx, ingress, lbIngresses, lbInfos); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+	ready, err := r.reconcileReadyCondition(ctx, ingress, ingressAnnos.LoadBalancer, lbIngresses, lbInfos)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if !ready && (result.RequeueAfter == 0 || result.RequeueAfter > verificationRequeueInterval) {
+		result.RequeueAfter = verificationRequeueInterval
+	}
+
+	return result, nil
+}
+
+// dryRun returns whether AWS resources of ingress must be left untouched, by the --dry-run flag or its dry-run annotation.
+func (r *Reconciler) dryRun(ingress *extensions.Ingress) bool {
+	if r.store.GetConfig().DryRun {
+		return true
+	}
+	ingressAnnos, err := r.store.GetIngressAnnotations(k8s.MetaNamespaceKey(ingress))
+	return err == nil && ingressAnnos.LoadBalancer.DryRun
+}
+
+// reportPlan emits the AWS operations planned by a dry-run reconcile. Planning stops at the first resource that would be created,
+// since the operations after it depend on the resource, which isn't a failure of the reconcile.
+func (r *Reconciler) reportPlan(ctx context.Context, plan *albctx.Plan, result reconcile.Result, reconcileErr error) (reconcile.Result, error) {
+	if reconcileErr != nil && !plan.Halted() {
+		return reconcile.Result{}, reconcileErr
+	}
+	message := describePlan(plan)
+	if message == "" {
+		albctx.GetLogger(ctx).Infof("dry-run: no changes planned")
+		return result, nil
+	}
+	albctx.GetLogger(ctx).Infof("dry-run: %v", message)
+	albctx.GetEventf(ctx)(corev1.EventTypeNormal, "DRY_RUN", "%v", message)
+	return result, nil
+}
+
+// paused returns whether AWS mutations of ingress are paused by its reconcile annotation.
+func (r *Reconciler) paused(ingress *extensions.Ingress) bool {
+	ingressAnnos, err := r.store.GetIngressAnnotations(k8s.MetaNamespaceKey(ingress))
+	return err == nil && ingressAnnos.LoadBalancer.Paused
+}
+
+// reportDrift emits the AWS operations a paused reconcile would have performed, i.e. how AWS resources drifted from the ingress.
+// Drift is a warning since nothing converges it until the ingress is resumed.
+func (r *Reconciler) reportDrift(ctx context.Context, plan *albctx.Plan, result reconcile.Result, reconcileErr error) (reconcile.Result, error) {
+	if reconcileErr != nil && !plan.Halted() {
+		return reconcile.Result{}, reconcileErr
+	}
+	message := describePlan(plan)
+	if message == "" {
+		albctx.GetLogger(ctx).Infof("reconcile paused: no drift")
+		return result, nil
+	}
+	albctx.GetLogger(ctx).Infof("reconcile paused: %v", message)
+	albctx.GetEventf(ctx)(corev1.EventTypeWarning, "DRIFT", "reconcile paused, %v", message)
+	return result, nil
+}
+
+// describePlan lists the operations of plan, or returns an empty string if nothing is planned.
+func describePlan(plan *albctx.Plan) string {
+	operations := plan.Operations()
+	if len(operations) == 0 {
+		return ""
+	}
+	message := fmt.Sprintf("%d AWS operations planned: %v", len(operations), strings.Join(operations, ", "))
+	if plan.Halted() {
+		message += ", further operations depend on the resources to create"
+	}
+	return message
+}
+
+// pruneUngrantedBackends returns ingress without the routes to services in other namespaces that aren't granted to it, see backend.PruneUngrantedBackends.
+// The returned ingress is only meant to build the LoadBalancer, updates to the ingress object must use ingress itself.
+func (r *Reconciler) pruneUngrantedBackends(ctx context.Context, ingress *extensions.Ingress) (*extensions.Ingress, error) {
+	routed, notGrantedErrs, err := backend.PruneUngrantedBackends(ctx, r.cache, ingress)
+	if err != nil {
+		albctx.GetEventf(ctx)(corev1.EventTypeWarning, "ERROR", "%v", err)
+		return nil, err
+	}
+	for _, err := range notGrantedErrs {
+		albctx.GetEventf(ctx)(corev1.EventTypeWarning, "ERROR", "%v, routes to it are removed", err)
+	}
+	return routed, nil
+}
+
+// shardIngress splits the ingress into shards that are reconciled as separate LoadBalancers, if sharding is enabled for ingress.
+func (r *Reconciler) shardIngress(ctx context.Context, ingress *extensions.Ingress, lbAnnos *loadbalancer.Config) []*extensions.Ingress {
+	if lbAnnos.ShardMaxRules == nil {
+		return []*extensions.Ingress{ingress}
+	}
+
+	shards := shard.Split(ingress, shard.Limits{
+		MaxRules:        int(aws.Int64Value(lbAnnos.ShardMaxRules)),
+		MaxCertificates: int(aws.Int64Value(lbAnnos.ShardMaxCertificates)),
+	})
+	if len(shards) > 1 {
+		albctx.GetLogger(ctx).Infof("ingress split into %d shards", len(shards))
+	}
+	return shards
 }
 
 func (r *Reconciler) deleteIngress(ctx context.Context, ingressKey types.NamespacedName) error {
 	ctx = r.buildReconcileContext(ctx, ingressKey, nil)
+	if err := r.staticIPController.Delete(ctx, ingressKey); err != nil {
+		return err
+	}
+	if err := r.failoverController.Delete(ctx, ingressKey); err != nil {
+		return err
+	}
+	if r.dnsController != nil {
+		if err := r.dnsController.Delete(ctx, ingressKey); err != nil {
+			return err
+		}
+	}
+	if err := r.blueGreenController.Delete(ctx, ingressKey); err != nil {
+		return err
+	}
 	if err := r.lbController.Delete(ctx, ingressKey); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (r *Reconciler) updateIngressStatus(ctx context.Context, ingress *extensions.Ingress, lbInfo *lb.LoadBalancer) error {
-	if len(ingress.Status.LoadBalancer.Ingress) != 1 ||
-		ingress.Status.LoadBalancer.Ingress[0].IP != "" ||
-		ingress.Status.LoadBalancer.Ingress[0].Hostname != lbInfo.DNSName {
-		ingress.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{
-			{
-				Hostname: lbInfo.DNSName,
-			},
+// updateIngressStatus publishes the DNS names of LoadBalancers in shard order, along with their canonical hosted zone IDs.
+// Both are refreshed whenever LoadBalancers got recreated.
+func (r *Reconciler) updateIngressStatus(ctx context.Context, ingress *extensions.Ingress, lbInfos []*lb.LoadBalancer) error {
+	lbIngresses := make([]corev1.LoadBalancerIngress, 0, len(lbInfos))
+	hostedZoneIDs := make([]string, 0, len(lbInfos))
+	for _, lbInfo := range lbInfos {
+		lbIngresses = append(lbIngresses, corev1.LoadBalancerIngress{
+			Hostname: lbInfo.DNSName,
+		})
+		hostedZoneIDs = append(hostedZoneIDs, lbInfo.HostedZoneID)
+	}
+	if !reflect.DeepEqual(ingress.Status.LoadBalancer.Ingress, lbIngresses) {
+		ingress.Status.LoadBalancer.Ingress = lbIngresses
+		if err := r.client.Status().Update(ctx, ingress); err != nil {
+			return err
+		}
+	}
+	if hostedZoneID := strings.Join(hostedZoneIDs, ","); ingress.Annotations[AnnotationHostedZoneID] != hostedZoneID {
+		if ingress.Annotations == nil {
+			ingress.Annotations = make(map[string]string)
 		}
-		return r.client.Status().Update(ctx, ingress)
+		ingress.Annotations[AnnotationHostedZoneID] = hostedZoneID
+		return r.client.Update(ctx, ingress)
 	}
 	return nil
 }
 
+// managedResourceCounts counts the AWS resources of lbInfos by type, for the managed_resources metric.
+func managedResourceCounts(lbInfos []*lb.LoadBalancer) map[string]int {
+	counts := map[string]int{"loadbalancer": len(lbInfos)}
+	for _, lbInfo := range lbInfos {
+		counts["targetgroup"] += len(lbInfo.TargetGroupArns)
+	}
+	return counts
+}
+
 func (r *Reconciler) buildReconcileContext(ctx context.Context, ingressKey types.NamespacedName, ingress *extensions.Ingress) context.Context {
-	ctx = albctx.SetLogger(ctx, log.New(ingressKey.String()))
+	ctx = albctx.SetLogger(ctx, log.New(ingressKey.String()).WithSubsystem("reconcile").WithValues("ingress", ingressKey.String()))
 	if ingress != nil {
 		ctx = albctx.SetEventf(ctx, func(eventType string, reason string, messageFmt string, args ...interface{}) {
-			r.recorder.Eventf(ingress, eventType, reason, messageFmt, args...)
+			r.recorder.Eventf(ingress, eventType, reason, messageFmt, log.RedactArgs(args)...)
 		})
 	}
 	return ctx
@@ -0,0 +1,196 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/golang/mock/gomock"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/failover"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/generator"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/health"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/lb"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/loadbalancer"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/backend"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/inventory"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/metric"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
+	mock_cache "github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks/controller-runtime/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	corev1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func Test_describePlan(t *testing.T) {
+	plan := &albctx.Plan{}
+	assert.Equal(t, "", describePlan(plan))
+
+	plan.Record("ModifyListener")
+	plan.Record("CreateRule")
+	assert.Equal(t, "2 AWS operations planned: ModifyListener, CreateRule", describePlan(plan))
+
+	plan.Halt()
+	assert.Equal(t, "2 AWS operations planned: ModifyListener, CreateRule, further operations depend on the resources to create", describePlan(plan))
+}
+
+// recordingLBController records the ingresses LoadBalancers are reconciled for, keyed by ingress name.
+type recordingLBController struct {
+	reconciled map[string]*extensions.Ingress
+}
+
+func (c *recordingLBController) Reconcile(ctx context.Context, ingress *extensions.Ingress) (*lb.LoadBalancer, error) {
+	c.reconciled[ingress.Name] = ingress
+	return &lb.LoadBalancer{DNSName: ingress.Name + ".elb.amazonaws.com", HostedZoneID: "Z2", State: elbv2.LoadBalancerStateEnumActive}, nil
+}
+
+func (c *recordingLBController) Delete(ctx context.Context, ingressKey types.NamespacedName) error {
+	return nil
+}
+
+type blueStackOnly struct{}
+
+func (blueStackOnly) Reconcile(ctx context.Context, ingress *extensions.Ingress) (*lb.LoadBalancer, bool, error) {
+	panic("unexpected blue/green reconcile")
+}
+
+func (blueStackOnly) HasGreenStack(ctx context.Context, ingressKey types.NamespacedName) (bool, error) {
+	return false, nil
+}
+
+func (blueStackOnly) Delete(ctx context.Context, ingressKey types.NamespacedName) error {
+	return nil
+}
+
+type noStaticIP struct{}
+
+func (noStaticIP) Reconcile(ctx context.Context, ingress *extensions.Ingress, alb *lb.LoadBalancer) (*lb.LoadBalancer, error) {
+	return alb, nil
+}
+
+func (noStaticIP) Delete(ctx context.Context, ingressKey types.NamespacedName) error {
+	return nil
+}
+
+type healthyTargets struct{}
+
+func (healthyTargets) Check(ctx context.Context, lbInfos []*lb.LoadBalancer) (health.Result, error) {
+	return health.Result{}, nil
+}
+
+func TestReconciler_reconcileLoadBalancers_revokedGrant(t *testing.T) {
+	ingress := &extensions.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "team",
+			Name:      "web",
+			Annotations: map[string]string{
+				"alb.ingress.kubernetes.io/service-namespace.shared": "platform",
+			},
+		},
+		Spec: extensions.IngressSpec{
+			Rules: []extensions.IngressRule{
+				{
+					Host: "web.example.com",
+					IngressRuleValue: extensions.IngressRuleValue{
+						HTTP: &extensions.HTTPIngressRuleValue{
+							Paths: []extensions.HTTPIngressPath{
+								{Path: "/web", Backend: extensions.IngressBackend{ServiceName: "web", ServicePort: intstr.FromInt(80)}},
+								{Path: "/shared", Backend: extensions.IngressBackend{ServiceName: "shared", ServicePort: intstr.FromInt(80)}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockCache := mock_cache.NewMockCache(ctrl)
+	grantKey := types.NamespacedName{Namespace: "platform", Name: backend.ReferenceGrantConfigMap}
+	mockCache.EXPECT().Get(gomock.Any(), grantKey, gomock.Any()).SetArg(2, corev1.ConfigMap{Data: map[string]string{"team": "other"}})
+
+	cloud := &mocks.CloudAPI{}
+	cloud.On("GetLoadBalancerByName", mock.Anything, mock.Anything).Return(nil, nil)
+	cloud.On("GetResourceRecordSets", mock.Anything, "Z1", "web.example.com").Return(nil, nil)
+	cloud.On("ChangeResourceRecordSetsWithContext", mock.Anything, mock.Anything).Return(&route53.ChangeResourceRecordSetsOutput{}, nil)
+
+	dummyStore := store.NewDummy()
+	dummyStore.GetIngressAnnotationsResponse.LoadBalancer = &loadbalancer.Config{
+		Failover: &loadbalancer.FailoverConfig{HostedZoneID: "Z1", RecordName: "web.example.com"},
+	}
+	lbController := &recordingLBController{reconciled: make(map[string]*extensions.Ingress)}
+	r := &Reconciler{
+		client:              fake.NewFakeClient(ingress.DeepCopy()),
+		cache:               mockCache,
+		store:               dummyStore,
+		lbController:        lbController,
+		failoverController:  failover.NewController(cloud, dummyStore, &generator.NameGenerator{}, lbController),
+		blueGreenController: blueStackOnly{},
+		staticIPController:  noStaticIP{},
+		healthChecker:       healthyTargets{},
+		inventory:           inventory.NewInventory(cloud, "cluster"),
+		metricCollector:     metric.DummyCollector{},
+	}
+
+	ingressKey := types.NamespacedName{Namespace: "team", Name: "web"}
+	_, err := r.reconcileLoadBalancers(context.Background(), ingressKey, ingress, newStatusReport())
+	assert.NoError(t, err)
+
+	expectedPaths := []extensions.HTTPIngressPath{
+		{Path: "/web", Backend: extensions.IngressBackend{ServiceName: "web", ServicePort: intstr.FromInt(80)}},
+	}
+	for _, name := range []string{"web", "web.failover"} {
+		stackIngress, ok := lbController.reconciled[name]
+		if assert.True(t, ok, "LoadBalancer of %v not reconciled", name) {
+			assert.Equal(t, expectedPaths, stackIngress.Spec.Rules[0].HTTP.Paths, "routes of %v", name)
+		}
+	}
+	cloud.AssertExpectations(t)
+}
+
+func TestReconciler_reconcileRequest_reservedName(t *testing.T) {
+	ingressKey := types.NamespacedName{Namespace: "team", Name: "web.failover"}
+
+	t.Run("existing ingress is ignored and its finalizer removed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		ingress := &extensions.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "team", Name: "web.failover", Finalizers: []string{IngressFinalizer}}}
+		mockCache := mock_cache.NewMockCache(ctrl)
+		mockCache.EXPECT().Get(gomock.Any(), ingressKey, gomock.Any()).SetArg(2, *ingress)
+		recorder := record.NewFakeRecorder(1)
+		client := fake.NewFakeClient(ingress.DeepCopy())
+		r := &Reconciler{client: client, cache: mockCache, recorder: recorder}
+
+		result, err := r.reconcileRequest(context.Background(), reconcile.Request{NamespacedName: ingressKey})
+		assert.NoError(t, err)
+		assert.Equal(t, reconcile.Result{}, result)
+		assert.Contains(t, <-recorder.Events, "ingress name web.failover is reserved")
+		updated := &extensions.Ingress{}
+		assert.NoError(t, client.Get(context.Background(), ingressKey, updated))
+		assert.Empty(t, updated.Finalizers)
+	})
+
+	t.Run("deleted ingress leaves the AWS resources named after it alone", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		mockCache := mock_cache.NewMockCache(ctrl)
+		mockCache.EXPECT().Get(gomock.Any(), ingressKey, gomock.Any()).Return(apierrors.NewNotFound(schema.GroupResource{Resource: "ingresses"}, ingressKey.Name))
+		// deleting the secondary ALB of team/web would panic on the missing controllers.
+		r := &Reconciler{cache: mockCache}
+
+		result, err := r.reconcileRequest(context.Background(), reconcile.Request{NamespacedName: ingressKey})
+		assert.NoError(t, err)
+		assert.Equal(t, reconcile.Result{}, result)
+	})
+}
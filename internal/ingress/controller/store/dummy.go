@@ -18,6 +18,7 @@ type Dummy struct {
 	GetNodeInstanceIDFunc func(*corev1.Node) (string, error)
 
 	GetServiceEndpointsFunc func(string) (*corev1.Endpoints, error)
+	GetPodFunc              func(string) (*corev1.Pod, error)
 }
 
 // GetConfigMap ...
@@ -60,6 +61,14 @@ func (d Dummy) GetIngressAnnotations(key string) (*annotations.Ingress, error) {
 	return d.GetIngressAnnotationsResponse, nil
 }
 
+// UpdateDerivedIngress ...
+func (d Dummy) UpdateDerivedIngress(ing *extensions.Ingress) {
+}
+
+// DeleteDerivedIngress ...
+func (d Dummy) DeleteDerivedIngress(ing *extensions.Ingress) {
+}
+
 // Run ...
 func (d Dummy) Run(stopCh chan struct{}) {
 }
@@ -82,6 +91,11 @@ func (d *Dummy) GetNodeInstanceID(node *corev1.Node) (string, error) {
 	return d.GetNodeInstanceIDFunc(node)
 }
 
+// GetPod ...
+func (d *Dummy) GetPod(key string) (*corev1.Pod, error) {
+	return d.GetPodFunc(key)
+}
+
 // GetInstanceIDFromPodIP ...
 func (d *Dummy) GetInstanceIDFromPodIP(s string) (string, error) {
 	return "", nil
@@ -93,6 +107,7 @@ func NewDummy() *Dummy {
 		ListNodesFunc:                 func() []*corev1.Node { return nil },
 		GetNodeInstanceIDFunc:         func(*corev1.Node) (string, error) { return "", nil },
 		GetServiceEndpointsFunc:       func(string) (*corev1.Endpoints, error) { return nil, nil },
+		GetPodFunc:                    func(key string) (*corev1.Pod, error) { return nil, NotExistsError(key) },
 		GetIngressAnnotationsResponse: annotations.NewIngressDummy(),
 		GetServiceAnnotationsResponse: annotations.NewServiceDummy(),
 	}
@@ -0,0 +1,127 @@
+package store
+
+import (
+	"sort"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/k8s"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
+)
+
+// indexEndpointSliceByServiceKey indexes EndpointSlices by the key of their Service.
+const indexEndpointSliceByServiceKey = "serviceKey"
+
+// EndpointSliceLister makes an Indexer that lists EndpointSlices by their Service.
+type EndpointSliceLister struct {
+	cache.Indexer
+}
+
+// ByServiceKey returns the Endpoints of the Service matching key, merged from its EndpointSlices in the local EndpointSlice Store.
+func (s *EndpointSliceLister) ByServiceKey(key string) (*apiv1.Endpoints, error) {
+	objs, err := s.ByIndex(indexEndpointSliceByServiceKey, key)
+	if err != nil {
+		return nil, err
+	}
+	if len(objs) == 0 {
+		return nil, NotExistsError(key)
+	}
+	namespace, name, err := k8s.ParseNameNS(key)
+	if err != nil {
+		return nil, err
+	}
+	slices := make([]*unstructured.Unstructured, 0, len(objs))
+	for _, obj := range objs {
+		slices = append(slices, obj.(*unstructured.Unstructured))
+	}
+	sort.Slice(slices, func(i, j int) bool { return slices[i].GetName() < slices[j].GetName() })
+	return endpointsFromSlices(namespace, name, slices)
+}
+
+// serviceKeyIndexers index EndpointSlices by the key of their Service.
+var serviceKeyIndexers = cache.Indexers{
+	indexEndpointSliceByServiceKey: func(obj interface{}) ([]string, error) {
+		slice, ok := obj.(metav1.Object)
+		if !ok {
+			return nil, nil
+		}
+		serviceKey, ok := k8s.EndpointSliceServiceKey(slice)
+		if !ok {
+			return nil, nil
+		}
+		return []string{serviceKey.String()}, nil
+	},
+}
+
+// endpointSlice is the subset of discovery.k8s.io/v1beta1 EndpointSlices targets are resolved from.
+type endpointSlice struct {
+	AddressType string                  `json:"addressType"`
+	Endpoints   []endpointSliceEndpoint `json:"endpoints"`
+	Ports       []endpointSlicePort     `json:"ports"`
+}
+
+type endpointSliceEndpoint struct {
+	Addresses  []string `json:"addresses"`
+	Conditions struct {
+		Ready *bool `json:"ready,omitempty"`
+	} `json:"conditions"`
+	Hostname  *string                `json:"hostname,omitempty"`
+	TargetRef *apiv1.ObjectReference `json:"targetRef,omitempty"`
+	Topology  map[string]string      `json:"topology,omitempty"`
+}
+
+type endpointSlicePort struct {
+	Name     *string         `json:"name,omitempty"`
+	Protocol *apiv1.Protocol `json:"protocol,omitempty"`
+	Port     *int32          `json:"port,omitempty"`
+}
+
+// endpointsFromSlices merges the EndpointSlices of the namespace/name Service into Endpoints, with a subset per EndpointSlice.
+// Endpoints whose readiness is unknown are ready, and only IP addresses are resolved.
+func endpointsFromSlices(namespace string, name string, slices []*unstructured.Unstructured) (*apiv1.Endpoints, error) {
+	eps := &apiv1.Endpoints{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	for _, obj := range slices {
+		var slice endpointSlice
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), &slice); err != nil {
+			return nil, err
+		}
+		if slice.AddressType != "IPv4" && slice.AddressType != "IP" {
+			continue
+		}
+		var subset apiv1.EndpointSubset
+		for _, port := range slice.Ports {
+			epPort := apiv1.EndpointPort{}
+			if port.Name != nil {
+				epPort.Name = *port.Name
+			}
+			if port.Port != nil {
+				epPort.Port = *port.Port
+			}
+			if port.Protocol != nil {
+				epPort.Protocol = *port.Protocol
+			}
+			subset.Ports = append(subset.Ports, epPort)
+		}
+		for _, ep := range slice.Endpoints {
+			if len(ep.Addresses) == 0 {
+				continue
+			}
+			epAddr := apiv1.EndpointAddress{IP: ep.Addresses[0], TargetRef: ep.TargetRef}
+			if ep.Hostname != nil {
+				epAddr.Hostname = *ep.Hostname
+			}
+			if nodeName, ok := ep.Topology["kubernetes.io/hostname"]; ok {
+				epAddr.NodeName = &nodeName
+			}
+			if ep.Conditions.Ready == nil || *ep.Conditions.Ready {
+				subset.Addresses = append(subset.Addresses, epAddr)
+			} else {
+				subset.NotReadyAddresses = append(subset.NotReadyAddresses, epAddr)
+			}
+		}
+		eps.Subsets = append(eps.Subsets, subset)
+	}
+	return eps, nil
+}
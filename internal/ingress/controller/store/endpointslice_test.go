@@ -0,0 +1,145 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/k8s"
+	"github.com/stretchr/testify/assert"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/cache"
+)
+
+func newEndpointSlice(name string, serviceName string, addressType string, endpoints []interface{}, ports []interface{}) *unstructured.Unstructured {
+	slice := k8s.NewEndpointSlice()
+	slice.SetNamespace("default")
+	slice.SetName(name)
+	if serviceName != "" {
+		slice.SetLabels(map[string]string{k8s.LabelServiceName: serviceName})
+	}
+	slice.Object["addressType"] = addressType
+	slice.Object["endpoints"] = endpoints
+	slice.Object["ports"] = ports
+	return slice
+}
+
+func TestEndpointSliceLister_ByServiceKey(t *testing.T) {
+	httpPorts := []interface{}{map[string]interface{}{"name": "http", "port": int64(8080), "protocol": "TCP"}}
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, serviceKeyIndexers)
+	for _, slice := range []*unstructured.Unstructured{
+		newEndpointSlice("web-b", "web", "IPv4", []interface{}{
+			map[string]interface{}{"addresses": []interface{}{"10.0.0.3"}, "conditions": map[string]interface{}{"ready": false},
+				"targetRef": map[string]interface{}{"kind": "Pod", "namespace": "default", "name": "web-3"}},
+		}, httpPorts),
+		newEndpointSlice("web-a", "web", "IPv4", []interface{}{
+			map[string]interface{}{"addresses": []interface{}{"10.0.0.1"}, "conditions": map[string]interface{}{"ready": true},
+				"targetRef": map[string]interface{}{"kind": "Pod", "namespace": "default", "name": "web-1"},
+				"topology":  map[string]interface{}{"kubernetes.io/hostname": "node-1"}},
+			map[string]interface{}{"addresses": []interface{}{"10.0.0.2"}, "conditions": map[string]interface{}{}},
+		}, httpPorts),
+		newEndpointSlice("web-fqdn", "web", "FQDN", []interface{}{
+			map[string]interface{}{"addresses": []interface{}{"web.example.com"}},
+		}, httpPorts),
+		newEndpointSlice("unlabeled", "", "IPv4", []interface{}{
+			map[string]interface{}{"addresses": []interface{}{"10.0.0.9"}},
+		}, httpPorts),
+	} {
+		assert.NoError(t, indexer.Add(slice))
+	}
+	lister := &EndpointSliceLister{Indexer: indexer}
+
+	eps, err := lister.ByServiceKey("default/web")
+	assert.NoError(t, err)
+	nodeName := "node-1"
+	assert.Equal(t, &apiv1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web"},
+		Subsets: []apiv1.EndpointSubset{
+			{
+				Addresses: []apiv1.EndpointAddress{
+					{IP: "10.0.0.1", NodeName: &nodeName, TargetRef: &apiv1.ObjectReference{Kind: "Pod", Namespace: "default", Name: "web-1"}},
+					// endpoints of unknown readiness are ready.
+					{IP: "10.0.0.2"},
+				},
+				Ports: []apiv1.EndpointPort{{Name: "http", Port: 8080, Protocol: apiv1.ProtocolTCP}},
+			},
+			{
+				NotReadyAddresses: []apiv1.EndpointAddress{
+					{IP: "10.0.0.3", TargetRef: &apiv1.ObjectReference{Kind: "Pod", Namespace: "default", Name: "web-3"}},
+				},
+				Ports: []apiv1.EndpointPort{{Name: "http", Port: 8080, Protocol: apiv1.ProtocolTCP}},
+			},
+		},
+	}, eps)
+
+	_, err = lister.ByServiceKey("default/api")
+	assert.Equal(t, NotExistsError("default/api"), err)
+}
+
+func TestK8sStore_GetServiceEndpoints(t *testing.T) {
+	sliceIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, serviceKeyIndexers)
+	assert.NoError(t, sliceIndexer.Add(newEndpointSlice("web-a", "web", "IPv4", []interface{}{
+		map[string]interface{}{"addresses": []interface{}{"10.0.0.1"}},
+	}, []interface{}{map[string]interface{}{"port": int64(8080)}})))
+	endpointStore := cache.NewStore(cache.MetaNamespaceKeyFunc)
+	assert.NoError(t, endpointStore.Add(&apiv1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web"},
+		Subsets:    []apiv1.EndpointSubset{{Addresses: []apiv1.EndpointAddress{{IP: "10.0.1.1"}}, Ports: []apiv1.EndpointPort{{Port: 8080}}}},
+	}))
+	assert.NoError(t, endpointStore.Add(&apiv1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "external"},
+		Subsets:    []apiv1.EndpointSubset{{Addresses: []apiv1.EndpointAddress{{IP: "10.0.2.1"}}, Ports: []apiv1.EndpointPort{{Port: 443}}}},
+	}))
+
+	for _, tc := range []struct {
+		name        string
+		slices      cache.Indexer
+		key         string
+		expectedIPs []string
+		expectedErr error
+	}{
+		{
+			name:        "EndpointSlices disabled",
+			key:         "default/web",
+			expectedIPs: []string{"10.0.1.1"},
+		},
+		{
+			name:        "EndpointSlices enabled",
+			slices:      sliceIndexer,
+			key:         "default/web",
+			expectedIPs: []string{"10.0.0.1"},
+		},
+		{
+			name:        "service without EndpointSlices falls back to Endpoints",
+			slices:      sliceIndexer,
+			key:         "default/external",
+			expectedIPs: []string{"10.0.2.1"},
+		},
+		{
+			name:        "service without endpoints",
+			slices:      sliceIndexer,
+			key:         "default/api",
+			expectedErr: NotExistsError("default/api"),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s := k8sStore{listers: &Lister{
+				Endpoint:      EndpointLister{Store: endpointStore},
+				EndpointSlice: EndpointSliceLister{Indexer: tc.slices},
+			}}
+			eps, err := s.GetServiceEndpoints(tc.key)
+			if tc.expectedErr != nil {
+				assert.Equal(t, tc.expectedErr, err)
+				return
+			}
+			assert.NoError(t, err)
+			var ips []string
+			for _, subset := range eps.Subsets {
+				for _, addr := range subset.Addresses {
+					ips = append(ips, addr.IP)
+				}
+			}
+			assert.Equal(t, tc.expectedIPs, ips)
+		})
+	}
+}
@@ -9,6 +9,8 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	v1 "k8s.io/api/core/v1"
+
+	v1beta1 "k8s.io/api/extensions/v1beta1"
 )
 
 // MockStorer is an autogenerated mock type for the Storer type
@@ -16,6 +18,11 @@ type MockStorer struct {
 	mock.Mock
 }
 
+// DeleteDerivedIngress provides a mock function with given fields: ing
+func (_m *MockStorer) DeleteDerivedIngress(ing *v1beta1.Ingress) {
+	_m.Called(ing)
+}
+
 // GetConfig provides a mock function with given fields:
 func (_m *MockStorer) GetConfig() *config.Configuration {
 	ret := _m.Called()
@@ -97,6 +104,29 @@ func (_m *MockStorer) GetNodeInstanceID(node *v1.Node) (string, error) {
 	return r0, r1
 }
 
+// GetPod provides a mock function with given fields: key
+func (_m *MockStorer) GetPod(key string) (*v1.Pod, error) {
+	ret := _m.Called(key)
+
+	var r0 *v1.Pod
+	if rf, ok := ret.Get(0).(func(string) *v1.Pod); ok {
+		r0 = rf(key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1.Pod)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetService provides a mock function with given fields: key
 func (_m *MockStorer) GetService(key string) (*v1.Service, error) {
 	ret := _m.Called(key)
@@ -181,3 +211,8 @@ func (_m *MockStorer) ListNodes() []*v1.Node {
 
 	return r0
 }
+
+// UpdateDerivedIngress provides a mock function with given fields: ing
+func (_m *MockStorer) UpdateDerivedIngress(ing *v1beta1.Ingress) {
+	_m.Called(ing)
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/class"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/config"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/shard"
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/k8s"
 	corev1 "k8s.io/api/core/v1"
 	extensions "k8s.io/api/extensions/v1beta1"
@@ -45,6 +46,9 @@ type Storer interface {
 	// GetServiceEndpoints returns the Endpoints of a Service matching key.
 	GetServiceEndpoints(key string) (*corev1.Endpoints, error)
 
+	// GetPod returns the Pod matching key.
+	GetPod(key string) (*corev1.Pod, error)
+
 	// GetServiceAnnotations returns the parsed annotations of an Service matching key. if ingress is non-nil, merges ingress annotations into the service.
 	GetServiceAnnotations(key string, ingress *annotations.Ingress) (*annotations.Service, error)
 
@@ -62,6 +66,12 @@ type Storer interface {
 
 	// GetNodeInstanceID gets the instance id of node
 	GetNodeInstanceID(node *corev1.Node) (string, error)
+
+	// UpdateDerivedIngress parses the annotations of an ingress derived from ingresses in the store, like the merged ingress of an IngressGroup.
+	UpdateDerivedIngress(ing *extensions.Ingress)
+
+	// DeleteDerivedIngress removes the annotations of a derived ingress.
+	DeleteDerivedIngress(ing *extensions.Ingress)
 }
 
 // Informer defines the required SharedIndexInformers that interact with the API server.
@@ -69,8 +79,10 @@ type Informer struct {
 	Ingress  cache.SharedIndexInformer
 	Service  cache.SharedIndexInformer
 	Endpoint cache.SharedIndexInformer
-	Node     cache.SharedIndexInformer
-	Pod      cache.SharedIndexInformer
+	// EndpointSlice is nil unless EndpointSlices are enabled.
+	EndpointSlice cache.SharedIndexInformer
+	Node          cache.SharedIndexInformer
+	Pod           cache.SharedIndexInformer
 }
 
 // Lister contains object listers (stores).
@@ -78,6 +90,7 @@ type Lister struct {
 	Ingress           IngressLister
 	Service           ServiceLister
 	Endpoint          EndpointLister
+	EndpointSlice     EndpointSliceLister
 	Node              NodeLister
 	Pod               PodLister
 	IngressAnnotation IngressAnnotationsLister
@@ -145,6 +158,22 @@ func New(mgr manager.Manager, cfg *config.Configuration) (Storer, error) {
 	}
 	store.listers.Endpoint.Store = store.informers.Endpoint.GetStore()
 
+	if cfg.FeatureGate.Enabled(config.EndpointSlices) {
+		if _, err := mgr.GetRESTMapper().RESTMapping(k8s.EndpointSliceGVK.GroupKind(), k8s.EndpointSliceGVK.Version); err != nil {
+			glog.Warningf("EndpointSlices %v aren't served, resolving endpoints from Endpoints: %v", k8s.EndpointSliceGVK.GroupVersion(), err)
+			cfg.FeatureGate.Disable(config.EndpointSlices)
+		} else {
+			store.informers.EndpointSlice, err = mgrCache.GetInformer(k8s.NewEndpointSlice())
+			if err != nil {
+				return nil, err
+			}
+			if err := store.informers.EndpointSlice.AddIndexers(serviceKeyIndexers); err != nil {
+				return nil, err
+			}
+			store.listers.EndpointSlice.Indexer = store.informers.EndpointSlice.GetIndexer()
+		}
+	}
+
 	store.informers.Node, err = mgrCache.GetInformer(&corev1.Node{})
 	if err != nil {
 		return nil, err
@@ -200,8 +229,20 @@ func New(mgr manager.Manager, cfg *config.Configuration) (Storer, error) {
 			store.extractServiceAnnotations(svc)
 		},
 		DeleteFunc: func(obj interface{}) {
-			svc := obj.(*corev1.Service)
-			store.extractServiceAnnotations(svc)
+			svc, ok := obj.(*corev1.Service)
+			if !ok {
+				tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+				if !ok {
+					glog.Errorf("couldn't get object from tombstone %#v", obj)
+					return
+				}
+				svc, ok = tombstone.Obj.(*corev1.Service)
+				if !ok {
+					glog.Errorf("Tombstone contained object that is not a Service: %#v", obj)
+					return
+				}
+			}
+			_ = store.listers.ServiceAnnotation.Delete(svc)
 		},
 		UpdateFunc: func(old, cur interface{}) {
 			if !reflect.DeepEqual(old, cur) {
@@ -213,6 +254,8 @@ func New(mgr manager.Manager, cfg *config.Configuration) (Storer, error) {
 
 	store.informers.Ingress.AddEventHandler(ingEventHandler)
 	store.informers.Service.AddEventHandler(svcEventHandler)
+	cfg.AnnotationDefaults.OnChange(store.refreshIngressAnnotations)
+	cfg.AnnotationOverrides.OnChange(store.refreshIngressAnnotations)
 	return store, nil
 }
 
@@ -222,6 +265,11 @@ func (s *k8sStore) extractIngressAnnotations(ing *extensions.Ingress) {
 	key := k8s.MetaNamespaceKey(ing)
 	glog.V(3).Infof("updating annotations information for ingress %v", key)
 
+	ingressClass := class.GetIngressClass(ing)
+	if len(s.cfg.AnnotationDefaults.Get(ingressClass)) != 0 || len(s.cfg.AnnotationOverrides.Get(ingressClass)) != 0 {
+		ing = ing.DeepCopy()
+		ing.Annotations = s.cfg.AnnotationOverrides.Override(ingressClass, s.cfg.AnnotationDefaults.Merge(ingressClass, ing.Annotations))
+	}
 	anns := s.ingannotations.ExtractIngress(ing)
 
 	err := s.listers.IngressAnnotation.Update(anns)
@@ -230,6 +278,27 @@ func (s *k8sStore) extractIngressAnnotations(ing *extensions.Ingress) {
 	}
 }
 
+// UpdateDerivedIngress parses the annotations of an ingress derived from ingresses in the store.
+func (s *k8sStore) UpdateDerivedIngress(ing *extensions.Ingress) {
+	s.extractIngressAnnotations(ing)
+}
+
+// DeleteDerivedIngress removes the annotations of a derived ingress.
+func (s *k8sStore) DeleteDerivedIngress(ing *extensions.Ingress) {
+	_ = s.listers.IngressAnnotation.Delete(ing)
+}
+
+// refreshIngressAnnotations parses annotations of all ingresses again, after default or locked annotations changed.
+func (s *k8sStore) refreshIngressAnnotations() {
+	for _, item := range s.listers.Ingress.List() {
+		ing := item.(*extensions.Ingress)
+		if !class.IsValidIngress(s.cfg.IngressClass, ing) {
+			continue
+		}
+		s.extractIngressAnnotations(ing)
+	}
+}
+
 // extractServiceAnnotations parses service annotations converting the value of the
 // annotation to a go struct and also information about the referenced secrets
 func (s *k8sStore) extractServiceAnnotations(svc *corev1.Service) {
@@ -270,8 +339,14 @@ func (s k8sStore) GetConfig() *config.Configuration {
 }
 
 // GetIngressAnnotations returns the parsed annotations of an Ingress matching key.
+// Shards of an ingress share the annotations of the ingress they are split from.
 func (s k8sStore) GetIngressAnnotations(key string) (*annotations.Ingress, error) {
 	ia, err := s.listers.IngressAnnotation.ByKey(key)
+	if _, notExists := err.(NotExistsError); notExists {
+		if parentKey := shard.ParentKey(key); parentKey != key {
+			ia, err = s.listers.IngressAnnotation.ByKey(parentKey)
+		}
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -294,10 +369,22 @@ func (s k8sStore) GetServiceAnnotations(key string, ingress *annotations.Ingress
 }
 
 // GetServiceEndpoints returns the Endpoints of a Service matching key.
+// With EndpointSlices enabled, they're merged from the EndpointSlices of the Service, or read from its Endpoints if it has no EndpointSlices.
 func (s k8sStore) GetServiceEndpoints(key string) (*corev1.Endpoints, error) {
+	if s.listers.EndpointSlice.Indexer != nil {
+		eps, err := s.listers.EndpointSlice.ByServiceKey(key)
+		if _, notExists := err.(NotExistsError); !notExists {
+			return eps, err
+		}
+	}
 	return s.listers.Endpoint.ByKey(key)
 }
 
+// GetPod returns the Pod matching key.
+func (s k8sStore) GetPod(key string) (*corev1.Pod, error) {
+	return s.listers.Pod.ByKey(key)
+}
+
 func (s *k8sStore) GetNodeInstanceID(node *corev1.Node) (string, error) {
 	nodeVersion, _ := semver.ParseTolerant(node.Status.NodeInfo.KubeletVersion)
 	if nodeVersion.Major == 1 && nodeVersion.Minor <= 10 {
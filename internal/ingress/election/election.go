@@ -0,0 +1,292 @@
+package election
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang/glog"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+const (
+	// LockTypeLeases holds the leader election record in a coordination.k8s.io Lease.
+	LockTypeLeases = "leases"
+	// LockTypeConfigMaps holds the leader election record in an annotation of a ConfigMap.
+	LockTypeConfigMaps = resourcelock.ConfigMapsResourceLock
+
+	// timings of leader election, a standby takes over at most leaseDuration after the leader stopped renewing.
+	leaseDuration = 15 * time.Second
+	renewDeadline = 10 * time.Second
+	retryPeriod   = 2 * time.Second
+
+	// StandbyRequeueInterval is the interval standbys requeue ingresses at without reconciling them,
+	// so that a new leader reconciles every ingress shortly after taking over.
+	StandbyRequeueInterval = 10 * time.Second
+
+	inClusterNamespacePath = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+)
+
+// Elector elects a leader among the replicas of the controller, only the leader mutates AWS resources.
+type Elector interface {
+	manager.Runnable
+
+	// IsLeader returns whether this replica currently leads.
+	IsLeader() bool
+
+	// Leading returns a channel closed once this replica leads.
+	Leading() <-chan struct{}
+
+	// BeginReconcile registers a reconcile that may mutate AWS resources. It returns false unless this replica leads and isn't
+	// draining, in which case the reconcile must be skipped. Every successful BeginReconcile must be followed by EndReconcile.
+	BeginReconcile() bool
+
+	// EndReconcile registers the end of a reconcile begun by BeginReconcile.
+	EndReconcile()
+
+	// Drain stops further reconciles from beginning, and waits up to timeout for reconciles in progress to end.
+	// It returns the number of reconciles still in progress. Leadership is kept while draining, so that no other replica
+	// mutates the AWS resources reconciles in progress are mutating.
+	Drain(timeout time.Duration) int
+
+	// Release stops campaigning and gives up leadership, once drained.
+	Release() error
+}
+
+// NewElector constructs an Elector campaigning for lock once started.
+// Losing the lock fails Start, since the controller can't tell which mutations the new leader has already made.
+func NewElector(lock resourcelock.Interface) Elector {
+	return &defaultElector{
+		lock:      lock,
+		leading:   make(chan struct{}),
+		drained:   make(chan struct{}),
+		releasing: make(chan struct{}),
+		stopped:   make(chan struct{}),
+	}
+}
+
+// NewAlwaysLeader constructs an Elector of a controller running without leader election, which always leads.
+func NewAlwaysLeader() Elector {
+	elector := NewElector(nil).(*defaultElector)
+	elector.startLeading()
+	return elector
+}
+
+type defaultElector struct {
+	lock resourcelock.Interface
+
+	leader      int32
+	leading     chan struct{}
+	leadingOnce sync.Once
+
+	// reconciles is the number of reconciles in progress, drained is closed once draining and none is left.
+	mutex      sync.Mutex
+	reconciles int
+	draining   bool
+	drained    chan struct{}
+
+	// releasing is closed by Release to stop campaigning, stopped is closed once campaigning stopped.
+	started     int32
+	releasing   chan struct{}
+	releaseOnce sync.Once
+	stopped     chan struct{}
+}
+
+func (e *defaultElector) IsLeader() bool {
+	return atomic.LoadInt32(&e.leader) == 1
+}
+
+func (e *defaultElector) Leading() <-chan struct{} {
+	return e.leading
+}
+
+func (e *defaultElector) BeginReconcile() bool {
+	if !e.IsLeader() {
+		return false
+	}
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	if e.draining {
+		return false
+	}
+	e.reconciles++
+	return true
+}
+
+func (e *defaultElector) EndReconcile() {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.reconciles--
+	if e.draining && e.reconciles == 0 {
+		close(e.drained)
+	}
+}
+
+func (e *defaultElector) Drain(timeout time.Duration) int {
+	e.mutex.Lock()
+	if !e.draining {
+		e.draining = true
+		if e.reconciles == 0 {
+			close(e.drained)
+		}
+	}
+	e.mutex.Unlock()
+
+	select {
+	case <-e.drained:
+	case <-time.After(timeout):
+	}
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	return e.reconciles
+}
+
+// Release clears the holder of the lock if this replica holds it, so that standbys don't wait for this replica to renew it.
+func (e *defaultElector) Release() error {
+	e.releaseOnce.Do(func() { close(e.releasing) })
+	if e.lock == nil || atomic.LoadInt32(&e.started) == 0 {
+		return nil
+	}
+	select {
+	case <-e.stopped:
+	case <-time.After(renewDeadline):
+		return fmt.Errorf("timed out waiting for %v to stop campaigning", e.lock.Identity())
+	}
+
+	record, err := e.lock.Get()
+	if err != nil {
+		return err
+	}
+	if record.HolderIdentity != e.lock.Identity() {
+		return nil
+	}
+	now := metav1.Now()
+	if err := e.lock.Update(resourcelock.LeaderElectionRecord{
+		LeaseDurationSeconds: 1,
+		AcquireTime:          now,
+		RenewTime:            now,
+		LeaderTransitions:    record.LeaderTransitions,
+	}); err != nil {
+		return err
+	}
+	glog.Infof("%v released the leadership of %v", e.lock.Identity(), e.lock.Describe())
+	return nil
+}
+
+// Start campaigns for the lock until Release is called, it's added to the manager so that campaigning starts once caches are synced.
+// Closing stop doesn't stop campaigning, since leadership must be kept until reconciles in progress are drained.
+func (e *defaultElector) Start(stop <-chan struct{}) error {
+	if e.lock == nil {
+		<-stop
+		return nil
+	}
+	atomic.StoreInt32(&e.started, 1)
+	defer close(e.stopped)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-e.releasing:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	elector, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+		Lock:          e.lock,
+		LeaseDuration: leaseDuration,
+		RenewDeadline: renewDeadline,
+		RetryPeriod:   retryPeriod,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(context.Context) {
+				glog.Infof("%v became the leader of %v", e.lock.Identity(), e.lock.Describe())
+				e.startLeading()
+			},
+			OnStoppedLeading: func() {
+				atomic.StoreInt32(&e.leader, 0)
+			},
+			OnNewLeader: func(identity string) {
+				if identity != e.lock.Identity() {
+					glog.Infof("%v is the leader of %v, waiting on standby", identity, e.lock.Describe())
+				}
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create leader elector due to %w", err)
+	}
+	elector.Run(ctx)
+
+	select {
+	case <-e.releasing:
+		return nil
+	default:
+		return fmt.Errorf("%v lost the leadership of %v", e.lock.Identity(), e.lock.Describe())
+	}
+}
+
+func (e *defaultElector) startLeading() {
+	atomic.StoreInt32(&e.leader, 1)
+	e.leadingOnce.Do(func() { close(e.leading) })
+}
+
+// LeaderOnly wraps runnable to start once elector leads, e.g. so that background loops emitting events only run on the leader.
+func LeaderOnly(elector Elector, runnable manager.Runnable) manager.Runnable {
+	return manager.RunnableFunc(func(stop <-chan struct{}) error {
+		select {
+		case <-elector.Leading():
+			return runnable.Start(stop)
+		case <-stop:
+			return nil
+		}
+	})
+}
+
+// NewLock constructs a lock of lockType named id in namespace, defaulting to the namespace of the controller pod.
+// Each replica is identified by its hostname, which is the pod name.
+func NewLock(restCfg *rest.Config, recorder record.EventRecorder, lockType string, namespace string, id string) (resourcelock.Interface, error) {
+	if namespace == "" {
+		content, err := ioutil.ReadFile(inClusterNamespacePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to determine the namespace of leader election, specify it if not running in-cluster, due to %w", err)
+		}
+		namespace = strings.TrimSpace(string(content))
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		return nil, err
+	}
+	lockConfig := resourcelock.ResourceLockConfig{
+		Identity:      hostname + "_" + string(uuid.NewUUID()),
+		EventRecorder: recorder,
+	}
+	clientset, err := kubernetes.NewForConfig(restCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	switch lockType {
+	case LockTypeLeases:
+		return &LeaseLock{
+			LeaseMeta:  metav1.ObjectMeta{Namespace: namespace, Name: id},
+			Client:     clientset.CoordinationV1beta1(),
+			LockConfig: lockConfig,
+		}, nil
+	case LockTypeConfigMaps:
+		return resourcelock.New(lockType, namespace, id, clientset.CoreV1(), lockConfig)
+	default:
+		return nil, fmt.Errorf("invalid leader election lock type %v, must be %v or %v", lockType, LockTypeLeases, LockTypeConfigMaps)
+	}
+}
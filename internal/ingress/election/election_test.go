@@ -0,0 +1,76 @@
+package election
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+func TestLeaseLock(t *testing.T) {
+	lock := &LeaseLock{
+		LeaseMeta:  metav1.ObjectMeta{Namespace: "kube-system", Name: "ingress-controller-leader-alb"},
+		Client:     fake.NewSimpleClientset().CoordinationV1beta1(),
+		LockConfig: resourcelock.ResourceLockConfig{Identity: "pod-1"},
+	}
+	assert.Equal(t, "kube-system/ingress-controller-leader-alb", lock.Describe())
+
+	_, err := lock.Get()
+	assert.Error(t, err)
+	now := metav1.NewTime(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	record := resourcelock.LeaderElectionRecord{
+		HolderIdentity:       "pod-1",
+		LeaseDurationSeconds: 15,
+		AcquireTime:          now,
+		RenewTime:            now,
+	}
+	assert.NoError(t, lock.Create(record))
+
+	record.RenewTime = metav1.NewTime(now.Add(time.Minute))
+	record.LeaderTransitions = 1
+	assert.NoError(t, lock.Update(record))
+	actual, err := lock.Get()
+	assert.NoError(t, err)
+	assert.Equal(t, record.HolderIdentity, actual.HolderIdentity)
+	assert.Equal(t, record.LeaderTransitions, actual.LeaderTransitions)
+	assert.True(t, record.RenewTime.Equal(&actual.RenewTime))
+}
+
+func TestLeaderOnly(t *testing.T) {
+	started := make(chan struct{}, 1)
+	runnable := manager.RunnableFunc(func(<-chan struct{}) error {
+		started <- struct{}{}
+		return nil
+	})
+
+	stop := make(chan struct{})
+	close(stop)
+	assert.NoError(t, LeaderOnly(NewElector(nil), runnable).Start(stop))
+	assert.Empty(t, started)
+
+	elector := NewAlwaysLeader()
+	assert.True(t, elector.IsLeader())
+	assert.NoError(t, LeaderOnly(elector, runnable).Start(make(chan struct{})))
+	assert.Len(t, started, 1)
+}
+
+func TestDrain(t *testing.T) {
+	assert.False(t, NewElector(nil).BeginReconcile())
+
+	elector := NewAlwaysLeader()
+	assert.True(t, elector.BeginReconcile())
+	assert.Equal(t, 1, elector.Drain(10*time.Millisecond))
+	assert.False(t, elector.BeginReconcile())
+
+	drained := make(chan int)
+	go func() {
+		drained <- elector.Drain(time.Minute)
+	}()
+	elector.EndReconcile()
+	assert.Equal(t, 0, <-drained)
+	assert.NoError(t, elector.Release())
+}
@@ -0,0 +1,225 @@
+// Package group merges the ingresses of an IngressGroup into a single ingress, which is reconciled as one ALB.
+//
+// Ingresses join a group by the `group.name` annotation, possibly across namespaces. The merged ingress is named
+// by the group, in Namespace which can't collide with real namespaces. Rules of members are concatenated ordered
+// by the `group.order` annotation, then by namespace and name of members.
+//
+// Backends of members are qualified by their ingress, so that backends with the same name in different members
+// never collide. Annotations named by backends(actions, conditions, priority and service-namespace) are qualified alike,
+// any other annotation must have the same value on every member that sets it.
+package group
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/action"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/parser"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/backend"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// Namespace is the namespace of merged ingresses, it contains a dot so that it's never a valid namespace.
+const Namespace = "ingress.group"
+
+const (
+	annotationName  = "group.name"
+	annotationOrder = "group.order"
+
+	annotationClass = "kubernetes.io/ingress.class"
+)
+
+// backendNamedAnnotations are the annotations named by backends, like `actions.${action-name}`.
+var backendNamedAnnotations = []string{"actions.", "conditions.", "priority.", "service-namespace."}
+
+// Name returns the name of the group ingress belongs to, or empty if it doesn't belong to a group.
+func Name(ingress *extensions.Ingress) string {
+	return strings.TrimSpace(ingress.Annotations[parser.GetAnnotationWithPrefix(annotationName)])
+}
+
+// Key returns the namespaced name of the merged ingress of group.
+func Key(groupName string) types.NamespacedName {
+	return types.NamespacedName{Namespace: Namespace, Name: groupName}
+}
+
+// IsKey returns whether key is the namespaced name of a merged ingress.
+func IsKey(key types.NamespacedName) bool {
+	return key.Namespace == Namespace
+}
+
+// Merge merges members of group into a single ingress.
+func Merge(groupName string, members []*extensions.Ingress) (*extensions.Ingress, error) {
+	if errs := validation.IsDNS1123Label(groupName); len(errs) != 0 {
+		return nil, fmt.Errorf("invalid group name %v: %v", groupName, strings.Join(errs, ", "))
+	}
+	members, err := sortMembers(members)
+	if err != nil {
+		return nil, err
+	}
+
+	merged := &extensions.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   Namespace,
+			Name:        groupName,
+			Annotations: make(map[string]string),
+		},
+	}
+	// annotationOwners records the member that set each annotation, to report conflicts.
+	annotationOwners := make(map[string]string)
+	for _, member := range members {
+		memberKey := member.Namespace + "/" + member.Name
+		for k, v := range qualifyAnnotations(member) {
+			if owner, ok := annotationOwners[k]; ok && merged.Annotations[k] != v {
+				return nil, fmt.Errorf("annotation %v of ingress %v conflicts with ingress %v in group %v", k, memberKey, owner, groupName)
+			}
+			merged.Annotations[k] = v
+			annotationOwners[k] = memberKey
+		}
+
+		if member.Spec.Backend != nil {
+			if merged.Spec.Backend != nil {
+				return nil, fmt.Errorf("default backend of ingress %v conflicts with another ingress in group %v", memberKey, groupName)
+			}
+			merged.Spec.Backend = qualifyBackend(member, *member.Spec.Backend)
+		}
+		for _, rule := range member.Spec.Rules {
+			rule = *rule.DeepCopy()
+			if rule.HTTP != nil {
+				for i := range rule.HTTP.Paths {
+					rule.HTTP.Paths[i].Backend = *qualifyBackend(member, rule.HTTP.Paths[i].Backend)
+				}
+			}
+			merged.Spec.Rules = append(merged.Spec.Rules, rule)
+		}
+		for _, tls := range member.Spec.TLS {
+			tls = *tls.DeepCopy()
+			tls.SecretName = qualifySecretName(member, tls.SecretName)
+			merged.Spec.TLS = append(merged.Spec.TLS, tls)
+		}
+	}
+	return merged, nil
+}
+
+// sortMembers returns members ordered by `group.order`, then by namespace and name.
+func sortMembers(members []*extensions.Ingress) ([]*extensions.Ingress, error) {
+	orders := make(map[*extensions.Ingress]int, len(members))
+	for _, member := range members {
+		order := 0
+		if raw, ok := member.Annotations[parser.GetAnnotationWithPrefix(annotationOrder)]; ok {
+			var err error
+			if order, err = strconv.Atoi(strings.TrimSpace(raw)); err != nil {
+				return nil, fmt.Errorf("invalid %v of ingress %v/%v: %v", annotationOrder, member.Namespace, member.Name, raw)
+			}
+		}
+		orders[member] = order
+	}
+	sorted := append([]*extensions.Ingress(nil), members...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if orders[sorted[i]] != orders[sorted[j]] {
+			return orders[sorted[i]] < orders[sorted[j]]
+		}
+		return sorted[i].Namespace+"/"+sorted[i].Name < sorted[j].Namespace+"/"+sorted[j].Name
+	})
+	return sorted, nil
+}
+
+// qualifyName returns the name of a backend of member within the merged ingress, see backend.ServiceKey.
+func qualifyName(member *extensions.Ingress, name string) string {
+	return member.Namespace + "/" + member.Name + "/" + name
+}
+
+// qualifyBackend returns the backend of member within the merged ingress.
+func qualifyBackend(member *extensions.Ingress, ingressBackend extensions.IngressBackend) *extensions.IngressBackend {
+	return &extensions.IngressBackend{
+		ServiceName: qualifyName(member, ingressBackend.ServiceName),
+		ServicePort: ingressBackend.ServicePort,
+	}
+}
+
+// qualifySecretName returns the TLS secret of member within the merged ingress, qualified by the namespace of member.
+func qualifySecretName(member *extensions.Ingress, secretName string) string {
+	if secretName == "" || arn.IsARN(secretName) {
+		return secretName
+	}
+	return member.Namespace + "/" + secretName
+}
+
+// qualifyAnnotations returns the annotations of member merged into the ingress of group.
+// The namespace of services is made explicit, since it's no longer implied by the namespace of the merged ingress.
+func qualifyAnnotations(member *extensions.Ingress) map[string]string {
+	result := make(map[string]string)
+	prefix := parser.GetAnnotationWithPrefix("")
+	for k, v := range member.Annotations {
+		if k == annotationClass {
+			result[k] = v
+			continue
+		}
+		if !strings.HasPrefix(k, prefix) {
+			continue
+		}
+		suffix := strings.TrimPrefix(k, prefix)
+		if suffix == annotationName || suffix == annotationOrder {
+			continue
+		}
+		result[k] = v
+		for _, namedPrefix := range backendNamedAnnotations {
+			if strings.HasPrefix(suffix, namedPrefix) {
+				delete(result, k)
+				result[prefix+namedPrefix+qualifyName(member, strings.TrimPrefix(suffix, namedPrefix))] = v
+				break
+			}
+		}
+	}
+
+	addServiceNamespace := func(serviceName string) {
+		serviceKey := backend.ServiceKey(member, extensions.IngressBackend{ServiceName: serviceName})
+		result[parser.GetAnnotationWithPrefix("service-namespace."+qualifyName(member, serviceName))] = serviceKey.Namespace
+	}
+	addBackend := func(ingressBackend *extensions.IngressBackend) {
+		if ingressBackend == nil || action.Use(ingressBackend.ServicePort.String()) {
+			return
+		}
+		addServiceNamespace(ingressBackend.ServiceName)
+	}
+	addBackend(member.Spec.Backend)
+	for _, rule := range member.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for i := range rule.HTTP.Paths {
+			addBackend(&rule.HTTP.Paths[i].Backend)
+		}
+	}
+
+	// services forwarded to by actions are referenced by name as well.
+	actionPrefix := prefix + "actions."
+	for k, v := range result {
+		if !strings.HasPrefix(k, actionPrefix) {
+			continue
+		}
+		forwardAction := action.Action{}
+		if err := json.Unmarshal([]byte(v), &forwardAction); err != nil || forwardAction.ForwardConfig == nil {
+			// invalid actions are reported when parsing annotations of the merged ingress.
+			continue
+		}
+		for _, tgt := range forwardAction.ForwardConfig.TargetGroups {
+			if tgt.ServiceName == nil {
+				continue
+			}
+			addServiceNamespace(*tgt.ServiceName)
+			tgt.ServiceName = awssdk.String(qualifyName(member, *tgt.ServiceName))
+		}
+		if raw, err := json.Marshal(forwardAction); err == nil {
+			result[k] = string(raw)
+		}
+	}
+	return result
+}
@@ -0,0 +1,171 @@
+package group
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations/action"
+	"github.com/stretchr/testify/assert"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func member(namespace, name string, annotations map[string]string, services ...string) *extensions.Ingress {
+	ingress := &extensions.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Annotations: annotations},
+	}
+	rule := extensions.IngressRule{
+		IngressRuleValue: extensions.IngressRuleValue{
+			HTTP: &extensions.HTTPIngressRuleValue{},
+		},
+	}
+	for _, service := range services {
+		rule.HTTP.Paths = append(rule.HTTP.Paths, extensions.HTTPIngressPath{
+			Path: "/" + service,
+			Backend: extensions.IngressBackend{
+				ServiceName: service,
+				ServicePort: intstr.FromInt(80),
+			},
+		})
+	}
+	ingress.Spec.Rules = append(ingress.Spec.Rules, rule)
+	return ingress
+}
+
+func TestName(t *testing.T) {
+	assert.Equal(t, "", Name(member("ns", "ingress", nil)))
+	assert.Equal(t, "my-group", Name(member("ns", "ingress", map[string]string{
+		"alb.ingress.kubernetes.io/group.name": " my-group",
+	})))
+}
+
+func TestKey(t *testing.T) {
+	assert.Equal(t, types.NamespacedName{Namespace: Namespace, Name: "my-group"}, Key("my-group"))
+	assert.True(t, IsKey(Key("my-group")))
+	assert.False(t, IsKey(types.NamespacedName{Namespace: "default", Name: "my-group"}))
+}
+
+func TestMerge(t *testing.T) {
+	a := member("ns-a", "ingress", map[string]string{
+		"alb.ingress.kubernetes.io/group.name":  "my-group",
+		"alb.ingress.kubernetes.io/group.order": "2",
+		"alb.ingress.kubernetes.io/scheme":      "internet-facing",
+		"kubernetes.io/ingress.class":           "alb",
+		"unrelated":                             "value",
+	}, "svc")
+	a.Spec.TLS = []extensions.IngressTLS{{Hosts: []string{"a.example.com"}, SecretName: "tls-secret"}}
+	b := member("ns-b", "ingress", map[string]string{
+		"alb.ingress.kubernetes.io/group.name":            "my-group",
+		"alb.ingress.kubernetes.io/group.order":           "1",
+		"alb.ingress.kubernetes.io/scheme":                "internet-facing",
+		"alb.ingress.kubernetes.io/service-namespace.svc": "shared",
+		"alb.ingress.kubernetes.io/conditions.svc":        `[{"Field":"host-header"}]`,
+	}, "svc")
+
+	merged, err := Merge("my-group", []*extensions.Ingress{a, b})
+	assert.NoError(t, err)
+	assert.Equal(t, Namespace, merged.Namespace)
+	assert.Equal(t, "my-group", merged.Name)
+	assert.Equal(t, map[string]string{
+		"alb.ingress.kubernetes.io/scheme":                             "internet-facing",
+		"kubernetes.io/ingress.class":                                  "alb",
+		"alb.ingress.kubernetes.io/service-namespace.ns-a/ingress/svc": "ns-a",
+		"alb.ingress.kubernetes.io/service-namespace.ns-b/ingress/svc": "shared",
+		"alb.ingress.kubernetes.io/conditions.ns-b/ingress/svc":        `[{"Field":"host-header"}]`,
+	}, merged.Annotations)
+	assert.Len(t, merged.Spec.Rules, 2)
+	assert.Equal(t, "ns-b/ingress/svc", merged.Spec.Rules[0].HTTP.Paths[0].Backend.ServiceName, "rules are ordered by group.order")
+	assert.Equal(t, "ns-a/ingress/svc", merged.Spec.Rules[1].HTTP.Paths[0].Backend.ServiceName)
+	assert.Equal(t, []extensions.IngressTLS{{Hosts: []string{"a.example.com"}, SecretName: "ns-a/tls-secret"}}, merged.Spec.TLS)
+	assert.Equal(t, "svc", a.Spec.Rules[0].HTTP.Paths[0].Backend.ServiceName, "members are left untouched")
+}
+
+func TestMerge_forwardActions(t *testing.T) {
+	forward := action.Action{
+		Type: aws.String("forward"),
+		ForwardConfig: &action.ForwardActionConfig{
+			TargetGroups: []*action.TargetGroupTuple{
+				{ServiceName: aws.String("svc-a"), ServicePort: aws.String("80"), Weight: aws.Int64(50)},
+				{ServiceName: aws.String("svc-b"), ServicePort: aws.String("80"), Weight: aws.Int64(50)},
+			},
+		},
+	}
+	raw, _ := json.Marshal(forward)
+	ingress := member("ns", "ingress", map[string]string{
+		"alb.ingress.kubernetes.io/group.name":              "my-group",
+		"alb.ingress.kubernetes.io/actions.weighted":        string(raw),
+		"alb.ingress.kubernetes.io/service-namespace.svc-b": "shared",
+	})
+
+	merged, err := Merge("my-group", []*extensions.Ingress{ingress})
+	assert.NoError(t, err)
+	mergedAction := action.Action{}
+	assert.NoError(t, json.Unmarshal([]byte(merged.Annotations["alb.ingress.kubernetes.io/actions.ns/ingress/weighted"]), &mergedAction))
+	assert.Equal(t, "ns/ingress/svc-a", aws.StringValue(mergedAction.ForwardConfig.TargetGroups[0].ServiceName))
+	assert.Equal(t, "ns/ingress/svc-b", aws.StringValue(mergedAction.ForwardConfig.TargetGroups[1].ServiceName))
+	assert.Equal(t, "ns", merged.Annotations["alb.ingress.kubernetes.io/service-namespace.ns/ingress/svc-a"])
+	assert.Equal(t, "shared", merged.Annotations["alb.ingress.kubernetes.io/service-namespace.ns/ingress/svc-b"])
+}
+
+func TestMerge_conflicts(t *testing.T) {
+	groupAnnotations := func(extra map[string]string) map[string]string {
+		annotations := map[string]string{"alb.ingress.kubernetes.io/group.name": "my-group"}
+		for k, v := range extra {
+			annotations[k] = v
+		}
+		return annotations
+	}
+	withDefaultBackend := func(ingress *extensions.Ingress) *extensions.Ingress {
+		ingress.Spec.Backend = &extensions.IngressBackend{ServiceName: "default", ServicePort: intstr.FromInt(80)}
+		return ingress
+	}
+	for _, tc := range []struct {
+		name        string
+		groupName   string
+		members     []*extensions.Ingress
+		expectedErr string
+	}{
+		{
+			name:      "conflicting annotations",
+			groupName: "my-group",
+			members: []*extensions.Ingress{
+				member("ns", "a", groupAnnotations(map[string]string{"alb.ingress.kubernetes.io/scheme": "internal"})),
+				member("ns", "b", groupAnnotations(map[string]string{"alb.ingress.kubernetes.io/scheme": "internet-facing"})),
+			},
+			expectedErr: "annotation alb.ingress.kubernetes.io/scheme of ingress ns/b conflicts with ingress ns/a in group my-group",
+		},
+		{
+			name:      "multiple default backends",
+			groupName: "my-group",
+			members: []*extensions.Ingress{
+				withDefaultBackend(member("ns", "a", groupAnnotations(nil))),
+				withDefaultBackend(member("ns", "b", groupAnnotations(nil))),
+			},
+			expectedErr: "default backend of ingress ns/b conflicts with another ingress in group my-group",
+		},
+		{
+			name:      "invalid order",
+			groupName: "my-group",
+			members: []*extensions.Ingress{
+				member("ns", "a", groupAnnotations(map[string]string{"alb.ingress.kubernetes.io/group.order": "first"})),
+			},
+			expectedErr: "invalid group.order of ingress ns/a: first",
+		},
+		{
+			name:        "invalid group name",
+			groupName:   "My_Group",
+			members:     []*extensions.Ingress{member("ns", "a", nil)},
+			expectedErr: "invalid group name My_Group",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Merge(tc.groupName, tc.members)
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tc.expectedErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package inventory
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/golang/glog"
+)
+
+// HandlerPath is the path the inventory is served at.
+const HandlerPath = "/admin/v1/loadbalancers"
+
+// NewHandler constructs a read-only http.Handler serving the LoadBalancers of inventory as JSON.
+// Requests must carry token as a bearer token in the Authorization header.
+func NewHandler(inventory Inventory, token string) http.Handler {
+	return &handler{
+		inventory: inventory,
+		token:     []byte(token),
+	}
+}
+
+type handler struct {
+	inventory Inventory
+	token     []byte
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !h.authenticated(r) {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	lbs, err := h.inventory.List(r.Context())
+	if err != nil {
+		glog.Errorf("failed to list load balancers due to %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if lbs == nil {
+		lbs = []LoadBalancer{}
+	}
+	payload, err := json.Marshal(struct {
+		LoadBalancers []LoadBalancer `json:"loadBalancers"`
+	}{lbs})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(payload)
+}
+
+func (h *handler) authenticated(r *http.Request) bool {
+	header := r.Header.Get("Authorization")
+	if !strings.HasPrefix(header, "Bearer ") {
+		return false
+	}
+	token := []byte(strings.TrimPrefix(header, "Bearer "))
+	return len(h.token) != 0 && subtle.ConstantTimeCompare(token, h.token) == 1
+}
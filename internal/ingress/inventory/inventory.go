@@ -0,0 +1,342 @@
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/generator"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/lb"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/shard"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/log"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// maximum number of resources per DescribeTags call.
+const describeTagsBatchSize = 20
+
+// Inventory lists the LoadBalancers managed by the controller, together with the result of the last reconcile of their ingresses.
+type Inventory interface {
+	// RecordReconcile records the result of a reconcile of ingress, err is nil if it succeeded.
+	RecordReconcile(ingressKey types.NamespacedName, err error)
+
+	// BeginReconcile records that a reconcile of ingress started, it's in-flight until its result is recorded.
+	BeginReconcile(ingressKey types.NamespacedName)
+
+	// RecordDesired records the desired configuration of ingress, as parsed from its annotations.
+	RecordDesired(ingressKey types.NamespacedName, desired *annotations.Ingress)
+
+	// RecordCurrent records the LoadBalancers of ingress, as observed by its reconcile.
+	RecordCurrent(ingressKey types.NamespacedName, current []*lb.LoadBalancer)
+
+	// Forget removes the reconcile result of a deleted ingress.
+	Forget(ingressKey types.NamespacedName)
+
+	// List returns the LoadBalancers owned by the cluster, sorted by ingress.
+	List(ctx context.Context) ([]LoadBalancer, error)
+
+	// State returns the in-memory state of reconciles sorted by ingress, without calling AWS.
+	State() []ReconcileState
+}
+
+// LoadBalancer is a LoadBalancer managed by the controller.
+type LoadBalancer struct {
+	Arn     string `json:"arn"`
+	Name    string `json:"name"`
+	DNSName string `json:"dnsName"`
+	Type    string `json:"type"`
+	Scheme  string `json:"scheme"`
+	State   string `json:"state"`
+
+	// Ingress is the namespace/name of the ingress that owns the LoadBalancer.
+	// Shards, secondary ALBs and green stacks are owned by the ingress they're derived from.
+	Ingress string `json:"ingress"`
+	// Stack is the namespace/name of the ingress the LoadBalancer is tagged with, which differs from Ingress for derived LoadBalancers.
+	Stack string `json:"stack"`
+
+	Listeners     []Listener       `json:"listeners"`
+	TargetGroups  []TargetGroup    `json:"targetGroups"`
+	LastReconcile *ReconcileResult `json:"lastReconcile,omitempty"`
+}
+
+// Listener is a listener of a LoadBalancer, with its rules.
+type Listener struct {
+	Arn      string `json:"arn"`
+	Protocol string `json:"protocol"`
+	Port     int64  `json:"port"`
+	Rules    []Rule `json:"rules"`
+}
+
+// Rule is a rule of a listener.
+type Rule struct {
+	Arn        string                 `json:"arn"`
+	Priority   string                 `json:"priority"`
+	Conditions []*elbv2.RuleCondition `json:"conditions"`
+	Actions    []*elbv2.Action        `json:"actions"`
+}
+
+// TargetGroup is a targetGroup referenced by the listeners of a LoadBalancer.
+type TargetGroup struct {
+	Arn string `json:"arn"`
+	// Targets counts the targets of the targetGroup by their health state.
+	Targets map[string]int `json:"targets"`
+}
+
+// ReconcileResult is the result of a reconcile of an ingress.
+type ReconcileResult struct {
+	Time      time.Time `json:"time"`
+	Succeeded bool      `json:"succeeded"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// ReconcileState is the state of reconciles of an ingress kept in memory by the controller.
+type ReconcileState struct {
+	Ingress string `json:"ingress"`
+	// ReconcilingSince is the start of the in-flight reconcile, a stuck reconcile has been in-flight for long.
+	ReconcilingSince *time.Time       `json:"reconcilingSince,omitempty"`
+	LastReconcile    *ReconcileResult `json:"lastReconcile,omitempty"`
+	// Desired is the configuration of the last reconcile, with sensitive fields redacted.
+	Desired *annotations.Ingress `json:"desired,omitempty"`
+	// Current are the LoadBalancers observed by the last reconcile.
+	Current []*lb.LoadBalancer `json:"current,omitempty"`
+}
+
+// NewInventory constructs an Inventory of the LoadBalancers owned by clusterName.
+func NewInventory(cloud aws.CloudAPI, clusterName string) Inventory {
+	return &defaultInventory{
+		cloud:       cloud,
+		clusterName: clusterName,
+		states:      make(map[types.NamespacedName]*ReconcileState),
+		now:         time.Now,
+	}
+}
+
+type defaultInventory struct {
+	cloud       aws.CloudAPI
+	clusterName string
+
+	mutex  sync.Mutex
+	states map[types.NamespacedName]*ReconcileState
+	now    func() time.Time
+}
+
+func (i *defaultInventory) BeginReconcile(ingressKey types.NamespacedName) {
+	now := i.now()
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	i.state(ingressKey).ReconcilingSince = &now
+}
+
+func (i *defaultInventory) RecordDesired(ingressKey types.NamespacedName, desired *annotations.Ingress) {
+	redacted, _ := log.Redact(desired).(*annotations.Ingress)
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	i.state(ingressKey).Desired = redacted
+}
+
+func (i *defaultInventory) RecordCurrent(ingressKey types.NamespacedName, current []*lb.LoadBalancer) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	i.state(ingressKey).Current = current
+}
+
+func (i *defaultInventory) RecordReconcile(ingressKey types.NamespacedName, err error) {
+	result := ReconcileResult{
+		Time:      i.now(),
+		Succeeded: err == nil,
+	}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	state := i.state(ingressKey)
+	state.ReconcilingSince = nil
+	state.LastReconcile = &result
+}
+
+func (i *defaultInventory) Forget(ingressKey types.NamespacedName) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	delete(i.states, ingressKey)
+}
+
+func (i *defaultInventory) State() []ReconcileState {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	states := make([]ReconcileState, 0, len(i.states))
+	for _, state := range i.states {
+		states = append(states, *state)
+	}
+	sort.Slice(states, func(a, b int) bool {
+		return states[a].Ingress < states[b].Ingress
+	})
+	return states
+}
+
+// state returns the state of ingress, the mutex must be held.
+func (i *defaultInventory) state(ingressKey types.NamespacedName) *ReconcileState {
+	state, ok := i.states[ingressKey]
+	if !ok {
+		state = &ReconcileState{Ingress: ingressKey.String()}
+		i.states[ingressKey] = state
+	}
+	return state
+}
+
+func (i *defaultInventory) lastReconcile(ingressKey types.NamespacedName) *ReconcileResult {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	state, ok := i.states[ingressKey]
+	if !ok {
+		return nil
+	}
+	return state.LastReconcile
+}
+
+func (i *defaultInventory) List(ctx context.Context) ([]LoadBalancer, error) {
+	arns, err := i.cloud.GetResourcesByFilters(map[string][]string{
+		"kubernetes.io/cluster/" + i.clusterName: {"owned"},
+	}, aws.ResourceTypeEnumELBLoadBalancer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get load balancers by tags due to %w", err)
+	}
+
+	var lbs []LoadBalancer
+	for start := 0; start < len(arns); start += describeTagsBatchSize {
+		end := start + describeTagsBatchSize
+		if end > len(arns) {
+			end = len(arns)
+		}
+		resp, err := i.cloud.DescribeELBV2TagsWithContext(ctx, &elbv2.DescribeTagsInput{
+			ResourceArns: aws.StringSlice(arns[start:end]),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe tags of load balancers due to %w", err)
+		}
+		for _, desc := range resp.TagDescriptions {
+			lb, err := i.describeLoadBalancer(ctx, aws.StringValue(desc.ResourceArn), desc.Tags)
+			if err != nil {
+				return nil, err
+			}
+			if lb != nil {
+				lbs = append(lbs, *lb)
+			}
+		}
+	}
+	sort.Slice(lbs, func(a, b int) bool {
+		if lbs[a].Ingress != lbs[b].Ingress {
+			return lbs[a].Ingress < lbs[b].Ingress
+		}
+		return lbs[a].Stack < lbs[b].Stack
+	})
+	return lbs, nil
+}
+
+// describeLoadBalancer returns the LoadBalancer with arn and its listeners, rules and targetGroups, or nil if it no longer exists.
+func (i *defaultInventory) describeLoadBalancer(ctx context.Context, arn string, tags []*elbv2.Tag) (*LoadBalancer, error) {
+	instance, err := i.cloud.GetLoadBalancerByArn(ctx, arn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get load balancer %v due to %w", arn, err)
+	}
+	if instance == nil {
+		return nil, nil
+	}
+
+	var namespace, ingressName string
+	for _, tag := range tags {
+		switch aws.StringValue(tag.Key) {
+		case generator.TagKeyNamespace:
+			namespace = aws.StringValue(tag.Value)
+		case generator.TagKeyIngressName:
+			ingressName = aws.StringValue(tag.Value)
+		}
+	}
+	ingressKey := types.NamespacedName{Namespace: namespace, Name: shard.ParentName(ingressName)}
+	lb := &LoadBalancer{
+		Arn:           arn,
+		Name:          aws.StringValue(instance.LoadBalancerName),
+		DNSName:       aws.StringValue(instance.DNSName),
+		Type:          aws.StringValue(instance.Type),
+		Scheme:        aws.StringValue(instance.Scheme),
+		Ingress:       ingressKey.String(),
+		Stack:         types.NamespacedName{Namespace: namespace, Name: ingressName}.String(),
+		LastReconcile: i.lastReconcile(ingressKey),
+	}
+	if instance.State != nil {
+		lb.State = aws.StringValue(instance.State.Code)
+	}
+
+	listeners, err := i.cloud.ListListenersByLoadBalancer(ctx, arn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list listeners of load balancer %v due to %w", arn, err)
+	}
+	tgArns := sets.NewString()
+	for _, instance := range listeners {
+		listener := Listener{
+			Arn:      aws.StringValue(instance.ListenerArn),
+			Protocol: aws.StringValue(instance.Protocol),
+			Port:     aws.Int64Value(instance.Port),
+		}
+		tgArns.Insert(targetGroupArns(instance.DefaultActions)...)
+		rules, err := i.cloud.GetRules(ctx, listener.Arn)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get rules of listener %v due to %w", listener.Arn, err)
+		}
+		for _, rule := range rules {
+			listener.Rules = append(listener.Rules, Rule{
+				Arn:        aws.StringValue(rule.RuleArn),
+				Priority:   aws.StringValue(rule.Priority),
+				Conditions: rule.Conditions,
+				Actions:    rule.Actions,
+			})
+			tgArns.Insert(targetGroupArns(rule.Actions)...)
+		}
+		lb.Listeners = append(lb.Listeners, listener)
+	}
+
+	for _, tgArn := range tgArns.List() {
+		resp, err := i.cloud.DescribeTargetHealthWithContext(ctx, &elbv2.DescribeTargetHealthInput{
+			TargetGroupArn: aws.String(tgArn),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe target health of %v due to %w", tgArn, err)
+		}
+		tg := TargetGroup{
+			Arn:     tgArn,
+			Targets: make(map[string]int),
+		}
+		for _, description := range resp.TargetHealthDescriptions {
+			if description.TargetHealth != nil {
+				tg.Targets[aws.StringValue(description.TargetHealth.State)]++
+			}
+		}
+		lb.TargetGroups = append(lb.TargetGroups, tg)
+	}
+	return lb, nil
+}
+
+// targetGroupArns returns the ARNs of targetGroups that actions forward to.
+func targetGroupArns(actions []*elbv2.Action) []string {
+	var arns []string
+	for _, action := range actions {
+		if aws.StringValue(action.Type) != elbv2.ActionTypeEnumForward {
+			continue
+		}
+		if action.TargetGroupArn != nil {
+			arns = append(arns, aws.StringValue(action.TargetGroupArn))
+		}
+		if action.ForwardConfig != nil {
+			for _, tgt := range action.ForwardConfig.TargetGroups {
+				arns = append(arns, aws.StringValue(tgt.TargetGroupArn))
+			}
+		}
+	}
+	return arns
+}
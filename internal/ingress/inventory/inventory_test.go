@@ -0,0 +1,202 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/lb"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/annotations"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func newTestInventory(cloud aws.CloudAPI, now time.Time) *defaultInventory {
+	inventory := NewInventory(cloud, "cluster").(*defaultInventory)
+	inventory.now = func() time.Time { return now }
+	return inventory
+}
+
+func Test_List(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	cloud := &mocks.CloudAPI{}
+	cloud.On("GetResourcesByFilters", map[string][]string{
+		"kubernetes.io/cluster/cluster": {"owned"},
+	}, aws.ResourceTypeEnumELBLoadBalancer).Return([]string{"lb1"}, nil)
+	cloud.On("DescribeELBV2TagsWithContext", ctx, &elbv2.DescribeTagsInput{
+		ResourceArns: aws.StringSlice([]string{"lb1"}),
+	}).Return(&elbv2.DescribeTagsOutput{
+		TagDescriptions: []*elbv2.TagDescription{
+			{
+				ResourceArn: aws.String("lb1"),
+				Tags: []*elbv2.Tag{
+					{Key: aws.String("kubernetes.io/namespace"), Value: aws.String("namespace")},
+					{Key: aws.String("kubernetes.io/ingress-name"), Value: aws.String("ingress.shard-1")},
+				},
+			},
+		},
+	}, nil)
+	cloud.On("GetLoadBalancerByArn", ctx, "lb1").Return(&elbv2.LoadBalancer{
+		LoadBalancerArn:  aws.String("lb1"),
+		LoadBalancerName: aws.String("name"),
+		DNSName:          aws.String("dns"),
+		Type:             aws.String(elbv2.LoadBalancerTypeEnumApplication),
+		Scheme:           aws.String(elbv2.LoadBalancerSchemeEnumInternal),
+		State:            &elbv2.LoadBalancerState{Code: aws.String(elbv2.LoadBalancerStateEnumActive)},
+	}, nil)
+	forward := []*elbv2.Action{{Type: aws.String(elbv2.ActionTypeEnumForward), TargetGroupArn: aws.String("tg1")}}
+	cloud.On("ListListenersByLoadBalancer", ctx, "lb1").Return([]*elbv2.Listener{
+		{ListenerArn: aws.String("ls1"), Protocol: aws.String("HTTP"), Port: aws.Int64(80), DefaultActions: forward},
+	}, nil)
+	cloud.On("GetRules", ctx, "ls1").Return([]*elbv2.Rule{
+		{RuleArn: aws.String("rule1"), Priority: aws.String("1"), Actions: forward},
+	}, nil)
+	cloud.On("DescribeTargetHealthWithContext", ctx, &elbv2.DescribeTargetHealthInput{
+		TargetGroupArn: aws.String("tg1"),
+	}).Return(&elbv2.DescribeTargetHealthOutput{
+		TargetHealthDescriptions: []*elbv2.TargetHealthDescription{
+			{TargetHealth: &elbv2.TargetHealth{State: aws.String(elbv2.TargetHealthStateEnumHealthy)}},
+			{TargetHealth: &elbv2.TargetHealth{State: aws.String(elbv2.TargetHealthStateEnumHealthy)}},
+			{TargetHealth: &elbv2.TargetHealth{State: aws.String(elbv2.TargetHealthStateEnumUnhealthy)}},
+		},
+	}, nil)
+
+	inventory := newTestInventory(cloud, now)
+	inventory.RecordReconcile(types.NamespacedName{Namespace: "namespace", Name: "ingress"}, errors.New("failed"))
+
+	lbs, err := inventory.List(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []LoadBalancer{
+		{
+			Arn:     "lb1",
+			Name:    "name",
+			DNSName: "dns",
+			Type:    elbv2.LoadBalancerTypeEnumApplication,
+			Scheme:  elbv2.LoadBalancerSchemeEnumInternal,
+			State:   elbv2.LoadBalancerStateEnumActive,
+			Ingress: "namespace/ingress",
+			Stack:   "namespace/ingress.shard-1",
+			Listeners: []Listener{
+				{
+					Arn:      "ls1",
+					Protocol: "HTTP",
+					Port:     80,
+					Rules:    []Rule{{Arn: "rule1", Priority: "1", Actions: forward}},
+				},
+			},
+			TargetGroups: []TargetGroup{
+				{Arn: "tg1", Targets: map[string]int{elbv2.TargetHealthStateEnumHealthy: 2, elbv2.TargetHealthStateEnumUnhealthy: 1}},
+			},
+			LastReconcile: &ReconcileResult{Time: now, Succeeded: false, Error: "failed"},
+		},
+	}, lbs)
+	cloud.AssertExpectations(t)
+}
+
+func Test_RecordReconcile(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	ingressKey := types.NamespacedName{Namespace: "namespace", Name: "ingress"}
+	inventory := newTestInventory(&mocks.CloudAPI{}, now)
+	assert.Nil(t, inventory.lastReconcile(ingressKey))
+
+	inventory.RecordReconcile(ingressKey, errors.New("failed"))
+	inventory.RecordReconcile(ingressKey, nil)
+	assert.Equal(t, &ReconcileResult{Time: now, Succeeded: true}, inventory.lastReconcile(ingressKey))
+
+	inventory.Forget(ingressKey)
+	assert.Nil(t, inventory.lastReconcile(ingressKey))
+}
+
+func Test_targetGroupArns(t *testing.T) {
+	assert.Equal(t, []string{"tg1", "tg2", "tg3"}, targetGroupArns([]*elbv2.Action{
+		{Type: aws.String(elbv2.ActionTypeEnumForward), TargetGroupArn: aws.String("tg1")},
+		{Type: aws.String(elbv2.ActionTypeEnumFixedResponse)},
+		{
+			Type: aws.String(elbv2.ActionTypeEnumForward),
+			ForwardConfig: &elbv2.ForwardActionConfig{
+				TargetGroups: []*elbv2.TargetGroupTuple{
+					{TargetGroupArn: aws.String("tg2")},
+					{TargetGroupArn: aws.String("tg3")},
+				},
+			},
+		},
+	}))
+}
+
+func Test_Handler(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		method        string
+		authorization string
+		expectedCode  int
+	}{
+		{name: "missing token", method: http.MethodGet, expectedCode: http.StatusUnauthorized},
+		{name: "invalid token", method: http.MethodGet, authorization: "Bearer other", expectedCode: http.StatusUnauthorized},
+		{name: "not a bearer token", method: http.MethodGet, authorization: "token", expectedCode: http.StatusUnauthorized},
+		{name: "write request", method: http.MethodPost, authorization: "Bearer token", expectedCode: http.StatusMethodNotAllowed},
+		{name: "valid token", method: http.MethodGet, authorization: "Bearer token", expectedCode: http.StatusOK},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cloud := &mocks.CloudAPI{}
+			cloud.On("GetResourcesByFilters", mock.Anything, aws.ResourceTypeEnumELBLoadBalancer).Return(nil, nil)
+
+			req := httptest.NewRequest(tc.method, HandlerPath, nil)
+			if tc.authorization != "" {
+				req.Header.Set("Authorization", tc.authorization)
+			}
+			recorder := httptest.NewRecorder()
+			NewHandler(NewInventory(cloud, "cluster"), "token").ServeHTTP(recorder, req)
+
+			assert.Equal(t, tc.expectedCode, recorder.Code)
+			if tc.expectedCode == http.StatusOK {
+				assert.JSONEq(t, `{"loadBalancers":[]}`, recorder.Body.String())
+			}
+		})
+	}
+}
+
+func Test_State(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	ingressKey := types.NamespacedName{Namespace: "namespace", Name: "ingress"}
+	inventory := newTestInventory(&mocks.CloudAPI{}, now)
+
+	inventory.BeginReconcile(ingressKey)
+	desired := annotations.NewIngressDummy()
+	inventory.RecordDesired(ingressKey, desired)
+	inventory.RecordCurrent(ingressKey, []*lb.LoadBalancer{{Arn: "lb1"}})
+	states := inventory.State()
+	assert.Equal(t, 1, len(states))
+	assert.Equal(t, &now, states[0].ReconcilingSince)
+	assert.Nil(t, states[0].LastReconcile)
+	// desired is redacted into a copy, so that later reconciles don't mutate the dumped state.
+	assert.Equal(t, desired, states[0].Desired)
+	assert.False(t, desired == states[0].Desired)
+	assert.Equal(t, []*lb.LoadBalancer{{Arn: "lb1"}}, states[0].Current)
+
+	inventory.RecordReconcile(ingressKey, nil)
+	states = inventory.State()
+	assert.Nil(t, states[0].ReconcilingSince)
+	assert.Equal(t, &ReconcileResult{Time: now, Succeeded: true}, states[0].LastReconcile)
+}
+
+func Test_StateHandler(t *testing.T) {
+	inventory := newTestInventory(&mocks.CloudAPI{}, time.Now())
+	inventory.RecordReconcile(types.NamespacedName{Namespace: "namespace", Name: "ingress"}, errors.New("failed"))
+
+	recorder := httptest.NewRecorder()
+	NewStateHandler(inventory).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, StateHandlerPath, nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), `"error": "failed"`)
+
+	recorder = httptest.NewRecorder()
+	NewStateHandler(inventory).ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, StateHandlerPath, nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+}
@@ -0,0 +1,44 @@
+package inventory
+
+import (
+	"encoding/json"
+	"net/http"
+	"runtime"
+	"time"
+)
+
+// StateHandlerPath is the path the in-memory state of reconciles is served at.
+const StateHandlerPath = "/debug/state"
+
+// NewStateHandler constructs an http.Handler serving the in-memory state of inventory as JSON, for diagnosing stuck reconciles.
+// Unlike the admin API, the state is served without calling AWS.
+func NewStateHandler(inventory Inventory) http.Handler {
+	return &stateHandler{
+		inventory: inventory,
+		now:       time.Now,
+	}
+}
+
+type stateHandler struct {
+	inventory Inventory
+	now       func() time.Time
+}
+
+func (h *stateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	payload, err := json.MarshalIndent(struct {
+		Time       time.Time        `json:"time"`
+		Goroutines int              `json:"goroutines"`
+		Ingresses  []ReconcileState `json:"ingresses"`
+	}{h.now(), runtime.NumGoroutine(), h.inventory.State()}, "", "  ")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(payload)
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package collectors
 
 import (
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -24,9 +26,12 @@ import (
 type AWSAPIController struct {
 	prometheus.Collector
 
-	awsAPIRequest *prometheus.CounterVec
-	awsAPIError   *prometheus.CounterVec
-	awsAPIRetry   *prometheus.CounterVec
+	awsAPIRequest  *prometheus.CounterVec
+	awsAPIError    *prometheus.CounterVec
+	awsAPIRetry    *prometheus.CounterVec
+	awsAPIThrottle *prometheus.CounterVec
+
+	awsAPIRequestDuration *prometheus.HistogramVec
 }
 
 // NewAWSAPIController creates a new prometheus collector for the
@@ -47,7 +52,7 @@ func NewAWSAPIController() *AWSAPIController {
 				Name:      "aws_api_errors",
 				Help:      `Cumulative number of errors from the AWS API`,
 			},
-			[]string{"service", "operation"},
+			[]string{"service", "operation", "error_code"},
 		),
 		awsAPIRetry: prometheus.NewCounterVec(
 			prometheus.CounterOpts{
@@ -57,6 +62,23 @@ func NewAWSAPIController() *AWSAPIController {
 			},
 			[]string{"service", "operation"},
 		),
+		awsAPIThrottle: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: PrometheusNamespace,
+				Name:      "aws_api_throttles",
+				Help:      `Cumulative number of requests to the AWS API that were throttled`,
+			},
+			[]string{"service", "operation"},
+		),
+		awsAPIRequestDuration: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: PrometheusNamespace,
+				Name:      "aws_api_request_duration_seconds",
+				Help:      `Duration of requests made to the AWS API, including retries`,
+				Buckets:   []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+			},
+			[]string{"service", "operation"},
+		),
 	}
 }
 
@@ -75,11 +97,23 @@ func (a *AWSAPIController) IncAPIRetryCount(l prometheus.Labels) {
 	a.awsAPIRetry.With(l).Inc()
 }
 
+// IncAPIThrottleCount increment the throttled requests counter
+func (a *AWSAPIController) IncAPIThrottleCount(l prometheus.Labels) {
+	a.awsAPIThrottle.With(l).Inc()
+}
+
+// ObserveAPIRequestDuration records the duration of a request
+func (a *AWSAPIController) ObserveAPIRequestDuration(l prometheus.Labels, duration time.Duration) {
+	a.awsAPIRequestDuration.With(l).Observe(duration.Seconds())
+}
+
 // Describe implements prometheus.Collector
 func (a AWSAPIController) Describe(ch chan<- *prometheus.Desc) {
 	a.awsAPIRequest.Describe(ch)
 	a.awsAPIError.Describe(ch)
 	a.awsAPIRetry.Describe(ch)
+	a.awsAPIThrottle.Describe(ch)
+	a.awsAPIRequestDuration.Describe(ch)
 }
 
 // Collect implements the prometheus.Collector interface.
@@ -87,4 +121,6 @@ func (a AWSAPIController) Collect(ch chan<- prometheus.Metric) {
 	a.awsAPIRequest.Collect(ch)
 	a.awsAPIError.Collect(ch)
 	a.awsAPIRetry.Collect(ch)
+	a.awsAPIThrottle.Collect(ch)
+	a.awsAPIRequestDuration.Collect(ch)
 }
@@ -0,0 +1,76 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package collectors
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// CertificateExpiry is the expiry of a certificate attached to the listeners of an ALB
+type CertificateExpiry struct {
+	Namespace      string
+	IngressName    string
+	CertificateArn string
+
+	NotAfter time.Time
+}
+
+// CertificateController defines metrics about the certificates attached to ALBs
+type CertificateController struct {
+	prometheus.Collector
+
+	expiry *prometheus.GaugeVec
+}
+
+// NewCertificateController creates a new prometheus collector for the
+// expiry of certificates attached to ALBs
+func NewCertificateController() *CertificateController {
+	return &CertificateController{
+		expiry: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: PrometheusNamespace,
+				Name:      "alb_certificate_expiry_timestamp_seconds",
+				Help:      `Time after which a certificate attached to an ALB is no longer valid, in seconds since epoch`,
+			},
+			[]string{"namespace", "ingress", "certificate_arn"},
+		),
+	}
+}
+
+// SetCertificateExpiry replaces the certificate metrics with expiries
+func (cc *CertificateController) SetCertificateExpiry(expiries []CertificateExpiry) {
+	cc.expiry.Reset()
+	for _, expiry := range expiries {
+		cc.expiry.With(prometheus.Labels{
+			"namespace":       expiry.Namespace,
+			"ingress":         expiry.IngressName,
+			"certificate_arn": expiry.CertificateArn,
+		}).Set(float64(expiry.NotAfter.Unix()))
+	}
+}
+
+// Describe implements prometheus.Collector
+func (cc CertificateController) Describe(ch chan<- *prometheus.Desc) {
+	cc.expiry.Describe(ch)
+}
+
+// Collect implements the prometheus.Collector interface.
+func (cc CertificateController) Collect(ch chan<- prometheus.Metric) {
+	cc.expiry.Collect(ch)
+}
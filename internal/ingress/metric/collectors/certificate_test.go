@@ -0,0 +1,58 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package collectors
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestCertificateController(t *testing.T) {
+	cc := NewCertificateController()
+	reg := prometheus.NewPedanticRegistry()
+	if err := reg.Register(cc); err != nil {
+		t.Errorf("registering collector failed: %s", err)
+	}
+
+	cc.SetCertificateExpiry([]CertificateExpiry{
+		{
+			Namespace:      "namespace",
+			IngressName:    "stale",
+			CertificateArn: "arn:aws:acm:us-west-2:123456789012:certificate/stale",
+			NotAfter:       time.Unix(1500000000, 0),
+		},
+	})
+	cc.SetCertificateExpiry([]CertificateExpiry{
+		{
+			Namespace:      "namespace",
+			IngressName:    "ingress",
+			CertificateArn: "arn:aws:acm:us-west-2:123456789012:certificate/cert",
+			NotAfter:       time.Unix(1600000000, 0),
+		},
+	})
+
+	want := `
+		# HELP aws_alb_ingress_controller_alb_certificate_expiry_timestamp_seconds Time after which a certificate attached to an ALB is no longer valid, in seconds since epoch
+		# TYPE aws_alb_ingress_controller_alb_certificate_expiry_timestamp_seconds gauge
+		aws_alb_ingress_controller_alb_certificate_expiry_timestamp_seconds{certificate_arn="arn:aws:acm:us-west-2:123456789012:certificate/cert",ingress="ingress",namespace="namespace"} 1.6e+09
+	`
+	if err := GatherAndCompare(cc, want, []string{"aws_alb_ingress_controller_alb_certificate_expiry_timestamp_seconds"}, reg); err != nil {
+		t.Errorf("unexpected error collecting result:\n%s", err)
+	}
+}
@@ -18,6 +18,7 @@ package collectors
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/golang/glog"
 	"github.com/prometheus/client_golang/prometheus"
@@ -31,6 +32,8 @@ type Controller struct {
 	reconcileOperation       *prometheus.CounterVec
 	reconcileOperationErrors *prometheus.CounterVec
 	managedIngresses         *prometheus.GaugeVec
+	reconcileDuration        *prometheus.HistogramVec
+	managedResources         *prometheus.GaugeVec
 
 	labels prometheus.Labels
 }
@@ -68,6 +71,23 @@ func NewController(class string) *Controller {
 			},
 			[]string{"class", "namespace"},
 		),
+		reconcileDuration: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: PrometheusNamespace,
+				Name:      "reconcile_duration_seconds",
+				Help:      `Duration of Ingress controller reconcile operations, per ingress`,
+				Buckets:   []float64{0.1, 0.5, 1, 2.5, 5, 10, 30, 60, 120, 300},
+			},
+			[]string{"class", "ingress"},
+		),
+		managedResources: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: PrometheusNamespace,
+				Name:      "managed_resources",
+				Help:      `Number of AWS resources managed for an ingress, by resource type`,
+			},
+			[]string{"class", "ingress", "resource"},
+		),
 	}
 
 	return cm
@@ -87,6 +107,25 @@ func (cm *Controller) IncReconcileErrorCount(name string) {
 	cm.reconcileOperationErrors.With(l).Inc()
 }
 
+// ObserveReconcileDuration records the duration of a reconcile of ingress
+func (cm *Controller) ObserveReconcileDuration(name string, duration time.Duration) {
+	cm.reconcileDuration.With(prometheus.Labels{
+		"class":   cm.labels["class"],
+		"ingress": name,
+	}).Observe(duration.Seconds())
+}
+
+// SetManagedResources sets the number of AWS resources managed for ingress, by resource type
+func (cm *Controller) SetManagedResources(name string, counts map[string]int) {
+	for _, resource := range ManagedResourceTypes {
+		cm.managedResources.With(prometheus.Labels{
+			"class":    cm.labels["class"],
+			"ingress":  name,
+			"resource": resource,
+		}).Set(float64(counts[resource]))
+	}
+}
+
 // SetManagedIngresses sets the number of managed ingresses
 func (cm *Controller) SetManagedIngresses(nsmap map[string]int, registry prometheus.Gatherer) {
 	l := prometheus.Labels{
@@ -138,6 +177,8 @@ func (cm Controller) Describe(ch chan<- *prometheus.Desc) {
 	cm.reconcileOperation.Describe(ch)
 	cm.reconcileOperationErrors.Describe(ch)
 	cm.managedIngresses.Describe(ch)
+	cm.reconcileDuration.Describe(ch)
+	cm.managedResources.Describe(ch)
 }
 
 // Collect implements the prometheus.Collector interface.
@@ -145,6 +186,8 @@ func (cm Controller) Collect(ch chan<- prometheus.Metric) {
 	cm.reconcileOperation.Collect(ch)
 	cm.reconcileOperationErrors.Collect(ch)
 	cm.managedIngresses.Collect(ch)
+	cm.reconcileDuration.Collect(ch)
+	cm.managedResources.Collect(ch)
 }
 
 // RemoveMetrics removes metrics for ingresses that have been removed
@@ -154,4 +197,12 @@ func (cm *Controller) RemoveMetrics(name string) {
 	}
 	l["ingress"] = name
 	cm.reconcileOperationErrors.Delete(l)
+	cm.reconcileDuration.Delete(l)
+	for _, resource := range ManagedResourceTypes {
+		cm.managedResources.Delete(prometheus.Labels{
+			"class":    l["class"],
+			"ingress":  name,
+			"resource": resource,
+		})
+	}
 }
@@ -64,6 +64,21 @@ func TestControllerCounters(t *testing.T) {
 			`,
 			metrics: []string{"aws_alb_ingress_controller_errors"},
 		},
+		{
+			name: "managed resources are set per ingress and removed along with it",
+			test: func(cm *Controller) {
+				cm.SetManagedResources("namespace/ingressName", map[string]int{"loadbalancer": 2, "targetgroup": 5})
+				cm.SetManagedResources("namespace/removed", map[string]int{"loadbalancer": 1})
+				cm.RemoveMetrics("namespace/removed")
+			},
+			want: `
+				# HELP aws_alb_ingress_controller_managed_resources Number of AWS resources managed for an ingress, by resource type
+				# TYPE aws_alb_ingress_controller_managed_resources gauge
+				aws_alb_ingress_controller_managed_resources{class="alb",ingress="namespace/ingressName",resource="loadbalancer"} 2
+				aws_alb_ingress_controller_managed_resources{class="alb",ingress="namespace/ingressName",resource="targetgroup"} 5
+			`,
+			metrics: []string{"aws_alb_ingress_controller_managed_resources"},
+		},
 	}
 
 	for _, c := range cases {
@@ -0,0 +1,111 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package collectors
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// LCU dimensions, see https://aws.amazon.com/elasticloadbalancing/pricing/
+const (
+	LCUDimensionNewConnections    = "new_connections"
+	LCUDimensionActiveConnections = "active_connections"
+	LCUDimensionProcessedBytes    = "processed_bytes"
+	LCUDimensionRuleEvaluations   = "rule_evaluations"
+)
+
+// LCUUsage is the estimated LCU consumption of a single ALB
+type LCUUsage struct {
+	Namespace    string
+	IngressName  string
+	LoadBalancer string
+
+	// Dimensions contains the estimated LCUs per LCU dimension
+	Dimensions map[string]float64
+}
+
+// LCUs returns the LCUs that are charged for the usage, which is the LCUs of the highest dimension.
+func (u LCUUsage) LCUs() float64 {
+	var lcus float64
+	for _, v := range u.Dimensions {
+		if v > lcus {
+			lcus = v
+		}
+	}
+	return lcus
+}
+
+// LCUController defines metrics about the estimated LCU consumption of ALBs
+type LCUController struct {
+	prometheus.Collector
+
+	lcuUsage *prometheus.GaugeVec
+	lcus     *prometheus.GaugeVec
+}
+
+// NewLCUController creates a new prometheus collector for the
+// LCU consumption of ALBs
+func NewLCUController() *LCUController {
+	return &LCUController{
+		lcuUsage: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: PrometheusNamespace,
+				Name:      "alb_lcu_usage",
+				Help:      `Estimated LCUs consumed by an ALB per LCU dimension`,
+			},
+			[]string{"namespace", "ingress", "load_balancer", "dimension"},
+		),
+		lcus: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: PrometheusNamespace,
+				Name:      "alb_lcus",
+				Help:      `Estimated LCUs charged for an ALB`,
+			},
+			[]string{"namespace", "ingress", "load_balancer"},
+		),
+	}
+}
+
+// SetLCUUsage replaces the LCU metrics with usages
+func (lc *LCUController) SetLCUUsage(usages []LCUUsage) {
+	lc.lcuUsage.Reset()
+	lc.lcus.Reset()
+	for _, usage := range usages {
+		l := prometheus.Labels{
+			"namespace":     usage.Namespace,
+			"ingress":       usage.IngressName,
+			"load_balancer": usage.LoadBalancer,
+		}
+		lc.lcus.With(l).Set(usage.LCUs())
+		for dimension, v := range usage.Dimensions {
+			l["dimension"] = dimension
+			lc.lcuUsage.With(l).Set(v)
+		}
+	}
+}
+
+// Describe implements prometheus.Collector
+func (lc LCUController) Describe(ch chan<- *prometheus.Desc) {
+	lc.lcuUsage.Describe(ch)
+	lc.lcus.Describe(ch)
+}
+
+// Collect implements the prometheus.Collector interface.
+func (lc LCUController) Collect(ch chan<- prometheus.Metric) {
+	lc.lcuUsage.Collect(ch)
+	lc.lcus.Collect(ch)
+}
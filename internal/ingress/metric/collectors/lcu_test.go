@@ -0,0 +1,70 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package collectors
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestLCUController(t *testing.T) {
+	lc := NewLCUController()
+	reg := prometheus.NewPedanticRegistry()
+	if err := reg.Register(lc); err != nil {
+		t.Errorf("registering collector failed: %s", err)
+	}
+
+	lc.SetLCUUsage([]LCUUsage{
+		{
+			Namespace:    "namespace",
+			IngressName:  "stale",
+			LoadBalancer: "app/lb-stale/0123456789abcdef",
+			Dimensions: map[string]float64{
+				LCUDimensionNewConnections: 1,
+			},
+		},
+	})
+	lc.SetLCUUsage([]LCUUsage{
+		{
+			Namespace:    "namespace",
+			IngressName:  "ingress",
+			LoadBalancer: "app/lb/0123456789abcdef",
+			Dimensions: map[string]float64{
+				LCUDimensionNewConnections:    0.5,
+				LCUDimensionActiveConnections: 0.25,
+				LCUDimensionProcessedBytes:    2,
+				LCUDimensionRuleEvaluations:   0,
+			},
+		},
+	})
+
+	want := `
+		# HELP aws_alb_ingress_controller_alb_lcu_usage Estimated LCUs consumed by an ALB per LCU dimension
+		# TYPE aws_alb_ingress_controller_alb_lcu_usage gauge
+		aws_alb_ingress_controller_alb_lcu_usage{dimension="active_connections",ingress="ingress",load_balancer="app/lb/0123456789abcdef",namespace="namespace"} 0.25
+		aws_alb_ingress_controller_alb_lcu_usage{dimension="new_connections",ingress="ingress",load_balancer="app/lb/0123456789abcdef",namespace="namespace"} 0.5
+		aws_alb_ingress_controller_alb_lcu_usage{dimension="processed_bytes",ingress="ingress",load_balancer="app/lb/0123456789abcdef",namespace="namespace"} 2
+		aws_alb_ingress_controller_alb_lcu_usage{dimension="rule_evaluations",ingress="ingress",load_balancer="app/lb/0123456789abcdef",namespace="namespace"} 0
+		# HELP aws_alb_ingress_controller_alb_lcus Estimated LCUs charged for an ALB
+		# TYPE aws_alb_ingress_controller_alb_lcus gauge
+		aws_alb_ingress_controller_alb_lcus{ingress="ingress",load_balancer="app/lb/0123456789abcdef",namespace="namespace"} 2
+	`
+	if err := GatherAndCompare(lc, want, []string{"aws_alb_ingress_controller_alb_lcu_usage", "aws_alb_ingress_controller_alb_lcus"}, reg); err != nil {
+		t.Errorf("unexpected error collecting result:\n%s", err)
+	}
+}
@@ -18,3 +18,6 @@ package collectors
 
 // PrometheusNamespace default metric namespace
 var PrometheusNamespace = "aws_alb_ingress_controller"
+
+// ManagedResourceTypes are the types of AWS resources counted by the managed_resources metric
+var ManagedResourceTypes = []string{"loadbalancer", "targetgroup"}
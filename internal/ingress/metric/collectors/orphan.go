@@ -0,0 +1,66 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package collectors
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Types of orphaned resources
+const (
+	OrphanTypeLoadBalancer  = "load_balancer"
+	OrphanTypeTargetGroup   = "target_group"
+	OrphanTypeSecurityGroup = "security_group"
+)
+
+// OrphanController defines metrics about AWS resources tagged for the cluster whose ingress no longer exists
+type OrphanController struct {
+	prometheus.Collector
+
+	orphans *prometheus.GaugeVec
+}
+
+// NewOrphanController creates a new prometheus collector for orphaned AWS resources
+func NewOrphanController() *OrphanController {
+	return &OrphanController{
+		orphans: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: PrometheusNamespace,
+				Name:      "orphaned_resources",
+				Help:      `Number of AWS resources tagged for the cluster whose ingress no longer exists, as of the last sweep`,
+			},
+			[]string{"resource_type"},
+		),
+	}
+}
+
+// SetOrphanedResources sets the number of orphaned resources per resource type
+func (oc *OrphanController) SetOrphanedResources(counts map[string]int) {
+	for _, resourceType := range []string{OrphanTypeLoadBalancer, OrphanTypeTargetGroup, OrphanTypeSecurityGroup} {
+		oc.orphans.With(prometheus.Labels{"resource_type": resourceType}).Set(float64(counts[resourceType]))
+	}
+}
+
+// Describe implements prometheus.Collector
+func (oc OrphanController) Describe(ch chan<- *prometheus.Desc) {
+	oc.orphans.Describe(ch)
+}
+
+// Collect implements the prometheus.Collector interface.
+func (oc OrphanController) Collect(ch chan<- prometheus.Metric) {
+	oc.orphans.Collect(ch)
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package collectors
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestOrphanController(t *testing.T) {
+	oc := NewOrphanController()
+	reg := prometheus.NewPedanticRegistry()
+	if err := reg.Register(oc); err != nil {
+		t.Errorf("registering collector failed: %s", err)
+	}
+
+	oc.SetOrphanedResources(map[string]int{OrphanTypeLoadBalancer: 2, OrphanTypeSecurityGroup: 1})
+	oc.SetOrphanedResources(map[string]int{OrphanTypeTargetGroup: 3})
+
+	want := `
+		# HELP aws_alb_ingress_controller_orphaned_resources Number of AWS resources tagged for the cluster whose ingress no longer exists, as of the last sweep
+		# TYPE aws_alb_ingress_controller_orphaned_resources gauge
+		aws_alb_ingress_controller_orphaned_resources{resource_type="load_balancer"} 0
+		aws_alb_ingress_controller_orphaned_resources{resource_type="security_group"} 0
+		aws_alb_ingress_controller_orphaned_resources{resource_type="target_group"} 3
+	`
+	if err := GatherAndCompare(oc, want, []string{"aws_alb_ingress_controller_orphaned_resources"}, reg); err != nil {
+		t.Errorf("unexpected error collecting result:\n%s", err)
+	}
+}
@@ -36,7 +36,7 @@ func GatherAndCompare(c prometheus.Collector, expected string, metricNames []str
 
 	metrics, err := reg.Gather()
 	if err != nil {
-		return fmt.Errorf("gathering metrics failed: %s", err)
+		return fmt.Errorf("gathering metrics failed: %w", err)
 	}
 	if metricNames != nil {
 		metrics = filterMetrics(metrics, metricNames)
@@ -44,7 +44,7 @@ func GatherAndCompare(c prometheus.Collector, expected string, metricNames []str
 	var tp expfmt.TextParser
 	expectedMetrics, err := tp.TextToMetricFamilies(bytes.NewReader([]byte(expected)))
 	if err != nil {
-		return fmt.Errorf("parsing expected metrics failed: %s", err)
+		return fmt.Errorf("parsing expected metrics failed: %w", err)
 	}
 
 	if !reflect.DeepEqual(metrics, normalizeMetricFamilies(expectedMetrics)) {
@@ -53,7 +53,7 @@ func GatherAndCompare(c prometheus.Collector, expected string, metricNames []str
 		enc := expfmt.NewEncoder(&buf1, expfmt.FmtText)
 		for _, mf := range metrics {
 			if err := enc.Encode(mf); err != nil {
-				return fmt.Errorf("encoding result failed: %s", err)
+				return fmt.Errorf("encoding result failed: %w", err)
 			}
 		}
 		// Encode normalized expected metrics again to generate them in the same ordering
@@ -62,7 +62,7 @@ func GatherAndCompare(c prometheus.Collector, expected string, metricNames []str
 		enc = expfmt.NewEncoder(&buf2, expfmt.FmtText)
 		for _, mf := range normalizeMetricFamilies(expectedMetrics) {
 			if err := enc.Encode(mf); err != nil {
-				return fmt.Errorf("encoding result failed: %s", err)
+				return fmt.Errorf("encoding result failed: %w", err)
 			}
 		}
 
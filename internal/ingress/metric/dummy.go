@@ -17,7 +17,11 @@ limitations under the License.
 package metric
 
 import (
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/metric/collectors"
 )
 
 // DummyCollector dummy implementation for mocks in tests
@@ -32,6 +36,12 @@ func (dc DummyCollector) IncReconcileErrorCount(string) {}
 // SetManagedIngresses ...
 func (dc DummyCollector) SetManagedIngresses(map[string]int) {}
 
+// ObserveReconcileDuration ...
+func (dc DummyCollector) ObserveReconcileDuration(string, time.Duration) {}
+
+// SetManagedResources ...
+func (dc DummyCollector) SetManagedResources(string, map[string]int) {}
+
 // IncAPIRequestCount ...
 func (dc DummyCollector) IncAPIRequestCount(prometheus.Labels) {}
 
@@ -41,6 +51,21 @@ func (dc DummyCollector) IncAPIErrorCount(prometheus.Labels) {}
 // IncAPIRetryCount ...
 func (dc DummyCollector) IncAPIRetryCount(prometheus.Labels) {}
 
+// IncAPIThrottleCount ...
+func (dc DummyCollector) IncAPIThrottleCount(prometheus.Labels) {}
+
+// ObserveAPIRequestDuration ...
+func (dc DummyCollector) ObserveAPIRequestDuration(prometheus.Labels, time.Duration) {}
+
+// SetLCUUsage ...
+func (dc DummyCollector) SetLCUUsage([]collectors.LCUUsage) {}
+
+// SetCertificateExpiry ...
+func (dc DummyCollector) SetCertificateExpiry([]collectors.CertificateExpiry) {}
+
+// SetOrphanedResources ...
+func (dc DummyCollector) SetOrphanedResources(map[string]int) {}
+
 // Start ...
 func (dc DummyCollector) Start() {}
 
@@ -17,6 +17,8 @@ limitations under the License.
 package metric
 
 import (
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/metric/collectors"
@@ -27,10 +29,18 @@ type Collector interface {
 	IncReconcileCount()
 	IncReconcileErrorCount(string)
 	SetManagedIngresses(map[string]int)
+	ObserveReconcileDuration(string, time.Duration)
+	SetManagedResources(string, map[string]int)
 
 	IncAPIRequestCount(prometheus.Labels)
 	IncAPIErrorCount(prometheus.Labels)
 	IncAPIRetryCount(prometheus.Labels)
+	IncAPIThrottleCount(prometheus.Labels)
+	ObserveAPIRequestDuration(prometheus.Labels, time.Duration)
+
+	SetLCUUsage([]collectors.LCUUsage)
+	SetCertificateExpiry([]collectors.CertificateExpiry)
+	SetOrphanedResources(map[string]int)
 
 	RemoveMetrics(string)
 
@@ -41,6 +51,9 @@ type Collector interface {
 type collector struct {
 	ingressController *collectors.Controller
 	awsAPIController  *collectors.AWSAPIController
+	lcuController     *collectors.LCUController
+	certController    *collectors.CertificateController
+	orphanController  *collectors.OrphanController
 
 	registry *prometheus.Registry
 }
@@ -49,10 +62,16 @@ type collector struct {
 func NewCollector(registry *prometheus.Registry, ingressClass string) (Collector, error) {
 	ic := collectors.NewController(ingressClass)
 	ac := collectors.NewAWSAPIController()
+	lc := collectors.NewLCUController()
+	cc := collectors.NewCertificateController()
+	oc := collectors.NewOrphanController()
 
 	return Collector(&collector{
 		ingressController: ic,
 		awsAPIController:  ac,
+		lcuController:     lc,
+		certController:    cc,
+		orphanController:  oc,
 		registry:          registry,
 	}), nil
 }
@@ -69,6 +88,14 @@ func (c *collector) SetManagedIngresses(i map[string]int) {
 	c.ingressController.SetManagedIngresses(i, c.registry)
 }
 
+func (c *collector) ObserveReconcileDuration(s string, d time.Duration) {
+	c.ingressController.ObserveReconcileDuration(s, d)
+}
+
+func (c *collector) SetManagedResources(s string, counts map[string]int) {
+	c.ingressController.SetManagedResources(s, counts)
+}
+
 func (c *collector) IncAPIRequestCount(l prometheus.Labels) {
 	c.awsAPIController.IncAPIRequestCount(l)
 }
@@ -81,6 +108,26 @@ func (c *collector) IncAPIRetryCount(l prometheus.Labels) {
 	c.awsAPIController.IncAPIRetryCount(l)
 }
 
+func (c *collector) IncAPIThrottleCount(l prometheus.Labels) {
+	c.awsAPIController.IncAPIThrottleCount(l)
+}
+
+func (c *collector) ObserveAPIRequestDuration(l prometheus.Labels, d time.Duration) {
+	c.awsAPIController.ObserveAPIRequestDuration(l, d)
+}
+
+func (c *collector) SetLCUUsage(usages []collectors.LCUUsage) {
+	c.lcuController.SetLCUUsage(usages)
+}
+
+func (c *collector) SetCertificateExpiry(expiries []collectors.CertificateExpiry) {
+	c.certController.SetCertificateExpiry(expiries)
+}
+
+func (c *collector) SetOrphanedResources(counts map[string]int) {
+	c.orphanController.SetOrphanedResources(counts)
+}
+
 func (c *collector) RemoveMetrics(ingressName string) {
 	c.ingressController.RemoveMetrics(ingressName)
 }
@@ -88,9 +135,15 @@ func (c *collector) RemoveMetrics(ingressName string) {
 func (c *collector) Start() {
 	c.registry.MustRegister(c.ingressController)
 	c.registry.MustRegister(c.awsAPIController)
+	c.registry.MustRegister(c.lcuController)
+	c.registry.MustRegister(c.certController)
+	c.registry.MustRegister(c.orphanController)
 }
 
 func (c *collector) Stop() {
 	c.registry.Unregister(c.ingressController)
 	c.registry.Unregister(c.awsAPIController)
+	c.registry.Unregister(c.lcuController)
+	c.registry.Unregister(c.certController)
+	c.registry.Unregister(c.orphanController)
 }
@@ -0,0 +1,230 @@
+package secretref
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// Scheme prefixes annotation values that reference a key of a k8s secret, e.g. secretref://namespace/name/key
+const Scheme = "secretref://"
+
+const FieldSecretRef = "secretRef"
+
+// Reference identifies a key of a k8s secret.
+type Reference struct {
+	Namespace string
+	Name      string
+	Key       string
+}
+
+// SecretKey returns the key of referenced secret.
+func (r Reference) SecretKey() types.NamespacedName {
+	return types.NamespacedName{
+		Namespace: r.Namespace,
+		Name:      r.Name,
+	}
+}
+
+func (r Reference) String() string {
+	return Scheme + r.Namespace + "/" + r.Name + "/" + r.Key
+}
+
+// IsReference returns whether an annotation value is a secret reference.
+func IsReference(value string) bool {
+	return strings.HasPrefix(value, Scheme)
+}
+
+// Parse parses an annotation value in form of secretref://namespace/name/key.
+func Parse(value string) (Reference, error) {
+	if !IsReference(value) {
+		return Reference{}, errors.Errorf("%v is not a secret reference", value)
+	}
+	parts := strings.Split(strings.TrimPrefix(value, Scheme), "/")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return Reference{}, errors.Errorf("invalid secret reference %v, expected format is %vnamespace/name/key", value, Scheme)
+	}
+	return Reference{
+		Namespace: parts[0],
+		Name:      parts[1],
+		Key:       parts[2],
+	}, nil
+}
+
+// Resolver resolves annotation values that reference k8s secrets, so sensitive configuration don't need to live in annotations.
+type Resolver interface {
+	// Init setup index & watch functionality, so that objects referencing a secret are reconciled when the secret changes.
+	Init(controller controller.Controller, ingressChan chan<- event.GenericEvent, serviceChan chan<- event.GenericEvent) error
+
+	// Resolve returns a copy of annotations of an object in namespace, with secret references replaced by the referenced secret value.
+	Resolve(ctx context.Context, namespace string, annotations map[string]string) (map[string]string, error)
+}
+
+// NewResolver constructs new secret reference Resolver
+func NewResolver(cache cache.Cache) Resolver {
+	return &defaultResolver{
+		cache: cache,
+	}
+}
+
+type defaultResolver struct {
+	cache cache.Cache
+}
+
+func (r *defaultResolver) Init(controller controller.Controller, ingressChan chan<- event.GenericEvent, serviceChan chan<- event.GenericEvent) error {
+	if err := r.cache.IndexField(&extensions.Ingress{}, FieldSecretRef, func(obj runtime.Object) []string {
+		ingress := obj.(*extensions.Ingress)
+		return buildSecretRefIndex(ingress.Namespace, ingress.Annotations)
+	}); err != nil {
+		return err
+	}
+	if err := r.cache.IndexField(&corev1.Service{}, FieldSecretRef, func(obj runtime.Object) []string {
+		service := obj.(*corev1.Service)
+		return buildSecretRefIndex(service.Namespace, service.Annotations)
+	}); err != nil {
+		return err
+	}
+
+	if err := controller.Watch(&source.Kind{Type: &corev1.Secret{}}, &EnqueueRequestsForSecretEvent{
+		IngressChan: ingressChan,
+		ServiceChan: serviceChan,
+		Cache:       r.cache,
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *defaultResolver) Resolve(ctx context.Context, namespace string, annotations map[string]string) (map[string]string, error) {
+	if annotations == nil {
+		return nil, nil
+	}
+	resolved := make(map[string]string, len(annotations))
+	for name, value := range annotations {
+		resolvedValue, err := resolveValue(value, func(ref string) (string, error) {
+			return r.resolveReference(ctx, namespace, ref)
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to resolve annotation %v", name)
+		}
+		resolved[name] = resolvedValue
+	}
+	return resolved, nil
+}
+
+// resolveReference returns the value of the secret key referenced by value.
+func (r *defaultResolver) resolveReference(ctx context.Context, namespace string, value string) (string, error) {
+	ref, err := Parse(value)
+	if err != nil {
+		return "", err
+	}
+	// secrets can only be referenced from the same namespace, otherwise any user able to create ingresses can read every secret.
+	if ref.Namespace != namespace {
+		return "", errors.Errorf("secret reference %v must be in namespace %v", ref, namespace)
+	}
+	secret := corev1.Secret{}
+	if err := r.cache.Get(ctx, ref.SecretKey(), &secret); err != nil {
+		return "", errors.Wrapf(err, "failed to load k8s secret: %v", ref.SecretKey())
+	}
+	data, ok := secret.Data[ref.Key]
+	if !ok {
+		return "", errors.Errorf("key %v not found in k8s secret: %v", ref.Key, ref.SecretKey())
+	}
+	return string(data), nil
+}
+
+// resolveValue returns value with secret references replaced by resolve. A reference is either the whole value,
+// or a string within a JSON value, e.g. the client secret of an OIDC configuration within an `actions.${action-name}` annotation.
+func resolveValue(value string, resolve func(ref string) (string, error)) (string, error) {
+	if IsReference(value) {
+		return resolve(value)
+	}
+	doc, ok := parseJSONWithReferences(value)
+	if !ok {
+		return value, nil
+	}
+	doc, err := replaceReferences(doc, resolve)
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(doc)
+	if err != nil {
+		return "", err
+	}
+	return string(payload), nil
+}
+
+// parseJSONWithReferences parses value if it's a JSON value that contains secret references.
+func parseJSONWithReferences(value string) (interface{}, bool) {
+	if !strings.Contains(value, Scheme) {
+		return nil, false
+	}
+	decoder := json.NewDecoder(strings.NewReader(value))
+	// numbers are kept as they're written, since they're marshaled back once references are replaced.
+	decoder.UseNumber()
+	var doc interface{}
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, false
+	}
+	return doc, true
+}
+
+// replaceReferences replaces the strings within doc that are secret references.
+func replaceReferences(doc interface{}, replace func(ref string) (string, error)) (interface{}, error) {
+	switch v := doc.(type) {
+	case string:
+		if !IsReference(v) {
+			return v, nil
+		}
+		return replace(v)
+	case []interface{}:
+		for i := range v {
+			item, err := replaceReferences(v[i], replace)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = item
+		}
+		return v, nil
+	case map[string]interface{}:
+		for key := range v {
+			item, err := replaceReferences(v[key], replace)
+			if err != nil {
+				return nil, err
+			}
+			v[key] = item
+		}
+		return v, nil
+	}
+	return doc, nil
+}
+
+// buildSecretRefIndex returns the keys of secrets referenced by annotations of an object in namespace.
+func buildSecretRefIndex(namespace string, annotations map[string]string) []string {
+	secretKeys := sets.NewString()
+	for _, value := range annotations {
+		// references are collected by replacing them with themselves.
+		_, _ = resolveValue(value, func(value string) (string, error) {
+			if ref, err := Parse(value); err == nil && ref.Namespace == namespace {
+				secretKeys.Insert(ref.SecretKey().String())
+			}
+			return value, nil
+		})
+	}
+	if secretKeys.Len() == 0 {
+		return nil
+	}
+	return secretKeys.List()
+}
@@ -0,0 +1,214 @@
+package secretref
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	mock_cache "github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks/controller-runtime/cache"
+	mock_controller "github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks/controller-runtime/controller"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+func TestParse(t *testing.T) {
+	for _, tc := range []struct {
+		value       string
+		expectedRef Reference
+		expectedErr string
+	}{
+		{
+			value:       "secretref://namespace/secret/clientSecret",
+			expectedRef: Reference{Namespace: "namespace", Name: "secret", Key: "clientSecret"},
+		},
+		{
+			value:       "plain value",
+			expectedErr: "plain value is not a secret reference",
+		},
+		{
+			value:       "secretref://namespace/secret",
+			expectedErr: "invalid secret reference secretref://namespace/secret, expected format is secretref://namespace/name/key",
+		},
+		{
+			value:       "secretref://namespace//key",
+			expectedErr: "invalid secret reference secretref://namespace//key, expected format is secretref://namespace/name/key",
+		},
+	} {
+		t.Run(tc.value, func(t *testing.T) {
+			ref, err := Parse(tc.value)
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedRef, ref)
+				assert.Equal(t, tc.value, ref.String())
+			}
+		})
+	}
+}
+
+func TestDefaultResolver_Init(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ingressChan := make(chan event.GenericEvent)
+	serviceChan := make(chan event.GenericEvent)
+	mockCache := mock_cache.NewMockCache(ctrl)
+	mockCache.EXPECT().IndexField(&extensions.Ingress{}, FieldSecretRef, gomock.Any())
+	mockCache.EXPECT().IndexField(&corev1.Service{}, FieldSecretRef, gomock.Any())
+	mockController := mock_controller.NewMockController(ctrl)
+	mockController.EXPECT().Watch(&source.Kind{Type: &corev1.Secret{}}, &EnqueueRequestsForSecretEvent{
+		IngressChan: ingressChan,
+		ServiceChan: serviceChan,
+		Cache:       mockCache,
+	})
+
+	resolver := NewResolver(mockCache)
+	assert.NoError(t, resolver.Init(mockController, ingressChan, serviceChan))
+}
+
+func TestDefaultResolver_Resolve(t *testing.T) {
+	secret := corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "namespace",
+			Name:      "secret",
+		},
+		Data: map[string][]byte{
+			"oidc":  []byte("{\"SecretName\": \"oidc-secret\"}"),
+			"token": []byte("s3cr3t"),
+		},
+	}
+	for _, tc := range []struct {
+		name                string
+		annotations         map[string]string
+		secret              *corev1.Secret
+		getErr              error
+		expectedAnnotations map[string]string
+		expectedErr         string
+	}{
+		{
+			name:                "no annotations",
+			annotations:         nil,
+			expectedAnnotations: nil,
+		},
+		{
+			name: "annotations without secret references",
+			annotations: map[string]string{
+				"alb.ingress.kubernetes.io/auth-type": "oidc",
+			},
+			expectedAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/auth-type": "oidc",
+			},
+		},
+		{
+			name: "annotations with secret references",
+			annotations: map[string]string{
+				"alb.ingress.kubernetes.io/auth-type":     "oidc",
+				"alb.ingress.kubernetes.io/auth-idp-oidc": "secretref://namespace/secret/oidc",
+			},
+			secret: &secret,
+			expectedAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/auth-type":     "oidc",
+				"alb.ingress.kubernetes.io/auth-idp-oidc": "{\"SecretName\": \"oidc-secret\"}",
+			},
+		},
+		{
+			name: "annotations with secret references within JSON",
+			annotations: map[string]string{
+				"alb.ingress.kubernetes.io/actions.response": `{"Type":"fixed-response","FixedResponseConfig":{"StatusCode":"200","MessageBody":"secretref://namespace/secret/token"}}`,
+			},
+			secret: &secret,
+			expectedAnnotations: map[string]string{
+				"alb.ingress.kubernetes.io/actions.response": `{"FixedResponseConfig":{"MessageBody":"s3cr3t","StatusCode":"200"},"Type":"fixed-response"}`,
+			},
+		},
+		{
+			name: "secret key doesn't exist",
+			annotations: map[string]string{
+				"alb.ingress.kubernetes.io/auth-idp-oidc": "secretref://namespace/secret/missing",
+			},
+			secret:      &secret,
+			expectedErr: "failed to resolve annotation alb.ingress.kubernetes.io/auth-idp-oidc: key missing not found in k8s secret: namespace/secret",
+		},
+		{
+			name: "secret cannot be loaded",
+			annotations: map[string]string{
+				"alb.ingress.kubernetes.io/auth-idp-oidc": "secretref://namespace/secret/oidc",
+			},
+			getErr:      errors.New("secret not found"),
+			expectedErr: "failed to resolve annotation alb.ingress.kubernetes.io/auth-idp-oidc: failed to load k8s secret: namespace/secret: secret not found",
+		},
+		{
+			name: "secret in other namespace",
+			annotations: map[string]string{
+				"alb.ingress.kubernetes.io/auth-idp-oidc": "secretref://other/secret/oidc",
+			},
+			expectedErr: "failed to resolve annotation alb.ingress.kubernetes.io/auth-idp-oidc: secret reference secretref://other/secret/oidc must be in namespace namespace",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockCache := mock_cache.NewMockCache(ctrl)
+			secretKey := types.NamespacedName{Namespace: "namespace", Name: "secret"}
+			if tc.secret != nil {
+				mockCache.EXPECT().Get(gomock.Any(), secretKey, gomock.Any()).SetArg(2, *tc.secret)
+			}
+			if tc.getErr != nil {
+				mockCache.EXPECT().Get(gomock.Any(), secretKey, gomock.Any()).Return(tc.getErr)
+			}
+
+			resolver := NewResolver(mockCache)
+			annotations, err := resolver.Resolve(context.Background(), "namespace", tc.annotations)
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedAnnotations, annotations)
+			}
+		})
+	}
+}
+
+func TestBuildSecretRefIndex(t *testing.T) {
+	for _, tc := range []struct {
+		name            string
+		annotations     map[string]string
+		expectedIndexes []string
+	}{
+		{
+			name:            "no secret references",
+			annotations:     map[string]string{"alb.ingress.kubernetes.io/auth-type": "oidc"},
+			expectedIndexes: nil,
+		},
+		{
+			name: "secret references",
+			annotations: map[string]string{
+				"alb.ingress.kubernetes.io/auth-idp-oidc":    "secretref://namespace/secret-b/oidc",
+				"alb.ingress.kubernetes.io/auth-scope":       "secretref://namespace/secret-a/scope",
+				"alb.ingress.kubernetes.io/auth-type":        "secretref://namespace/secret-a/type",
+				"alb.ingress.kubernetes.io/auth-idp-cognito": "secretref://other/secret/cognito",
+			},
+			expectedIndexes: []string{"namespace/secret-a", "namespace/secret-b"},
+		},
+		{
+			name: "secret references within JSON",
+			annotations: map[string]string{
+				"alb.ingress.kubernetes.io/actions.response": `{"Type":"fixed-response","FixedResponseConfig":{"MessageBody":"secretref://namespace/secret-a/body"}}`,
+				"alb.ingress.kubernetes.io/conditions.rule":  `[{"Field":"http-header","HttpHeaderConfig":{"Values":["secretref://namespace/secret-b/token"]}}]`,
+			},
+			expectedIndexes: []string{"namespace/secret-a", "namespace/secret-b"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedIndexes, buildSecretRefIndex("namespace", tc.annotations))
+		})
+	}
+}
@@ -0,0 +1,229 @@
+// Package shard derives the ingresses that are reconciled as separate ALBs from a single ingress.
+//
+// Very large ingresses are split across multiple ALBs: rules are grouped by host and packed in order
+// into shards, so that no shard exceeds the configured number of rules or TLS hosts. Shard 0 keeps the
+// original ingress name, which means an ingress that fits into a single ALB is reconciled exactly as if
+// sharding was disabled.
+//
+// An ingress with failover enabled gets a secondary ALB, which is reconciled from a copy of the ingress
+// named by FailoverName. Likewise, the green stack of an ingress using blue/green deployments is reconciled
+// from a copy of the ingress named by GreenName.
+//
+// These derived names are valid ingress names as well, so the controller doesn't serve user ingresses whose
+// names are reserved by IsReserved: their LoadBalancers couldn't be told apart from those derived from another ingress.
+package shard
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// nameSeparator separates the ingress name from the shard index.
+const nameSeparator = ".shard-"
+
+// failoverSuffix is appended to the ingress name for the secondary ALB of an ingress with failover enabled.
+const failoverSuffix = ".failover"
+
+// greenSuffix is appended to the ingress name for the green stack of an ingress using blue/green deployments.
+const greenSuffix = ".green"
+
+// Name returns the name used for the idx-th shard of ingress.
+func Name(ingressName string, idx int) string {
+	if idx == 0 {
+		return ingressName
+	}
+	return fmt.Sprintf("%s%s%d", ingressName, nameSeparator, idx)
+}
+
+// Key returns the namespaced name used for the idx-th shard of ingress.
+func Key(ingressKey types.NamespacedName, idx int) types.NamespacedName {
+	return types.NamespacedName{
+		Namespace: ingressKey.Namespace,
+		Name:      Name(ingressKey.Name, idx),
+	}
+}
+
+// Parse returns the ingress name and shard index for a name generated by Name.
+func Parse(name string) (string, int) {
+	i := strings.LastIndex(name, nameSeparator)
+	if i < 0 {
+		return name, 0
+	}
+	idx, err := strconv.Atoi(name[i+len(nameSeparator):])
+	if err != nil || idx < 1 {
+		return name, 0
+	}
+	return name[:i], idx
+}
+
+// FailoverName returns the name used for the secondary ALB of ingress.
+func FailoverName(ingressName string) string {
+	return ingressName + failoverSuffix
+}
+
+// FailoverKey returns the namespaced name used for the secondary ALB of ingress.
+func FailoverKey(ingressKey types.NamespacedName) types.NamespacedName {
+	return types.NamespacedName{
+		Namespace: ingressKey.Namespace,
+		Name:      FailoverName(ingressKey.Name),
+	}
+}
+
+// IsFailover returns whether name is generated by FailoverName.
+func IsFailover(name string) bool {
+	return strings.HasSuffix(name, failoverSuffix)
+}
+
+// GreenName returns the name used for the green stack of ingress.
+func GreenName(ingressName string) string {
+	return ingressName + greenSuffix
+}
+
+// GreenKey returns the namespaced name used for the green stack of ingress.
+func GreenKey(ingressKey types.NamespacedName) types.NamespacedName {
+	return types.NamespacedName{
+		Namespace: ingressKey.Namespace,
+		Name:      GreenName(ingressKey.Name),
+	}
+}
+
+// IsGreen returns whether name is generated by GreenName.
+func IsGreen(name string) bool {
+	return strings.HasSuffix(name, greenSuffix)
+}
+
+// IsReserved returns whether name could have been generated by Name, FailoverName or GreenName, ingresses with
+// such names are rejected so that they never collide with the derived ingresses of another one.
+func IsReserved(name string) bool {
+	if _, idx := Parse(name); idx > 0 {
+		return true
+	}
+	return IsFailover(name) || IsGreen(name)
+}
+
+// ParentKey returns the store key of the ingress that owns the shard, secondary ALB or green stack with specified store key.
+func ParentKey(key string) string {
+	parts := strings.SplitN(key, "/", 2)
+	if len(parts) != 2 {
+		return key
+	}
+	return parts[0] + "/" + ParentName(parts[1])
+}
+
+// ParentName returns the name of the ingress that owns the shard, secondary ALB or green stack with specified name.
+func ParentName(name string) string {
+	name = strings.TrimSuffix(name, failoverSuffix)
+	name = strings.TrimSuffix(name, greenSuffix)
+	name, _ = Parse(name)
+	return name
+}
+
+// Limits are the per-ALB limits used to split an ingress.
+type Limits struct {
+	MaxRules        int
+	MaxCertificates int
+}
+
+type hostGroup struct {
+	host     string
+	rules    []extensions.IngressRule
+	numRules int
+	tls      bool
+}
+
+// Split partitions the rules of ingress into shards that fit into limits.
+// Rules of the same host are always placed into the same shard, a host exceeding the limits on its own gets a dedicated shard.
+func Split(ingress *extensions.Ingress, limits Limits) []*extensions.Ingress {
+	groups := groupRulesByHost(ingress)
+	if len(groups) == 0 {
+		return []*extensions.Ingress{ingress}
+	}
+
+	var packed [][]*hostGroup
+	var current []*hostGroup
+	numRules, numCerts := 0, 0
+	for _, group := range groups {
+		certs := 0
+		if group.tls {
+			certs = 1
+		}
+		if len(current) != 0 && (numRules+group.numRules > limits.MaxRules || numCerts+certs > limits.MaxCertificates) {
+			packed = append(packed, current)
+			current, numRules, numCerts = nil, 0, 0
+		}
+		current = append(current, group)
+		numRules += group.numRules
+		numCerts += certs
+	}
+	packed = append(packed, current)
+
+	if len(packed) == 1 {
+		return []*extensions.Ingress{ingress}
+	}
+	shards := make([]*extensions.Ingress, 0, len(packed))
+	for idx, hostGroups := range packed {
+		shards = append(shards, buildShard(ingress, idx, hostGroups))
+	}
+	return shards
+}
+
+func groupRulesByHost(ingress *extensions.Ingress) []*hostGroup {
+	tlsHosts := make(map[string]bool)
+	for _, tls := range ingress.Spec.TLS {
+		for _, host := range tls.Hosts {
+			tlsHosts[host] = true
+		}
+	}
+
+	var groups []*hostGroup
+	groupByHost := make(map[string]*hostGroup)
+	for _, rule := range ingress.Spec.Rules {
+		group, ok := groupByHost[rule.Host]
+		if !ok {
+			group = &hostGroup{host: rule.Host, tls: tlsHosts[rule.Host]}
+			groupByHost[rule.Host] = group
+			groups = append(groups, group)
+		}
+		group.rules = append(group.rules, rule)
+		if rule.HTTP != nil {
+			group.numRules += len(rule.HTTP.Paths)
+		}
+	}
+	return groups
+}
+
+func buildShard(ingress *extensions.Ingress, idx int, hostGroups []*hostGroup) *extensions.Ingress {
+	hosts := make(map[string]bool)
+	shard := ingress.DeepCopy()
+	shard.Name = Name(ingress.Name, idx)
+	shard.Spec.Rules = nil
+	for _, group := range hostGroups {
+		hosts[group.host] = true
+		shard.Spec.Rules = append(shard.Spec.Rules, group.rules...)
+	}
+
+	shard.Spec.TLS = nil
+	for _, tls := range ingress.Spec.TLS {
+		if len(tls.Hosts) == 0 {
+			shard.Spec.TLS = append(shard.Spec.TLS, tls)
+			continue
+		}
+		var shardHosts []string
+		for _, host := range tls.Hosts {
+			if hosts[host] {
+				shardHosts = append(shardHosts, host)
+			}
+		}
+		if len(shardHosts) != 0 {
+			shard.Spec.TLS = append(shard.Spec.TLS, extensions.IngressTLS{
+				Hosts:      shardHosts,
+				SecretName: tls.SecretName,
+			})
+		}
+	}
+	return shard
+}
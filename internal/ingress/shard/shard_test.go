@@ -0,0 +1,159 @@
+package shard
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func hostRule(host string, paths ...string) extensions.IngressRule {
+	rule := extensions.IngressRule{
+		Host: host,
+		IngressRuleValue: extensions.IngressRuleValue{
+			HTTP: &extensions.HTTPIngressRuleValue{},
+		},
+	}
+	for _, path := range paths {
+		rule.HTTP.Paths = append(rule.HTTP.Paths, extensions.HTTPIngressPath{
+			Path: path,
+			Backend: extensions.IngressBackend{
+				ServiceName: "svc",
+			},
+		})
+	}
+	return rule
+}
+
+func TestName(t *testing.T) {
+	for _, tc := range []struct {
+		Name         string
+		Idx          int
+		ExpectedName string
+	}{
+		{Name: "ingress", Idx: 0, ExpectedName: "ingress"},
+		{Name: "ingress", Idx: 3, ExpectedName: "ingress.shard-3"},
+	} {
+		name := Name(tc.Name, tc.Idx)
+		assert.Equal(t, tc.ExpectedName, name)
+
+		parsedName, parsedIdx := Parse(name)
+		assert.Equal(t, tc.Name, parsedName)
+		assert.Equal(t, tc.Idx, parsedIdx)
+	}
+}
+
+func TestParse(t *testing.T) {
+	for _, tc := range []struct {
+		Name         string
+		ExpectedName string
+		ExpectedIdx  int
+	}{
+		{Name: "ingress", ExpectedName: "ingress", ExpectedIdx: 0},
+		{Name: "ingress.shard-2", ExpectedName: "ingress", ExpectedIdx: 2},
+		{Name: "ingress.shard-x", ExpectedName: "ingress.shard-x", ExpectedIdx: 0},
+		{Name: "ingress.shard-0", ExpectedName: "ingress.shard-0", ExpectedIdx: 0},
+	} {
+		name, idx := Parse(tc.Name)
+		assert.Equal(t, tc.ExpectedName, name)
+		assert.Equal(t, tc.ExpectedIdx, idx)
+	}
+}
+
+func TestKeys(t *testing.T) {
+	key := Key(types.NamespacedName{Namespace: "ns", Name: "ingress"}, 2)
+	assert.Equal(t, "ns/ingress.shard-2", key.String())
+	assert.Equal(t, "ns/ingress", ParentKey(key.String()))
+	assert.Equal(t, "ns/ingress", ParentKey("ns/ingress"))
+
+	failoverKey := FailoverKey(types.NamespacedName{Namespace: "ns", Name: "ingress"})
+	assert.Equal(t, "ns/ingress.failover", failoverKey.String())
+	assert.True(t, IsFailover(failoverKey.Name))
+	assert.False(t, IsFailover("ingress"))
+	assert.Equal(t, "ns/ingress", ParentKey(failoverKey.String()))
+
+	greenKey := GreenKey(types.NamespacedName{Namespace: "ns", Name: "ingress"})
+	assert.Equal(t, "ns/ingress.green", greenKey.String())
+	assert.True(t, IsGreen(greenKey.Name))
+	assert.False(t, IsGreen("ingress"))
+	assert.Equal(t, "ns/ingress", ParentKey(greenKey.String()))
+}
+
+func TestIsReserved(t *testing.T) {
+	for _, tc := range []struct {
+		Name     string
+		Expected bool
+	}{
+		{Name: "web", Expected: false},
+		{Name: "web.example", Expected: false},
+		{Name: "web.shard-x", Expected: false},
+		{Name: "web.shard-0", Expected: false},
+		{Name: "web.shard-1", Expected: true},
+		{Name: "web.failover", Expected: true},
+		{Name: "web.green", Expected: true},
+		{Name: "web.green.failover", Expected: true},
+	} {
+		assert.Equal(t, tc.Expected, IsReserved(tc.Name), tc.Name)
+	}
+}
+
+func TestSplit(t *testing.T) {
+	ingress := &extensions.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "ingress",
+		},
+		Spec: extensions.IngressSpec{
+			TLS: []extensions.IngressTLS{
+				{Hosts: []string{"a.example.com", "c.example.com"}},
+			},
+			Rules: []extensions.IngressRule{
+				hostRule("a.example.com", "/1", "/2"),
+				hostRule("b.example.com", "/1"),
+				hostRule("a.example.com", "/3"),
+				hostRule("c.example.com", "/1", "/2", "/3"),
+			},
+		},
+	}
+
+	t.Run("fits into single shard", func(t *testing.T) {
+		shards := Split(ingress, Limits{MaxRules: 7, MaxCertificates: 2})
+		assert.Equal(t, []*extensions.Ingress{ingress}, shards)
+	})
+
+	t.Run("split by rules", func(t *testing.T) {
+		shards := Split(ingress, Limits{MaxRules: 4, MaxCertificates: 25})
+		assert.Len(t, shards, 2)
+
+		assert.Equal(t, "ingress", shards[0].Name)
+		assert.Equal(t, []extensions.IngressRule{
+			hostRule("a.example.com", "/1", "/2"),
+			hostRule("a.example.com", "/3"),
+			hostRule("b.example.com", "/1"),
+		}, shards[0].Spec.Rules)
+		assert.Equal(t, []extensions.IngressTLS{{Hosts: []string{"a.example.com"}}}, shards[0].Spec.TLS)
+
+		assert.Equal(t, "ingress.shard-1", shards[1].Name)
+		assert.Equal(t, []extensions.IngressRule{
+			hostRule("c.example.com", "/1", "/2", "/3"),
+		}, shards[1].Spec.Rules)
+		assert.Equal(t, []extensions.IngressTLS{{Hosts: []string{"c.example.com"}}}, shards[1].Spec.TLS)
+
+		assert.Len(t, ingress.Spec.Rules, 4, "original ingress must not be modified")
+	})
+
+	t.Run("split by certificates", func(t *testing.T) {
+		shards := Split(ingress, Limits{MaxRules: 100, MaxCertificates: 1})
+		assert.Len(t, shards, 2)
+		assert.Equal(t, []extensions.IngressTLS{{Hosts: []string{"a.example.com"}}}, shards[0].Spec.TLS)
+		assert.Equal(t, []extensions.IngressTLS{{Hosts: []string{"c.example.com"}}}, shards[1].Spec.TLS)
+	})
+
+	t.Run("oversized host gets dedicated shard", func(t *testing.T) {
+		shards := Split(ingress, Limits{MaxRules: 1, MaxCertificates: 25})
+		assert.Len(t, shards, 3)
+		assert.Len(t, shards[2].Spec.Rules, 1)
+	})
+}
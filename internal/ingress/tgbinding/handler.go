@@ -0,0 +1,84 @@
+package tgbinding
+
+import (
+	"context"
+
+	"github.com/golang/glog"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/k8s"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/apis/elbv2/v1alpha1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// FieldServiceRef indexes TargetGroupBindings by the key of their referenced service.
+const FieldServiceRef = "serviceRef"
+
+// IndexTargetGroupBindingByServiceRef returns the key of the service referenced by a TargetGroupBinding.
+func IndexTargetGroupBindingByServiceRef(obj runtime.Object) []string {
+	tgb := obj.(*v1alpha1.TargetGroupBinding)
+	return []string{types.NamespacedName{Namespace: tgb.Namespace, Name: tgb.Spec.ServiceRef.Name}.String()}
+}
+
+// enqueueRequestsForService enqueues the TargetGroupBindings referencing a service, upon changes of the service or its endpoints.
+func enqueueRequestsForService(cache cache.Cache) handler.EventHandler {
+	return &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: handler.ToRequestsFunc(func(obj handler.MapObject) []reconcile.Request {
+			return serviceBindingRequests(cache, types.NamespacedName{Namespace: obj.Meta.GetNamespace(), Name: obj.Meta.GetName()})
+		}),
+	}
+}
+
+// enqueueRequestsForEndpointSlice enqueues the TargetGroupBindings referencing the service of an EndpointSlice, upon changes of the EndpointSlice.
+func enqueueRequestsForEndpointSlice(cache cache.Cache) handler.EventHandler {
+	return &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: handler.ToRequestsFunc(func(obj handler.MapObject) []reconcile.Request {
+			serviceKey, ok := k8s.EndpointSliceServiceKey(obj.Meta)
+			if !ok {
+				return nil
+			}
+			return serviceBindingRequests(cache, serviceKey)
+		}),
+	}
+}
+
+// serviceBindingRequests returns the requests for TargetGroupBindings referencing the service of serviceKey.
+func serviceBindingRequests(cache cache.Cache, serviceKey types.NamespacedName) []reconcile.Request {
+	tgbList := &v1alpha1.TargetGroupBindingList{}
+	if err := cache.List(context.Background(), client.MatchingField(FieldServiceRef, serviceKey.String()), tgbList); err != nil {
+		glog.Errorf("failed to fetch impacted TargetGroupBindings by service %v due to %v", serviceKey, err)
+		return nil
+	}
+	return bindingRequests(tgbList, "")
+}
+
+// enqueueRequestsForNode enqueues the TargetGroupBindings with instance targets, upon changes of nodes.
+func enqueueRequestsForNode(cache cache.Cache) handler.EventHandler {
+	return &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: handler.ToRequestsFunc(func(obj handler.MapObject) []reconcile.Request {
+			tgbList := &v1alpha1.TargetGroupBindingList{}
+			if err := cache.List(context.Background(), nil, tgbList); err != nil {
+				glog.Errorf("failed to fetch impacted TargetGroupBindings by node %v due to %v", obj.Meta.GetName(), err)
+				return nil
+			}
+			return bindingRequests(tgbList, v1alpha1.TargetTypeInstance)
+		}),
+	}
+}
+
+// bindingRequests returns the requests for TargetGroupBindings in tgbList, limited to those of targetType unless it's empty.
+func bindingRequests(tgbList *v1alpha1.TargetGroupBindingList, targetType v1alpha1.TargetType) []reconcile.Request {
+	var requests []reconcile.Request
+	for _, tgb := range tgbList.Items {
+		if targetType != "" && bindingTargetType(&tgb) != targetType {
+			continue
+		}
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: tgb.Namespace, Name: tgb.Name},
+		})
+	}
+	return requests
+}
@@ -0,0 +1,206 @@
+package tgbinding
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tg"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/albctx"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/aws"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/election"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/k8s"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/apis/elbv2/v1alpha1"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/log"
+	corev1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// finalizer is added to TargetGroupBindings, so that their targets are deregistered before they are deleted.
+const finalizer = "elbv2.k8s.aws/targetgroupbinding"
+
+// NewReconciler constructs a reconciler that keeps the targets of TargetGroupBindings in sync with the endpoints of their services.
+// Targets are only registered while elector leads.
+func NewReconciler(client client.Client, recorder record.EventRecorder, cloud aws.CloudAPI, store store.Storer, targetsController tg.TargetsController, elector election.Elector) reconcile.Reconciler {
+	return &defaultReconciler{
+		client:            client,
+		recorder:          recorder,
+		cloud:             cloud,
+		store:             store,
+		targetsController: targetsController,
+		elector:           elector,
+	}
+}
+
+// Init setup index & watch functionality for controller c of TargetGroupBindings, EndpointSlices are watched if endpointSlices.
+func Init(c controller.Controller, cache cache.Cache, endpointSlices bool) error {
+	if err := cache.IndexField(&v1alpha1.TargetGroupBinding{}, FieldServiceRef, IndexTargetGroupBindingByServiceRef); err != nil {
+		return err
+	}
+	if err := c.Watch(&source.Kind{Type: &v1alpha1.TargetGroupBinding{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return err
+	}
+	if err := c.Watch(&source.Kind{Type: &corev1.Service{}}, enqueueRequestsForService(cache)); err != nil {
+		return err
+	}
+	if err := c.Watch(&source.Kind{Type: &corev1.Endpoints{}}, enqueueRequestsForService(cache)); err != nil {
+		return err
+	}
+	if endpointSlices {
+		if err := c.Watch(&source.Kind{Type: k8s.NewEndpointSlice()}, enqueueRequestsForEndpointSlice(cache)); err != nil {
+			return err
+		}
+	}
+	return c.Watch(&source.Kind{Type: &corev1.Node{}}, enqueueRequestsForNode(cache))
+}
+
+type defaultReconciler struct {
+	client            client.Client
+	recorder          record.EventRecorder
+	cloud             aws.CloudAPI
+	store             store.Storer
+	targetsController tg.TargetsController
+	elector           election.Elector
+}
+
+func (r *defaultReconciler) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	if !r.elector.BeginReconcile() {
+		return reconcile.Result{RequeueAfter: election.StandbyRequeueInterval}, nil
+	}
+	defer r.elector.EndReconcile()
+	ctx := albctx.SetLogger(context.Background(), log.New(request.NamespacedName.String()).WithSubsystem("targetgroupbinding").WithValues("targetGroupBinding", request.NamespacedName.String()))
+	tgb := &v1alpha1.TargetGroupBinding{}
+	if err := r.client.Get(ctx, request.NamespacedName, tgb); err != nil {
+		if errors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+	ctx = albctx.SetEventf(ctx, func(eventType string, reason string, messageFmt string, args ...interface{}) {
+		r.recorder.Eventf(tgb, eventType, reason, messageFmt, log.RedactArgs(args)...)
+	})
+
+	if tgb.DeletionTimestamp != nil {
+		if !hasFinalizer(tgb) {
+			return reconcile.Result{}, nil
+		}
+		if err := r.deregisterTargets(ctx, tgb.Spec.TargetGroupARN); err != nil {
+			albctx.GetEventf(ctx)(corev1.EventTypeWarning, "ERROR", "Error deregistering targets from target group %s: %s", tgb.Spec.TargetGroupARN, err.Error())
+			return reconcile.Result{}, err
+		}
+		tgb.Finalizers = removeFinalizer(tgb.Finalizers)
+		return reconcile.Result{}, r.client.Update(ctx, tgb)
+	}
+
+	if !hasFinalizer(tgb) {
+		tgb.Finalizers = append(tgb.Finalizers, finalizer)
+		if err := r.client.Update(ctx, tgb); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	if err := validTargetType(bindingTargetType(tgb)); err != nil {
+		albctx.GetEventf(ctx)(corev1.EventTypeWarning, "ERROR", "%v", err)
+		return reconcile.Result{}, nil
+	}
+	ingress, ingressBackend := bindingBackend(tgb)
+	targets := tg.NewTargets(string(bindingTargetType(tgb)), ingress, ingressBackend)
+	targets.TgArn = tgb.Spec.TargetGroupARN
+	if err := r.targetsController.Reconcile(ctx, targets); err != nil {
+		albctx.GetEventf(ctx)(corev1.EventTypeWarning, "ERROR", "Error reconciling targets of target group %s: %s", tgb.Spec.TargetGroupARN, err.Error())
+		return reconcile.Result{}, err
+	}
+
+	if tgb.Status.ObservedGeneration != tgb.Generation {
+		tgb.Status.ObservedGeneration = tgb.Generation
+		if err := r.client.Status().Update(ctx, tgb); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+	return reconcile.Result{}, nil
+}
+
+// deregisterTargets deregisters all targets from the targetGroup with tgArn, which is fine to no longer exist.
+func (r *defaultReconciler) deregisterTargets(ctx context.Context, tgArn string) error {
+	resp, err := r.cloud.DescribeTargetHealthWithContext(ctx, &elbv2.DescribeTargetHealthInput{TargetGroupArn: aws.String(tgArn)})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == elbv2.ErrCodeTargetGroupNotFoundException {
+			return nil
+		}
+		return err
+	}
+
+	var targets []*elbv2.TargetDescription
+	for _, thd := range resp.TargetHealthDescriptions {
+		if aws.StringValue(thd.TargetHealth.State) == elbv2.TargetHealthStateEnumDraining {
+			continue
+		}
+		targets = append(targets, thd.Target)
+	}
+	if len(targets) == 0 {
+		return nil
+	}
+	albctx.GetLogger(ctx).Infof("Removing all targets from %v", tgArn)
+	_, err = r.cloud.DeregisterTargetsWithContext(ctx, &elbv2.DeregisterTargetsInput{
+		TargetGroupArn: aws.String(tgArn),
+		Targets:        targets,
+	})
+	return err
+}
+
+// bindingBackend returns an ingress & backend equivalent to the service reference of tgb, so that its targets are resolved like those of ingresses.
+func bindingBackend(tgb *v1alpha1.TargetGroupBinding) (*extensions.Ingress, *extensions.IngressBackend) {
+	ingress := &extensions.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Namespace: tgb.Namespace, Name: tgb.Name},
+	}
+	ingressBackend := &extensions.IngressBackend{
+		ServiceName: tgb.Spec.ServiceRef.Name,
+		ServicePort: tgb.Spec.ServiceRef.Port,
+	}
+	return ingress, ingressBackend
+}
+
+func bindingTargetType(tgb *v1alpha1.TargetGroupBinding) v1alpha1.TargetType {
+	if tgb.Spec.TargetType == "" {
+		return v1alpha1.TargetTypeInstance
+	}
+	return tgb.Spec.TargetType
+}
+
+func hasFinalizer(tgb *v1alpha1.TargetGroupBinding) bool {
+	for _, f := range tgb.Finalizers {
+		if f == finalizer {
+			return true
+		}
+	}
+	return false
+}
+
+func removeFinalizer(finalizers []string) []string {
+	var result []string
+	for _, f := range finalizers {
+		if f != finalizer {
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
+// validTargetType returns an error unless targetType is a valid target type of TargetGroupBindings.
+func validTargetType(targetType v1alpha1.TargetType) error {
+	if targetType != v1alpha1.TargetTypeInstance && targetType != v1alpha1.TargetTypeIP {
+		return fmt.Errorf("invalid targetType %v, must be %v or %v", targetType, v1alpha1.TargetTypeInstance, v1alpha1.TargetTypeIP)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package tgbinding
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/alb/tg"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/controller/store"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/internal/ingress/election"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/mocks"
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/apis/elbv2/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	corev1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestDefaultReconciler_Reconcile(t *testing.T) {
+	assert.NoError(t, v1alpha1.AddToScheme(scheme.Scheme))
+	tgArn := "arn:aws:elasticloadbalancing:us-west-2:123456789012:targetgroup/my-targets/73e2d6bc24d8a067"
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "namespace", Name: "binding"}}
+
+	t.Run("registers targets of service", func(t *testing.T) {
+		tgb := &v1alpha1.TargetGroupBinding{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "namespace", Name: "binding", Generation: 1},
+			Spec: v1alpha1.TargetGroupBindingSpec{
+				TargetGroupARN: tgArn,
+				TargetType:     v1alpha1.TargetTypeIP,
+				ServiceRef:     v1alpha1.ServiceReference{Name: "service", Port: intstr.FromInt(80)},
+			},
+		}
+		client := fake.NewFakeClient(tgb)
+		targetsController := &tg.MockTargetsController{}
+		targetsController.On("Reconcile", mock.Anything, &tg.Targets{
+			TgArn:      tgArn,
+			TargetType: elbv2.TargetTypeEnumIp,
+			Ingress:    &extensions.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "namespace", Name: "binding"}},
+			Backend:    &extensions.IngressBackend{ServiceName: "service", ServicePort: intstr.FromInt(80)},
+		}).Return(nil)
+
+		mockStore := store.NewDummy()
+		mockStore.GetServiceEndpointsFunc = func(string) (*corev1.Endpoints, error) {
+			return &corev1.Endpoints{}, nil
+		}
+
+		r := NewReconciler(client, record.NewFakeRecorder(10), &mocks.CloudAPI{}, mockStore, targetsController, election.NewAlwaysLeader())
+		result, err := r.Reconcile(request)
+		assert.NoError(t, err)
+		assert.Equal(t, reconcile.Result{}, result)
+		targetsController.AssertExpectations(t)
+
+		updated := &v1alpha1.TargetGroupBinding{}
+		assert.NoError(t, client.Get(context.Background(), request.NamespacedName, updated))
+		assert.Equal(t, []string{finalizer}, updated.Finalizers)
+		assert.Equal(t, int64(1), updated.Status.ObservedGeneration)
+	})
+
+	t.Run("deregisters targets upon deletion", func(t *testing.T) {
+		now := metav1.Now()
+		tgb := &v1alpha1.TargetGroupBinding{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "namespace", Name: "binding", Finalizers: []string{finalizer}, DeletionTimestamp: &now},
+			Spec: v1alpha1.TargetGroupBindingSpec{
+				TargetGroupARN: tgArn,
+				ServiceRef:     v1alpha1.ServiceReference{Name: "service", Port: intstr.FromInt(80)},
+			},
+		}
+		client := fake.NewFakeClient(tgb)
+		cloud := &mocks.CloudAPI{}
+		cloud.On("DescribeTargetHealthWithContext", mock.Anything, &elbv2.DescribeTargetHealthInput{TargetGroupArn: aws.String(tgArn)}).Return(&elbv2.DescribeTargetHealthOutput{
+			TargetHealthDescriptions: []*elbv2.TargetHealthDescription{
+				{
+					Target:       &elbv2.TargetDescription{Id: aws.String("i-1"), Port: aws.Int64(30080)},
+					TargetHealth: &elbv2.TargetHealth{State: aws.String(elbv2.TargetHealthStateEnumHealthy)},
+				},
+				{
+					Target:       &elbv2.TargetDescription{Id: aws.String("i-2"), Port: aws.Int64(30080)},
+					TargetHealth: &elbv2.TargetHealth{State: aws.String(elbv2.TargetHealthStateEnumDraining)},
+				},
+			},
+		}, nil)
+		cloud.On("DeregisterTargetsWithContext", mock.Anything, &elbv2.DeregisterTargetsInput{
+			TargetGroupArn: aws.String(tgArn),
+			Targets:        []*elbv2.TargetDescription{{Id: aws.String("i-1"), Port: aws.Int64(30080)}},
+		}).Return(nil, nil)
+
+		r := NewReconciler(client, record.NewFakeRecorder(10), cloud, store.NewDummy(), &tg.MockTargetsController{}, election.NewAlwaysLeader())
+		_, err := r.Reconcile(request)
+		assert.NoError(t, err)
+		cloud.AssertExpectations(t)
+
+		updated := &v1alpha1.TargetGroupBinding{}
+		assert.NoError(t, client.Get(context.Background(), request.NamespacedName, updated))
+		assert.Empty(t, updated.Finalizers)
+	})
+}
@@ -0,0 +1,30 @@
+package k8s
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// EndpointSliceGVK is the version of EndpointSlices resolved, they're handled as unstructured objects since the client libraries have no types of them.
+var EndpointSliceGVK = schema.GroupVersionKind{Group: "discovery.k8s.io", Version: "v1beta1", Kind: "EndpointSlice"}
+
+// LabelServiceName labels EndpointSlices with the name of the Service they belong to.
+const LabelServiceName = "kubernetes.io/service-name"
+
+// NewEndpointSlice returns an empty unstructured EndpointSlice, like the type of watches and informers of EndpointSlices.
+func NewEndpointSlice() *unstructured.Unstructured {
+	slice := &unstructured.Unstructured{}
+	slice.SetGroupVersionKind(EndpointSliceGVK)
+	return slice
+}
+
+// EndpointSliceServiceKey returns the key of the Service an EndpointSlice belongs to, or false if it isn't labeled with a Service.
+func EndpointSliceServiceKey(slice metav1.Object) (types.NamespacedName, bool) {
+	name := slice.GetLabels()[LabelServiceName]
+	if name == "" {
+		return types.NamespacedName{}, false
+	}
+	return types.NamespacedName{Namespace: slice.GetNamespace(), Name: name}, true
+}
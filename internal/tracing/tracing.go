@@ -0,0 +1,83 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/version"
+	"github.com/spf13/pflag"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName is the instrumentation name of spans started by the controller.
+const tracerName = "github.com/kubernetes-sigs/aws-alb-ingress-controller"
+
+// Options configures the export of traces.
+type Options struct {
+	// OTLPEndpoint is the host:port of the OTLP gRPC receiver spans are exported to, tracing is disabled when empty.
+	OTLPEndpoint string
+	// Insecure disables TLS towards OTLPEndpoint, e.g. for a collector sidecar.
+	Insecure bool
+	// SampleRatio is the fraction of reconciles traced.
+	SampleRatio float64
+}
+
+// BindFlags binds the tracing flags to fs.
+func (o *Options) BindFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.OTLPEndpoint, "tracing-otlp-endpoint", "",
+		`host:port of an OTLP gRPC receiver to export traces of reconciles to, e.g. an OpenTelemetry collector. Tracing is disabled if empty.`)
+	fs.BoolVar(&o.Insecure, "tracing-otlp-insecure", false,
+		`Export traces without TLS.`)
+	fs.Float64Var(&o.SampleRatio, "tracing-sample-ratio", 1,
+		`Fraction of reconciles to trace, between 0 and 1.`)
+}
+
+// Setup installs the global TracerProvider according to options, and returns a function flushing pending spans on shutdown.
+// Spans are discarded unless an OTLPEndpoint is configured.
+func Setup(ctx context.Context, options Options, clusterName string) (func(context.Context) error, error) {
+	if options.OTLPEndpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+	if options.SampleRatio < 0 || options.SampleRatio > 1 {
+		return nil, fmt.Errorf("tracing sample ratio must be between 0 and 1, got %v", options.SampleRatio)
+	}
+	exporterOptions := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(options.OTLPEndpoint)}
+	if options.Insecure {
+		exporterOptions = append(exporterOptions, otlptracegrpc.WithInsecure())
+	}
+	exporter, err := otlptracegrpc.New(ctx, exporterOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP exporter due to %w", err)
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(options.SampleRatio))),
+		sdktrace.WithResource(resource.NewSchemaless(
+			attribute.String("service.name", "aws-alb-ingress-controller"),
+			attribute.String("service.version", version.RELEASE),
+			attribute.String("k8s.cluster.name", clusterName),
+		)),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// Start starts a span as child of the span in ctx, and returns ctx carrying the new span.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End ends span, marking it failed by err if any.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
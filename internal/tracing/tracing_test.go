@@ -0,0 +1,25 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetup(t *testing.T) {
+	shutdown, err := Setup(context.Background(), Options{}, "cluster")
+	assert.NoError(t, err)
+	assert.NoError(t, shutdown(context.Background()))
+
+	_, err = Setup(context.Background(), Options{OTLPEndpoint: "localhost:4317", SampleRatio: 2}, "cluster")
+	assert.EqualError(t, err, "tracing sample ratio must be between 0 and 1, got 2")
+}
+
+func TestStart(t *testing.T) {
+	// spans are no-ops unless tracing is set up, but still carried by ctx.
+	ctx, span := Start(context.Background(), "Reconcile")
+	assert.NotNil(t, ctx)
+	End(span, errors.New("failed"))
+}
@@ -0,0 +1,79 @@
+package utils
+
+import (
+	"sync"
+)
+
+// KeyedMutex provides mutual exclusion per key, state is only kept for keys that are locked or waited for.
+type KeyedMutex interface {
+	// Lock locks key, blocks until key is available.
+	Lock(key string)
+
+	// Unlock unlocks key, it's a run-time error if key is not locked.
+	Unlock(key string)
+
+	// Locker returns a sync.Locker that locks key.
+	Locker(key string) sync.Locker
+}
+
+func NewKeyedMutex() KeyedMutex {
+	return &keyedMutex{
+		mutexes: make(map[string]*refCountedMutex),
+	}
+}
+
+type refCountedMutex struct {
+	sync.Mutex
+
+	// refs is the number of holders and waiters of the mutex.
+	refs int
+}
+
+type keyedMutex struct {
+	mu      sync.Mutex
+	mutexes map[string]*refCountedMutex
+}
+
+func (m *keyedMutex) Lock(key string) {
+	m.mu.Lock()
+	mutex, ok := m.mutexes[key]
+	if !ok {
+		mutex = &refCountedMutex{}
+		m.mutexes[key] = mutex
+	}
+	mutex.refs++
+	m.mu.Unlock()
+
+	mutex.Lock()
+}
+
+func (m *keyedMutex) Unlock(key string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	mutex, ok := m.mutexes[key]
+	if !ok {
+		panic("unlock of unlocked key " + key)
+	}
+	mutex.refs--
+	if mutex.refs == 0 {
+		delete(m.mutexes, key)
+	}
+	mutex.Unlock()
+}
+
+func (m *keyedMutex) Locker(key string) sync.Locker {
+	return &keyLocker{mutex: m, key: key}
+}
+
+type keyLocker struct {
+	mutex KeyedMutex
+	key   string
+}
+
+func (l *keyLocker) Lock() {
+	l.mutex.Lock(l.key)
+}
+
+func (l *keyLocker) Unlock() {
+	l.mutex.Unlock(l.key)
+}
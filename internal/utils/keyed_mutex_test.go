@@ -0,0 +1,56 @@
+package utils
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyedMutex_sameKey(t *testing.T) {
+	m := NewKeyedMutex()
+	var running, maxRunning int32
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m.Lock("lb")
+			defer m.Unlock("lb")
+			current := atomic.AddInt32(&running, 1)
+			if current > atomic.LoadInt32(&maxRunning) {
+				atomic.StoreInt32(&maxRunning, current)
+			}
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&running, -1)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), maxRunning)
+	assert.Empty(t, m.(*keyedMutex).mutexes)
+}
+
+func TestKeyedMutex_differentKeys(t *testing.T) {
+	m := NewKeyedMutex()
+	m.Lock("lb-1")
+	locked := make(chan struct{})
+	go func() {
+		locker := m.Locker("lb-2")
+		locker.Lock()
+		defer locker.Unlock()
+		close(locked)
+	}()
+	select {
+	case <-locked:
+	case <-time.After(time.Second):
+		t.Fatal("different keys should not block each other")
+	}
+	m.Unlock("lb-1")
+}
+
+func TestKeyedMutex_unlockUnlocked(t *testing.T) {
+	m := NewKeyedMutex()
+	assert.Panics(t, func() { m.Unlock("lb") })
+}
@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"sync"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+)
+
+// Parallelize calls fn for each piece in [0, pieces), with at most workers calls running concurrently.
+// Once a call fails, remaining pieces are not started, and the errors of all failed calls are returned as an aggregate.
+func Parallelize(workers int, pieces int, fn func(piece int) error) error {
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > pieces {
+		workers = pieces
+	}
+
+	toProcess := make(chan int, pieces)
+	for piece := 0; piece < pieces; piece++ {
+		toProcess <- piece
+	}
+	close(toProcess)
+
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		errs   []error
+		failed bool
+	)
+	wg.Add(workers)
+	for worker := 0; worker < workers; worker++ {
+		go func() {
+			defer wg.Done()
+			for piece := range toProcess {
+				mu.Lock()
+				stop := failed
+				mu.Unlock()
+				if stop {
+					return
+				}
+				if err := fn(piece); err != nil {
+					mu.Lock()
+					failed = true
+					errs = append(errs, err)
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(errs) == 1 {
+		return errs[0]
+	}
+	return utilerrors.NewAggregate(errs)
+}
@@ -0,0 +1,59 @@
+package utils
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParallelize(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		workers int
+		pieces  int
+	}{
+		{name: "no pieces", workers: 4, pieces: 0},
+		{name: "more pieces than workers", workers: 4, pieces: 20},
+		{name: "more workers than pieces", workers: 20, pieces: 4},
+		{name: "invalid workers", workers: 0, pieces: 4},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var mu sync.Mutex
+			processed := make(map[int]int)
+			var running, maxRunning int32
+			err := Parallelize(tc.workers, tc.pieces, func(piece int) error {
+				current := atomic.AddInt32(&running, 1)
+				defer atomic.AddInt32(&running, -1)
+				mu.Lock()
+				defer mu.Unlock()
+				if current > maxRunning {
+					maxRunning = current
+				}
+				processed[piece]++
+				return nil
+			})
+			assert.NoError(t, err)
+			assert.Len(t, processed, tc.pieces)
+			for _, count := range processed {
+				assert.Equal(t, 1, count)
+			}
+			assert.True(t, maxRunning <= int32(tc.workers) || maxRunning == 1)
+		})
+	}
+}
+
+func TestParallelize_error(t *testing.T) {
+	var processed int32
+	err := Parallelize(1, 10, func(piece int) error {
+		atomic.AddInt32(&processed, 1)
+		if piece == 2 {
+			return errors.New("failed")
+		}
+		return nil
+	})
+	assert.EqualError(t, err, "failed")
+	assert.Equal(t, int32(3), processed)
+}
@@ -5,19 +5,30 @@ package mocks
 import (
 	acm "github.com/aws/aws-sdk-go/service/acm"
 
+	cloudwatch "github.com/aws/aws-sdk-go/service/cloudwatch"
+
 	context "context"
 
 	ec2 "github.com/aws/aws-sdk-go/service/ec2"
 
 	elbv2 "github.com/aws/aws-sdk-go/service/elbv2"
+	iam "github.com/aws/aws-sdk-go/service/iam"
 
 	mock "github.com/stretchr/testify/mock"
 
 	resourcegroupstaggingapi "github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
 
+	route53 "github.com/aws/aws-sdk-go/service/route53"
+
+	shield "github.com/aws/aws-sdk-go/service/shield"
+
+	sqs "github.com/aws/aws-sdk-go/service/sqs"
+
 	waf "github.com/aws/aws-sdk-go/service/waf"
 
 	wafregional "github.com/aws/aws-sdk-go/service/wafregional"
+
+	wafv2 "github.com/aws/aws-sdk-go/service/wafv2"
 )
 
 // CloudAPI is an autogenerated mock type for the CloudAPI type
@@ -62,6 +73,20 @@ func (_m *CloudAPI) AddELBV2TagsWithContext(_a0 context.Context, _a1 *elbv2.AddT
 	return r0, r1
 }
 
+// AddLambdaInvokePermission provides a mock function with given fields: ctx, functionArn, statementID, principal, sourceArn
+func (_m *CloudAPI) AddLambdaInvokePermission(ctx context.Context, functionArn string, statementID string, principal string, sourceArn string) error {
+	ret := _m.Called(ctx, functionArn, statementID, principal, sourceArn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) error); ok {
+		r0 = rf(ctx, functionArn, statementID, principal, sourceArn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // AddListenerCertificates provides a mock function with given fields: _a0, _a1
 func (_m *CloudAPI) AddListenerCertificates(_a0 context.Context, _a1 *elbv2.AddListenerCertificatesInput) (*elbv2.AddListenerCertificatesOutput, error) {
 	ret := _m.Called(_a0, _a1)
@@ -85,6 +110,43 @@ func (_m *CloudAPI) AddListenerCertificates(_a0 context.Context, _a1 *elbv2.AddL
 	return r0, r1
 }
 
+// AddTagsToCertificate provides a mock function with given fields: ctx, certArn, tags
+func (_m *CloudAPI) AddTagsToCertificate(ctx context.Context, certArn string, tags []*acm.Tag) error {
+	ret := _m.Called(ctx, certArn, tags)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []*acm.Tag) error); ok {
+		r0 = rf(ctx, certArn, tags)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AllocateAddressWithContext provides a mock function with given fields: _a0, _a1
+func (_m *CloudAPI) AllocateAddressWithContext(_a0 context.Context, _a1 *ec2.AllocateAddressInput) (*ec2.AllocateAddressOutput, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *ec2.AllocateAddressOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *ec2.AllocateAddressInput) *ec2.AllocateAddressOutput); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ec2.AllocateAddressOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *ec2.AllocateAddressInput) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // AssociateWAF provides a mock function with given fields: ctx, resourceArn, webACLId
 func (_m *CloudAPI) AssociateWAF(ctx context.Context, resourceArn *string, webACLId *string) (*wafregional.AssociateWebACLOutput, error) {
 	ret := _m.Called(ctx, resourceArn, webACLId)
@@ -108,6 +170,29 @@ func (_m *CloudAPI) AssociateWAF(ctx context.Context, resourceArn *string, webAC
 	return r0, r1
 }
 
+// AssociateWAFv2 provides a mock function with given fields: ctx, resourceArn, webACLArn
+func (_m *CloudAPI) AssociateWAFv2(ctx context.Context, resourceArn *string, webACLArn *string) (*wafv2.AssociateWebACLOutput, error) {
+	ret := _m.Called(ctx, resourceArn, webACLArn)
+
+	var r0 *wafv2.AssociateWebACLOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *string, *string) *wafv2.AssociateWebACLOutput); ok {
+		r0 = rf(ctx, resourceArn, webACLArn)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*wafv2.AssociateWebACLOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *string, *string) error); ok {
+		r1 = rf(ctx, resourceArn, webACLArn)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // AuthorizeSecurityGroupIngressWithContext provides a mock function with given fields: _a0, _a1
 func (_m *CloudAPI) AuthorizeSecurityGroupIngressWithContext(_a0 context.Context, _a1 *ec2.AuthorizeSecurityGroupIngressInput) (*ec2.AuthorizeSecurityGroupIngressOutput, error) {
 	ret := _m.Called(_a0, _a1)
@@ -131,6 +216,29 @@ func (_m *CloudAPI) AuthorizeSecurityGroupIngressWithContext(_a0 context.Context
 	return r0, r1
 }
 
+// ChangeResourceRecordSetsWithContext provides a mock function with given fields: _a0, _a1
+func (_m *CloudAPI) ChangeResourceRecordSetsWithContext(_a0 context.Context, _a1 *route53.ChangeResourceRecordSetsInput) (*route53.ChangeResourceRecordSetsOutput, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *route53.ChangeResourceRecordSetsOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *route53.ChangeResourceRecordSetsInput) *route53.ChangeResourceRecordSetsOutput); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*route53.ChangeResourceRecordSetsOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *route53.ChangeResourceRecordSetsInput) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateEC2TagsWithContext provides a mock function with given fields: _a0, _a1
 func (_m *CloudAPI) CreateEC2TagsWithContext(_a0 context.Context, _a1 *ec2.CreateTagsInput) (*ec2.CreateTagsOutput, error) {
 	ret := _m.Called(_a0, _a1)
@@ -246,6 +354,29 @@ func (_m *CloudAPI) CreateSecurityGroupWithContext(_a0 context.Context, _a1 *ec2
 	return r0, r1
 }
 
+// CreateShieldProtection provides a mock function with given fields: ctx, name, resourceArn
+func (_m *CloudAPI) CreateShieldProtection(ctx context.Context, name *string, resourceArn *string) (*shield.CreateProtectionOutput, error) {
+	ret := _m.Called(ctx, name, resourceArn)
+
+	var r0 *shield.CreateProtectionOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *string, *string) *shield.CreateProtectionOutput); ok {
+		r0 = rf(ctx, name, resourceArn)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shield.CreateProtectionOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *string, *string) error); ok {
+		r1 = rf(ctx, name, resourceArn)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateTargetGroupWithContext provides a mock function with given fields: _a0, _a1
 func (_m *CloudAPI) CreateTargetGroupWithContext(_a0 context.Context, _a1 *elbv2.CreateTargetGroupInput) (*elbv2.CreateTargetGroupOutput, error) {
 	ret := _m.Called(_a0, _a1)
@@ -269,6 +400,43 @@ func (_m *CloudAPI) CreateTargetGroupWithContext(_a0 context.Context, _a1 *elbv2
 	return r0, r1
 }
 
+// CreateVpcEndpointServiceConfigurationWithContext provides a mock function with given fields: _a0, _a1
+func (_m *CloudAPI) CreateVpcEndpointServiceConfigurationWithContext(_a0 context.Context, _a1 *ec2.CreateVpcEndpointServiceConfigurationInput) (*ec2.CreateVpcEndpointServiceConfigurationOutput, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *ec2.CreateVpcEndpointServiceConfigurationOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *ec2.CreateVpcEndpointServiceConfigurationInput) *ec2.CreateVpcEndpointServiceConfigurationOutput); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ec2.CreateVpcEndpointServiceConfigurationOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *ec2.CreateVpcEndpointServiceConfigurationInput) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteCertificate provides a mock function with given fields: ctx, certArn
+func (_m *CloudAPI) DeleteCertificate(ctx context.Context, certArn string) error {
+	ret := _m.Called(ctx, certArn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, certArn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteEC2TagsWithContext provides a mock function with given fields: _a0, _a1
 func (_m *CloudAPI) DeleteEC2TagsWithContext(_a0 context.Context, _a1 *ec2.DeleteTagsInput) (*ec2.DeleteTagsOutput, error) {
 	ret := _m.Called(_a0, _a1)
@@ -343,6 +511,29 @@ func (_m *CloudAPI) DeleteRuleWithContext(_a0 context.Context, _a1 *elbv2.Delete
 	return r0, r1
 }
 
+// DeleteSQSMessageWithContext provides a mock function with given fields: _a0, _a1
+func (_m *CloudAPI) DeleteSQSMessageWithContext(_a0 context.Context, _a1 *sqs.DeleteMessageInput) (*sqs.DeleteMessageOutput, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *sqs.DeleteMessageOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *sqs.DeleteMessageInput) *sqs.DeleteMessageOutput); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*sqs.DeleteMessageOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *sqs.DeleteMessageInput) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeleteSecurityGroupByID provides a mock function with given fields: _a0, _a1
 func (_m *CloudAPI) DeleteSecurityGroupByID(_a0 context.Context, _a1 string) error {
 	ret := _m.Called(_a0, _a1)
@@ -357,6 +548,29 @@ func (_m *CloudAPI) DeleteSecurityGroupByID(_a0 context.Context, _a1 string) err
 	return r0
 }
 
+// DeleteShieldProtection provides a mock function with given fields: ctx, protectionID
+func (_m *CloudAPI) DeleteShieldProtection(ctx context.Context, protectionID *string) (*shield.DeleteProtectionOutput, error) {
+	ret := _m.Called(ctx, protectionID)
+
+	var r0 *shield.DeleteProtectionOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *string) *shield.DeleteProtectionOutput); ok {
+		r0 = rf(ctx, protectionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shield.DeleteProtectionOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *string) error); ok {
+		r1 = rf(ctx, protectionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeleteTargetGroupByArn provides a mock function with given fields: _a0, _a1
 func (_m *CloudAPI) DeleteTargetGroupByArn(_a0 context.Context, _a1 string) error {
 	ret := _m.Called(_a0, _a1)
@@ -371,6 +585,29 @@ func (_m *CloudAPI) DeleteTargetGroupByArn(_a0 context.Context, _a1 string) erro
 	return r0
 }
 
+// DeleteVpcEndpointServiceConfigurationsWithContext provides a mock function with given fields: _a0, _a1
+func (_m *CloudAPI) DeleteVpcEndpointServiceConfigurationsWithContext(_a0 context.Context, _a1 *ec2.DeleteVpcEndpointServiceConfigurationsInput) (*ec2.DeleteVpcEndpointServiceConfigurationsOutput, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *ec2.DeleteVpcEndpointServiceConfigurationsOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *ec2.DeleteVpcEndpointServiceConfigurationsInput) *ec2.DeleteVpcEndpointServiceConfigurationsOutput); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ec2.DeleteVpcEndpointServiceConfigurationsOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *ec2.DeleteVpcEndpointServiceConfigurationsInput) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeregisterTargetsWithContext provides a mock function with given fields: _a0, _a1
 func (_m *CloudAPI) DeregisterTargetsWithContext(_a0 context.Context, _a1 *elbv2.DeregisterTargetsInput) (*elbv2.DeregisterTargetsOutput, error) {
 	ret := _m.Called(_a0, _a1)
@@ -394,6 +631,29 @@ func (_m *CloudAPI) DeregisterTargetsWithContext(_a0 context.Context, _a1 *elbv2
 	return r0, r1
 }
 
+// DescribeAddressesWithContext provides a mock function with given fields: _a0, _a1
+func (_m *CloudAPI) DescribeAddressesWithContext(_a0 context.Context, _a1 *ec2.DescribeAddressesInput) (*ec2.DescribeAddressesOutput, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *ec2.DescribeAddressesOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *ec2.DescribeAddressesInput) *ec2.DescribeAddressesOutput); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ec2.DescribeAddressesOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *ec2.DescribeAddressesInput) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DescribeCertificate provides a mock function with given fields: ctx, certArn
 func (_m *CloudAPI) DescribeCertificate(ctx context.Context, certArn string) (*acm.CertificateDetail, error) {
 	ret := _m.Called(ctx, certArn)
@@ -578,6 +838,75 @@ func (_m *CloudAPI) DescribeTargetHealthWithContext(_a0 context.Context, _a1 *el
 	return r0, r1
 }
 
+// DescribeVpcEndpointConnections provides a mock function with given fields: _a0, _a1
+func (_m *CloudAPI) DescribeVpcEndpointConnections(_a0 context.Context, _a1 *ec2.DescribeVpcEndpointConnectionsInput) ([]*ec2.VpcEndpointConnection, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 []*ec2.VpcEndpointConnection
+	if rf, ok := ret.Get(0).(func(context.Context, *ec2.DescribeVpcEndpointConnectionsInput) []*ec2.VpcEndpointConnection); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*ec2.VpcEndpointConnection)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *ec2.DescribeVpcEndpointConnectionsInput) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DescribeVpcEndpointServiceConfigurations provides a mock function with given fields: _a0, _a1
+func (_m *CloudAPI) DescribeVpcEndpointServiceConfigurations(_a0 context.Context, _a1 *ec2.DescribeVpcEndpointServiceConfigurationsInput) ([]*ec2.ServiceConfiguration, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 []*ec2.ServiceConfiguration
+	if rf, ok := ret.Get(0).(func(context.Context, *ec2.DescribeVpcEndpointServiceConfigurationsInput) []*ec2.ServiceConfiguration); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*ec2.ServiceConfiguration)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *ec2.DescribeVpcEndpointServiceConfigurationsInput) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DescribeVpcEndpointServicePermissions provides a mock function with given fields: _a0, _a1
+func (_m *CloudAPI) DescribeVpcEndpointServicePermissions(_a0 context.Context, _a1 *ec2.DescribeVpcEndpointServicePermissionsInput) ([]*ec2.AllowedPrincipal, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 []*ec2.AllowedPrincipal
+	if rf, ok := ret.Get(0).(func(context.Context, *ec2.DescribeVpcEndpointServicePermissionsInput) []*ec2.AllowedPrincipal); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*ec2.AllowedPrincipal)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *ec2.DescribeVpcEndpointServicePermissionsInput) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DisassociateWAF provides a mock function with given fields: ctx, resourceArn
 func (_m *CloudAPI) DisassociateWAF(ctx context.Context, resourceArn *string) (*wafregional.DisassociateWebACLOutput, error) {
 	ret := _m.Called(ctx, resourceArn)
@@ -601,6 +930,29 @@ func (_m *CloudAPI) DisassociateWAF(ctx context.Context, resourceArn *string) (*
 	return r0, r1
 }
 
+// DisassociateWAFv2 provides a mock function with given fields: ctx, resourceArn
+func (_m *CloudAPI) DisassociateWAFv2(ctx context.Context, resourceArn *string) (*wafv2.DisassociateWebACLOutput, error) {
+	ret := _m.Called(ctx, resourceArn)
+
+	var r0 *wafv2.DisassociateWebACLOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *string) *wafv2.DisassociateWebACLOutput); ok {
+		r0 = rf(ctx, resourceArn)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*wafv2.DisassociateWebACLOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *string) error); ok {
+		r1 = rf(ctx, resourceArn)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetClusterName provides a mock function with given fields:
 func (_m *CloudAPI) GetClusterName() string {
 	ret := _m.Called()
@@ -624,13 +976,82 @@ func (_m *CloudAPI) GetClusterSubnets(_a0 string) ([]*ec2.Subnet, error) {
 		r0 = rf(_a0)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*ec2.Subnet)
+			r0 = ret.Get(0).([]*ec2.Subnet)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetInstancesByIDs provides a mock function with given fields: _a0
+func (_m *CloudAPI) GetInstancesByIDs(_a0 []string) ([]*ec2.Instance, error) {
+	ret := _m.Called(_a0)
+
+	var r0 []*ec2.Instance
+	if rf, ok := ret.Get(0).(func([]string) []*ec2.Instance); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*ec2.Instance)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]string) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLoadBalancerByArn provides a mock function with given fields: _a0, _a1
+func (_m *CloudAPI) GetLoadBalancerByArn(_a0 context.Context, _a1 string) (*elbv2.LoadBalancer, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *elbv2.LoadBalancer
+	if rf, ok := ret.Get(0).(func(context.Context, string) *elbv2.LoadBalancer); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*elbv2.LoadBalancer)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLoadBalancerByName provides a mock function with given fields: _a0, _a1
+func (_m *CloudAPI) GetLoadBalancerByName(_a0 context.Context, _a1 string) (*elbv2.LoadBalancer, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *elbv2.LoadBalancer
+	if rf, ok := ret.Get(0).(func(context.Context, string) *elbv2.LoadBalancer); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*elbv2.LoadBalancer)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(_a0)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -638,22 +1059,22 @@ func (_m *CloudAPI) GetClusterSubnets(_a0 string) ([]*ec2.Subnet, error) {
 	return r0, r1
 }
 
-// GetInstancesByIDs provides a mock function with given fields: _a0
-func (_m *CloudAPI) GetInstancesByIDs(_a0 []string) ([]*ec2.Instance, error) {
-	ret := _m.Called(_a0)
+// GetLoadBalancersByTags provides a mock function with given fields: _a0, _a1
+func (_m *CloudAPI) GetLoadBalancersByTags(_a0 context.Context, _a1 map[string][]string) ([]*elbv2.LoadBalancer, error) {
+	ret := _m.Called(_a0, _a1)
 
-	var r0 []*ec2.Instance
-	if rf, ok := ret.Get(0).(func([]string) []*ec2.Instance); ok {
-		r0 = rf(_a0)
+	var r0 []*elbv2.LoadBalancer
+	if rf, ok := ret.Get(0).(func(context.Context, map[string][]string) []*elbv2.LoadBalancer); ok {
+		r0 = rf(_a0, _a1)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*ec2.Instance)
+			r0 = ret.Get(0).([]*elbv2.LoadBalancer)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func([]string) error); ok {
-		r1 = rf(_a0)
+	if rf, ok := ret.Get(1).(func(context.Context, map[string][]string) error); ok {
+		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -661,21 +1082,21 @@ func (_m *CloudAPI) GetInstancesByIDs(_a0 []string) ([]*ec2.Instance, error) {
 	return r0, r1
 }
 
-// GetLoadBalancerByArn provides a mock function with given fields: _a0, _a1
-func (_m *CloudAPI) GetLoadBalancerByArn(_a0 context.Context, _a1 string) (*elbv2.LoadBalancer, error) {
+// GetMetricData provides a mock function with given fields: _a0, _a1
+func (_m *CloudAPI) GetMetricData(_a0 context.Context, _a1 *cloudwatch.GetMetricDataInput) ([]*cloudwatch.MetricDataResult, error) {
 	ret := _m.Called(_a0, _a1)
 
-	var r0 *elbv2.LoadBalancer
-	if rf, ok := ret.Get(0).(func(context.Context, string) *elbv2.LoadBalancer); ok {
+	var r0 []*cloudwatch.MetricDataResult
+	if rf, ok := ret.Get(0).(func(context.Context, *cloudwatch.GetMetricDataInput) []*cloudwatch.MetricDataResult); ok {
 		r0 = rf(_a0, _a1)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*elbv2.LoadBalancer)
+			r0 = ret.Get(0).([]*cloudwatch.MetricDataResult)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, *cloudwatch.GetMetricDataInput) error); ok {
 		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
@@ -684,22 +1105,22 @@ func (_m *CloudAPI) GetLoadBalancerByArn(_a0 context.Context, _a1 string) (*elbv
 	return r0, r1
 }
 
-// GetLoadBalancerByName provides a mock function with given fields: _a0, _a1
-func (_m *CloudAPI) GetLoadBalancerByName(_a0 context.Context, _a1 string) (*elbv2.LoadBalancer, error) {
-	ret := _m.Called(_a0, _a1)
+// GetResourceRecordSets provides a mock function with given fields: ctx, hostedZoneID, recordName
+func (_m *CloudAPI) GetResourceRecordSets(ctx context.Context, hostedZoneID string, recordName string) ([]*route53.ResourceRecordSet, error) {
+	ret := _m.Called(ctx, hostedZoneID, recordName)
 
-	var r0 *elbv2.LoadBalancer
-	if rf, ok := ret.Get(0).(func(context.Context, string) *elbv2.LoadBalancer); ok {
-		r0 = rf(_a0, _a1)
+	var r0 []*route53.ResourceRecordSet
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []*route53.ResourceRecordSet); ok {
+		r0 = rf(ctx, hostedZoneID, recordName)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*elbv2.LoadBalancer)
+			r0 = ret.Get(0).([]*route53.ResourceRecordSet)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(_a0, _a1)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, hostedZoneID, recordName)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -829,6 +1250,29 @@ func (_m *CloudAPI) GetSecurityGroupsByName(_a0 context.Context, _a1 []string) (
 	return r0, r1
 }
 
+// GetShieldProtection provides a mock function with given fields: ctx, resourceArn
+func (_m *CloudAPI) GetShieldProtection(ctx context.Context, resourceArn *string) (*shield.Protection, error) {
+	ret := _m.Called(ctx, resourceArn)
+
+	var r0 *shield.Protection
+	if rf, ok := ret.Get(0).(func(context.Context, *string) *shield.Protection); ok {
+		r0 = rf(ctx, resourceArn)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shield.Protection)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *string) error); ok {
+		r1 = rf(ctx, resourceArn)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetSubnetsByNameOrID provides a mock function with given fields: _a0, _a1
 func (_m *CloudAPI) GetSubnetsByNameOrID(_a0 context.Context, _a1 []string) ([]*ec2.Subnet, error) {
 	ret := _m.Called(_a0, _a1)
@@ -935,6 +1379,29 @@ func (_m *CloudAPI) GetVpcWithContext(_a0 context.Context) (*ec2.Vpc, error) {
 	return r0, r1
 }
 
+// GetWAFv2WebACLForResource provides a mock function with given fields: ctx, resourceArn
+func (_m *CloudAPI) GetWAFv2WebACLForResource(ctx context.Context, resourceArn *string) (*wafv2.WebACL, error) {
+	ret := _m.Called(ctx, resourceArn)
+
+	var r0 *wafv2.WebACL
+	if rf, ok := ret.Get(0).(func(context.Context, *string) *wafv2.WebACL); ok {
+		r0 = rf(ctx, resourceArn)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*wafv2.WebACL)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *string) error); ok {
+		r1 = rf(ctx, resourceArn)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetWebACLSummary provides a mock function with given fields: ctx, resourceArn
 func (_m *CloudAPI) GetWebACLSummary(ctx context.Context, resourceArn *string) (*waf.WebACLSummary, error) {
 	ret := _m.Called(ctx, resourceArn)
@@ -958,6 +1425,27 @@ func (_m *CloudAPI) GetWebACLSummary(ctx context.Context, resourceArn *string) (
 	return r0, r1
 }
 
+// ImportCertificate provides a mock function with given fields: ctx, input
+func (_m *CloudAPI) ImportCertificate(ctx context.Context, input *acm.ImportCertificateInput) (string, error) {
+	ret := _m.Called(ctx, input)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, *acm.ImportCertificateInput) string); ok {
+		r0 = rf(ctx, input)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *acm.ImportCertificateInput) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // IsNodeHealthy provides a mock function with given fields: _a0
 func (_m *CloudAPI) IsNodeHealthy(_a0 string) (bool, error) {
 	ret := _m.Called(_a0)
@@ -979,6 +1467,29 @@ func (_m *CloudAPI) IsNodeHealthy(_a0 string) (bool, error) {
 	return r0, r1
 }
 
+// ListAllResourceRecordSets provides a mock function with given fields: ctx, hostedZoneID
+func (_m *CloudAPI) ListAllResourceRecordSets(ctx context.Context, hostedZoneID string) ([]*route53.ResourceRecordSet, error) {
+	ret := _m.Called(ctx, hostedZoneID)
+
+	var r0 []*route53.ResourceRecordSet
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*route53.ResourceRecordSet); ok {
+		r0 = rf(ctx, hostedZoneID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*route53.ResourceRecordSet)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, hostedZoneID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListCertificates provides a mock function with given fields: ctx, input
 func (_m *CloudAPI) ListCertificates(ctx context.Context, input *acm.ListCertificatesInput) ([]*acm.CertificateSummary, error) {
 	ret := _m.Called(ctx, input)
@@ -1002,6 +1513,29 @@ func (_m *CloudAPI) ListCertificates(ctx context.Context, input *acm.ListCertifi
 	return r0, r1
 }
 
+// ListHostedZones provides a mock function with given fields: ctx
+func (_m *CloudAPI) ListHostedZones(ctx context.Context) ([]*route53.HostedZone, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*route53.HostedZone
+	if rf, ok := ret.Get(0).(func(context.Context) []*route53.HostedZone); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*route53.HostedZone)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListListenersByLoadBalancer provides a mock function with given fields: _a0, _a1
 func (_m *CloudAPI) ListListenersByLoadBalancer(_a0 context.Context, _a1 string) ([]*elbv2.Listener, error) {
 	ret := _m.Called(_a0, _a1)
@@ -1025,6 +1559,52 @@ func (_m *CloudAPI) ListListenersByLoadBalancer(_a0 context.Context, _a1 string)
 	return r0, r1
 }
 
+// ListServerCertificates provides a mock function with given fields: ctx
+func (_m *CloudAPI) ListServerCertificates(ctx context.Context) ([]*iam.ServerCertificateMetadata, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*iam.ServerCertificateMetadata
+	if rf, ok := ret.Get(0).(func(context.Context) []*iam.ServerCertificateMetadata); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*iam.ServerCertificateMetadata)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListTagsForCertificate provides a mock function with given fields: ctx, certArn
+func (_m *CloudAPI) ListTagsForCertificate(ctx context.Context, certArn string) ([]*acm.Tag, error) {
+	ret := _m.Called(ctx, certArn)
+
+	var r0 []*acm.Tag
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*acm.Tag); ok {
+		r0 = rf(ctx, certArn)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*acm.Tag)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, certArn)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ModifyListenerWithContext provides a mock function with given fields: _a0, _a1
 func (_m *CloudAPI) ModifyListenerWithContext(_a0 context.Context, _a1 *elbv2.ModifyListenerInput) (*elbv2.ModifyListenerOutput, error) {
 	ret := _m.Called(_a0, _a1)
@@ -1163,6 +1743,75 @@ func (_m *CloudAPI) ModifyTargetGroupWithContext(_a0 context.Context, _a1 *elbv2
 	return r0, r1
 }
 
+// ModifyVpcEndpointServiceConfigurationWithContext provides a mock function with given fields: _a0, _a1
+func (_m *CloudAPI) ModifyVpcEndpointServiceConfigurationWithContext(_a0 context.Context, _a1 *ec2.ModifyVpcEndpointServiceConfigurationInput) (*ec2.ModifyVpcEndpointServiceConfigurationOutput, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *ec2.ModifyVpcEndpointServiceConfigurationOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *ec2.ModifyVpcEndpointServiceConfigurationInput) *ec2.ModifyVpcEndpointServiceConfigurationOutput); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ec2.ModifyVpcEndpointServiceConfigurationOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *ec2.ModifyVpcEndpointServiceConfigurationInput) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ModifyVpcEndpointServicePermissionsWithContext provides a mock function with given fields: _a0, _a1
+func (_m *CloudAPI) ModifyVpcEndpointServicePermissionsWithContext(_a0 context.Context, _a1 *ec2.ModifyVpcEndpointServicePermissionsInput) (*ec2.ModifyVpcEndpointServicePermissionsOutput, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *ec2.ModifyVpcEndpointServicePermissionsOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *ec2.ModifyVpcEndpointServicePermissionsInput) *ec2.ModifyVpcEndpointServicePermissionsOutput); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ec2.ModifyVpcEndpointServicePermissionsOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *ec2.ModifyVpcEndpointServicePermissionsInput) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReceiveSQSMessagesWithContext provides a mock function with given fields: _a0, _a1
+func (_m *CloudAPI) ReceiveSQSMessagesWithContext(_a0 context.Context, _a1 *sqs.ReceiveMessageInput) (*sqs.ReceiveMessageOutput, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *sqs.ReceiveMessageOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *sqs.ReceiveMessageInput) *sqs.ReceiveMessageOutput); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*sqs.ReceiveMessageOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *sqs.ReceiveMessageInput) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RegisterTargetsWithContext provides a mock function with given fields: _a0, _a1
 func (_m *CloudAPI) RegisterTargetsWithContext(_a0 context.Context, _a1 *elbv2.RegisterTargetsInput) (*elbv2.RegisterTargetsOutput, error) {
 	ret := _m.Called(_a0, _a1)
@@ -1186,6 +1835,52 @@ func (_m *CloudAPI) RegisterTargetsWithContext(_a0 context.Context, _a1 *elbv2.R
 	return r0, r1
 }
 
+// RejectVpcEndpointConnectionsWithContext provides a mock function with given fields: _a0, _a1
+func (_m *CloudAPI) RejectVpcEndpointConnectionsWithContext(_a0 context.Context, _a1 *ec2.RejectVpcEndpointConnectionsInput) (*ec2.RejectVpcEndpointConnectionsOutput, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *ec2.RejectVpcEndpointConnectionsOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *ec2.RejectVpcEndpointConnectionsInput) *ec2.RejectVpcEndpointConnectionsOutput); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ec2.RejectVpcEndpointConnectionsOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *ec2.RejectVpcEndpointConnectionsInput) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReleaseAddressWithContext provides a mock function with given fields: _a0, _a1
+func (_m *CloudAPI) ReleaseAddressWithContext(_a0 context.Context, _a1 *ec2.ReleaseAddressInput) (*ec2.ReleaseAddressOutput, error) {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 *ec2.ReleaseAddressOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *ec2.ReleaseAddressInput) *ec2.ReleaseAddressOutput); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ec2.ReleaseAddressOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *ec2.ReleaseAddressInput) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RemoveELBV2TagsWithContext provides a mock function with given fields: _a0, _a1
 func (_m *CloudAPI) RemoveELBV2TagsWithContext(_a0 context.Context, _a1 *elbv2.RemoveTagsInput) (*elbv2.RemoveTagsOutput, error) {
 	ret := _m.Called(_a0, _a1)
@@ -1209,6 +1904,20 @@ func (_m *CloudAPI) RemoveELBV2TagsWithContext(_a0 context.Context, _a1 *elbv2.R
 	return r0, r1
 }
 
+// RemoveLambdaInvokePermission provides a mock function with given fields: ctx, functionArn, statementID
+func (_m *CloudAPI) RemoveLambdaInvokePermission(ctx context.Context, functionArn string, statementID string) error {
+	ret := _m.Called(ctx, functionArn, statementID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, functionArn, statementID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // RemoveListenerCertificates provides a mock function with given fields: _a0, _a1
 func (_m *CloudAPI) RemoveListenerCertificates(_a0 context.Context, _a1 *elbv2.RemoveListenerCertificatesInput) (*elbv2.RemoveListenerCertificatesOutput, error) {
 	ret := _m.Called(_a0, _a1)
@@ -1448,6 +2157,20 @@ func (_m *CloudAPI) WAFRegionalAvailable() bool {
 	return r0
 }
 
+// WAFv2Available provides a mock function with given fields:
+func (_m *CloudAPI) WAFv2Available() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // WebACLExists provides a mock function with given fields: ctx, webACLId
 func (_m *CloudAPI) WebACLExists(ctx context.Context, webACLId *string) (bool, error) {
 	ret := _m.Called(ctx, webACLId)
@@ -0,0 +1,47 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// IngressClassParamsSpec defines the settings of ALBs for ingresses of a class, which take precedence over the annotations of ingresses.
+type IngressClassParamsSpec struct {
+	// Scheme is the scheme of ALBs, either internal or internet-facing.
+	// +optional
+	Scheme string `json:"scheme,omitempty"`
+
+	// Subnets are the IDs or names of subnets of ALBs.
+	// +optional
+	Subnets []string `json:"subnets,omitempty"`
+
+	// SecurityGroups are the IDs or names of securityGroups attached to ALBs.
+	// +optional
+	SecurityGroups []string `json:"securityGroups,omitempty"`
+
+	// InboundCIDRs are the CIDRs allowed to access ALBs.
+	// +optional
+	InboundCIDRs []string `json:"inboundCIDRs,omitempty"`
+
+	// SSLPolicy is the security policy of HTTPS listeners.
+	// +optional
+	SSLPolicy string `json:"sslPolicy,omitempty"`
+
+	// Tags are the tags of ALBs and their targetGroups.
+	// +optional
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// IngressClassParams locks settings of ALBs for ingresses of the class it's named after.
+type IngressClassParams struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec IngressClassParamsSpec `json:"spec,omitempty"`
+}
+
+// IngressClassParamsList contains a list of IngressClassParams
+type IngressClassParamsList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []IngressClassParams `json:"items"`
+}
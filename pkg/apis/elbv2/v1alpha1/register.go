@@ -0,0 +1,29 @@
+// Package v1alpha1 contains the v1alpha1 API types of the elbv2.k8s.aws group.
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// SchemeGroupVersion is the group version of the API types in this package.
+var SchemeGroupVersion = schema.GroupVersion{Group: "elbv2.k8s.aws", Version: "v1alpha1"}
+
+var (
+	// SchemeBuilder registers the API types in this package with a scheme.
+	SchemeBuilder = runtime.NewSchemeBuilder(addKnownTypes)
+	// AddToScheme adds the API types in this package to a scheme.
+	AddToScheme = SchemeBuilder.AddToScheme
+)
+
+func addKnownTypes(scheme *runtime.Scheme) error {
+	scheme.AddKnownTypes(SchemeGroupVersion,
+		&IngressClassParams{},
+		&IngressClassParamsList{},
+		&TargetGroupBinding{},
+		&TargetGroupBindingList{},
+	)
+	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
+	return nil
+}
@@ -0,0 +1,60 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// TargetType is the type of targets registered to the bound targetGroup.
+type TargetType string
+
+const (
+	TargetTypeInstance TargetType = "instance"
+	TargetTypeIP       TargetType = "ip"
+)
+
+// ServiceReference references a port of a service in the namespace of the TargetGroupBinding.
+type ServiceReference struct {
+	// Name is the name of the service.
+	Name string `json:"name"`
+
+	// Port is the port of the service, by number or name.
+	Port intstr.IntOrString `json:"port"`
+}
+
+// TargetGroupBindingSpec defines the desired state of TargetGroupBinding
+type TargetGroupBindingSpec struct {
+	// TargetGroupARN is the ARN of an existing targetGroup, which isn't managed by the controller otherwise.
+	TargetGroupARN string `json:"targetGroupARN"`
+
+	// TargetType is the type of targets registered to the targetGroup, it must match the targetType of the targetGroup.
+	// Defaults to instance.
+	// +optional
+	TargetType TargetType `json:"targetType,omitempty"`
+
+	// ServiceRef is the service whose endpoints are registered as targets.
+	ServiceRef ServiceReference `json:"serviceRef"`
+}
+
+// TargetGroupBindingStatus defines the observed state of TargetGroupBinding
+type TargetGroupBindingStatus struct {
+	// ObservedGeneration is the generation of the TargetGroupBinding whose targets were last registered.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// TargetGroupBinding binds a service to an existing targetGroup, whose targets are kept in sync with the endpoints of the service.
+type TargetGroupBinding struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TargetGroupBindingSpec   `json:"spec,omitempty"`
+	Status TargetGroupBindingStatus `json:"status,omitempty"`
+}
+
+// TargetGroupBindingList contains a list of TargetGroupBinding
+type TargetGroupBindingList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TargetGroupBinding `json:"items"`
+}
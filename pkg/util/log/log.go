@@ -3,55 +3,96 @@ package log
 
 import (
 	"fmt"
+	"os"
 	"reflect"
 	"strings"
 
 	"github.com/aws/aws-sdk-go/aws/awsutil"
 	"github.com/golang/glog"
+	"go.uber.org/zap/zapcore"
 )
 
 type Logger struct {
 	name string
+	// subsystem selects the level of structured logs, it defaults to name.
+	subsystem string
+	// fields are the key/value pairs added to structured logs, e.g. the ingress or ARN of the resource being reconciled.
+	fields []interface{}
 }
 
 // New creates a new Logger.
 // The name appears in the log lines.
 func New(name string) *Logger {
-	return &Logger{name: name}
+	return &Logger{name: name, subsystem: name}
+}
+
+// WithSubsystem returns a copy of the Logger, whose structured logs are leveled by subsystem instead of its name.
+func (l *Logger) WithSubsystem(subsystem string) *Logger {
+	return &Logger{name: l.name, subsystem: subsystem, fields: l.fields}
+}
+
+// WithValues returns a copy of the Logger, whose structured logs carry additional key/value pairs.
+// Plain text logs don't include them, since the name already identifies what's logged.
+func (l *Logger) WithValues(keysAndValues ...interface{}) *Logger {
+	fields := make([]interface{}, 0, len(l.fields)+len(keysAndValues))
+	fields = append(fields, l.fields...)
+	fields = append(fields, keysAndValues...)
+	return &Logger{name: l.name, subsystem: l.subsystem, fields: fields}
 }
 
 // Debugf will print debug messages if debug logging is enabled
 func (l *Logger) Debugf(format string, args ...interface{}) {
+	if l.writeStructured(zapcore.DebugLevel, format, args) {
+		return
+	}
 	debugf(format, l.name, 2, args...)
 }
 
 // DebugLevelf will print debug messages if debug logging is enabled
 func (l *Logger) DebugLevelf(level int, format string, args ...interface{}) {
+	if l.writeStructured(zapcore.DebugLevel, format, args) {
+		return
+	}
 	debugf(format, l.name, level, args...)
 }
 
 // Infof will print info level messages
 func (l *Logger) Infof(format string, args ...interface{}) {
+	if l.writeStructured(zapcore.InfoLevel, format, args) {
+		return
+	}
 	infof(format, l.name, args...)
 }
 
 // Warnf will print warning level messages
 func (l *Logger) Warnf(format string, args ...interface{}) {
+	if l.writeStructured(zapcore.WarnLevel, format, args) {
+		return
+	}
 	warnf(format, l.name, args...)
 }
 
 // Errorf will print error level messages
 func (l *Logger) Errorf(format string, args ...interface{}) {
+	if l.writeStructured(zapcore.ErrorLevel, format, args) {
+		return
+	}
 	errorf(format, l.name, args...)
 }
 
 // Fatalf will print error level messages
 func (l *Logger) Fatalf(format string, args ...interface{}) {
+	if l.writeStructured(zapcore.FatalLevel, format, args) {
+		return
+	}
 	fatalf(format, l.name, args...)
 }
 
 // Exitf will print error level messages and exit
 func (l *Logger) Exitf(format string, args ...interface{}) {
+	if l.writeStructured(zapcore.ErrorLevel, format, args) {
+		os.Exit(1)
+	}
 	exitf(format, l.name, args...)
 }
 
@@ -59,7 +100,7 @@ func (l *Logger) Exitf(format string, args ...interface{}) {
 func debugf(format, ingressName string, level int, args ...interface{}) {
 	if glog.V(2) {
 		prefix := fmt.Sprintf("%s: ", ingressName)
-		for _, line := range strings.Split(fmt.Sprintf(format, args...), "\n") {
+		for _, line := range strings.Split(fmt.Sprintf(format, RedactArgs(args)...), "\n") {
 			glog.InfoDepth(level, prefix, line)
 		}
 	}
@@ -68,7 +109,7 @@ func debugf(format, ingressName string, level int, args ...interface{}) {
 // infof will print info level messages
 func infof(format, ingressName string, args ...interface{}) {
 	prefix := fmt.Sprintf("%s: ", ingressName)
-	for _, line := range strings.Split(fmt.Sprintf(format, args...), "\n") {
+	for _, line := range strings.Split(fmt.Sprintf(format, RedactArgs(args)...), "\n") {
 		glog.InfoDepth(2, prefix, line)
 	}
 }
@@ -76,7 +117,7 @@ func infof(format, ingressName string, args ...interface{}) {
 // warnf will print warning level messages
 func warnf(format, ingressName string, args ...interface{}) {
 	prefix := fmt.Sprintf("%s: ", ingressName)
-	for _, line := range strings.Split(fmt.Sprintf(format, args...), "\n") {
+	for _, line := range strings.Split(fmt.Sprintf(format, RedactArgs(args)...), "\n") {
 		glog.WarningDepth(2, prefix, line)
 	}
 }
@@ -84,7 +125,7 @@ func warnf(format, ingressName string, args ...interface{}) {
 // errorf will print error level messages
 func errorf(format, ingressName string, args ...interface{}) {
 	prefix := fmt.Sprintf("%s: ", ingressName)
-	for _, line := range strings.Split(fmt.Sprintf(format, args...), "\n") {
+	for _, line := range strings.Split(fmt.Sprintf(format, RedactArgs(args)...), "\n") {
 		glog.ErrorDepth(2, prefix, line)
 	}
 }
@@ -92,18 +133,32 @@ func errorf(format, ingressName string, args ...interface{}) {
 // fatalf will print error level messages
 func fatalf(format, ingressName string, args ...interface{}) {
 	prefix := fmt.Sprintf("%s: ", ingressName)
-	glog.FatalDepth(2, fmt.Sprintf(prefix+format, args...))
+	glog.FatalDepth(2, fmt.Sprintf(prefix+format, RedactArgs(args)...))
 }
 
 // Exitf will print error level messages and exit
 func exitf(format, ingressName string, args ...interface{}) {
 	prefix := fmt.Sprintf("%s: ", ingressName)
-	glog.ExitDepth(2, fmt.Sprintf(prefix+format, args...))
+	glog.ExitDepth(2, fmt.Sprintf(prefix+format, RedactArgs(args)...))
 }
 
 // Prettify uses awsutil.Prettify to print structs, but also removes '\n' for better logging.
+// Values of sensitive fields are redacted, see Redact.
 func Prettify(i interface{}) string {
-	return strings.Replace(awsutil.Prettify(i), "\n", "", -1)
+	return strings.Replace(awsutil.Prettify(Redact(i)), "\n", "", -1)
+}
+
+// Pretty returns i formatted like Prettify once it's logged, so that debug lines which aren't logged don't format and redact i.
+func Pretty(i interface{}) fmt.Stringer {
+	return pretty{i}
+}
+
+type pretty struct {
+	i interface{}
+}
+
+func (p pretty) String() string {
+	return Prettify(p.i)
 }
 
 type stringInt interface {
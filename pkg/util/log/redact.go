@@ -0,0 +1,204 @@
+package log
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/service/acm"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// RedactedValue replaces the value of sensitive fields in logs and events.
+const RedactedValue = "<redacted>"
+
+// sensitiveNames are the lower cased names of struct fields and map keys whose values must never be logged,
+// e.g. the OIDC client secret in authenticate actions and the private key in TLS secrets.
+var sensitiveNames = map[string]bool{
+	"clientsecret":    true,
+	"privatekey":      true,
+	"passphrase":      true,
+	"password":        true,
+	"sessionkey":      true,
+	"sessiontoken":    true,
+	"secretaccesskey": true,
+	"tls.key":         true,
+}
+
+// sensitiveTypes are the types known to hold sensitive fields or map entries, values of other types are logged as is.
+var sensitiveTypes = map[reflect.Type]bool{
+	reflect.TypeOf(elbv2.AuthenticateOidcActionConfig{}): true,
+	reflect.TypeOf(acm.ImportCertificateInput{}):         true,
+	reflect.TypeOf(corev1.Secret{}):                      true,
+	reflect.TypeOf(map[string][]byte{}):                  true,
+}
+
+var (
+	sensitiveTypesMutex sync.RWMutex
+	// sensitiveTypeCache caches whether a type holds values of sensitiveTypes, since types are checked for every logged argument.
+	sensitiveTypeCache sync.Map
+)
+
+// RegisterSensitiveType registers the type of v as holding sensitive fields or map entries, so that values of it are redacted by Redact.
+// It's meant to be called from init functions.
+func RegisterSensitiveType(v interface{}) {
+	sensitiveTypesMutex.Lock()
+	defer sensitiveTypesMutex.Unlock()
+	sensitiveTypes[reflect.TypeOf(v)] = true
+	sensitiveTypeCache.Range(func(key, _ interface{}) bool {
+		sensitiveTypeCache.Delete(key)
+		return true
+	})
+}
+
+// Redact returns a copy of i, with the values of sensitive fields and map entries replaced by RedactedValue.
+// Only values of types that hold one of the sensitive types are copied, i itself is never modified.
+func Redact(i interface{}) interface{} {
+	if i == nil || !isSensitiveType(reflect.TypeOf(i)) {
+		return i
+	}
+	return redact(reflect.ValueOf(i)).Interface()
+}
+
+// RedactArgs returns a copy of args for formatting, with each arg redacted by Redact.
+func RedactArgs(args []interface{}) []interface{} {
+	redacted := make([]interface{}, len(args))
+	for idx, arg := range args {
+		redacted[idx] = Redact(arg)
+	}
+	return redacted
+}
+
+func isSensitive(name string) bool {
+	return sensitiveNames[strings.ToLower(name)]
+}
+
+// isSensitiveType returns whether values of t may hold values of sensitiveTypes.
+func isSensitiveType(t reflect.Type) bool {
+	if cached, ok := sensitiveTypeCache.Load(t); ok {
+		return cached.(bool)
+	}
+	sensitiveTypesMutex.RLock()
+	sensitive := holdsSensitiveType(t, make(map[reflect.Type]bool))
+	sensitiveTypesMutex.RUnlock()
+	sensitiveTypeCache.Store(t, sensitive)
+	return sensitive
+}
+
+// holdsSensitiveType walks the fields and elements of t, visited guards against recursive types.
+// Interfaces may hold anything, so they're deemed sensitive.
+func holdsSensitiveType(t reflect.Type, visited map[reflect.Type]bool) bool {
+	if sensitiveTypes[t] {
+		return true
+	}
+	if visited[t] {
+		return false
+	}
+	visited[t] = true
+	switch t.Kind() {
+	case reflect.Interface:
+		return true
+	case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+		return holdsSensitiveType(t.Elem(), visited)
+	case reflect.Struct:
+		for idx := 0; idx < t.NumField(); idx++ {
+			field := t.Field(idx)
+			if field.PkgPath != "" {
+				continue
+			}
+			if holdsSensitiveType(field.Type, visited) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func redact(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.New(v.Type().Elem())
+		out.Elem().Set(redact(v.Elem()))
+		return out
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.New(v.Type()).Elem()
+		out.Set(redact(v.Elem()))
+		return out
+	case reflect.Struct:
+		out := reflect.New(v.Type()).Elem()
+		out.Set(v)
+		for idx := 0; idx < v.NumField(); idx++ {
+			field := v.Type().Field(idx)
+			if field.PkgPath != "" {
+				continue
+			}
+			if isSensitive(field.Name) {
+				out.Field(idx).Set(redactedValue(v.Field(idx)))
+			} else {
+				out.Field(idx).Set(redact(v.Field(idx)))
+			}
+		}
+		return out
+	case reflect.Slice:
+		if v.IsNil() || v.Type().Elem().Kind() == reflect.Uint8 {
+			return v
+		}
+		out := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for idx := 0; idx < v.Len(); idx++ {
+			out.Index(idx).Set(redact(v.Index(idx)))
+		}
+		return out
+	case reflect.Array:
+		out := reflect.New(v.Type()).Elem()
+		for idx := 0; idx < v.Len(); idx++ {
+			out.Index(idx).Set(redact(v.Index(idx)))
+		}
+		return out
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.MakeMapWithSize(v.Type(), v.Len())
+		for _, key := range v.MapKeys() {
+			if key.Kind() == reflect.String && isSensitive(key.String()) {
+				out.SetMapIndex(key, redactedValue(v.MapIndex(key)))
+			} else {
+				out.SetMapIndex(key, redact(v.MapIndex(key)))
+			}
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// redactedValue returns RedactedValue in the type of v if possible, or the zero value of v's type otherwise.
+func redactedValue(v reflect.Value) reflect.Value {
+	redacted := reflect.ValueOf(RedactedValue)
+	switch {
+	case v.Kind() == reflect.String:
+		return redacted.Convert(v.Type())
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8:
+		return reflect.ValueOf([]byte(RedactedValue)).Convert(v.Type())
+	case v.Kind() == reflect.Ptr && v.Type().Elem().Kind() == reflect.String:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.New(v.Type().Elem())
+		out.Elem().Set(redacted.Convert(v.Type().Elem()))
+		return out
+	case v.Kind() == reflect.Interface && !v.IsNil():
+		out := reflect.New(v.Type()).Elem()
+		out.Set(redactedValue(v.Elem()))
+		return out
+	default:
+		return reflect.Zero(v.Type())
+	}
+}
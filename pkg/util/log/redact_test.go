@@ -0,0 +1,122 @@
+package log
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/stretchr/testify/assert"
+)
+
+type authConfig struct {
+	ClientID     string
+	ClientSecret string
+	Extra        map[string]string
+	secret       string
+}
+
+type unknownConfig struct {
+	ClientSecret string
+}
+
+func TestRedact(t *testing.T) {
+	RegisterSensitiveType(authConfig{})
+	for _, tc := range []struct {
+		name     string
+		input    interface{}
+		expected interface{}
+	}{
+		{
+			name:     "nil",
+			input:    nil,
+			expected: nil,
+		},
+		{
+			name:     "plain values are kept",
+			input:    "clientSecret",
+			expected: "clientSecret",
+		},
+		{
+			name:     "unknown types are kept",
+			input:    &unknownConfig{ClientSecret: "secret"},
+			expected: &unknownConfig{ClientSecret: "secret"},
+		},
+		{
+			name:     "maps of unknown types are kept",
+			input:    map[string]string{"password": "pass"},
+			expected: map[string]string{"password": "pass"},
+		},
+		{
+			name: "struct fields",
+			input: authConfig{
+				ClientID:     "id",
+				ClientSecret: "secret",
+				Extra:        map[string]string{"prompt": "login", "password": "pass"},
+				secret:       "unexported",
+			},
+			expected: authConfig{
+				ClientID:     "id",
+				ClientSecret: RedactedValue,
+				Extra:        map[string]string{"prompt": "login", "password": RedactedValue},
+				secret:       "unexported",
+			},
+		},
+		{
+			name: "aws payloads",
+			input: &elbv2.CreateRuleInput{
+				Priority: aws.Int64(1),
+				Actions: []*elbv2.Action{
+					{
+						Type: aws.String(elbv2.ActionTypeEnumAuthenticateOidc),
+						AuthenticateOidcConfig: &elbv2.AuthenticateOidcActionConfig{
+							ClientId:     aws.String("id"),
+							ClientSecret: aws.String("secret"),
+						},
+					},
+				},
+			},
+			expected: &elbv2.CreateRuleInput{
+				Priority: aws.Int64(1),
+				Actions: []*elbv2.Action{
+					{
+						Type: aws.String(elbv2.ActionTypeEnumAuthenticateOidc),
+						AuthenticateOidcConfig: &elbv2.AuthenticateOidcActionConfig{
+							ClientId:     aws.String("id"),
+							ClientSecret: aws.String(RedactedValue),
+						},
+					},
+				},
+			},
+		},
+		{
+			name:     "secret data",
+			input:    map[string][]byte{"tls.crt": []byte("crt"), "tls.key": []byte("key")},
+			expected: map[string][]byte{"tls.crt": []byte("crt"), "tls.key": []byte(RedactedValue)},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, Redact(tc.input))
+		})
+	}
+}
+
+func TestRedact_inputNotModified(t *testing.T) {
+	input := &elbv2.AuthenticateOidcActionConfig{ClientSecret: aws.String("secret")}
+	Redact(input)
+	assert.Equal(t, "secret", aws.StringValue(input.ClientSecret))
+}
+
+func TestRedact_notCopied(t *testing.T) {
+	input := &unknownConfig{ClientSecret: "secret"}
+	assert.True(t, input == Redact(input))
+}
+
+func TestPrettify(t *testing.T) {
+	output := Prettify(&elbv2.AuthenticateOidcActionConfig{
+		ClientId:     aws.String("id"),
+		ClientSecret: aws.String("secret"),
+	})
+	assert.Contains(t, output, RedactedValue)
+	assert.NotContains(t, output, "\"secret\"")
+	assert.NotContains(t, output, "\n")
+}
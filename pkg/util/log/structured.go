@@ -0,0 +1,137 @@
+package log
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/zapr"
+	"github.com/spf13/pflag"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// FormatText logs plain text lines through glog.
+	FormatText = "text"
+	// FormatJSON logs a JSON object per line, with the message, level, subsystem and fields of the Logger.
+	FormatJSON = "json"
+)
+
+// Options configures the output of all Loggers.
+type Options struct {
+	// Format is either FormatText or FormatJSON.
+	Format string
+	// Level is the minimum level of structured logs, one of debug, info, warn and error.
+	Level string
+	// SubsystemLevels overrides Level for the Loggers of a subsystem.
+	SubsystemLevels map[string]string
+}
+
+// BindFlags binds the logging flags to fs.
+func (o *Options) BindFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.Format, "log-format", FormatText,
+		`Format of logs, either text or json. Plain text logs are leveled by the glog flags, e.g. -v.`)
+	fs.StringVar(&o.Level, "log-level", "info",
+		`Minimum level of json logs, one of debug, info, warn and error.`)
+	fs.StringToStringVar(&o.SubsystemLevels, "log-subsystem-levels", nil,
+		`Levels of json logs by subsystem, overriding --log-level, e.g. reconcile=debug,certificate-monitor=warn.`)
+}
+
+// structuredLogger is the state of structured logging, which is disabled as long as logger is nil.
+type structuredLogger struct {
+	logger          *zap.Logger
+	level           zapcore.Level
+	subsystemLevels map[string]zapcore.Level
+}
+
+var (
+	structuredMutex sync.RWMutex
+	structured      structuredLogger
+)
+
+// Configure sets the output of all Loggers according to options.
+func Configure(options Options) error {
+	if options.Format != FormatText && options.Format != FormatJSON {
+		return fmt.Errorf("unknown log format %v", options.Format)
+	}
+	level, err := parseLevel(options.Level)
+	if err != nil {
+		return err
+	}
+	subsystemLevels := make(map[string]zapcore.Level, len(options.SubsystemLevels))
+	for subsystem, value := range options.SubsystemLevels {
+		if subsystemLevels[subsystem], err = parseLevel(value); err != nil {
+			return fmt.Errorf("invalid level of subsystem %v due to %w", subsystem, err)
+		}
+	}
+
+	next := structuredLogger{level: level, subsystemLevels: subsystemLevels}
+	if options.Format == FormatJSON {
+		encoderConfig := zap.NewProductionEncoderConfig()
+		encoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+		// levels are filtered by subsystem before entries reach the core.
+		core := zapcore.NewCore(zapcore.NewJSONEncoder(encoderConfig), zapcore.Lock(os.Stderr), zapcore.DebugLevel)
+		next.logger = zap.New(core, zap.AddCaller(), zap.AddCallerSkip(2))
+	}
+	structuredMutex.Lock()
+	structured = next
+	structuredMutex.Unlock()
+	return nil
+}
+
+// LogR returns a logr.Logger writing to the structured output, for libraries like controller-runtime.
+// It returns nil unless structured logging is configured.
+func LogR() logr.Logger {
+	structuredMutex.RLock()
+	defer structuredMutex.RUnlock()
+	if structured.logger == nil {
+		return nil
+	}
+	// logr calls zap directly, unlike Loggers.
+	return zapr.NewLogger(structured.logger.WithOptions(zap.AddCallerSkip(-1)))
+}
+
+// writeStructured writes a structured log entry, it returns false if structured logging is disabled.
+func (l *Logger) writeStructured(level zapcore.Level, format string, args []interface{}) bool {
+	structuredMutex.RLock()
+	s := structured
+	structuredMutex.RUnlock()
+	if s.logger == nil {
+		return false
+	}
+
+	minLevel, ok := s.subsystemLevels[l.subsystem]
+	if !ok {
+		minLevel = s.level
+	}
+	if level < minLevel {
+		return true
+	}
+	// args are only formatted and redacted once the line is known to be written.
+	if !s.logger.Core().Enabled(level) {
+		return true
+	}
+	if entry := s.logger.Check(level, fmt.Sprintf(format, RedactArgs(args)...)); entry != nil {
+		entry.Write(l.zapFields()...)
+	}
+	return true
+}
+
+func (l *Logger) zapFields() []zap.Field {
+	fields := make([]zap.Field, 0, len(l.fields)/2+1)
+	fields = append(fields, zap.String("subsystem", l.subsystem))
+	for i := 0; i+1 < len(l.fields); i += 2 {
+		fields = append(fields, zap.Any(fmt.Sprint(l.fields[i]), Redact(l.fields[i+1])))
+	}
+	return fields
+}
+
+func parseLevel(value string) (zapcore.Level, error) {
+	var level zapcore.Level
+	if err := level.UnmarshalText([]byte(value)); err != nil {
+		return level, err
+	}
+	return level, nil
+}
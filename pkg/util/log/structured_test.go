@@ -0,0 +1,55 @@
+package log
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigure(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		options     Options
+		expectedErr string
+	}{
+		{
+			name:    "text",
+			options: Options{Format: FormatText, Level: "info"},
+		},
+		{
+			name:    "json with subsystem levels",
+			options: Options{Format: FormatJSON, Level: "warn", SubsystemLevels: map[string]string{"reconcile": "debug"}},
+		},
+		{
+			name:        "unknown format",
+			options:     Options{Format: "xml", Level: "info"},
+			expectedErr: "unknown log format xml",
+		},
+		{
+			name:        "invalid subsystem level",
+			options:     Options{Format: FormatJSON, Level: "info", SubsystemLevels: map[string]string{"reconcile": "verbose"}},
+			expectedErr: `invalid level of subsystem reconcile due to unrecognized level: "verbose"`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			defer func() { _ = Configure(Options{Format: FormatText, Level: "info"}) }()
+			err := Configure(tc.options)
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.options.Format == FormatJSON, LogR() != nil)
+			}
+		})
+	}
+}
+
+func TestLogger_WithValues(t *testing.T) {
+	parent := New("ns/ingress").WithSubsystem("reconcile").WithValues("ingress", "ns/ingress")
+	child := parent.WithValues("loadBalancerArn", "arn")
+
+	assert.Equal(t, []interface{}{"ingress", "ns/ingress"}, parent.fields)
+	assert.Equal(t, []interface{}{"ingress", "ns/ingress", "loadBalancerArn", "arn"}, child.fields)
+	assert.Equal(t, "reconcile", child.subsystem)
+	assert.Equal(t, "ns/ingress", child.name)
+}
@@ -0,0 +1,63 @@
+package types
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/kubernetes-sigs/aws-alb-ingress-controller/pkg/util/log"
+)
+
+// maxDiffValueLength truncates values in a Diff, since event messages are limited in size.
+const maxDiffValueLength = 120
+
+// Diff is a field-level summary of how the current state of an AWS resource differs from its desired state,
+// e.g. `SslPolicy: ELBSecurityPolicy-2016-08 -> ELBSecurityPolicy-TLS-1-2-2017-01`.
+type Diff []string
+
+// Compare records field of the resource unless current and desired are DeepEqual, and returns whether they differ.
+func (d *Diff) Compare(field string, current interface{}, desired interface{}) bool {
+	if DeepEqual(current, desired) {
+		return false
+	}
+	d.Add(field, current, desired)
+	return true
+}
+
+// Add records that field of the resource changes from current to desired.
+func (d *Diff) Add(field string, current interface{}, desired interface{}) {
+	*d = append(*d, fmt.Sprintf("%s: %s -> %s", field, formatDiffValue(current), formatDiffValue(desired)))
+}
+
+// Empty returns whether the resource doesn't need modification.
+func (d Diff) Empty() bool {
+	return len(d) == 0
+}
+
+func (d Diff) String() string {
+	return strings.Join(d, ", ")
+}
+
+// formatDiffValue formats scalars like *string by their value, other values like actions are formatted as in logs.
+func formatDiffValue(value interface{}) string {
+	v := reflect.ValueOf(value)
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return "<nil>"
+		}
+		v = v.Elem()
+	}
+	var s string
+	switch v.Kind() {
+	case reflect.String, reflect.Bool, reflect.Int, reflect.Int64, reflect.Float64:
+		s = fmt.Sprint(v.Interface())
+	case reflect.Invalid:
+		return "<nil>"
+	default:
+		s = log.Prettify(value)
+	}
+	if len(s) > maxDiffValueLength {
+		s = s[:maxDiffValueLength] + "..."
+	}
+	return s
+}
@@ -0,0 +1,24 @@
+package types
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	var diff Diff
+	assert.False(t, diff.Compare("Port", aws.Int64(443), aws.Int64(443)))
+	assert.True(t, diff.Empty())
+
+	assert.True(t, diff.Compare("SslPolicy", aws.String("ELBSecurityPolicy-2016-08"), aws.String("ELBSecurityPolicy-TLS-1-2-2017-01")))
+	diff.Add("HealthCheckPath", nil, "/healthz")
+	assert.Equal(t, "SslPolicy: ELBSecurityPolicy-2016-08 -> ELBSecurityPolicy-TLS-1-2-2017-01, HealthCheckPath: <nil> -> /healthz", diff.String())
+}
+
+func Test_formatDiffValue(t *testing.T) {
+	assert.Equal(t, "[\"a\",\"b\"]", formatDiffValue([]string{"a", "b"}))
+	assert.Equal(t, strings.Repeat("x", maxDiffValueLength)+"...", formatDiffValue(strings.Repeat("x", maxDiffValueLength+1)))
+}
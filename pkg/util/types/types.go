@@ -18,7 +18,7 @@ func init() {
 func DeepEqual(x, y interface{}) bool {
 	b := awsutil.DeepEqual(x, y)
 	if !b {
-		logger.DebugLevelf(3, "DeepEqual(%v, %v) found inequality", log.Prettify(x), log.Prettify(y))
+		logger.DebugLevelf(3, "DeepEqual(%v, %v) found inequality", log.Pretty(x), log.Pretty(y))
 	}
 	return b
 }